@@ -0,0 +1,97 @@
+package recall
+
+import (
+	"fmt"
+	"sort"
+)
+
+const (
+	// DefaultFeedbackAffinity is the ranking weight of local feedback
+	// affinity when Config.FeedbackAffinity is zero.
+	DefaultFeedbackAffinity = 0.1
+
+	// feedbackAffinityCap is the net helpful feedback at which an entry's
+	// affinity reaches its full weight.
+	feedbackAffinityCap = 3
+)
+
+// netHelpful returns, for each entry this store has given more helpful
+// than incorrect or outdated feedback (see SourceReport), that surplus.
+func (s *Store) netHelpful() (map[string]int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil, ErrStoreClosed
+	}
+
+	rows, err := s.db.Query(`
+		SELECT lore_id, SUM(outcome = ?) - SUM(outcome IN (?, ?)) AS net
+		FROM feedback_log
+		WHERE namespace = ?
+		GROUP BY lore_id
+		HAVING net > 0
+	`, string(FeedbackHelpful), string(FeedbackIncorrect), string(FeedbackOutdated), s.namespace)
+	if err != nil {
+		return nil, fmt.Errorf("store: feedback affinity: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	net := make(map[string]int)
+	for rows.Next() {
+		var id string
+		var n int
+		if err := rows.Scan(&id, &n); err != nil {
+			return nil, fmt.Errorf("store: scan feedback affinity: %w", err)
+		}
+		net[id] = n
+	}
+	return net, rows.Err()
+}
+
+// feedbackAffinity returns the ranking multiplier of each entry this
+// client's store has marked helpful: 1 + weight × min(net helpful, cap) /
+// cap. It returns nil when affinity is disabled or nothing qualifies;
+// a failed read is logged and also yields nil, leaving ranking unchanged.
+func (c *Client) feedbackAffinity() map[string]float64 {
+	weight := c.config.FeedbackAffinity
+	if weight == 0 {
+		weight = DefaultFeedbackAffinity
+	}
+	if weight < 0 {
+		return nil
+	}
+	net, err := c.store.netHelpful()
+	if err != nil {
+		c.debug.LogError("feedback affinity", err)
+		return nil
+	}
+	if len(net) == 0 {
+		return nil
+	}
+	affinity := make(map[string]float64, len(net))
+	for id, n := range net {
+		affinity[id] = 1 + weight*float64(min(n, feedbackAffinityCap))/feedbackAffinityCap
+	}
+	return affinity
+}
+
+// affinityWeight returns the multiplier of id, 1 if it has none.
+func affinityWeight(affinity map[string]float64, id string) float64 {
+	if w, ok := affinity[id]; ok {
+		return w
+	}
+	return 1.0
+}
+
+// weightByAffinity multiplies similarity scores by feedback affinity and
+// re-sorts.
+func weightByAffinity(scored []ScoredLore, affinity map[string]float64) {
+	if affinity == nil {
+		return
+	}
+	for i := range scored {
+		scored[i].Score *= affinityWeight(affinity, scored[i].ID)
+	}
+	sort.SliceStable(scored, func(i, j int) bool { return scored[i].Score > scored[j].Score })
+}
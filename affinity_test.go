@@ -0,0 +1,55 @@
+package recall
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestClient_Query_FeedbackAffinity(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		weight   float64
+		wantTop  string
+		affinity float64
+	}{
+		{"default weight", 0, "liked", 1 + DefaultFeedbackAffinity*2/3},
+		{"disabled", -1, "closer", 1},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, Config{SourceID: "test", FeedbackAffinity: tt.weight})
+
+			now := time.Now().UTC()
+			for _, l := range []*Lore{
+				{ID: "closer", Embedding: PackFloat32([]float32{1, 0.02})},
+				{ID: "liked", Embedding: PackFloat32([]float32{1, 0.05})},
+			} {
+				l.Content, l.Category, l.Confidence, l.SourceID, l.CreatedAt, l.UpdatedAt = "entry "+l.ID, CategoryPatternOutcome, 0.5, "test", now, now
+				if err := client.store.InsertLore(l); err != nil {
+					t.Fatalf("InsertLore() error = %v", err)
+				}
+			}
+			// Two net helpful marks: three helpful, one incorrect
+			for _, ft := range []FeedbackType{Helpful, Helpful, Incorrect, Helpful} {
+				if _, err := client.Feedback("liked", ft); err != nil {
+					t.Fatalf("Feedback() error = %v", err)
+				}
+			}
+
+			result, err := client.Query(context.Background(), QueryParams{Query: "q", QueryEmbedding: []float32{1, 0}, K: 1, Explain: true})
+			if err != nil || len(result.Lore) != 1 {
+				t.Fatalf("Query() = %v, %v", result, err)
+			}
+			if result.Lore[0].ID != tt.wantTop {
+				t.Errorf("Lore[0] = %s, want %s", result.Lore[0].ID, tt.wantTop)
+			}
+			e := result.Explanations[tt.wantTop]
+			if got := e.Affinity; got < tt.affinity-1e-9 || got > tt.affinity+1e-9 {
+				t.Errorf("Affinity = %v, want %v", got, tt.affinity)
+			}
+			if want := *e.Similarity * e.Trust * e.CategoryWeight * e.Affinity; e.Score != want {
+				t.Errorf("Score = %v, want %v", e.Score, want)
+			}
+		})
+	}
+}
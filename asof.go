@@ -0,0 +1,230 @@
+package recall
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+)
+
+// HistoricalLore is a lore entry as it existed at a past point in time.
+//
+// Exact is true when the state was reconstructed from the change_log.
+// Entries with no recorded history before the requested time (for example,
+// lore received via bootstrap or delta sync) fall back to their current row
+// state and have Exact set to false.
+type HistoricalLore struct {
+	Lore
+	Exact bool `json:"exact"`
+}
+
+// AsOfQueryResult contains read-only results of a point-in-time query.
+// Results are not tracked in the session and cannot receive feedback.
+type AsOfQueryResult struct {
+	AsOf     time.Time        `json:"as_of"`
+	ReadOnly bool             `json:"read_only"`
+	Lore     []HistoricalLore `json:"lore"`
+}
+
+// LoreAsOf reconstructs the set of active lore entries at the given time.
+//
+// The latest change_log entry per entity at or before asOf determines its
+// state; a delete means the entity was not active. Entities without change_log
+// history up to asOf are included from their current row if they were created
+// at or before asOf and not deleted by then.
+func (s *Store) LoreAsOf(asOf time.Time) ([]HistoricalLore, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil, ErrStoreClosed
+	}
+
+	cutoff := asOf.UTC().Format(time.RFC3339)
+
+	rows, err := s.db.Query(`
+		SELECT entity_id, operation, payload
+		FROM change_log
+		WHERE table_name = 'lore_entries' AND created_at <= ?
+		ORDER BY sequence ASC
+	`, cutoff)
+	if err != nil {
+		return nil, fmt.Errorf("store: query change_log history: %w", err)
+	}
+
+	history := make(map[string]*Lore)
+	for rows.Next() {
+		var entityID, operation string
+		var payload sql.NullString
+		if err := rows.Scan(&entityID, &operation, &payload); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("store: scan change_log: %w", err)
+		}
+		if operation == "delete" || !payload.Valid {
+			history[entityID] = nil
+			continue
+		}
+		lore, err := parseLorePayload([]byte(payload.String))
+		if err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("store: decode change_log payload for %s: %w", entityID, err)
+		}
+		history[entityID] = lore
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("store: iterate change_log: %w", err)
+	}
+
+	var results []HistoricalLore
+	for _, lore := range history {
+		if lore != nil && lore.Namespace == s.namespace {
+			results = append(results, HistoricalLore{Lore: *lore, Exact: true})
+		}
+	}
+
+	current, err := s.db.Query(`
+		SELECT id, content, context, category, confidence, embedding, embedding_status, source_id, sources,
+		       validation_count, last_validated_at, created_at, updated_at, deleted_at, synced_at, namespace
+		FROM lore_entries
+		WHERE created_at <= ? AND (deleted_at IS NULL OR deleted_at > ?) AND namespace = ?
+	`, cutoff, cutoff, s.namespace)
+	if err != nil {
+		return nil, fmt.Errorf("store: query lore: %w", err)
+	}
+	defer func() { _ = current.Close() }()
+
+	for current.Next() {
+		lore, err := s.scanLoreRows(current)
+		if err != nil {
+			return nil, err
+		}
+		if _, seen := history[lore.ID]; seen {
+			continue
+		}
+		lore.DeletedAt = nil
+		results = append(results, HistoricalLore{Lore: *lore})
+	}
+	if err := current.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if !results[i].CreatedAt.Equal(results[j].CreatedAt) {
+			return results[i].CreatedAt.Before(results[j].CreatedAt)
+		}
+		return results[i].ID < results[j].ID
+	})
+	return results, nil
+}
+
+// QueryAsOf queries lore as it existed at the given time, for retrospective
+// analysis ("what did we believe before the incident?").
+//
+// Category, MinConfidence and source filters apply to the historical state. When
+// QueryEmbedding is provided, results are ranked using current embeddings,
+// since embeddings are not recorded in history. Results are read-only:
+// they are not tracked in the session and carry no session refs.
+func (c *Client) QueryAsOf(ctx context.Context, params QueryParams, asOf time.Time) (*AsOfQueryResult, error) {
+	if asOf.IsZero() {
+		return nil, &ValidationError{Field: "AsOf", Message: "timestamp is required"}
+	}
+	if err := applyWorkingContext(ctx, &params); err != nil {
+		return nil, err
+	}
+	if params.K == 0 {
+		params.K = 5
+	}
+	c.applyMinConfidenceDefault(&params)
+
+	release, err := c.store.enter(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	all, err := c.store.LoreAsOf(asOf)
+	if err != nil {
+		return nil, fmt.Errorf("client: query as of: %w", err)
+	}
+
+	categories := make(map[Category]bool, len(params.Categories))
+	for _, cat := range params.Categories {
+		categories[cat] = true
+	}
+
+	filtered := make([]HistoricalLore, 0, len(all))
+	for _, l := range all {
+		if l.Confidence < params.minConfidenceFor(l.Category) {
+			continue
+		}
+		if len(categories) > 0 && !categories[l.Category] {
+			continue
+		}
+		if !matchesSources(l.SourceID, params) {
+			continue
+		}
+		filtered = append(filtered, l)
+	}
+
+	if len(params.QueryEmbedding) > 0 {
+		filtered, err = c.rankHistorical(params, filtered)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if params.K > 0 && len(filtered) > params.K {
+		filtered = filtered[:params.K]
+	}
+
+	return &AsOfQueryResult{AsOf: asOf.UTC(), ReadOnly: true, Lore: filtered}, nil
+}
+
+// rankHistorical orders historical entries by similarity using current embeddings,
+// those of Config.EmbeddingModel when one is set. Entries without a current
+// embedding, including those deleted since, are dropped, matching live
+// similarity queries.
+func (c *Client) rankHistorical(params QueryParams, entries []HistoricalLore) ([]HistoricalLore, error) {
+	ids := make([]string, len(entries))
+	byID := make(map[string]HistoricalLore, len(entries))
+	for i, l := range entries {
+		ids[i] = l.ID
+		byID[l.ID] = l
+	}
+
+	current, err := c.store.getLoreByIDs(ids, c.config.EmbeddingModel)
+	if err != nil {
+		return nil, fmt.Errorf("client: query as of: %w", err)
+	}
+
+	candidates := make([]CandidateLore, 0, len(current))
+	for _, l := range current {
+		if len(l.Embedding) == 0 {
+			continue
+		}
+		if embedding := UnpackFloat32(l.Embedding); embedding != nil {
+			candidates = append(candidates, CandidateLore{ID: l.ID, Embedding: embedding})
+		}
+	}
+
+	scored := c.searcher.Search(params.QueryEmbedding, candidates, len(candidates))
+	ranked := make([]HistoricalLore, 0, len(scored))
+	for _, s := range scored {
+		if params.MinSimilarity != nil && s.Score < *params.MinSimilarity {
+			continue
+		}
+		ranked = append(ranked, byID[s.ID])
+	}
+	return ranked, nil
+}
+
+// matchesSources reports whether sourceID passes the SourceIDs and
+// ExcludeSourceIDs filters of params.
+func matchesSources(sourceID string, params QueryParams) bool {
+	if len(params.SourceIDs) > 0 && !slices.Contains(params.SourceIDs, sourceID) {
+		return false
+	}
+	return !slices.Contains(params.ExcludeSourceIDs, sourceID)
+}
@@ -0,0 +1,189 @@
+package recall
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// backdateChangeLog rewrites change_log created_at for an entity so tests can
+// place history at distinct points in time.
+func backdateChangeLog(t *testing.T, store *Store, sequence int64, at time.Time) {
+	t.Helper()
+	if _, err := store.db.Exec(`UPDATE change_log SET created_at = ? WHERE sequence = ?`,
+		at.UTC().Format(time.RFC3339), sequence); err != nil {
+		t.Fatalf("backdate change_log: %v", err)
+	}
+}
+
+func TestStore_LoreAsOf_ReconstructsHistory(t *testing.T) {
+	store := newTestStore(t)
+	base := time.Now().UTC().Add(-72 * time.Hour).Truncate(time.Second)
+
+	lore := &Lore{ID: "hist-1", Content: "original belief", Category: CategoryPatternOutcome,
+		Confidence: 0.7, CreatedAt: base, UpdatedAt: base}
+	if err := store.InsertLore(lore); err != nil {
+		t.Fatalf("InsertLore: %v", err)
+	}
+	backdateChangeLog(t, store, 1, base)
+
+	if _, err := store.ApplyFeedback("hist-1", ConfidenceIncorrectDelta, false); err != nil {
+		t.Fatalf("ApplyFeedback: %v", err)
+	}
+	backdateChangeLog(t, store, 2, base.Add(24*time.Hour))
+
+	if err := store.DeleteLoreByID("hist-1"); err != nil {
+		t.Fatalf("DeleteLoreByID: %v", err)
+	}
+	backdateChangeLog(t, store, 3, base.Add(48*time.Hour))
+
+	tests := []struct {
+		name       string
+		asOf       time.Time
+		wantCount  int
+		wantConfid float64
+	}{
+		{"before creation", base.Add(-time.Hour), 0, 0},
+		{"after creation", base.Add(time.Hour), 1, 0.7},
+		{"after feedback", base.Add(25 * time.Hour), 1, 0.7 - 0.15},
+		{"after delete", base.Add(49 * time.Hour), 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := store.LoreAsOf(tt.asOf)
+			if err != nil {
+				t.Fatalf("LoreAsOf: %v", err)
+			}
+			if len(got) != tt.wantCount {
+				t.Fatalf("len = %d, want %d", len(got), tt.wantCount)
+			}
+			if tt.wantCount == 1 {
+				if !got[0].Exact {
+					t.Error("Exact = false, want true for change_log history")
+				}
+				if diff := got[0].Confidence - tt.wantConfid; diff > 1e-9 || diff < -1e-9 {
+					t.Errorf("Confidence = %v, want %v", got[0].Confidence, tt.wantConfid)
+				}
+			}
+		})
+	}
+}
+
+func TestStore_LoreAsOf_FallsBackToCurrentRow(t *testing.T) {
+	store := newTestStore(t)
+	created := time.Now().UTC().Add(-48 * time.Hour)
+	if err := store.UpsertLore(&Lore{ID: "synced-1", Content: "from engram", Category: CategoryPatternOutcome,
+		Confidence: 0.9, CreatedAt: created, UpdatedAt: created}); err != nil {
+		t.Fatalf("UpsertLore: %v", err)
+	}
+
+	got, err := store.LoreAsOf(time.Now().Add(-24 * time.Hour))
+	if err != nil {
+		t.Fatalf("LoreAsOf: %v", err)
+	}
+	if len(got) != 1 || got[0].Exact {
+		t.Fatalf("got %+v, want one inexact entry", got)
+	}
+
+	got, _ = store.LoreAsOf(created.Add(-time.Hour))
+	if len(got) != 0 {
+		t.Errorf("entry should not exist before its creation, got %d", len(got))
+	}
+}
+
+func TestClient_QueryAsOf_FiltersAndIsReadOnly(t *testing.T) {
+	store := newTestStore(t)
+	client := &Client{store: store, session: NewSession(), searcher: &BruteForceSearcher{}}
+	now := time.Now().UTC()
+
+	for _, l := range []*Lore{
+		{ID: "q1", Content: "a", Category: CategoryPatternOutcome, Confidence: 0.9},
+		{ID: "q2", Content: "b", Category: CategoryTestingStrategy, Confidence: 0.9, SourceID: "agent-b"},
+		{ID: "q3", Content: "c", Category: CategoryPatternOutcome, Confidence: 0.2},
+	} {
+		l.CreatedAt, l.UpdatedAt = now, now
+		if err := store.InsertLore(l); err != nil {
+			t.Fatalf("InsertLore: %v", err)
+		}
+	}
+
+	result, err := client.QueryAsOf(context.Background(), QueryParams{
+		Categories: []Category{CategoryPatternOutcome},
+	}, now.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("QueryAsOf: %v", err)
+	}
+	if !result.ReadOnly {
+		t.Error("ReadOnly = false")
+	}
+	if len(result.Lore) != 1 || result.Lore[0].ID != "q1" {
+		t.Errorf("Lore = %+v, want only q1", result.Lore)
+	}
+	if len(client.GetSessionLore()) != 0 {
+		t.Error("historical results must not be tracked in the session")
+	}
+
+	bySource, err := client.QueryAsOf(context.Background(), QueryParams{SourceIDs: []string{"agent-b"}}, now.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("QueryAsOf: %v", err)
+	}
+	if len(bySource.Lore) != 1 || bySource.Lore[0].ID != "q2" {
+		t.Errorf("Lore(SourceIDs) = %+v, want only q2", bySource.Lore)
+	}
+
+	var ve *ValidationError
+	if _, err := client.QueryAsOf(context.Background(), QueryParams{}, time.Time{}); !errors.As(err, &ve) {
+		t.Errorf("zero timestamp: err = %v, want ValidationError", err)
+	}
+}
+
+func TestClient_QueryAsOf_RanksWithModelEmbeddings(t *testing.T) {
+	client := newTestClient(t, Config{EmbeddingModel: "new", MaintenanceWait: -1})
+	store := client.store
+
+	if err := store.SetMetadata("embedding_model", "old"); err != nil {
+		t.Fatalf("SetMetadata: %v", err)
+	}
+	now := time.Now().UTC()
+	for _, l := range []*Lore{
+		{ID: "a", Content: "alpha", Embedding: PackFloat32([]float32{1, 0})},
+		{ID: "b", Content: "beta", Embedding: PackFloat32([]float32{0, 1})},
+		{ID: "c", Content: "gamma", Embedding: PackFloat32([]float32{1, 0})},
+	} {
+		l.Category, l.Confidence, l.CreatedAt, l.UpdatedAt = CategoryPatternOutcome, 0.8, now, now
+		if err := store.UpsertLore(l); err != nil {
+			t.Fatalf("UpsertLore: %v", err)
+		}
+	}
+	// In the new model's space, b is the better match; c has no vector.
+	for id, vector := range map[string][]float32{"a": {0, 1}, "b": {1, 0}} {
+		if err := client.SetEmbedding(id, "new", vector); err != nil {
+			t.Fatalf("SetEmbedding: %v", err)
+		}
+	}
+
+	params := QueryParams{QueryEmbedding: []float32{1, 0}}
+	result, err := client.QueryAsOf(context.Background(), params, now.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("QueryAsOf: %v", err)
+	}
+	var ids []string
+	for _, l := range result.Lore {
+		ids = append(ids, l.ID)
+	}
+	if len(ids) != 2 || ids[0] != "b" || ids[1] != "a" {
+		t.Errorf("QueryAsOf ranked %v, want [b a] by the new model's vectors", ids)
+	}
+
+	// Historical queries wait out maintenance like live ones
+	err = store.Exclusive(context.Background(), func(context.Context) error {
+		if _, err := client.QueryAsOf(context.Background(), params, now.Add(time.Minute)); !errors.Is(err, ErrMaintenance) {
+			t.Errorf("QueryAsOf during maintenance = %v, want ErrMaintenance", err)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Exclusive: %v", err)
+	}
+}
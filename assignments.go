@@ -0,0 +1,253 @@
+package recall
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Assignment names who maintains a lore entry: the Owner keeps it accurate
+// and the Reviewer checks it. An empty name is unassigned. Assignments are
+// local-only.
+type Assignment struct {
+	LoreID     string    `json:"lore_id"`
+	Owner      string    `json:"owner,omitempty"`
+	Reviewer   string    `json:"reviewer,omitempty"`
+	AssignedBy string    `json:"assigned_by"` // Actor that last changed the assignment
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// AssignmentRole selects the assignments Assigned lists.
+type AssignmentRole string
+
+const (
+	RoleAny      AssignmentRole = ""
+	RoleOwner    AssignmentRole = "owner"
+	RoleReviewer AssignmentRole = "reviewer"
+)
+
+// IsValid reports whether r is a known role.
+func (r AssignmentRole) IsValid() bool {
+	return r == RoleAny || r == RoleOwner || r == RoleReviewer
+}
+
+// AssignedLore is a lore entry with its assignment.
+type AssignedLore struct {
+	Lore       Lore       `json:"lore"`
+	Assignment Assignment `json:"assignment"`
+}
+
+// SetAssignment stores the owner and reviewer of an existing lore entry,
+// replacing any previous assignment; with both empty, the assignment is
+// removed. Returns ErrNotFound if the entry does not exist or is deleted.
+func (s *Store) SetAssignment(a *Assignment) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return ErrStoreClosed
+	}
+
+	var exists int
+	if err := s.db.QueryRow(`SELECT COUNT(*) FROM lore_entries WHERE id = ? AND deleted_at IS NULL AND namespace = ?`, a.LoreID, s.namespace).Scan(&exists); err != nil {
+		return fmt.Errorf("store: set assignment: %w", err)
+	}
+	if exists == 0 {
+		return ErrNotFound
+	}
+
+	if a.Owner == "" && a.Reviewer == "" {
+		if _, err := s.db.Exec(`DELETE FROM lore_assignments WHERE lore_id = ?`, a.LoreID); err != nil {
+			return fmt.Errorf("store: set assignment: %w", err)
+		}
+		return nil
+	}
+	a.UpdatedAt = s.now().Truncate(time.Second)
+	_, err := s.db.Exec(`
+		INSERT INTO lore_assignments (lore_id, owner, reviewer, assigned_by, updated_at) VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(lore_id) DO UPDATE SET
+			owner = excluded.owner, reviewer = excluded.reviewer,
+			assigned_by = excluded.assigned_by, updated_at = excluded.updated_at
+	`, a.LoreID, a.Owner, a.Reviewer, a.AssignedBy, a.UpdatedAt.UTC().Format(time.RFC3339))
+	if err != nil {
+		return fmt.Errorf("store: set assignment: %w", err)
+	}
+	return nil
+}
+
+// Assignment returns the assignment of loreID, or nil if it is unassigned.
+func (s *Store) Assignment(loreID string) (*Assignment, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil, ErrStoreClosed
+	}
+
+	a, err := scanAssignment(s.db.QueryRow(`
+		SELECT lore_id, owner, reviewer, assigned_by, updated_at FROM lore_assignments WHERE lore_id = ?
+	`, loreID).Scan)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("store: assignment: %w", err)
+	}
+	return a, nil
+}
+
+// AssignedTo returns the assignments of live entries in the namespace
+// where person holds role, most recently assigned first.
+func (s *Store) AssignedTo(person string, role AssignmentRole) ([]Assignment, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil, ErrStoreClosed
+	}
+
+	var condition string
+	args := []any{s.namespace}
+	switch role {
+	case RoleOwner:
+		condition = "a.owner = ?"
+		args = append(args, person)
+	case RoleReviewer:
+		condition = "a.reviewer = ?"
+		args = append(args, person)
+	default:
+		condition = "(a.owner = ? OR a.reviewer = ?)"
+		args = append(args, person, person)
+	}
+	rows, err := s.db.Query(`
+		SELECT a.lore_id, a.owner, a.reviewer, a.assigned_by, a.updated_at
+		FROM lore_assignments a JOIN lore_entries l ON l.id = a.lore_id
+		WHERE l.deleted_at IS NULL AND l.namespace = ? AND `+condition+`
+		ORDER BY a.updated_at DESC, a.lore_id
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("store: assigned to: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var assignments []Assignment
+	for rows.Next() {
+		a, err := scanAssignment(rows.Scan)
+		if err != nil {
+			return nil, fmt.Errorf("store: scan assignment: %w", err)
+		}
+		assignments = append(assignments, *a)
+	}
+	return assignments, rows.Err()
+}
+
+func scanAssignment(scan func(dest ...any) error) (*Assignment, error) {
+	a := &Assignment{}
+	var updatedAt string
+	if err := scan(&a.LoreID, &a.Owner, &a.Reviewer, &a.AssignedBy, &updatedAt); err != nil {
+		return nil, err
+	}
+	a.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt)
+	return a, nil
+}
+
+// AssignOption changes one field of an assignment.
+type AssignOption func(*Assignment)
+
+// AssignOwner sets the entry's owner. An empty name unassigns the owner.
+func AssignOwner(name string) AssignOption {
+	return func(a *Assignment) {
+		a.Owner = strings.TrimSpace(name)
+	}
+}
+
+// AssignReviewer sets the entry's reviewer. An empty name unassigns the
+// reviewer.
+func AssignReviewer(name string) AssignOption {
+	return func(a *Assignment) {
+		a.Reviewer = strings.TrimSpace(name)
+	}
+}
+
+// Assign changes the owner or reviewer of lore; fields without an option
+// are kept. ref may be a lore ID or a session ref (L1, L2, ...). The
+// returned assignment has neither field set once both are unassigned.
+func (c *Client) Assign(ref string, opts ...AssignOption) (*Assignment, error) {
+	if len(opts) == 0 {
+		return nil, &ValidationError{Field: "Assignment", Message: "needs an owner or reviewer"}
+	}
+	if err := c.authorize(ActionAnnotate, ""); err != nil {
+		return nil, err
+	}
+	loreID, err := c.resolveRef(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	a, err := c.store.Assignment(loreID)
+	if err != nil {
+		return nil, fmt.Errorf("client: assign: %w", err)
+	}
+	if a == nil {
+		a = &Assignment{LoreID: loreID}
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	a.AssignedBy = c.actor()
+	if err := c.store.SetAssignment(a); err != nil {
+		return nil, fmt.Errorf("client: assign: %w", err)
+	}
+	return a, nil
+}
+
+// Assignment returns the owner and reviewer of lore, or nil if it is
+// unassigned. ref may be a lore ID or a session ref.
+func (c *Client) Assignment(ref string) (*Assignment, error) {
+	loreID, err := c.resolveRef(ref)
+	if err != nil {
+		return nil, err
+	}
+	a, err := c.store.Assignment(loreID)
+	if err != nil {
+		return nil, fmt.Errorf("client: assignment: %w", err)
+	}
+	return a, nil
+}
+
+// Assigned lists the entries assigned to person in role (RoleAny for
+// either), most recently assigned first. An empty person selects the
+// client's actor (Config.Actor, defaulting to SourceID).
+func (c *Client) Assigned(ctx context.Context, person string, role AssignmentRole) ([]AssignedLore, error) {
+	if !role.IsValid() {
+		return nil, &ValidationError{Field: "Role", Message: "must be owner or reviewer"}
+	}
+	if person == "" {
+		person = c.actor()
+	}
+	release, err := c.store.enter(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	assignments, err := c.store.AssignedTo(person, role)
+	if err != nil {
+		return nil, fmt.Errorf("client: assigned: %w", err)
+	}
+	assigned := make([]AssignedLore, 0, len(assignments))
+	for _, a := range assignments {
+		lore, err := c.store.Get(a.LoreID)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("client: assigned: %w", err)
+		}
+		assigned = append(assigned, AssignedLore{Lore: *lore, Assignment: a})
+	}
+	return assigned, nil
+}
@@ -0,0 +1,149 @@
+package recall
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestClient_Assign(t *testing.T) {
+	client := newTestClient(t, Config{AutoSync: false, SourceID: "lead"})
+
+	lore, err := client.Record("Rotate signing keys before they expire.", CategoryArchitecturalDecision)
+	if err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+
+	if a, err := client.Assignment(lore.ID); err != nil || a != nil {
+		t.Fatalf("Assignment before Assign = %+v, %v; want nil", a, err)
+	}
+
+	a, err := client.Assign(lore.ID, AssignOwner("alice"), AssignReviewer(" bob "))
+	if err != nil {
+		t.Fatalf("Assign: %v", err)
+	}
+	if a.Owner != "alice" || a.Reviewer != "bob" || a.AssignedBy != "lead" {
+		t.Errorf("Assign = %+v, want owner alice, reviewer bob, by lead", a)
+	}
+
+	// Fields without an option are kept.
+	if _, err := client.Assign(lore.ID, AssignReviewer("carol")); err != nil {
+		t.Fatalf("Assign reviewer: %v", err)
+	}
+	a, err = client.Assignment(lore.ID)
+	if err != nil {
+		t.Fatalf("Assignment: %v", err)
+	}
+	if a.Owner != "alice" || a.Reviewer != "carol" {
+		t.Errorf("Assignment = %+v, want owner alice, reviewer carol", a)
+	}
+
+	// Unassigning both removes the assignment.
+	if _, err := client.Assign(lore.ID, AssignOwner(""), AssignReviewer("")); err != nil {
+		t.Fatalf("Assign empty: %v", err)
+	}
+	if a, err := client.Assignment(lore.ID); err != nil || a != nil {
+		t.Errorf("Assignment after unassigning = %+v, %v; want nil", a, err)
+	}
+
+	var ve *ValidationError
+	if _, err := client.Assign(lore.ID); !errors.As(err, &ve) {
+		t.Errorf("Assign without options = %v, want ValidationError", err)
+	}
+	if _, err := client.Assign("01HQMISSING0000000000000000", AssignOwner("alice")); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Assign missing entry = %v, want ErrNotFound", err)
+	}
+}
+
+func TestClient_Assigned(t *testing.T) {
+	client := newTestClient(t, Config{AutoSync: false, Actor: "alice"})
+
+	owned, err := client.Record("Pin base images by digest.", CategoryDependencyBehavior)
+	if err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+	reviewed, err := client.Record("Run migrations before deploying readers.", CategoryPatternOutcome)
+	if err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+	other, err := client.Record("Cache DNS lookups in long-lived workers.", CategoryPerformanceInsight)
+	if err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+	for _, assign := range []struct {
+		id   string
+		opts []AssignOption
+	}{
+		{owned.ID, []AssignOption{AssignOwner("alice")}},
+		{reviewed.ID, []AssignOption{AssignOwner("bob"), AssignReviewer("alice")}},
+		{other.ID, []AssignOption{AssignOwner("bob")}},
+	} {
+		if _, err := client.Assign(assign.id, assign.opts...); err != nil {
+			t.Fatalf("Assign: %v", err)
+		}
+	}
+
+	ctx := context.Background()
+	mine, err := client.Assigned(ctx, "", RoleAny)
+	if err != nil {
+		t.Fatalf("Assigned: %v", err)
+	}
+	if len(mine) != 2 {
+		t.Fatalf("Assigned(me) = %d entries, want 2", len(mine))
+	}
+	for _, a := range mine {
+		if a.Lore.ID == other.ID {
+			t.Errorf("Assigned(me) includes bob's entry")
+		}
+		if a.Lore.ID != a.Assignment.LoreID {
+			t.Errorf("Lore %s paired with assignment of %s", a.Lore.ID, a.Assignment.LoreID)
+		}
+	}
+
+	reviews, err := client.Assigned(ctx, "alice", RoleReviewer)
+	if err != nil {
+		t.Fatalf("Assigned reviewer: %v", err)
+	}
+	if len(reviews) != 1 || reviews[0].Lore.ID != reviewed.ID {
+		t.Errorf("Assigned(alice, reviewer) = %+v, want only %s", reviews, reviewed.ID)
+	}
+
+	if err := client.store.DeleteLoreByID(owned.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	owners, err := client.Assigned(ctx, "alice", RoleOwner)
+	if err != nil {
+		t.Fatalf("Assigned owner: %v", err)
+	}
+	if len(owners) != 0 {
+		t.Errorf("Assigned(alice, owner) = %d entries after delete, want 0", len(owners))
+	}
+
+	var ve *ValidationError
+	if _, err := client.Assigned(ctx, "alice", "approver"); !errors.As(err, &ve) {
+		t.Errorf("Assigned with unknown role = %v, want ValidationError", err)
+	}
+}
+
+func TestClient_Query_Owners(t *testing.T) {
+	client := newTestClient(t, Config{AutoSync: false})
+
+	owned, err := client.Record("Batch writes to the audit table.", CategoryPerformanceInsight)
+	if err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+	if _, err := client.Record("Index the audit table by actor.", CategoryPerformanceInsight); err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+	if _, err := client.Assign(owned.ID, AssignOwner("dana")); err != nil {
+		t.Fatalf("Assign: %v", err)
+	}
+
+	result, err := client.Query(context.Background(), QueryParams{Query: "audit table", K: 10, Owners: []string{"dana"}})
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	if len(result.Lore) != 1 || result.Lore[0].ID != owned.ID {
+		t.Errorf("Query(Owners: dana) = %d entries, want only %s", len(result.Lore), owned.ID)
+	}
+}
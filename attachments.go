@@ -0,0 +1,415 @@
+package recall
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// attachmentsTable is the change_log table_name of synced attachment links.
+const attachmentsTable = "lore_attachments"
+
+// Attachment limits.
+const (
+	MaxAttachmentSize       = 256 << 10
+	MaxAttachmentsPerLore   = 10
+	MaxAttachmentNameLength = 255
+)
+
+// Attachment is a small artifact linked to a lore entry. Content is stored
+// once per SHA-256 hash, which is the attachment's ID; several entries may
+// link the same content under different names.
+type Attachment struct {
+	ID        string    `json:"id"`
+	LoreID    string    `json:"lore_id"`
+	Name      string    `json:"name"`
+	MediaType string    `json:"media_type"`
+	Size      int64     `json:"size"`
+	Synced    bool      `json:"synced"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// Data is set only by Client.Attachment and Store.GetAttachment.
+	Data []byte `json:"data,omitempty"`
+}
+
+// AttachmentID returns the content address of data.
+func AttachmentID(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// attachmentPayload is the change_log payload of a synced attachment link.
+type attachmentPayload struct {
+	LoreID    string `json:"lore_id"`
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	MediaType string `json:"media_type"`
+	Data      []byte `json:"data,omitempty"`
+}
+
+// attachmentEntityID is the change_log entity_id of an attachment link.
+func attachmentEntityID(loreID, id string) string {
+	return loreID + "/" + id
+}
+
+// AddAttachment stores data and links it to an existing lore entry. Linking
+// the same content again updates its name and media type. With sync set,
+// the link and content are written to change_log for push.
+func (s *Store) AddAttachment(loreID string, a *Attachment, data []byte, sync bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return ErrStoreClosed
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("store: begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := s.getLoreTx(tx, loreID); err != nil {
+		return err
+	}
+
+	a.ID = AttachmentID(data)
+	a.LoreID = loreID
+	a.Size = int64(len(data))
+	a.Synced = sync
+	a.CreatedAt = s.now().Truncate(time.Second)
+
+	var links int
+	var linked bool
+	if err := tx.QueryRow(`
+		SELECT COUNT(*), COALESCE(MAX(hash = ?), 0) FROM lore_attachments WHERE lore_id = ?
+	`, a.ID, loreID).Scan(&links, &linked); err != nil {
+		return fmt.Errorf("store: count attachments: %w", err)
+	}
+	if !linked && links >= MaxAttachmentsPerLore {
+		return &ValidationError{Field: "Attachment", Message: fmt.Sprintf("entry already has %d attachments", MaxAttachmentsPerLore)}
+	}
+
+	if err := upsertAttachmentTx(tx, a, data); err != nil {
+		return err
+	}
+
+	if sync {
+		payload, err := json.Marshal(attachmentPayload{LoreID: loreID, ID: a.ID, Name: a.Name, MediaType: a.MediaType, Data: data})
+		if err != nil {
+			return fmt.Errorf("store: marshal attachment: %w", err)
+		}
+		if err := s.appendChangeLog(tx, attachmentsTable, attachmentEntityID(loreID, a.ID), "upsert", payload, s.sourceID); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("store: commit: %w", err)
+	}
+	return nil
+}
+
+// upsertAttachmentTx stores content (once per hash) and links it to a.LoreID.
+func upsertAttachmentTx(tx *sql.Tx, a *Attachment, data []byte) error {
+	createdAt := a.CreatedAt.Format(time.RFC3339)
+	if _, err := tx.Exec(`
+		INSERT OR IGNORE INTO attachments (hash, size, data, created_at) VALUES (?, ?, ?, ?)
+	`, a.ID, len(data), data, createdAt); err != nil {
+		return fmt.Errorf("store: insert attachment: %w", err)
+	}
+	if _, err := tx.Exec(`
+		INSERT INTO lore_attachments (lore_id, hash, name, media_type, synced, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT (lore_id, hash) DO UPDATE SET
+			name = excluded.name, media_type = excluded.media_type, synced = MAX(synced, excluded.synced)
+	`, a.LoreID, a.ID, a.Name, a.MediaType, a.Synced, createdAt); err != nil {
+		return fmt.Errorf("store: link attachment: %w", err)
+	}
+	return nil
+}
+
+// removeAttachmentTx unlinks an attachment and drops its content once no
+// entry links it. It reports whether a link existed and was synced.
+func removeAttachmentTx(tx *sql.Tx, loreID, id string) (found, synced bool, err error) {
+	err = tx.QueryRow(`SELECT synced FROM lore_attachments WHERE lore_id = ? AND hash = ?`, loreID, id).Scan(&synced)
+	if err == sql.ErrNoRows {
+		return false, false, nil
+	}
+	if err != nil {
+		return false, false, fmt.Errorf("store: get attachment link: %w", err)
+	}
+	if _, err := tx.Exec(`DELETE FROM lore_attachments WHERE lore_id = ? AND hash = ?`, loreID, id); err != nil {
+		return false, false, fmt.Errorf("store: unlink attachment: %w", err)
+	}
+	if _, err := tx.Exec(`
+		DELETE FROM attachments WHERE hash = ? AND NOT EXISTS (SELECT 1 FROM lore_attachments WHERE hash = ?)
+	`, id, id); err != nil {
+		return false, false, fmt.Errorf("store: delete attachment: %w", err)
+	}
+	return true, synced, nil
+}
+
+// RemoveAttachment unlinks an attachment from a lore entry. Content no
+// longer linked anywhere is deleted. Removing a synced link is pushed.
+// Returns ErrNotFound if the entry has no such attachment.
+func (s *Store) RemoveAttachment(loreID, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return ErrStoreClosed
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("store: begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := s.getLoreTx(tx, loreID); err != nil {
+		return err
+	}
+	found, synced, err := removeAttachmentTx(tx, loreID, id)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrNotFound
+	}
+	if synced {
+		if err := s.appendChangeLog(tx, attachmentsTable, attachmentEntityID(loreID, id), "delete", nil, s.sourceID); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("store: commit: %w", err)
+	}
+	return nil
+}
+
+// Attachments lists the attachments of a lore entry, oldest first,
+// without their content.
+func (s *Store) Attachments(loreID string) ([]Attachment, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil, ErrStoreClosed
+	}
+
+	rows, err := s.db.Query(`
+		SELECT la.hash, la.lore_id, la.name, la.media_type, a.size, la.synced, la.created_at
+		FROM lore_attachments la
+		JOIN attachments a ON a.hash = la.hash
+		JOIN lore_entries l ON l.id = la.lore_id AND l.deleted_at IS NULL AND l.namespace = ?
+		WHERE la.lore_id = ?
+		ORDER BY la.created_at, la.name
+	`, s.namespace, loreID)
+	if err != nil {
+		return nil, fmt.Errorf("store: list attachments: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var attachments []Attachment
+	for rows.Next() {
+		a, err := scanAttachment(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, *a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("store: iterate attachments: %w", err)
+	}
+	return attachments, nil
+}
+
+// GetAttachment returns an attachment with its content. Name, media type
+// and LoreID come from the oldest link visible in the namespace.
+// Returns ErrNotFound if no live entry in the namespace links it.
+func (s *Store) GetAttachment(id string) (*Attachment, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil, ErrStoreClosed
+	}
+
+	var data []byte
+	a, err := scanAttachment(func(dest ...any) error {
+		return s.db.QueryRow(`
+			SELECT la.hash, la.lore_id, la.name, la.media_type, a.size, la.synced, la.created_at, a.data
+			FROM lore_attachments la
+			JOIN attachments a ON a.hash = la.hash
+			JOIN lore_entries l ON l.id = la.lore_id AND l.deleted_at IS NULL AND l.namespace = ?
+			WHERE la.hash = ?
+			ORDER BY la.created_at
+			LIMIT 1
+		`, s.namespace, strings.ToLower(id)).Scan(append(dest, &data)...)
+	})
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	a.Data = data
+	return a, nil
+}
+
+// scanAttachment scans the common attachment columns.
+func scanAttachment(scan func(dest ...any) error) (*Attachment, error) {
+	var a Attachment
+	var createdAt string
+	if err := scan(&a.ID, &a.LoreID, &a.Name, &a.MediaType, &a.Size, &a.Synced, &createdAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, err
+		}
+		return nil, fmt.Errorf("store: scan attachment: %w", err)
+	}
+	a.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+	return &a, nil
+}
+
+// parseAttachmentDelta converts a remote lore_attachments change into a
+// delta operation.
+func parseAttachmentDelta(entry DeltaEntry) (deltaOp, error) {
+	if entry.Operation == "delete" {
+		loreID, id, ok := strings.Cut(entry.EntityID, "/")
+		if !ok {
+			return deltaOp{}, fmt.Errorf("invalid attachment entity %q", entry.EntityID)
+		}
+		return deltaOp{detach: &Attachment{LoreID: loreID, ID: id}}, nil
+	}
+
+	var p attachmentPayload
+	if err := json.Unmarshal(entry.Payload, &p); err != nil {
+		return deltaOp{}, fmt.Errorf("decode attachment: %w", err)
+	}
+	if AttachmentID(p.Data) != p.ID {
+		return deltaOp{}, fmt.Errorf("attachment %s: content does not match its hash", p.ID)
+	}
+	createdAt, _ := time.Parse(time.RFC3339, entry.CreatedAt)
+	return deltaOp{attach: &Attachment{
+		ID: p.ID, LoreID: p.LoreID, Name: p.Name, MediaType: p.MediaType,
+		Size: int64(len(p.Data)), Synced: true, CreatedAt: createdAt.UTC(), Data: p.Data,
+	}}, nil
+}
+
+// AttachOption configures Attach.
+type AttachOption func(*attachOptions)
+
+type attachOptions struct {
+	mediaType string
+	sync      bool
+}
+
+// WithMediaType sets the attachment's media type. By default it is sniffed
+// from the content.
+func WithMediaType(mediaType string) AttachOption {
+	return func(o *attachOptions) {
+		o.mediaType = mediaType
+	}
+}
+
+// WithAttachmentSync pushes the attachment to Engram with the next sync.
+// Attachments are local-only by default.
+func WithAttachmentSync() AttachOption {
+	return func(o *attachOptions) {
+		o.sync = true
+	}
+}
+
+// Attach links data of up to MaxAttachmentSize bytes to lore. ref may be a
+// lore ID or a session ref (L1, L2, ...). Synced attachments need the
+// record permission for the entry's category.
+func (c *Client) Attach(ref, name string, data []byte, opts ...AttachOption) (*Attachment, error) {
+	options := attachOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if err := c.authorize(ActionAnnotate, ""); err != nil {
+		return nil, err
+	}
+
+	name = strings.TrimSpace(name)
+	switch {
+	case name == "":
+		return nil, &ValidationError{Field: "Name", Message: "cannot be empty"}
+	case len(name) > MaxAttachmentNameLength:
+		return nil, &ValidationError{Field: "Name", Message: fmt.Sprintf("exceeds %d character limit", MaxAttachmentNameLength)}
+	case len(data) == 0:
+		return nil, &ValidationError{Field: "Data", Message: "cannot be empty"}
+	case len(data) > MaxAttachmentSize:
+		return nil, &ValidationError{Field: "Data", Message: fmt.Sprintf("exceeds %d byte limit", MaxAttachmentSize)}
+	}
+
+	loreID, err := c.resolveRef(ref)
+	if err != nil {
+		return nil, err
+	}
+	if options.sync {
+		lore, err := c.store.Get(loreID)
+		if err != nil {
+			return nil, err
+		}
+		if err := c.authorize(ActionRecord, lore.Category); err != nil {
+			return nil, err
+		}
+	}
+
+	a := &Attachment{Name: name, MediaType: options.mediaType}
+	if a.MediaType == "" {
+		a.MediaType = http.DetectContentType(data)
+	}
+	if err := c.store.AddAttachment(loreID, a, data, options.sync); err != nil {
+		return nil, fmt.Errorf("client: attach: %w", err)
+	}
+	return a, nil
+}
+
+// Attachments lists the attachments of lore without their content.
+// ref may be a lore ID or a session ref.
+func (c *Client) Attachments(ref string) ([]Attachment, error) {
+	loreID, err := c.resolveRef(ref)
+	if err != nil {
+		return nil, err
+	}
+	attachments, err := c.store.Attachments(loreID)
+	if err != nil {
+		return nil, fmt.Errorf("client: attachments: %w", err)
+	}
+	return attachments, nil
+}
+
+// Attachment returns an attachment, including its content, by ID.
+func (c *Client) Attachment(id string) (*Attachment, error) {
+	a, err := c.store.GetAttachment(id)
+	if err != nil {
+		return nil, fmt.Errorf("client: attachment: %w", err)
+	}
+	return a, nil
+}
+
+// Detach removes an attachment from lore. ref may be a lore ID or a
+// session ref.
+func (c *Client) Detach(ref, id string) error {
+	if err := c.authorize(ActionAnnotate, ""); err != nil {
+		return err
+	}
+	loreID, err := c.resolveRef(ref)
+	if err != nil {
+		return err
+	}
+	if err := c.store.RemoveAttachment(loreID, strings.ToLower(id)); err != nil {
+		return fmt.Errorf("client: detach: %w", err)
+	}
+	return nil
+}
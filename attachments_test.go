@@ -0,0 +1,171 @@
+package recall
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newAttachmentClient(t *testing.T) (*Client, *Lore, *Lore) {
+	t.Helper()
+	client := newTestClient(t, Config{})
+
+	a, err := client.Record("Pool size must match max_connections", CategoryPerformanceInsight)
+	if err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+	b, err := client.Record("Raise the pool for batch jobs", CategoryPerformanceInsight)
+	if err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+	return client, a, b
+}
+
+func TestClient_Attach(t *testing.T) {
+	client, a, b := newAttachmentClient(t)
+	diff := []byte("-pool_size: 10\n+pool_size: 50\n")
+	changesBefore := getChangeLogCount(t, client.store)
+
+	att, err := client.Attach(a.ID, "pool.diff", diff)
+	if err != nil {
+		t.Fatalf("Attach: %v", err)
+	}
+	if att.ID != AttachmentID(diff) || att.Size != int64(len(diff)) || !strings.HasPrefix(att.MediaType, "text/plain") {
+		t.Errorf("attachment = %+v", att)
+	}
+	if _, err := client.Attach(b.ID, "same.diff", diff, WithMediaType("text/x-diff")); err != nil {
+		t.Fatalf("Attach second entry: %v", err)
+	}
+	if n := getChangeLogCount(t, client.store); n != changesBefore {
+		t.Errorf("change_log grew to %d; local attachments must not sync", n)
+	}
+
+	var blobs int
+	if err := client.store.db.QueryRow(`SELECT COUNT(*) FROM attachments`).Scan(&blobs); err != nil || blobs != 1 {
+		t.Errorf("attachments rows = %d (%v), want content stored once", blobs, err)
+	}
+
+	list, err := client.Attachments(b.ID)
+	if err != nil {
+		t.Fatalf("Attachments: %v", err)
+	}
+	if len(list) != 1 || list[0].Name != "same.diff" || list[0].MediaType != "text/x-diff" || list[0].Data != nil {
+		t.Errorf("Attachments = %+v", list)
+	}
+
+	got, err := client.Attachment(att.ID)
+	if err != nil {
+		t.Fatalf("Attachment: %v", err)
+	}
+	if !bytes.Equal(got.Data, diff) || got.LoreID != a.ID || got.Name != "pool.diff" {
+		t.Errorf("Attachment = %+v", got)
+	}
+
+	// Content is kept while any entry links it.
+	if err := client.Detach(a.ID, att.ID); err != nil {
+		t.Fatalf("Detach: %v", err)
+	}
+	if _, err := client.Attachment(att.ID); err != nil {
+		t.Errorf("Attachment after one detach: %v", err)
+	}
+	if err := client.Detach(b.ID, att.ID); err != nil {
+		t.Fatalf("Detach: %v", err)
+	}
+	if _, err := client.Attachment(att.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Attachment after last detach = %v, want ErrNotFound", err)
+	}
+	if err := client.Detach(b.ID, att.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("second Detach = %v, want ErrNotFound", err)
+	}
+}
+
+func TestClient_Attach_Validation(t *testing.T) {
+	client, a, _ := newAttachmentClient(t)
+
+	tests := []struct {
+		name  string
+		ref   string
+		aname string
+		data  []byte
+		field string
+	}{
+		{"empty name", a.ID, " ", []byte("x"), "Name"},
+		{"empty data", a.ID, "x.txt", nil, "Data"},
+		{"too large", a.ID, "big.bin", make([]byte, MaxAttachmentSize+1), "Data"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.Attach(tt.ref, tt.aname, tt.data)
+			var ve *ValidationError
+			if !errors.As(err, &ve) || ve.Field != tt.field {
+				t.Errorf("Attach() error = %v, want ValidationError on %s", err, tt.field)
+			}
+		})
+	}
+
+	if _, err := client.Attach("01MISSING0000000000000000", "x.txt", []byte("x")); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Attach to missing lore = %v, want ErrNotFound", err)
+	}
+
+	for i := 0; i < MaxAttachmentsPerLore; i++ {
+		if _, err := client.Attach(a.ID, "log.txt", []byte{byte('a' + i)}); err != nil {
+			t.Fatalf("Attach #%d: %v", i, err)
+		}
+	}
+	var ve *ValidationError
+	if _, err := client.Attach(a.ID, "one-too-many.txt", []byte("z")); !errors.As(err, &ve) {
+		t.Errorf("Attach past the limit = %v, want ValidationError", err)
+	}
+	// Re-linking existing content does not count against the limit.
+	if _, err := client.Attach(a.ID, "renamed.txt", []byte("a")); err != nil {
+		t.Errorf("re-attach: %v", err)
+	}
+}
+
+func TestAttachmentSync_PushAndDelta(t *testing.T) {
+	client, a, _ := newAttachmentClient(t)
+	data := []byte("ERROR pool exhausted after 30s")
+
+	att, err := client.Attach(a.ID, "api.log", data, WithAttachmentSync())
+	if err != nil {
+		t.Fatalf("Attach: %v", err)
+	}
+	entries, err := client.store.UnpushedChanges(client.store.SourceID(), 0, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	last := entries[len(entries)-1]
+	if last.TableName != attachmentsTable || last.EntityID != a.ID+"/"+att.ID || last.Operation != "upsert" {
+		t.Fatalf("last change = %s %s %s, want attachment upsert", last.TableName, last.EntityID, last.Operation)
+	}
+
+	// A second store receives the attachment through delta sync.
+	remote := newTestStore(t)
+	if err := remote.InsertLore(a); err != nil {
+		t.Fatal(err)
+	}
+	page := SyncDeltaResponse{Entries: []DeltaEntry{{
+		Sequence: 1, TableName: last.TableName, EntityID: last.EntityID, Operation: last.Operation,
+		Payload: last.Payload, SourceID: "other", CreatedAt: last.CreatedAt,
+	}}, LastSequence: 1}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(page)
+	}))
+	defer server.Close()
+
+	if _, err := newTestSyncer(t, remote, server.URL).SyncDelta(context.Background()); err != nil {
+		t.Fatalf("SyncDelta: %v", err)
+	}
+	got, err := remote.GetAttachment(att.ID)
+	if err != nil {
+		t.Fatalf("GetAttachment: %v", err)
+	}
+	if !bytes.Equal(got.Data, data) || !got.Synced || got.Name != "api.log" {
+		t.Errorf("remote attachment = %+v", got)
+	}
+}
@@ -0,0 +1,251 @@
+package recall
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+)
+
+// DefaultRemoteAuditInterval is how often background sync audits the store
+// against Engram when Config.RemoteAudit is set and
+// Config.RemoteAuditInterval is zero.
+const DefaultRemoteAuditInterval = 7 * 24 * time.Hour
+
+// syncMetaLastAudit is the sync_meta key holding when the last remote audit
+// completed, so the schedule survives restarts.
+const syncMetaLastAudit = "last_remote_audit"
+
+// DriftKind classifies a difference found by a remote audit.
+type DriftKind string
+
+const (
+	// DriftMissingLocal is an entry Engram has that the store does not.
+	DriftMissingLocal DriftKind = "missing_local"
+
+	// DriftMissingRemote is a pushed entry Engram does not have.
+	DriftMissingRemote DriftKind = "missing_remote"
+
+	// DriftTimestamp is an entry whose updated_at differs between the
+	// store and Engram.
+	DriftTimestamp DriftKind = "divergent_timestamp"
+)
+
+// Drift is one entry that differs between the store and Engram.
+type Drift struct {
+	LoreID          string     `json:"lore_id"`
+	Kind            DriftKind  `json:"kind"`
+	LocalUpdatedAt  *time.Time `json:"local_updated_at,omitempty"`
+	RemoteUpdatedAt *time.Time `json:"remote_updated_at,omitempty"`
+}
+
+// AuditReport is the result of comparing the store with Engram's digest.
+type AuditReport struct {
+	At            time.Time `json:"at"`
+	LocalEntries  int       `json:"local_entries"`  // Live entries compared; unpushed entries are skipped
+	RemoteEntries int       `json:"remote_entries"` // Live entries in Engram's digest
+	Unpushed      int       `json:"unpushed"`       // Local entries with changes not yet pushed
+	MissingLocal  int       `json:"missing_local"`
+	MissingRemote int       `json:"missing_remote"`
+	Divergent     int       `json:"divergent"`
+	Drift         []Drift   `json:"drift"`
+}
+
+// Drifted reports whether the audit found any difference.
+func (r *AuditReport) Drifted() bool {
+	return len(r.Drift) > 0
+}
+
+// digestEntry is one live entry in Engram's digest.
+type digestEntry struct {
+	ID        string `json:"id"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+// digestResponse is the body of GET /stores/{store_id}/sync/digest.
+type digestResponse struct {
+	Entries []digestEntry `json:"entries"`
+}
+
+// digestPath returns the API path for the store's lore digest.
+func (s *Syncer) digestPath() string {
+	if s.storeID == "" {
+		panic("recall: digestPath requires storeID to be set")
+	}
+	return fmt.Sprintf("/api/v1/stores/%s/sync/digest", encodeStoreID(s.storeID))
+}
+
+// FetchDigest returns the ID and updated_at of every live entry Engram
+// holds in namespace, without their content.
+func (s *Syncer) FetchDigest(ctx context.Context, namespace string) ([]digestEntry, error) {
+	reqURL := s.engramURL + s.digestPath()
+	if namespace != "" {
+		reqURL += "?namespace=" + url.QueryEscape(namespace)
+	}
+	req, err := http.NewRequestWithContext(ctx, "GET", reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("digest: create request: %w", err)
+	}
+	s.setHeaders(req)
+
+	resp, err := s.do(req)
+	if err != nil {
+		return nil, &SyncError{Operation: "digest", Err: err}
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return nil, &SyncError{Operation: "digest", StatusCode: resp.StatusCode, Err: errors.New(truncate(string(respBody), 200))}
+	}
+	var digest digestResponse
+	if err := json.NewDecoder(resp.Body).Decode(&digest); err != nil {
+		return nil, fmt.Errorf("digest: decode response: %w", err)
+	}
+	return digest.Entries, nil
+}
+
+// localDigestEntry is one live entry of the store for a remote audit.
+type localDigestEntry struct {
+	UpdatedAt time.Time
+	Unpushed  bool
+}
+
+// localDigest returns the updated_at of every live entry in the namespace,
+// and whether it has changes not yet pushed.
+func (s *Store) localDigest() (map[string]localDigestEntry, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil, ErrStoreClosed
+	}
+
+	rows, err := s.db.Query(`
+		SELECT id, updated_at, `+unpushedLoreCondition+`
+		FROM lore_entries WHERE deleted_at IS NULL AND namespace = ?
+	`, s.namespace)
+	if err != nil {
+		return nil, fmt.Errorf("store: local digest: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	digest := make(map[string]localDigestEntry)
+	for rows.Next() {
+		var id, updatedAt string
+		var e localDigestEntry
+		if err := rows.Scan(&id, &updatedAt, &e.Unpushed); err != nil {
+			return nil, fmt.Errorf("store: scan local digest: %w", err)
+		}
+		e.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt)
+		e.UpdatedAt = e.UpdatedAt.UTC().Truncate(time.Second)
+		digest[id] = e
+	}
+	return digest, rows.Err()
+}
+
+// AuditRemote compares the store with a digest of Engram's live entries
+// (IDs and updated_at only; no content is transferred) and reports drift:
+// entries missing on either side and entries whose updated_at differs.
+// Entries with unpushed local changes are expected to differ and are
+// skipped. A report with drift is also sent to Config.OnSyncEvent as
+// SyncEventAuditDrift. Returns ErrOffline if Engram is not configured.
+func (c *Client) AuditRemote(ctx context.Context) (*AuditReport, error) {
+	if c.syncer == nil {
+		return nil, ErrOffline
+	}
+	release, err := c.store.enter(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	remote, err := c.syncer.FetchDigest(ctx, c.store.namespace)
+	if err != nil {
+		return nil, fmt.Errorf("client: audit remote: %w", err)
+	}
+	local, err := c.store.localDigest()
+	if err != nil {
+		return nil, fmt.Errorf("client: audit remote: %w", err)
+	}
+
+	report := compareDigests(local, remote)
+	report.At = c.store.now().UTC()
+	if err := c.store.SetSyncMeta(syncMetaLastAudit, report.At.Format(time.RFC3339)); err != nil {
+		c.debug.LogError("record remote audit", err)
+	}
+	if report.Drifted() {
+		c.syncer.emitSyncEvent(SyncEvent{Type: SyncEventAuditDrift, Audit: report})
+	}
+	return report, nil
+}
+
+// compareDigests reports the drift between the store's and Engram's
+// digests, ordered by lore ID.
+func compareDigests(local map[string]localDigestEntry, remote []digestEntry) *AuditReport {
+	report := &AuditReport{RemoteEntries: len(remote), Drift: []Drift{}}
+	seen := make(map[string]bool, len(remote))
+	for _, r := range remote {
+		seen[r.ID] = true
+		remoteAt, _ := time.Parse(time.RFC3339, r.UpdatedAt)
+		remoteAt = remoteAt.UTC().Truncate(time.Second)
+		l, ok := local[r.ID]
+		switch {
+		case !ok:
+			report.MissingLocal++
+			report.Drift = append(report.Drift, Drift{LoreID: r.ID, Kind: DriftMissingLocal, RemoteUpdatedAt: &remoteAt})
+		case l.Unpushed:
+			// Expected to differ until pushed
+		case !l.UpdatedAt.Equal(remoteAt):
+			localAt := l.UpdatedAt
+			report.Divergent++
+			report.Drift = append(report.Drift, Drift{LoreID: r.ID, Kind: DriftTimestamp, LocalUpdatedAt: &localAt, RemoteUpdatedAt: &remoteAt})
+		}
+	}
+	for id, l := range local {
+		if l.Unpushed {
+			report.Unpushed++
+			continue
+		}
+		report.LocalEntries++
+		if !seen[id] {
+			localAt := l.UpdatedAt
+			report.MissingRemote++
+			report.Drift = append(report.Drift, Drift{LoreID: id, Kind: DriftMissingRemote, LocalUpdatedAt: &localAt})
+		}
+	}
+	sort.Slice(report.Drift, func(i, j int) bool {
+		return report.Drift[i].LoreID < report.Drift[j].LoreID
+	})
+	return report
+}
+
+// auditIfDue runs a remote audit after a background sync when
+// Config.RemoteAudit is set and the last audit is older than
+// Config.RemoteAuditInterval. Failures are logged and retried on the next
+// tick.
+func (c *Client) auditIfDue(ctx context.Context) {
+	if !c.config.RemoteAudit {
+		return
+	}
+	interval := c.config.RemoteAuditInterval
+	if interval <= 0 {
+		interval = DefaultRemoteAuditInterval
+	}
+	last, err := c.store.GetSyncMeta(syncMetaLastAudit)
+	if err != nil {
+		c.debug.LogError("remote audit", err)
+		return
+	}
+	if at, err := time.Parse(time.RFC3339, last); err == nil && c.store.now().Sub(at) < interval {
+		return
+	}
+	if _, err := c.AuditRemote(ctx); err != nil {
+		c.debug.LogError("remote audit", err)
+	}
+}
@@ -0,0 +1,134 @@
+package recall
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newDigestServer returns an Engram stub serving entries as the store's
+// digest, and the number of digests it served.
+func newDigestServer(t *testing.T, entries []digestEntry) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var served atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/stores/test-store/sync/digest" {
+			_, _ = w.Write([]byte(`{}`))
+			return
+		}
+		served.Add(1)
+		_ = json.NewEncoder(w).Encode(digestResponse{Entries: entries})
+	}))
+	t.Cleanup(server.Close)
+	return server, &served
+}
+
+func TestClient_AuditRemote(t *testing.T) {
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	server, _ := newDigestServer(t, []digestEntry{
+		{ID: "01MATCH000000000000000000", UpdatedAt: now.Format(time.RFC3339)},
+		{ID: "01DIVERGE0000000000000000", UpdatedAt: now.Add(time.Hour).Format(time.RFC3339)},
+		{ID: "01REMOTEONLY0000000000000", UpdatedAt: now.Format(time.RFC3339)},
+		{ID: "01UNPUSHED000000000000000", UpdatedAt: now.Format(time.RFC3339)},
+	})
+
+	var events []SyncEvent
+	client := newTestClient(t, Config{
+		Store:       "test-store",
+		EngramURL:   server.URL,
+		APIKey:      "key",
+		AutoSync:    false,
+		OnSyncEvent: func(e SyncEvent) { events = append(events, e) },
+	})
+
+	// Upserted entries have no change_log entry, like lore applied by
+	// delta sync; the inserted one has unpushed changes.
+	for _, id := range []string{"01MATCH000000000000000000", "01DIVERGE0000000000000000", "01LOCALONLY00000000000000"} {
+		if err := client.store.UpsertLore(&Lore{
+			ID: id, Content: "Entry " + id, Category: CategoryPatternOutcome, Confidence: 0.5,
+			SourceID: "peer", CreatedAt: now, UpdatedAt: now,
+		}); err != nil {
+			t.Fatalf("UpsertLore: %v", err)
+		}
+	}
+	if err := client.store.InsertLore(&Lore{
+		ID: "01UNPUSHED000000000000000", Content: "Unpushed", Category: CategoryPatternOutcome, Confidence: 0.5,
+		SourceID: "test", CreatedAt: now, UpdatedAt: now.Add(time.Minute),
+	}); err != nil {
+		t.Fatalf("InsertLore: %v", err)
+	}
+
+	report, err := client.AuditRemote(context.Background())
+	if err != nil {
+		t.Fatalf("AuditRemote: %v", err)
+	}
+	if report.LocalEntries != 3 || report.RemoteEntries != 4 || report.Unpushed != 1 {
+		t.Errorf("entries = %d local, %d remote, %d unpushed; want 3, 4, 1", report.LocalEntries, report.RemoteEntries, report.Unpushed)
+	}
+	want := []struct {
+		id   string
+		kind DriftKind
+	}{
+		{"01DIVERGE0000000000000000", DriftTimestamp},
+		{"01LOCALONLY00000000000000", DriftMissingRemote},
+		{"01REMOTEONLY0000000000000", DriftMissingLocal},
+	}
+	if len(report.Drift) != len(want) {
+		t.Fatalf("Drift = %+v, want %d entries", report.Drift, len(want))
+	}
+	for i, w := range want {
+		if report.Drift[i].LoreID != w.id || report.Drift[i].Kind != w.kind {
+			t.Errorf("Drift[%d] = %s %s, want %s %s", i, report.Drift[i].LoreID, report.Drift[i].Kind, w.id, w.kind)
+		}
+	}
+	if report.MissingLocal != 1 || report.MissingRemote != 1 || report.Divergent != 1 {
+		t.Errorf("counts = %d/%d/%d, want 1/1/1", report.MissingLocal, report.MissingRemote, report.Divergent)
+	}
+
+	if len(events) != 1 || events[0].Type != SyncEventAuditDrift || events[0].Audit != report {
+		t.Errorf("events = %+v, want one SyncEventAuditDrift with the report", events)
+	}
+	if last, _ := client.store.GetSyncMeta(syncMetaLastAudit); last == "" {
+		t.Error("last audit time not recorded")
+	}
+}
+
+func TestClient_AuditIfDue(t *testing.T) {
+	server, served := newDigestServer(t, nil)
+	client := newTestClient(t, Config{
+		Store:       "test-store",
+		EngramURL:   server.URL,
+		APIKey:      "key",
+		AutoSync:    false,
+		RemoteAudit: true,
+	})
+
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	client.store.SetClock(ClockFunc(func() time.Time { return now }))
+
+	ctx := context.Background()
+	client.auditIfDue(ctx)
+	client.auditIfDue(ctx)
+	if served.Load() != 1 {
+		t.Fatalf("digests after two checks = %d, want 1", served.Load())
+	}
+
+	now = now.Add(DefaultRemoteAuditInterval)
+	client.auditIfDue(ctx)
+	if served.Load() != 2 {
+		t.Errorf("digests after the interval = %d, want 2", served.Load())
+	}
+}
+
+func TestClient_AuditRemote_Offline(t *testing.T) {
+	client := newTestClient(t, Config{})
+
+	if _, err := client.AuditRemote(context.Background()); !errors.Is(err, ErrOffline) {
+		t.Errorf("AuditRemote offline = %v, want ErrOffline", err)
+	}
+}
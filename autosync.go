@@ -0,0 +1,156 @@
+package recall
+
+import (
+	"context"
+	"math/rand"
+	"time"
+)
+
+// syncBackoffLimit caps how far failures stretch the background sync
+// wait, as a multiple of Config.SyncInterval.
+const syncBackoffLimit = 8
+
+// AutoSyncStatus reports the background sync scheduler (Config.AutoSync).
+type AutoSyncStatus struct {
+	// Paused is set between PauseSync and ResumeSync.
+	Paused bool `json:"paused"`
+
+	// LastRun is when the last background sync started, LastDuration how
+	// long it took and LastError its error, if it failed. All are zero
+	// before the first run.
+	LastRun      time.Time     `json:"last_run,omitempty"`
+	LastDuration time.Duration `json:"last_duration_ns,omitempty"`
+	LastError    string        `json:"last_error,omitempty"`
+
+	// ConsecutiveFailures counts background syncs failed in a row; each
+	// doubles the wait before the next, up to 8×SyncInterval.
+	ConsecutiveFailures int `json:"consecutive_failures,omitempty"`
+
+	// NextRun is when the next background sync is due.
+	NextRun time.Time `json:"next_run,omitempty"`
+}
+
+// PauseSync stops background sync from starting new runs until
+// ResumeSync. A run in flight completes. Sync, SyncNow and the other
+// explicit calls still work.
+func (c *Client) PauseSync() {
+	c.autoSyncMu.Lock()
+	defer c.autoSyncMu.Unlock()
+	c.autoSync.Paused = true
+}
+
+// ResumeSync lets background sync run again after PauseSync, starting
+// with a run right away.
+func (c *Client) ResumeSync() {
+	c.autoSyncMu.Lock()
+	wasPaused := c.autoSync.Paused
+	c.autoSync.Paused = false
+	c.autoSyncMu.Unlock()
+
+	if wasPaused {
+		select {
+		case c.syncWake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// syncPaused reports whether PauseSync is in effect.
+func (c *Client) syncPaused() bool {
+	c.autoSyncMu.Lock()
+	defer c.autoSyncMu.Unlock()
+	return c.autoSync.Paused
+}
+
+// autoSyncStatus returns a copy of the scheduler's status.
+func (c *Client) autoSyncStatus() AutoSyncStatus {
+	c.autoSyncMu.Lock()
+	defer c.autoSyncMu.Unlock()
+	return c.autoSync
+}
+
+// nextSyncWait returns the wait before the next background sync:
+// SyncInterval, doubled per consecutive failure up to syncBackoffLimit
+// times, then spread by SyncJitter.
+func (c *Client) nextSyncWait(failures int) time.Duration {
+	interval := c.config.SyncInterval
+	wait := interval
+	for i := 0; i < failures && wait < interval*syncBackoffLimit; i++ {
+		wait *= 2
+	}
+	wait = min(wait, interval*syncBackoffLimit)
+	if jitter := c.config.SyncJitter; jitter > 0 {
+		wait += time.Duration((rand.Float64()*2 - 1) * jitter * float64(wait))
+	}
+	return wait
+}
+
+// backgroundSync runs Sync on the AutoSync schedule until the client
+// closes, skipping runs while paused, disabled by the kill-switch, or
+// during Engram maintenance.
+func (c *Client) backgroundSync() {
+	defer close(c.syncDone)
+
+	for {
+		wait := c.nextSyncWait(c.autoSyncStatus().ConsecutiveFailures)
+		c.autoSyncMu.Lock()
+		c.autoSync.NextRun = time.Now().Add(wait)
+		c.autoSyncMu.Unlock()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-c.stopSync:
+			timer.Stop()
+			return
+		case <-c.syncWake:
+			timer.Stop()
+		case <-timer.C:
+		}
+		if c.syncPaused() || c.syncDisabled() || c.pausedForMaintenance() {
+			continue
+		}
+
+		// Create cancellable context
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+
+		// Run sync, but also listen for stop signal
+		start := time.Now()
+		var err error
+		done := make(chan struct{})
+		go func() {
+			err = c.sync(ctx)
+			c.telemetry.countSync(err)
+			c.auditIfDue(ctx)
+			close(done)
+		}()
+
+		select {
+		case <-done:
+			// Sync completed normally
+		case <-c.stopSync:
+			cancel() // Cancel in-flight HTTP requests
+			<-done   // Wait for Sync to return
+			return
+		}
+		cancel()
+		c.recordAutoSync(start, err)
+	}
+}
+
+// recordAutoSync records the outcome of a background sync that started
+// at start.
+func (c *Client) recordAutoSync(start time.Time, err error) {
+	c.autoSyncMu.Lock()
+	defer c.autoSyncMu.Unlock()
+
+	c.autoSync.LastRun = start
+	c.autoSync.LastDuration = time.Since(start)
+	c.autoSync.LastError = ""
+	if err != nil {
+		c.autoSync.LastError = err.Error()
+		c.autoSync.ConsecutiveFailures++
+		c.debug.LogError("background sync", err)
+		return
+	}
+	c.autoSync.ConsecutiveFailures = 0
+}
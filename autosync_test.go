@@ -0,0 +1,145 @@
+package recall
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClient_NextSyncWait(t *testing.T) {
+	c := &Client{config: Config{SyncInterval: time.Minute}}
+	for failures, want := range map[int]time.Duration{
+		0:  time.Minute,
+		1:  2 * time.Minute,
+		2:  4 * time.Minute,
+		3:  8 * time.Minute,
+		10: 8 * time.Minute,
+	} {
+		if got := c.nextSyncWait(failures); got != want {
+			t.Errorf("nextSyncWait(%d) = %v, want %v", failures, got, want)
+		}
+	}
+
+	c.config.SyncJitter = 0.5
+	seen := make(map[time.Duration]bool)
+	for range 100 {
+		wait := c.nextSyncWait(1)
+		if wait < time.Minute || wait > 3*time.Minute {
+			t.Fatalf("nextSyncWait(1) with jitter 0.5 = %v, want 2m ± 1m", wait)
+		}
+		seen[wait] = true
+	}
+	if len(seen) < 50 {
+		t.Errorf("jitter produced only %d distinct waits in 100", len(seen))
+	}
+}
+
+func TestClient_BackgroundSync_BackoffPauseResume(t *testing.T) {
+	var failing atomic.Bool
+	var requests, pushed atomic.Int64
+	failing.Store(true)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if failing.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		switch r.URL.Path {
+		case "/api/v1/stores/test-store/sync/push":
+			var req SyncPushRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			pushed.Add(int64(len(req.Entries)))
+			_ = json.NewEncoder(w).Encode(SyncPushResponse{Accepted: len(req.Entries), RemoteSequence: 1})
+		case "/api/v1/stores/test-store/sync/delta":
+			_ = json.NewEncoder(w).Encode(SyncDeltaResponse{})
+		default:
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client := newTestClient(t, Config{
+		Store:        "test-store",
+		EngramURL:    server.URL,
+		APIKey:       "key",
+		AutoSync:     true,
+		SyncInterval: 10 * time.Millisecond,
+		SyncJitter:   0.2,
+		SyncRetry:    RetryPolicy{MaxAttempts: 1},
+	})
+	if _, err := client.Record("Background sync backs off.", CategoryPatternOutcome); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+
+	status := func() AutoSyncStatus {
+		t.Helper()
+		stats, err := client.Stats()
+		if err != nil {
+			t.Fatalf("Stats() error = %v", err)
+		}
+		if stats.AutoSync == nil {
+			t.Fatal("Stats().AutoSync is nil with AutoSync on")
+		}
+		return *stats.AutoSync
+	}
+
+	eventually(t, 5*time.Second, func() error {
+		if s := status(); s.ConsecutiveFailures < 2 {
+			return fmt.Errorf("%d consecutive failures, want 2", s.ConsecutiveFailures)
+		}
+		return nil
+	})
+	if s := status(); s.LastError == "" || s.LastRun.IsZero() || s.NextRun.Before(s.LastRun) {
+		t.Errorf("status after failures = %+v", s)
+	}
+
+	client.PauseSync()
+	time.Sleep(50 * time.Millisecond) // let a run in flight finish
+	before := requests.Load()
+	time.Sleep(200 * time.Millisecond)
+	if after := requests.Load(); after != before {
+		t.Errorf("paused sync made %d requests", after-before)
+	}
+	if !status().Paused {
+		t.Error("Paused = false after PauseSync")
+	}
+
+	failing.Store(false)
+	client.ResumeSync()
+	eventually(t, 5*time.Second, func() error {
+		if s := status(); s.Paused || s.LastError != "" || s.ConsecutiveFailures != 0 {
+			return fmt.Errorf("status after resume = %+v", s)
+		}
+		return nil
+	})
+	if n := pushed.Load(); n != 1 {
+		t.Errorf("background sync pushed %d entries, want 1", n)
+	}
+}
+
+func TestClient_Stats_AutoSyncOff(t *testing.T) {
+	client := newTestClient(t, Config{})
+
+	stats, err := client.Stats()
+	if err != nil {
+		t.Fatalf("Stats() error = %v", err)
+	}
+	if stats.AutoSync != nil {
+		t.Errorf("offline Stats().AutoSync = %+v, want nil", stats.AutoSync)
+	}
+	client.PauseSync() // harmless offline
+	client.ResumeSync()
+
+	for _, jitter := range []float64{-0.1, 1} {
+		cfg := Config{LocalPath: "/tmp/test.db", SyncJitter: jitter}
+		var ve *ValidationError
+		if err := cfg.Validate(); !errors.As(err, &ve) || ve.Field != "SyncJitter" {
+			t.Errorf("Validate() with SyncJitter %v = %v, want a SyncJitter ValidationError", jitter, err)
+		}
+	}
+}
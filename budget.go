@@ -0,0 +1,242 @@
+package recall
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// SyncBudget caps Engram network usage, e.g. on metered connections.
+// Zero values mean unlimited.
+type SyncBudget struct {
+	// MaxBytesPerDay limits request plus response body bytes per UTC day.
+	MaxBytesPerDay int64
+	// MaxRequestsPerHour limits the number of requests per UTC hour.
+	MaxRequestsPerHour int
+}
+
+// IsZero reports whether no limits are configured.
+func (b SyncBudget) IsZero() bool {
+	return b.MaxBytesPerDay <= 0 && b.MaxRequestsPerHour <= 0
+}
+
+// BudgetDeferral describes a request deferred because the sync budget was spent.
+type BudgetDeferral struct {
+	Reason        string    // "bytes_per_day" or "requests_per_hour"
+	Path          string    // Engram API path of the deferred request
+	BytesUsed     int64     // Bytes consumed in the current day window
+	BytesLimit    int64     // Configured MaxBytesPerDay
+	RequestsUsed  int       // Requests made in the current hour window
+	RequestsLimit int       // Configured MaxRequestsPerHour
+	RetryAfter    time.Time // Start of the next window
+}
+
+// BudgetDeferredFunc is called whenever the sync budget defers a request.
+type BudgetDeferredFunc func(BudgetDeferral)
+
+// BudgetUsage reports consumption in the current budget windows.
+type BudgetUsage struct {
+	Budget       SyncBudget `json:"budget"`
+	Day          string     `json:"day"`
+	BytesUsed    int64      `json:"bytes_used"`
+	Hour         string     `json:"hour"`
+	RequestsUsed int        `json:"requests_used"`
+}
+
+// sync_meta keys for persisted budget accounting.
+const (
+	budgetDayKey      = "budget_day"
+	budgetBytesKey    = "budget_bytes_used"
+	budgetHourKey     = "budget_hour"
+	budgetRequestsKey = "budget_requests_used"
+)
+
+// SetSyncBudget sets the sync bandwidth budget. A zero budget disables enforcement.
+func (s *Syncer) SetSyncBudget(budget SyncBudget) {
+	s.budgetMu.Lock()
+	defer s.budgetMu.Unlock()
+	s.budget = budget
+}
+
+// SetBudgetDeferredHandler sets the callback invoked when the budget defers a request.
+func (s *Syncer) SetBudgetDeferredHandler(fn BudgetDeferredFunc) {
+	s.budgetMu.Lock()
+	defer s.budgetMu.Unlock()
+	s.onBudgetDeferred = fn
+}
+
+// BudgetUsage returns consumption in the current day and hour windows.
+func (s *Syncer) BudgetUsage() (*BudgetUsage, error) {
+	s.budgetMu.Lock()
+	defer s.budgetMu.Unlock()
+
+	usage, err := s.loadBudgetUsage(s.now())
+	if err != nil {
+		return nil, err
+	}
+	usage.Budget = s.budget
+	return usage, nil
+}
+
+// reserveBudget accounts for one request before it is sent. It returns an
+// error wrapping ErrSyncBudgetExceeded if either window is exhausted.
+func (s *Syncer) reserveBudget(req *http.Request) error {
+	s.budgetMu.Lock()
+	if s.budget.IsZero() {
+		s.budgetMu.Unlock()
+		return nil
+	}
+
+	now := s.now()
+	usage, err := s.loadBudgetUsage(now)
+	if err != nil {
+		s.budgetMu.Unlock()
+		return err
+	}
+
+	var deferral *BudgetDeferral
+	switch {
+	case s.budget.MaxBytesPerDay > 0 && usage.BytesUsed >= s.budget.MaxBytesPerDay:
+		deferral = &BudgetDeferral{Reason: "bytes_per_day",
+			RetryAfter: now.UTC().Truncate(24 * time.Hour).Add(24 * time.Hour)}
+	case s.budget.MaxRequestsPerHour > 0 && usage.RequestsUsed >= s.budget.MaxRequestsPerHour:
+		deferral = &BudgetDeferral{Reason: "requests_per_hour",
+			RetryAfter: now.UTC().Truncate(time.Hour).Add(time.Hour)}
+	}
+
+	if deferral == nil {
+		usage.RequestsUsed++
+		if req.ContentLength > 0 {
+			usage.BytesUsed += req.ContentLength
+		}
+		err := s.saveBudgetUsage(usage)
+		s.budgetMu.Unlock()
+		return err
+	}
+
+	deferral.Path = req.URL.Path
+	deferral.BytesUsed, deferral.BytesLimit = usage.BytesUsed, s.budget.MaxBytesPerDay
+	deferral.RequestsUsed, deferral.RequestsLimit = usage.RequestsUsed, s.budget.MaxRequestsPerHour
+	handler := s.onBudgetDeferred
+	s.budgetMu.Unlock()
+
+	s.debug.LogSync("budget", fmt.Sprintf("deferred %s: %s exhausted until %s",
+		deferral.Path, deferral.Reason, deferral.RetryAfter.Format(time.RFC3339)))
+	if handler != nil {
+		handler(*deferral)
+	}
+	return fmt.Errorf("%w: %s limit reached, retry after %s",
+		ErrSyncBudgetExceeded, deferral.Reason, deferral.RetryAfter.Format(time.RFC3339))
+}
+
+// recordBudgetBytes adds response bytes to the current day window.
+func (s *Syncer) recordBudgetBytes(n int64) {
+	s.budgetMu.Lock()
+	defer s.budgetMu.Unlock()
+	if s.budget.IsZero() || n <= 0 {
+		return
+	}
+
+	usage, err := s.loadBudgetUsage(s.now())
+	if err != nil {
+		s.debug.LogError("budget", err)
+		return
+	}
+	usage.BytesUsed += n
+	if err := s.saveBudgetUsage(usage); err != nil {
+		s.debug.LogError("budget", err)
+	}
+}
+
+// meterResponse wraps the response body so bytes read count against the budget.
+func (s *Syncer) meterResponse(resp *http.Response) {
+	if resp == nil || resp.Body == nil {
+		return
+	}
+	s.budgetMu.Lock()
+	enabled := !s.budget.IsZero()
+	s.budgetMu.Unlock()
+	if enabled {
+		resp.Body = &meteredBody{ReadCloser: resp.Body, record: s.recordBudgetBytes}
+	}
+}
+
+// loadBudgetUsage reads persisted usage and rolls windows forward. Overspend
+// beyond a window's limit (a response larger than the remaining budget)
+// carries over into the next window instead of being forgiven.
+// Caller must hold budgetMu.
+func (s *Syncer) loadBudgetUsage(now time.Time) (*BudgetUsage, error) {
+	day := now.UTC().Format("2006-01-02")
+	hour := now.UTC().Format("2006-01-02T15")
+
+	usage := &BudgetUsage{Day: day, Hour: hour}
+	vals := make(map[string]string, 4)
+	for _, key := range []string{budgetDayKey, budgetBytesKey, budgetHourKey, budgetRequestsKey} {
+		v, err := s.store.GetSyncMeta(key)
+		if err != nil {
+			return nil, fmt.Errorf("sync budget: read %s: %w", key, err)
+		}
+		vals[key] = v
+	}
+
+	bytesUsed, _ := strconv.ParseInt(vals[budgetBytesKey], 10, 64)
+	if vals[budgetDayKey] == day {
+		usage.BytesUsed = bytesUsed
+	} else if s.budget.MaxBytesPerDay > 0 && bytesUsed > s.budget.MaxBytesPerDay {
+		usage.BytesUsed = bytesUsed - s.budget.MaxBytesPerDay
+	}
+
+	requestsUsed, _ := strconv.Atoi(vals[budgetRequestsKey])
+	if vals[budgetHourKey] == hour {
+		usage.RequestsUsed = requestsUsed
+	}
+	return usage, nil
+}
+
+// saveBudgetUsage persists usage to sync_meta. Caller must hold budgetMu.
+func (s *Syncer) saveBudgetUsage(usage *BudgetUsage) error {
+	for key, value := range map[string]string{
+		budgetDayKey:      usage.Day,
+		budgetBytesKey:    strconv.FormatInt(usage.BytesUsed, 10),
+		budgetHourKey:     usage.Hour,
+		budgetRequestsKey: strconv.Itoa(usage.RequestsUsed),
+	} {
+		if err := s.store.SetSyncMeta(key, value); err != nil {
+			return fmt.Errorf("sync budget: write %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// now returns the current time, using nowFn if set (for testing), else
+// the store's clock.
+func (s *Syncer) now() time.Time {
+	if s.nowFn != nil {
+		return s.nowFn()
+	}
+	return s.store.now()
+}
+
+// meteredBody reports the number of bytes read when closed.
+type meteredBody struct {
+	io.ReadCloser
+	n      int64
+	record func(int64)
+	done   bool
+}
+
+func (b *meteredBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.n += int64(n)
+	return n, err
+}
+
+func (b *meteredBody) Close() error {
+	if !b.done {
+		b.done = true
+		b.record(b.n)
+	}
+	return b.ReadCloser.Close()
+}
@@ -0,0 +1,147 @@
+package recall
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newBudgetServer(t *testing.T, body string) (*httptest.Server, *int) {
+	t.Helper()
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func TestSyncBudget_RequestsPerHour_Defers(t *testing.T) {
+	store := newTestStore(t)
+	server, calls := newBudgetServer(t, `{"status":"healthy"}`)
+
+	syncer := newTestSyncer(t, store, server.URL)
+	now := time.Date(2026, 3, 1, 10, 15, 0, 0, time.UTC)
+	syncer.nowFn = func() time.Time { return now }
+	syncer.SetSyncBudget(SyncBudget{MaxRequestsPerHour: 2})
+
+	var deferrals []BudgetDeferral
+	syncer.SetBudgetDeferredHandler(func(d BudgetDeferral) { deferrals = append(deferrals, d) })
+
+	for i := 0; i < 2; i++ {
+		if _, err := syncer.Health(context.Background()); err != nil {
+			t.Fatalf("Health #%d: %v", i+1, err)
+		}
+	}
+
+	_, err := syncer.Health(context.Background())
+	if !errors.Is(err, ErrSyncBudgetExceeded) {
+		t.Fatalf("err = %v, want ErrSyncBudgetExceeded", err)
+	}
+	if *calls != 2 {
+		t.Errorf("server calls = %d, want 2", *calls)
+	}
+	if len(deferrals) != 1 {
+		t.Fatalf("deferrals = %d, want 1", len(deferrals))
+	}
+	d := deferrals[0]
+	if d.Reason != "requests_per_hour" || d.Path != "/api/v1/health" || d.RequestsUsed != 2 {
+		t.Errorf("unexpected deferral: %+v", d)
+	}
+	if want := time.Date(2026, 3, 1, 11, 0, 0, 0, time.UTC); !d.RetryAfter.Equal(want) {
+		t.Errorf("RetryAfter = %v, want %v", d.RetryAfter, want)
+	}
+
+	// The next hour opens a fresh window.
+	now = now.Add(time.Hour)
+	if _, err := syncer.Health(context.Background()); err != nil {
+		t.Errorf("Health in next hour: %v", err)
+	}
+}
+
+func TestSyncBudget_BytesPerDay_CarriesOverspend(t *testing.T) {
+	store := newTestStore(t)
+	body := `{"status":"healthy","padding":"` + strings.Repeat("x", 200) + `"}`
+	server, _ := newBudgetServer(t, body)
+
+	syncer := newTestSyncer(t, store, server.URL)
+	now := time.Date(2026, 3, 1, 23, 0, 0, 0, time.UTC)
+	syncer.nowFn = func() time.Time { return now }
+	syncer.SetSyncBudget(SyncBudget{MaxBytesPerDay: 100})
+
+	// The first response overspends the day's budget.
+	if _, err := syncer.Health(context.Background()); err != nil {
+		t.Fatalf("Health: %v", err)
+	}
+	if _, err := syncer.Health(context.Background()); !errors.Is(err, ErrSyncBudgetExceeded) {
+		t.Fatalf("err = %v, want ErrSyncBudgetExceeded", err)
+	}
+
+	usage, err := syncer.BudgetUsage()
+	if err != nil {
+		t.Fatalf("BudgetUsage: %v", err)
+	}
+	spent := usage.BytesUsed
+	if spent <= 200 {
+		t.Fatalf("BytesUsed = %d, want > 200", spent)
+	}
+
+	// Overspend beyond the limit carries into the next day.
+	now = now.Add(2 * time.Hour)
+	usage, err = syncer.BudgetUsage()
+	if err != nil {
+		t.Fatalf("BudgetUsage: %v", err)
+	}
+	if usage.Day != "2026-03-02" || usage.BytesUsed != spent-100 {
+		t.Errorf("next day usage = %+v, want day 2026-03-02 with %d bytes", usage, spent-100)
+	}
+	if _, err := syncer.Health(context.Background()); !errors.Is(err, ErrSyncBudgetExceeded) {
+		t.Errorf("err = %v, want carried-over overspend to defer", err)
+	}
+}
+
+func TestSyncBudget_PersistsAcrossSyncers(t *testing.T) {
+	store := newTestStore(t)
+	server, _ := newBudgetServer(t, `{"status":"healthy"}`)
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	first := newTestSyncer(t, store, server.URL)
+	first.nowFn = func() time.Time { return now }
+	first.SetSyncBudget(SyncBudget{MaxRequestsPerHour: 1})
+	if _, err := first.Health(context.Background()); err != nil {
+		t.Fatalf("Health: %v", err)
+	}
+
+	second := newTestSyncer(t, store, server.URL)
+	second.nowFn = func() time.Time { return now }
+	second.SetSyncBudget(SyncBudget{MaxRequestsPerHour: 1})
+	if _, err := second.Health(context.Background()); !errors.Is(err, ErrSyncBudgetExceeded) {
+		t.Errorf("err = %v, want ErrSyncBudgetExceeded from persisted usage", err)
+	}
+}
+
+func TestSyncBudget_ZeroBudgetSkipsAccounting(t *testing.T) {
+	store := newTestStore(t)
+	server, _ := newBudgetServer(t, `{"status":"healthy"}`)
+
+	syncer := newTestSyncer(t, store, server.URL)
+	for i := 0; i < 5; i++ {
+		if _, err := syncer.Health(context.Background()); err != nil {
+			t.Fatalf("Health: %v", err)
+		}
+	}
+
+	v, err := store.GetSyncMeta(budgetRequestsKey)
+	if err != nil {
+		t.Fatalf("GetSyncMeta: %v", err)
+	}
+	if v != "" {
+		t.Errorf("budget_requests_used = %q, want empty", v)
+	}
+}
@@ -0,0 +1,191 @@
+package recall
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// canaryVersionRE matches an Engram API version, e.g. "v2".
+var canaryVersionRE = regexp.MustCompile(`^v[0-9]+$`)
+
+// CanaryDiscrepancy describes a canary response that differs from the
+// response of the endpoint sync trusts.
+type CanaryDiscrepancy struct {
+	Operation string `json:"operation"` // "push" or "delta"
+	Version   string `json:"version"`   // Config.SyncCanary
+	Field     string `json:"field"`     // What differed, e.g. "status", "last_sequence", "entries[3]"
+	Primary   string `json:"primary"`
+	Canary    string `json:"canary"`
+}
+
+func (d CanaryDiscrepancy) String() string {
+	return fmt.Sprintf("%s %s: %s is %s, canary %s", d.Version, d.Operation, d.Field, d.Primary, d.Canary)
+}
+
+// SetCanary shadows sync requests to the given Engram API version, e.g.
+// "v2"; see Config.SyncCanary. An empty version disables the canary.
+func (s *Syncer) SetCanary(version string) {
+	s.canary = version
+}
+
+// canaryURL returns path, an /api/v1/ path, under the canary version.
+func (s *Syncer) canaryURL(path string) string {
+	return s.engramURL + "/api/" + s.canary + strings.TrimPrefix(path, "/api/v1")
+}
+
+// shadowPush re-sends a push batch that Engram accepted to the canary
+// endpoint and compares the responses. The batch keeps its push_id, so
+// Engram recognizes it as already applied.
+func (s *Syncer) shadowPush(ctx context.Context, body []byte, primary *SyncPushResponse) {
+	if s.canary == "" {
+		return
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", s.canaryURL(s.pushPath()), bytes.NewReader(body))
+	if err != nil {
+		s.debug.LogError("canary push", err)
+		return
+	}
+	s.setHeaders(req)
+	req.Header.Set("Content-Type", "application/json")
+	if len(s.signingSecret) > 0 {
+		req.Header.Set(SignatureHeader, SignBody(s.signingSecret, body))
+	}
+
+	var canary SyncPushResponse
+	if !s.shadow(req, "push", &canary) {
+		return
+	}
+	var found []CanaryDiscrepancy
+	add := func(field string, p, c any) {
+		found = append(found, CanaryDiscrepancy{Field: field, Primary: fmt.Sprint(p), Canary: fmt.Sprint(c)})
+	}
+	if primary.Accepted != canary.Accepted {
+		add("accepted", primary.Accepted, canary.Accepted)
+	}
+	if primary.RemoteSequence != canary.RemoteSequence {
+		add("remote_sequence", primary.RemoteSequence, canary.RemoteSequence)
+	}
+	s.recordCanary("push", found)
+}
+
+// shadowDelta fetches the delta page the primary endpoint returned from
+// the canary endpoint and compares the two.
+func (s *Syncer) shadowDelta(ctx context.Context, after int64, cursor string, primary *SyncDeltaResponse) {
+	if s.canary == "" {
+		return
+	}
+	reqURL := fmt.Sprintf("%s?after=%d&limit=%d", s.canaryURL(s.deltaPath()), after, s.pageSize())
+	if cursor != "" {
+		reqURL += "&cursor=" + url.QueryEscape(cursor)
+	}
+	req, err := http.NewRequestWithContext(ctx, "GET", reqURL, nil)
+	if err != nil {
+		s.debug.LogError("canary delta", err)
+		return
+	}
+	s.setHeaders(req)
+
+	var canary SyncDeltaResponse
+	if !s.shadow(req, "delta", &canary) {
+		return
+	}
+	s.recordCanary("delta", compareDeltaPages(primary, &canary))
+}
+
+// shadow sends a canary request and decodes its response into v. It
+// reports whether v can be compared; a failed or non-200 response is
+// recorded as a "status" discrepancy, unless the request was cancelled or
+// deferred by the sync budget. The response is never trusted: it
+// cannot fail the sync, pause it for maintenance, or change the store.
+func (s *Syncer) shadow(req *http.Request, op string, v any) bool {
+	resp, err := s.send(req)
+	if err != nil {
+		if req.Context().Err() == nil && !errors.Is(err, ErrSyncBudgetExceeded) {
+			s.recordCanary(op, []CanaryDiscrepancy{{Field: "status", Primary: "200", Canary: err.Error()}})
+		}
+		return false
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		s.recordCanary(op, []CanaryDiscrepancy{{Field: "status", Primary: "200", Canary: fmt.Sprintf("%d: %s", resp.StatusCode, truncate(string(body), 200))}})
+		return false
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		s.recordCanary(op, []CanaryDiscrepancy{{Field: "body", Primary: "valid", Canary: err.Error()}})
+		return false
+	}
+	return true
+}
+
+// compareDeltaPages lists how canary differs from primary. Payloads are
+// compared as JSON values, so formatting and key order do not matter.
+func compareDeltaPages(primary, canary *SyncDeltaResponse) []CanaryDiscrepancy {
+	var found []CanaryDiscrepancy
+	add := func(field string, p, c any) {
+		found = append(found, CanaryDiscrepancy{Field: field, Primary: fmt.Sprint(p), Canary: fmt.Sprint(c)})
+	}
+	if primary.LastSequence != canary.LastSequence {
+		add("last_sequence", primary.LastSequence, canary.LastSequence)
+	}
+	if primary.LatestSequence != canary.LatestSequence {
+		add("latest_sequence", primary.LatestSequence, canary.LatestSequence)
+	}
+	if primary.HasMore != canary.HasMore {
+		add("has_more", primary.HasMore, canary.HasMore)
+	}
+	if len(primary.Entries) != len(canary.Entries) {
+		add("entries", len(primary.Entries), len(canary.Entries))
+	}
+	for i := range min(len(primary.Entries), len(canary.Entries)) {
+		p, c := primary.Entries[i], canary.Entries[i]
+		pKey := fmt.Sprintf("%d %s %s/%s", p.Sequence, p.Operation, p.TableName, p.EntityID)
+		cKey := fmt.Sprintf("%d %s %s/%s", c.Sequence, c.Operation, c.TableName, c.EntityID)
+		switch {
+		case pKey != cKey || p.SourceID != c.SourceID:
+			add(fmt.Sprintf("entries[%d]", i), pKey+" from "+p.SourceID, cKey+" from "+c.SourceID)
+		case !sameJSON(p.Payload, c.Payload):
+			add(fmt.Sprintf("entries[%d].payload", i), truncate(string(p.Payload), 200), truncate(string(c.Payload), 200))
+		}
+	}
+	return found
+}
+
+// sameJSON reports whether a and b encode the same JSON value.
+func sameJSON(a, b json.RawMessage) bool {
+	if bytes.Equal(a, b) {
+		return true
+	}
+	var av, bv any
+	if json.Unmarshal(a, &av) != nil || json.Unmarshal(b, &bv) != nil {
+		return false
+	}
+	return reflect.DeepEqual(av, bv)
+}
+
+// recordCanary counts a canary comparison and reports its discrepancies.
+// Counting is best-effort.
+func (s *Syncer) recordCanary(op string, found []CanaryDiscrepancy) {
+	deltas := map[string]int64{metricCanaryChecks: 1}
+	if len(found) > 0 {
+		deltas[metricCanaryDiscrepancies] = 1
+	}
+	if err := s.store.incrementMetrics(deltas); err != nil {
+		s.debug.LogError("record canary", err)
+	}
+	for _, d := range found {
+		d.Operation, d.Version = op, s.canary
+		s.debug.LogSync("canary", d.String())
+		s.emitSyncEvent(SyncEvent{Type: SyncEventCanaryDiscrepancy, Reason: op, Canary: &d})
+	}
+}
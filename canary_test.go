@@ -0,0 +1,128 @@
+package recall
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSyncCanary_Push(t *testing.T) {
+	store := newTestStore(t)
+	insertTestChangeLogEntries(t, store, 2)
+
+	var paths []string
+	var pushIDs []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		var req SyncPushRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		pushIDs = append(pushIDs, req.PushID)
+		resp := SyncPushResponse{Accepted: len(req.Entries), RemoteSequence: 10}
+		if strings.HasPrefix(r.URL.Path, "/api/v2/") {
+			resp.RemoteSequence = 9
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	syncer := newTestSyncer(t, store, server.URL)
+	syncer.SetCanary("v2")
+	var events []SyncEvent
+	syncer.SetSyncEventHandler(func(ev SyncEvent) { events = append(events, ev) })
+
+	result, err := syncer.SyncPush(context.Background())
+	if err != nil || result.EntriesPushed != 2 {
+		t.Fatalf("SyncPush() = %+v, %v", result, err)
+	}
+	if len(paths) != 2 || paths[0] != "/api/v1/stores/test-store/sync/push" || paths[1] != "/api/v2/stores/test-store/sync/push" {
+		t.Fatalf("paths = %v, want the v1 push then its v2 shadow", paths)
+	}
+	if pushIDs[0] != pushIDs[1] {
+		t.Errorf("shadow push_id = %s, want the batch's %s", pushIDs[1], pushIDs[0])
+	}
+	if len(events) != 1 || events[0].Type != SyncEventCanaryDiscrepancy || events[0].Canary.Field != "remote_sequence" ||
+		events[0].Canary.Primary != "10" || events[0].Canary.Canary != "9" || events[0].Canary.Operation != "push" {
+		t.Fatalf("events = %+v, want one remote_sequence discrepancy", events)
+	}
+	if m, _ := store.SyncMetrics(); m.CanaryChecks != 1 || m.CanaryDiscrepancies != 1 {
+		t.Errorf("metrics = %+v, want 1 check and 1 discrepancy", m)
+	}
+}
+
+func TestSyncCanary_Delta(t *testing.T) {
+	store := newTestStore(t)
+
+	page := SyncDeltaResponse{Entries: []DeltaEntry{{
+		Sequence: 1, TableName: "lore_entries", EntityID: "01CANARY", Operation: "upsert", SourceID: "other",
+		Payload:   json.RawMessage(`{"id":"01CANARY","content":"Canary content","category":"PATTERN_OUTCOME","confidence":0.5,"source_id":"other","created_at":"2026-01-01T00:00:00Z","updated_at":"2026-01-01T00:00:00Z"}`),
+		CreatedAt: "2026-01-01T00:00:00Z",
+	}}, LastSequence: 1, LatestSequence: 1}
+
+	canaryStatus := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/api/v2/") {
+			if canaryStatus != http.StatusOK {
+				w.WriteHeader(canaryStatus)
+				return
+			}
+			// Same page, with the payload's keys reordered
+			shadow := page
+			shadow.Entries = []DeltaEntry{page.Entries[0]}
+			shadow.Entries[0].Payload = json.RawMessage(`{"updated_at":"2026-01-01T00:00:00Z","created_at":"2026-01-01T00:00:00Z","source_id":"other","confidence":0.5,"category":"PATTERN_OUTCOME","content":"Canary content","id":"01CANARY"}`)
+			_ = json.NewEncoder(w).Encode(shadow)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(page)
+	}))
+	defer server.Close()
+
+	syncer := newTestSyncer(t, store, server.URL)
+	syncer.SetCanary("v2")
+	var events []SyncEvent
+	syncer.SetSyncEventHandler(func(ev SyncEvent) { events = append(events, ev) })
+
+	if _, err := syncer.SyncDelta(context.Background()); err != nil {
+		t.Fatalf("SyncDelta() error = %v", err)
+	}
+	if len(events) != 0 {
+		t.Errorf("events = %+v, want matching pages", events)
+	}
+
+	// A failing canary is reported but does not fail the sync
+	canaryStatus = http.StatusNotFound
+	page.Entries, page.LastSequence = nil, 1
+	if _, err := syncer.SyncDelta(context.Background()); err != nil {
+		t.Fatalf("SyncDelta() with a failing canary error = %v", err)
+	}
+	if len(events) != 1 || events[0].Canary.Field != "status" || !strings.HasPrefix(events[0].Canary.Canary, "404") {
+		t.Errorf("events = %+v, want one status discrepancy", events)
+	}
+	if m, _ := store.SyncMetrics(); m.CanaryChecks != 2 || m.CanaryDiscrepancies != 1 {
+		t.Errorf("metrics = %+v, want 2 checks and 1 discrepancy", m)
+	}
+	if _, err := store.Get("01CANARY"); err != nil {
+		t.Errorf("trusted delta entry was not applied: %v", err)
+	}
+}
+
+func TestCompareDeltaPages(t *testing.T) {
+	primary := &SyncDeltaResponse{Entries: []DeltaEntry{
+		{Sequence: 1, TableName: "lore_entries", EntityID: "a", Operation: "upsert", Payload: json.RawMessage(`{"x":1}`)},
+		{Sequence: 2, TableName: "lore_entries", EntityID: "b", Operation: "delete"},
+	}, LastSequence: 2, HasMore: true}
+	canary := &SyncDeltaResponse{Entries: []DeltaEntry{
+		{Sequence: 1, TableName: "lore_entries", EntityID: "a", Operation: "upsert", Payload: json.RawMessage(`{"x":2}`)},
+	}, LastSequence: 1}
+
+	var fields []string
+	for _, d := range compareDeltaPages(primary, canary) {
+		fields = append(fields, d.Field)
+	}
+	want := "last_sequence,has_more,entries,entries[0].payload"
+	if strings.Join(fields, ",") != want {
+		t.Errorf("fields = %v, want %s", fields, want)
+	}
+}
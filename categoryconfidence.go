@@ -0,0 +1,73 @@
+package recall
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DefaultMinConfidence is the confidence Query requires when neither
+// QueryParams.MinConfidence nor Config.CategoryMinConfidence sets one.
+const DefaultMinConfidence = 0.5
+
+// ParseCategoryMinConfidence parses a comma-separated list of
+// category=confidence pairs, e.g.
+// "EDGE_CASE_DISCOVERY=0.3,ARCHITECTURAL_DECISION=0.6", as used by
+// RECALL_CATEGORY_MIN_CONFIDENCE.
+func ParseCategoryMinConfidence(s string) (map[Category]float64, error) {
+	defaults := make(map[Category]float64)
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		category, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, &ValidationError{Field: "CategoryMinConfidence", Message: fmt.Sprintf("%q: want category=confidence", pair)}
+		}
+		v, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return nil, &ValidationError{Field: "CategoryMinConfidence", Message: fmt.Sprintf("%q: invalid confidence", pair)}
+		}
+		defaults[Category(strings.ToUpper(strings.TrimSpace(category)))] = v
+	}
+	return defaults, validateCategoryMinConfidence(defaults)
+}
+
+// validateCategoryMinConfidence rejects unknown categories and
+// confidences outside [0, 1].
+func validateCategoryMinConfidence(defaults map[Category]float64) error {
+	for category, v := range defaults {
+		if !category.IsValid() {
+			return &ValidationError{Field: "CategoryMinConfidence", Message: fmt.Sprintf("invalid category %q", category)}
+		}
+		if v < 0 || v > 1 {
+			return &ValidationError{Field: "CategoryMinConfidence", Message: fmt.Sprintf("confidence for %s must be between 0 and 1", category)}
+		}
+	}
+	return nil
+}
+
+// applyMinConfidenceDefault fills an unset params.MinConfidence with
+// DefaultMinConfidence and attaches Config.CategoryMinConfidence, whose
+// entries override it for their categories.
+func (c *Client) applyMinConfidenceDefault(params *QueryParams) {
+	if params.MinConfidence != nil {
+		return
+	}
+	defaultConfidence := DefaultMinConfidence
+	params.MinConfidence = &defaultConfidence
+	params.categoryMinConfidence = c.config.CategoryMinConfidence
+}
+
+// minConfidenceFor returns the confidence params require of lore in
+// category.
+func (p QueryParams) minConfidenceFor(category Category) float64 {
+	if v, ok := p.categoryMinConfidence[category]; ok {
+		return v
+	}
+	if p.MinConfidence != nil {
+		return *p.MinConfidence
+	}
+	return 0
+}
@@ -0,0 +1,90 @@
+package recall
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseCategoryMinConfidence(t *testing.T) {
+	defaults, err := ParseCategoryMinConfidence("edge_case_discovery=0.3, ARCHITECTURAL_DECISION=0.6")
+	if err != nil {
+		t.Fatalf("ParseCategoryMinConfidence() error = %v", err)
+	}
+	if len(defaults) != 2 || defaults[CategoryEdgeCaseDiscovery] != 0.3 || defaults[CategoryArchitecturalDecision] != 0.6 {
+		t.Errorf("ParseCategoryMinConfidence() = %v", defaults)
+	}
+
+	for _, in := range []string{"EDGE_CASE_DISCOVERY", "EDGE_CASE_DISCOVERY=low", "EDGE_CASE_DISCOVERY=1.5", "GOSSIP=0.2"} {
+		_, err := ParseCategoryMinConfidence(in)
+		var verr *ValidationError
+		if !errors.As(err, &verr) || verr.Field != "CategoryMinConfidence" {
+			t.Errorf("ParseCategoryMinConfidence(%q) error = %v, want CategoryMinConfidence ValidationError", in, err)
+		}
+	}
+}
+
+func TestQuery_CategoryMinConfidenceDefaults(t *testing.T) {
+	client := newTestClient(t, Config{
+		CategoryMinConfidence: map[Category]float64{
+			CategoryEdgeCaseDiscovery:     0.3,
+			CategoryArchitecturalDecision: 0.6,
+		},
+	})
+
+	now := time.Now().UTC()
+	for _, l := range []Lore{
+		{ID: "edge", Category: CategoryEdgeCaseDiscovery, Confidence: 0.35},
+		{ID: "arch-low", Category: CategoryArchitecturalDecision, Confidence: 0.55},
+		{ID: "arch", Category: CategoryArchitecturalDecision, Confidence: 0.65},
+		{ID: "pattern-low", Category: CategoryPatternOutcome, Confidence: 0.45},
+		{ID: "pattern", Category: CategoryPatternOutcome, Confidence: 0.5},
+	} {
+		l.Content, l.SourceID, l.CreatedAt, l.UpdatedAt = "entry "+l.ID, "test", now, now
+		l.Embedding = PackFloat32([]float32{1, 0})
+		if err := client.store.InsertLore(&l); err != nil {
+			t.Fatalf("InsertLore() error = %v", err)
+		}
+	}
+
+	ids := func(lore []Lore) map[string]bool {
+		got := make(map[string]bool, len(lore))
+		for _, l := range lore {
+			got[l.ID] = true
+		}
+		return got
+	}
+	want := map[string]bool{"edge": true, "arch": true, "pattern": true}
+
+	for name, params := range map[string]QueryParams{
+		"basic":    {Query: "q", K: 10},
+		"semantic": {Query: "q", K: 10, QueryEmbedding: []float32{1, 0}},
+	} {
+		result, err := client.Query(context.Background(), params)
+		if err != nil {
+			t.Fatalf("%s: Query() error = %v", name, err)
+		}
+		if got := ids(result.Lore); len(got) != len(want) || !got["edge"] || !got["arch"] || !got["pattern"] {
+			t.Errorf("%s: Query() = %v, want %v", name, got, want)
+		}
+	}
+
+	asOf, err := client.QueryAsOf(context.Background(), QueryParams{Query: "q", K: 10}, now.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("QueryAsOf() error = %v", err)
+	}
+	if len(asOf.Lore) != len(want) {
+		t.Errorf("QueryAsOf() returned %d entries, want %d", len(asOf.Lore), len(want))
+	}
+
+	// An explicit MinConfidence applies to every category.
+	minConf := 0.4
+	result, err := client.Query(context.Background(), QueryParams{Query: "q", K: 10, MinConfidence: &minConf})
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+	if got := ids(result.Lore); len(got) != 4 || got["edge"] {
+		t.Errorf("explicit MinConfidence: Query() = %v, want all but edge", got)
+	}
+}
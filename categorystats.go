@@ -0,0 +1,82 @@
+package recall
+
+import (
+	"context"
+	"fmt"
+)
+
+// CategoryStats gauges how reliable a category's lore is, so prompts can
+// caveat results from weak categories.
+type CategoryStats struct {
+	Category               Category `json:"category"`
+	LoreCount              int      `json:"lore_count"`
+	AverageConfidence      float64  `json:"average_confidence"`
+	AverageValidationCount float64  `json:"average_validation_count"`
+
+	// Feedback counts the helpful, incorrect and outdated feedback given
+	// through this store on the category's active entries; IncorrectRate
+	// is the share that was incorrect, 0 without feedback.
+	Feedback      int     `json:"feedback"`
+	Incorrect     int     `json:"incorrect"`
+	IncorrectRate float64 `json:"incorrect_rate"`
+}
+
+// categoryStats aggregates active lore and logged feedback by category.
+func (s *Store) categoryStats(ctx context.Context) ([]CategoryStats, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil, ErrStoreClosed
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT l.category, COUNT(*), AVG(l.confidence), AVG(l.validation_count),
+		       COALESCE(SUM(f.judged), 0), COALESCE(SUM(f.incorrect), 0)
+		FROM lore_entries l
+		LEFT JOIN (
+			SELECT lore_id, SUM(outcome IN (?1, ?2, ?3)) AS judged, SUM(outcome = ?2) AS incorrect
+			FROM feedback_log WHERE namespace = ?4 GROUP BY lore_id
+		) f ON f.lore_id = l.id
+		WHERE l.deleted_at IS NULL AND l.namespace = ?4
+		GROUP BY l.category
+		ORDER BY l.category
+	`, string(FeedbackHelpful), string(FeedbackIncorrect), string(FeedbackOutdated), s.namespace)
+	if err != nil {
+		return nil, fmt.Errorf("store: category stats: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	stats := []CategoryStats{}
+	for rows.Next() {
+		var cs CategoryStats
+		if err := rows.Scan(&cs.Category, &cs.LoreCount, &cs.AverageConfidence, &cs.AverageValidationCount,
+			&cs.Feedback, &cs.Incorrect); err != nil {
+			return nil, fmt.Errorf("store: scan category stats: %w", err)
+		}
+		if cs.Feedback > 0 {
+			cs.IncorrectRate = float64(cs.Incorrect) / float64(cs.Feedback)
+		}
+		stats = append(stats, cs)
+	}
+	return stats, rows.Err()
+}
+
+// CategoryStats returns per-category reliability aggregates for the active
+// lore, ordered by category: entry count, average confidence and
+// validation count, and the incorrect-feedback rate of the feedback given
+// through this store (see SourceReport). Categories without lore are
+// omitted.
+func (c *Client) CategoryStats(ctx context.Context) ([]CategoryStats, error) {
+	release, err := c.store.enter(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	stats, err := c.store.categoryStats(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("client: category stats: %w", err)
+	}
+	return stats, nil
+}
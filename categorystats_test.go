@@ -0,0 +1,51 @@
+package recall
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestClient_CategoryStats(t *testing.T) {
+	client := newTestClient(t, Config{SourceID: "test"})
+
+	now := time.Now().UTC()
+	for _, l := range []*Lore{
+		{ID: "p1", Category: CategoryPatternOutcome, Confidence: 0.8, ValidationCount: 4},
+		{ID: "p2", Category: CategoryPatternOutcome, Confidence: 0.4, ValidationCount: 0},
+		{ID: "t1", Category: CategoryTestingStrategy, Confidence: 0.6, ValidationCount: 1},
+	} {
+		l.Content, l.SourceID, l.CreatedAt, l.UpdatedAt = "entry "+l.ID, "test", now, now
+		if err := client.store.InsertLore(l); err != nil {
+			t.Fatalf("InsertLore() error = %v", err)
+		}
+	}
+	for _, fb := range []struct {
+		id string
+		ft FeedbackType
+	}{
+		{"p1", Helpful}, {"p2", Incorrect}, {"p2", Outdated}, {"p2", NotRelevant},
+	} {
+		if _, err := client.Feedback(fb.id, fb.ft); err != nil {
+			t.Fatalf("Feedback(%s, %s) error = %v", fb.id, fb.ft, err)
+		}
+	}
+
+	stats, err := client.CategoryStats(context.Background())
+	if err != nil {
+		t.Fatalf("CategoryStats() error = %v", err)
+	}
+	if len(stats) != 2 {
+		t.Fatalf("CategoryStats() = %+v, want 2 categories", stats)
+	}
+	p, ts := stats[0], stats[1]
+	if p.Category != CategoryPatternOutcome || p.LoreCount != 2 || p.AverageValidationCount != 2.5 {
+		t.Errorf("stats[0] = %+v", p)
+	}
+	if p.Feedback != 3 || p.Incorrect != 1 || p.IncorrectRate != 1.0/3 {
+		t.Errorf("stats[0] feedback = %d, %d, %v; want 3, 1, 1/3", p.Feedback, p.Incorrect, p.IncorrectRate)
+	}
+	if ts.Category != CategoryTestingStrategy || ts.AverageConfidence != 0.6 || ts.Feedback != 0 || ts.IncorrectRate != 0 {
+		t.Errorf("stats[1] = %+v", ts)
+	}
+}
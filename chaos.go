@@ -0,0 +1,182 @@
+package recall
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrInjectedFault is the error of an Engram request dropped by a
+// FaultInjector.
+var ErrInjectedFault = errors.New("recall: injected fault")
+
+// Fault is a failure a FaultInjector applies to one Engram request.
+type Fault string
+
+const (
+	// FaultNone lets the request through untouched.
+	FaultNone Fault = ""
+
+	// FaultDrop fails the request with ErrInjectedFault before it reaches
+	// Engram, like a network failure.
+	FaultDrop Fault = "drop"
+
+	// FaultDelay holds the request for FaultInjector.Delay first, or until
+	// its context is done.
+	FaultDelay Fault = "delay"
+
+	// FaultCorrupt sends the request but truncates and garbles the
+	// response body, like a proxy cutting the connection mid-response.
+	FaultCorrupt Fault = "corrupt"
+)
+
+// FaultCounts counts the requests a FaultInjector has seen, by fault.
+type FaultCounts struct {
+	Requests  int `json:"requests"`
+	Dropped   int `json:"dropped"`
+	Delayed   int `json:"delayed"`
+	Corrupted int `json:"corrupted"`
+}
+
+// FaultInjector injects faults into Engram requests for resilience
+// testing: set it as Config.UnsafeFaultInjection, or wrap a transport
+// with Wrap. Each request first takes the next fault from Script; once
+// the script is used up, faults are drawn at the configured rates from a
+// sequence seeded by Seed, so a run can be replayed. Never enable it
+// against a production Engram.
+//
+// A FaultInjector is safe for concurrent use and must not be copied after
+// first use.
+type FaultInjector struct {
+	DropRate    float64       // Share of requests dropped, 0–1
+	DelayRate   float64       // Share of requests delayed by Delay, 0–1
+	CorruptRate float64       // Share of responses corrupted, 0–1
+	Delay       time.Duration // How long FaultDelay holds a request
+	Seed        int64         // Seeds the random faults
+	Script      []Fault       // Faults for the first requests, in order
+
+	mu     sync.Mutex
+	rng    *rand.Rand
+	counts FaultCounts
+}
+
+// validate checks the rates and delay.
+func (f *FaultInjector) validate() error {
+	for _, r := range []struct {
+		name string
+		rate float64
+	}{{"DropRate", f.DropRate}, {"DelayRate", f.DelayRate}, {"CorruptRate", f.CorruptRate}} {
+		if r.rate < 0 || r.rate > 1 {
+			return &ValidationError{Field: "UnsafeFaultInjection." + r.name, Message: "must be between 0 and 1"}
+		}
+	}
+	if f.DropRate+f.DelayRate+f.CorruptRate > 1 {
+		return &ValidationError{Field: "UnsafeFaultInjection", Message: "rates must not add up to more than 1"}
+	}
+	if f.Delay < 0 {
+		return &ValidationError{Field: "UnsafeFaultInjection.Delay", Message: "must be non-negative"}
+	}
+	for _, fault := range f.Script {
+		switch fault {
+		case FaultNone, FaultDrop, FaultDelay, FaultCorrupt:
+		default:
+			return &ValidationError{Field: "UnsafeFaultInjection.Script", Message: fmt.Sprintf("unknown fault %q", fault)}
+		}
+	}
+	return nil
+}
+
+// Counts returns the requests seen so far, by fault.
+func (f *FaultInjector) Counts() FaultCounts {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.counts
+}
+
+// Wrap returns a transport that injects f's faults into requests sent
+// through next. A nil next wraps the default transport.
+func (f *FaultInjector) Wrap(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = newDefaultTransport()
+	}
+	return &faultTransport{faults: f, next: next}
+}
+
+// next picks the fault for the next request and counts it.
+func (f *FaultInjector) next() Fault {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var fault Fault
+	if n := f.counts.Requests; n < len(f.Script) {
+		fault = f.Script[n]
+	} else {
+		if f.rng == nil {
+			f.rng = rand.New(rand.NewSource(f.Seed))
+		}
+		switch r := f.rng.Float64(); {
+		case r < f.DropRate:
+			fault = FaultDrop
+		case r < f.DropRate+f.DelayRate:
+			fault = FaultDelay
+		case r < f.DropRate+f.DelayRate+f.CorruptRate:
+			fault = FaultCorrupt
+		}
+	}
+
+	f.counts.Requests++
+	switch fault {
+	case FaultDrop:
+		f.counts.Dropped++
+	case FaultDelay:
+		f.counts.Delayed++
+	case FaultCorrupt:
+		f.counts.Corrupted++
+	}
+	return fault
+}
+
+// faultTransport is the http.RoundTripper returned by FaultInjector.Wrap.
+type faultTransport struct {
+	faults *FaultInjector
+	next   http.RoundTripper
+}
+
+func (t *faultTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	switch t.faults.next() {
+	case FaultDrop:
+		if req.Body != nil {
+			_ = req.Body.Close()
+		}
+		return nil, fmt.Errorf("%s %s: %w", req.Method, req.URL.Path, ErrInjectedFault)
+	case FaultDelay:
+		timer := time.NewTimer(t.faults.Delay)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-req.Context().Done():
+			if req.Body != nil {
+				_ = req.Body.Close()
+			}
+			return nil, req.Context().Err()
+		}
+	case FaultCorrupt:
+		resp, err := t.next.RoundTrip(req)
+		if err != nil {
+			return resp, err
+		}
+		body, _ := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		corrupted := append(body[:len(body)/2:len(body)/2], "\x00\xff"...)
+		resp.Body = io.NopCloser(bytes.NewReader(corrupted))
+		resp.ContentLength = int64(len(corrupted))
+		resp.Header.Del("Content-Length")
+		return resp, nil
+	}
+	return t.next.RoundTrip(req)
+}
@@ -0,0 +1,197 @@
+package recall
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// chaosEngram is an Engram stub that counts the lore pushed under each
+// push_id, so tests can check retries don't duplicate entries.
+type chaosEngram struct {
+	mu     sync.Mutex
+	pushes map[string]int
+	pulls  int
+}
+
+func newChaosEngram(t *testing.T) (*chaosEngram, *httptest.Server) {
+	t.Helper()
+	e := &chaosEngram{pushes: make(map[string]int)}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		e.mu.Lock()
+		defer e.mu.Unlock()
+		switch r.URL.Path {
+		case "/api/v1/stores/test-store/sync/push":
+			var req SyncPushRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			e.pushes[req.PushID] = len(req.Entries)
+			_ = json.NewEncoder(w).Encode(SyncPushResponse{Accepted: len(req.Entries), RemoteSequence: 10})
+		case "/api/v1/stores/test-store/sync/delta":
+			e.pulls++
+			ts := time.Now().UTC().Format(time.RFC3339)
+			entries := []DeltaEntry{{
+				Sequence: 11, TableName: "lore_entries", EntityID: "01CHAOSREMOTE000000000000", Operation: "upsert",
+				Payload:  makeDeltaPayload("01CHAOSREMOTE000000000000", "remote content", "PATTERN_OUTCOME", "peer", ts, ts),
+				SourceID: "peer", CreatedAt: ts, ReceivedAt: ts,
+			}}
+			_ = json.NewEncoder(w).Encode(SyncDeltaResponse{Entries: entries, LastSequence: 11, LatestSequence: 11})
+		default:
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return e, server
+}
+
+func (e *chaosEngram) pushIDs() map[string]int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	ids := make(map[string]int, len(e.pushes))
+	for id, n := range e.pushes {
+		ids[id] = n
+	}
+	return ids
+}
+
+func newChaosClient(t *testing.T, server *httptest.Server, faults *FaultInjector) *Client {
+	t.Helper()
+	client := newTestClient(t, Config{
+		Store:                "test-store",
+		EngramURL:            server.URL,
+		APIKey:               "key",
+		SyncRetry:            RetryPolicy{MaxWait: time.Millisecond},
+		UnsafeFaultInjection: faults,
+	})
+	if _, err := client.Record("Chaos runs need a seed.", CategoryTestingStrategy); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+	return client
+}
+
+func TestChaos_DroppedPushesAreRetried(t *testing.T) {
+	engram, server := newChaosEngram(t)
+	faults := &FaultInjector{Script: []Fault{FaultDrop, FaultDrop}}
+	client := newChaosClient(t, server, faults)
+
+	result, err := client.SyncPush(context.Background())
+	if err != nil {
+		t.Fatalf("SyncPush() error = %v", err)
+	}
+	if result.EntriesPushed != 1 {
+		t.Errorf("EntriesPushed = %d, want 1", result.EntriesPushed)
+	}
+	if got := faults.Counts(); got.Dropped != 2 || got.Requests != 3 {
+		t.Errorf("Counts() = %+v, want 2 dropped of 3", got)
+	}
+	if got := engram.pushIDs(); len(got) != 1 {
+		t.Errorf("pushes = %v, want one push_id", got)
+	}
+}
+
+func TestChaos_CorruptPushResponseReusesPushID(t *testing.T) {
+	engram, server := newChaosEngram(t)
+	faults := &FaultInjector{Script: []Fault{FaultCorrupt}}
+	client := newChaosClient(t, server, faults)
+
+	if _, err := client.SyncPush(context.Background()); err == nil {
+		t.Fatal("SyncPush() with a corrupted response succeeded")
+	}
+	// Engram applied the push but we never heard; the retry must reuse
+	// the push_id so Engram can dedupe it.
+	result, err := client.SyncPush(context.Background())
+	if err != nil {
+		t.Fatalf("SyncPush() retry error = %v", err)
+	}
+	if !result.Recovered {
+		t.Error("retry was not recovered from the push journal")
+	}
+	if got := engram.pushIDs(); len(got) != 1 {
+		t.Errorf("pushes = %v, want the retry under the same push_id", got)
+	}
+}
+
+func TestChaos_CorruptDeltaLeavesStoreUnchanged(t *testing.T) {
+	engram, server := newChaosEngram(t)
+	faults := &FaultInjector{Script: []Fault{FaultCorrupt}}
+	client := newChaosClient(t, server, faults)
+
+	if _, err := client.SyncDelta(context.Background()); err == nil {
+		t.Fatal("SyncDelta() with a corrupted response succeeded")
+	}
+	if _, err := client.store.Get("01CHAOSREMOTE000000000000"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get() after corrupted delta error = %v, want ErrNotFound", err)
+	}
+
+	result, err := client.SyncDelta(context.Background())
+	if err != nil {
+		t.Fatalf("SyncDelta() retry error = %v", err)
+	}
+	if result.EntriesApplied != 1 || engram.pulls != 2 {
+		t.Errorf("EntriesApplied = %d after %d pulls, want 1 after 2", result.EntriesApplied, engram.pulls)
+	}
+}
+
+func TestChaos_DelayHonorsContext(t *testing.T) {
+	_, server := newChaosEngram(t)
+	faults := &FaultInjector{Delay: time.Minute, Script: []Fault{FaultDelay}}
+	client := newChaosClient(t, server, faults)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := client.SyncDelta(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("SyncDelta() error = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("SyncDelta() took %v, want it cut short by the context", elapsed)
+	}
+}
+
+func TestFaultInjector_SeededFaultsReplay(t *testing.T) {
+	run := func() []Fault {
+		f := &FaultInjector{DropRate: 0.3, DelayRate: 0.2, CorruptRate: 0.1, Seed: 42, Script: []Fault{FaultCorrupt}}
+		faults := make([]Fault, 50)
+		for i := range faults {
+			faults[i] = f.next()
+		}
+		return faults
+	}
+	first, second := run(), run()
+	if first[0] != FaultCorrupt {
+		t.Errorf("first fault = %q, want the scripted corrupt", first[0])
+	}
+	seen := make(map[Fault]bool)
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("fault %d = %q then %q, want the same sequence for the same seed", i, first[i], second[i])
+		}
+		seen[first[i]] = true
+	}
+	if len(seen) != 4 {
+		t.Errorf("faults seen = %v, want all four kinds in 50 draws", seen)
+	}
+}
+
+func TestConfig_Validate_UnsafeFaultInjection(t *testing.T) {
+	for name, tc := range map[string]struct {
+		faults *FaultInjector
+		field  string
+	}{
+		"negative rate":  {&FaultInjector{DropRate: -0.1}, "UnsafeFaultInjection.DropRate"},
+		"rate above one": {&FaultInjector{CorruptRate: 1.5}, "UnsafeFaultInjection.CorruptRate"},
+		"rates sum":      {&FaultInjector{DropRate: 0.6, DelayRate: 0.6}, "UnsafeFaultInjection"},
+		"negative delay": {&FaultInjector{Delay: -time.Second}, "UnsafeFaultInjection.Delay"},
+		"unknown fault":  {&FaultInjector{Script: []Fault{"explode"}}, "UnsafeFaultInjection.Script"},
+	} {
+		cfg := Config{LocalPath: "/tmp/test.db", UnsafeFaultInjection: tc.faults}
+		var ve *ValidationError
+		if err := cfg.Validate(); !errors.As(err, &ve) || ve.Field != tc.field {
+			t.Errorf("%s: Validate() = %v, want a %s ValidationError", name, err, tc.field)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package recall
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// ChecksumError is returned when a lore row no longer matches the checksum
+// recorded when it was last written, meaning the SQLite file was corrupted
+// or edited outside Recall. Extractable via errors.As(); matches
+// ErrChecksumMismatch with errors.Is().
+type ChecksumError struct {
+	LoreID   string
+	Stored   string
+	Computed string
+}
+
+func (e *ChecksumError) Error() string {
+	return fmt.Sprintf("store: lore %s fails its checksum (stored %.12s, computed %.12s)", e.LoreID, e.Stored, e.Computed)
+}
+
+// Is reports whether target is ErrChecksumMismatch.
+func (e *ChecksumError) Is(target error) bool { return target == ErrChecksumMismatch }
+
+// ChecksumFunc is called for each checksum mismatch detected.
+type ChecksumFunc func(*ChecksumError)
+
+// loreChecksum returns the checksum of a lore row's identity and content:
+// the fields Recall never changes without rewriting the checksum too.
+func loreChecksum(id, content, context string, category Category) string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{id, content, context, string(category)}, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
+
+// SetChecksumHandler sets the callback invoked for each checksum mismatch
+// detected by Get or VerifyConsistency. It runs without the store lock
+// held.
+func (s *Store) SetChecksumHandler(fn ChecksumFunc) {
+	s.onChecksum = fn
+}
+
+// verifyChecksumTx checks lore against its stored checksum. Rows written
+// before checksums existed, and not yet backfilled, are not checked.
+func verifyChecksumTx(q queryer, lore *Lore) error {
+	rows, err := q.Query(`SELECT checksum FROM lore_entries WHERE id = ?`, lore.ID)
+	if err != nil {
+		return fmt.Errorf("store: query checksum: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var stored string
+	if rows.Next() {
+		if err := rows.Scan(&stored); err != nil {
+			return fmt.Errorf("store: scan checksum: %w", err)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("store: query checksum: %w", err)
+	}
+	if stored == "" {
+		return nil
+	}
+	if computed := loreChecksum(lore.ID, lore.Content, lore.Context, lore.Category); computed != stored {
+		return &ChecksumError{LoreID: lore.ID, Stored: stored, Computed: computed}
+	}
+	return nil
+}
+
+// notifyChecksum reports mismatches to the checksum handler. Callers must
+// not hold s.mu.
+func (s *Store) notifyChecksum(mismatches ...*ChecksumError) {
+	if s.onChecksum == nil {
+		return
+	}
+	for _, m := range mismatches {
+		s.onChecksum(m)
+	}
+}
+
+// checksumMismatchesTx checks every lore row, including tombstones,
+// against its stored checksum.
+func checksumMismatchesTx(q queryer) ([]*ChecksumError, error) {
+	rows, err := q.Query(`SELECT id, content, COALESCE(context, ''), category, checksum FROM lore_entries WHERE checksum != ''`)
+	if err != nil {
+		return nil, fmt.Errorf("store: query checksums: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var mismatches []*ChecksumError
+	for rows.Next() {
+		var id, content, context, category, stored string
+		if err := rows.Scan(&id, &content, &context, &category, &stored); err != nil {
+			return nil, fmt.Errorf("store: scan checksums: %w", err)
+		}
+		if computed := loreChecksum(id, content, context, Category(category)); computed != stored {
+			mismatches = append(mismatches, &ChecksumError{LoreID: id, Stored: stored, Computed: computed})
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("store: iterate checksums: %w", err)
+	}
+	return mismatches, nil
+}
+
+// backfillChecksums computes checksums for rows written before checksums
+// were introduced.
+func (s *Store) backfillChecksums() error {
+	rows, err := s.db.Query(`SELECT id, content, COALESCE(context, ''), category FROM lore_entries WHERE checksum = ''`)
+	if err != nil {
+		return fmt.Errorf("store: backfill checksums: %w", err)
+	}
+	checksums := make(map[string]string)
+	for rows.Next() {
+		var id, content, context, category string
+		if err := rows.Scan(&id, &content, &context, &category); err != nil {
+			rows.Close()
+			return fmt.Errorf("store: backfill checksums: %w", err)
+		}
+		checksums[id] = loreChecksum(id, content, context, Category(category))
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("store: backfill checksums: %w", err)
+	}
+	if len(checksums) == 0 {
+		return nil
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("store: begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	for id, sum := range checksums {
+		if _, err := tx.Exec(`UPDATE lore_entries SET checksum = ? WHERE id = ?`, sum, id); err != nil {
+			return fmt.Errorf("store: backfill checksums: %w", err)
+		}
+	}
+	return tx.Commit()
+}
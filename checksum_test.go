@@ -0,0 +1,86 @@
+package recall
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestStore_ChecksumMismatch(t *testing.T) {
+	var mismatches []*ChecksumError
+	client := newTestClient(t, Config{
+		SourceID:           "test",
+		OnChecksumMismatch: func(e *ChecksumError) { mismatches = append(mismatches, e) },
+	})
+	s := client.store
+
+	tampered, _ := client.Record("Pin the Go toolchain in CI", CategoryDependencyBehavior)
+	intact, _ := client.Record("Cache module downloads", CategoryPerformanceInsight)
+
+	if _, err := s.Get(tampered.ID); err != nil {
+		t.Fatalf("Get() before tampering error = %v", err)
+	}
+
+	// Edit the file behind Recall's back
+	if _, err := s.db.Exec(`UPDATE lore_entries SET content = 'Never pin anything' WHERE id = ?`, tampered.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := s.Get(tampered.ID)
+	var cerr *ChecksumError
+	if !errors.As(err, &cerr) || !errors.Is(err, ErrChecksumMismatch) || cerr.LoreID != tampered.ID {
+		t.Fatalf("Get() error = %v, want a ChecksumError for %s", err, tampered.ID)
+	}
+	if _, err := s.Get(intact.ID); err != nil {
+		t.Errorf("Get(intact) error = %v", err)
+	}
+
+	report, err := s.VerifyConsistency(true)
+	if err != nil {
+		t.Fatalf("VerifyConsistency() error = %v", err)
+	}
+	if report.OK() || len(report.Issues) != 1 {
+		t.Fatalf("VerifyConsistency() issues = %+v, want one unrepaired checksum mismatch", report.Issues)
+	}
+	if issue := report.Issues[0]; issue.Kind != IssueChecksumMismatch || issue.EntityID != tampered.ID || issue.Repaired {
+		t.Errorf("issue = %+v", issue)
+	}
+	if len(mismatches) != 2 || mismatches[1].LoreID != tampered.ID {
+		t.Errorf("OnChecksumMismatch calls = %v, want one from Get and one from VerifyConsistency", mismatches)
+	}
+
+	// Rewriting the content through Recall records a new checksum
+	if _, err := s.UpdateContent(tampered.ID, "Pin the Go toolchain in CI", "test"); err != nil {
+		t.Fatalf("UpdateContent() error = %v", err)
+	}
+	if _, err := s.Get(tampered.ID); err != nil {
+		t.Errorf("Get() after UpdateContent error = %v", err)
+	}
+}
+
+func TestStore_BackfillChecksums(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lore.db")
+	s, err := NewStore(path)
+	if err != nil {
+		t.Fatalf("NewStore() error = %v", err)
+	}
+	lore, _ := s.Record(Lore{Content: "Written before checksums", Category: CategoryPatternOutcome, Confidence: 0.5})
+	if _, err := s.db.Exec(`UPDATE lore_entries SET checksum = ''`); err != nil {
+		t.Fatal(err)
+	}
+	_ = s.Close()
+
+	s, err = NewStore(path)
+	if err != nil {
+		t.Fatalf("NewStore() reopen error = %v", err)
+	}
+	defer func() { _ = s.Close() }()
+
+	var checksum string
+	if err := s.db.QueryRow(`SELECT checksum FROM lore_entries WHERE id = ?`, lore.ID).Scan(&checksum); err != nil {
+		t.Fatal(err)
+	}
+	if checksum != loreChecksum(lore.ID, lore.Content, lore.Context, lore.Category) {
+		t.Errorf("checksum = %q after reopen, want the backfilled checksum", checksum)
+	}
+}
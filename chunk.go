@@ -0,0 +1,233 @@
+package recall
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxChunkParts is the most parts Record splits content into when
+// Config.ChunkLongContent is set, so content may be up to
+// MaxChunkParts × MaxContentLength characters.
+const MaxChunkParts = 16
+
+// InsertChunks inserts parts, in order, as the linked parts of one piece of
+// chunked content sharing groupID. Each part gets its own change_log entry
+// and syncs as ordinary lore; the links are local-only.
+func (s *Store) InsertChunks(groupID string, parts []*Lore) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return ErrStoreClosed
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("store: begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	now := s.now().Format(time.RFC3339)
+	for i, part := range parts {
+		if err := s.recordLoreTx(tx, part); err != nil {
+			return err
+		}
+		_, err := tx.Exec(`INSERT INTO lore_chunks (lore_id, group_id, part, created_at) VALUES (?, ?, ?, ?)`,
+			part.ID, groupID, i+1, now)
+		if err != nil {
+			return fmt.Errorf("store: insert chunk: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("store: commit: %w", err)
+	}
+	return nil
+}
+
+// ChunkGroups returns, for each of the given lore IDs that is a part of
+// chunked content, the IDs of the live parts of its group in order.
+func (s *Store) ChunkGroups(loreIDs []string) (map[string][]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil, ErrStoreClosed
+	}
+
+	groups := make(map[string][]string)
+	if len(loreIDs) == 0 {
+		return groups, nil
+	}
+
+	placeholders := make([]string, len(loreIDs))
+	args := make([]any, 0, len(loreIDs)+1)
+	for i, id := range loreIDs {
+		placeholders[i] = "?"
+		args = append(args, id)
+	}
+	args = append(args, s.namespace)
+
+	rows, err := s.db.Query(fmt.Sprintf(`
+		SELECT c.lore_id, g.lore_id FROM lore_chunks c
+		JOIN lore_chunks g ON g.group_id = c.group_id
+		JOIN lore_entries l ON l.id = g.lore_id
+		WHERE c.lore_id IN (%s) AND l.deleted_at IS NULL AND l.namespace = ?
+		ORDER BY c.lore_id, g.part
+	`, strings.Join(placeholders, ",")), args...)
+	if err != nil {
+		return nil, fmt.Errorf("store: query chunks: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var id, part string
+		if err := rows.Scan(&id, &part); err != nil {
+			return nil, fmt.Errorf("store: scan chunk: %w", err)
+		}
+		groups[id] = append(groups[id], part)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("store: iterate chunks: %w", err)
+	}
+	return groups, nil
+}
+
+// Chunks returns the parts of the chunked content ref belongs to, in
+// order. If ref was not recorded in chunks, the result holds just ref.
+// ref may be a lore ID or a session ref (L1, L2, ...).
+func (c *Client) Chunks(ref string) ([]Lore, error) {
+	id, err := c.resolveRef(ref)
+	if err != nil {
+		return nil, err
+	}
+	groups, err := c.store.ChunkGroups([]string{id})
+	if err != nil {
+		return nil, fmt.Errorf("client: chunks: %w", err)
+	}
+	ids := groups[id]
+	if len(ids) == 0 {
+		ids = []string{id}
+	}
+
+	parts := make([]Lore, 0, len(ids))
+	for _, partID := range ids {
+		part, err := c.store.Get(partID)
+		if err != nil {
+			return nil, fmt.Errorf("client: chunks: %w", err)
+		}
+		parts = append(parts, *part)
+	}
+	return parts, nil
+}
+
+// recordChunks records content that exceeds MaxContentLength as linked
+// parts and returns the first part.
+func (c *Client) recordChunks(content string, category Category, options recordOptions, confidence float64) (*Lore, error) {
+	texts := splitContent(content, MaxContentLength)
+	if len(texts) > MaxChunkParts {
+		return nil, &ValidationError{Field: "Content", Message: fmt.Sprintf("needs %d chunks; at most %d are allowed", len(texts), MaxChunkParts)}
+	}
+
+	now := c.store.now()
+	parts := make([]*Lore, len(texts))
+	for i, text := range texts {
+		parts[i] = &Lore{
+			ID:         c.store.newID(),
+			Content:    text,
+			Category:   category,
+			Context:    options.context,
+			Confidence: confidence,
+			SourceID:   c.config.SourceID,
+			Sources:    options.sources,
+			CreatedAt:  now,
+			UpdatedAt:  now,
+		}
+	}
+	if err := c.store.InsertChunks(c.store.newID(), parts); err != nil {
+		return nil, fmt.Errorf("client: record: %w", err)
+	}
+	c.telemetry.records.Add(int64(len(parts)))
+	c.storeTokenCounts(parts...)
+	c.checkQuota(false)
+	return parts[0], nil
+}
+
+// splitContent cuts content into parts of at most maxLen bytes, preferring
+// paragraph, then line, then word boundaries. The separator stays at the
+// end of the earlier part, so the parts concatenate back to content.
+func splitContent(content string, maxLen int) []string {
+	var parts []string
+	for len(content) > maxLen {
+		cut := maxLen
+		for cut > 0 && !utf8.RuneStart(content[cut]) {
+			cut--
+		}
+		for _, sep := range []string{"\n\n", "\n", " "} {
+			if i := strings.LastIndex(content[:cut], sep); i > cut/2 {
+				cut = i + len(sep)
+				break
+			}
+		}
+		parts = append(parts, content[:cut])
+		content = content[cut:]
+	}
+	if content != "" {
+		parts = append(parts, content)
+	}
+	return parts
+}
+
+// reassembleChunks replaces the parts of chunked content in lore with one
+// entry per group, at the rank of the group's best part. The entry is the
+// group's first part with the content of all its parts. Returns the
+// reassembled lore and, for each reassembled entry, its part IDs; nil if
+// lore holds no parts.
+func (c *Client) reassembleChunks(lore []Lore, similarity map[string]float64) ([]Lore, map[string][]string, error) {
+	ids := make([]string, len(lore))
+	for i, l := range lore {
+		ids[i] = l.ID
+	}
+	groups, err := c.store.ChunkGroups(ids)
+	if err != nil || len(groups) == 0 {
+		return lore, nil, err
+	}
+
+	chunks := make(map[string][]string)
+	result := make([]Lore, 0, len(lore))
+	for _, l := range lore {
+		partIDs, ok := groups[l.ID]
+		if !ok {
+			result = append(result, l)
+			continue
+		}
+		first := partIDs[0]
+		if _, done := chunks[first]; done {
+			continue
+		}
+		chunks[first] = partIDs
+
+		var whole *Lore
+		var content strings.Builder
+		for _, partID := range partIDs {
+			part := &l
+			if partID != l.ID {
+				if part, err = c.store.Get(partID); err != nil {
+					return nil, nil, err
+				}
+			}
+			if whole == nil {
+				copied := *part
+				whole = &copied
+			}
+			content.WriteString(part.Content)
+		}
+		whole.Content = content.String()
+		if score, ok := similarity[l.ID]; ok && first != l.ID {
+			similarity[first] = score
+		}
+		result = append(result, *whole)
+	}
+	return result, chunks, nil
+}
@@ -0,0 +1,115 @@
+package recall
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSplitContent(t *testing.T) {
+	para := strings.Repeat("word ", 500) // 2500 bytes
+	content := para + "\n\n" + para + "\n\n" + strings.Repeat("é", 3000)
+	parts := splitContent(content, MaxContentLength)
+	if got := strings.Join(parts, ""); got != content {
+		t.Fatal("parts do not concatenate back to the content")
+	}
+	for i, part := range parts {
+		if part == "" || len(part) > MaxContentLength {
+			t.Errorf("part %d has %d bytes", i, len(part))
+		}
+	}
+	if !strings.HasSuffix(parts[0], "\n\n") {
+		t.Errorf("part 0 ends %q, want a paragraph break", parts[0][len(parts[0])-10:])
+	}
+
+	if parts := splitContent("short", MaxContentLength); len(parts) != 1 || parts[0] != "short" {
+		t.Errorf("splitContent(short) = %q", parts)
+	}
+}
+
+func TestClient_Record_ChunkLongContent(t *testing.T) {
+	long := strings.Repeat("Retry budgets bound the load a failing dependency sees. ", 150)
+
+	client := newTestClient(t, Config{AutoSync: false})
+
+	var ve *ValidationError
+	if _, err := client.Record(long, CategoryPatternOutcome); !errors.As(err, &ve) {
+		t.Fatalf("Record without chunking = %v, want ValidationError", err)
+	}
+
+	client.config.ChunkLongContent = true
+	first, err := client.Record(long, CategoryPatternOutcome, WithContext("resilience review"))
+	if err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+	parts, err := client.Chunks(first.ID)
+	if err != nil {
+		t.Fatalf("Chunks: %v", err)
+	}
+	if len(parts) != 3 || parts[0].ID != first.ID {
+		t.Fatalf("Chunks = %d parts starting %s, want 3 starting %s", len(parts), parts[0].ID, first.ID)
+	}
+	var joined strings.Builder
+	for _, p := range parts {
+		if p.Context != "resilience review" || len(p.Content) > MaxContentLength {
+			t.Errorf("part %s: context %q, %d chars", p.ID, p.Context, len(p.Content))
+		}
+		joined.WriteString(p.Content)
+	}
+	if joined.String() != long {
+		t.Error("parts do not concatenate back to the content")
+	}
+
+	// A short entry is its own only part.
+	short, err := client.Record("Short entry", CategoryPatternOutcome)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parts, err := client.Chunks(short.ID); err != nil || len(parts) != 1 {
+		t.Errorf("Chunks(short) = %d parts, %v", len(parts), err)
+	}
+
+	// Too many parts is rejected.
+	if _, err := client.Record(strings.Repeat("x ", MaxChunkParts*MaxContentLength), CategoryPatternOutcome); !errors.As(err, &ve) {
+		t.Errorf("Record over MaxChunkParts = %v, want ValidationError", err)
+	}
+}
+
+func TestClient_Query_ReassemblesChunks(t *testing.T) {
+	long := strings.Repeat("Idempotency keys make retried payments safe. ", 200)
+
+	client := newTestClient(t, Config{AutoSync: false, ChunkLongContent: true})
+
+	first, err := client.Record(long, CategoryPatternOutcome)
+	if err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+	other, err := client.Record("Unrelated entry", CategoryPatternOutcome)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := client.Query(context.Background(), QueryParams{K: 10})
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	if len(result.Lore) != 2 {
+		t.Fatalf("Query returned %d entries, want the group and %s", len(result.Lore), other.ID)
+	}
+	var whole *Lore
+	for i := range result.Lore {
+		if result.Lore[i].ID == first.ID {
+			whole = &result.Lore[i]
+		}
+	}
+	if whole == nil || whole.Content != long {
+		t.Fatalf("Query did not return %s reassembled", first.ID)
+	}
+	if parts := result.Chunks[first.ID]; len(parts) != 3 || parts[0] != first.ID {
+		t.Errorf("Chunks[%s] = %v, want its 3 parts", first.ID, parts)
+	}
+	if _, ok := result.Chunks[other.ID]; ok {
+		t.Errorf("Chunks lists unchunked %s", other.ID)
+	}
+}
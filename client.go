@@ -2,11 +2,11 @@ package recall
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
-
-	"github.com/oklog/ulid/v2"
 )
 
 // Client is the main interface for interacting with lore.
@@ -18,9 +18,26 @@ type Client struct {
 	config   Config
 	debug    *DebugLogger
 
+	recovered *RecoveredError
+
+	feedbackQueue *feedbackCoalescer // nil unless Config.FeedbackCoalesceWindow
+
+	telemetry     telemetryCounters
+	telemetryDone chan struct{}
+	rolloverDone  chan struct{}
+	embedDone     chan struct{}
+
+	quotaMu        sync.Mutex
+	quotaState     HealthState
+	quotaCheckedAt time.Time
+
+	autoSyncMu sync.Mutex
+	autoSync   AutoSyncStatus
+
 	mu       sync.Mutex
 	stopSync chan struct{}
 	syncDone chan struct{}
+	syncWake chan struct{}
 }
 
 // New creates a new Recall client.
@@ -31,10 +48,34 @@ func New(cfg Config) (*Client, error) {
 		return nil, err
 	}
 
-	store, err := NewStore(cfg.LocalPath)
+	var store *Store
+	var err error
+	if cfg.LazyOpen {
+		store, err = NewLazyStore(cfg.LocalPath)
+	} else {
+		store, err = NewStore(cfg.LocalPath)
+	}
+	var recovered *RecoveredError
+	if errors.As(err, &recovered) {
+		err = nil
+	}
 	if err != nil {
 		return nil, fmt.Errorf("client: %w", err)
 	}
+	if err := store.SetNamespace(cfg.Namespace); err != nil {
+		_ = store.Close()
+		return nil, fmt.Errorf("client: %w", err)
+	}
+	store.SetMaintenanceWait(cfg.MaintenanceWait)
+	store.SetClock(cfg.Clock)
+	store.SetIDGenerator(cfg.IDGenerator)
+	store.SetChecksumHandler(cfg.OnChecksumMismatch)
+	if cfg.LegalHold {
+		if err := store.SetLegalHold(true); err != nil {
+			_ = store.Close()
+			return nil, fmt.Errorf("client: %w", err)
+		}
+	}
 
 	// Create debug logger if enabled
 	debug, err := NewDebugLogger(cfg.Debug, cfg.DebugLogPath)
@@ -50,12 +91,65 @@ func New(cfg Config) (*Client, error) {
 		debug:    debug,
 		stopSync: make(chan struct{}),
 		syncDone: make(chan struct{}),
+		syncWake: make(chan struct{}, 1),
+
+		telemetryDone: make(chan struct{}),
+		rolloverDone:  make(chan struct{}),
+		embedDone:     make(chan struct{}),
+
+		recovered: recovered,
+	}
+	if recovered != nil {
+		debug.LogError("open store", recovered)
+	}
+	if cfg.FeedbackCoalesceWindow > 0 && !cfg.ConfirmFeedback {
+		c.feedbackQueue = &feedbackCoalescer{store: store, window: cfg.FeedbackCoalesceWindow}
 	}
 
 	if !cfg.IsOffline() {
 		c.syncer = NewSyncer(store, cfg.EngramURL, cfg.APIKey, cfg.SourceID)
 		c.syncer.SetStoreID(cfg.Store)
 		c.syncer.SetDebugLogger(debug)
+		c.syncer.SetCredentialRefresh(cfg.CredentialRefresh)
+		c.syncer.SetSigningSecret(cfg.SigningSecret)
+		c.syncer.SetConflictPolicy(cfg.ConflictPolicy)
+		c.syncer.SetSyncBudget(cfg.SyncBudget)
+		c.syncer.SetBudgetDeferredHandler(cfg.OnBudgetDeferred)
+		c.syncer.SetSyncEventHandler(cfg.OnSyncEvent)
+		c.syncer.SetDeltaPageSize(cfg.DeltaPageSize)
+		c.syncer.SetRetryPolicy(cfg.SyncRetry)
+		c.syncer.SetRetryHandler(cfg.OnRetry)
+		c.syncer.SetCanary(cfg.SyncCanary)
+		c.syncer.SetPauseFile(cfg.SyncPauseFile)
+		if cfg.HTTPTransport != nil {
+			c.syncer.SetTransport(cfg.HTTPTransport)
+		}
+		if cfg.UnsafeFaultInjection != nil {
+			debug.Log("unsafe fault injection enabled for Engram requests")
+			c.syncer.SetTransport(cfg.UnsafeFaultInjection.Wrap(c.syncer.client.Transport))
+		}
+	}
+
+	// Telemetry is opt-in
+	if cfg.Telemetry != nil {
+		go c.telemetryLoop()
+	} else {
+		close(c.telemetryDone)
+	}
+
+	// A rolling store whose period has ended rolls over before first use
+	if cfg.AutoRollover {
+		c.rolloverIfDue()
+		go c.rolloverLoop()
+	} else {
+		close(c.rolloverDone)
+	}
+
+	// Embed pending lore locally when a provider is configured
+	if cfg.EmbeddingProvider != nil {
+		go c.embedLoop()
+	} else {
+		close(c.embedDone)
 	}
 
 	// Start background sync if enabled
@@ -75,6 +169,8 @@ type RecordOption func(*recordOptions)
 type recordOptions struct {
 	context    string
 	confidence *float64 // nil means use default (0.5)
+	sources    []string
+	inboxID    string // approved inbox entry; see withInboxID
 }
 
 // WithContext sets the context for the lore entry.
@@ -94,6 +190,13 @@ func WithConfidence(c float64) RecordOption {
 
 // Record captures new lore with content and category.
 // Optional parameters can be provided via WithContext and WithConfidence.
+// With Config.DedupExact, an exact re-record returns the existing entry with
+// Merged set instead of inserting a duplicate. With Config.ChunkLongContent,
+// content over MaxContentLength is recorded as linked parts and the first
+// part is returned; see Chunks. With Config.ContentOverflow, such content is
+// truncated and the full text kept locally; see FullContent. With
+// Config.SummarizeContext, context over
+// MaxContextLength is compressed by Config.Summarizer; see ContextSummary.
 func (c *Client) Record(content string, category Category, opts ...RecordOption) (*Lore, error) {
 	// Apply options
 	options := recordOptions{}
@@ -101,19 +204,42 @@ func (c *Client) Record(content string, category Category, opts ...RecordOption)
 		opt(&options)
 	}
 
+	submitted := content
+	content, normalized := c.normalize(content)
+
 	// Validate inputs (fail fast)
 	if content == "" {
 		return nil, &ValidationError{Field: "Content", Message: "cannot be empty"}
 	}
-	if len(content) > MaxContentLength {
+	if len(content) > MaxContentLength && !c.config.ChunkLongContent && !c.config.ContentOverflow {
 		return nil, &ValidationError{Field: "Content", Message: "exceeds 4000 character limit"}
 	}
+	if len(content) > MaxAttachmentSize && c.config.ContentOverflow {
+		return nil, &ValidationError{Field: "Content", Message: fmt.Sprintf("exceeds %d byte overflow limit", MaxAttachmentSize)}
+	}
+	contextSummary, err := c.summarizeContext(&options)
+	if err != nil {
+		return nil, err
+	}
 	if len(options.context) > MaxContextLength {
 		return nil, &ValidationError{Field: "Context", Message: "exceeds 1000 character limit"}
 	}
 	if !category.IsValid() {
 		return nil, &ValidationError{Field: "Category", Message: "invalid: must be one of " + validCategoriesString()}
 	}
+	for _, source := range options.sources {
+		if source == "" || strings.Contains(source, ",") {
+			return nil, &ValidationError{Field: "Sources", Message: "must be non-empty and must not contain commas"}
+		}
+	}
+	if err := c.authorize(ActionRecord, category); err != nil {
+		return nil, err
+	}
+	release, err := c.store.enter(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	defer release()
 
 	// Validate confidence if provided
 	confidence := ConfidenceDefault
@@ -124,24 +250,73 @@ func (c *Client) Record(content string, category Category, opts ...RecordOption)
 		confidence = *options.confidence
 	}
 
+	if c.config.Inbox && options.inboxID == "" {
+		return c.stage(content, category, options, confidence)
+	}
+
+	var overflow string
+	if len(content) > MaxContentLength && c.config.ContentOverflow {
+		overflow, content = content, truncateText(content, MaxContentLength)
+	}
+	if len(content) > MaxContentLength {
+		first, err := c.recordChunks(content, category, options, confidence)
+		if err == nil {
+			c.preserveOriginal(first, submitted, normalized)
+			c.keepContextSummary(first, contextSummary)
+		}
+		return first, err
+	}
+
 	// Build lore entry
-	now := time.Now().UTC()
+	now := c.store.now()
+	id := options.inboxID
+	if id == "" {
+		id = c.store.newID()
+	}
 	lore := &Lore{
-		ID:         ulid.Make().String(),
+		ID:         id,
 		Content:    content,
 		Category:   category,
 		Context:    options.context,
 		Confidence: confidence,
 		SourceID:   c.config.SourceID,
+		Sources:    options.sources,
 		CreatedAt:  now,
 		UpdatedAt:  now,
 	}
 
 	// Atomically insert lore + sync queue entry
-	if err := c.store.InsertLore(lore); err != nil {
-		return nil, fmt.Errorf("client: record: %w", err)
+	if !c.config.DedupExact {
+		if err := c.store.InsertLore(lore); err != nil {
+			return nil, fmt.Errorf("client: record: %w", err)
+		}
+		c.telemetry.records.Add(1)
+		c.storeTokenCounts(lore)
+		c.preserveOriginal(lore, submitted, normalized)
+		c.keepContextSummary(lore, contextSummary)
+		if overflow != "" {
+			c.keepOverflow(lore, overflow)
+		}
+		c.checkQuota(false)
+		return lore, nil
 	}
 
+	existing, err := c.store.InsertLoreUnique(lore)
+	if err != nil {
+		return nil, fmt.Errorf("client: record: %w", err)
+	}
+	if existing != nil {
+		existing.Merged = true
+		return existing, nil
+	}
+	c.telemetry.records.Add(1)
+	c.storeTokenCounts(lore)
+	c.preserveOriginal(lore, submitted, normalized)
+	c.keepContextSummary(lore, contextSummary)
+	if overflow != "" {
+		c.keepOverflow(lore, overflow)
+	}
+	c.checkQuota(false)
 	return lore, nil
 }
 
@@ -178,59 +353,219 @@ func (c *Client) RecordLegacy(ctx context.Context, params RecordParams) (*Lore,
 //     ranking results by cosine similarity to the query vector.
 //   - If QueryEmbedding is empty: falls back to basic filtering by category
 //     and confidence, returning results in creation order.
+//
+// With Config.EmbeddingProvider, an empty QueryEmbedding is first filled by
+// embedding Query, except in keyword mode. If the provider fails, the query
+// runs without an embedding; only semantic mode returns the error.
+//
+// CategoryQuotas, if set, reserves slots per category from the ranking and
+// backfills any shortfall with the best remaining matches.
+//
+// Fields left unset are first filled from Config.QueryProfile.
 func (c *Client) Query(ctx context.Context, params QueryParams) (*QueryResult, error) {
-	// Set defaults only when both K and MinConfidence are unset
+	if params.MaxLatency < 0 {
+		return nil, &ValidationError{Field: "MaxLatency", Message: "cannot be negative"}
+	}
+	params.Deadline = queryDeadline(params, time.Now())
+
+	if c.config.QueryProfile != nil {
+		params = c.config.QueryProfile.Apply(params)
+	}
+	if params.MinSimilarity != nil && (*params.MinSimilarity < -1 || *params.MinSimilarity > 1) {
+		return nil, &ValidationError{Field: "MinSimilarity", Message: "must be between -1 and 1"}
+	}
+	if err := c.embedQuery(ctx, &params); err != nil {
+		return nil, err
+	}
+	if err := validateQueryMode(params); err != nil {
+		return nil, err
+	}
+	if err := validateSampling(params); err != nil {
+		return nil, err
+	}
+	if err := applyWorkingContext(ctx, &params); err != nil {
+		return nil, err
+	}
+
+	// The embedding provider is called above and Engram below without
+	// holding the maintenance gate, so a slow one cannot stall Maintain
+	release, err := c.store.enter(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { release() }()
+
+	quotaTotal, err := validateCategoryQuotas(params.CategoryQuotas)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set defaults only when both K and MinConfidence are unset.
+	// With quotas, K defaults to the quota total.
 	if params.K == 0 {
 		params.K = 5
+		if quotaTotal > 0 {
+			params.K = quotaTotal
+		}
+	}
+	if quotaTotal > params.K {
+		return nil, &ValidationError{Field: "CategoryQuotas", Message: fmt.Sprintf("total %d exceeds K (%d)", quotaTotal, params.K)}
 	}
-	if params.MinConfidence == nil {
-		defaultConfidence := 0.5
-		params.MinConfidence = &defaultConfidence
+	c.applyMinConfidenceDefault(&params)
+
+	// Quotas select from the full ranking rather than the top K, and
+	// sampling from a pool past it
+	rankParams := params
+	if quotaTotal > 0 {
+		rankParams.K = 0
+	} else if params.Temperature > 0 {
+		rankParams.K = params.K * samplePoolFactor
 	}
 
 	var lore []Lore
-	var err error
-
-	if len(params.QueryEmbedding) > 0 {
-		lore, err = c.queryWithSimilarity(params)
-	} else {
+	var stats QueryStats
+	var similarity map[string]float64
+
+	switch {
+	case params.Mode == QueryModeKeyword:
+		lore, err = c.queryKeyword(rankParams, &stats)
+	case params.Mode == QueryModeHybrid:
+		lore, similarity, err = c.queryHybrid(rankParams, &stats)
+	case len(params.QueryEmbedding) > 0:
+		lore, similarity, err = c.queryWithSimilarity(rankParams, &stats)
+	default:
 		// No embedding provided, fall back to basic query
-		lore, err = c.store.Query(params)
+		start := time.Now()
+		lore, err = c.store.Query(rankParams)
+		stats.FilterTime = time.Since(start)
 		if err != nil {
 			return nil, fmt.Errorf("client: query: %w", err)
 		}
+		superseded, err := c.supersededIDs(lore)
+		if err != nil {
+			return nil, fmt.Errorf("client: query: %w", err)
+		}
+		if rankParams.HideSuperseded {
+			lore = withoutSuperseded(lore, superseded)
+		}
+		stats.countMatches(lore)
 
 		// Apply K limit (basic query doesn't rank by similarity)
-		if params.K > 0 && len(lore) > params.K {
-			lore = lore[:params.K]
+		start = time.Now()
+		c.rankByTrust(lore, c.feedbackAffinity())
+		rankSupersededLast(lore, superseded)
+		if rankParams.K > 0 && len(lore) > rankParams.K {
+			lore = lore[:rankParams.K]
 		}
+		stats.RankTime = time.Since(start)
 	}
 	if err != nil {
 		return nil, err
 	}
+	if !stats.Partial && c.needsRemoteFallback(params, lore, similarity) {
+		release()
+		release = func() {}
+		lore, similarity = c.remoteFallback(ctx, params, rankParams.K, lore, similarity, &stats)
+		relock, err := c.store.enter(ctx)
+		if err != nil {
+			return nil, err
+		}
+		release = relock
+	}
+	if params.MinSimilarity != nil && len(params.QueryEmbedding) > 0 && params.usesSimilarity() {
+		lore, stats.BelowSimilarity = aboveSimilarity(lore, similarity, *params.MinSimilarity)
+	}
+
+	if params.Temperature > 0 {
+		start := time.Now()
+		if pool := params.K * samplePoolFactor; len(lore) > pool {
+			lore = lore[:pool]
+		}
+		stats.SampledFrom = len(lore)
+		lore = sampleLore(lore, similarity, params.Temperature, sampleRand(params))
+		if quotaTotal == 0 && len(lore) > params.K {
+			lore = lore[:params.K]
+		}
+		stats.RankTime += time.Since(start)
+	}
+	if quotaTotal > 0 {
+		start := time.Now()
+		lore = applyCategoryQuotas(lore, params.CategoryQuotas, params.K)
+		stats.RankTime += time.Since(start)
+	}
+
+	lore, chunks, err := c.reassembleChunks(lore, similarity)
+	if err != nil {
+		return nil, fmt.Errorf("client: query: %w", err)
+	}
+	if err := c.setFreshness(lore); err != nil {
+		return nil, fmt.Errorf("client: query: %w", err)
+	}
 
 	// Track in session for feedback
 	refs := make(map[string]string)
+	ids := make([]string, 0, len(lore))
 	for _, l := range lore {
 		ref := c.session.Track(l.ID)
 		refs[ref] = l.ID
+		ids = append(ids, l.ID)
 	}
 
-	return &QueryResult{Lore: lore, SessionRefs: refs}, nil
+	// Usage stats are best-effort; a failed write must not fail the query
+	if err := c.store.RecordUsage(ids); err != nil {
+		c.debug.LogError("record usage", err)
+	}
+
+	c.telemetry.queries.Add(1)
+
+	result := &QueryResult{Lore: lore, SessionRefs: refs, Stats: stats, Chunks: chunks}
+	result.SupersededBy, err = c.supersededIDs(lore)
+	if err != nil {
+		return nil, fmt.Errorf("client: query: %w", err)
+	}
+	result.Episodes, err = c.episodeIDs(lore)
+	if err != nil {
+		return nil, fmt.Errorf("client: query: %w", err)
+	}
+	result.Protected, err = c.protectedFloors(lore)
+	if err != nil {
+		return nil, fmt.Errorf("client: query: %w", err)
+	}
+	result.NeedsValidation = c.needsValidationIDs(lore)
+	result.TokenCounts, result.TotalTokens = c.tokenCounts(lore, chunks)
+	if params.Explain {
+		result.Explanations = c.explain(lore, similarity, stats.RoutedCategory, result.SupersededBy)
+	}
+	if params.IncludeNotes {
+		result.Notes, err = c.store.NotesFor(ids)
+		if err != nil {
+			return nil, fmt.Errorf("client: query: %w", err)
+		}
+	}
+	if c.config.QueryLog {
+		c.logQuery(params, lore, similarity)
+	}
+	return result, nil
 }
 
 // queryWithSimilarity performs semantic similarity search using the query embedding.
 // It retrieves candidates matching filters, then ranks them by cosine similarity.
-func (c *Client) queryWithSimilarity(params QueryParams) ([]Lore, error) {
+// Scores are weighted by Config.SourceTrust and category routing. Match counts and phase timings
+// are recorded in stats. Also returns the unweighted similarity of each
+// returned entry.
+func (c *Client) queryWithSimilarity(params QueryParams, stats *QueryStats) ([]Lore, map[string]float64, error) {
 	// Get all lore with embeddings that match filters
-	lore, err := c.store.QueryWithEmbeddings(params)
+	start := time.Now()
+	lore, err := c.queryEmbeddings(params)
+	stats.FilterTime = time.Since(start)
 	if err != nil {
-		return nil, fmt.Errorf("client: query: %w", err)
+		return nil, nil, fmt.Errorf("client: query: %w", err)
 	}
 
 	// Convert to candidates for similarity search
 	candidates := make([]CandidateLore, 0, len(lore))
 	loreByID := make(map[string]Lore, len(lore))
+	matched := make([]Lore, 0, len(lore))
 	for _, l := range lore {
 		if len(l.Embedding) > 0 {
 			embedding := UnpackFloat32(l.Embedding)
@@ -240,22 +575,72 @@ func (c *Client) queryWithSimilarity(params QueryParams) ([]Lore, error) {
 					Embedding: embedding,
 				})
 				loreByID[l.ID] = l
+				matched = append(matched, l)
 			}
 		}
 	}
+	superseded, err := c.supersededIDs(matched)
+	if err != nil {
+		return nil, nil, fmt.Errorf("client: query: %w", err)
+	}
+	if params.HideSuperseded && superseded != nil {
+		matched = withoutSuperseded(matched, superseded)
+		kept := candidates[:0]
+		for _, cand := range candidates {
+			if _, ok := superseded[cand.ID]; !ok {
+				kept = append(kept, cand)
+			}
+		}
+		candidates = kept
+		superseded = nil
+	}
+	stats.countMatches(matched)
+
+	var routed Category
+	if c.routingEnabled(params) {
+		routed, _ = routeCategory(params.QueryEmbedding, candidates, loreByID)
+		stats.RoutedCategory = routed
+	}
+
+	// Perform similarity search. Trust, routing, feedback affinity and
+	// supersession can reorder entries, so weighting needs every score
+	// before the top K is taken.
+	affinity := c.feedbackAffinity()
+	k := params.K
+	if len(c.config.SourceTrust) > 0 || routed != "" || superseded != nil || affinity != nil {
+		k = 0
+	}
+	start = time.Now()
+	scored, n := c.searchWithin(params.QueryEmbedding, candidates, k, params.Deadline)
+	stats.ScoreTime = time.Since(start)
+	if n < len(candidates) {
+		stats.Partial = true
+		stats.Scored = n
+	}
 
-	// Perform similarity search
-	scored := c.searcher.Search(params.QueryEmbedding, candidates, params.K)
+	similarity := make(map[string]float64, len(scored))
+	for _, s := range scored {
+		similarity[s.ID] = s.Score
+	}
 
-	// Rebuild lore slice in similarity order
+	// Rebuild lore slice in score order
+	start = time.Now()
+	c.weightByTrust(scored, loreByID)
+	weightByCategory(scored, loreByID, routed)
+	weightByAffinity(scored, affinity)
+	demoteSuperseded(scored, superseded)
+	if params.K > 0 && len(scored) > params.K {
+		scored = scored[:params.K]
+	}
 	result := make([]Lore, 0, len(scored))
 	for _, s := range scored {
 		if l, ok := loreByID[s.ID]; ok {
 			result = append(result, l)
 		}
 	}
+	stats.RankTime = time.Since(start)
 
-	return result, nil
+	return result, similarity, nil
 }
 
 // Feedback applies feedback to a single lore entry, adjusting its confidence.
@@ -267,47 +652,88 @@ func (c *Client) queryWithSimilarity(params QueryParams) ([]Lore, error) {
 // Confidence adjustments:
 //   - Helpful:     +0.08
 //   - Incorrect:   -0.15
+//   - Outdated:    -0.30 (see ReplaceOutdated)
 //   - NotRelevant:  0.00 (unchanged)
 //
 // Returns the updated Lore entry with new confidence value.
 // Returns ErrNotFound if:
 //   - L-ref does not exist in the current session
 //   - Lore ID does not exist in the store
+//
+// Returns ErrFeedbackSkipped, leaving the entry unchanged, if the actor
+// gave it the same feedback within Config.FeedbackWindow.
+//
+// With Config.FeedbackCoalesceWindow, Feedback waits for the window's
+// batch to be applied before returning.
 func (c *Client) Feedback(ref string, ft FeedbackType) (*Lore, error) {
-	var loreID string
-
-	if isLRef(ref) {
-		// Try direct resolve first
-		id, ok := c.session.Resolve(ref)
-		if !ok {
-			// Try fuzzy match as fallback
-			contentLookup := func(id string) string {
-				lore, err := c.store.Get(id)
-				if err != nil {
-					return ""
-				}
-				return lore.Content
-			}
-			id, ok = c.session.FuzzyMatch(ref, contentLookup)
-			if !ok {
-				return nil, ErrNotFound
-			}
-		}
-		loreID = id
-	} else {
-		// Assume it's a lore ID - validate it exists
-		loreID = ref
+	if err := c.authorize(ActionFeedback, ""); err != nil {
+		return nil, err
+	}
+	release, err := c.store.enter(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	loreID, err := c.resolveRef(ref)
+	if err != nil {
+		return nil, err
+	}
+	event, err := c.claimFeedback(loreID, ft)
+	if err != nil {
+		return nil, fmt.Errorf("client: feedback: %w", err)
 	}
 
-	delta := feedbackDelta(ft)
-	isHelpful := ft == Helpful
-	lore, err := c.store.ApplyFeedback(loreID, delta, isHelpful)
+	var lore *Lore
+	switch {
+	case c.config.ConfirmFeedback:
+		lore, err = c.confirmFeedback(loreID, ft)
+	case c.feedbackQueue != nil:
+		// Logged with the rest of the window
+		lore, err = c.feedbackQueue.apply(loreID, ft, c.actor(), c.actorLevel())
+	default:
+		lore, err = c.store.applyFeedback(loreID, feedbackDelta(ft), ft == Helpful, c.actorLevel())
+	}
 	if err != nil {
+		if event != 0 {
+			c.store.releaseFeedback(event)
+		}
 		return nil, fmt.Errorf("client: feedback: %w", err)
 	}
+	if c.feedbackQueue == nil {
+		if err := c.store.logFeedback(loreID, ft, c.actor()); err != nil {
+			c.debug.LogError("feedback log", err)
+		}
+	}
+	c.telemetry.feedback.Add(1)
 	return lore, nil
 }
 
+// resolveRef maps a session ref (L1, L2, ...) to a lore ID, falling back to a
+// fuzzy match against session content. Any other ref is assumed to be a lore ID.
+func (c *Client) resolveRef(ref string) (string, error) {
+	if !isLRef(ref) {
+		return ref, nil
+	}
+
+	// Try direct resolve first
+	if id, ok := c.session.Resolve(ref); ok {
+		return id, nil
+	}
+
+	// Try fuzzy match as fallback
+	contentLookup := func(id string) string {
+		lore, err := c.store.Get(id)
+		if err != nil {
+			return ""
+		}
+		return lore.Content
+	}
+	if id, ok := c.session.FuzzyMatch(ref, contentLookup); ok {
+		return id, nil
+	}
+	return "", ErrNotFound
+}
+
 // isLRef returns true if ref matches L-ref format (L followed by digits).
 func isLRef(ref string) bool {
 	if len(ref) < 2 || ref[0] != 'L' {
@@ -324,7 +750,19 @@ func isLRef(ref string) bool {
 // FeedbackBatch provides batch feedback on recalled lore.
 // Deprecated: Use Feedback() for single-entry feedback.
 func (c *Client) FeedbackBatch(ctx context.Context, params FeedbackParams) (*FeedbackResult, error) {
-	return c.store.ApplyFeedbackBatch(c.session, params)
+	if err := c.authorize(ActionFeedback, ""); err != nil {
+		return nil, err
+	}
+	release, err := c.store.enter(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	result, err := c.store.applyFeedbackBatch(c.session, params, c.feedbackDedup(), c.actor(), c.actorLevel())
+	if err == nil {
+		c.telemetry.feedback.Add(1)
+	}
+	return result, err
 }
 
 // GetSessionLore returns all lore surfaced this session.
@@ -361,7 +799,29 @@ func (c *Client) Sync(ctx context.Context) error {
 	if c.syncer == nil {
 		return ErrOffline
 	}
-	return c.syncer.Sync(ctx)
+	err := c.sync(ctx)
+	c.telemetry.countSync(err)
+	return err
+}
+
+// sync reconciles feedback queued under Config.ConfirmFeedback, then
+// pushes and pulls.
+func (c *Client) sync(ctx context.Context) error {
+	release, err := c.store.enter(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	if c.config.ConfirmFeedback {
+		if _, err := c.reconcileFeedback(ctx); err != nil {
+			return fmt.Errorf("client: reconcile feedback: %w", err)
+		}
+	}
+	err = c.syncer.Sync(ctx)
+	c.replayAfterPush(ctx)
+	c.checkQuota(true)
+	return err
 }
 
 // SyncPush pushes pending lore to Engram.
@@ -370,7 +830,17 @@ func (c *Client) SyncPush(ctx context.Context) (*PushResult, error) {
 	if c.syncer == nil {
 		return nil, ErrOffline
 	}
-	return c.syncer.SyncPush(ctx)
+	release, err := c.store.enter(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	result, err := c.syncer.SyncPush(ctx)
+	if err == nil {
+		c.replayAfterPush(ctx)
+	}
+	c.telemetry.countSync(err)
+	return result, err
 }
 
 // SyncPull pulls updates from Engram.
@@ -379,7 +849,13 @@ func (c *Client) SyncPull(ctx context.Context) error {
 	if c.syncer == nil {
 		return ErrOffline
 	}
-	_, err := c.syncer.SyncDelta(ctx)
+	release, err := c.store.enter(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+	_, err = c.syncer.SyncDelta(ctx)
+	c.telemetry.countSync(err)
 	return err
 }
 
@@ -394,7 +870,23 @@ func (c *Client) SyncDelta(ctx context.Context) (*DeltaResult, error) {
 	if c.syncer == nil {
 		return nil, ErrOffline
 	}
-	return c.syncer.SyncDelta(ctx)
+	release, err := c.store.enter(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	result, err := c.syncer.SyncDelta(ctx)
+	c.telemetry.countSync(err)
+	return result, err
+}
+
+// Diff compares the local store with Engram without modifying either side.
+// Returns ErrOffline if Engram is not configured.
+func (c *Client) Diff(ctx context.Context) (*DiffResult, error) {
+	if c.syncer == nil {
+		return nil, ErrOffline
+	}
+	return c.syncer.Diff(ctx)
 }
 
 // Bootstrap downloads a full snapshot from Engram and replaces the local lore.
@@ -409,9 +901,15 @@ func (c *Client) SyncDelta(ctx context.Context) (*DeltaResult, error) {
 //  4. Atomically replaces local lore (preserves data on failure)
 //  5. Updates metadata (embedding_model, last_sync)
 //
+// The replace and metadata updates run under Store.Exclusive, so concurrent
+// operations see the store either before or after them.
+//
 // Returns ErrOffline if Engram is not configured.
 // Returns ErrModelMismatch if local embedding model differs from remote.
 func (c *Client) Bootstrap(ctx context.Context) error {
+	if err := c.authorize(ActionReinit, ""); err != nil {
+		return err
+	}
 	if c.syncer == nil {
 		return ErrOffline
 	}
@@ -428,14 +926,21 @@ func (c *Client) Bootstrap(ctx context.Context) error {
 //
 // Returns ErrPendingSyncExists if unsynced local changes exist.
 // Returns ErrOffline if Engram is not configured and opts.AllowEmpty is false.
+//
+// Like Bootstrap, Reinitialize downloads the snapshot first and takes
+// Store.Exclusive only for the replace: it waits for in-flight operations
+// to finish, and operations started meanwhile queue behind it (see
+// Config.MaintenanceWait), so none sees a partly replaced store. Pending
+// changes are checked again under the lock, so none recorded during the
+// download is lost.
 func (c *Client) Reinitialize(ctx context.Context, opts ReinitOptions) (*ReinitResult, error) {
-	// 1. Check for pending sync entries
-	pendingCount, err := c.store.HasPendingSync()
-	if err != nil {
-		return nil, fmt.Errorf("reinit: check pending sync: %w", err)
+	if err := c.authorize(ActionReinit, ""); err != nil {
+		return nil, err
 	}
-	if pendingCount > 0 {
-		return nil, ErrPendingSyncExists
+
+	// 1. Check for pending sync entries
+	if err := c.checkNoPendingSync(); err != nil {
+		return nil, err
 	}
 
 	// 2. Check if we're in offline mode
@@ -444,15 +949,17 @@ func (c *Client) Reinitialize(ctx context.Context, opts ReinitOptions) (*ReinitR
 			return nil, ErrOffline
 		}
 		// Create empty database
-		return c.reinitEmpty()
+		return c.reinitEmpty(ctx)
 	}
 
 	// 3. Try to bootstrap from Engram
-	err = c.syncer.Bootstrap(ctx)
-	if err != nil {
+	if err := c.syncer.bootstrap(ctx, c.checkNoPendingSync); err != nil {
+		if errors.Is(err, ErrPendingSyncExists) {
+			return nil, err
+		}
 		// Check if Engram is unreachable and we're allowed to create empty
 		if opts.AllowEmpty {
-			return c.reinitEmpty()
+			return c.reinitEmpty(ctx)
 		}
 		return nil, fmt.Errorf("reinit: bootstrap: %w", err)
 	}
@@ -466,28 +973,135 @@ func (c *Client) Reinitialize(ctx context.Context, opts ReinitOptions) (*ReinitR
 	return &ReinitResult{
 		Source:    "engram",
 		LoreCount: stats.LoreCount,
-		Timestamp: time.Now().UTC(),
+		Timestamp: c.store.now(),
 	}, nil
 }
 
-// reinitEmpty creates an empty database by clearing all lore entries.
-func (c *Client) reinitEmpty() (*ReinitResult, error) {
-	// Clear the database by replacing with an empty snapshot
-	// Use ReplaceFromSnapshot with an empty reader to trigger the clear
-	if err := c.store.ClearAllLore(); err != nil {
-		return nil, fmt.Errorf("reinit: clear lore: %w", err)
+// checkNoPendingSync returns ErrPendingSyncExists if the change_log holds
+// unsynced entries.
+func (c *Client) checkNoPendingSync() error {
+	pendingCount, err := c.store.HasPendingSync()
+	if err != nil {
+		return fmt.Errorf("reinit: check pending sync: %w", err)
+	}
+	if pendingCount > 0 {
+		return ErrPendingSyncExists
+	}
+	return nil
+}
+
+// reinitEmpty creates an empty database by clearing all lore entries,
+// under Store.Exclusive.
+func (c *Client) reinitEmpty(ctx context.Context) (*ReinitResult, error) {
+	err := c.store.Exclusive(ctx, func(context.Context) error {
+		if err := c.checkNoPendingSync(); err != nil {
+			return err
+		}
+		if err := c.store.ClearAllLore(); err != nil {
+			return fmt.Errorf("reinit: clear lore: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return &ReinitResult{
 		Source:    "empty",
 		LoreCount: 0,
-		Timestamp: time.Now().UTC(),
+		Timestamp: c.store.now(),
 	}, nil
 }
 
 // Stats returns store statistics.
 func (c *Client) Stats() (*StoreStats, error) {
-	return c.store.Stats()
+	stats, err := c.store.Stats()
+	if err != nil {
+		return nil, err
+	}
+	c.applyQuota(stats)
+	if c.syncer != nil && c.config.AutoSync {
+		status := c.autoSyncStatus()
+		stats.AutoSync = &status
+	}
+	return stats, nil
+}
+
+// BudgetUsage returns sync budget consumption in the current windows.
+// Returns ErrOffline if Engram is not configured.
+func (c *Client) BudgetUsage() (*BudgetUsage, error) {
+	if c.syncer == nil {
+		return nil, ErrOffline
+	}
+	return c.syncer.BudgetUsage()
+}
+
+// Maintain garbage-collects dead embedding blobs and compacts the local
+// database. It runs under Store.Exclusive, like Reinitialize; ctx bounds
+// the wait for in-flight operations to drain.
+func (c *Client) Maintain(ctx context.Context) (*MaintenanceResult, error) {
+	var result *MaintenanceResult
+	err := c.store.Exclusive(ctx, func(context.Context) error {
+		var err error
+		result, err = c.store.Maintain()
+		return err
+	})
+	return result, err
+}
+
+// Conflicts returns sync conflicts recorded under ConflictPolicyReview.
+// If includeResolved is false, only unresolved conflicts are returned.
+func (c *Client) Conflicts(includeResolved bool) ([]SyncConflict, error) {
+	return c.store.ListConflicts(includeResolved)
+}
+
+// ResolveConflict resolves a recorded sync conflict.
+// For ConflictResolutionMerged, merged supplies the edited version; its
+// content, context, category, and confidence replace the local entry.
+// The resolution is queued for push so Engram receives the winning state.
+func (c *Client) ResolveConflict(id int64, resolution ConflictResolution, merged *Lore) (*Lore, error) {
+	if err := c.authorize(ActionResolve, ""); err != nil {
+		return nil, err
+	}
+	conflict, err := c.store.GetConflict(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var winner Lore
+	switch resolution {
+	case ConflictResolutionLocal:
+		winner = conflict.Local
+	case ConflictResolutionRemote:
+		winner = conflict.Remote
+	case ConflictResolutionMerged:
+		if merged == nil {
+			return nil, &ValidationError{Field: "Merged", Message: "required for merged resolution"}
+		}
+		winner = *merged
+	default:
+		return nil, &ValidationError{Field: "Resolution", Message: "must be local, remote, or merged"}
+	}
+	winner.ID = conflict.LoreID
+
+	if winner.Content == "" {
+		return nil, &ValidationError{Field: "Content", Message: "cannot be empty"}
+	}
+	if !winner.Category.IsValid() {
+		return nil, &ValidationError{Field: "Category", Message: "invalid: must be one of " + validCategoriesString()}
+	}
+	if winner.Confidence < ConfidenceMin || winner.Confidence > ConfidenceMax {
+		return nil, &ValidationError{Field: "Confidence", Message: "must be between 0.0 and 1.0"}
+	}
+
+	resolved, err := c.store.ResolveConflict(id, &winner, resolution)
+	if err != nil {
+		return nil, err
+	}
+	if c.config.OnSyncEvent != nil {
+		c.config.OnSyncEvent(SyncEvent{Type: SyncEventConflictResolved, LoreID: resolved.ID, Resolution: resolution})
+	}
+	return resolved, nil
 }
 
 // HealthCheck returns the health status of the client.
@@ -532,6 +1146,23 @@ func (c *Client) Close() error {
 	case <-time.After(5 * time.Second):
 	}
 
+	// Wait for an in-flight rollover
+	<-c.rolloverDone
+
+	// Wait for the embedding worker; its provider call is cancelled
+	<-c.embedDone
+
+	// Send the final telemetry report
+	select {
+	case <-c.telemetryDone:
+	case <-time.After(5 * time.Second):
+	}
+
+	// Apply feedback waiting in a coalescing window
+	if c.feedbackQueue != nil {
+		c.feedbackQueue.flush()
+	}
+
 	// Flush pending changes
 	if c.syncer != nil {
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -547,38 +1178,14 @@ func (c *Client) Close() error {
 	return c.store.Close()
 }
 
-func (c *Client) backgroundSync() {
-	defer close(c.syncDone)
-
-	ticker := time.NewTicker(c.config.SyncInterval)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-c.stopSync:
-			return
-		case <-ticker.C:
-			// Create cancellable context
-			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-
-			// Run sync, but also listen for stop signal
-			done := make(chan struct{})
-			go func() {
-				_ = c.syncer.Sync(ctx)
-				close(done)
-			}()
-
-			select {
-			case <-done:
-				// Sync completed normally
-			case <-c.stopSync:
-				cancel() // Cancel in-flight HTTP requests
-				<-done   // Wait for Sync to return
-				return
-			}
-			cancel()
-		}
+// SetAPIKey rotates the Engram API key without restarting the client.
+// Subsequent requests, including those made by background sync, use the new key.
+// Has no effect in offline mode.
+func (c *Client) SetAPIKey(apiKey string) {
+	if c.syncer == nil {
+		return
 	}
+	c.syncer.SetAPIKey(apiKey)
 }
 
 // ListStores returns all available stores from Engram.
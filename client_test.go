@@ -684,6 +684,41 @@ func TestQuery_TopKLimitsResults(t *testing.T) {
 	}
 }
 
+// TestQuery_StatsReportTotalBeforeTopK verifies QueryResult.Stats counts
+// every filtered match, not just the returned top K.
+func TestQuery_StatsReportTotalBeforeTopK(t *testing.T) {
+	h := newQueryTestHelper(t)
+	defer h.close()
+
+	queryVec := []float32{1.0, 0.0, 0.0}
+	for i := 0; i < 4; i++ {
+		id := string(rune('a' + i))
+		emb := []float32{1.0 - float32(i)*0.1, float32(i) * 0.1, 0.0}
+		h.insertLoreWithEmbedding(id, "Content "+id, recall.CategoryPatternOutcome, 0.8, emb)
+	}
+	h.insertLoreWithEmbedding("e", "Edge content", recall.CategoryEdgeCaseDiscovery, 0.8, queryVec)
+
+	result, err := h.client.Query(context.Background(), recall.QueryParams{
+		Query:          "test query",
+		QueryEmbedding: queryVec,
+		K:              2,
+	})
+	if err != nil {
+		t.Fatalf("Query() returned error: %v", err)
+	}
+
+	stats := result.Stats
+	if len(result.Lore) != 2 || stats.TotalMatched != 5 {
+		t.Errorf("returned %d of TotalMatched %d, want 2 of 5", len(result.Lore), stats.TotalMatched)
+	}
+	if stats.CategoryCounts[recall.CategoryPatternOutcome] != 4 || stats.CategoryCounts[recall.CategoryEdgeCaseDiscovery] != 1 {
+		t.Errorf("CategoryCounts = %v", stats.CategoryCounts)
+	}
+	if stats.FilterTime <= 0 {
+		t.Errorf("phase timings not recorded: %+v", stats)
+	}
+}
+
 // TestQuery_MinConfidenceFiltersResults tests AC #3:
 // MinConfidence=0.7 filters entries below 0.7.
 func TestQuery_MinConfidenceFiltersResults(t *testing.T) {
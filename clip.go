@@ -0,0 +1,225 @@
+package recall
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// MaxClipBytes is the most RecordFromURL reads from a page; the rest is
+// ignored.
+const MaxClipBytes = 2 << 20
+
+// clipFetchTimeout bounds a RecordFromURL fetch when ctx has no deadline.
+const clipFetchTimeout = 30 * time.Second
+
+// Summarizer compresses text to at most maxLen characters, e.g. with an
+// LLM. Used when clipped content exceeds MaxContentLength.
+type Summarizer interface {
+	Summarize(ctx context.Context, text string, maxLen int) (string, error)
+}
+
+// WithSources sets the entry's sources, such as the URL it was clipped
+// from. Sources must not contain commas.
+func WithSources(sources ...string) RecordOption {
+	return func(o *recordOptions) {
+		o.sources = append(o.sources, sources...)
+	}
+}
+
+// RecordFromURL fetches rawURL, extracts its readable text, and records it
+// as lore in category with the URL as its source. Pages over MaxClipBytes
+// are cut off. Text over MaxContentLength is compressed with
+// Config.Summarizer if set, and truncated otherwise. Without WithContext,
+// the page title becomes the context. HTML and plain text pages are
+// supported.
+func (c *Client) RecordFromURL(ctx context.Context, rawURL string, category Category, opts ...RecordOption) (*Lore, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, &ValidationError{Field: "URL", Message: "must be an absolute http or https URL"}
+	}
+	if !category.IsValid() {
+		return nil, &ValidationError{Field: "Category", Message: "invalid: must be one of " + validCategoriesString()}
+	}
+	if err := c.authorize(ActionRecord, category); err != nil {
+		return nil, err
+	}
+
+	title, text, err := fetchReadableText(ctx, u.String())
+	if err != nil {
+		return nil, fmt.Errorf("client: record from url: %w", err)
+	}
+	if text == "" {
+		return nil, &ValidationError{Field: "URL", Message: "page has no readable text"}
+	}
+
+	if len(text) > MaxContentLength && c.config.Summarizer != nil {
+		summary, err := c.config.Summarizer.Summarize(ctx, text, MaxContentLength)
+		if err != nil {
+			return nil, fmt.Errorf("client: record from url: summarize: %w", err)
+		}
+		text = summary
+	}
+	text = truncateText(text, MaxContentLength)
+
+	options := recordOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if options.context == "" && title != "" {
+		opts = append(opts, WithContext(truncateText(title, MaxContextLength)))
+	}
+	// Commas separate sources in storage; they are percent-encoded in URLs.
+	opts = append(opts, WithSources(strings.ReplaceAll(u.String(), ",", "%2C")))
+
+	return c.Record(text, category, opts...)
+}
+
+// fetchReadableText GETs pageURL and returns its title and readable text.
+func fetchReadableText(ctx context.Context, pageURL string) (title, text string, err error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, clipFetchTimeout)
+		defer cancel()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL, nil)
+	if err != nil {
+		return "", "", err
+	}
+	req.Header.Set("Accept", "text/html, text/plain;q=0.9")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", "", fmt.Errorf("fetch %s: %w", pageURL, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return "", "", fmt.Errorf("fetch %s: %s", pageURL, resp.Status)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, MaxClipBytes))
+	if err != nil {
+		return "", "", fmt.Errorf("fetch %s: %w", pageURL, err)
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	switch {
+	case mediaType == "" || mediaType == "text/html" || mediaType == "application/xhtml+xml":
+		title, text = extractReadableText(string(body))
+		return title, text, nil
+	case strings.HasPrefix(mediaType, "text/"):
+		return "", strings.TrimSpace(string(body)), nil
+	default:
+		return "", "", &ValidationError{Field: "URL", Message: "unsupported content type " + mediaType}
+	}
+}
+
+// skippedElements hold no readable text.
+var skippedElements = map[atom.Atom]bool{
+	atom.Script: true, atom.Style: true, atom.Noscript: true, atom.Template: true,
+	atom.Svg: true, atom.Iframe: true, atom.Nav: true, atom.Header: true,
+	atom.Footer: true, atom.Aside: true, atom.Form: true, atom.Button: true,
+}
+
+// blockElements start a new line in the extracted text.
+var blockElements = map[atom.Atom]bool{
+	atom.P: true, atom.Div: true, atom.Br: true, atom.Li: true, atom.Tr: true,
+	atom.H1: true, atom.H2: true, atom.H3: true, atom.H4: true, atom.H5: true, atom.H6: true,
+	atom.Pre: true, atom.Blockquote: true, atom.Section: true, atom.Article: true,
+	atom.Table: true, atom.Ul: true, atom.Ol: true, atom.Dt: true, atom.Dd: true,
+}
+
+// extractReadableText returns the title and visible text of an HTML page,
+// preferring <main> or <article> when the page has one. Runs of spaces
+// collapse to one and blocks are separated by newlines.
+func extractReadableText(page string) (title, text string) {
+	doc, err := html.Parse(strings.NewReader(page))
+	if err != nil {
+		return "", ""
+	}
+
+	var root *html.Node
+	var find func(*html.Node)
+	find = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			switch n.DataAtom {
+			case atom.Title:
+				if title == "" && n.FirstChild != nil {
+					title = strings.Join(strings.Fields(n.FirstChild.Data), " ")
+				}
+			case atom.Main, atom.Article:
+				if root == nil {
+					root = n
+				}
+			}
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			find(child)
+		}
+	}
+	find(doc)
+	if root == nil {
+		root = doc
+	}
+
+	var lines []string
+	var line strings.Builder
+	flush := func() {
+		if s := strings.Join(strings.Fields(line.String()), " "); s != "" {
+			lines = append(lines, s)
+		}
+		line.Reset()
+	}
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		switch n.Type {
+		case html.TextNode:
+			line.WriteString(n.Data)
+			line.WriteByte(' ')
+			return
+		case html.ElementNode:
+			if skippedElements[n.DataAtom] || n.DataAtom == atom.Head {
+				return
+			}
+		}
+		block := n.Type == html.ElementNode && blockElements[n.DataAtom]
+		if block {
+			flush()
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+		if block {
+			flush()
+		}
+	}
+	walk(root)
+	flush()
+
+	return title, strings.Join(lines, "\n")
+}
+
+// truncateText cuts s to at most maxLen bytes, at a word boundary where
+// possible, and marks the cut with "...".
+func truncateText(s string, maxLen int) string {
+	if len(s) <= maxLen {
+		return s
+	}
+	cut := maxLen - 3
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	if i := strings.LastIndexAny(s[:cut], " \n"); i > cut/2 {
+		cut = i
+	}
+	return strings.TrimRight(s[:cut], " \n") + "..."
+}
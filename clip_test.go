@@ -0,0 +1,136 @@
+package recall
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const clipPage = `<!doctype html>
+<html><head><title>Postmortem:  DB outage</title><style>body { color: red }</style></head>
+<body>
+<nav><a href="/">Home</a></nav>
+<main>
+  <h1>What happened</h1>
+  <p>The connection pool   was exhausted
+  by long transactions.</p>
+  <script>track()</script>
+  <ul><li>Set a statement timeout</li><li>Alert on pool saturation</li></ul>
+</main>
+<footer>Copyright</footer>
+</body></html>`
+
+type stubSummarizer struct{ got string }
+
+func (s *stubSummarizer) Summarize(ctx context.Context, text string, maxLen int) (string, error) {
+	s.got = text
+	return "summary of the page", nil
+}
+
+func newClipServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/page":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			_, _ = w.Write([]byte(clipPage))
+		case "/long":
+			w.Header().Set("Content-Type", "text/plain")
+			_, _ = w.Write([]byte(strings.Repeat("word ", 2000)))
+		case "/image":
+			w.Header().Set("Content-Type", "image/png")
+			_, _ = w.Write([]byte{0x89, 'P', 'N', 'G'})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestExtractReadableText(t *testing.T) {
+	title, text := extractReadableText(clipPage)
+	if title != "Postmortem: DB outage" {
+		t.Errorf("title = %q", title)
+	}
+	want := "What happened\nThe connection pool was exhausted by long transactions.\nSet a statement timeout\nAlert on pool saturation"
+	if text != want {
+		t.Errorf("text = %q, want %q", text, want)
+	}
+}
+
+func TestClient_RecordFromURL(t *testing.T) {
+	server := newClipServer(t)
+	client := newTestClient(t, Config{AutoSync: false})
+
+	lore, err := client.RecordFromURL(context.Background(), server.URL+"/page?a=1,2", CategoryEdgeCaseDiscovery)
+	if err != nil {
+		t.Fatalf("RecordFromURL: %v", err)
+	}
+	if !strings.HasPrefix(lore.Content, "What happened\nThe connection pool") || strings.Contains(lore.Content, "track()") {
+		t.Errorf("Content = %q", lore.Content)
+	}
+	if lore.Context != "Postmortem: DB outage" {
+		t.Errorf("Context = %q, want the page title", lore.Context)
+	}
+	stored, err := client.store.Get(lore.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stored.Sources) != 1 || stored.Sources[0] != server.URL+"/page?a=1%2C2" {
+		t.Errorf("Sources = %v, want the URL with commas escaped", stored.Sources)
+	}
+
+	// An explicit context wins over the title.
+	lore, err = client.RecordFromURL(context.Background(), server.URL+"/page", CategoryEdgeCaseDiscovery, WithContext("incident 42"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lore.Context != "incident 42" {
+		t.Errorf("Context = %q, want incident 42", lore.Context)
+	}
+}
+
+func TestClient_RecordFromURL_LongContent(t *testing.T) {
+	server := newClipServer(t)
+	client := newTestClient(t, Config{AutoSync: false})
+
+	lore, err := client.RecordFromURL(context.Background(), server.URL+"/long", CategoryPatternOutcome)
+	if err != nil {
+		t.Fatalf("RecordFromURL: %v", err)
+	}
+	if len(lore.Content) > MaxContentLength || !strings.HasSuffix(lore.Content, "word...") {
+		t.Errorf("Content has %d chars ending %q, want truncated at a word", len(lore.Content), lore.Content[len(lore.Content)-10:])
+	}
+
+	summarizer := &stubSummarizer{}
+	client.config.Summarizer = summarizer
+	lore, err = client.RecordFromURL(context.Background(), server.URL+"/long", CategoryPatternOutcome)
+	if err != nil {
+		t.Fatalf("RecordFromURL: %v", err)
+	}
+	if lore.Content != "summary of the page" || len(summarizer.got) <= MaxContentLength {
+		t.Errorf("Content = %q (summarizer got %d chars), want the summary", lore.Content, len(summarizer.got))
+	}
+}
+
+func TestClient_RecordFromURL_Errors(t *testing.T) {
+	server := newClipServer(t)
+	client := newTestClient(t, Config{AutoSync: false})
+
+	var ve *ValidationError
+	for _, rawURL := range []string{"file:///etc/passwd", "not a url", server.URL + "/image"} {
+		if _, err := client.RecordFromURL(context.Background(), rawURL, CategoryPatternOutcome); !errors.As(err, &ve) {
+			t.Errorf("RecordFromURL(%q) = %v, want ValidationError", rawURL, err)
+		}
+	}
+	if _, err := client.RecordFromURL(context.Background(), server.URL+"/missing", CategoryPatternOutcome); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("RecordFromURL(missing) = %v, want 404 error", err)
+	}
+	if _, err := client.Record("x", CategoryPatternOutcome, WithSources("a,b")); !errors.As(err, &ve) {
+		t.Errorf("Record with comma source = %v, want ValidationError", err)
+	}
+}
@@ -0,0 +1,65 @@
+package recall
+
+import (
+	"time"
+
+	"github.com/oklog/ulid/v2"
+)
+
+// Clock supplies the current time for timestamps the store and client
+// write. Inject a fixed or stepping clock for golden tests and replays.
+type Clock interface {
+	Now() time.Time
+}
+
+// ClockFunc adapts a function to Clock.
+type ClockFunc func() time.Time
+
+// Now calls f.
+func (f ClockFunc) Now() time.Time { return f() }
+
+// IDGenerator supplies IDs for new lore entries and chunk groups. IDs must
+// be unique within a store; the default generates ULIDs.
+type IDGenerator interface {
+	NewID() string
+}
+
+// IDGeneratorFunc adapts a function to IDGenerator.
+type IDGeneratorFunc func() string
+
+// NewID calls f.
+func (f IDGeneratorFunc) NewID() string { return f() }
+
+// SystemClock is the default Clock: time.Now.
+var SystemClock Clock = ClockFunc(time.Now)
+
+// ULIDGenerator is the default IDGenerator: ulid.Make.
+var ULIDGenerator IDGenerator = IDGeneratorFunc(func() string { return ulid.Make().String() })
+
+// SetClock sets the clock for timestamps the store writes. Nil restores
+// SystemClock. Call it before the store is used concurrently.
+func (s *Store) SetClock(clock Clock) {
+	s.clock = clock
+}
+
+// SetIDGenerator sets the generator for IDs of new lore. Nil restores
+// ULIDGenerator. Call it before the store is used concurrently.
+func (s *Store) SetIDGenerator(ids IDGenerator) {
+	s.ids = ids
+}
+
+// now returns the store clock's current time in UTC.
+func (s *Store) now() time.Time {
+	if s.clock == nil {
+		return SystemClock.Now().UTC()
+	}
+	return s.clock.Now().UTC()
+}
+
+// newID returns an ID for new lore from the store's generator.
+func (s *Store) newID() string {
+	if s.ids == nil {
+		return ULIDGenerator.NewID()
+	}
+	return s.ids.NewID()
+}
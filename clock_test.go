@@ -0,0 +1,51 @@
+package recall
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClient_ClockAndIDGenerator(t *testing.T) {
+	fixed := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	n := 0
+	client := newTestClient(t, Config{
+		Clock:            ClockFunc(func() time.Time { return fixed }),
+		IDGenerator:      IDGeneratorFunc(func() string { n++; return fmt.Sprintf("ID%024d", n) }),
+		ChunkLongContent: true,
+	})
+
+	lore, err := client.Record("Pin the base image", CategoryDependencyBehavior)
+	if err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+	if lore.ID != "ID000000000000000000000001" || !lore.CreatedAt.Equal(fixed) || !lore.UpdatedAt.Equal(fixed) {
+		t.Errorf("Record = %s at %v/%v, want generated ID at the fixed time", lore.ID, lore.CreatedAt, lore.UpdatedAt)
+	}
+
+	var logged string
+	if err := client.store.db.QueryRow(`SELECT created_at FROM change_log WHERE entity_id = ?`, lore.ID).Scan(&logged); err != nil {
+		t.Fatalf("read change_log: %v", err)
+	}
+	if logged != fixed.Format(time.RFC3339) {
+		t.Errorf("change_log created_at = %s, want %s", logged, fixed.Format(time.RFC3339))
+	}
+
+	fixed = fixed.Add(time.Hour)
+	updated, err := client.Feedback(lore.ID, Helpful)
+	if err != nil {
+		t.Fatalf("Feedback: %v", err)
+	}
+	if !updated.UpdatedAt.Equal(fixed) || updated.LastValidatedAt == nil || !updated.LastValidatedAt.Equal(fixed) {
+		t.Errorf("Feedback updated at %v, validated at %v; want %v", updated.UpdatedAt, updated.LastValidatedAt, fixed)
+	}
+
+	first, err := client.Record(strings.Repeat("word ", MaxContentLength/5+10), CategoryPatternOutcome)
+	if err != nil {
+		t.Fatalf("Record long content: %v", err)
+	}
+	if !strings.HasPrefix(first.ID, "ID0000") {
+		t.Errorf("chunk ID = %s, want a generated ID", first.ID)
+	}
+}
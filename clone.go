@@ -0,0 +1,156 @@
+package recall
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// CloneOptions controls CloneStore.
+type CloneOptions struct {
+	// IncludeChangeLog keeps the change_log and the push and pull
+	// positions in the clone. Its entries carry the original's source_id,
+	// so the clone never pushes them. By default both are cleared.
+	IncludeChangeLog bool
+}
+
+// CloneResult describes a cloned store.
+type CloneResult struct {
+	Path      string `json:"path"`
+	SourceID  string `json:"source_id"`
+	LoreCount int    `json:"lore_count"`
+}
+
+// Clone writes a detached copy of the store to destPath, which must not
+// exist: every namespace's lore, feedback history and local tables, under
+// a new source_id and with sync state cleared (see CloneOptions). Under
+// legal hold, the clone is recorded as an export.
+func (s *Store) Clone(ctx context.Context, destPath string, opts CloneOptions) (*CloneResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil, ErrStoreClosed
+	}
+	if _, err := os.Stat(destPath); err == nil {
+		return nil, fmt.Errorf("store: clone: %s already exists", destPath)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("store: clone: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
+		return nil, fmt.Errorf("store: clone: %w", err)
+	}
+
+	if _, err := s.db.ExecContext(ctx, `VACUUM INTO ?`, destPath); err != nil {
+		return nil, fmt.Errorf("store: clone: %w", err)
+	}
+	result, err := detachClone(ctx, destPath, opts)
+	if err != nil {
+		_ = os.Remove(destPath)
+		return nil, err
+	}
+	result.Path = destPath
+
+	sum, err := fileSHA256(destPath)
+	if err != nil {
+		return nil, fmt.Errorf("store: clone: %w", err)
+	}
+	if err := s.logExport("clone", destPath, result.LoreCount, sum); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// detachClone gives the database at path a new source_id and, unless
+// opts.IncludeChangeLog is set, clears its change_log and sync state.
+func detachClone(ctx context.Context, path string, opts CloneOptions) (*CloneResult, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("store: open clone: %w", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	sourceID, err := generateUUIDv4()
+	if err != nil {
+		return nil, fmt.Errorf("store: clone: %w", err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("store: begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, `UPDATE sync_meta SET value = ? WHERE key = 'source_id'`, sourceID); err != nil {
+		return nil, fmt.Errorf("store: detach clone: %w", err)
+	}
+	// An interrupted push belongs to the original; the clone must not
+	// re-send it.
+	if _, err := tx.ExecContext(ctx, `DELETE FROM sync_meta WHERE key = ?`, pushJournalKey); err != nil {
+		return nil, fmt.Errorf("store: detach clone: %w", err)
+	}
+	if !opts.IncludeChangeLog {
+		for _, stmt := range []string{
+			`DELETE FROM change_log`,
+			`DELETE FROM sync_meta WHERE key NOT IN ('source_id', 'schema_version', 'sync_queue_converted')`,
+			`INSERT INTO sync_meta (key, value) VALUES ('last_push_seq', '0'), ('last_pull_seq', '0')`,
+			`DELETE FROM metadata WHERE key = 'last_sync'`,
+		} {
+			if _, err := tx.ExecContext(ctx, stmt); err != nil {
+				return nil, fmt.Errorf("store: detach clone: %w", err)
+			}
+		}
+	}
+
+	// VACUUM INTO may renumber rowids, which key the keyword index
+	if _, err := tx.ExecContext(ctx, rebuildKeywordIndex); err != nil {
+		return nil, fmt.Errorf("store: rebuild clone keyword index: %w", err)
+	}
+
+	result := &CloneResult{SourceID: sourceID}
+	if err := tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM lore_entries WHERE deleted_at IS NULL`).Scan(&result.LoreCount); err != nil {
+		return nil, fmt.Errorf("store: count clone lore: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("store: commit: %w", err)
+	}
+	return result, nil
+}
+
+// fileSHA256 returns the SHA-256 of the file at path.
+func fileSHA256(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
+
+// CloneStore writes a detached copy of the client's store to targetPath,
+// for experimenting with consolidation or decay policies on a sandbox
+// without risking the real store. Open the clone with a Config whose
+// LocalPath is targetPath, offline or against a scratch Engram.
+func (c *Client) CloneStore(ctx context.Context, targetPath string, opts CloneOptions) (*CloneResult, error) {
+	release, err := c.store.enter(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	result, err := c.store.Clone(ctx, targetPath, opts)
+	if err != nil {
+		return nil, fmt.Errorf("client: clone store: %w", err)
+	}
+	return result, nil
+}
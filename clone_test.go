@@ -0,0 +1,103 @@
+package recall
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestClient_CloneStore(t *testing.T) {
+	client := newTestClient(t, Config{AutoSync: false})
+
+	lore, err := client.Record("Cache invalidation needs versioned keys", CategoryPatternOutcome)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.store.SetSyncMeta("last_pull_seq", "42"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		name        string
+		opts        CloneOptions
+		wantChanges int
+		wantPullSeq string
+	}{
+		{"default", CloneOptions{}, 0, "0"},
+		{"with change log", CloneOptions{IncludeChangeLog: true}, 1, "42"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			target := filepath.Join(t.TempDir(), "sandbox", "lore.db")
+			result, err := client.CloneStore(context.Background(), target, tt.opts)
+			if err != nil {
+				t.Fatalf("CloneStore: %v", err)
+			}
+			if result.LoreCount != 1 || result.Path != target {
+				t.Errorf("result = %+v, want 1 entry at %s", result, target)
+			}
+
+			clone, err := NewStore(target)
+			if err != nil {
+				t.Fatalf("open clone: %v", err)
+			}
+			defer func() { _ = clone.Close() }()
+
+			if clone.SourceID() == client.store.SourceID() || clone.SourceID() != result.SourceID {
+				t.Errorf("clone source_id = %q, original %q, result %q", clone.SourceID(), client.store.SourceID(), result.SourceID)
+			}
+			got, err := clone.Get(lore.ID)
+			if err != nil || got.Content != lore.Content {
+				t.Errorf("clone Get = %v, %v", got, err)
+			}
+			if n := getChangeLogCount(t, clone); n != tt.wantChanges {
+				t.Errorf("change_log entries = %d, want %d", n, tt.wantChanges)
+			}
+			if seq, _ := clone.GetSyncMeta("last_pull_seq"); seq != tt.wantPullSeq {
+				t.Errorf("last_pull_seq = %q, want %q", seq, tt.wantPullSeq)
+			}
+			// The original's changes are never pushed from the clone.
+			if pending, _ := clone.UnpushedChanges(clone.SourceID(), 0, 10); len(pending) != 0 {
+				t.Errorf("clone has %d changes to push", len(pending))
+			}
+
+			// Writes to the clone leave the original untouched.
+			if err := clone.DeleteLoreByID(lore.ID); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := client.store.Get(lore.ID); err != nil {
+				t.Errorf("original lost entry: %v", err)
+			}
+		})
+	}
+}
+
+func TestStore_Clone_ExistingTarget(t *testing.T) {
+	store := newTestStore(t)
+	target := filepath.Join(t.TempDir(), "lore.db")
+	if _, err := store.Clone(context.Background(), target, CloneOptions{}); err != nil {
+		t.Fatalf("Clone: %v", err)
+	}
+	if _, err := store.Clone(context.Background(), target, CloneOptions{}); err == nil {
+		t.Error("Clone overwrote an existing file")
+	}
+}
+
+func TestStore_Clone_LegalHoldLogsExport(t *testing.T) {
+	store := newTestStore(t)
+	if err := store.SetLegalHold(true); err != nil {
+		t.Fatal(err)
+	}
+	target := filepath.Join(t.TempDir(), "lore.db")
+	if _, err := store.Clone(context.Background(), target, CloneOptions{}); err != nil {
+		t.Fatalf("Clone: %v", err)
+	}
+
+	report, err := store.ComplianceReport(time.Now().Add(-time.Hour), time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Exports) != 1 || report.Exports[0].Format != "clone" || report.Exports[0].Destination != target {
+		t.Errorf("exports = %+v, want one clone export", report.Exports)
+	}
+}
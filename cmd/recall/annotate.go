@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hyperengineering/recall"
+	"github.com/spf13/cobra"
+)
+
+var annotateCmd = &cobra.Command{
+	Use:   "annotate <lore-id> [note]",
+	Short: "Attach a private note to lore",
+	Long: `Attach a private note to a lore entry without editing its content.
+
+Notes are local-only: they are never synced to Engram. Without a note
+argument, the entry's existing notes are listed.
+
+Examples:
+  recall annotate 01HQXYZ... "verify with platform team"
+  recall annotate 01HQXYZ...
+  recall query "retry policies" --notes`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runAnnotate,
+}
+
+func runAnnotate(cmd *cobra.Command, args []string) error {
+	cfg, err := loadAndValidateConfig()
+	if err != nil {
+		return err
+	}
+
+	client, err := recall.New(cfg)
+	if err != nil {
+		return fmt.Errorf("initialize client: %w", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	if len(args) == 2 {
+		note, err := client.Annotate(args[0], args[1])
+		if err != nil {
+			return fmt.Errorf("annotate: %w", err)
+		}
+		if outputJSON {
+			return outputAsJSON(cmd, note)
+		}
+		printSuccess(cmd.OutOrStdout(), "Added note %d to %s", note.ID, shortID(note.LoreID))
+		return nil
+	}
+
+	notes, err := client.Notes(args[0])
+	if err != nil {
+		return fmt.Errorf("list notes: %w", err)
+	}
+	if outputJSON {
+		if notes == nil {
+			notes = []recall.Note{}
+		}
+		return outputAsJSON(cmd, notes)
+	}
+
+	out := cmd.OutOrStdout()
+	if len(notes) == 0 {
+		printMuted(out, "No notes for %s", shortID(args[0]))
+		return nil
+	}
+	for _, n := range notes {
+		_, _ = fmt.Fprintf(out, "  #%d %s  %s\n", n.ID, n.CreatedAt.Format(time.DateTime), n.Note)
+	}
+	return nil
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hyperengineering/recall"
+)
+
+func TestCLI_Annotate_AddListAndQuery(t *testing.T) {
+	defer testEnv(t)()
+	resetQueryFlags()
+	defer resetQueryFlags()
+
+	client, err := recall.New(recall.Config{LocalPath: os.Getenv("RECALL_DB_PATH"), SourceID: "test-client"})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	lore, err := client.Record("Retry with jitter", recall.CategoryPatternOutcome)
+	if err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+	_ = client.Close()
+
+	var stdout bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetArgs([]string{"annotate", lore.ID, "verify with platform team"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("annotate: %v", err)
+	}
+	if !strings.Contains(stdout.String(), "Added note") {
+		t.Errorf("output = %q, want confirmation", stdout.String())
+	}
+
+	stdout.Reset()
+	rootCmd.SetArgs([]string{"annotate", lore.ID})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("annotate list: %v", err)
+	}
+	if !strings.Contains(stdout.String(), "verify with platform team") {
+		t.Errorf("list output = %q, want note", stdout.String())
+	}
+
+	stdout.Reset()
+	rootCmd.SetArgs([]string{"query", "retry", "--notes"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("query --notes: %v", err)
+	}
+	if !strings.Contains(stdout.String(), "Note: verify with platform team") {
+		t.Errorf("query output = %q, want note", stdout.String())
+	}
+
+	resetQueryFlags()
+	stdout.Reset()
+	rootCmd.SetArgs([]string{"query", "retry"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("query: %v", err)
+	}
+	if strings.Contains(stdout.String(), "Note:") {
+		t.Errorf("notes should be omitted without --notes, got %q", stdout.String())
+	}
+}
+
+func TestCLI_Annotate_UnknownLore(t *testing.T) {
+	defer testEnv(t)()
+
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"annotate", "missing", "note"})
+	if err := rootCmd.Execute(); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("err = %v, want not found", err)
+	}
+}
+
+func TestCLI_Attach_AddListAndGet(t *testing.T) {
+	defer testEnv(t)()
+	defer func() { attachName, attachType, attachSync, attachRemove, attachmentOutput = "", "", false, "", "" }()
+
+	client, err := recall.New(recall.Config{LocalPath: os.Getenv("RECALL_DB_PATH"), SourceID: "test-client"})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	lore, err := client.Record("Raise the pool size", recall.CategoryPerformanceInsight)
+	if err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+	_ = client.Close()
+
+	file := filepath.Join(t.TempDir(), "pool.diff")
+	content := []byte("-pool: 10\n+pool: 50\n")
+	if err := os.WriteFile(file, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+	id := recall.AttachmentID(content)
+
+	var stdout bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetArgs([]string{"attach", lore.ID, file})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("attach: %v", err)
+	}
+	if !strings.Contains(stdout.String(), "Attached pool.diff") {
+		t.Errorf("output = %q, want confirmation", stdout.String())
+	}
+
+	stdout.Reset()
+	rootCmd.SetArgs([]string{"attach", lore.ID})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("attach list: %v", err)
+	}
+	if !strings.Contains(stdout.String(), id[:12]) || !strings.Contains(stdout.String(), "pool.diff") {
+		t.Errorf("list output = %q, want attachment", stdout.String())
+	}
+
+	stdout.Reset()
+	rootCmd.SetArgs([]string{"attachment", id})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("attachment: %v", err)
+	}
+	if stdout.String() != string(content) {
+		t.Errorf("attachment output = %q, want file content", stdout.String())
+	}
+}
+
+func TestCLI_UpdateAndHistory(t *testing.T) {
+	defer testEnv(t)()
+
+	client, err := recall.New(recall.Config{LocalPath: os.Getenv("RECALL_DB_PATH"), SourceID: "test-client"})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	lore, err := client.Record("Retry the upload three times", recall.CategoryPatternOutcome)
+	if err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+	_ = client.Close()
+
+	var stdout bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetArgs([]string{"update", lore.ID, "Retry the upload five times"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if !strings.Contains(stdout.String(), "Updated: "+lore.ID) {
+		t.Errorf("output = %q, want confirmation", stdout.String())
+	}
+
+	stdout.Reset()
+	rootCmd.SetArgs([]string{"history", lore.ID})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("history: %v", err)
+	}
+	if !strings.Contains(stdout.String(), "v2") || !strings.Contains(stdout.String(), "[-three-] {+five+}") {
+		t.Errorf("history output = %q, want the diff", stdout.String())
+	}
+}
+
+func TestCLI_Validate(t *testing.T) {
+	defer testEnv(t)()
+
+	path := filepath.Join(t.TempDir(), "payload.json")
+	payload := `{"lore_id": "01MISSING", "id": "abc", "name": "x", "media_type": "text/plain"}`
+	if err := os.WriteFile(path, []byte(payload), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetArgs([]string{"validate", path})
+	err := rootCmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "2 issue(s)") {
+		t.Fatalf("validate error = %v, want 2 issues", err)
+	}
+	for _, want := range []string{path + ":1:13 /lore_id: lore 01MISSING", path + ":1:32 /id: must match"} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("output = %q, want %q", stdout.String(), want)
+		}
+	}
+}
+
+func TestCLI_SeedPack(t *testing.T) {
+	defer testEnv(t)()
+	defer func() { seedPack, seedRemovePack, seedListPacks = "", "", false }()
+
+	var stdout bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetArgs([]string{"seed", "--pack", "go-service"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("seed --pack error = %v", err)
+	}
+	if !strings.Contains(stdout.String(), "inserted  starter-pack:go-service/context-first-arg") {
+		t.Errorf("output = %q, want the pack's entries", stdout.String())
+	}
+
+	stdout.Reset()
+	seedPack = ""
+	rootCmd.SetArgs([]string{"seed", "--remove-pack", "go-service"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("seed --remove-pack error = %v", err)
+	}
+	if !strings.Contains(stdout.String(), "Removed 8 entries from starter pack go-service") {
+		t.Errorf("output = %q", stdout.String())
+	}
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hyperengineering/recall"
+	"github.com/spf13/cobra"
+)
+
+var assignCmd = &cobra.Command{
+	Use:   "assign <lore-id>",
+	Short: "Set the owner or reviewer of lore",
+	Long: `Set who maintains a lore entry: the owner keeps it accurate and the
+reviewer checks it. Flags that are not given keep their value; an empty
+value unassigns. Without flags, the current assignment is shown.
+
+Assignments are local-only.
+
+Examples:
+  recall assign 01HQXYZ... --owner alice --reviewer bob
+  recall assign 01HQXYZ... --reviewer ""
+  recall assign 01HQXYZ...`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAssign,
+}
+
+var assignedCmd = &cobra.Command{
+	Use:   "assigned",
+	Short: "List lore assigned to someone",
+	Long: `List the entries someone owns or reviews, most recently assigned first.
+"--to me" (the default) selects RECALL_ACTOR, or the source ID if unset.
+
+Examples:
+  recall assigned
+  recall assigned --to alice --role reviewer --json`,
+	Args: cobra.NoArgs,
+	RunE: runAssigned,
+}
+
+var (
+	assignOwner    string
+	assignReviewer string
+
+	assignedTo   string
+	assignedRole string
+)
+
+func init() {
+	assignCmd.Flags().StringVar(&assignOwner, "owner", "", "Owner of the entry (empty unassigns)")
+	assignCmd.Flags().StringVar(&assignReviewer, "reviewer", "", "Reviewer of the entry (empty unassigns)")
+
+	assignedCmd.Flags().StringVar(&assignedTo, "to", "me", "Person whose assignments to list")
+	assignedCmd.Flags().StringVar(&assignedRole, "role", "", "Only entries where they are the owner or the reviewer")
+}
+
+func runAssign(cmd *cobra.Command, args []string) error {
+	cfg, err := loadAndValidateConfig()
+	if err != nil {
+		return err
+	}
+	client, err := recall.New(cfg)
+	if err != nil {
+		return fmt.Errorf("initialize client: %w", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	var opts []recall.AssignOption
+	if cmd.Flags().Changed("owner") {
+		opts = append(opts, recall.AssignOwner(assignOwner))
+	}
+	if cmd.Flags().Changed("reviewer") {
+		opts = append(opts, recall.AssignReviewer(assignReviewer))
+	}
+
+	var a *recall.Assignment
+	if len(opts) == 0 {
+		a, err = client.Assignment(args[0])
+	} else {
+		a, err = client.Assign(args[0], opts...)
+	}
+	if err != nil {
+		return fmt.Errorf("assign: %w", err)
+	}
+	if outputJSON {
+		return outputAsJSON(cmd, a)
+	}
+
+	out := cmd.OutOrStdout()
+	if a == nil || (a.Owner == "" && a.Reviewer == "") {
+		printMuted(out, "%s is unassigned", shortID(args[0]))
+		return nil
+	}
+	if len(opts) > 0 {
+		printSuccess(out, "Assigned %s", shortID(a.LoreID))
+	}
+	_, _ = fmt.Fprintf(out, "  Owner:    %s\n", orUnassigned(a.Owner))
+	_, _ = fmt.Fprintf(out, "  Reviewer: %s\n", orUnassigned(a.Reviewer))
+	return nil
+}
+
+func runAssigned(cmd *cobra.Command, args []string) error {
+	cfg, err := loadAndValidateConfig()
+	if err != nil {
+		return err
+	}
+	client, err := recall.New(cfg)
+	if err != nil {
+		return fmt.Errorf("initialize client: %w", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	person := assignedTo
+	if person == "me" {
+		person = ""
+	}
+	assigned, err := client.Assigned(context.Background(), person, recall.AssignmentRole(assignedRole))
+	if err != nil {
+		return fmt.Errorf("assigned: %w", err)
+	}
+	if outputJSON {
+		return outputAsJSON(cmd, assigned)
+	}
+
+	out := cmd.OutOrStdout()
+	if len(assigned) == 0 {
+		printMuted(out, "Nothing assigned to %s", assignedTo)
+		return nil
+	}
+	rows := make([][]string, 0, len(assigned))
+	for _, a := range assigned {
+		content := strings.ReplaceAll(a.Lore.Content, "\n", " ")
+		if len(content) > 60 {
+			content = content[:57] + "..."
+		}
+		rows = append(rows, []string{a.Lore.ID, string(a.Lore.Category), orUnassigned(a.Assignment.Owner), orUnassigned(a.Assignment.Reviewer), content})
+	}
+	_, _ = fmt.Fprintln(out, renderTable([]string{"ID", "CATEGORY", "OWNER", "REVIEWER", "CONTENT"}, rows))
+	return nil
+}
+
+func orUnassigned(name string) string {
+	if name == "" {
+		return "-"
+	}
+	return name
+}
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/hyperengineering/recall"
+	"github.com/spf13/cobra"
+)
+
+var attachCmd = &cobra.Command{
+	Use:   "attach <lore-id> [file]",
+	Short: "Attach a small file to lore",
+	Long: `Attach a small artifact, such as a config diff or log excerpt, to a lore
+entry. Content is stored once per SHA-256 hash, which is the attachment ID.
+Files are limited to 256 KiB and entries to 10 attachments.
+
+Attachments are local-only unless --sync is given. Without a file
+argument, the entry's attachments are listed. Use "-" to read stdin.
+
+Examples:
+  recall attach 01HQXYZ... pool.diff
+  kubectl logs api | tail -50 | recall attach L1 - --name api.log --sync
+  recall attach 01HQXYZ...
+  recall attach 01HQXYZ... --remove 3f5a...
+  recall attachment 3f5a... -o pool.diff`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runAttach,
+}
+
+var attachmentCmd = &cobra.Command{
+	Use:   "attachment <id>",
+	Short: "Print an attachment's content",
+	Long: `Print an attachment's content to stdout, or write it to a file with -o.
+
+Example:
+  recall attachment 3f5a... -o pool.diff`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAttachment,
+}
+
+var (
+	attachName   string
+	attachType   string
+	attachSync   bool
+	attachRemove string
+
+	attachmentOutput string
+)
+
+func init() {
+	attachCmd.Flags().StringVar(&attachName, "name", "", "Attachment name (default: the file name)")
+	attachCmd.Flags().StringVar(&attachType, "type", "", "Media type (default: detected from content)")
+	attachCmd.Flags().BoolVar(&attachSync, "sync", false, "Push the attachment to Engram with the next sync")
+	attachCmd.Flags().StringVar(&attachRemove, "remove", "", "Remove the attachment with this ID")
+
+	attachmentCmd.Flags().StringVarP(&attachmentOutput, "output", "o", "", "Write content to this file")
+}
+
+func runAttach(cmd *cobra.Command, args []string) error {
+	cfg, err := loadAndValidateConfig()
+	if err != nil {
+		return err
+	}
+
+	client, err := recall.New(cfg)
+	if err != nil {
+		return fmt.Errorf("initialize client: %w", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	out := cmd.OutOrStdout()
+
+	if attachRemove != "" {
+		if len(args) == 2 {
+			return fmt.Errorf("--remove does not take a file")
+		}
+		if err := client.Detach(args[0], attachRemove); err != nil {
+			return fmt.Errorf("remove attachment: %w", err)
+		}
+		printSuccess(out, "Removed attachment %s from %s", shortID(attachRemove), shortID(args[0]))
+		return nil
+	}
+
+	if len(args) == 2 {
+		data, err := readAttachmentFile(cmd, args[1])
+		if err != nil {
+			return err
+		}
+		name := attachName
+		if name == "" && args[1] != "-" {
+			name = filepath.Base(args[1])
+		}
+		var opts []recall.AttachOption
+		if attachType != "" {
+			opts = append(opts, recall.WithMediaType(attachType))
+		}
+		if attachSync {
+			opts = append(opts, recall.WithAttachmentSync())
+		}
+
+		a, err := client.Attach(args[0], name, data, opts...)
+		if err != nil {
+			return fmt.Errorf("attach: %w", err)
+		}
+		if outputJSON {
+			return outputAsJSON(cmd, a)
+		}
+		printSuccess(out, "Attached %s (%s, %d bytes) to %s as %s", a.Name, a.MediaType, a.Size, shortID(a.LoreID), a.ID)
+		return nil
+	}
+
+	attachments, err := client.Attachments(args[0])
+	if err != nil {
+		return fmt.Errorf("list attachments: %w", err)
+	}
+	if outputJSON {
+		if attachments == nil {
+			attachments = []recall.Attachment{}
+		}
+		return outputAsJSON(cmd, attachments)
+	}
+	if len(attachments) == 0 {
+		printMuted(out, "No attachments for %s", shortID(args[0]))
+		return nil
+	}
+	for _, a := range attachments {
+		synced := ""
+		if a.Synced {
+			synced = "  synced"
+		}
+		_, _ = fmt.Fprintf(out, "  %s  %s  %s  %d bytes%s\n", a.ID[:12], a.Name, a.MediaType, a.Size, synced)
+	}
+	return nil
+}
+
+// readAttachmentFile reads a file, or stdin for "-", refusing content
+// over the attachment size limit without reading all of it.
+func readAttachmentFile(cmd *cobra.Command, path string) ([]byte, error) {
+	var r io.Reader = cmd.InOrStdin()
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("open attachment: %w", err)
+		}
+		defer func() { _ = f.Close() }()
+		r = f
+	}
+	data, err := io.ReadAll(io.LimitReader(r, recall.MaxAttachmentSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("read attachment: %w", err)
+	}
+	if len(data) > recall.MaxAttachmentSize {
+		return nil, fmt.Errorf("attachment exceeds %d byte limit", recall.MaxAttachmentSize)
+	}
+	return data, nil
+}
+
+func runAttachment(cmd *cobra.Command, args []string) error {
+	cfg, err := loadAndValidateConfig()
+	if err != nil {
+		return err
+	}
+
+	client, err := recall.New(cfg)
+	if err != nil {
+		return fmt.Errorf("initialize client: %w", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	a, err := client.Attachment(args[0])
+	if err != nil {
+		return fmt.Errorf("get attachment: %w", err)
+	}
+	if outputJSON {
+		return outputAsJSON(cmd, a)
+	}
+	if attachmentOutput != "" {
+		if err := os.WriteFile(attachmentOutput, a.Data, 0644); err != nil {
+			return fmt.Errorf("write attachment: %w", err)
+		}
+		printSuccess(cmd.OutOrStdout(), "Wrote %s (%d bytes) to %s", a.Name, a.Size, attachmentOutput)
+		return nil
+	}
+	_, err = cmd.OutOrStdout().Write(a.Data)
+	return err
+}
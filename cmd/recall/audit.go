@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hyperengineering/recall"
+	"github.com/spf13/cobra"
+)
+
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Audit the store for consistency",
+}
+
+var auditRemoteCmd = &cobra.Command{
+	Use:   "remote",
+	Short: "Compare the store with Engram",
+	Long: `Compare the store with a digest of Engram's live entries (IDs and
+updated_at only; no content is transferred) and report drift: entries
+missing locally, pushed entries missing from Engram, and entries whose
+updated_at differs. Entries with unpushed local changes are skipped.
+
+Exits with an error when drift is found, for use in scheduled checks.
+Set RECALL_REMOTE_AUDIT to also audit weekly from background sync.
+
+Example:
+  recall audit remote
+  recall audit remote --json`,
+	Args: cobra.NoArgs,
+	RunE: runAuditRemote,
+}
+
+func init() {
+	auditCmd.AddCommand(auditRemoteCmd)
+}
+
+func runAuditRemote(cmd *cobra.Command, args []string) error {
+	cfg, err := loadAndValidateConfig()
+	if err != nil {
+		return err
+	}
+	if cfg.IsOffline() {
+		return fmt.Errorf("remote audit unavailable: ENGRAM_URL not configured (offline-only mode)")
+	}
+	cfg.AutoSync = false
+	client, err := recall.New(cfg)
+	if err != nil {
+		return fmt.Errorf("initialize client: %w", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	report, err := client.AuditRemote(context.Background())
+	if err != nil {
+		return fmt.Errorf("audit remote: %w", err)
+	}
+	if outputJSON {
+		if err := outputAsJSON(cmd, report); err != nil {
+			return err
+		}
+	} else {
+		printAuditReport(cmd, report)
+	}
+	if report.Drifted() {
+		return fmt.Errorf("drift found in %d entries", len(report.Drift))
+	}
+	return nil
+}
+
+func printAuditReport(cmd *cobra.Command, report *recall.AuditReport) {
+	out := cmd.OutOrStdout()
+	if !report.Drifted() {
+		printSuccess(out, "No drift: %d local and %d remote entries match", report.LocalEntries, report.RemoteEntries)
+		if report.Unpushed > 0 {
+			printMuted(out, "%d entries with unpushed changes were skipped", report.Unpushed)
+		}
+		return
+	}
+	printWarning(out, "Drift: %d missing locally, %d missing from Engram, %d with divergent timestamps",
+		report.MissingLocal, report.MissingRemote, report.Divergent)
+	rows := make([][]string, 0, len(report.Drift))
+	for _, d := range report.Drift {
+		rows = append(rows, []string{d.LoreID, string(d.Kind), auditTime(d.LocalUpdatedAt), auditTime(d.RemoteUpdatedAt)})
+	}
+	_, _ = fmt.Fprintln(out, renderTable([]string{"ID", "DRIFT", "LOCAL UPDATED", "REMOTE UPDATED"}, rows))
+	if report.Unpushed > 0 {
+		printMuted(out, "%d entries with unpushed changes were skipped", report.Unpushed)
+	}
+}
+
+func auditTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Local().Format(time.RFC3339)
+}
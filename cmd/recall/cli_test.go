@@ -3,14 +3,31 @@ package main
 import (
 	"bytes"
 	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/hyperengineering/recall"
+	"github.com/hyperengineering/recall/internal/store"
 )
 
+// TestMain disables git-based store selection and workspace discovery:
+// tests run inside this repository's checkout and must not resolve to a
+// store named after it or pick up a manifest from a parent directory.
+func TestMain(m *testing.M) {
+	os.Setenv(store.GitStoreEnv, store.GitStoreModeOff)
+	os.Setenv(recall.WorkspaceEnv, "off")
+	// Never read the developer's own config file
+	dir, _ := os.MkdirTemp("", "recall-config")
+	os.Setenv("RECALL_CONFIG", filepath.Join(dir, "config.json"))
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
 // testEnv sets up a test environment with a temporary database.
 // Returns a cleanup function.
 func testEnv(t *testing.T) func() {
@@ -54,6 +71,9 @@ func testEnv(t *testing.T) func() {
 		recordCategory = ""
 		recordContext = ""
 		recordConfidence = 0.5
+		recordURL = ""
+		recordChunk = false
+		recordOverflow = false
 	}
 }
 
@@ -305,6 +325,93 @@ func TestCLI_Record_MissingContent(t *testing.T) {
 	}
 }
 
+func TestCLI_Record_URL(t *testing.T) {
+	cleanup := testEnv(t)
+	defer cleanup()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte("<html><head><title>Retry notes</title></head><body><main><p>Retries need jitter.</p></main></body></html>"))
+	}))
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetArgs([]string{"record", "--url", server.URL, "-c", "PATTERN_OUTCOME", "--json"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var lore recall.Lore
+	if err := json.Unmarshal(stdout.Bytes(), &lore); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, stdout.String())
+	}
+	if lore.Content != "Retries need jitter." || lore.Context != "Retry notes" {
+		t.Errorf("recorded %q with context %q", lore.Content, lore.Context)
+	}
+}
+
+func TestCLI_Record_ContentAndURL(t *testing.T) {
+	cleanup := testEnv(t)
+	defer cleanup()
+
+	rootCmd.SetArgs([]string{"record", "--content", "x", "--url", "https://example.com", "-c", "PATTERN_OUTCOME"})
+
+	if err := rootCmd.Execute(); err == nil {
+		t.Fatal("expected error for --content with --url")
+	}
+}
+
+func TestCLI_Record_Chunk(t *testing.T) {
+	cleanup := testEnv(t)
+	defer cleanup()
+
+	long := strings.Repeat("Chunked content stays within limits. ", 150)
+
+	rootCmd.SetArgs([]string{"record", "--content", long, "-c", "PATTERN_OUTCOME"})
+	if err := rootCmd.Execute(); err == nil {
+		t.Fatal("expected error for long content without --chunk")
+	}
+
+	var stdout bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetArgs([]string{"record", "--content", long, "-c", "PATTERN_OUTCOME", "--chunk"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(stdout.String(), "Parts: 2") {
+		t.Errorf("output should report 2 parts, got: %s", stdout.String())
+	}
+}
+
+func TestCLI_Record_Normalize(t *testing.T) {
+	cleanup := testEnv(t)
+	defer cleanup()
+
+	t.Setenv("RECALL_NORMALIZE", "ansi,whitespace")
+
+	var stdout bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetArgs([]string{"record", "--content", "\x1b[31mUse   pgx v5\x1b[0m ", "-c", "PATTERN_OUTCOME", "--json"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var lore recall.Lore
+	if err := json.Unmarshal(stdout.Bytes(), &lore); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, stdout.String())
+	}
+	if lore.Content != "Use pgx v5" {
+		t.Errorf("Content = %q, want normalized", lore.Content)
+	}
+
+	t.Setenv("RECALL_NORMALIZE", "ansi,emoji")
+	rootCmd.SetArgs([]string{"record", "--content", "x", "-c", "PATTERN_OUTCOME"})
+	if err := rootCmd.Execute(); err == nil || !strings.Contains(err.Error(), "emoji") {
+		t.Errorf("expected unknown normalizer error, got %v", err)
+	}
+}
+
 func TestCLI_Record_MissingCategory(t *testing.T) {
 	cleanup := testEnv(t)
 	defer cleanup()
@@ -494,9 +601,9 @@ func TestCLI_Config_DefaultPath(t *testing.T) {
 	}()
 
 	cfg := loadConfig()
-	// Default store is "default", so LocalPath should contain .recall/stores/default/lore.db
-	if !strings.Contains(cfg.LocalPath, ".recall") || !strings.Contains(cfg.LocalPath, "stores") {
-		t.Errorf("loadConfig().LocalPath = %q, should contain .recall/stores (multi-store default)", cfg.LocalPath)
+	// Default store is "default", so LocalPath should contain recall/stores/default/lore.db
+	if !strings.Contains(cfg.LocalPath, filepath.Join("recall", "stores")) {
+		t.Errorf("loadConfig().LocalPath = %q, should contain recall/stores (multi-store default)", cfg.LocalPath)
 	}
 	if !strings.HasSuffix(cfg.LocalPath, "lore.db") {
 		t.Errorf("loadConfig().LocalPath = %q, should end with lore.db", cfg.LocalPath)
@@ -611,6 +718,22 @@ func resetQueryFlags() {
 	queryTop = 5
 	queryMinConfidence = 0.0
 	queryCategory = ""
+	queryAsOf = ""
+	queryNotes = false
+	queryCompact = false
+	queryTruncate = 0
+	queryQuota = ""
+	queryProfile = ""
+	queryExplain = false
+	queryHideSuperseded = false
+	querySources = nil
+	queryExcludeSources = nil
+	queryOwners = nil
+	queryOnlySynced = false
+	queryMode = ""
+	queryTemperature = 0
+	querySeed = 0
+	querySyncState = false
 }
 
 func resetFeedbackFlags() {
@@ -619,6 +742,10 @@ func resetFeedbackFlags() {
 	feedbackHelpful = ""
 	feedbackNotRelevant = ""
 	feedbackIncorrect = ""
+	feedbackOutdated = ""
+	feedbackReplace = ""
+	feedbackFile = ""
+	feedbackDryRun = false
 }
 
 func TestCLI_Query_NoResults(t *testing.T) {
@@ -657,6 +784,130 @@ func TestCLI_Query_TopFlag(t *testing.T) {
 	}
 }
 
+func TestCLI_Query_AsOfFlag(t *testing.T) {
+	cleanup := testEnv(t)
+	defer cleanup()
+	resetQueryFlags()
+	defer resetQueryFlags()
+
+	var stdout bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetArgs([]string{"query", "test", "--as-of", "2024-03-01"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("query with --as-of should work: %v", err)
+	}
+	if !strings.Contains(stdout.String(), "Historical view as of 2024-03-01T23:59:59Z (read-only)") {
+		t.Errorf("output should be marked historical, got: %s", stdout.String())
+	}
+
+	resetQueryFlags()
+	rootCmd.SetArgs([]string{"query", "test", "--as-of", "last tuesday"})
+	if err := rootCmd.Execute(); err == nil || !strings.Contains(err.Error(), "invalid --as-of") {
+		t.Errorf("expected invalid --as-of error, got %v", err)
+	}
+}
+
+func TestCLI_Query_ModeFlag(t *testing.T) {
+	cleanup := testEnv(t)
+	defer cleanup()
+	resetQueryFlags()
+	defer resetQueryFlags()
+
+	for _, content := range []string{"Kafka rebalances stall under load", "Pin Go toolchain versions"} {
+		rootCmd.SetArgs([]string{"record", "--content", content, "-c", "PATTERN_OUTCOME"})
+		if err := rootCmd.Execute(); err != nil {
+			t.Fatalf("record: %v", err)
+		}
+	}
+
+	var stdout bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetArgs([]string{"query", "kafka", "--mode", "keyword"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("query with --mode keyword: %v", err)
+	}
+	if out := stdout.String(); !strings.Contains(out, "Kafka rebalances") || strings.Contains(out, "toolchain") {
+		t.Errorf("keyword query output = %s", out)
+	}
+
+	resetQueryFlags()
+	rootCmd.SetArgs([]string{"query", "kafka", "--mode", "fuzzy"})
+	if err := rootCmd.Execute(); err == nil || !strings.Contains(err.Error(), "unknown mode") {
+		t.Errorf("expected unknown mode error, got %v", err)
+	}
+}
+
+func TestCLI_Query_TemperatureFlag(t *testing.T) {
+	cleanup := testEnv(t)
+	defer cleanup()
+	resetQueryFlags()
+	defer resetQueryFlags()
+
+	rootCmd.SetArgs([]string{"record", "--content", "Sampled lore still needs feedback", "-c", "PATTERN_OUTCOME"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("record: %v", err)
+	}
+
+	var stdout bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetArgs([]string{"query", "feedback", "--temperature", "0.5", "--seed", "3"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("query with --temperature: %v", err)
+	}
+	if out := stdout.String(); !strings.Contains(out, "Sampled lore") {
+		t.Errorf("sampled query output = %s", out)
+	}
+
+	resetQueryFlags()
+	rootCmd.SetArgs([]string{"query", "feedback", "--temperature", "-1"})
+	if err := rootCmd.Execute(); err == nil || !strings.Contains(err.Error(), "Temperature") {
+		t.Errorf("expected temperature error, got %v", err)
+	}
+}
+
+func TestCLI_Query_CompactFlag(t *testing.T) {
+	cleanup := testEnv(t)
+	defer cleanup()
+	resetQueryFlags()
+	defer resetQueryFlags()
+
+	var stdout bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetArgs([]string{"record", "--content", "Queue consumers need idempotency keys", "--category", "PATTERN_OUTCOME", "--confidence", "0.9"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("record: %v", err)
+	}
+
+	stdout.Reset()
+	rootCmd.SetArgs([]string{"query", "queue", "--compact", "--truncate", "20"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("query --compact: %v", err)
+	}
+	if got := stdout.String(); !strings.Contains(got, "|high] Queue consumers nee…") {
+		t.Errorf("compact output = %q", got)
+	}
+}
+
+func TestCLI_Query_QuotaFlag(t *testing.T) {
+	cleanup := testEnv(t)
+	defer cleanup()
+	resetQueryFlags()
+	defer resetQueryFlags()
+
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"query", "test", "--quota", "PATTERN_OUTCOME=2,TESTING_STRATEGY=1"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("query with --quota should work: %v", err)
+	}
+
+	resetQueryFlags()
+	rootCmd.SetArgs([]string{"query", "test", "--quota", "PATTERN_OUTCOME"})
+	if err := rootCmd.Execute(); err == nil || !strings.Contains(err.Error(), "invalid --quota") {
+		t.Errorf("expected invalid --quota error, got %v", err)
+	}
+}
+
 func TestCLI_Query_ShortFlag(t *testing.T) {
 	cleanup := testEnv(t)
 	defer cleanup()
@@ -687,6 +938,73 @@ func TestCLI_Query_CategoryFlag(t *testing.T) {
 	}
 }
 
+func TestCLI_Query_SourceFlags(t *testing.T) {
+	cleanup := testEnv(t)
+	defer cleanup()
+	resetQueryFlags()
+	defer resetQueryFlags()
+
+	var stdout bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetArgs([]string{"record", "--content", "Queue consumers need idempotency keys", "--category", "PATTERN_OUTCOME"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("record: %v", err)
+	}
+
+	for _, tc := range []struct {
+		args []string
+		want bool
+	}{
+		{[]string{"--source", "test-client"}, true},
+		{[]string{"--source", "other-agent,human"}, false},
+		{[]string{"--exclude-source", "test-client"}, false},
+		{[]string{"--exclude-source", "other-agent"}, true},
+	} {
+		resetQueryFlags()
+		stdout.Reset()
+		rootCmd.SetArgs(append([]string{"query", "queue"}, tc.args...))
+		if err := rootCmd.Execute(); err != nil {
+			t.Fatalf("query %v: %v", tc.args, err)
+		}
+		if got := strings.Contains(stdout.String(), "idempotency keys"); got != tc.want {
+			t.Errorf("query %v: found = %v, want %v; output: %s", tc.args, got, tc.want, stdout.String())
+		}
+	}
+}
+
+func TestCLI_Query_SyncState(t *testing.T) {
+	cleanup := testEnv(t)
+	defer cleanup()
+	resetQueryFlags()
+	defer resetQueryFlags()
+
+	var stdout bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetArgs([]string{"record", "--content", "Queue consumers need idempotency keys", "--category", "PATTERN_OUTCOME"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("record: %v", err)
+	}
+
+	stdout.Reset()
+	rootCmd.SetArgs([]string{"query", "queue", "--sync-state"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("query --sync-state: %v", err)
+	}
+	if got := stdout.String(); !strings.Contains(got, "Sync: unpushed, embedding: pending") {
+		t.Errorf("output = %q", got)
+	}
+
+	resetQueryFlags()
+	stdout.Reset()
+	rootCmd.SetArgs([]string{"query", "queue", "--only-synced"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("query --only-synced: %v", err)
+	}
+	if got := stdout.String(); !strings.Contains(got, "No matching lore found") {
+		t.Errorf("--only-synced should skip unpushed lore, got %q", got)
+	}
+}
+
 func TestCLI_Query_JSON(t *testing.T) {
 	cleanup := testEnv(t)
 	defer cleanup()
@@ -825,6 +1143,7 @@ func TestCLI_Feedback_TypeVariants(t *testing.T) {
 		{"not_relevant", recall.NotRelevant},
 		{"not-relevant", recall.NotRelevant},
 		{"notrelevant", recall.NotRelevant},
+		{"Outdated", recall.Outdated},
 	}
 
 	for _, tc := range tests {
@@ -881,6 +1200,20 @@ func TestCLI_SyncPush_Offline(t *testing.T) {
 	}
 }
 
+func TestCLI_SyncNow_Offline(t *testing.T) {
+	cleanup := testEnv(t)
+	defer cleanup()
+
+	os.Setenv("ENGRAM_URL", "")
+
+	rootCmd.SetArgs([]string{"sync", "now", "--parallel"})
+
+	err := rootCmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "offline") {
+		t.Errorf("sync now in offline mode error = %v, want offline", err)
+	}
+}
+
 func TestCLI_SyncBootstrap_Offline(t *testing.T) {
 	cleanup := testEnv(t)
 	defer cleanup()
@@ -901,6 +1234,28 @@ func TestCLI_SyncBootstrap_Offline(t *testing.T) {
 	}
 }
 
+func TestCLI_SyncStatus_Offline(t *testing.T) {
+	cleanup := testEnv(t)
+	defer cleanup()
+
+	os.Setenv("ENGRAM_URL", "")
+
+	var stdout bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetArgs([]string{"sync", "status"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("sync status failed: %v", err)
+	}
+
+	output := stdout.String()
+	for _, want := range []string{"Offline", "Last sync: never", "Unpushed changes: 0"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q: %s", want, output)
+		}
+	}
+}
+
 func TestCLI_Session_JSON(t *testing.T) {
 	cleanup := testEnv(t)
 	defer cleanup()
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hyperengineering/recall"
+	"github.com/spf13/cobra"
+)
+
+var complianceCmd = &cobra.Command{
+	Use:   "compliance",
+	Short: "Legal hold and data egress reporting",
+	Long: `Manage legal hold and report what data left the machine.
+
+While a store is under legal hold (RECALL_LEGAL_HOLD, or "recall
+compliance hold"):
+  - deleted or replaced lore is retained as tombstones indefinitely
+  - every export is logged with the SHA-256 of its content
+  - every change pushed to Engram is logged with its payload hash
+
+Examples:
+  recall compliance hold
+  recall compliance report --since 2026-01-01 --until 2026-03-31
+  recall compliance report --since 2026-01-01 --json
+  recall compliance hold --release`,
+}
+
+var complianceHoldCmd = &cobra.Command{
+	Use:   "hold",
+	Short: "Place the store under legal hold",
+	Long: `Place the store under legal hold, or show whether it is.
+
+With --release, the hold is lifted (requires the delete permission).
+Tombstones and logs recorded during the hold are kept.
+
+Example:
+  recall compliance hold
+  recall compliance hold --status
+  recall compliance hold --release`,
+	Args: cobra.NoArgs,
+	RunE: runComplianceHold,
+}
+
+var complianceReportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "List data that left the machine in a time window",
+	Long: `List the changes pushed to Engram and the exports made between --since
+and --until (default: now), with the tombstones held in that window. Only
+activity while the store was under legal hold is recorded.
+
+Times are RFC3339 or YYYY-MM-DD (--until covers the whole day).
+
+Example:
+  recall compliance report --since 2026-01-01 --until 2026-03-31`,
+	Args: cobra.NoArgs,
+	RunE: runComplianceReport,
+}
+
+var (
+	complianceRelease bool
+	complianceStatus  bool
+	complianceSince   string
+	complianceUntil   string
+)
+
+func init() {
+	complianceHoldCmd.Flags().BoolVar(&complianceRelease, "release", false, "Lift the legal hold")
+	complianceHoldCmd.Flags().BoolVar(&complianceStatus, "status", false, "Only show whether the store is under legal hold")
+	complianceReportCmd.Flags().StringVar(&complianceSince, "since", "", "Start of the window (required)")
+	complianceReportCmd.Flags().StringVar(&complianceUntil, "until", "", "End of the window (default: now)")
+	_ = complianceReportCmd.MarkFlagRequired("since")
+
+	complianceCmd.AddCommand(complianceHoldCmd)
+	complianceCmd.AddCommand(complianceReportCmd)
+}
+
+func runComplianceHold(cmd *cobra.Command, args []string) error {
+	if complianceRelease && complianceStatus {
+		return fmt.Errorf("--release and --status are mutually exclusive")
+	}
+	cfg, err := loadAndValidateConfig()
+	if err != nil {
+		return err
+	}
+	// Releasing must not re-apply the hold through RECALL_LEGAL_HOLD.
+	if complianceRelease {
+		cfg.LegalHold = false
+	}
+	if !complianceRelease && !complianceStatus {
+		cfg.LegalHold = true
+	}
+
+	client, err := recall.New(cfg)
+	if err != nil {
+		return fmt.Errorf("initialize client: %w", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	if complianceRelease {
+		if err := client.ReleaseLegalHold(); err != nil {
+			return fmt.Errorf("release legal hold: %w", err)
+		}
+	}
+	held, err := client.LegalHold()
+	if err != nil {
+		return fmt.Errorf("read legal hold: %w", err)
+	}
+
+	if outputJSON {
+		return outputAsJSON(cmd, map[string]bool{"legal_hold": held})
+	}
+	out := cmd.OutOrStdout()
+	switch {
+	case complianceRelease:
+		printSuccess(out, "Legal hold released")
+	case held && !complianceStatus:
+		printSuccess(out, "Store is under legal hold")
+	case held:
+		printInfo(out, "Store is under legal hold")
+	default:
+		printMuted(out, "Store is not under legal hold")
+	}
+	return nil
+}
+
+func runComplianceReport(cmd *cobra.Command, args []string) error {
+	since, err := parseWindowTime("--since", complianceSince, false)
+	if err != nil {
+		return err
+	}
+	var until time.Time
+	if complianceUntil != "" {
+		if until, err = parseWindowTime("--until", complianceUntil, true); err != nil {
+			return err
+		}
+	}
+
+	cfg, err := loadAndValidateConfig()
+	if err != nil {
+		return err
+	}
+	client, err := recall.New(cfg)
+	if err != nil {
+		return fmt.Errorf("initialize client: %w", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	report, err := client.ComplianceReport(since, until)
+	if err != nil {
+		return fmt.Errorf("compliance report: %w", err)
+	}
+	if outputJSON {
+		return outputAsJSON(cmd, report)
+	}
+
+	out := cmd.OutOrStdout()
+	printInfo(out, "Compliance report %s to %s", report.Since.Format(time.RFC3339), report.Until.Format(time.RFC3339))
+	if !report.LegalHold {
+		printWarning(out, "Store is not under legal hold; activity outside a hold is not recorded")
+	}
+
+	_, _ = fmt.Fprintf(out, "\nPushed to Engram: %d changes\n", len(report.Egress))
+	if len(report.Egress) > 0 {
+		rows := make([][]string, 0, len(report.Egress))
+		for _, e := range report.Egress {
+			rows = append(rows, []string{e.PushedAt.Format(time.RFC3339), strconv.FormatInt(e.Sequence, 10),
+				e.TableName, e.EntityID, e.Operation, strconv.Itoa(e.PayloadBytes), e.PayloadSHA256[:12]})
+		}
+		_, _ = fmt.Fprintln(out, renderTable([]string{"PUSHED", "SEQ", "TABLE", "ENTITY", "OP", "BYTES", "SHA256"}, rows))
+	}
+
+	_, _ = fmt.Fprintf(out, "\nExports: %d\n", len(report.Exports))
+	if len(report.Exports) > 0 {
+		rows := make([][]string, 0, len(report.Exports))
+		for _, e := range report.Exports {
+			rows = append(rows, []string{e.ExportedAt.Format(time.RFC3339), e.Format, e.Destination,
+				strconv.Itoa(e.Entries), e.SHA256})
+		}
+		_, _ = fmt.Fprintln(out, renderTable([]string{"EXPORTED", "FORMAT", "DESTINATION", "ENTRIES", "SHA256"}, rows))
+	}
+
+	_, _ = fmt.Fprintf(out, "\nTombstones held: %d\n", len(report.Tombstones))
+	return nil
+}
+
+// parseWindowTime parses RFC3339 or YYYY-MM-DD; a date used as the end of
+// a window covers the whole day.
+func parseWindowTime(flag, s string, end bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	if d, err := time.Parse("2006-01-02", s); err == nil {
+		if end {
+			return d.Add(24*time.Hour - time.Second), nil
+		}
+		return d, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid %s %q: use RFC3339 (2024-03-01T12:00:00Z) or YYYY-MM-DD", flag, s)
+}
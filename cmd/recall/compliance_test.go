@@ -0,0 +1,60 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func resetComplianceFlags() {
+	complianceRelease = false
+	complianceStatus = false
+	complianceSince = ""
+	complianceUntil = ""
+}
+
+func TestCLI_ComplianceHold(t *testing.T) {
+	defer testEnv(t)()
+	defer resetComplianceFlags()
+
+	run := func(args ...string) string {
+		t.Helper()
+		resetComplianceFlags()
+		var stdout bytes.Buffer
+		rootCmd.SetOut(&stdout)
+		rootCmd.SetArgs(args)
+		if err := rootCmd.Execute(); err != nil {
+			t.Fatalf("%v: unexpected error: %v", args, err)
+		}
+		return stdout.String()
+	}
+
+	if out := run("compliance", "hold", "--status"); !strings.Contains(out, "not under legal hold") {
+		t.Errorf("status before hold = %q", out)
+	}
+	if out := run("compliance", "hold"); !strings.Contains(out, "under legal hold") {
+		t.Errorf("hold = %q", out)
+	}
+	if out := run("compliance", "report", "--since", "2020-01-01"); !strings.Contains(out, "Pushed to Engram: 0 changes") ||
+		strings.Contains(out, "not under legal hold") {
+		t.Errorf("report = %q", out)
+	}
+	if out := run("compliance", "hold", "--release"); !strings.Contains(out, "released") {
+		t.Errorf("release = %q", out)
+	}
+	if out := run("compliance", "hold", "--status"); !strings.Contains(out, "not under legal hold") {
+		t.Errorf("status after release = %q", out)
+	}
+}
+
+func TestCLI_ComplianceReport_InvalidSince(t *testing.T) {
+	defer testEnv(t)()
+	defer resetComplianceFlags()
+
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"compliance", "report", "--since", "last week"})
+	err := rootCmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "invalid --since") {
+		t.Errorf("err = %v, want invalid --since", err)
+	}
+}
@@ -0,0 +1,432 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/hyperengineering/recall"
+	"github.com/hyperengineering/recall/internal/store"
+	"github.com/spf13/cobra"
+)
+
+// configSetting is a setting that can be persisted in the config file. The
+// file fills in for the environment variable: flag > env > file > default.
+type configSetting struct {
+	Key    string
+	Env    string
+	Flag   func() string // Value of the overriding flag, if any
+	Secret bool
+	Bool   bool
+	Value  func(recall.Config) string // Effective value after loadConfig; nil uses the setting itself
+}
+
+var configSettings = []configSetting{
+	{Key: "store", Env: "ENGRAM_STORE", Flag: func() string { return cfgStore },
+		Value: func(c recall.Config) string { return c.Store }},
+	{Key: "namespace", Env: "RECALL_NAMESPACE", Flag: func() string { return cfgNamespace },
+		Value: func(c recall.Config) string { return c.Namespace }},
+	{Key: "engram_url", Env: "ENGRAM_URL", Flag: func() string { return cfgEngramURL },
+		Value: func(c recall.Config) string { return c.EngramURL }},
+	{Key: "api_key", Env: "ENGRAM_API_KEY", Flag: func() string { return cfgAPIKey }, Secret: true,
+		Value: func(c recall.Config) string { return c.APIKey }},
+	{Key: "source_id", Env: "RECALL_SOURCE_ID", Flag: func() string { return cfgSourceID },
+		Value: func(c recall.Config) string { return c.SourceID }},
+	{Key: "signing_secret", Env: "RECALL_SIGNING_SECRET", Secret: true,
+		Value: func(c recall.Config) string { return c.SigningSecret }},
+	{Key: "conflict_policy", Env: "RECALL_CONFLICT_POLICY",
+		Value: func(c recall.Config) string { return string(c.ConflictPolicy) }},
+	{Key: "sync_canary", Env: "RECALL_SYNC_CANARY",
+		Value: func(c recall.Config) string { return c.SyncCanary }},
+	{Key: "sync_pause_file", Env: "RECALL_SYNC_PAUSE_FILE",
+		Value: func(c recall.Config) string { return c.SyncPauseFile }},
+	{Key: "dedup_exact", Env: "RECALL_DEDUP_EXACT", Bool: true,
+		Value: func(c recall.Config) string { return strconv.FormatBool(c.DedupExact) }},
+	{Key: "confirm_feedback", Env: "RECALL_CONFIRM_FEEDBACK", Bool: true,
+		Value: func(c recall.Config) string { return strconv.FormatBool(c.ConfirmFeedback) }},
+	{Key: "feedback_window", Env: "RECALL_FEEDBACK_WINDOW",
+		Value: func(c recall.Config) string {
+			if c.FeedbackWindow == 0 {
+				return ""
+			}
+			return c.FeedbackWindow.String()
+		}},
+	{Key: "feedback_coalesce_window", Env: "RECALL_FEEDBACK_COALESCE_WINDOW",
+		Value: func(c recall.Config) string {
+			if c.FeedbackCoalesceWindow == 0 {
+				return ""
+			}
+			return c.FeedbackCoalesceWindow.String()
+		}},
+	{Key: "remote_audit", Env: "RECALL_REMOTE_AUDIT", Bool: true,
+		Value: func(c recall.Config) string { return strconv.FormatBool(c.RemoteAudit) }},
+	{Key: "remote_audit_interval", Env: "RECALL_REMOTE_AUDIT_INTERVAL",
+		Value: func(c recall.Config) string {
+			if c.RemoteAuditInterval == 0 {
+				return ""
+			}
+			return c.RemoteAuditInterval.String()
+		}},
+	{Key: "remote_fallback_score", Env: "RECALL_REMOTE_FALLBACK_SCORE",
+		Value: func(c recall.Config) string {
+			if c.RemoteFallbackScore == 0 {
+				return ""
+			}
+			return strconv.FormatFloat(c.RemoteFallbackScore, 'g', -1, 64)
+		}},
+	{Key: "feedback_affinity", Env: "RECALL_FEEDBACK_AFFINITY",
+		Value: func(c recall.Config) string {
+			if c.FeedbackAffinity == 0 {
+				return ""
+			}
+			return strconv.FormatFloat(c.FeedbackAffinity, 'g', -1, 64)
+		}},
+	{Key: "legal_hold", Env: "RECALL_LEGAL_HOLD", Bool: true,
+		Value: func(c recall.Config) string { return strconv.FormatBool(c.LegalHold) }},
+	{Key: "query_log", Env: "RECALL_QUERY_LOG", Bool: true,
+		Value: func(c recall.Config) string { return strconv.FormatBool(c.QueryLog) }},
+	{Key: "inbox", Env: "RECALL_INBOX", Bool: true,
+		Value: func(c recall.Config) string { return strconv.FormatBool(c.Inbox) }},
+	{Key: "model_family", Env: "RECALL_MODEL_FAMILY",
+		Value: func(c recall.Config) string {
+			if c.TokenEstimator == nil {
+				return recall.DefaultTokenEstimator.Name()
+			}
+			return c.TokenEstimator.Name()
+		}},
+	{Key: "embedding_model", Env: "RECALL_EMBEDDING_MODEL",
+		Value: func(c recall.Config) string { return c.EmbeddingModel }},
+	{Key: "actor", Env: "RECALL_ACTOR",
+		Value: func(c recall.Config) string { return c.Actor }},
+	{Key: "policy", Env: "RECALL_POLICY"},
+	{Key: "source_trust", Env: "RECALL_SOURCE_TRUST"},
+	{Key: "category_min_confidence", Env: "RECALL_CATEGORY_MIN_CONFIDENCE"},
+	{Key: "soft_quota", Env: "RECALL_SOFT_QUOTA"},
+	{Key: "normalize", Env: "RECALL_NORMALIZE"},
+	{Key: "preserve_original", Env: "RECALL_PRESERVE_ORIGINAL", Bool: true,
+		Value: func(c recall.Config) string { return strconv.FormatBool(c.PreserveOriginal) }},
+	{Key: "sync_overflow", Env: "RECALL_SYNC_OVERFLOW", Bool: true,
+		Value: func(c recall.Config) string { return strconv.FormatBool(c.SyncOverflow) }},
+	{Key: "lazy_open", Env: "RECALL_LAZY_OPEN", Bool: true,
+		Value: func(c recall.Config) string { return strconv.FormatBool(c.LazyOpen) }},
+}
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect and edit configuration",
+	Long: `Inspect the effective configuration and persist settings to the config file.
+
+Settings resolve as flag > environment variable > config file > default.
+The config file is $RECALL_CONFIG, or recall/config.json under the user
+config directory (~/.config on Linux).`,
+}
+
+var configShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show the effective configuration",
+	Long: `Show the fully resolved configuration and where each value came from:
+flag, env, file, workspace (manifest or git remote), or default.
+Secrets are masked.
+
+Example:
+  recall config show
+  recall config show --json`,
+	Args: cobra.NoArgs,
+	RunE: runConfigShow,
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Persist a setting to the config file",
+	Long: `Persist a setting to the config file. Environment variables and flags
+still take precedence.
+
+Keys: ` + strings.Join(configKeys(), ", ") + `
+
+Example:
+  recall config set engram_url https://engram.example.com
+  recall config set dedup_exact true`,
+	Args: cobra.ExactArgs(2),
+	RunE: runConfigSet,
+}
+
+var configUnsetCmd = &cobra.Command{
+	Use:   "unset <key>",
+	Short: "Remove a setting from the config file",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runConfigUnset,
+}
+
+func init() {
+	configCmd.AddCommand(configShowCmd)
+	configCmd.AddCommand(configSetCmd)
+	configCmd.AddCommand(configUnsetCmd)
+	rootCmd.AddCommand(configCmd)
+}
+
+// CLIConfigValue for JSON output.
+type CLIConfigValue struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Source string `json:"source"`
+}
+
+// configFilePath returns the config file location: $RECALL_CONFIG, or
+// recall/config.json under the user config directory.
+func configFilePath() string {
+	if p := os.Getenv("RECALL_CONFIG"); p != "" {
+		return p
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "recall", "config.json")
+}
+
+// readConfigFile reads the config file. A missing file is empty.
+func readConfigFile() (map[string]string, error) {
+	values := map[string]string{}
+	path := configFilePath()
+	if path == "" {
+		return values, nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return values, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("config file: %w", err)
+	}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("config file %s: %w", path, err)
+	}
+	return values, nil
+}
+
+// writeConfigFile writes the config file. It may hold secrets, so it is
+// readable by the owner only.
+func writeConfigFile(values map[string]string) error {
+	path := configFilePath()
+	if path == "" {
+		return errors.New("config file: cannot determine user config directory; set RECALL_CONFIG")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("config file: %w", err)
+	}
+	data, err := json.MarshalIndent(values, "", "  ")
+	if err != nil {
+		return fmt.Errorf("config file: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0600); err != nil {
+		return fmt.Errorf("config file: %w", err)
+	}
+	return nil
+}
+
+// setting returns the environment variable env, falling back to the
+// matching config file entry. An unreadable config file is ignored here;
+// loadAndValidateConfig reports it.
+func setting(env string) string {
+	if v := os.Getenv(env); v != "" {
+		return v
+	}
+	values, err := readConfigFile()
+	if err != nil {
+		return ""
+	}
+	for _, s := range configSettings {
+		if s.Env == env {
+			return values[s.Key]
+		}
+	}
+	return ""
+}
+
+// configKeys returns the keys accepted by `recall config set`.
+func configKeys() []string {
+	keys := make([]string, len(configSettings))
+	for i, s := range configSettings {
+		keys[i] = s.Key
+	}
+	return keys
+}
+
+func findConfigSetting(key string) (configSetting, error) {
+	for _, s := range configSettings {
+		if s.Key == key {
+			return s, nil
+		}
+	}
+	return configSetting{}, fmt.Errorf("unknown config key %q (valid: %s)", key, strings.Join(configKeys(), ", "))
+}
+
+// maskSecret hides all but the last four characters of long secrets.
+func maskSecret(v string) string {
+	if v == "" {
+		return ""
+	}
+	if len(v) <= 8 {
+		return "****"
+	}
+	return "****" + v[len(v)-4:]
+}
+
+func runConfigShow(cmd *cobra.Command, args []string) error {
+	file, err := readConfigFile()
+	if err != nil {
+		return err
+	}
+	cfg := loadConfig()
+	defaults := recall.DefaultConfig()
+
+	var values []CLIConfigValue
+	fileSource := "default"
+	if os.Getenv("RECALL_CONFIG") != "" {
+		fileSource = "env"
+	}
+	values = append(values, CLIConfigValue{Key: "config_file", Value: configFilePath(), Source: fileSource})
+
+	for _, s := range configSettings {
+		v := setting(s.Env)
+		if s.Value != nil {
+			v = s.Value(cfg)
+		}
+		source := "default"
+		switch {
+		case s.Flag != nil && s.Flag() != "":
+			source = "flag"
+		case os.Getenv(s.Env) != "":
+			source = "env"
+		case file[s.Key] != "":
+			source = "file"
+		case s.Key == "store" && v != defaults.Store, s.Key == "namespace" && v != "":
+			source = "workspace"
+		}
+		if s.Secret {
+			v = maskSecret(v)
+		}
+		values = append(values, CLIConfigValue{Key: s.Key, Value: v, Source: source})
+	}
+
+	pathSource := "default"
+	switch {
+	case cfgLorePath != "":
+		pathSource = "flag"
+	case os.Getenv("RECALL_DB_PATH") != "":
+		pathSource = "env"
+	case os.Getenv("RECALL_HOME") != "":
+		pathSource = "env"
+	}
+	values = append(values,
+		CLIConfigValue{Key: "local_path", Value: cfg.LocalPath, Source: pathSource},
+		CLIConfigValue{Key: "store_root", Value: store.DefaultStoreRoot(), Source: "default"},
+		CLIConfigValue{Key: "sync_interval", Value: cfg.SyncInterval.String(), Source: "default"},
+		CLIConfigValue{Key: "auto_sync", Value: strconv.FormatBool(cfg.AutoSync), Source: "default"},
+	)
+
+	if outputJSON {
+		return outputAsJSON(cmd, values)
+	}
+	rows := make([][]string, len(values))
+	for i, v := range values {
+		rows[i] = []string{v.Key, v.Value, v.Source}
+	}
+	_, _ = fmt.Fprint(cmd.OutOrStdout(), renderTable([]string{"KEY", "VALUE", "SOURCE"}, rows))
+	return nil
+}
+
+func runConfigSet(cmd *cobra.Command, args []string) error {
+	key, value := args[0], args[1]
+	s, err := findConfigSetting(key)
+	if err != nil {
+		return err
+	}
+	if err := validateConfigValue(s, &value); err != nil {
+		return err
+	}
+
+	values, err := readConfigFile()
+	if err != nil {
+		return err
+	}
+	if value == "" {
+		delete(values, key)
+	} else {
+		values[key] = value
+	}
+	if err := writeConfigFile(values); err != nil {
+		return err
+	}
+
+	if s.Secret {
+		value = maskSecret(value)
+	}
+	printSuccess(cmd.OutOrStdout(), "Set %s = %s in %s", key, value, configFilePath())
+	if os.Getenv(s.Env) != "" {
+		printWarning(cmd.OutOrStdout(), "%s is set and overrides the config file", s.Env)
+	}
+	return nil
+}
+
+func runConfigUnset(cmd *cobra.Command, args []string) error {
+	if _, err := findConfigSetting(args[0]); err != nil {
+		return err
+	}
+	values, err := readConfigFile()
+	if err != nil {
+		return err
+	}
+	delete(values, args[0])
+	if err := writeConfigFile(values); err != nil {
+		return err
+	}
+	printSuccess(cmd.OutOrStdout(), "Removed %s from %s", args[0], configFilePath())
+	return nil
+}
+
+// validateConfigValue rejects values loadConfig would reject, and
+// normalizes booleans: false is stored as absent, since the environment
+// variables treat any non-empty value as true.
+func validateConfigValue(s configSetting, value *string) error {
+	switch {
+	case s.Bool:
+		b, err := strconv.ParseBool(*value)
+		if err != nil {
+			return fmt.Errorf("%s must be true or false", s.Key)
+		}
+		*value = ""
+		if b {
+			*value = "true"
+		}
+	case s.Key == "store":
+		if err := store.ValidateStoreID(*value); err != nil {
+			return fmt.Errorf("invalid store ID %q: %w", *value, err)
+		}
+	case s.Key == "conflict_policy":
+		if !recall.ConflictPolicy(*value).IsValid() {
+			return fmt.Errorf("conflict_policy must be %s or %s", recall.ConflictPolicyRemoteWins, recall.ConflictPolicyReview)
+		}
+	case s.Key == "source_trust":
+		if _, err := recall.ParseSourceTrust(*value); err != nil {
+			return err
+		}
+	case s.Key == "category_min_confidence":
+		if _, err := recall.ParseCategoryMinConfidence(*value); err != nil {
+			return err
+		}
+	case s.Key == "soft_quota":
+		if _, err := recall.ParseSoftQuota(*value); err != nil {
+			return err
+		}
+	case s.Key == "policy":
+		if _, err := recall.LoadAccessPolicy(*value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
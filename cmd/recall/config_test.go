@@ -0,0 +1,112 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// testConfigFile points RECALL_CONFIG at a fresh file for one test.
+func testConfigFile(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.json")
+	t.Setenv("RECALL_CONFIG", path)
+	return path
+}
+
+func TestCLI_ConfigSet_PersistsAndApplies(t *testing.T) {
+	cleanup := testEnv(t)
+	defer cleanup()
+	path := testConfigFile(t)
+	t.Setenv("ENGRAM_URL", "")
+
+	var stdout bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetArgs([]string{"config", "set", "engram_url", "https://engram.example.com"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("config set: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read config file: %v", err)
+	}
+	if !strings.Contains(string(data), `"engram_url": "https://engram.example.com"`) {
+		t.Errorf("config file = %s", data)
+	}
+	if info, _ := os.Stat(path); info.Mode().Perm() != 0600 {
+		t.Errorf("config file mode = %v, want 0600", info.Mode().Perm())
+	}
+
+	if got := loadConfig().EngramURL; got != "https://engram.example.com" {
+		t.Errorf("loadConfig().EngramURL = %q, want value from config file", got)
+	}
+
+	// The environment overrides the file
+	t.Setenv("ENGRAM_URL", "https://env.example.com")
+	if got := loadConfig().EngramURL; got != "https://env.example.com" {
+		t.Errorf("loadConfig().EngramURL = %q, want env value", got)
+	}
+}
+
+func TestCLI_ConfigSet_RejectsInvalid(t *testing.T) {
+	cleanup := testEnv(t)
+	defer cleanup()
+	testConfigFile(t)
+
+	for _, args := range [][]string{
+		{"config", "set", "no_such_key", "x"},
+		{"config", "set", "conflict_policy", "local_wins"},
+		{"config", "set", "dedup_exact", "maybe"},
+	} {
+		rootCmd.SetArgs(args)
+		if err := rootCmd.Execute(); err == nil {
+			t.Errorf("%v should fail", args)
+		}
+	}
+}
+
+func TestCLI_ConfigShow_SourcesAndMasking(t *testing.T) {
+	cleanup := testEnv(t)
+	defer cleanup()
+	path := testConfigFile(t)
+	if err := os.WriteFile(path, []byte(`{"api_key": "file-secret-key-1234", "actor": "ci-bot"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	outputJSON = true
+	rootCmd.SetArgs([]string{"config", "show", "--json"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("config show: %v", err)
+	}
+
+	var values []CLIConfigValue
+	if err := json.Unmarshal(stdout.Bytes(), &values); err != nil {
+		t.Fatalf("unmarshal: %v\n%s", err, stdout.String())
+	}
+	got := make(map[string]CLIConfigValue)
+	for _, v := range values {
+		got[v.Key] = v
+	}
+
+	if v := got["api_key"]; v.Source != "file" || v.Value != "****1234" {
+		t.Errorf("api_key = %+v, want masked value from file", v)
+	}
+	if v := got["actor"]; v.Source != "file" || v.Value != "ci-bot" {
+		t.Errorf("actor = %+v", v)
+	}
+	if v := got["source_id"]; v.Source != "env" || v.Value != "test-client" {
+		t.Errorf("source_id = %+v", v)
+	}
+	if v := got["config_file"]; v.Value != path {
+		t.Errorf("config_file = %+v, want %s", v, path)
+	}
+	if strings.Contains(stdout.String(), "file-secret-key") {
+		t.Error("config show leaked a secret")
+	}
+}
@@ -0,0 +1,304 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hyperengineering/recall"
+	"github.com/spf13/cobra"
+)
+
+var (
+	conflictsAll bool
+	conflictsUse string
+)
+
+var conflictsCmd = &cobra.Command{
+	Use:   "conflicts",
+	Short: "List sync conflicts awaiting review",
+	Long: `List lore entries whose remote changes collided with unpushed local edits.
+
+Conflicts are only recorded when the conflict policy is "review"
+(RECALL_CONFLICT_POLICY=review). Under the default "remote_wins" policy,
+remote changes overwrite local edits.
+
+Flags:
+  --all     Include already-resolved conflicts
+
+Example:
+  recall conflicts
+  recall conflicts --all --json
+  recall conflicts resolve 3`,
+	RunE: runConflicts,
+}
+
+var conflictsResolveCmd = &cobra.Command{
+	Use:   "resolve <id>",
+	Short: "Resolve a sync conflict",
+	Long: `Resolve a sync conflict by choosing a winner or entering a merged version.
+
+Without --use, shows a field-by-field diff of the local and remote versions
+and prompts for a choice:
+  l  keep the local version
+  r  take the remote version
+  e  edit a merged version (each field defaults to the local value)
+  s  skip without resolving
+
+The resolution is queued for push so Engram receives the winning state.
+
+Example:
+  recall conflicts resolve 3
+  recall conflicts resolve 3 --use remote`,
+	Args: cobra.ExactArgs(1),
+	RunE: runConflictsResolve,
+}
+
+func init() {
+	conflictsCmd.Flags().BoolVar(&conflictsAll, "all", false, "Include resolved conflicts")
+	conflictsResolveCmd.Flags().StringVar(&conflictsUse, "use", "", "Resolve non-interactively: local or remote")
+	conflictsCmd.AddCommand(conflictsResolveCmd)
+}
+
+// CLIConflict for JSON output.
+type CLIConflict struct {
+	ID             int64              `json:"id"`
+	LoreID         string             `json:"lore_id"`
+	RemoteSequence int64              `json:"remote_sequence"`
+	DetectedAt     string             `json:"detected_at"`
+	ResolvedAt     string             `json:"resolved_at,omitempty"`
+	Resolution     string             `json:"resolution,omitempty"`
+	Diffs          []recall.FieldDiff `json:"diffs"`
+}
+
+func runConflicts(cmd *cobra.Command, args []string) error {
+	cfg, err := loadAndValidateConfig()
+	if err != nil {
+		return err
+	}
+
+	client, err := recall.New(cfg)
+	if err != nil {
+		return fmt.Errorf("initialize client: %w", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	conflicts, err := client.Conflicts(conflictsAll)
+	if err != nil {
+		return fmt.Errorf("list conflicts: %w", err)
+	}
+
+	if outputJSON {
+		items := make([]CLIConflict, 0, len(conflicts))
+		for i := range conflicts {
+			items = append(items, toCLIConflict(&conflicts[i]))
+		}
+		return outputAsJSON(cmd, items)
+	}
+
+	out := cmd.OutOrStdout()
+	if len(conflicts) == 0 {
+		printMuted(out, "No conflicts.")
+		return nil
+	}
+
+	rows := make([][]string, 0, len(conflicts))
+	for i := range conflicts {
+		c := &conflicts[i]
+		fields := make([]string, 0, 4)
+		for _, d := range c.FieldDiffs() {
+			fields = append(fields, d.Field)
+		}
+		status := "unresolved"
+		if c.ResolvedAt != nil {
+			status = string(c.Resolution)
+		}
+		rows = append(rows, []string{
+			strconv.FormatInt(c.ID, 10),
+			c.LoreID,
+			strings.Join(fields, ", "),
+			c.DetectedAt.Format("2006-01-02 15:04"),
+			status,
+		})
+	}
+	_, _ = fmt.Fprint(out, renderTable([]string{"ID", "LORE", "FIELDS", "DETECTED", "STATUS"}, rows))
+	return nil
+}
+
+func runConflictsResolve(cmd *cobra.Command, args []string) error {
+	id, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid conflict id %q", args[0])
+	}
+
+	cfg, err := loadAndValidateConfig()
+	if err != nil {
+		return err
+	}
+
+	client, err := recall.New(cfg)
+	if err != nil {
+		return fmt.Errorf("initialize client: %w", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	out := cmd.OutOrStdout()
+
+	var resolution recall.ConflictResolution
+	var merged *recall.Lore
+
+	switch strings.ToLower(conflictsUse) {
+	case "local":
+		resolution = recall.ConflictResolutionLocal
+	case "remote":
+		resolution = recall.ConflictResolutionRemote
+	case "":
+		conflict, err := findConflict(client, id)
+		if err != nil {
+			return err
+		}
+		resolution, merged, err = promptConflictResolution(cmd.InOrStdin(), out, conflict)
+		if err != nil {
+			return err
+		}
+		if resolution == "" {
+			printMuted(out, "Skipped.")
+			return nil
+		}
+	default:
+		return fmt.Errorf("invalid --use value %q: must be local or remote", conflictsUse)
+	}
+
+	lore, err := client.ResolveConflict(id, resolution, merged)
+	if errors.Is(err, recall.ErrNotFound) {
+		return fmt.Errorf("conflict %d not found or already resolved", id)
+	}
+	if err != nil {
+		return fmt.Errorf("resolve conflict: %w", err)
+	}
+
+	if outputJSON {
+		return outputAsJSON(cmd, map[string]interface{}{
+			"id":         id,
+			"resolution": resolution,
+			"lore":       lore,
+		})
+	}
+	printSuccess(out, "Resolved conflict %d (%s)", id, resolution)
+	_, _ = fmt.Fprintf(out, "  Lore: %s\n", lore.ID)
+	printMuted(out, "Run 'recall sync push' to send the resolution to Engram")
+	return nil
+}
+
+// findConflict looks up an unresolved conflict by ID.
+func findConflict(client *recall.Client, id int64) (*recall.SyncConflict, error) {
+	conflicts, err := client.Conflicts(false)
+	if err != nil {
+		return nil, fmt.Errorf("list conflicts: %w", err)
+	}
+	for i := range conflicts {
+		if conflicts[i].ID == id {
+			return &conflicts[i], nil
+		}
+	}
+	return nil, fmt.Errorf("conflict %d not found or already resolved", id)
+}
+
+// promptConflictResolution shows the field diffs and reads the user's choice.
+// Returns an empty resolution if the user skips.
+func promptConflictResolution(in io.Reader, out io.Writer, c *recall.SyncConflict) (recall.ConflictResolution, *recall.Lore, error) {
+	var sb strings.Builder
+	for _, d := range c.FieldDiffs() {
+		_, _ = fmt.Fprintf(&sb, "%s\n  local:  %s\n  remote: %s\n", d.Field, d.Local, d.Remote)
+	}
+	_, _ = fmt.Fprint(out, renderPanel(fmt.Sprintf("Conflict %d: %s", c.ID, c.LoreID), strings.TrimRight(sb.String(), "\n")))
+	_, _ = fmt.Fprintln(out)
+
+	reader := bufio.NewReader(in)
+	for {
+		_, _ = fmt.Fprint(out, "Keep [l]ocal, take [r]emote, [e]dit merged, or [s]kip? ")
+		choice, err := readPromptLine(reader)
+		if err != nil {
+			return "", nil, err
+		}
+		switch strings.ToLower(choice) {
+		case "l", "local":
+			return recall.ConflictResolutionLocal, nil, nil
+		case "r", "remote":
+			return recall.ConflictResolutionRemote, nil, nil
+		case "s", "skip":
+			return "", nil, nil
+		case "e", "edit":
+			merged, err := promptMergedLore(reader, out, &c.Local)
+			if err != nil {
+				return "", nil, err
+			}
+			return recall.ConflictResolutionMerged, merged, nil
+		}
+	}
+}
+
+// promptMergedLore asks for each editable field, defaulting to the local value.
+func promptMergedLore(reader *bufio.Reader, out io.Writer, local *recall.Lore) (*recall.Lore, error) {
+	merged := *local
+	ask := func(field, current string) (string, error) {
+		_, _ = fmt.Fprintf(out, "%s [%s]: ", field, current)
+		v, err := readPromptLine(reader)
+		if err != nil || v == "" {
+			return current, err
+		}
+		return v, nil
+	}
+
+	var err error
+	if merged.Content, err = ask("content", local.Content); err != nil {
+		return nil, err
+	}
+	if merged.Context, err = ask("context", local.Context); err != nil {
+		return nil, err
+	}
+	category, err := ask("category", string(local.Category))
+	if err != nil {
+		return nil, err
+	}
+	merged.Category = recall.Category(category)
+	confidence, err := ask("confidence", strconv.FormatFloat(local.Confidence, 'f', 2, 64))
+	if err != nil {
+		return nil, err
+	}
+	if merged.Confidence, err = strconv.ParseFloat(confidence, 64); err != nil {
+		return nil, fmt.Errorf("invalid confidence %q", confidence)
+	}
+	return &merged, nil
+}
+
+// readPromptLine reads one trimmed line of input. EOF after partial input is accepted.
+func readPromptLine(reader *bufio.Reader) (string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", fmt.Errorf("read input: %w", err)
+	}
+	return strings.TrimSpace(line), nil
+}
+
+func toCLIConflict(c *recall.SyncConflict) CLIConflict {
+	item := CLIConflict{
+		ID:             c.ID,
+		LoreID:         c.LoreID,
+		RemoteSequence: c.RemoteSequence,
+		DetectedAt:     c.DetectedAt.Format(time.RFC3339),
+		Resolution:     string(c.Resolution),
+		Diffs:          c.FieldDiffs(),
+	}
+	if c.ResolvedAt != nil {
+		item.ResolvedAt = c.ResolvedAt.Format(time.RFC3339)
+	}
+	if item.Diffs == nil {
+		item.Diffs = []recall.FieldDiff{}
+	}
+	return item
+}
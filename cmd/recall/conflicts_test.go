@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/hyperengineering/recall"
+)
+
+func testConflict() *recall.SyncConflict {
+	return &recall.SyncConflict{
+		ID:     1,
+		LoreID: "lore-1",
+		Local:  recall.Lore{ID: "lore-1", Content: "local", Category: recall.CategoryPatternOutcome, Confidence: 0.6},
+		Remote: recall.Lore{ID: "lore-1", Content: "remote", Category: recall.CategoryPatternOutcome, Confidence: 0.6},
+	}
+}
+
+func TestCLI_Conflicts_Empty(t *testing.T) {
+	defer testEnv(t)()
+	defer func() { conflictsAll = false }()
+
+	var stdout bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetArgs([]string{"conflicts"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(stdout.String(), "No conflicts") {
+		t.Errorf("output = %q, want 'No conflicts'", stdout.String())
+	}
+}
+
+func TestCLI_ConflictsResolve_NotFound(t *testing.T) {
+	defer testEnv(t)()
+	defer func() { conflictsUse = "" }()
+
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"conflicts", "resolve", "42", "--use", "local"})
+
+	err := rootCmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("err = %v, want not found", err)
+	}
+}
+
+func TestPromptConflictResolution_ShowsDiffAndPicksRemote(t *testing.T) {
+	var out bytes.Buffer
+	resolution, merged, err := promptConflictResolution(strings.NewReader("x\nr\n"), &out, testConflict())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resolution != recall.ConflictResolutionRemote || merged != nil {
+		t.Errorf("resolution = %q, merged = %v", resolution, merged)
+	}
+	output := out.String()
+	if !strings.Contains(output, "local:  local") || !strings.Contains(output, "remote: remote") {
+		t.Errorf("output should show field diff, got %q", output)
+	}
+	if strings.Contains(output, "confidence") {
+		t.Error("unchanged fields should not be shown")
+	}
+}
+
+func TestPromptConflictResolution_EditMerged(t *testing.T) {
+	input := "e\nmerged content\n\n\n0.9\n"
+	resolution, merged, err := promptConflictResolution(strings.NewReader(input), &bytes.Buffer{}, testConflict())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resolution != recall.ConflictResolutionMerged {
+		t.Fatalf("resolution = %q, want merged", resolution)
+	}
+	if merged.Content != "merged content" || merged.Category != recall.CategoryPatternOutcome || merged.Confidence != 0.9 {
+		t.Errorf("merged = %+v", merged)
+	}
+}
+
+func TestPromptConflictResolution_Skip(t *testing.T) {
+	resolution, _, err := promptConflictResolution(strings.NewReader("s\n"), &bytes.Buffer{}, testConflict())
+	if err != nil || resolution != "" {
+		t.Errorf("resolution = %q, err = %v; want skip", resolution, err)
+	}
+}
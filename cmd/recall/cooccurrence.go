@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hyperengineering/recall"
+	"github.com/spf13/cobra"
+)
+
+var cooccurrenceCmd = &cobra.Command{
+	Use:   "cooccurrence",
+	Short: "Show which categories, tags and contexts occur together",
+	Long: `Show how the store's taxonomy is used: the contexts that generate the
+most lore, the categories recorded under the same contexts, and the tags
+used with each category and with each other. Tags are the entries'
+sources, such as starter-pack:<name> or a clipped URL.
+
+Use it to spot categories that overlap, tags that always travel
+together, and contexts worth capturing more carefully.
+
+Example:
+  recall cooccurrence
+  recall cooccurrence --top 20 --json`,
+	Args: cobra.NoArgs,
+	RunE: runCooccurrence,
+}
+
+var cooccurrenceTop int
+
+func init() {
+	cooccurrenceCmd.Flags().IntVar(&cooccurrenceTop, "top", recall.DefaultCooccurrenceLimit, "Rows to show in each list")
+}
+
+func runCooccurrence(cmd *cobra.Command, args []string) error {
+	cfg, err := loadAndValidateConfig()
+	if err != nil {
+		return err
+	}
+	client, err := recall.New(cfg)
+	if err != nil {
+		return fmt.Errorf("initialize client: %w", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	report, err := client.Cooccurrence(context.Background(), cooccurrenceTop)
+	if err != nil {
+		return fmt.Errorf("cooccurrence: %w", err)
+	}
+	if outputJSON {
+		return outputAsJSON(cmd, report)
+	}
+
+	out := cmd.OutOrStdout()
+	if report.Entries == 0 {
+		printMuted(out, "No lore to analyze")
+		return nil
+	}
+	printInfo(out, "Co-occurrence across %d entries", report.Entries)
+
+	_, _ = fmt.Fprintln(out, "\nTop contexts:")
+	if len(report.Contexts) == 0 {
+		printMuted(out, "No entries have a context")
+	} else {
+		rows := make([][]string, 0, len(report.Contexts))
+		for _, c := range report.Contexts {
+			categories := make([]string, len(c.Categories))
+			for i, category := range c.Categories {
+				categories[i] = string(category)
+			}
+			rows = append(rows, []string{recall.TruncateContent(c.Context, 50), strconv.Itoa(c.Entries), strings.Join(categories, ", ")})
+		}
+		_, _ = fmt.Fprintln(out, renderTable([]string{"CONTEXT", "ENTRIES", "CATEGORIES"}, rows))
+	}
+
+	_, _ = fmt.Fprintln(out, "\nCategories sharing contexts:")
+	if len(report.CategoryPairs) == 0 {
+		printMuted(out, "No context has more than one category")
+	} else {
+		rows := make([][]string, 0, len(report.CategoryPairs))
+		for _, p := range report.CategoryPairs {
+			rows = append(rows, []string{string(p.A), string(p.B), strconv.Itoa(p.Contexts)})
+		}
+		_, _ = fmt.Fprintln(out, renderTable([]string{"CATEGORY", "WITH", "CONTEXTS"}, rows))
+	}
+
+	_, _ = fmt.Fprintln(out, "\nTags by category:")
+	if len(report.TagCategories) == 0 {
+		printMuted(out, "No entries have tags")
+		return nil
+	}
+	rows := make([][]string, 0, len(report.TagCategories))
+	for _, tc := range report.TagCategories {
+		rows = append(rows, []string{recall.TruncateContent(tc.Tag, 50), string(tc.Category), strconv.Itoa(tc.Entries)})
+	}
+	_, _ = fmt.Fprintln(out, renderTable([]string{"TAG", "CATEGORY", "ENTRIES"}, rows))
+
+	if len(report.TagPairs) > 0 {
+		_, _ = fmt.Fprintln(out, "\nTags used together:")
+		rows := make([][]string, 0, len(report.TagPairs))
+		for _, p := range report.TagPairs {
+			rows = append(rows, []string{recall.TruncateContent(p.A, 40), recall.TruncateContent(p.B, 40), strconv.Itoa(p.Entries)})
+		}
+		_, _ = fmt.Fprintln(out, renderTable([]string{"TAG", "WITH", "ENTRIES"}, rows))
+	}
+	return nil
+}
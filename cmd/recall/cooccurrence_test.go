@@ -0,0 +1,45 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/hyperengineering/recall"
+)
+
+func TestCLI_Cooccurrence(t *testing.T) {
+	defer testEnv(t)()
+	defer func() { cooccurrenceTop = recall.DefaultCooccurrenceLimit }()
+
+	run := func(args ...string) string {
+		t.Helper()
+		var stdout bytes.Buffer
+		rootCmd.SetOut(&stdout)
+		rootCmd.SetArgs(args)
+		if err := rootCmd.Execute(); err != nil {
+			t.Fatalf("%v: unexpected error: %v", args, err)
+		}
+		return stdout.String()
+	}
+
+	if out := run("cooccurrence"); !strings.Contains(out, "No lore to analyze") {
+		t.Errorf("empty store = %q", out)
+	}
+	run("record", "--content", "Mock the clock in retry tests", "-c", "TESTING_STRATEGY", "--context", "billing")
+	run("record", "--content", "Invoices round half to even", "-c", "EDGE_CASE_DISCOVERY", "--context", "billing")
+
+	out := run("cooccurrence")
+	if !strings.Contains(out, "billing") || !strings.Contains(out, "EDGE_CASE_DISCOVERY") || !strings.Contains(out, "No entries have tags") {
+		t.Errorf("cooccurrence = %q", out)
+	}
+
+	var report recall.CooccurrenceReport
+	if err := json.Unmarshal([]byte(run("cooccurrence", "--top", "5", "--json")), &report); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(report.Contexts) != 1 || report.Contexts[0].Entries != 2 || len(report.CategoryPairs) != 1 {
+		t.Errorf("report = %+v, want one context with 2 entries and one category pair", report)
+	}
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/hyperengineering/recall"
+	"github.com/spf13/cobra"
+)
+
+var (
+	diffSummary bool
+	diffFull    bool
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Show divergence between local lore and Engram",
+	Long: `Compare the local store with Engram without changing either side.
+
+Reports entries that exist only locally, only on Engram, and entries
+present on both sides whose fields differ.
+
+Flags:
+  --summary  Show counts only (default)
+  --full     List every divergent entry with field-level differences
+
+Example:
+  recall diff
+  recall diff --full
+  recall diff --full --json`,
+	RunE: runDiff,
+}
+
+func init() {
+	diffCmd.Flags().BoolVar(&diffSummary, "summary", false, "Show counts only (default)")
+	diffCmd.Flags().BoolVar(&diffFull, "full", false, "Show every divergent entry with field differences")
+	diffCmd.MarkFlagsMutuallyExclusive("summary", "full")
+}
+
+// CLIDiffSummary for JSON output.
+type CLIDiffSummary struct {
+	LocalOnly      int   `json:"local_only"`
+	RemoteOnly     int   `json:"remote_only"`
+	Differing      int   `json:"differing"`
+	Identical      int   `json:"identical"`
+	RemoteSequence int64 `json:"remote_sequence"`
+	InSync         bool  `json:"in_sync"`
+}
+
+func runDiff(cmd *cobra.Command, args []string) error {
+	cfg, err := loadAndValidateConfig()
+	if err != nil {
+		return err
+	}
+
+	if cfg.IsOffline() {
+		return fmt.Errorf("diff unavailable: ENGRAM_URL not configured (offline-only mode)")
+	}
+
+	client, err := recall.New(cfg)
+	if err != nil {
+		return fmt.Errorf("initialize client: %w", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	var result *recall.DiffResult
+	out := cmd.OutOrStdout()
+	err = runWithSpinner(out, "Comparing with Engram", func() error {
+		var err error
+		result, err = client.Diff(ctx)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("diff: %w", err)
+	}
+
+	if outputJSON {
+		if diffFull {
+			return outputAsJSON(cmd, result)
+		}
+		return outputAsJSON(cmd, CLIDiffSummary{
+			LocalOnly:      len(result.LocalOnly),
+			RemoteOnly:     len(result.RemoteOnly),
+			Differing:      len(result.Differing),
+			Identical:      result.Identical,
+			RemoteSequence: result.RemoteSequence,
+			InSync:         result.InSync(),
+		})
+	}
+
+	if result.InSync() {
+		printSuccess(out, "Local store matches Engram (%d entries, sequence %d)", result.Identical, result.RemoteSequence)
+		return nil
+	}
+
+	printWarning(out, "Local store diverges from Engram (sequence %d)", result.RemoteSequence)
+	_, _ = fmt.Fprintf(out, "  Only local:  %d\n", len(result.LocalOnly))
+	_, _ = fmt.Fprintf(out, "  Only remote: %d\n", len(result.RemoteOnly))
+	_, _ = fmt.Fprintf(out, "  Differing:   %d\n", len(result.Differing))
+	_, _ = fmt.Fprintf(out, "  Identical:   %d\n", result.Identical)
+
+	if diffFull {
+		outputDiffFull(out, result)
+	}
+	return nil
+}
+
+// outputDiffFull lists each divergent entry.
+func outputDiffFull(out io.Writer, result *recall.DiffResult) {
+	for _, l := range result.LocalOnly {
+		_, _ = fmt.Fprintf(out, "\n+ %s (local only)\n  %s\n", l.ID, l.Content)
+	}
+	for _, l := range result.RemoteOnly {
+		_, _ = fmt.Fprintf(out, "\n- %s (remote only)\n  %s\n", l.ID, l.Content)
+	}
+	for _, d := range result.Differing {
+		_, _ = fmt.Fprintf(out, "\n~ %s\n", d.ID)
+		for _, f := range d.Fields {
+			_, _ = fmt.Fprintf(out, "  %s\n    local:  %s\n    remote: %s\n", f.Field, f.Local, f.Remote)
+		}
+	}
+}
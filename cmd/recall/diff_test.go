@@ -0,0 +1,19 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestCLI_Diff_OfflineError(t *testing.T) {
+	defer testEnv(t)()
+
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"diff"})
+
+	err := rootCmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "offline") {
+		t.Errorf("err = %v, want offline error", err)
+	}
+}
@@ -2,7 +2,11 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/hyperengineering/recall"
@@ -17,15 +21,29 @@ var feedbackCmd = &cobra.Command{
 Confidence adjustments:
   - helpful:      +0.08 confidence
   - incorrect:    -0.15 confidence
+  - outdated:     -0.30 confidence
   - not_relevant:  no change
 
 Single-item mode:
   recall feedback --id <lore-id> --type helpful
   recall feedback --id L1 --type incorrect
 
+Outdated lore can be replaced in one step. The replacement is recorded in
+the same category and supersedes the old entry, which queries then rank
+below it (or hide with --hide-superseded):
+  recall feedback --id L2 --type outdated --replace "Use pgx v5; v4 is EOL"
+
 Batch mode:
   recall feedback --helpful L1,L2 --incorrect L3
-  recall feedback --helpful "queue consumer idempotency"`,
+  recall feedback --helpful "queue consumer idempotency"
+
+With RECALL_FEEDBACK_WINDOW set (e.g. 1h), feedback you already gave an
+entry within the window, with the same type, is skipped.
+
+File mode (CSV with columns id_or_ref,outcome,reason, or a JSON array of
+objects with those keys; "-" reads stdin):
+  recall feedback --file retro.csv --dry-run
+  recall feedback --file retro.json`,
 	RunE: runFeedback,
 }
 
@@ -38,19 +56,33 @@ var (
 	feedbackHelpful     string
 	feedbackNotRelevant string
 	feedbackIncorrect   string
+	feedbackOutdated    string
+
+	// Outdated replacement
+	feedbackReplace string
+
+	// File mode
+	feedbackFile   string
+	feedbackDryRun bool
 )
 
-var validFeedbackTypes = []string{"helpful", "incorrect", "not_relevant"}
+var validFeedbackTypes = []string{"helpful", "incorrect", "not_relevant", "outdated"}
 
 func init() {
 	// Single-item flags
 	feedbackCmd.Flags().StringVar(&feedbackID, "id", "", "Lore ID or session ref (L1, L2, ...)")
-	feedbackCmd.Flags().StringVar(&feedbackType, "type", "", "Feedback type: helpful, incorrect, not_relevant")
+	feedbackCmd.Flags().StringVar(&feedbackType, "type", "", "Feedback type: helpful, incorrect, not_relevant, outdated")
+	feedbackCmd.Flags().StringVar(&feedbackReplace, "replace", "", "With --type outdated, record this content as the replacement")
 
 	// Batch flags
 	feedbackCmd.Flags().StringVar(&feedbackHelpful, "helpful", "", "Comma-separated helpful refs")
 	feedbackCmd.Flags().StringVar(&feedbackNotRelevant, "not-relevant", "", "Comma-separated not-relevant refs")
 	feedbackCmd.Flags().StringVar(&feedbackIncorrect, "incorrect", "", "Comma-separated incorrect refs")
+	feedbackCmd.Flags().StringVar(&feedbackOutdated, "outdated", "", "Comma-separated outdated refs")
+
+	// File flags
+	feedbackCmd.Flags().StringVar(&feedbackFile, "file", "", "CSV or JSON file of id_or_ref,outcome,reason rows (- for stdin)")
+	feedbackCmd.Flags().BoolVar(&feedbackDryRun, "dry-run", false, "With --file, show each row's effect without applying it")
 }
 
 func runFeedback(cmd *cobra.Command, args []string) error {
@@ -61,14 +93,22 @@ func runFeedback(cmd *cobra.Command, args []string) error {
 
 	// Determine mode: single-item vs batch
 	singleMode := feedbackID != "" || feedbackType != ""
-	batchMode := feedbackHelpful != "" || feedbackNotRelevant != "" || feedbackIncorrect != ""
+	batchMode := feedbackHelpful != "" || feedbackNotRelevant != "" || feedbackIncorrect != "" || feedbackOutdated != ""
+
+	fileMode := feedbackFile != ""
 
 	if singleMode && batchMode {
-		return fmt.Errorf("cannot mix --id/--type with batch flags (--helpful, --incorrect, --not-relevant)")
+		return fmt.Errorf("cannot mix --id/--type with batch flags (--helpful, --incorrect, --not-relevant, --outdated)")
+	}
+	if fileMode && (singleMode || batchMode) {
+		return fmt.Errorf("cannot mix --file with --id/--type or batch flags")
+	}
+	if feedbackDryRun && !fileMode {
+		return fmt.Errorf("--dry-run requires --file")
 	}
 
-	if !singleMode && !batchMode {
-		return fmt.Errorf("provide --id and --type, or use batch flags (--helpful, --incorrect, --not-relevant)")
+	if !singleMode && !batchMode && !fileMode {
+		return fmt.Errorf("provide --id and --type, use batch flags (--helpful, --incorrect, --not-relevant, --outdated), or --file")
 	}
 
 	client, err := recall.New(cfg)
@@ -80,9 +120,35 @@ func runFeedback(cmd *cobra.Command, args []string) error {
 	if singleMode {
 		return runFeedbackSingle(cmd, client)
 	}
+	if fileMode {
+		return runFeedbackFile(cmd, client)
+	}
 	return runFeedbackBatch(cmd, client)
 }
 
+func runFeedbackFile(cmd *cobra.Command, client *recall.Client) error {
+	var r io.Reader = cmd.InOrStdin()
+	if feedbackFile != "-" {
+		f, err := os.Open(feedbackFile)
+		if err != nil {
+			return fmt.Errorf("open feedback file: %w", err)
+		}
+		defer func() { _ = f.Close() }()
+		r = f
+	}
+
+	format := recall.FeedbackFileCSV
+	if strings.EqualFold(filepath.Ext(feedbackFile), ".json") {
+		format = recall.FeedbackFileJSON
+	}
+
+	result, err := client.FeedbackFromReader(r, recall.FeedbackFileOptions{Format: format, DryRun: feedbackDryRun})
+	if err != nil {
+		return fmt.Errorf("apply feedback: %w", err)
+	}
+	return outputFeedbackFile(cmd, result)
+}
+
 func runFeedbackSingle(cmd *cobra.Command, client *recall.Client) error {
 	if feedbackID == "" {
 		return fmt.Errorf("--id is required in single-item mode")
@@ -96,7 +162,21 @@ func runFeedbackSingle(cmd *cobra.Command, client *recall.Client) error {
 		return err
 	}
 
+	if feedbackReplace != "" {
+		if ft != recall.Outdated {
+			return fmt.Errorf("--replace requires --type outdated")
+		}
+		replacement, err := client.ReplaceOutdated(feedbackID, feedbackReplace)
+		if err != nil {
+			return fmt.Errorf("replace outdated lore: %w", err)
+		}
+		return outputFeedbackReplace(cmd, feedbackID, replacement)
+	}
+
 	lore, err := client.Feedback(feedbackID, ft)
+	if errors.Is(err, recall.ErrFeedbackSkipped) {
+		return outputFeedbackSkipped(cmd, feedbackID, ft)
+	}
 	if err != nil {
 		return fmt.Errorf("apply feedback: %w", err)
 	}
@@ -116,6 +196,9 @@ func runFeedbackBatch(cmd *cobra.Command, client *recall.Client) error {
 	if feedbackIncorrect != "" {
 		params.Incorrect = splitAndTrim(feedbackIncorrect)
 	}
+	if feedbackOutdated != "" {
+		params.Outdated = splitAndTrim(feedbackOutdated)
+	}
 
 	result, err := client.FeedbackBatch(context.Background(), params)
 	if err != nil {
@@ -134,6 +217,8 @@ func parseFeedbackType(s string) (recall.FeedbackType, error) {
 		return recall.Incorrect, nil
 	case "not_relevant", "not-relevant", "notrelevant":
 		return recall.NotRelevant, nil
+	case "outdated":
+		return recall.Outdated, nil
 	default:
 		return "", fmt.Errorf("invalid feedback type %q: valid types are %s",
 			s, strings.Join(validFeedbackTypes, ", "))
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hyperengineering/recall"
+	"github.com/spf13/cobra"
+)
+
+var inboxCmd = &cobra.Command{
+	Use:   "inbox",
+	Short: "List lore staged for approval",
+	Long: `List lore recorded while RECALL_INBOX was set, oldest first.
+
+Staged lore is local-only: it is not returned by queries and not pushed to
+Engram until approved. Approving records the entry under the same ID, so
+it syncs like any other lore; rejecting discards it.
+
+Example:
+  recall inbox
+  recall inbox approve 01HQXYZ... 01HQXZA...
+  recall inbox reject 01HQXYZ...`,
+	Args: cobra.NoArgs,
+	RunE: runInbox,
+}
+
+var inboxApproveCmd = &cobra.Command{
+	Use:   "approve <id>...",
+	Short: "Record staged lore so it syncs",
+	Args:  cobra.MinimumNArgs(1),
+	RunE:  runInboxApprove,
+}
+
+var inboxRejectCmd = &cobra.Command{
+	Use:   "reject <id>...",
+	Short: "Discard staged lore",
+	Args:  cobra.MinimumNArgs(1),
+	RunE:  runInboxReject,
+}
+
+func init() {
+	inboxCmd.AddCommand(inboxApproveCmd)
+	inboxCmd.AddCommand(inboxRejectCmd)
+}
+
+func runInbox(cmd *cobra.Command, args []string) error {
+	cfg, err := loadAndValidateConfig()
+	if err != nil {
+		return err
+	}
+	client, err := recall.New(cfg)
+	if err != nil {
+		return fmt.Errorf("initialize client: %w", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	entries, err := client.Inbox()
+	if err != nil {
+		return fmt.Errorf("inbox: %w", err)
+	}
+	if outputJSON {
+		if entries == nil {
+			entries = []recall.InboxEntry{}
+		}
+		return outputAsJSON(cmd, entries)
+	}
+
+	out := cmd.OutOrStdout()
+	if len(entries) == 0 {
+		printMuted(out, "Inbox is empty")
+		return nil
+	}
+	rows := make([][]string, 0, len(entries))
+	for _, e := range entries {
+		content := strings.ReplaceAll(e.Content, "\n", " ")
+		if len(content) > 60 {
+			content = content[:57] + "..."
+		}
+		rows = append(rows, []string{e.ID, string(e.Category), fmt.Sprintf("%.2f", e.Confidence), e.Actor, e.CreatedAt.Format("2006-01-02 15:04"), content})
+	}
+	_, _ = fmt.Fprintln(out, renderTable([]string{"ID", "CATEGORY", "CONFIDENCE", "ACTOR", "STAGED", "CONTENT"}, rows))
+	return nil
+}
+
+func runInboxApprove(cmd *cobra.Command, args []string) error {
+	cfg, err := loadAndValidateConfig()
+	if err != nil {
+		return err
+	}
+	client, err := recall.New(cfg)
+	if err != nil {
+		return fmt.Errorf("initialize client: %w", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	approved := make([]*recall.Lore, 0, len(args))
+	for _, id := range args {
+		lore, err := client.ApproveInbox(id)
+		if err != nil {
+			return fmt.Errorf("approve %s: %w", id, err)
+		}
+		approved = append(approved, lore)
+		if !outputJSON {
+			printSuccess(cmd.OutOrStdout(), "Approved %s", shortID(lore.ID))
+		}
+	}
+	if outputJSON {
+		return outputAsJSON(cmd, approved)
+	}
+	return nil
+}
+
+func runInboxReject(cmd *cobra.Command, args []string) error {
+	cfg, err := loadAndValidateConfig()
+	if err != nil {
+		return err
+	}
+	client, err := recall.New(cfg)
+	if err != nil {
+		return fmt.Errorf("initialize client: %w", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	out := cmd.OutOrStdout()
+	rejected := make([]string, 0, len(args))
+	for _, id := range args {
+		removed, err := client.RejectInbox(id)
+		if err != nil {
+			return fmt.Errorf("reject %s: %w", id, err)
+		}
+		if !removed {
+			return fmt.Errorf("reject %s: %w", id, recall.ErrNotFound)
+		}
+		rejected = append(rejected, id)
+		if !outputJSON {
+			printSuccess(out, "Rejected %s", shortID(id))
+		}
+	}
+	if outputJSON {
+		return outputAsJSON(cmd, map[string]any{"rejected": rejected})
+	}
+	return nil
+}
@@ -1,6 +1,9 @@
 package main
 
 import (
+	"context"
+	"time"
+
 	"github.com/hyperengineering/recall"
 	recallmcp "github.com/hyperengineering/recall/mcp"
 	"github.com/spf13/cobra"
@@ -46,6 +49,9 @@ func runMCP(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	// The server is long-lived, so it rolls rolling stores over itself
+	cfg.AutoRollover = true
+
 	// Create Recall client - this persists for the server lifetime
 	client, err := recall.New(cfg)
 	if err != nil {
@@ -53,6 +59,11 @@ func runMCP(cmd *cobra.Command, args []string) error {
 	}
 	defer func() { _ = client.Close() }()
 
+	// Warm the store before the first tool call; failures only cost speed
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	_, _ = client.Warmup(ctx)
+	cancel()
+
 	// Start MCP server over stdio
 	server := recallmcp.NewServer(client)
 	return server.Run()
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hyperengineering/recall"
+	"github.com/spf13/cobra"
+)
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Finish migrating the local store to the current format",
+	Long: `Bring the local store up to date. Schema migrations run whenever the
+store opens; this also converts changes left in the legacy sync queue into
+change_log entries, after which only the change_log tracks unpushed work.
+With RECALL_CONFIRM_FEEDBACK set, queued feedback stays for reconciliation.
+
+Running it again is safe.
+
+Example:
+  recall migrate
+  recall migrate --json`,
+	RunE: runMigrate,
+}
+
+// migrateOutput is the JSON output of recall migrate.
+type migrateOutput struct {
+	SchemaVersion string                      `json:"schema_version"`
+	SyncQueue     *recall.SyncQueueConversion `json:"sync_queue"`
+}
+
+func runMigrate(cmd *cobra.Command, args []string) error {
+	cfg, err := loadAndValidateConfig()
+	if err != nil {
+		return err
+	}
+
+	client, err := recall.New(cfg)
+	if err != nil {
+		return fmt.Errorf("initialize client: %w", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	conversion, err := client.MigrateSyncQueue(context.Background())
+	if err != nil {
+		return fmt.Errorf("migrate sync queue: %w", err)
+	}
+	stats, err := client.Stats()
+	if err != nil {
+		return fmt.Errorf("get stats: %w", err)
+	}
+
+	if outputJSON {
+		return outputAsJSON(cmd, migrateOutput{SchemaVersion: stats.SchemaVersion, SyncQueue: conversion})
+	}
+
+	out := cmd.OutOrStdout()
+	printSuccess(out, "Store is at schema version %s", stats.SchemaVersion)
+	_, _ = fmt.Fprintf(out, "  Sync queue converted: %s\n", conversion.ConvertedAt.Local().Format(time.RFC3339))
+	_, _ = fmt.Fprintf(out, "  Entries moved to change_log: %d\n", conversion.Converted)
+	_, _ = fmt.Fprintf(out, "  Entries dropped (already logged or deleted): %d\n", conversion.Dropped)
+	if conversion.Kept > 0 {
+		printMuted(out, "  %d queued feedback entries kept for reconciliation", conversion.Kept)
+	}
+	return nil
+}
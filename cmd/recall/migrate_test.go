@@ -0,0 +1,48 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hyperengineering/recall"
+)
+
+func TestCLI_Migrate(t *testing.T) {
+	defer testEnv(t)()
+
+	// A legacy write leaves a row in the sync queue.
+	store, err := recall.NewStore(os.Getenv("RECALL_DB_PATH"))
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	if _, err := store.Record(recall.Lore{Content: "Legacy entry", Category: recall.CategoryPatternOutcome}); err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+	_ = store.Close()
+
+	var stdout bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetArgs([]string{"migrate"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	if out := stdout.String(); !strings.Contains(out, "Entries moved to change_log: 1") {
+		t.Errorf("output = %q", out)
+	}
+
+	stdout.Reset()
+	rootCmd.SetArgs([]string{"migrate", "--json"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("migrate --json: %v", err)
+	}
+	var out migrateOutput
+	if err := json.Unmarshal(stdout.Bytes(), &out); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, stdout.String())
+	}
+	if out.SchemaVersion == "" || out.SyncQueue == nil || out.SyncQueue.Converted != 0 {
+		t.Errorf("second run = %+v, want nothing left to convert", out)
+	}
+}
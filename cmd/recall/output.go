@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"strconv"
 	"strings"
 	"time"
 
@@ -30,7 +31,11 @@ func outputAsJSON(cmd *cobra.Command, v interface{}) error {
 // outputLoreHuman prints a lore entry in human-readable format.
 func outputLoreHuman(cmd *cobra.Command, lore *recall.Lore) error {
 	out := cmd.OutOrStdout()
-	printSuccess(out, "Recorded: %s", lore.ID)
+	if lore.Staged {
+		printSuccess(out, "Staged in inbox: %s", lore.ID)
+	} else {
+		printSuccess(out, "Recorded: %s", lore.ID)
+	}
 	_, _ = fmt.Fprintf(out, "  Category: %s\n", lore.Category)
 	_, _ = fmt.Fprintf(out, "  Confidence: %.2f\n", lore.Confidence)
 	if lore.Context != "" {
@@ -72,7 +77,11 @@ func outputQueryResultHuman(cmd *cobra.Command, result *recall.QueryResult) erro
 		return nil
 	}
 
-	printInfo(out, "Found %d matching entries:", len(result.Lore))
+	if total := result.Stats.TotalMatched; total > len(result.Lore) {
+		printInfo(out, "Showing %d of %d matching entries (~%d tokens):", len(result.Lore), total, result.TotalTokens)
+	} else {
+		printInfo(out, "Found %d matching entries (~%d tokens):", len(result.Lore), result.TotalTokens)
+	}
 	_, _ = fmt.Fprintln(out)
 
 	for i, lore := range result.Lore {
@@ -105,6 +114,71 @@ func outputQueryResultHuman(cmd *cobra.Command, result *recall.QueryResult) erro
 				_, _ = fmt.Fprintf(out, "    Context: %s\n", lore.Context)
 			}
 		}
+		if result.NeedsValidation[lore.ID] {
+			line := fmt.Sprintf("Needs validation: confirm or refute with recall feedback --id %s", ref)
+			if isTTY() {
+				line = mutedStyle.Render(line)
+			}
+			_, _ = fmt.Fprintf(out, "    %s\n", line)
+		}
+		if lore.Remote {
+			line := "Remote: fetched from Engram ahead of the next sync"
+			if isTTY() {
+				line = mutedStyle.Render(line)
+			}
+			_, _ = fmt.Fprintf(out, "    %s\n", line)
+		}
+		if querySyncState {
+			line := fmt.Sprintf("Sync: %s, embedding: %s", lore.Freshness, lore.EmbeddingStatus)
+			if lore.SyncedAt != nil {
+				line += ", pushed " + lore.SyncedAt.Local().Format(time.RFC3339)
+			}
+			if isTTY() {
+				line = mutedStyle.Render(line)
+			}
+			_, _ = fmt.Fprintf(out, "    %s\n", line)
+		}
+		if floor, ok := result.Protected[lore.ID]; ok {
+			line := fmt.Sprintf("Protected: confidence floor %.2f", floor)
+			if isTTY() {
+				line = mutedStyle.Render(line)
+			}
+			_, _ = fmt.Fprintf(out, "    %s\n", line)
+		}
+		if by, ok := result.SupersededBy[lore.ID]; ok {
+			line := "Superseded by " + by
+			if isTTY() {
+				line = mutedStyle.Render(line)
+			}
+			_, _ = fmt.Fprintf(out, "    %s\n", line)
+		}
+		for _, note := range result.Notes[lore.ID] {
+			if isTTY() {
+				_, _ = fmt.Fprintf(out, "    %s\n", mutedStyle.Render("Note: "+note.Note))
+			} else {
+				_, _ = fmt.Fprintf(out, "    Note: %s\n", note.Note)
+			}
+		}
+		if e, ok := result.Explanations[lore.ID]; ok {
+			line := fmt.Sprintf("Score: %.3f (source %s, trust %.2f", e.Score, e.SourceID, e.Trust)
+			if e.Similarity != nil {
+				line += fmt.Sprintf(", similarity %.3f", *e.Similarity)
+			}
+			if e.CategoryWeight > 1 {
+				line += fmt.Sprintf(", category boost %.2f", e.CategoryWeight)
+			}
+			if e.Affinity > 1 {
+				line += fmt.Sprintf(", feedback affinity %.2f", e.Affinity)
+			}
+			if e.Superseded {
+				line += ", superseded"
+			}
+			line += ")"
+			if isTTY() {
+				line = mutedStyle.Render(line)
+			}
+			_, _ = fmt.Fprintf(out, "    %s\n", line)
+		}
 		if i < len(result.Lore)-1 {
 			_, _ = fmt.Fprintln(out)
 		}
@@ -144,6 +218,35 @@ func outputFeedbackSingle(cmd *cobra.Command, ref string, lore *recall.Lore) err
 	return nil
 }
 
+// outputFeedbackSkipped reports feedback ignored as a repeat within the
+// feedback window.
+func outputFeedbackSkipped(cmd *cobra.Command, ref string, ft recall.FeedbackType) error {
+	if outputJSON {
+		return outputAsJSON(cmd, map[string]interface{}{
+			"ref":     ref,
+			"skipped": true,
+		})
+	}
+	printWarning(cmd.OutOrStdout(), "Skipped: %s feedback was already given to %s within the feedback window", ft, ref)
+	return nil
+}
+
+// outputFeedbackReplace prints the replacement recorded for outdated lore.
+func outputFeedbackReplace(cmd *cobra.Command, ref string, replacement *recall.Lore) error {
+	if outputJSON {
+		return outputAsJSON(cmd, map[string]interface{}{
+			"ref":         ref,
+			"replacement": replacement,
+		})
+	}
+
+	out := cmd.OutOrStdout()
+	printSuccess(out, "Marked %s outdated", ref)
+	_, _ = fmt.Fprintf(out, "  Replaced by: %s\n", replacement.ID)
+	_, _ = fmt.Fprintf(out, "  Category: %s\n", replacement.Category)
+	return nil
+}
+
 // outputFeedbackBatch prints batch feedback results.
 func outputFeedbackBatch(cmd *cobra.Command, result *recall.FeedbackResult) error {
 	if outputJSON {
@@ -152,6 +255,9 @@ func outputFeedbackBatch(cmd *cobra.Command, result *recall.FeedbackResult) erro
 
 	out := cmd.OutOrStdout()
 
+	if len(result.Skipped) > 0 {
+		printMuted(out, "Skipped (already given within the feedback window): %s", strings.Join(result.Skipped, ", "))
+	}
 	if len(result.Updated) == 0 {
 		printWarning(out, "No lore entries were updated.")
 		return nil
@@ -175,6 +281,46 @@ func outputFeedbackBatch(cmd *cobra.Command, result *recall.FeedbackResult) erro
 	return nil
 }
 
+// outputFeedbackFile prints a per-row report of bulk feedback.
+func outputFeedbackFile(cmd *cobra.Command, result *recall.FeedbackFileResult) error {
+	if outputJSON {
+		return outputAsJSON(cmd, result)
+	}
+
+	out := cmd.OutOrStdout()
+	rows := make([][]string, len(result.Rows))
+	for i, r := range result.Rows {
+		change := fmt.Sprintf("%.2f → %.2f", r.Previous, r.Current)
+		status := "ok"
+		if r.Noted {
+			status = "ok, noted"
+		}
+		if r.Skipped {
+			status = "skipped (repeat)"
+		}
+		if r.Error != "" {
+			change, status = "-", r.Error
+		}
+		rows[i] = []string{strconv.Itoa(r.Row), r.Ref, string(r.Outcome), change, status}
+	}
+	_, _ = fmt.Fprint(out, renderTable([]string{"ROW", "REF", "OUTCOME", "CONFIDENCE", "STATUS"}, rows))
+
+	verb := "Applied"
+	if result.DryRun {
+		verb = "Dry run: would apply"
+	}
+	skipped := ""
+	if result.Skipped > 0 {
+		skipped = fmt.Sprintf("; %d skipped", result.Skipped)
+	}
+	if result.Failed > 0 {
+		printWarning(out, "%s %d rows%s; %d failed", verb, result.Applied, skipped, result.Failed)
+	} else {
+		printSuccess(out, "%s %d rows%s", verb, result.Applied, skipped)
+	}
+	return nil
+}
+
 // CLIPushResult for JSON output.
 type CLIPushResult struct {
 	Pushed     int   `json:"pushed"`
@@ -234,6 +380,7 @@ func outputSyncBootstrap(cmd *cobra.Command, stats *recall.StoreStats, duration
 type CLIDeltaResult struct {
 	Applied    int   `json:"applied"`
 	Skipped    int   `json:"skipped"`
+	Conflicted int   `json:"conflicted"`
 	Sequence   int64 `json:"sequence"`
 	DurationMs int64 `json:"duration_ms"`
 }
@@ -242,10 +389,12 @@ type CLIDeltaResult struct {
 func outputSyncDelta(cmd *cobra.Command, result *recall.DeltaResult, duration time.Duration) error {
 	applied := 0
 	skipped := 0
+	conflicted := 0
 	seq := int64(0)
 	if result != nil {
 		applied = result.EntriesApplied
 		skipped = result.EntriesSkipped
+		conflicted = result.EntriesConflicted
 		seq = result.LastSequence
 	}
 
@@ -253,6 +402,7 @@ func outputSyncDelta(cmd *cobra.Command, result *recall.DeltaResult, duration ti
 		return outputAsJSON(cmd, CLIDeltaResult{
 			Applied:    applied,
 			Skipped:    skipped,
+			Conflicted: conflicted,
 			Sequence:   seq,
 			DurationMs: duration.Milliseconds(),
 		})
@@ -264,11 +414,97 @@ func outputSyncDelta(cmd *cobra.Command, result *recall.DeltaResult, duration ti
 	if skipped > 0 {
 		_, _ = fmt.Fprintf(out, "  Entries skipped (source filter): %d\n", skipped)
 	}
+	if conflicted > 0 {
+		printWarning(out, "%d conflict(s) need review: run 'recall conflicts'", conflicted)
+	}
 	_, _ = fmt.Fprintf(out, "  Sequence position: %d\n", seq)
 
 	return nil
 }
 
+// CLISyncNowResult for JSON output.
+type CLISyncNowResult struct {
+	Push       *CLIPushResult  `json:"push,omitempty"`
+	PushError  string          `json:"push_error,omitempty"`
+	Pull       *CLIDeltaResult `json:"pull,omitempty"`
+	PullError  string          `json:"pull_error,omitempty"`
+	Parallel   bool            `json:"parallel"`
+	DurationMs int64           `json:"duration_ms"`
+}
+
+// outputSyncNow prints the push and pull halves of a full sync.
+func outputSyncNow(cmd *cobra.Command, report *recall.SyncReport, duration time.Duration) error {
+	if outputJSON {
+		result := CLISyncNowResult{Parallel: report.Parallel, DurationMs: duration.Milliseconds()}
+		if report.Push != nil {
+			result.Push = &CLIPushResult{Pushed: report.Push.EntriesPushed, DurationMs: report.PushTime.Milliseconds()}
+		}
+		if report.PushError != nil {
+			result.PushError = report.PushError.Error()
+		}
+		if report.Pull != nil {
+			result.Pull = &CLIDeltaResult{
+				Applied:    report.Pull.EntriesApplied,
+				Skipped:    report.Pull.EntriesSkipped,
+				Conflicted: report.Pull.EntriesConflicted,
+				Sequence:   report.Pull.LastSequence,
+				DurationMs: report.PullTime.Milliseconds(),
+			}
+		}
+		if report.PullError != nil {
+			result.PullError = report.PullError.Error()
+		}
+		return outputAsJSON(cmd, result)
+	}
+
+	out := cmd.OutOrStdout()
+	if report.PushError == nil && report.PullError == nil {
+		printSuccess(out, "Sync complete (took %s)", duration.Round(time.Millisecond))
+	}
+	if report.Push != nil {
+		_, _ = fmt.Fprintf(out, "  Pushed %d entries (%s)\n", report.Push.EntriesPushed, report.PushTime.Round(time.Millisecond))
+	} else {
+		printWarning(out, "Push failed: %v", report.PushError)
+	}
+	if report.Pull != nil {
+		_, _ = fmt.Fprintf(out, "  Applied %d entries, sequence position %d (%s)\n",
+			report.Pull.EntriesApplied, report.Pull.LastSequence, report.PullTime.Round(time.Millisecond))
+		if report.Pull.EntriesConflicted > 0 {
+			printWarning(out, "%d conflict(s) need review: run 'recall conflicts'", report.Pull.EntriesConflicted)
+		}
+	} else {
+		printWarning(out, "Pull failed: %v", report.PullError)
+	}
+	return nil
+}
+
+// outputSyncStatus prints the sync state.
+func outputSyncStatus(cmd *cobra.Command, status *recall.SyncStatus) error {
+	if outputJSON {
+		return outputAsJSON(cmd, status)
+	}
+
+	out := cmd.OutOrStdout()
+	if !status.Online {
+		printMuted(out, "Offline: ENGRAM_URL not configured")
+	}
+	lastSync := "never"
+	if !status.LastSync.IsZero() {
+		lastSync = status.LastSync.Format(time.RFC3339)
+	}
+	_, _ = fmt.Fprintf(out, "  Last sync: %s\n", lastSync)
+	_, _ = fmt.Fprintf(out, "  Unpushed changes: %d\n", status.UnpushedChanges)
+	if status.DeferredFeedback > 0 {
+		_, _ = fmt.Fprintf(out, "  Feedback waiting for its lore to be pushed: %d\n", status.DeferredFeedback)
+	}
+	if status.DisabledBy != "" {
+		printWarning(out, "Sync disabled by kill-switch: %s", status.DisabledBy)
+	} else if status.Paused {
+		printWarning(out, "Auto-sync paused: Engram maintenance until %s", status.PausedUntil.Local().Format(time.RFC3339))
+	}
+	return nil
+}
+
 // SyncReinitResult for JSON output.
 type SyncReinitResult struct {
 	Source     string `json:"source"`
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hyperengineering/recall"
+	"github.com/spf13/cobra"
+)
+
+var pinnedCmd = &cobra.Command{
+	Use:   "pinned <pin-id>",
+	Short: "Show a pinned query result",
+	Long: `Show a result set pinned with recall query --pin (or Client.PinResult):
+the entries the query returned, in rank order, with the confidence and
+score each had when pinned. Entries deleted since are listed as missing.
+
+Pin IDs are stable, so a transcript can quote one for later audits.
+
+Example:
+  recall query "retry policies" --explain --pin
+  recall pinned 01HQXYZ...
+  recall pinned 01HQXYZ... --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPinned,
+}
+
+func runPinned(cmd *cobra.Command, args []string) error {
+	cfg, err := loadAndValidateConfig()
+	if err != nil {
+		return err
+	}
+	client, err := recall.New(cfg)
+	if err != nil {
+		return fmt.Errorf("initialize client: %w", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	pinned, err := client.GetPinned(args[0])
+	if err != nil {
+		return fmt.Errorf("pinned: %w", err)
+	}
+	if outputJSON {
+		return outputAsJSON(cmd, pinned)
+	}
+
+	out := cmd.OutOrStdout()
+	printInfo(out, "Pinned %s by %s", pinned.CreatedAt.Format(time.RFC3339), pinned.Actor)
+	live := make(map[string]recall.Lore, len(pinned.Lore))
+	for _, l := range pinned.Lore {
+		live[l.ID] = l
+	}
+	rows := make([][]string, 0, len(pinned.Entries))
+	for i, e := range pinned.Entries {
+		score := "-"
+		if e.Score != nil {
+			score = fmt.Sprintf("%.3f", *e.Score)
+		}
+		content := "(deleted)"
+		if l, ok := live[e.ID]; ok {
+			content = strings.ReplaceAll(l.Content, "\n", " ")
+			if len(content) > 60 {
+				content = content[:57] + "..."
+			}
+		}
+		rows = append(rows, []string{fmt.Sprint(i + 1), e.ID, fmt.Sprintf("%.2f", e.Confidence), score, content})
+	}
+	_, _ = fmt.Fprintln(out, renderTable([]string{"RANK", "ID", "CONFIDENCE", "SCORE", "CONTENT"}, rows))
+	if len(pinned.Missing) > 0 {
+		printWarning(out, "%d pinned entr(ies) deleted since", len(pinned.Missing))
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/hyperengineering/recall"
+	"github.com/spf13/cobra"
+)
+
+var protectCmd = &cobra.Command{
+	Use:   "protect <lore-id>",
+	Short: "Keep feedback from lowering foundational lore",
+	Long: `Protect a lore entry so feedback cannot lower its confidence below a
+floor, unless it comes from an actor whose access policy level is at least
+--min-level. Setting or removing a protection under an access policy needs
+that level too. Without an access policy, all feedback is held at the
+floor.
+
+Protections are local-only: they are never synced to Engram.
+
+Examples:
+  recall protect 01HQXYZ...
+  recall protect 01HQXYZ... --floor 0.7 --min-level 2
+  recall protect 01HQXYZ... --remove`,
+	Args: cobra.ExactArgs(1),
+	RunE: runProtect,
+}
+
+var (
+	protectFloor    float64
+	protectMinLevel int
+	protectRemove   bool
+)
+
+func init() {
+	protectCmd.Flags().Float64Var(&protectFloor, "floor", recall.DefaultProtectedFloor, "Lowest confidence feedback can leave the entry at")
+	protectCmd.Flags().IntVar(&protectMinLevel, "min-level", 1, "Access policy level whose feedback may go below the floor")
+	protectCmd.Flags().BoolVar(&protectRemove, "remove", false, "Remove the entry's protection")
+}
+
+func runProtect(cmd *cobra.Command, args []string) error {
+	cfg, err := loadAndValidateConfig()
+	if err != nil {
+		return err
+	}
+
+	client, err := recall.New(cfg)
+	if err != nil {
+		return fmt.Errorf("initialize client: %w", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	out := cmd.OutOrStdout()
+	if protectRemove {
+		removed, err := client.Unprotect(args[0])
+		if err != nil {
+			return fmt.Errorf("unprotect: %w", err)
+		}
+		if outputJSON {
+			return outputAsJSON(cmd, map[string]any{"lore_id": args[0], "removed": removed})
+		}
+		if !removed {
+			printMuted(out, "%s was not protected", shortID(args[0]))
+			return nil
+		}
+		printSuccess(out, "Removed protection from %s", shortID(args[0]))
+		return nil
+	}
+
+	p, err := client.Protect(args[0], recall.ProtectOptions{Floor: protectFloor, MinLevel: protectMinLevel})
+	if err != nil {
+		return fmt.Errorf("protect: %w", err)
+	}
+	if outputJSON {
+		return outputAsJSON(cmd, p)
+	}
+	printSuccess(out, "Protected %s: confidence floor %.2f below level %d", shortID(p.LoreID), p.Floor, p.MinLevel)
+	return nil
+}
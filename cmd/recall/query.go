@@ -3,7 +3,10 @@ package main
 import (
 	"context"
 	"fmt"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hyperengineering/recall"
 	"github.com/spf13/cobra"
@@ -17,21 +20,78 @@ var queryCmd = &cobra.Command{
 Example:
   recall query "implementing message consumers"
   recall query "database performance" --top 10 --min-confidence 0.7
-  recall query "testing strategies" --category TESTING_STRATEGY,PATTERN_OUTCOME --json`,
+  recall query "testing strategies" --category TESTING_STRATEGY,PATTERN_OUTCOME --json
+  recall query "deploy rollbacks" --as-of 2024-03-01T12:00:00Z
+  recall query "retry policies" --notes
+  recall query "auth flows" --quota PATTERN_OUTCOME=2,EDGE_CASE_DISCOVERY=2,TESTING_STRATEGY=1
+  recall query "retry policies" --compact --truncate 120
+  recall query "retry policies" --explain --pin
+  recall query "flaky consumer" --mode keyword
+  recall query "retry policies" --temperature 0.3
+
+With --as-of, lore is reconstructed as it existed at that time from the
+local change history. Historical results are read-only and cannot receive
+feedback.
+
+With --pin, the result set is pinned and its pin ID printed to stderr;
+recall pinned <pin-id> shows it again later. Add --explain to pin scores.
+
+With --mode keyword, the words of the query are matched against lore
+content and context, ranked by relevance. This finds lore recorded
+locally that is still waiting for an embedding. --mode hybrid combines
+keyword matches with semantic ranking.
+
+With --temperature, results are sampled from a wider pool, weighted by
+confidence and similarity, so less-validated lore surfaces now and then.
+--seed repeats a draw.`,
 	Args: cobra.ExactArgs(1),
 	RunE: runQuery,
 }
 
 var (
-	queryTop           int
-	queryMinConfidence float64
-	queryCategory      string
+	queryTop            int
+	queryMinConfidence  float64
+	queryCategory       string
+	queryAsOf           string
+	queryNotes          bool
+	queryCompact        bool
+	queryTruncate       int
+	queryQuota          string
+	queryProfile        string
+	queryExplain        bool
+	queryHideSuperseded bool
+	querySources        []string
+	queryExcludeSources []string
+	queryOwners         []string
+	queryOnlySynced     bool
+	queryMode           string
+	queryTemperature    float64
+	querySeed           int64
+	querySyncState      bool
+	queryPin            bool
 )
 
 func init() {
 	queryCmd.Flags().IntVarP(&queryTop, "top", "k", 5, "Maximum number of results")
 	queryCmd.Flags().Float64Var(&queryMinConfidence, "min-confidence", 0.0, "Minimum confidence threshold")
 	queryCmd.Flags().StringVar(&queryCategory, "category", "", "Comma-separated categories to filter")
+	queryCmd.Flags().StringSliceVar(&querySources, "source", nil, "Only lore recorded by these source IDs (repeatable or comma-separated)")
+	queryCmd.Flags().StringSliceVar(&queryExcludeSources, "exclude-source", nil, "Skip lore recorded by these source IDs (repeatable or comma-separated)")
+	queryCmd.Flags().StringSliceVar(&queryOwners, "owner", nil, "Only lore owned by these people (see recall assign)")
+	queryCmd.Flags().StringVar(&queryAsOf, "as-of", "", "Query lore as of a past time (RFC3339 or YYYY-MM-DD)")
+	queryCmd.Flags().BoolVar(&queryNotes, "notes", false, "Include private notes attached to results")
+	queryCmd.Flags().StringVar(&queryQuota, "quota", "", "Per-category result quotas, e.g. PATTERN_OUTCOME=2,TESTING_STRATEGY=1")
+	queryCmd.Flags().BoolVar(&queryExplain, "explain", false, "Show how each result was scored (similarity, source trust)")
+	queryCmd.Flags().BoolVar(&queryHideSuperseded, "hide-superseded", false, "Hide lore that a newer entry supersedes")
+	queryCmd.Flags().BoolVar(&queryOnlySynced, "only-synced", false, "Only lore Engram already has (skip unpushed local changes)")
+	queryCmd.Flags().StringVar(&queryMode, "mode", "", "Match by keyword, or hybrid keyword and semantic ranking (keyword, hybrid, semantic)")
+	queryCmd.Flags().Float64Var(&queryTemperature, "temperature", 0, "Sample results weighted by score instead of taking the top results (e.g. 0.3)")
+	queryCmd.Flags().Int64Var(&querySeed, "seed", 0, "With --temperature, seed the draw to make it reproducible")
+	queryCmd.Flags().BoolVar(&querySyncState, "sync-state", false, "Show each result's sync freshness and embedding status")
+	queryCmd.Flags().BoolVar(&queryPin, "pin", false, "Pin the result set for reproducible runs (see recall pinned)")
+	queryCmd.Flags().StringVar(&queryProfile, "profile", "", "Named retrieval profile from the workspace manifest")
+	queryCmd.Flags().BoolVar(&queryCompact, "compact", false, "Token-efficient bulleted output for prompt injection")
+	queryCmd.Flags().IntVar(&queryTruncate, "truncate", 0, "With --compact, truncate content to N characters")
 }
 
 func runQuery(cmd *cobra.Command, args []string) error {
@@ -40,6 +100,14 @@ func runQuery(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if queryProfile != "" {
+		profile, err := workspaceProfile(queryProfile)
+		if err != nil {
+			return err
+		}
+		cfg.QueryProfile = profile
+	}
+
 	client, err := recall.New(cfg)
 	if err != nil {
 		return fmt.Errorf("initialize client: %w", err)
@@ -47,8 +115,12 @@ func runQuery(cmd *cobra.Command, args []string) error {
 	defer func() { _ = client.Close() }()
 
 	params := recall.QueryParams{
-		Query: args[0],
-		K:     queryTop,
+		Query:        args[0],
+		IncludeNotes: queryNotes,
+	}
+	// Leave K to the retrieval profile unless --top is given.
+	if cmd.Flags().Changed("top") || cfg.QueryProfile == nil {
+		params.K = queryTop
 	}
 
 	if cmd.Flags().Changed("min-confidence") {
@@ -62,10 +134,136 @@ func runQuery(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	params.SourceIDs = querySources
+	params.ExcludeSourceIDs = queryExcludeSources
+	params.Owners = queryOwners
+
+	if queryQuota != "" {
+		quotas, err := parseQuotas(queryQuota)
+		if err != nil {
+			return err
+		}
+		params.CategoryQuotas = quotas
+		if !cmd.Flags().Changed("top") {
+			params.K = 0 // default to the quota total
+		}
+	}
+
+	if queryAsOf != "" {
+		asOf, err := parseAsOf(queryAsOf)
+		if err != nil {
+			return err
+		}
+		result, err := client.QueryAsOf(context.Background(), params, asOf)
+		if err != nil {
+			return fmt.Errorf("query lore: %w", err)
+		}
+		return outputQueryAsOfResult(cmd, result)
+	}
+
+	params.Explain = queryExplain
+	params.HideSuperseded = queryHideSuperseded
+	params.OnlySynced = queryOnlySynced
+	params.Mode = recall.QueryMode(queryMode)
+	params.Temperature = queryTemperature
+	params.Seed = querySeed
 	result, err := client.Query(context.Background(), params)
 	if err != nil {
 		return fmt.Errorf("query lore: %w", err)
 	}
+	if queryPin {
+		pinID, err := client.PinResult(result)
+		if err != nil {
+			return fmt.Errorf("pin result: %w", err)
+		}
+		printInfo(cmd.ErrOrStderr(), "Pinned as %s", pinID)
+	}
+
+	if queryCompact {
+		opts := recall.FormatOptions{MaxContentLength: queryTruncate, DedupPrefixes: true}
+		if outputJSON {
+			return outputAsJSON(cmd, recall.CompactResult(result, opts))
+		}
+		_, _ = fmt.Fprint(cmd.OutOrStdout(), recall.FormatBullets(result, opts))
+		return nil
+	}
 
 	return outputQueryResult(cmd, result)
 }
+
+// parseQuotas parses CATEGORY=N pairs separated by commas.
+// workspaceProfile looks up a named retrieval profile in the workspace manifest.
+func workspaceProfile(name string) (*recall.RetrievalProfile, error) {
+	ws, err := findWorkspace()
+	if err != nil {
+		return nil, err
+	}
+	if ws == nil {
+		return nil, fmt.Errorf("--profile %q: no %s found", name, recall.WorkspaceFileName)
+	}
+	profile, ok := ws.Profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("--profile %q: not defined in %s", name, filepath.Join(ws.Root, recall.WorkspaceFileName))
+	}
+	return &profile, nil
+}
+
+func parseQuotas(s string) (map[recall.Category]int, error) {
+	quotas := make(map[recall.Category]int)
+	for _, pair := range strings.Split(s, ",") {
+		cat, n, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		count, err := strconv.Atoi(strings.TrimSpace(n))
+		if !ok || err != nil {
+			return nil, fmt.Errorf("invalid --quota %q: use CATEGORY=N pairs", pair)
+		}
+		quotas[recall.Category(strings.TrimSpace(cat))] = count
+	}
+	return quotas, nil
+}
+
+// parseAsOf accepts an RFC3339 timestamp or a YYYY-MM-DD date (end of day UTC).
+func parseAsOf(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	if d, err := time.Parse("2006-01-02", s); err == nil {
+		return d.Add(24*time.Hour - time.Second), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --as-of %q: use RFC3339 (2024-03-01T12:00:00Z) or YYYY-MM-DD", s)
+}
+
+// outputQueryAsOfResult prints point-in-time results, clearly marked as historical.
+func outputQueryAsOfResult(cmd *cobra.Command, result *recall.AsOfQueryResult) error {
+	if outputJSON {
+		return outputAsJSON(cmd, result)
+	}
+
+	out := cmd.OutOrStdout()
+	printWarning(out, "Historical view as of %s (read-only)", result.AsOf.Format(time.RFC3339))
+
+	if len(result.Lore) == 0 {
+		printMuted(out, "No lore matched at that time.")
+		return nil
+	}
+
+	for _, l := range result.Lore {
+		marker := ""
+		if !l.Exact {
+			marker = ", current state: no earlier history"
+		}
+		_, _ = fmt.Fprintf(out, "\n[%s] %s (confidence: %.2f%s)\n", shortID(l.ID), l.Category, l.Confidence, marker)
+		_, _ = fmt.Fprintf(out, "    %s\n", l.Content)
+		if l.Context != "" {
+			_, _ = fmt.Fprintf(out, "    Context: %s\n", l.Context)
+		}
+	}
+	return nil
+}
+
+// shortID returns the first 8 characters of a lore ID.
+func shortID(id string) string {
+	if len(id) >= 8 {
+		return id[:8]
+	}
+	return id
+}
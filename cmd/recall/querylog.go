@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hyperengineering/recall"
+	"github.com/spf13/cobra"
+)
+
+var queryLogCmd = &cobra.Command{
+	Use:   "query-log",
+	Short: "Show logged queries for retrieval audits",
+	Long: `Show the queries recorded while RECALL_QUERY_LOG was set, newest first,
+with the lore each one returned.
+
+Each entry holds the query text (or, for embedding queries, a SHA-256 of
+the embedding), the effective parameters, and the returned lore IDs with
+their confidence and similarity, so a retrieval can be audited and
+reproduced. --json prints the full entries.
+
+Entries older than 30 days, and all but the newest 10000, are deleted as
+new queries are logged.
+
+Example:
+  recall query-log
+  recall query-log --since 2026-03-01 --limit 100 --json`,
+	Args: cobra.NoArgs,
+	RunE: runQueryLog,
+}
+
+var (
+	queryLogSince string
+	queryLogLimit int
+)
+
+func init() {
+	queryLogCmd.Flags().StringVar(&queryLogSince, "since", "", "Only queries at or after this time (RFC3339 or YYYY-MM-DD)")
+	queryLogCmd.Flags().IntVar(&queryLogLimit, "limit", 20, "Maximum entries to show (0 = all)")
+}
+
+func runQueryLog(cmd *cobra.Command, args []string) error {
+	var since time.Time
+	if queryLogSince != "" {
+		var err error
+		if since, err = parseWindowTime("--since", queryLogSince, false); err != nil {
+			return err
+		}
+	}
+
+	cfg, err := loadAndValidateConfig()
+	if err != nil {
+		return err
+	}
+	client, err := recall.New(cfg)
+	if err != nil {
+		return fmt.Errorf("initialize client: %w", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	entries, err := client.QueryLog(since, queryLogLimit)
+	if err != nil {
+		return fmt.Errorf("query log: %w", err)
+	}
+	if outputJSON {
+		if entries == nil {
+			entries = []recall.QueryLogEntry{}
+		}
+		return outputAsJSON(cmd, entries)
+	}
+
+	out := cmd.OutOrStdout()
+	if len(entries) == 0 {
+		printMuted(out, "No logged queries")
+		if !cfg.QueryLog {
+			printInfo(out, "Set RECALL_QUERY_LOG=1 (or recall config set query_log true) to log queries")
+		}
+		return nil
+	}
+	rows := make([][]string, 0, len(entries))
+	for _, e := range entries {
+		query := e.Query
+		if e.EmbeddingSHA256 != "" {
+			query = strings.TrimSpace(query + " [embedding " + e.EmbeddingSHA256[:12] + "]")
+		}
+		ids := make([]string, len(e.Results))
+		for i, r := range e.Results {
+			ids[i] = r.ID
+		}
+		rows = append(rows, []string{e.QueriedAt.Format(time.RFC3339), e.Actor, query, strings.Join(ids, " ")})
+	}
+	_, _ = fmt.Fprintln(out, renderTable([]string{"QUERIED", "ACTOR", "QUERY", "RESULTS"}, rows))
+	return nil
+}
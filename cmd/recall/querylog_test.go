@@ -0,0 +1,47 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/hyperengineering/recall"
+)
+
+func TestCLI_QueryLog(t *testing.T) {
+	defer testEnv(t)()
+	defer resetQueryFlags()
+	defer func() { queryLogSince, queryLogLimit = "", 20 }()
+	t.Setenv("RECALL_QUERY_LOG", "1")
+
+	run := func(args ...string) string {
+		t.Helper()
+		var stdout bytes.Buffer
+		rootCmd.SetOut(&stdout)
+		rootCmd.SetArgs(args)
+		if err := rootCmd.Execute(); err != nil {
+			t.Fatalf("%v: unexpected error: %v", args, err)
+		}
+		return stdout.String()
+	}
+
+	if out := run("query-log"); !strings.Contains(out, "No logged queries") {
+		t.Errorf("empty log = %q", out)
+	}
+	run("record", "--content", "Audit me", "-c", "PATTERN_OUTCOME")
+	resetQueryFlags()
+	run("query", "audit")
+
+	if out := run("query-log"); !strings.Contains(out, "audit") || !strings.Contains(out, "test-client") {
+		t.Errorf("query-log = %q", out)
+	}
+
+	var entries []recall.QueryLogEntry
+	if err := json.Unmarshal([]byte(run("query-log", "--json", "--since", "2020-01-01")), &entries); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Query != "audit" || len(entries[0].Results) != 1 {
+		t.Errorf("entries = %+v", entries)
+	}
+}
@@ -12,9 +12,21 @@ var recordCmd = &cobra.Command{
 	Short: "Record new lore",
 	Long: `Record a new piece of experiential knowledge.
 
+With --url, the page is fetched and its readable text recorded, with the
+URL as the source. Text over 4000 characters is truncated.
+
+With --chunk, content over 4000 characters is recorded as linked parts
+instead of being rejected. Queries return a retrieved part as the whole
+content.
+
+With --overflow, content over 4000 characters is truncated and the full
+text kept as a local "overflow.txt" attachment (see recall attachments).
+Set RECALL_SYNC_OVERFLOW to sync the attachment too.
+
 Example:
   recall record --content "Queue consumers benefit from idempotency checks" --category PATTERN_OUTCOME
-  recall record --content "ORM generates N+1 queries" -c DEPENDENCY_BEHAVIOR --context story-2.1 --json`,
+  recall record --content "ORM generates N+1 queries" -c DEPENDENCY_BEHAVIOR --context story-2.1 --json
+  recall record --url https://example.com/postmortems/2026-03-db-outage -c EDGE_CASE_DISCOVERY`,
 	RunE: runRecord,
 }
 
@@ -23,6 +35,9 @@ var (
 	recordCategory   string
 	recordContext    string
 	recordConfidence float64
+	recordURL        string
+	recordChunk      bool
+	recordOverflow   bool
 )
 
 func init() {
@@ -30,16 +45,27 @@ func init() {
 	recordCmd.Flags().StringVarP(&recordCategory, "category", "c", "", "Lore category (required)")
 	recordCmd.Flags().StringVar(&recordContext, "context", "", "Additional context (story, epic, situation)")
 	recordCmd.Flags().Float64Var(&recordConfidence, "confidence", 0.5, "Initial confidence (0.0-1.0)")
+	recordCmd.Flags().StringVar(&recordURL, "url", "", "Record the readable text of a web page instead of --content")
+	recordCmd.Flags().BoolVar(&recordChunk, "chunk", false, "Split content over 4000 characters into linked parts")
+	recordCmd.Flags().BoolVar(&recordOverflow, "overflow", false, "Truncate content over 4000 characters and keep the full text locally")
 
-	_ = recordCmd.MarkFlagRequired("content")
 	_ = recordCmd.MarkFlagRequired("category")
 }
 
 func runRecord(cmd *cobra.Command, args []string) error {
+	if recordContent != "" && recordURL != "" {
+		return fmt.Errorf("use either --content or --url, not both")
+	}
+	if recordChunk && recordOverflow {
+		return fmt.Errorf("use either --chunk or --overflow, not both")
+	}
+
 	cfg, err := loadAndValidateConfig()
 	if err != nil {
 		return err
 	}
+	cfg.ChunkLongContent = recordChunk
+	cfg.ContentOverflow = recordOverflow
 
 	client, err := recall.New(cfg)
 	if err != nil {
@@ -56,10 +82,23 @@ func runRecord(cmd *cobra.Command, args []string) error {
 		opts = append(opts, recall.WithConfidence(recordConfidence))
 	}
 
-	lore, err := client.Record(recordContent, recall.Category(recordCategory), opts...)
+	var lore *recall.Lore
+	if recordURL != "" {
+		lore, err = client.RecordFromURL(cmd.Context(), recordURL, recall.Category(recordCategory), opts...)
+	} else {
+		lore, err = client.Record(recordContent, recall.Category(recordCategory), opts...)
+	}
 	if err != nil {
 		return fmt.Errorf("record lore: %w", err)
 	}
 
-	return outputLore(cmd, lore)
+	if err := outputLore(cmd, lore); err != nil {
+		return err
+	}
+	if recordChunk && !outputJSON {
+		if parts, err := client.Chunks(lore.ID); err == nil && len(parts) > 1 {
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "  Parts: %d\n", len(parts))
+		}
+	}
+	return nil
 }
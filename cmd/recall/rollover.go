@@ -0,0 +1,186 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hyperengineering/recall"
+	"github.com/spf13/cobra"
+)
+
+var (
+	rolloverDryRun         bool
+	rolloverForce          bool
+	rolloverInto           string
+	rolloverMinValidations int
+)
+
+var rolloverCmd = &cobra.Command{
+	Use:   "rollover",
+	Short: "Roll a sprint store over into its long-term store",
+	Long: `End the current period of a rolling store.
+
+Every entry is archived to a JSON file beside the store, entries with
+enough helpful feedback are promoted into the long-term store, and the
+rolling store starts the next period empty. Nothing happens before the
+period ends unless --force is given. The MCP server rolls stores over
+automatically.
+
+Subcommands:
+  set     Mark the store as rolling
+  off     Make the store permanent again
+  status  Show the store's rollover policy
+
+Example:
+  recall store create sprint --description "Current sprint"
+  ENGRAM_STORE=sprint recall rollover set weekly --into default
+  ENGRAM_STORE=sprint recall rollover --dry-run`,
+	Args: cobra.NoArgs,
+	RunE: runRollover,
+}
+
+var rolloverSetCmd = &cobra.Command{
+	Use:   "set <weekly|sprint>",
+	Short: "Mark the store as rolling",
+	Long: `Mark the store as rolling. A weekly period lasts 7 days and a sprint
+14 days; the first period starts now.
+
+Example:
+  recall rollover set sprint --into default --min-validations 2`,
+	Args: cobra.ExactArgs(1),
+	RunE: runRolloverSet,
+}
+
+var rolloverOffCmd = &cobra.Command{
+	Use:   "off",
+	Short: "Make the store permanent again",
+	Args:  cobra.NoArgs,
+	RunE:  runRolloverOff,
+}
+
+var rolloverStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the store's rollover policy",
+	Args:  cobra.NoArgs,
+	RunE:  runRolloverStatus,
+}
+
+func init() {
+	rolloverCmd.Flags().BoolVar(&rolloverDryRun, "dry-run", false, "Show what would be promoted without changing anything")
+	rolloverCmd.Flags().BoolVar(&rolloverForce, "force", false, "Roll over before the period ends")
+	rolloverSetCmd.Flags().StringVar(&rolloverInto, "into", "", "Long-term store that receives promoted entries (required)")
+	rolloverSetCmd.Flags().IntVar(&rolloverMinValidations, "min-validations", 1, "Helpful feedbacks an entry needs to be promoted")
+	_ = rolloverSetCmd.MarkFlagRequired("into")
+	rolloverCmd.AddCommand(rolloverSetCmd)
+	rolloverCmd.AddCommand(rolloverOffCmd)
+	rolloverCmd.AddCommand(rolloverStatusCmd)
+	rootCmd.AddCommand(rolloverCmd)
+}
+
+// newRolloverClient opens a client on the resolved store.
+func newRolloverClient() (*recall.Client, error) {
+	cfg, err := loadAndValidateConfig()
+	if err != nil {
+		return nil, err
+	}
+	client, err := recall.New(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("initialize client: %w", err)
+	}
+	return client, nil
+}
+
+func runRollover(cmd *cobra.Command, args []string) error {
+	client, err := newRolloverClient()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+
+	result, err := client.Rollover(context.Background(), recall.RolloverOptions{DryRun: rolloverDryRun, Force: rolloverForce})
+	if err != nil {
+		return err
+	}
+
+	if outputJSON {
+		return outputAsJSON(cmd, result)
+	}
+
+	out := cmd.OutOrStdout()
+	if result.Skipped {
+		printInfo(out, "Period ends %s; nothing to do (use --force to roll over now)",
+			result.PeriodEnd.Local().Format("2006-01-02 15:04"))
+		return nil
+	}
+	if result.DryRun {
+		printInfo(out, "Dry run: would archive %d entries and promote %d into %s",
+			result.Archived, len(result.Promoted), result.Target)
+		return nil
+	}
+	printSuccess(out, "Rolled over: %d archived, %d promoted, %d merged into %s",
+		result.Archived, len(result.Promoted), len(result.Merged), result.Target)
+	if result.ArchivePath != "" {
+		printMuted(out, "  Archive: %s", result.ArchivePath)
+	}
+	return nil
+}
+
+func runRolloverSet(cmd *cobra.Command, args []string) error {
+	client, err := newRolloverClient()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+
+	policy := &recall.RolloverPolicy{
+		Period:         recall.RolloverPeriod(args[0]),
+		Target:         rolloverInto,
+		MinValidations: rolloverMinValidations,
+	}
+	if err := client.SetRolloverPolicy(policy); err != nil {
+		return err
+	}
+	return runRolloverStatus(cmd, args)
+}
+
+func runRolloverOff(cmd *cobra.Command, args []string) error {
+	client, err := newRolloverClient()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+
+	if err := client.SetRolloverPolicy(nil); err != nil {
+		return err
+	}
+	printSuccess(cmd.OutOrStdout(), "Store is no longer rolling")
+	return nil
+}
+
+func runRolloverStatus(cmd *cobra.Command, args []string) error {
+	client, err := newRolloverClient()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+
+	policy, err := client.RolloverPolicy()
+	if err != nil {
+		return err
+	}
+
+	if outputJSON {
+		return outputAsJSON(cmd, policy)
+	}
+
+	out := cmd.OutOrStdout()
+	if policy == nil {
+		printInfo(out, "Store is not rolling")
+		return nil
+	}
+	printInfo(out, "Rolling %s into %s", policy.Period, policy.Target)
+	_, _ = fmt.Fprintf(out, "  Period: %s to %s\n",
+		policy.PeriodStart.Local().Format("2006-01-02 15:04"), policy.PeriodEnd().Local().Format("2006-01-02 15:04"))
+	_, _ = fmt.Fprintf(out, "  Promotes entries with %d+ helpful feedback\n", policy.MinValidations)
+	return nil
+}
@@ -4,7 +4,9 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hyperengineering/recall"
 	"github.com/hyperengineering/recall/internal/store"
@@ -17,6 +19,7 @@ var (
 	cfgAPIKey    string
 	cfgSourceID  string
 	cfgStore     string
+	cfgNamespace string
 	outputJSON   bool
 )
 
@@ -43,7 +46,8 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&cfgEngramURL, "engram-url", "", "URL of Engram central service")
 	rootCmd.PersistentFlags().StringVar(&cfgAPIKey, "api-key", "", "API key for Engram authentication")
 	rootCmd.PersistentFlags().StringVar(&cfgSourceID, "source-id", "", "Client source identifier")
-	rootCmd.PersistentFlags().StringVar(&cfgStore, "store", "", "Store ID to operate against (default: resolved from ENGRAM_STORE or 'default')")
+	rootCmd.PersistentFlags().StringVar(&cfgStore, "store", "", "Store ID to operate against (default: resolved from ENGRAM_STORE, the git origin remote, or 'default')")
+	rootCmd.PersistentFlags().StringVar(&cfgNamespace, "namespace", "", "Namespace within the store (default: RECALL_NAMESPACE or the default namespace)")
 	rootCmd.PersistentFlags().BoolVar(&outputJSON, "json", false, "Output as JSON")
 
 	rootCmd.AddCommand(recordCmd)
@@ -53,6 +57,26 @@ func init() {
 	rootCmd.AddCommand(statsCmd)
 	rootCmd.AddCommand(sessionCmd)
 	rootCmd.AddCommand(storeCmd)
+	rootCmd.AddCommand(conflictsCmd)
+	rootCmd.AddCommand(diffCmd)
+	rootCmd.AddCommand(annotateCmd)
+	rootCmd.AddCommand(protectCmd)
+	rootCmd.AddCommand(updateCmd)
+	rootCmd.AddCommand(historyCmd)
+	rootCmd.AddCommand(validateCmd)
+	rootCmd.AddCommand(attachCmd)
+	rootCmd.AddCommand(attachmentCmd)
+	rootCmd.AddCommand(complianceCmd)
+	rootCmd.AddCommand(queryLogCmd)
+	rootCmd.AddCommand(pinnedCmd)
+	rootCmd.AddCommand(inboxCmd)
+	rootCmd.AddCommand(migrateCmd)
+	rootCmd.AddCommand(trendingCmd)
+	rootCmd.AddCommand(sourceReportCmd)
+	rootCmd.AddCommand(cooccurrenceCmd)
+	rootCmd.AddCommand(assignCmd)
+	rootCmd.AddCommand(assignedCmd)
+	rootCmd.AddCommand(auditCmd)
 }
 
 func loadConfig() recall.Config {
@@ -77,28 +101,208 @@ func loadConfig() recall.Config {
 		if cfgLorePath == "" {
 			cfg.LocalPath = store.StoreDBPath(cfgStore)
 		}
+	} else if resolved, err := resolveStoreID(); err == nil && resolved != cfg.Store {
+		cfg.Store = resolved
+		if cfgLorePath == "" {
+			cfg.LocalPath = store.StoreDBPath(resolved)
+		}
 	}
 
 	// Override with environment variables (only if flags not set)
 	if v := os.Getenv("RECALL_DB_PATH"); v != "" && cfgLorePath == "" {
 		cfg.LocalPath = v
 	}
-	if v := os.Getenv("ENGRAM_URL"); v != "" && cfgEngramURL == "" {
+	if v := setting("ENGRAM_URL"); v != "" && cfgEngramURL == "" {
 		cfg.EngramURL = v
 	}
-	if v := os.Getenv("ENGRAM_API_KEY"); v != "" && cfgAPIKey == "" {
+	if v := setting("ENGRAM_API_KEY"); v != "" && cfgAPIKey == "" {
 		cfg.APIKey = v
 	}
-	if v := os.Getenv("RECALL_SOURCE_ID"); v != "" && cfgSourceID == "" {
+	if v := setting("RECALL_SOURCE_ID"); v != "" && cfgSourceID == "" {
 		cfg.SourceID = v
 	}
+	if v := setting("RECALL_SIGNING_SECRET"); v != "" {
+		cfg.SigningSecret = v
+	}
+	if v := setting("RECALL_CONFLICT_POLICY"); v != "" {
+		cfg.ConflictPolicy = recall.ConflictPolicy(v)
+	}
+	cfg.SyncCanary = setting("RECALL_SYNC_CANARY")
+	cfg.SyncPauseFile = setting("RECALL_SYNC_PAUSE_FILE")
+	cfg.DedupExact = setting("RECALL_DEDUP_EXACT") != ""
+	cfg.ConfirmFeedback = setting("RECALL_CONFIRM_FEEDBACK") != ""
+	if v := setting("RECALL_FEEDBACK_WINDOW"); v != "" {
+		// Invalid durations are reported by loadAndValidateConfig
+		cfg.FeedbackWindow, _ = time.ParseDuration(v)
+	}
+	if v := setting("RECALL_FEEDBACK_COALESCE_WINDOW"); v != "" {
+		// Invalid durations are reported by loadAndValidateConfig
+		cfg.FeedbackCoalesceWindow, _ = time.ParseDuration(v)
+	}
+	cfg.RemoteAudit = setting("RECALL_REMOTE_AUDIT") != ""
+	if v := setting("RECALL_REMOTE_AUDIT_INTERVAL"); v != "" {
+		// Invalid durations are reported by loadAndValidateConfig
+		cfg.RemoteAuditInterval, _ = time.ParseDuration(v)
+	}
+	if v := setting("RECALL_REMOTE_FALLBACK_SCORE"); v != "" {
+		// Invalid scores are reported by loadAndValidateConfig
+		cfg.RemoteFallbackScore, _ = strconv.ParseFloat(v, 64)
+	}
+	if v := setting("RECALL_FEEDBACK_AFFINITY"); v != "" {
+		// Invalid weights are reported by loadAndValidateConfig
+		cfg.FeedbackAffinity, _ = strconv.ParseFloat(v, 64)
+	}
+	cfg.LegalHold = setting("RECALL_LEGAL_HOLD") != ""
+	cfg.QueryLog = setting("RECALL_QUERY_LOG") != ""
+	cfg.Inbox = setting("RECALL_INBOX") != ""
+	if v := setting("RECALL_MODEL_FAMILY"); v != "" {
+		cfg.TokenEstimator = recall.TokenEstimatorFor(v)
+	}
+	cfg.EmbeddingModel = setting("RECALL_EMBEDDING_MODEL")
+	cfg.Actor = setting("RECALL_ACTOR")
+	if v := setting("RECALL_SOURCE_TRUST"); v != "" {
+		// Invalid weights are reported by loadAndValidateConfig
+		cfg.SourceTrust, _ = recall.ParseSourceTrust(v)
+	}
+	if v := setting("RECALL_CATEGORY_MIN_CONFIDENCE"); v != "" {
+		// Invalid defaults are reported by loadAndValidateConfig
+		cfg.CategoryMinConfidence, _ = recall.ParseCategoryMinConfidence(v)
+	}
+	if v := setting("RECALL_SOFT_QUOTA"); v != "" {
+		// Invalid limits are reported by loadAndValidateConfig
+		cfg.SoftQuota, _ = recall.ParseSoftQuota(v)
+	}
+	if v := setting("RECALL_NORMALIZE"); v != "" {
+		// Unknown normalizers are reported by loadAndValidateConfig
+		cfg.Normalizers, _ = recall.ParseNormalizers(v)
+	}
+	cfg.PreserveOriginal = setting("RECALL_PRESERVE_ORIGINAL") != ""
+	cfg.SyncOverflow = setting("RECALL_SYNC_OVERFLOW") != ""
+	cfg.LazyOpen = setting("RECALL_LAZY_OPEN") != ""
+	if policy, err := loadAccessPolicy(); err != nil {
+		// Fail closed: an unreadable policy makes every actor read-only.
+		// loadAndValidateConfig reports the error.
+		cfg.AccessPolicy = &recall.AccessPolicy{}
+	} else {
+		cfg.AccessPolicy = policy
+	}
+	cfg.Namespace = cfgNamespace
+	if v := setting("RECALL_NAMESPACE"); v != "" && cfgNamespace == "" {
+		cfg.Namespace = v
+	}
+	if ws, _ := findWorkspace(); ws != nil {
+		cwd, _ := os.Getwd()
+		ws.ApplyTo(&cfg, cwd)
+	}
+	if cfg.Namespace == "" && store.GitStoreMode() == store.GitStoreModeNamespace {
+		if ns, ok := gitStoreID(); ok {
+			cfg.Namespace = ns
+		}
+	}
 
 	return cfg
 }
 
+// resolveStoreID resolves the store when --store is not given:
+// ENGRAM_STORE (or the config file) > workspace manifest > enclosing git repository
+// (RECALL_GIT_STORE=store) > "default".
+func resolveStoreID() (string, error) {
+	if id := setting("ENGRAM_STORE"); id != "" {
+		return store.ResolveStore(id)
+	}
+	if ws, err := findWorkspace(); err != nil {
+		return "", err
+	} else if ws != nil {
+		cwd, _ := os.Getwd()
+		if id := ws.Resolve(cwd).Store; id != "" {
+			return id, nil
+		}
+	}
+	if store.GitStoreMode() == store.GitStoreModeStore {
+		if id, ok := gitStoreID(); ok {
+			return id, nil
+		}
+	}
+	return "default", nil
+}
+
+// findWorkspace loads the workspace manifest enclosing the working directory.
+// Returns nil, nil when there is none.
+func findWorkspace() (*recall.Workspace, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	return recall.FindWorkspace(cwd)
+}
+
+// gitStoreID derives a store ID from the git repository enclosing the
+// working directory.
+func gitStoreID() (string, bool) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", false
+	}
+	return store.GitStoreID(cwd)
+}
+
 // loadAndValidateConfig loads config from flags/env and validates it.
 // This is a convenience wrapper for commands that need validated config.
 func loadAndValidateConfig() (recall.Config, error) {
+	if _, err := readConfigFile(); err != nil {
+		return recall.Config{}, err
+	}
+	if _, err := findWorkspace(); err != nil {
+		return recall.Config{}, err
+	}
+	if _, err := loadAccessPolicy(); err != nil {
+		return recall.Config{}, err
+	}
+	if v := setting("RECALL_SOURCE_TRUST"); v != "" {
+		if _, err := recall.ParseSourceTrust(v); err != nil {
+			return recall.Config{}, err
+		}
+	}
+	if v := setting("RECALL_CATEGORY_MIN_CONFIDENCE"); v != "" {
+		if _, err := recall.ParseCategoryMinConfidence(v); err != nil {
+			return recall.Config{}, err
+		}
+	}
+	if v := setting("RECALL_SOFT_QUOTA"); v != "" {
+		if _, err := recall.ParseSoftQuota(v); err != nil {
+			return recall.Config{}, err
+		}
+	}
+	if v := setting("RECALL_NORMALIZE"); v != "" {
+		if _, err := recall.ParseNormalizers(v); err != nil {
+			return recall.Config{}, err
+		}
+	}
+	if v := setting("RECALL_FEEDBACK_WINDOW"); v != "" {
+		if _, err := time.ParseDuration(v); err != nil {
+			return recall.Config{}, fmt.Errorf("RECALL_FEEDBACK_WINDOW: %w", err)
+		}
+	}
+	if v := setting("RECALL_FEEDBACK_COALESCE_WINDOW"); v != "" {
+		if _, err := time.ParseDuration(v); err != nil {
+			return recall.Config{}, fmt.Errorf("RECALL_FEEDBACK_COALESCE_WINDOW: %w", err)
+		}
+	}
+	if v := setting("RECALL_REMOTE_AUDIT_INTERVAL"); v != "" {
+		if _, err := time.ParseDuration(v); err != nil {
+			return recall.Config{}, fmt.Errorf("RECALL_REMOTE_AUDIT_INTERVAL: %w", err)
+		}
+	}
+	if v := setting("RECALL_REMOTE_FALLBACK_SCORE"); v != "" {
+		if _, err := strconv.ParseFloat(v, 64); err != nil {
+			return recall.Config{}, fmt.Errorf("RECALL_REMOTE_FALLBACK_SCORE: %w", err)
+		}
+	}
+	if v := setting("RECALL_FEEDBACK_AFFINITY"); v != "" {
+		if _, err := strconv.ParseFloat(v, 64); err != nil {
+			return recall.Config{}, fmt.Errorf("RECALL_FEEDBACK_AFFINITY: %w", err)
+		}
+	}
 	cfg := loadConfig()
 	if err := validateConfig(cfg); err != nil {
 		return recall.Config{}, err
@@ -150,3 +354,27 @@ func fieldToFlag(field string) string {
 		return strings.ToLower(field)
 	}
 }
+
+// loadAccessPolicy loads the access policy named by RECALL_POLICY.
+// Returns nil when the variable is unset.
+func loadAccessPolicy() (*recall.AccessPolicy, error) {
+	path := setting("RECALL_POLICY")
+	if path == "" {
+		return nil, nil
+	}
+	return recall.LoadAccessPolicy(path)
+}
+
+// checkAccess checks an action that bypasses the client (store deletion,
+// namespace moves) against the configured access policy.
+func checkAccess(action recall.Action) error {
+	cfg, err := loadAndValidateConfig()
+	if err != nil {
+		return err
+	}
+	actor := cfg.Actor
+	if actor == "" {
+		actor = cfg.SourceID
+	}
+	return cfg.AccessPolicy.Check(actor, action, "")
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hyperengineering/recall"
+	"github.com/spf13/cobra"
+)
+
+var (
+	seedDryRun     bool
+	seedPack       string
+	seedRegistry   string
+	seedListPacks  bool
+	seedRemovePack string
+)
+
+var seedCmd = &cobra.Command{
+	Use:   "seed [dir]",
+	Short: "Apply version-controlled seed lore",
+	Long: `Apply the declarative lore definitions in a seed directory.
+
+Without a directory, the nearest .recall/seed/ in the working directory or
+its parents (up to the repository root) is used. YAML files hold lists of
+entries; Markdown files hold one entry each, with optional front matter.
+
+Seeding is idempotent: unchanged entries are skipped, changed entries
+update their lore, and entries whose content already exists are linked
+instead of duplicated.
+
+--pack applies a curated starter pack for a stack instead, from the packs
+built into recall or from --registry. Lore a pack inserts is marked with
+the source "starter-pack:<name>" and can be removed with --remove-pack.
+
+Examples:
+  recall seed
+  recall seed --dry-run
+  recall seed ./docs/lore
+  recall seed --list-packs
+  recall seed --pack go-service
+  recall seed --pack platform --registry https://lore.example.com/packs
+  recall seed --remove-pack go-service`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runSeed,
+}
+
+func init() {
+	seedCmd.Flags().BoolVar(&seedDryRun, "dry-run", false, "Show what would change without writing")
+	seedCmd.Flags().StringVar(&seedPack, "pack", "", "Apply a starter pack (see --list-packs)")
+	seedCmd.Flags().StringVar(&seedRegistry, "registry", "", "Fetch --pack from <registry>/<name>.yaml")
+	seedCmd.Flags().BoolVar(&seedListPacks, "list-packs", false, "List the built-in starter packs")
+	seedCmd.Flags().StringVar(&seedRemovePack, "remove-pack", "", "Delete the lore a starter pack inserted")
+	rootCmd.AddCommand(seedCmd)
+}
+
+func runSeed(cmd *cobra.Command, args []string) error {
+	if (seedPack != "" && seedRemovePack != "") || (seedListPacks && (seedPack != "" || seedRemovePack != "")) {
+		return fmt.Errorf("--pack, --list-packs and --remove-pack are mutually exclusive")
+	}
+	if seedListPacks {
+		return runListPacks(cmd)
+	}
+	if (seedPack != "" || seedRemovePack != "") && len(args) > 0 {
+		return fmt.Errorf("a seed directory cannot be combined with --pack or --remove-pack")
+	}
+
+	dir := ""
+	if len(args) == 1 {
+		dir = args[0]
+	} else if seedPack == "" && seedRemovePack == "" {
+		found, err := findSeedDir()
+		if err != nil {
+			return err
+		}
+		dir = found
+	}
+
+	cfg, err := loadAndValidateConfig()
+	if err != nil {
+		return err
+	}
+
+	client, err := recall.New(cfg)
+	if err != nil {
+		return fmt.Errorf("initialize client: %w", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	if seedRemovePack != "" {
+		return runRemovePack(cmd, client)
+	}
+
+	var result *recall.SeedResult
+	if seedPack != "" {
+		result, err = client.SeedStarterPack(cmd.Context(), seedPack, recall.SeedOptions{DryRun: seedDryRun, Registry: seedRegistry})
+	} else {
+		result, err = client.Seed(dir, recall.SeedOptions{DryRun: seedDryRun})
+	}
+	if err != nil {
+		return err
+	}
+
+	if outputJSON {
+		return outputAsJSON(cmd, result)
+	}
+
+	out := cmd.OutOrStdout()
+	for _, e := range result.Entries {
+		if e.Action != recall.SeedUnchanged {
+			_, _ = fmt.Fprintf(out, "  %-9s %s (%s)\n", e.Action, e.Key, shortID(e.LoreID))
+		}
+	}
+	verb := "Seeded"
+	if result.DryRun {
+		verb = "Dry run:"
+	}
+	printSuccess(out, "%s %d inserted, %d updated, %d adopted, %d unchanged",
+		verb, result.Inserted, result.Updated, result.Adopted, result.Unchanged)
+	return nil
+}
+
+func runListPacks(cmd *cobra.Command) error {
+	packs := recall.StarterPacks()
+	if outputJSON {
+		return outputAsJSON(cmd, packs)
+	}
+	out := cmd.OutOrStdout()
+	for _, name := range packs {
+		_, _ = fmt.Fprintf(out, "  %s\n", name)
+	}
+	return nil
+}
+
+func runRemovePack(cmd *cobra.Command, client *recall.Client) error {
+	removed, err := client.RemoveStarterPack(seedRemovePack)
+	if err != nil {
+		return err
+	}
+	if outputJSON {
+		return outputAsJSON(cmd, map[string]any{"pack": seedRemovePack, "removed": removed})
+	}
+	printSuccess(cmd.OutOrStdout(), "Removed %d entries from starter pack %s", removed, seedRemovePack)
+	return nil
+}
+
+// findSeedDir looks for .recall/seed in the working directory and its
+// parents, stopping at the repository root.
+func findSeedDir() (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	for {
+		candidate := filepath.Join(dir, recall.DefaultSeedDir)
+		if info, err := os.Stat(candidate); err == nil && info.IsDir() {
+			return candidate, nil
+		}
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			break
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+	return "", fmt.Errorf("no %s directory found; pass a seed directory", recall.DefaultSeedDir)
+}
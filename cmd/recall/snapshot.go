@@ -0,0 +1,119 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/hyperengineering/recall"
+	"github.com/spf13/cobra"
+)
+
+var snapshotDiffFull bool
+
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Inspect snapshot files",
+	Long:  `Inspect Engram snapshot files (or Recall store databases) offline.`,
+}
+
+var snapshotDiffCmd = &cobra.Command{
+	Use:   "diff <old.db> <new.db>",
+	Short: "Compare two snapshot files",
+	Long: `Compare two snapshot database files without modifying either.
+
+Reports entries added and removed, entries whose fields changed, the
+distribution of confidence drift, and per-category churn, so a bootstrap
+can be reviewed before it is applied. Store databases (lore.db) are
+accepted as well, e.g. to compare the local store with a new snapshot.
+
+Example:
+  recall snapshot diff current.db incoming.db
+  recall snapshot diff "$(recall stats --json | jq -r .path)" incoming.db --full
+  recall snapshot diff old.db new.db --json`,
+	Args: cobra.ExactArgs(2),
+	RunE: runSnapshotDiff,
+}
+
+func init() {
+	snapshotDiffCmd.Flags().BoolVar(&snapshotDiffFull, "full", false, "List every added, removed and changed entry")
+	snapshotCmd.AddCommand(snapshotDiffCmd)
+	rootCmd.AddCommand(snapshotCmd)
+}
+
+// CLISnapshotDiffSummary for JSON output without --full.
+type CLISnapshotDiffSummary struct {
+	OldCount        int                    `json:"old_count"`
+	NewCount        int                    `json:"new_count"`
+	Added           int                    `json:"added"`
+	Removed         int                    `json:"removed"`
+	Changed         int                    `json:"changed"`
+	Unchanged       int                    `json:"unchanged"`
+	ConfidenceDrift recall.ConfidenceDrift `json:"confidence_drift"`
+	CategoryChurn   []recall.CategoryChurn `json:"category_churn"`
+}
+
+func runSnapshotDiff(cmd *cobra.Command, args []string) error {
+	diff, err := recall.DiffSnapshots(args[0], args[1])
+	if err != nil {
+		return err
+	}
+
+	if outputJSON {
+		if snapshotDiffFull {
+			return outputAsJSON(cmd, diff)
+		}
+		return outputAsJSON(cmd, CLISnapshotDiffSummary{
+			OldCount:        diff.OldCount,
+			NewCount:        diff.NewCount,
+			Added:           len(diff.Added),
+			Removed:         len(diff.Removed),
+			Changed:         len(diff.Changed),
+			Unchanged:       diff.Unchanged,
+			ConfidenceDrift: diff.ConfidenceDrift,
+			CategoryChurn:   diff.CategoryChurn,
+		})
+	}
+
+	out := cmd.OutOrStdout()
+	_, _ = fmt.Fprintf(out, "Entries:   %d → %d\n", diff.OldCount, diff.NewCount)
+	_, _ = fmt.Fprintf(out, "  Added:     %d\n", len(diff.Added))
+	_, _ = fmt.Fprintf(out, "  Removed:   %d\n", len(diff.Removed))
+	_, _ = fmt.Fprintf(out, "  Changed:   %d\n", len(diff.Changed))
+	_, _ = fmt.Fprintf(out, "  Unchanged: %d\n", diff.Unchanged)
+
+	d := diff.ConfidenceDrift
+	_, _ = fmt.Fprintf(out, "\nConfidence drift (mean %+.3f, max %+.2f / %+.2f):\n", d.MeanDelta, d.MaxIncrease, d.MaxDecrease)
+	for _, b := range d.Buckets {
+		_, _ = fmt.Fprintf(out, "  %-14s %d\n", b.Label, b.Count)
+	}
+
+	_, _ = fmt.Fprintln(out, "\nCategory churn:")
+	for _, c := range diff.CategoryChurn {
+		_, _ = fmt.Fprintf(out, "  %-26s %4d → %-4d +%d -%d  moved in %d, out %d\n",
+			c.Category, c.OldCount, c.NewCount, c.Added, c.Removed, c.MovedIn, c.MovedOut)
+	}
+
+	if snapshotDiffFull {
+		outputSnapshotDiffFull(out, diff)
+	}
+	return nil
+}
+
+// outputSnapshotDiffFull lists each added, removed and changed entry.
+func outputSnapshotDiffFull(out io.Writer, diff *recall.SnapshotDiff) {
+	for _, l := range diff.Added {
+		_, _ = fmt.Fprintf(out, "\n+ %s [%s]\n  %s\n", l.ID, l.Category, l.Content)
+	}
+	for _, l := range diff.Removed {
+		_, _ = fmt.Fprintf(out, "\n- %s [%s]\n  %s\n", l.ID, l.Category, l.Content)
+	}
+	for _, c := range diff.Changed {
+		_, _ = fmt.Fprintf(out, "\n~ %s (%v)\n", c.ID, c.Fields)
+		if c.Old.Content != c.New.Content {
+			_, _ = fmt.Fprintf(out, "  old: %s\n  new: %s\n", c.Old.Content, c.New.Content)
+		}
+		if c.Old.Confidence != c.New.Confidence {
+			_, _ = fmt.Fprintf(out, "  confidence: %.2f → %.2f\n", c.Old.Confidence, c.New.Confidence)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hyperengineering/recall"
+)
+
+func TestCLI_SnapshotDiff(t *testing.T) {
+	defer testEnv(t)()
+
+	dir := t.TempDir()
+	paths := make([]string, 2)
+	for i, contents := range [][]string{{"first"}, {"first", "second"}} {
+		paths[i] = filepath.Join(dir, []string{"old.db", "new.db"}[i])
+		client, err := recall.New(recall.Config{LocalPath: paths[i]})
+		if err != nil {
+			t.Fatalf("New: %v", err)
+		}
+		for _, c := range contents {
+			if _, err := client.Record(c, recall.CategoryPatternOutcome); err != nil {
+				t.Fatalf("Record: %v", err)
+			}
+		}
+		client.Close()
+	}
+
+	var out bytes.Buffer
+	rootCmd.SetOut(&out)
+	rootCmd.SetArgs([]string{"snapshot", "diff", paths[0], paths[1]})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("snapshot diff: %v", err)
+	}
+
+	// IDs differ between the two stores, so every new entry counts as added.
+	for _, want := range []string{"Entries:   1 → 2", "Added:     2", "Removed:   1", "Category churn:"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, out.String())
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hyperengineering/recall"
+	"github.com/spf13/cobra"
+)
+
+var sourceReportCmd = &cobra.Command{
+	Use:   "source-report",
+	Short: "Summarize feedback by the source that recorded the lore",
+	Long: `Summarize the feedback given in the last window by the source_id of the
+lore it was given on, to show which agents record helpful lore and which
+record incorrect or outdated lore.
+
+The helpful rate is helpful feedback over helpful, incorrect and outdated
+feedback; not_relevant feedback is counted but left out of the rate.
+
+Example:
+  recall source-report
+  recall source-report --window 168h --json`,
+	Args: cobra.NoArgs,
+	RunE: runSourceReport,
+}
+
+var sourceReportWindow time.Duration
+
+func init() {
+	sourceReportCmd.Flags().DurationVar(&sourceReportWindow, "window", 30*24*time.Hour, "Feedback window, e.g. 24h or 720h")
+}
+
+func runSourceReport(cmd *cobra.Command, args []string) error {
+	cfg, err := loadAndValidateConfig()
+	if err != nil {
+		return err
+	}
+	client, err := recall.New(cfg)
+	if err != nil {
+		return fmt.Errorf("initialize client: %w", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	report, err := client.SourceReport(context.Background(), sourceReportWindow)
+	if err != nil {
+		return fmt.Errorf("source report: %w", err)
+	}
+	if outputJSON {
+		return outputAsJSON(cmd, report)
+	}
+
+	out := cmd.OutOrStdout()
+	if len(report.Sources) == 0 {
+		printMuted(out, "No feedback since %s", report.Since.Local().Format(time.RFC3339))
+		return nil
+	}
+	printInfo(out, "Feedback by source since %s:", report.Since.Local().Format(time.RFC3339))
+	rows := make([][]string, 0, len(report.Sources))
+	for _, s := range report.Sources {
+		rows = append(rows, []string{
+			s.SourceID,
+			strconv.Itoa(s.Entries),
+			strconv.Itoa(s.Helpful),
+			strconv.Itoa(s.Incorrect),
+			strconv.Itoa(s.Outdated),
+			strconv.Itoa(s.NotRelevant),
+			fmt.Sprintf("%.0f%%", s.HelpfulRate*100),
+		})
+	}
+	_, _ = fmt.Fprintln(out, renderTable([]string{"SOURCE", "ENTRIES", "HELPFUL", "INCORRECT", "OUTDATED", "NOT RELEVANT", "HELPFUL RATE"}, rows))
+	return nil
+}
@@ -33,8 +33,18 @@ type statsOutput struct {
 	LoreCount     int        `json:"lore_count"`
 	PendingSync   int        `json:"pending_sync"`
 	SchemaVersion string     `json:"schema_version"`
+	Path          string     `json:"path"`
+	MigratedFrom  string     `json:"migrated_from,omitempty"`
+	RecoveredFrom string     `json:"recovered_from,omitempty"`
 	LastSync      *time.Time `json:"last_sync,omitempty"`
+	SyncMetrics   recall.SyncMetrics `json:"sync_metrics"`
 	Health        *healthOutput `json:"health,omitempty"`
+
+	UnpushedChanges  int                   `json:"unpushed_changes"`
+	ChangeLogEntries int                   `json:"change_log_entries"`
+	ChangeLogBytes   int64                 `json:"change_log_bytes"`
+	State            recall.HealthState    `json:"state"`
+	Warnings         []recall.QuotaWarning `json:"warnings,omitempty"`
 }
 
 type healthOutput struct {
@@ -63,6 +73,16 @@ func runStats(cmd *cobra.Command, args []string) error {
 		LoreCount:     stats.LoreCount,
 		PendingSync:   stats.PendingSync,
 		SchemaVersion: stats.SchemaVersion,
+		Path:          stats.Path,
+		MigratedFrom:  stats.MigratedFrom,
+		RecoveredFrom: stats.RecoveredFrom,
+		SyncMetrics:   stats.SyncMetrics,
+
+		UnpushedChanges:  stats.UnpushedChanges,
+		ChangeLogEntries: stats.ChangeLogEntries,
+		ChangeLogBytes:   stats.ChangeLogBytes,
+		State:            stats.Health,
+		Warnings:         stats.Warnings,
 	}
 	if !stats.LastSync.IsZero() {
 		result.LastSync = &stats.LastSync
@@ -93,6 +113,13 @@ func runStats(cmd *cobra.Command, args []string) error {
 	statsContent.WriteString(fmt.Sprintf("Lore count:     %d\n", stats.LoreCount))
 	statsContent.WriteString(fmt.Sprintf("Pending sync:   %d\n", stats.PendingSync))
 	statsContent.WriteString(fmt.Sprintf("Schema version: %s\n", stats.SchemaVersion))
+	statsContent.WriteString(fmt.Sprintf("Location:       %s\n", stats.Path))
+	if stats.MigratedFrom != "" {
+		statsContent.WriteString(fmt.Sprintf("Migrated from:  %s\n", stats.MigratedFrom))
+	}
+	if stats.RecoveredFrom != "" {
+		statsContent.WriteString(fmt.Sprintf("Recovered from: %s\n", stats.RecoveredFrom))
+	}
 	if !stats.LastSync.IsZero() {
 		statsContent.WriteString(fmt.Sprintf("Last sync:      %s (%s ago)",
 			stats.LastSync.Format(time.RFC3339),
@@ -103,6 +130,31 @@ func runStats(cmd *cobra.Command, args []string) error {
 
 	_, _ = fmt.Fprintln(out, renderPanel("Local Store Statistics", statsContent.String()))
 
+	if len(stats.Warnings) > 0 {
+		var quotaContent strings.Builder
+		quotaContent.WriteString(fmt.Sprintf("State: %s", strings.ToUpper(string(stats.Health))))
+		for _, w := range stats.Warnings {
+			quotaContent.WriteString(fmt.Sprintf("\n%s %s: %d (limit %d)", iconWarning, w.Measure, w.Value, w.Limit))
+		}
+		_, _ = fmt.Fprintln(out, renderPanel("Soft Quota", quotaContent.String()))
+	}
+
+	if m := stats.SyncMetrics; m.ConflictsDetected+m.LocalOverwrites+m.PushesRejected > 0 {
+		var resolved int64
+		for _, n := range m.ConflictsResolved {
+			resolved += n
+		}
+		var syncContent strings.Builder
+		syncContent.WriteString(fmt.Sprintf("Conflicts:        %d detected, %d resolved\n", m.ConflictsDetected, resolved))
+		syncContent.WriteString(fmt.Sprintf("Local overwrites: %d\n", m.LocalOverwrites))
+		syncContent.WriteString(fmt.Sprintf("Rejected pushes:  %d (%d entries)", m.PushesRejected, m.EntriesRejected))
+		_, _ = fmt.Fprintln(out, renderPanel("Sync Conflicts", syncContent.String()))
+	}
+
+	if m := stats.SyncMetrics; m.CanaryChecks > 0 {
+		_, _ = fmt.Fprintln(out, renderPanel("Sync Canary", fmt.Sprintf("Compared:      %d responses\nDiscrepancies: %d", m.CanaryChecks, m.CanaryDiscrepancies)))
+	}
+
 	if health != nil {
 		var healthContent strings.Builder
 		if health.Healthy {
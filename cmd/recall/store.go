@@ -3,7 +3,9 @@ package main
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"sort"
@@ -168,7 +170,7 @@ func runStoreList(cmd *cobra.Command, args []string) error {
 		}
 
 		// Open store to get stats
-		s, err := recall.NewStore(dbPath)
+		s, err := openStore(cmd.ErrOrStderr(), dbPath)
 		if err != nil {
 			// Skip stores that can't be opened
 			continue
@@ -443,6 +445,10 @@ func runStoreDelete(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid store ID %q: %w", storeID, err)
 	}
 
+	if err := checkAccess(recall.ActionDelete); err != nil {
+		return err
+	}
+
 	// Check for --confirm flag
 	if !storeDeleteConfirm {
 		return fmt.Errorf("--confirm flag is required for delete\n\nUsage: recall store delete <store-id> --confirm [--force]")
@@ -461,15 +467,19 @@ func runStoreDelete(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("store %q not found", storeID)
 	}
 
-	// Get lore count for warning
+	// Get lore count for warning; a store under legal hold is never deleted
 	var loreCount int
-	s, err := recall.NewStore(dbPath)
+	s, err := openStore(cmd.ErrOrStderr(), dbPath)
 	if err == nil {
 		stats, _ := s.Stats()
 		if stats != nil {
 			loreCount = stats.LoreCount
 		}
+		held, holdErr := s.LegalHold()
 		_ = s.Close() // Best-effort close; store is being deleted anyway
+		if holdErr == nil && held {
+			return fmt.Errorf("delete store %q: %w", storeID, recall.ErrLegalHold)
+		}
 	}
 
 	out := cmd.OutOrStdout()
@@ -565,7 +575,7 @@ func runStoreInfo(cmd *cobra.Command, args []string) error {
 	} else {
 		// Resolve store from environment
 		var err error
-		storeID, err = store.ResolveStore("")
+		storeID, err = resolveStoreID()
 		if err != nil {
 			return fmt.Errorf("resolve store: %w", err)
 		}
@@ -586,7 +596,7 @@ func runStoreInfo(cmd *cobra.Command, args []string) error {
 	}
 
 	// Open store
-	s, err := recall.NewStore(dbPath)
+	s, err := openStore(cmd.ErrOrStderr(), dbPath)
 	if err != nil {
 		return fmt.Errorf("open store: %w", err)
 	}
@@ -726,3 +736,16 @@ func formatRelativeTime(t time.Time) string {
 		return fmt.Sprintf("%dw ago", weeks)
 	}
 }
+
+// openStore opens the store at dbPath. If the database was corrupt and has
+// been recovered, it warns on w and returns the recovered store.
+func openStore(w io.Writer, dbPath string) (*recall.Store, error) {
+	s, err := recall.NewStore(dbPath)
+	var recovered *recall.RecoveredError
+	if errors.As(err, &recovered) {
+		printWarning(w, "Database was corrupt: recovered %d lore entries; corrupt file moved to %s",
+			recovered.Recovered, recovered.QuarantinePath)
+		return s, nil
+	}
+	return s, err
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/hyperengineering/recall"
+	"github.com/spf13/cobra"
+)
+
+var storeCloneCmd = &cobra.Command{
+	Use:   "clone [store-id]",
+	Short: "Copy a store into a detached sandbox",
+	Long: `Copy a local store to a new database file for experiments.
+
+The clone holds the same lore and feedback history under a new source ID,
+with sync state cleared, so consolidation or decay policies can be tried
+on it without risking the real store. Open it with RECALL_DB_PATH.
+
+If store-id is not provided, uses the resolved store from environment/config.
+
+Examples:
+  recall store clone -o /tmp/sandbox/lore.db
+  recall store clone my-project -o sandbox.db --include-change-log`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runStoreClone,
+}
+
+var (
+	cloneOutputPath       string
+	cloneIncludeChangeLog bool
+)
+
+func init() {
+	storeCloneCmd.Flags().StringVarP(&cloneOutputPath, "output", "o", "", "Path of the new database (required; must not exist)")
+	storeCloneCmd.Flags().BoolVar(&cloneIncludeChangeLog, "include-change-log", false, "Keep the change log and sync positions")
+	_ = storeCloneCmd.MarkFlagRequired("output")
+
+	storeCmd.AddCommand(storeCloneCmd)
+}
+
+func runStoreClone(cmd *cobra.Command, args []string) error {
+	storeID, s, err := openLocalStore(args)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = s.Close() }()
+
+	result, err := s.Clone(cmd.Context(), cloneOutputPath, recall.CloneOptions{IncludeChangeLog: cloneIncludeChangeLog})
+	if err != nil {
+		return fmt.Errorf("clone: %w", err)
+	}
+
+	if outputJSON {
+		return outputAsJSON(cmd, result)
+	}
+
+	out := cmd.OutOrStdout()
+	printSuccess(out, "Cloned '%s' to %s", storeID, result.Path)
+	_, _ = fmt.Fprintf(out, "  Lore entries: %d\n", result.LoreCount)
+	_, _ = fmt.Fprintf(out, "  Source ID: %s\n", result.SourceID)
+	return nil
+}
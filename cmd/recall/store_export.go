@@ -21,22 +21,32 @@ var storeExportCmd = &cobra.Command{
 Supports JSON (default) and SQLite formats. JSON exports stream data
 to avoid memory issues with large stores.
 
+JSON exports bound for third parties can be redacted: --redact masks email
+addresses and common secret formats, and --redact-term masks named
+projects or customers. A summary of the redactions is appended to the
+export.
+
 Examples:
   recall store export my-store -o backup.json
   recall store export my-store -o backup.db --format sqlite
-  recall store export default -o default-backup.json`,
+  recall store export default -o default-backup.json
+  recall store export my-store -o shared.json --redact all --redact-term Nightjar`,
 	Args: cobra.ExactArgs(1),
 	RunE: runStoreExport,
 }
 
 var (
-	exportOutputPath string
-	exportFormat     string
+	exportOutputPath  string
+	exportFormat      string
+	exportRedact      string
+	exportRedactTerms []string
 )
 
 func init() {
 	storeExportCmd.Flags().StringVarP(&exportOutputPath, "output", "o", "", "Output file path (required)")
 	storeExportCmd.Flags().StringVar(&exportFormat, "format", "json", "Export format: json, sqlite")
+	storeExportCmd.Flags().StringVar(&exportRedact, "redact", "", "Mask built-in patterns in JSON exports: email, secrets, or all")
+	storeExportCmd.Flags().StringArrayVar(&exportRedactTerms, "redact-term", nil, "Mask a project or customer name in JSON exports (repeatable)")
 	_ = storeExportCmd.MarkFlagRequired("output")
 
 	storeCmd.AddCommand(storeExportCmd)
@@ -50,6 +60,8 @@ type ExportResult struct {
 	FilePath  string `json:"file_path"`
 	FileSize  int64  `json:"file_size"`
 	Duration  string `json:"duration"`
+
+	Redactions *recall.RedactionSummary `json:"redactions,omitempty"`
 }
 
 func runStoreExport(cmd *cobra.Command, args []string) error {
@@ -68,6 +80,14 @@ func runStoreExport(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid format %q: must be 'json' or 'sqlite'", exportFormat)
 	}
 
+	rules, err := exportRedactionRules()
+	if err != nil {
+		return err
+	}
+	if len(rules) > 0 && format != "json" {
+		return fmt.Errorf("--redact and --redact-term require --format json")
+	}
+
 	// Check if store exists
 	dbPath := store.StoreDBPath(storeID)
 	if _, err := os.Stat(dbPath); os.IsNotExist(err) {
@@ -75,7 +95,7 @@ func runStoreExport(cmd *cobra.Command, args []string) error {
 	}
 
 	// Open store
-	s, err := recall.NewStore(dbPath)
+	s, err := openStore(cmd.ErrOrStderr(), dbPath)
 	if err != nil {
 		return fmt.Errorf("open store: %w", err)
 	}
@@ -96,9 +116,10 @@ func runStoreExport(cmd *cobra.Command, args []string) error {
 
 	// Perform export
 	var fileSize int64
+	var redactions *recall.RedactionSummary
 	switch format {
 	case "json":
-		fileSize, err = exportJSON(ctx, s, storeID, exportOutputPath)
+		fileSize, redactions, err = exportJSON(ctx, s, storeID, exportOutputPath, rules)
 	case "sqlite":
 		fileSize, err = exportSQLite(ctx, s, exportOutputPath)
 	}
@@ -122,6 +143,8 @@ func runStoreExport(cmd *cobra.Command, args []string) error {
 			FilePath:  exportOutputPath,
 			FileSize:  fileSize,
 			Duration:  duration.Round(time.Millisecond).String(),
+
+			Redactions: redactions,
 		})
 	}
 
@@ -130,6 +153,12 @@ func runStoreExport(cmd *cobra.Command, args []string) error {
 	summary.WriteString(fmt.Sprintf("Lore entries: %d\n", loreCount))
 	summary.WriteString(fmt.Sprintf("File size:    %s\n", formatBytes(fileSize)))
 	summary.WriteString(fmt.Sprintf("Duration:     %s\n", duration.Round(time.Millisecond)))
+	if redactions != nil {
+		summary.WriteString(fmt.Sprintf("Redacted:     %d entries\n", redactions.Entries))
+		for _, r := range redactions.Rules {
+			summary.WriteString(fmt.Sprintf("  %-12s%d matches in %d entries\n", r.Rule+":", r.Matches, r.Entries))
+		}
+	}
 	summary.WriteString(fmt.Sprintf("Output:       %s", exportOutputPath))
 
 	_, _ = fmt.Fprintln(out, renderPanel("Export Summary", summary.String()))
@@ -149,35 +178,54 @@ func ensureParentDir(path string) error {
 	return nil
 }
 
-// exportJSON exports the store to a JSON file.
-func exportJSON(ctx context.Context, s *recall.Store, storeID, destPath string) (int64, error) {
+// exportRedactionRules builds the redaction rules from --redact and
+// --redact-term.
+func exportRedactionRules() ([]recall.RedactionRule, error) {
+	rules, err := recall.ParseRedactions(exportRedact)
+	if err != nil {
+		return nil, err
+	}
+	if len(exportRedactTerms) > 0 {
+		terms, err := recall.RedactTerms("term", exportRedactTerms...)
+		if err != nil {
+			return nil, fmt.Errorf("--redact-term: %w", err)
+		}
+		rules = append(rules, terms)
+	}
+	return rules, nil
+}
+
+// exportJSON exports the store to a JSON file, applying any redaction
+// rules.
+func exportJSON(ctx context.Context, s *recall.Store, storeID, destPath string, rules []recall.RedactionRule) (int64, *recall.RedactionSummary, error) {
 	// Ensure output directory exists
 	if err := ensureParentDir(destPath); err != nil {
-		return 0, err
+		return 0, nil, err
 	}
 
 	// Create output file
 	f, err := os.Create(destPath)
 	if err != nil {
-		return 0, fmt.Errorf("create output file: %w", err)
+		return 0, nil, fmt.Errorf("create output file: %w", err)
 	}
 	defer func() { _ = f.Close() }()
 
 	// Stream export
-	if err := s.ExportJSON(ctx, storeID, f); err != nil {
+	redactions, err := s.ExportJSONWithOptions(ctx, storeID, f, recall.ExportOptions{Redact: rules})
+	if err != nil {
 		_ = os.Remove(destPath)
-		return 0, err
+		return 0, nil, err
 	}
 
 	if err := f.Sync(); err != nil {
-		return 0, fmt.Errorf("sync file: %w", err)
+		return 0, nil, fmt.Errorf("sync file: %w", err)
 	}
 
 	fi, err := f.Stat()
 	if err != nil {
-		return 0, nil
+		return 0, redactions, nil
 	}
-	return fi.Size(), nil
+	return fi.Size(), redactions, nil
 }
 
 // exportSQLite exports the store to a SQLite file.
@@ -81,6 +81,10 @@ func runStoreImport(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid store ID %q: %w", storeID, err)
 	}
 
+	if err := checkAccess(recall.ActionImport); err != nil {
+		return err
+	}
+
 	// Validate merge strategy
 	strategy := recall.MergeStrategy(strings.ToLower(importMergeStrategy))
 	switch strategy {
@@ -111,7 +115,7 @@ func runStoreImport(cmd *cobra.Command, args []string) error {
 	}
 
 	// Open store
-	s, err := recall.NewStore(dbPath)
+	s, err := openStore(cmd.ErrOrStderr(), dbPath)
 	if err != nil {
 		return fmt.Errorf("open store: %w", err)
 	}
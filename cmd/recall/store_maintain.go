@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hyperengineering/recall"
+	"github.com/spf13/cobra"
+)
+
+var storeMaintainCmd = &cobra.Command{
+	Use:   "maintain [store-id]",
+	Short: "Garbage-collect and compact a store",
+	Long: `Run storage maintenance on a local store.
+
+Drops embedding blobs from deleted entries and rewrites the database to
+return space freed by updates and deletes, then reports reclaimed bytes.
+
+After an embedding model transition, --prune-embeddings <model> first drops
+every vector not produced by that model.
+
+If store-id is not provided, uses the resolved store from environment/config.
+
+Examples:
+  recall store maintain
+  recall store maintain my-project --json
+  recall store maintain --prune-embeddings text-embedding-3-large`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runStoreMaintain,
+}
+
+var maintainPruneEmbeddings string
+
+func init() {
+	storeMaintainCmd.Flags().StringVar(&maintainPruneEmbeddings, "prune-embeddings", "", "Drop vectors of every embedding model except this one")
+	storeCmd.AddCommand(storeMaintainCmd)
+}
+
+func runStoreMaintain(cmd *cobra.Command, args []string) error {
+	storeID, s, err := openLocalStore(args)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = s.Close() }()
+
+	var pruned *recall.EmbeddingPruneResult
+	if maintainPruneEmbeddings != "" {
+		if pruned, err = s.PruneEmbeddings(maintainPruneEmbeddings); err != nil {
+			return fmt.Errorf("prune embeddings: %w", err)
+		}
+	}
+
+	result, err := s.Maintain()
+	if err != nil {
+		return fmt.Errorf("maintain: %w", err)
+	}
+
+	if outputJSON {
+		if pruned != nil {
+			return outputAsJSON(cmd, struct {
+				*recall.MaintenanceResult
+				PrunedEmbeddings *recall.EmbeddingPruneResult `json:"pruned_embeddings"`
+			}{result, pruned})
+		}
+		return outputAsJSON(cmd, result)
+	}
+
+	out := cmd.OutOrStdout()
+	printSuccess(out, "Maintenance of '%s' complete (took %s)", storeID, result.Duration.Round(time.Millisecond))
+	if pruned != nil {
+		_, _ = fmt.Fprintf(out, "  Other models' embeddings dropped: %d\n", pruned.Removed+pruned.PrimaryCleared)
+	}
+	_, _ = fmt.Fprintf(out, "  Dead embeddings cleared: %d\n", result.EmbeddingsCleared)
+	_, _ = fmt.Fprintf(out, "  Size: %s -> %s\n", formatBytes(result.SizeBefore), formatBytes(result.SizeAfter))
+	_, _ = fmt.Fprintf(out, "  Reclaimed: %s\n", formatBytes(result.ReclaimedBytes))
+	return nil
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hyperengineering/recall"
+	"github.com/spf13/cobra"
+)
+
+var storeNamespacesCmd = &cobra.Command{
+	Use:   "namespaces [store-id]",
+	Short: "List namespaces in a store",
+	Long: `List the namespaces holding lore in a local store.
+
+Namespaces partition one database file so several projects can share it.
+Select a namespace for other commands with --namespace or RECALL_NAMESPACE.
+
+If store-id is not provided, uses the resolved store from environment/config.
+
+Examples:
+  recall store namespaces
+  recall store namespaces my-project --json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runStoreNamespaces,
+}
+
+var storeNamespaceMoveCmd = &cobra.Command{
+	Use:   "move [store-id]",
+	Short: "Move lore between namespaces",
+	Long: `Move lore from one namespace to another.
+
+Without filters every entry moves, merging the namespaces. With --category
+or --id only matching entries move, splitting them out. Moves are recorded
+as changes and sync to Engram. An empty --from or --to is the default
+namespace.
+
+Examples:
+  recall store namespaces move --from team-a --to team-b
+  recall store namespaces move --to payments --category TESTING_STRATEGY`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runStoreNamespaceMove,
+}
+
+var (
+	nsMoveFrom     string
+	nsMoveTo       string
+	nsMoveCategory string
+	nsMoveIDs      []string
+)
+
+func init() {
+	storeNamespaceMoveCmd.Flags().StringVar(&nsMoveFrom, "from", "", "Source namespace (default: the default namespace)")
+	storeNamespaceMoveCmd.Flags().StringVar(&nsMoveTo, "to", "", "Target namespace (default: the default namespace)")
+	storeNamespaceMoveCmd.Flags().StringVar(&nsMoveCategory, "category", "", "Comma-separated categories to move")
+	storeNamespaceMoveCmd.Flags().StringSliceVar(&nsMoveIDs, "id", nil, "Lore ID to move (repeatable)")
+
+	storeNamespacesCmd.AddCommand(storeNamespaceMoveCmd)
+	storeCmd.AddCommand(storeNamespacesCmd)
+}
+
+func runStoreNamespaces(cmd *cobra.Command, args []string) error {
+	storeID, s, err := openLocalStore(storeArgs(args))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = s.Close() }()
+
+	namespaces, err := s.Namespaces()
+	if err != nil {
+		return fmt.Errorf("namespaces: %w", err)
+	}
+
+	if outputJSON {
+		if namespaces == nil {
+			namespaces = []recall.NamespaceStats{}
+		}
+		return outputAsJSON(cmd, namespaces)
+	}
+
+	out := cmd.OutOrStdout()
+	if len(namespaces) == 0 {
+		printInfo(out, "Store '%s' has no lore", storeID)
+		return nil
+	}
+	for _, ns := range namespaces {
+		_, _ = fmt.Fprintf(out, "  %-30s %d\n", namespaceLabel(ns.Namespace), ns.LoreCount)
+	}
+	return nil
+}
+
+func runStoreNamespaceMove(cmd *cobra.Command, args []string) error {
+	if err := checkAccess(recall.ActionDelete); err != nil {
+		return err
+	}
+	_, s, err := openLocalStore(storeArgs(args))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = s.Close() }()
+
+	filter := recall.NamespaceMoveFilter{IDs: nsMoveIDs}
+	if nsMoveCategory != "" {
+		for _, c := range strings.Split(nsMoveCategory, ",") {
+			filter.Categories = append(filter.Categories, recall.Category(strings.TrimSpace(c)))
+		}
+	}
+
+	moved, err := s.MoveNamespace(nsMoveFrom, nsMoveTo, filter)
+	if err != nil {
+		return fmt.Errorf("move namespace: %w", err)
+	}
+
+	if outputJSON {
+		return outputAsJSON(cmd, map[string]any{"from": nsMoveFrom, "to": nsMoveTo, "moved": moved})
+	}
+	printSuccess(cmd.OutOrStdout(), "Moved %d entries from %s to %s", moved, namespaceLabel(nsMoveFrom), namespaceLabel(nsMoveTo))
+	return nil
+}
+
+// storeArgs falls back to the --store flag when no store-id argument is given.
+func storeArgs(args []string) []string {
+	if len(args) == 0 && cfgStore != "" {
+		return []string{cfgStore}
+	}
+	return args
+}
+
+// namespaceLabel renders the default namespace readably.
+func namespaceLabel(ns string) string {
+	if ns == "" {
+		return "(default)"
+	}
+	return ns
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -20,7 +21,6 @@ func testStoreEnv(t *testing.T) (storeRoot string, cleanup func()) {
 	t.Helper()
 
 	tmpDir := t.TempDir()
-	storeRoot = filepath.Join(tmpDir, ".recall", "stores")
 
 	// Save original env
 	origHome := os.Getenv("HOME")
@@ -28,7 +28,9 @@ func testStoreEnv(t *testing.T) (storeRoot string, cleanup func()) {
 
 	// Set test env - make tmpDir the home directory
 	os.Setenv("HOME", tmpDir)
+	t.Setenv("XDG_DATA_HOME", "")
 	os.Unsetenv("ENGRAM_STORE")
+	storeRoot = store.DefaultStoreRoot()
 
 	// Reset global flags
 	cfgLorePath = ""
@@ -37,6 +39,12 @@ func testStoreEnv(t *testing.T) (storeRoot string, cleanup func()) {
 	storeDescription = ""
 	storeDeleteConfirm = false
 	storeDeleteForce = false
+	cloneOutputPath = ""
+	cloneIncludeChangeLog = false
+	maintainPruneEmbeddings = ""
+	exportFormat = "json"
+	exportRedact = ""
+	exportRedactTerms = nil
 
 	return storeRoot, func() {
 		os.Setenv("HOME", origHome)
@@ -51,6 +59,12 @@ func testStoreEnv(t *testing.T) (storeRoot string, cleanup func()) {
 		storeDescription = ""
 		storeDeleteConfirm = false
 		storeDeleteForce = false
+		cloneOutputPath = ""
+		cloneIncludeChangeLog = false
+		maintainPruneEmbeddings = ""
+		exportFormat = "json"
+		exportRedact = ""
+		exportRedactTerms = nil
 	}
 }
 
@@ -357,6 +371,35 @@ func TestCLI_StoreDelete_WithForce(t *testing.T) {
 	}
 }
 
+func TestCLI_StoreDelete_DeniedByPolicy(t *testing.T) {
+	storeRoot, cleanup := testStoreEnv(t)
+	defer cleanup()
+
+	storeDir := filepath.Join(storeRoot, "guarded")
+	os.MkdirAll(storeDir, 0755)
+	s, _ := recall.NewStore(filepath.Join(storeDir, "lore.db"))
+	s.Close()
+
+	policyPath := filepath.Join(t.TempDir(), "policy.json")
+	os.WriteFile(policyPath, []byte(`{"actors": {"ci-bot": {}}}`), 0644)
+	t.Setenv("RECALL_POLICY", policyPath)
+	t.Setenv("RECALL_ACTOR", "ci-bot")
+
+	var stdout bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	storeDeleteConfirm = true
+	storeDeleteForce = true
+	rootCmd.SetArgs([]string{"store", "delete", "guarded", "--confirm", "--force"})
+
+	err := rootCmd.Execute()
+	if !errors.Is(err, recall.ErrPermissionDenied) {
+		t.Fatalf("err = %v, want ErrPermissionDenied", err)
+	}
+	if _, err := os.Stat(storeDir); err != nil {
+		t.Error("store directory should not have been deleted")
+	}
+}
+
 func TestCLI_StoreInfo_Explicit(t *testing.T) {
 	storeRoot, cleanup := testStoreEnv(t)
 	defer cleanup()
@@ -390,6 +433,37 @@ func TestCLI_StoreInfo_Explicit(t *testing.T) {
 	}
 }
 
+func TestCLI_StoreClone(t *testing.T) {
+	storeRoot, cleanup := testStoreEnv(t)
+	defer cleanup()
+
+	storeDir := filepath.Join(storeRoot, "clone-test")
+	os.MkdirAll(storeDir, 0755)
+	s, _ := recall.NewStore(filepath.Join(storeDir, "lore.db"))
+	sourceID := s.SourceID()
+	s.Close()
+
+	target := filepath.Join(t.TempDir(), "sandbox.db")
+	var stdout bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetArgs([]string{"store", "clone", "clone-test", "-o", target, "--json"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("store clone failed: %v", err)
+	}
+
+	var result recall.CloneResult
+	if err := json.Unmarshal(stdout.Bytes(), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, stdout.String())
+	}
+	if result.Path != target || result.SourceID == "" || result.SourceID == sourceID {
+		t.Errorf("result = %+v, want a new source ID at %s", result, target)
+	}
+	if _, err := os.Stat(target); err != nil {
+		t.Errorf("clone not written: %v", err)
+	}
+}
+
 func TestCLI_StoreInfo_Resolved(t *testing.T) {
 	storeRoot, cleanup := testStoreEnv(t)
 	defer cleanup()
@@ -979,3 +1053,239 @@ func TestFormatRelativeTime_JustNow(t *testing.T) {
 		t.Errorf("30 seconds ago should return 'just now', got: %s", result)
 	}
 }
+
+func TestCLI_StoreNamespaces_MoveAndList(t *testing.T) {
+	storeRoot, cleanup := testStoreEnv(t)
+	defer cleanup()
+	defer func() { nsMoveFrom, nsMoveTo, nsMoveCategory, nsMoveIDs = "", "", "", nil }()
+
+	storeDir := filepath.Join(storeRoot, "ns-test")
+	os.MkdirAll(storeDir, 0755)
+	s, _ := recall.NewStore(filepath.Join(storeDir, "lore.db"))
+	s.InsertLore(&recall.Lore{ID: "NSCLI00000000000000000001", Content: "a", Category: recall.CategoryTestingStrategy, Confidence: 0.5})
+	s.InsertLore(&recall.Lore{ID: "NSCLI00000000000000000002", Content: "b", Category: recall.CategoryPatternOutcome, Confidence: 0.5})
+	s.Close()
+
+	var stdout bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetArgs([]string{"store", "namespaces", "move", "ns-test", "--to", "qa", "--category", "TESTING_STRATEGY"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("namespaces move should not error: %v", err)
+	}
+	if !strings.Contains(stdout.String(), "Moved 1 entries") {
+		t.Errorf("output should report the move, got: %s", stdout.String())
+	}
+
+	stdout.Reset()
+	rootCmd.SetArgs([]string{"store", "namespaces", "ns-test"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("namespaces should not error: %v", err)
+	}
+	output := stdout.String()
+	if !strings.Contains(output, "(default)") || !strings.Contains(output, "qa") {
+		t.Errorf("output should list both namespaces, got: %s", output)
+	}
+}
+
+func TestCLI_Rollover_SetAndForce(t *testing.T) {
+	storeRoot, cleanup := testStoreEnv(t)
+	defer cleanup()
+
+	storeDir := filepath.Join(storeRoot, "sprint")
+	os.MkdirAll(storeDir, 0755)
+	s, _ := recall.NewStore(filepath.Join(storeDir, "lore.db"))
+	now := time.Now().UTC()
+	s.InsertLore(&recall.Lore{ID: "helpful", Content: "Validated insight", Category: recall.CategoryPatternOutcome,
+		Confidence: 0.8, ValidationCount: 1, CreatedAt: now, UpdatedAt: now})
+	s.InsertLore(&recall.Lore{ID: "unproven", Content: "Unproven hunch", Category: recall.CategoryPatternOutcome,
+		Confidence: 0.5, CreatedAt: now, UpdatedAt: now})
+	s.Close()
+	t.Setenv("ENGRAM_STORE", "sprint")
+	defer func() { rolloverForce, rolloverInto = false, "" }()
+
+	var stdout bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetArgs([]string{"rollover", "set", "weekly", "--into", "default"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("rollover set: %v", err)
+	}
+	if !strings.Contains(stdout.String(), "Rolling weekly into default") {
+		t.Errorf("output = %q, want policy summary", stdout.String())
+	}
+
+	stdout.Reset()
+	outputJSON = true
+	rootCmd.SetArgs([]string{"rollover", "--force", "--json"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("rollover --force: %v", err)
+	}
+	var result recall.RolloverResult
+	if err := json.Unmarshal(stdout.Bytes(), &result); err != nil {
+		t.Fatalf("parse JSON: %v\n%s", err, stdout.String())
+	}
+	if result.Archived != 2 || len(result.Promoted) != 1 || result.Promoted[0] != "helpful" {
+		t.Errorf("result = %+v, want 2 archived and helpful promoted", result)
+	}
+
+	target, _ := recall.NewStore(store.StoreDBPath("default"))
+	defer target.Close()
+	if _, err := target.Get("helpful"); err != nil {
+		t.Errorf("default store missing promoted entry: %v", err)
+	}
+}
+
+func TestCLI_Feedback_OutdatedReplace(t *testing.T) {
+	storeRoot, cleanup := testStoreEnv(t)
+	defer cleanup()
+	resetFeedbackFlags()
+	defer resetFeedbackFlags()
+	resetQueryFlags()
+	defer resetQueryFlags()
+
+	storeDir := filepath.Join(storeRoot, "deps")
+	os.MkdirAll(storeDir, 0755)
+	s, _ := recall.NewStore(filepath.Join(storeDir, "lore.db"))
+	now := time.Now().UTC()
+	s.InsertLore(&recall.Lore{ID: "old", Content: "Use pgx v4", Category: recall.CategoryDependencyBehavior,
+		Confidence: 0.5, CreatedAt: now, UpdatedAt: now})
+	s.Close()
+	t.Setenv("ENGRAM_STORE", "deps")
+
+	var stdout bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetArgs([]string{"feedback", "--id", "old", "--type", "helpful", "--replace", "Use pgx v5"})
+	if err := rootCmd.Execute(); err == nil || !strings.Contains(err.Error(), "--type outdated") {
+		t.Fatalf("--replace with helpful = %v, want --type outdated error", err)
+	}
+
+	resetFeedbackFlags()
+	stdout.Reset()
+	rootCmd.SetArgs([]string{"feedback", "--id", "old", "--type", "outdated", "--replace", "Use pgx v5"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("feedback --replace: %v", err)
+	}
+	if out := stdout.String(); !strings.Contains(out, "Marked old outdated") || !strings.Contains(out, "DEPENDENCY_BEHAVIOR") {
+		t.Errorf("output = %q, want replacement report", out)
+	}
+
+	stdout.Reset()
+	rootCmd.SetArgs([]string{"query", "pgx", "--min-confidence", "0"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("query: %v", err)
+	}
+	if out := stdout.String(); !strings.Contains(out, "Superseded by") || strings.Index(out, "Use pgx v5") > strings.Index(out, "Use pgx v4") {
+		t.Errorf("query output = %q, want the replacement first and the old entry marked", out)
+	}
+
+	resetQueryFlags()
+	stdout.Reset()
+	rootCmd.SetArgs([]string{"query", "pgx", "--min-confidence", "0", "--hide-superseded"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("query --hide-superseded: %v", err)
+	}
+	if out := stdout.String(); strings.Contains(out, "Use pgx v4") {
+		t.Errorf("query --hide-superseded output = %q, want the old entry hidden", out)
+	}
+}
+
+func TestCLI_Feedback_File(t *testing.T) {
+	storeRoot, cleanup := testStoreEnv(t)
+	defer cleanup()
+	resetFeedbackFlags()
+	defer resetFeedbackFlags()
+
+	storeDir := filepath.Join(storeRoot, "retro")
+	os.MkdirAll(storeDir, 0755)
+	s, _ := recall.NewStore(filepath.Join(storeDir, "lore.db"))
+	now := time.Now().UTC()
+	s.InsertLore(&recall.Lore{ID: "good", Content: "Feature flags for rollout", Category: recall.CategoryPatternOutcome,
+		Confidence: 0.5, CreatedAt: now, UpdatedAt: now})
+	s.Close()
+	t.Setenv("ENGRAM_STORE", "retro")
+
+	file := filepath.Join(t.TempDir(), "retro.csv")
+	os.WriteFile(file, []byte("id_or_ref,outcome,reason\ngood,helpful,worked in prod\nmissing,helpful,\n"), 0644)
+
+	var stdout bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetArgs([]string{"feedback", "--file", file})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("feedback --file: %v", err)
+	}
+	out := stdout.String()
+	if !strings.Contains(out, "0.50 → 0.58") || !strings.Contains(out, "Applied 1 rows; 1 failed") {
+		t.Errorf("output = %q, want per-row report and summary", out)
+	}
+}
+
+func TestCLI_Feedback_Window(t *testing.T) {
+	storeRoot, cleanup := testStoreEnv(t)
+	defer cleanup()
+	resetFeedbackFlags()
+	defer resetFeedbackFlags()
+
+	storeDir := filepath.Join(storeRoot, "window")
+	os.MkdirAll(storeDir, 0755)
+	s, _ := recall.NewStore(filepath.Join(storeDir, "lore.db"))
+	now := time.Now().UTC()
+	s.InsertLore(&recall.Lore{ID: "good", Content: "Feature flags for rollout", Category: recall.CategoryPatternOutcome,
+		Confidence: 0.5, CreatedAt: now, UpdatedAt: now})
+	s.Close()
+	t.Setenv("ENGRAM_STORE", "window")
+	t.Setenv("RECALL_FEEDBACK_WINDOW", "1h")
+
+	var stdout bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	for i := 0; i < 2; i++ {
+		resetFeedbackFlags()
+		rootCmd.SetArgs([]string{"feedback", "--id", "good", "--type", "helpful"})
+		if err := rootCmd.Execute(); err != nil {
+			t.Fatalf("feedback #%d: %v", i+1, err)
+		}
+	}
+	out := stdout.String()
+	if !strings.Contains(out, "Confidence: 0.58") || !strings.Contains(out, "Skipped: helpful feedback was already given to good") {
+		t.Errorf("output = %q, want the second feedback skipped", out)
+	}
+
+	t.Setenv("RECALL_FEEDBACK_WINDOW", "soon")
+	resetFeedbackFlags()
+	rootCmd.SetArgs([]string{"feedback", "--id", "good", "--type", "helpful"})
+	if err := rootCmd.Execute(); err == nil || !strings.Contains(err.Error(), "RECALL_FEEDBACK_WINDOW") {
+		t.Errorf("invalid window: err = %v, want RECALL_FEEDBACK_WINDOW error", err)
+	}
+}
+
+func TestCLI_StoreExport_Redact(t *testing.T) {
+	storeRoot, cleanup := testStoreEnv(t)
+	defer cleanup()
+
+	storeDir := filepath.Join(storeRoot, "shared")
+	os.MkdirAll(storeDir, 0755)
+	s, _ := recall.NewStore(filepath.Join(storeDir, "lore.db"))
+	now := time.Now().UTC()
+	s.InsertLore(&recall.Lore{ID: "a", Content: "Page ops@example.com about Nightjar", Category: recall.CategoryPatternOutcome,
+		Confidence: 0.5, CreatedAt: now, UpdatedAt: now})
+	s.Close()
+
+	out := filepath.Join(t.TempDir(), "shared.json")
+	var stdout bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetArgs([]string{"store", "export", "shared", "-o", out, "--redact", "email", "--redact-term", "Nightjar"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("store export: %v", err)
+	}
+	if !strings.Contains(stdout.String(), "Redacted:     1 entries") {
+		t.Errorf("output = %q, want a redaction summary", stdout.String())
+	}
+	data, _ := os.ReadFile(out)
+	if strings.Contains(string(data), "ops@example.com") || strings.Contains(string(data), "Nightjar") ||
+		!strings.Contains(string(data), `"redactions":{"entries":1`) {
+		t.Errorf("export = %s, want redacted content and summary", data)
+	}
+
+	rootCmd.SetArgs([]string{"store", "export", "shared", "-o", filepath.Join(t.TempDir(), "shared.db"), "--format", "sqlite", "--redact", "email"})
+	if err := rootCmd.Execute(); err == nil || !strings.Contains(err.Error(), "--format json") {
+		t.Errorf("sqlite with --redact: err = %v, want --format json error", err)
+	}
+}
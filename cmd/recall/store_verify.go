@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/hyperengineering/recall"
+	"github.com/hyperengineering/recall/internal/store"
+	"github.com/spf13/cobra"
+)
+
+var storeVerifyCmd = &cobra.Command{
+	Use:   "verify [store-id]",
+	Short: "Check change_log and lore consistency",
+	Long: `Verify that the sync change log agrees with stored lore.
+
+Checks that every unpushed change references an existing entry, that the
+latest unpushed change for each entry matches its current state, that
+sync sequence counters are aligned, and that every entry matches its
+checksum.
+
+With --repair, detected issues are fixed in a single transaction. Checksum
+mismatches (corruption or edits outside Recall) are reported only.
+
+If store-id is not provided, uses the resolved store from environment/config.
+
+Examples:
+  recall store verify
+  recall store verify my-project --repair
+  recall store verify --json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runStoreVerify,
+}
+
+var storeVerifyRepair bool
+
+func init() {
+	storeVerifyCmd.Flags().BoolVar(&storeVerifyRepair, "repair", false, "Fix detected issues")
+
+	storeCmd.AddCommand(storeVerifyCmd)
+}
+
+func runStoreVerify(cmd *cobra.Command, args []string) error {
+	storeID, s, err := openLocalStore(args)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = s.Close() }()
+
+	report, err := s.VerifyConsistency(storeVerifyRepair)
+	if err != nil {
+		return fmt.Errorf("verify: %w", err)
+	}
+
+	if outputJSON {
+		return outputAsJSON(cmd, report)
+	}
+
+	out := cmd.OutOrStdout()
+	if len(report.Issues) == 0 {
+		printSuccess(out, "Store '%s' is consistent (%d unpushed changes checked)", storeID, report.ChangesChecked)
+		return nil
+	}
+
+	for _, issue := range report.Issues {
+		status := ""
+		if issue.Repaired {
+			status = " [repaired]"
+		}
+		if issue.Sequence > 0 {
+			_, _ = fmt.Fprintf(out, "  #%d %s %s: %s%s\n", issue.Sequence, issue.Kind, issue.EntityID, issue.Detail, status)
+		} else if issue.EntityID != "" {
+			_, _ = fmt.Fprintf(out, "  %s %s: %s%s\n", issue.Kind, issue.EntityID, issue.Detail, status)
+		} else {
+			_, _ = fmt.Fprintf(out, "  %s: %s%s\n", issue.Kind, issue.Detail, status)
+		}
+	}
+
+	if report.OK() {
+		printSuccess(out, "Repaired %d issue(s)", report.Repaired)
+		return nil
+	}
+	printWarning(out, "Found %d issue(s); run with --repair to fix", len(report.Issues)-report.Repaired)
+	return nil
+}
+
+// openLocalStore opens the store named by args[0], or the resolved store from
+// environment/config when no argument is given.
+func openLocalStore(args []string) (string, *recall.Store, error) {
+	var storeID string
+	if len(args) > 0 {
+		storeID = args[0]
+	} else {
+		var err error
+		storeID, err = resolveStoreID()
+		if err != nil {
+			return "", nil, fmt.Errorf("resolve store: %w", err)
+		}
+	}
+
+	if err := store.ValidateStoreID(storeID); err != nil {
+		return "", nil, fmt.Errorf("invalid store ID %q: %w", storeID, err)
+	}
+
+	dbPath := store.StoreDBPath(storeID)
+	if _, err := os.Stat(dbPath); os.IsNotExist(err) {
+		return "", nil, fmt.Errorf("store %q not found", storeID)
+	}
+
+	s, err := openStore(os.Stderr, dbPath)
+	if err != nil {
+		return "", nil, fmt.Errorf("open store: %w", err)
+	}
+	return storeID, s, nil
+}
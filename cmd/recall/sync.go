@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 	"time"
@@ -15,9 +16,13 @@ import (
 )
 
 var (
-	syncReinit bool
-	syncForce  bool
-	syncStore  string
+	syncReinit   bool
+	syncForce    bool
+	syncStore    string
+	syncParallel bool
+	syncVerbose  bool
+	syncMaxWait  time.Duration
+	syncAttempts int
 )
 
 var syncCmd = &cobra.Command{
@@ -26,14 +31,21 @@ var syncCmd = &cobra.Command{
 	Long: `Synchronize local lore with the Engram central service.
 
 Subcommands:
+  now       Push local changes, then pull remote ones
   push      Push local changes to Engram
   bootstrap Download full snapshot from Engram
+  status    Show sync state, including Engram maintenance pauses
 
 Flags:
   --reinit  Reinitialize database from Engram (replaces all local data)
   --force   Skip confirmation prompts (for scripting)
 
+Pushes retry transient failures with exponential backoff. For "now" and
+"push", --verbose shows each retry (attempt, wait, error class), and
+--attempts and --max-wait override the retry policy.
+
 Example:
+  recall sync push --verbose --attempts 3 --max-wait 5s
   recall sync push
   recall sync bootstrap
   recall sync --reinit
@@ -41,6 +53,24 @@ Example:
 	RunE: runSync,
 }
 
+var syncNowCmd = &cobra.Command{
+	Use:   "now",
+	Short: "Push local changes, then pull remote ones",
+	Long: `Run a full sync: push pending local changes to Engram, then apply the
+changes other clients pushed. The delta is always applied after the push,
+so remote changes never overwrite local edits that were about to be sent.
+
+A failed push does not stop the pull; both outcomes are reported.
+
+Flags:
+  --parallel  Fetch the first delta page while the push is in flight
+
+Example:
+  recall sync now
+  recall sync now --parallel --json`,
+	RunE: runSyncNow,
+}
+
 var syncPushCmd = &cobra.Command{
 	Use:   "push",
 	Short: "Push local changes to Engram",
@@ -93,13 +123,31 @@ Example:
 	RunE: runSyncDelta,
 }
 
+var syncStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show sync state",
+	Long: `Show the last sync time, changes waiting to be pushed, and whether
+auto-sync is paused for an Engram maintenance window.
+
+Example:
+  recall sync status
+  recall sync status --json`,
+	RunE: runSyncStatus,
+}
+
 func init() {
 	syncCmd.Flags().BoolVar(&syncReinit, "reinit", false, "Reinitialize database from Engram")
 	syncCmd.Flags().BoolVar(&syncForce, "force", false, "Skip confirmation prompts")
 	syncCmd.PersistentFlags().StringVar(&syncStore, "store", "", "Store ID to operate against (default: resolved from ENGRAM_STORE or 'default')")
+	syncCmd.PersistentFlags().BoolVarP(&syncVerbose, "verbose", "v", false, "Show each push retry")
+	syncCmd.PersistentFlags().DurationVar(&syncMaxWait, "max-wait", 0, "Longest wait between push retries (default: 60s)")
+	syncCmd.PersistentFlags().IntVar(&syncAttempts, "attempts", 0, "Push attempts per batch, including the first (default: 6)")
+	syncNowCmd.Flags().BoolVar(&syncParallel, "parallel", false, "Fetch the first delta page while pushing")
+	syncCmd.AddCommand(syncNowCmd)
 	syncCmd.AddCommand(syncPushCmd)
 	syncCmd.AddCommand(syncBootstrapCmd)
 	syncCmd.AddCommand(syncDeltaCmd)
+	syncCmd.AddCommand(syncStatusCmd)
 }
 
 // loadSyncConfig loads config and applies the --store flag if set.
@@ -121,6 +169,80 @@ func loadSyncConfig() (recall.Config, error) {
 	return cfg, nil
 }
 
+// applyRetryFlags applies --attempts and --max-wait, and with --verbose
+// prints each push retry to out.
+func applyRetryFlags(cfg *recall.Config, out io.Writer) error {
+	if syncAttempts < 0 {
+		return fmt.Errorf("--attempts must be non-negative")
+	}
+	if syncMaxWait < 0 {
+		return fmt.Errorf("--max-wait must be non-negative")
+	}
+	if syncAttempts > 0 {
+		cfg.SyncRetry.MaxAttempts = syncAttempts
+	}
+	if syncMaxWait > 0 {
+		cfg.SyncRetry.MaxWait = syncMaxWait
+	}
+	if syncVerbose && !outputJSON {
+		cfg.OnRetry = func(e recall.RetryEvent) {
+			if isTTY() {
+				_, _ = fmt.Fprint(out, "\r\033[K") // clear the spinner line
+			}
+			printMuted(out, "  Attempt %d/%d failed (%s error): %v", e.Attempt-1, e.MaxAttempts, e.Class, e.Err)
+			printMuted(out, "  Retrying in %s", e.Wait)
+		}
+	}
+	return nil
+}
+
+func runSyncNow(cmd *cobra.Command, args []string) error {
+	cfg, err := loadSyncConfig()
+	if err != nil {
+		return err
+	}
+
+	if cfg.IsOffline() {
+		return fmt.Errorf("sync unavailable: ENGRAM_URL not configured (offline-only mode)")
+	}
+	cfg.ParallelSync = syncParallel
+	if err := applyRetryFlags(&cfg, cmd.OutOrStdout()); err != nil {
+		return err
+	}
+
+	client, err := recall.New(cfg)
+	if err != nil {
+		return fmt.Errorf("initialize client: %w", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	var report *recall.SyncReport
+	var syncErr error
+	start := time.Now()
+
+	out := cmd.OutOrStdout()
+	syncErr = runWithSpinner(out, "Syncing with Engram", func() error {
+		report, syncErr = client.SyncNow(ctx)
+		return syncErr
+	})
+
+	duration := time.Since(start)
+
+	if report == nil {
+		return fmt.Errorf("sync: %w", syncErr)
+	}
+	if err := outputSyncNow(cmd, report, duration); err != nil {
+		return err
+	}
+	if syncErr != nil {
+		return fmt.Errorf("sync: %w", syncErr)
+	}
+	return nil
+}
+
 func runSyncPush(cmd *cobra.Command, args []string) error {
 	cfg, err := loadSyncConfig()
 	if err != nil {
@@ -130,6 +252,9 @@ func runSyncPush(cmd *cobra.Command, args []string) error {
 	if cfg.IsOffline() {
 		return fmt.Errorf("sync unavailable: ENGRAM_URL not configured (offline-only mode)")
 	}
+	if err := applyRetryFlags(&cfg, cmd.OutOrStdout()); err != nil {
+		return err
+	}
 
 	client, err := recall.New(cfg)
 	if err != nil {
@@ -145,6 +270,15 @@ func runSyncPush(cmd *cobra.Command, args []string) error {
 	start := time.Now()
 
 	out := cmd.OutOrStdout()
+	if cfg.ConfirmFeedback {
+		reconciled, err := client.ReconcileFeedback(ctx)
+		if err != nil {
+			return fmt.Errorf("push: %w", err)
+		}
+		if !outputJSON && len(reconciled.Confirmed)+len(reconciled.Rejected) > 0 {
+			printInfo(out, "Confirmed %d queued feedback with Engram (%d rejected)", len(reconciled.Confirmed), len(reconciled.Rejected))
+		}
+	}
 	syncErr = runWithSpinner(out, "Pushing to Engram", func() error {
 		var err error
 		pushResult, err = client.SyncPush(ctx)
@@ -360,3 +494,22 @@ func runSyncDelta(cmd *cobra.Command, args []string) error {
 
 	return outputSyncDelta(cmd, deltaResult, duration)
 }
+
+func runSyncStatus(cmd *cobra.Command, args []string) error {
+	cfg, err := loadSyncConfig()
+	if err != nil {
+		return err
+	}
+
+	client, err := recall.New(cfg)
+	if err != nil {
+		return fmt.Errorf("initialize client: %w", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	status, err := client.SyncStatus()
+	if err != nil {
+		return fmt.Errorf("sync status: %w", err)
+	}
+	return outputSyncStatus(cmd, status)
+}
@@ -2,10 +2,15 @@ package main
 
 import (
 	"bytes"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync/atomic"
 	"testing"
+
+	"github.com/hyperengineering/recall"
 )
 
 func TestSync_StoreFlag_Help(t *testing.T) {
@@ -100,3 +105,48 @@ func TestSync_StoreFlag_VariableSet(t *testing.T) {
 		t.Errorf("syncStore = %q, want %q", syncStore, "my-project")
 	}
 }
+
+func TestSync_Push_VerboseRetries(t *testing.T) {
+	cleanup := testEnv(t)
+	defer cleanup()
+	defer func() { syncStore, syncVerbose, syncAttempts, syncMaxWait = "", false, 0, 0 }()
+	syncStore = ""
+
+	var pushes atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/sync/push") && pushes.Add(1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte(`{"accepted": 1}`))
+	}))
+	defer server.Close()
+
+	cfg, err := loadAndValidateConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := recall.New(cfg) // offline, so Close does not push
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Record("Retry visibly", recall.CategoryPatternOutcome); err != nil {
+		t.Fatal(err)
+	}
+	_ = client.Close()
+	os.Setenv("ENGRAM_URL", server.URL)
+	os.Setenv("ENGRAM_API_KEY", "key")
+
+	var stdout bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	_ = syncPushCmd.Flags().Set("help", "false") // left set by the --help tests
+	rootCmd.SetArgs([]string{"sync", "push", "--verbose", "--attempts", "2", "--max-wait", "10ms"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("sync push error = %v", err)
+	}
+	for _, want := range []string{"Attempt 1/2 failed (server error): sync push: HTTP 502", "Retrying in 10ms"} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("output = %q, want %q", stdout.String(), want)
+		}
+	}
+}
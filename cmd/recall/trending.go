@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hyperengineering/recall"
+	"github.com/spf13/cobra"
+)
+
+var trendingCmd = &cobra.Command{
+	Use:   "trending",
+	Short: "Show topics with unusually high recent activity",
+	Long: `Show lore topics that are trending: clusters of related entries recorded
+or given feedback much more often in the last window than in the four
+windows before it. Recurring pain points show up here early.
+
+Entries are grouped by category and, where they have embeddings, by
+similarity. A topic needs at least 3 recent events at twice its usual rate.
+
+Example:
+  recall trending
+  recall trending --window 72h --json`,
+	Args: cobra.NoArgs,
+	RunE: runTrending,
+}
+
+var trendingWindow time.Duration
+
+func init() {
+	trendingCmd.Flags().DurationVar(&trendingWindow, "window", 7*24*time.Hour, "Recent activity window, e.g. 24h or 168h")
+}
+
+func runTrending(cmd *cobra.Command, args []string) error {
+	cfg, err := loadAndValidateConfig()
+	if err != nil {
+		return err
+	}
+	client, err := recall.New(cfg)
+	if err != nil {
+		return fmt.Errorf("initialize client: %w", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	report, err := client.Trending(context.Background(), trendingWindow)
+	if err != nil {
+		return fmt.Errorf("trending: %w", err)
+	}
+	if outputJSON {
+		return outputAsJSON(cmd, report)
+	}
+
+	out := cmd.OutOrStdout()
+	if len(report.Topics) == 0 {
+		printMuted(out, "No trending topics since %s", report.Since.Local().Format(time.RFC3339))
+		return nil
+	}
+	printInfo(out, "Trending since %s (baseline from %s):", report.Since.Local().Format(time.RFC3339), report.BaselineSince.Local().Format(time.RFC3339))
+	rows := make([][]string, 0, len(report.Topics))
+	for _, topic := range report.Topics {
+		rows = append(rows, []string{
+			topic.Label,
+			string(topic.Category),
+			strconv.Itoa(len(topic.LoreIDs)),
+			strconv.Itoa(topic.RecentEvents),
+			strconv.Itoa(topic.BaselineEvents),
+			fmt.Sprintf("%.1fx", topic.Ratio),
+		})
+	}
+	_, _ = fmt.Fprintln(out, renderTable([]string{"TOPIC", "CATEGORY", "ENTRIES", "RECENT", "BASELINE", "RATE"}, rows))
+	return nil
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hyperengineering/recall"
+)
+
+func TestCLI_Trending(t *testing.T) {
+	defer testEnv(t)()
+	defer func() { trendingWindow = 7 * 24 * time.Hour }()
+
+	run := func(args ...string) string {
+		t.Helper()
+		var stdout bytes.Buffer
+		rootCmd.SetOut(&stdout)
+		rootCmd.SetArgs(args)
+		if err := rootCmd.Execute(); err != nil {
+			t.Fatalf("%v: unexpected error: %v", args, err)
+		}
+		return stdout.String()
+	}
+
+	if out := run("trending"); !strings.Contains(out, "No trending topics") {
+		t.Errorf("empty store = %q", out)
+	}
+	for _, content := range []string{"Flaky test: mock clock drift", "Flaky test: shared temp dir", "Flaky test: port reuse"} {
+		run("record", "--content", content, "-c", "TESTING_STRATEGY")
+	}
+
+	out := run("trending", "--window", "24h")
+	if !strings.Contains(out, "Flaky test") || !strings.Contains(out, "TESTING_STRATEGY") {
+		t.Errorf("trending = %q", out)
+	}
+
+	var report recall.TrendingReport
+	if err := json.Unmarshal([]byte(run("trending", "--json")), &report); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(report.Topics) != 1 || report.Topics[0].RecentEvents != 3 {
+		t.Errorf("report = %+v, want one topic with 3 recent events", report)
+	}
+}
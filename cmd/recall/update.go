@@ -0,0 +1,97 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hyperengineering/recall"
+	"github.com/spf13/cobra"
+)
+
+var updateCmd = &cobra.Command{
+	Use:   "update <lore-id> <content>",
+	Short: "Correct the content of lore in place",
+	Long: `Replace the content of a lore entry, for corrections that do not warrant
+a new entry. The edit is pushed with the next sync and recorded as a
+compact diff that "recall history" shows. The entry is re-embedded.
+
+To replace lore that is outdated rather than imprecise, use
+"recall feedback --type outdated --replace" instead.
+
+Examples:
+  recall update 01HQXYZ... "Retry the upload five times with backoff"
+  recall history 01HQXYZ...`,
+	Args: cobra.ExactArgs(2),
+	RunE: runUpdate,
+}
+
+var historyCmd = &cobra.Command{
+	Use:   "history <lore-id>",
+	Short: "Show the content edits made to lore",
+	Long: `Show the edits made to a lore entry with "recall update", oldest first.
+Each edit is a word diff from the version before it: removed words as
+[-...-] and added words as {+...+}.
+
+Example:
+  recall history 01HQXYZ...`,
+	Args: cobra.ExactArgs(1),
+	RunE: runHistory,
+}
+
+func runUpdate(cmd *cobra.Command, args []string) error {
+	cfg, err := loadAndValidateConfig()
+	if err != nil {
+		return err
+	}
+
+	client, err := recall.New(cfg)
+	if err != nil {
+		return fmt.Errorf("initialize client: %w", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	lore, err := client.Update(args[0], args[1])
+	if err != nil {
+		return fmt.Errorf("update: %w", err)
+	}
+	if outputJSON {
+		return outputAsJSON(cmd, lore)
+	}
+	printSuccess(cmd.OutOrStdout(), "Updated: %s", lore.ID)
+	return nil
+}
+
+func runHistory(cmd *cobra.Command, args []string) error {
+	cfg, err := loadAndValidateConfig()
+	if err != nil {
+		return err
+	}
+
+	client, err := recall.New(cfg)
+	if err != nil {
+		return fmt.Errorf("initialize client: %w", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	revisions, err := client.History(args[0])
+	if err != nil {
+		return fmt.Errorf("history: %w", err)
+	}
+	if outputJSON {
+		if revisions == nil {
+			revisions = []recall.Revision{}
+		}
+		return outputAsJSON(cmd, revisions)
+	}
+
+	out := cmd.OutOrStdout()
+	if len(revisions) == 0 {
+		printMuted(out, "No edits to %s", shortID(args[0]))
+		return nil
+	}
+	for _, r := range revisions {
+		_, _ = fmt.Fprintf(out, "  v%d %s  %s\n", r.Version, r.CreatedAt.Local().Format(time.DateTime), r.Actor)
+		_, _ = fmt.Fprintf(out, "    %s\n", r.Diff)
+	}
+	return nil
+}
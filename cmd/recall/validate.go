@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/hyperengineering/recall"
+	"github.com/spf13/cobra"
+)
+
+var validateCmd = &cobra.Command{
+	Use:   "validate <file>",
+	Short: "Check external change_log payloads before they are pushed",
+	Long: `Check lore and attachment payloads built outside recall against the
+Engram-compatible payload schemas in the schema package, without writing
+anything. "-" reads stdin.
+
+The file may hold a single payload, a change_log entry wrapping one
+({table_name, entity_id, operation, payload}), a JSON array of either, or
+a whole sync push request. A bare payload is an attachment when it has a
+lore_id field and lore otherwise.
+
+Each issue is reported with its line, column and JSON pointer. The
+command exits non-zero when any issue is found.
+
+Examples:
+  recall validate payload.json
+  recall validate - < entries.json
+  recall validate push.json --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runValidate,
+}
+
+func runValidate(cmd *cobra.Command, args []string) error {
+	var r io.Reader = cmd.InOrStdin()
+	if args[0] != "-" {
+		f, err := os.Open(args[0])
+		if err != nil {
+			return fmt.Errorf("open payload: %w", err)
+		}
+		defer func() { _ = f.Close() }()
+		r = f
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("read payload: %w", err)
+	}
+
+	cfg, err := loadAndValidateConfig()
+	if err != nil {
+		return err
+	}
+	client, err := recall.New(cfg)
+	if err != nil {
+		return fmt.Errorf("initialize client: %w", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	issues, err := client.ValidatePayload(data)
+	if err != nil {
+		return fmt.Errorf("validate: %w", err)
+	}
+
+	if outputJSON {
+		if issues == nil {
+			issues = []recall.PayloadIssue{}
+		}
+		if err := outputAsJSON(cmd, issues); err != nil {
+			return err
+		}
+	} else {
+		out := cmd.OutOrStdout()
+		if len(issues) == 0 {
+			printSuccess(out, "Payload is valid")
+			return nil
+		}
+		for _, issue := range issues {
+			_, _ = fmt.Fprintf(out, "  %s:%s\n", args[0], issue)
+		}
+	}
+	if len(issues) > 0 {
+		return fmt.Errorf("payload has %d issue(s)", len(issues))
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package recall
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// maxCoalescedFeedback flushes a coalescing window early once this many
+// feedback calls are waiting, bounding the transaction size.
+const maxCoalescedFeedback = 256
+
+// feedbackOp is one Feedback call waiting in a coalescing window. lore
+// and err are set, and done closed, when the window is flushed.
+type feedbackOp struct {
+	loreID string
+	ft     FeedbackType
+	actor  string
+	level  int
+
+	done chan struct{}
+	lore *Lore
+	err  error
+}
+
+// feedbackCoalescer applies the feedback calls made within a window in a
+// single transaction; see Config.FeedbackCoalesceWindow.
+type feedbackCoalescer struct {
+	store  *Store
+	window time.Duration
+
+	mu      sync.Mutex
+	pending []*feedbackOp
+	timer   *time.Timer
+}
+
+// apply queues feedback on loreID and waits for the window holding it to
+// be flushed, returning the entry as this call left it.
+func (q *feedbackCoalescer) apply(loreID string, ft FeedbackType, actor string, level int) (*Lore, error) {
+	op := &feedbackOp{loreID: loreID, ft: ft, actor: actor, level: level, done: make(chan struct{})}
+
+	q.mu.Lock()
+	q.pending = append(q.pending, op)
+	switch {
+	case len(q.pending) >= maxCoalescedFeedback:
+		q.mu.Unlock()
+		q.flush()
+	case q.timer == nil:
+		q.timer = time.AfterFunc(q.window, q.flush)
+		q.mu.Unlock()
+	default:
+		q.mu.Unlock()
+	}
+
+	<-op.done
+	return op.lore, op.err
+}
+
+// flush applies the waiting feedback calls and releases their callers.
+func (q *feedbackCoalescer) flush() {
+	q.mu.Lock()
+	ops := q.pending
+	q.pending = nil
+	if q.timer != nil {
+		q.timer.Stop()
+		q.timer = nil
+	}
+	q.mu.Unlock()
+
+	if len(ops) == 0 {
+		return
+	}
+	if err := q.store.applyFeedbackMany(ops); err != nil {
+		// One bad op rolls back the batch; apply the ops one at a time so
+		// only its caller fails.
+		for _, op := range ops {
+			op.lore, op.err = nil, nil
+			if len(ops) == 1 {
+				op.err = err
+			} else if err := q.store.applyFeedbackMany([]*feedbackOp{op}); err != nil {
+				op.lore, op.err = nil, err
+			}
+		}
+	}
+	for _, op := range ops {
+		close(op.done)
+	}
+}
+
+// applyFeedbackMany applies ops in order within one transaction, so
+// feedback on the same entry compounds as if applied one call at a time.
+// Each op gets the entry as it left it, or ErrNotFound. The change_log
+// gets one upsert per entry, with its final state, and the outcomes are
+// logged for SourceReport. An error fails the whole batch and nothing is
+// applied; flush then retries the ops one at a time.
+func (s *Store) applyFeedbackMany(ops []*feedbackOp) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return ErrStoreClosed
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("store: begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var order []string
+	final := make(map[string]*Lore)
+	for _, op := range ops {
+		lore, err := s.applyFeedbackTx(tx, op.loreID, feedbackDelta(op.ft), op.ft == Helpful, op.level)
+		if errors.Is(err, ErrNotFound) {
+			op.err = ErrNotFound
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		op.lore = lore
+		_ = s.logFeedbackTo(tx, op.loreID, op.ft, op.actor)
+		if _, ok := final[op.loreID]; !ok {
+			order = append(order, op.loreID)
+		}
+		final[op.loreID] = lore
+	}
+
+	for _, id := range order {
+		payloadJSON, err := lorePayloadJSON(final[id])
+		if err != nil {
+			return fmt.Errorf("store: marshal change_log payload: %w", err)
+		}
+		if err := s.appendChangeLog(tx, "lore_entries", id, "upsert", payloadJSON, s.sourceID); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("store: commit: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+package recall
+
+import (
+	"errors"
+	"math"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestClient_Feedback_Coalesced(t *testing.T) {
+	client := newTestClient(t, Config{
+		AutoSync:               false,
+		FeedbackCoalesceWindow: 50 * time.Millisecond,
+	})
+
+	a, err := client.Record("Batch inserts beat row-at-a-time writes.", CategoryPerformanceInsight)
+	if err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+	b, err := client.Record("Retry idempotent requests only.", CategoryPatternOutcome)
+	if err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+	if _, err := client.store.db.Exec("DELETE FROM change_log"); err != nil {
+		t.Fatal(err)
+	}
+
+	refs := []string{a.ID, a.ID, a.ID, b.ID, "01MISSING0000000000000000"}
+	lores := make([]*Lore, len(refs))
+	errs := make([]error, len(refs))
+	var wg sync.WaitGroup
+	for i, ref := range refs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			lores[i], errs[i] = client.Feedback(ref, Helpful)
+		}()
+	}
+	wg.Wait()
+
+	if !errors.Is(errs[4], ErrNotFound) {
+		t.Errorf("Feedback on a missing entry = %v, want ErrNotFound", errs[4])
+	}
+	var counts []int
+	for i := range 4 {
+		if errs[i] != nil {
+			t.Fatalf("Feedback(%s): %v", refs[i], errs[i])
+		}
+		if refs[i] == a.ID {
+			counts = append(counts, lores[i].ValidationCount)
+		}
+	}
+	// Each call sees the entry as its own feedback left it
+	seen := map[int]bool{}
+	for _, n := range counts {
+		seen[n] = true
+	}
+	if len(seen) != 3 || !seen[1] || !seen[2] || !seen[3] {
+		t.Errorf("validation counts returned = %v, want 1, 2 and 3", counts)
+	}
+
+	got, err := client.store.Get(a.ID)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if want := a.Confidence + 3*ConfidenceHelpfulDelta; math.Abs(got.Confidence-want) > 1e-9 || got.ValidationCount != 3 {
+		t.Errorf("entry = %.2f confidence, %d validations; want %.2f, 3", got.Confidence, got.ValidationCount, want)
+	}
+
+	var changes, logged int
+	if err := client.store.db.QueryRow("SELECT COUNT(*) FROM change_log").Scan(&changes); err != nil {
+		t.Fatal(err)
+	}
+	if changes != 2 {
+		t.Errorf("change_log entries = %d, want one per entry (2)", changes)
+	}
+	if err := client.store.db.QueryRow("SELECT COUNT(*) FROM feedback_log").Scan(&logged); err != nil {
+		t.Fatal(err)
+	}
+	if logged != 4 {
+		t.Errorf("feedback_log entries = %d, want 4", logged)
+	}
+}
+
+func TestFeedbackCoalescer_IsolatesFailedOp(t *testing.T) {
+	store := newTestStore(t)
+	good, err := store.Record(Lore{Content: "Cache negative lookups briefly.", Category: CategoryPerformanceInsight, Confidence: 0.5})
+	if err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+	bad, err := store.Record(Lore{Content: "Rotate logs daily.", Category: CategoryPatternOutcome, Confidence: 0.5})
+	if err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+	// Updates to bad fail, failing the batch holding them.
+	if _, err := store.db.Exec(`CREATE TRIGGER reject_bad BEFORE UPDATE OF confidence ON lore_entries
+		WHEN old.id = '` + bad.ID + `' BEGIN SELECT RAISE(ABORT, 'feedback rejected'); END`); err != nil {
+		t.Fatal(err)
+	}
+
+	q := &feedbackCoalescer{store: store, window: time.Hour}
+	ops := []*feedbackOp{
+		{loreID: good.ID, ft: Helpful, done: make(chan struct{})},
+		{loreID: bad.ID, ft: Helpful, done: make(chan struct{})},
+		{loreID: good.ID, ft: Helpful, done: make(chan struct{})},
+	}
+	q.pending = ops
+	q.flush()
+
+	if ops[1].err == nil || !strings.Contains(ops[1].err.Error(), "feedback rejected") {
+		t.Errorf("op on the rejected entry: err = %v, want its own error", ops[1].err)
+	}
+	for _, i := range []int{0, 2} {
+		if ops[i].err != nil || ops[i].lore == nil || ops[i].lore.ValidationCount != i/2+1 {
+			t.Errorf("op %d = %+v, %v; want applied with %d validations", i, ops[i].lore, ops[i].err, i/2+1)
+		}
+	}
+}
+
+func TestClient_Close_FlushesCoalescedFeedback(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "coalesce.db")
+	client, err := New(Config{LocalPath: path, AutoSync: false, FeedbackCoalesceWindow: time.Hour})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	lore, err := client.Record("Pin toolchain versions in CI.", CategoryDependencyBehavior)
+	if err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := client.Feedback(lore.ID, Helpful)
+		done <- err
+	}()
+	// Wait for the call to reach the window
+	for deadline := time.Now().Add(5 * time.Second); ; {
+		client.feedbackQueue.mu.Lock()
+		n := len(client.feedbackQueue.pending)
+		client.feedbackQueue.mu.Unlock()
+		if n == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("feedback never queued")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if err := client.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("Feedback: %v", err)
+	}
+
+	store, err := NewStore(path)
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+	got, err := store.Get(lore.ID)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.ValidationCount != 1 {
+		t.Errorf("ValidationCount = %d, want 1 after Close", got.ValidationCount)
+	}
+}
+
+func TestConfig_Validate_FeedbackCoalesceWindow(t *testing.T) {
+	cfg := Config{LocalPath: "x.db", FeedbackCoalesceWindow: -time.Second}
+	var ve *ValidationError
+	if err := cfg.Validate(); !errors.As(err, &ve) || ve.Field != "FeedbackCoalesceWindow" {
+		t.Errorf("Validate = %v, want a FeedbackCoalesceWindow ValidationError", err)
+	}
+}
@@ -1,6 +1,8 @@
 package recall
 
 import (
+	"context"
+	"net/http"
 	"os"
 	"time"
 
@@ -18,13 +20,256 @@ type Config struct {
 	// If empty, resolved using store resolution (explicit > ENGRAM_STORE env > "default").
 	Store string
 
+	// Namespace scopes all reads and writes to a partition of the store, so
+	// several projects can share one database file. Namespaces use the same
+	// format as store IDs. Empty selects the default namespace.
+	Namespace string
+
+	// QueryProfile supplies Query defaults for fields a QueryParams leaves
+	// unset, typically from a workspace manifest. Nil uses the built-in
+	// defaults (K=5, MinConfidence=0.5).
+	QueryProfile *RetrievalProfile
+
+	// Actor identifies who is acting, for AccessPolicy checks.
+	// Defaults to SourceID.
+	Actor string
+
+	// AccessPolicy restricts writes per actor on shared stores. Nil allows
+	// everything. Load one with LoadAccessPolicy.
+	AccessPolicy *AccessPolicy
+
+	// SourceTrust weights ranking by the SourceID that recorded each entry,
+	// e.g. {"ci-bot": 1.5, "*": 0.8}. Similarity is multiplied by the weight;
+	// without a query embedding, entries are ordered by weight. The "*" key
+	// (SourceTrustDefault) applies to unlisted sources, which otherwise
+	// weigh 1.0.
+	SourceTrust map[string]float64
+
+	// CategoryMinConfidence sets the default confidence Query requires per
+	// category when QueryParams.MinConfidence is nil, e.g.
+	// {EDGE_CASE_DISCOVERY: 0.3, ARCHITECTURAL_DECISION: 0.6}. Unlisted
+	// categories use DefaultMinConfidence. An explicit MinConfidence, from
+	// the params or QueryProfile, applies to every category instead.
+	CategoryMinConfidence map[Category]float64
+
+	// EmbeddingModel names the model query embeddings come from. During a
+	// model transition, queries score entries with their vector for this
+	// model (see Client.SetEmbedding); entries without one are skipped.
+	// Empty uses the vectors of the store's own embedding model.
+	EmbeddingModel string
+
+	// EmbeddingProvider, if set, embeds lore locally so semantic queries
+	// work offline: a background worker embeds entries pending embedding
+	// every EmbedInterval (see Client.EmbedPending), and Query embeds
+	// QueryParams.Query when QueryEmbedding is empty. Requires
+	// EmbeddingModel, naming the provider's model: vectors are stored
+	// under it, so queries and PruneEmbeddings tell them apart from
+	// Engram's.
+	EmbeddingProvider EmbeddingProvider
+
+	// EmbedInterval is how often the worker embeds pending lore. Defaults
+	// to DefaultEmbedInterval (30 seconds).
+	EmbedInterval time.Duration
+
+	// DisableCategoryRouting turns off category routing. By default, a query
+	// whose embedding is clearly nearest one category's centroid among the
+	// matches has that category's scores boosted by CategoryRoutingBoost,
+	// unless the query gives Categories, CategoryQuotas or NoRouting.
+	DisableCategoryRouting bool
+
+	// DedupExact makes Record return the existing entry, with Merged set,
+	// when the namespace already holds lore whose content has the same
+	// Fingerprint. The check is an indexed lookup made before any insert,
+	// backed by a unique index on the fingerprints Record claims, so
+	// concurrent writers to the same store cannot both insert the content.
+	DedupExact bool
+
+	// Inbox makes Record stage lore in a local inbox instead of recording
+	// it. Staged lore is not queryable or pushed until a curator approves
+	// it with Client.ApproveInbox, giving teams editorial control over what
+	// propagates to Engram.
+	Inbox bool
+
+	// QueryLog records every Query in the local query_log table: the query
+	// text, a hash of its embedding, the effective parameters, and the
+	// returned lore IDs with their scores. Read it with Client.QueryLog.
+	QueryLog bool
+
+	// QueryLogRetention deletes logged queries older than this. Defaults
+	// to DefaultQueryLogRetention; negative keeps them regardless of age.
+	QueryLogRetention time.Duration
+
+	// QueryLogMaxEntries keeps at most this many logged queries. Defaults
+	// to DefaultQueryLogMaxEntries; negative is unlimited.
+	QueryLogMaxEntries int
+
+	// Normalizers rewrite content, in order, before Record validates and
+	// stores it (see ParseNormalizers and AllNormalizers). Dedup and
+	// fingerprints see the normalized content.
+	Normalizers []Normalizer
+
+	// PreserveOriginal keeps the content of entries a normalizer changed,
+	// as submitted, for audit (see Client.OriginalContent).
+	PreserveOriginal bool
+
+	// TokenEstimator counts tokens for lore content, for the model family
+	// the lore is fed to (see TokenEstimatorFor). Counts are stored at
+	// record time and summed in query results. Defaults to
+	// DefaultTokenEstimator.
+	TokenEstimator TokenEstimator
+
+	// ChunkLongContent makes Record split content over MaxContentLength
+	// into up to MaxChunkParts linked parts instead of rejecting it. Queries
+	// return a retrieved part as the whole content. Chunked content skips
+	// DedupExact.
+	ChunkLongContent bool
+
+	// ContentOverflow makes Record truncate content over MaxContentLength
+	// instead of rejecting it, keeping the full text, up to
+	// MaxAttachmentSize bytes, as a local attachment (see
+	// Client.FullContent). Only the truncated content is embedded and
+	// synced. Cannot be combined with ChunkLongContent.
+	ContentOverflow bool
+
+	// SyncOverflow pushes the full text kept by ContentOverflow to Engram
+	// as a synced attachment. Overflow is local-only by default.
+	SyncOverflow bool
+
+	// LazyOpen defers opening, checking and migrating the store from New
+	// to its first use, for short-lived processes such as CLIs; use
+	// Client.EnsureReady to do it at a chosen point. A corrupt database is
+	// then reported by the first use instead of recovered.
+	LazyOpen bool
+
 	// EngramURL is the URL of the Engram central service.
 	// If empty, operates in offline-only mode.
 	EngramURL string
 
 	// APIKey authenticates with Engram.
+	// Use Client.SetAPIKey to rotate it without restarting.
 	APIKey string
 
+	// CredentialRefresh is an optional callback invoked when Engram responds
+	// with 401 Unauthorized. The returned key replaces APIKey and the failed
+	// request is retried once.
+	CredentialRefresh CredentialRefreshFunc
+
+	// SigningSecret is a shared secret used to HMAC-sign push payloads.
+	// When set, every push request (lore and feedback changes) carries an
+	// X-Recall-Signature header that Engram can verify.
+	SigningSecret string
+
+	// ConflictPolicy controls how delta sync handles remote changes to lore
+	// with unpushed local edits. Defaults to ConflictPolicyRemoteWins;
+	// ConflictPolicyReview records conflicts for `recall conflicts`.
+	ConflictPolicy ConflictPolicy
+
+	// SyncBudget caps Engram network usage (bytes per day, requests per hour).
+	// Requests beyond the budget fail with ErrSyncBudgetExceeded. Zero = unlimited.
+	SyncBudget SyncBudget
+
+	// OnBudgetDeferred is called whenever SyncBudget defers a request.
+	OnBudgetDeferred BudgetDeferredFunc
+
+	// SyncRetry bounds how a push batch is retried after a transient
+	// failure (network error or unexpected HTTP status). Zero fields use
+	// DefaultRetryAttempts and DefaultRetryMaxWait.
+	SyncRetry RetryPolicy
+
+	// OnRetry is called before each retry's backoff wait, with the attempt
+	// number, the wait and the class of the failure, e.g. to show progress.
+	OnRetry RetryFunc
+
+	// SyncCanary names a newer Engram API version, e.g. "v2", to validate
+	// before switching to it. Every accepted push and every delta page is
+	// also sent to the same endpoint under /api/<SyncCanary>/; its
+	// responses are compared with the v1 responses but never used.
+	// Discrepancies are counted in SyncMetrics and reported as
+	// SyncEventCanaryDiscrepancy events. Empty disables the canary.
+	SyncCanary string
+
+	// SyncPauseFile is a kill-switch for operations: while a file exists at
+	// this path, or SyncDisabledEnv is set, every Engram request fails with
+	// ErrSyncDisabled and auto-sync skips its ticks. Both are checked before
+	// each request. Defaults to DefaultSyncPauseFile().
+	SyncPauseFile string
+
+	// OnSyncEvent is called for each event counted in SyncMetrics: recorded
+	// and resolved conflicts, local edits overwritten by delta sync, pushes
+	// rejected by Engram, Engram maintenance windows, and canary
+	// discrepancies. It also receives remote audits that found drift
+	// (SyncEventAuditDrift). Use it to alert on
+	// knowledge loss or to feed a metrics backend.
+	OnSyncEvent SyncEventFunc
+
+	// RemoteAudit makes background sync compare the store with a digest of
+	// Engram's entries every RemoteAuditInterval (see Client.AuditRemote).
+	// The last audit time is kept in the store, so the schedule survives
+	// restarts.
+	RemoteAudit bool
+
+	// RemoteAuditInterval is how often RemoteAudit runs. Defaults to
+	// DefaultRemoteAuditInterval (one week).
+	RemoteAuditInterval time.Duration
+
+	// OnChecksumMismatch is called for each lore entry found not to match
+	// its checksum, when read or checked by VerifyConsistency: the
+	// database was corrupted or edited outside Recall.
+	OnChecksumMismatch ChecksumFunc
+
+	// LegalHold places the store under legal hold, for regulated teams.
+	// Deleted or replaced lore is then retained as tombstones indefinitely,
+	// exports are logged with the SHA-256 of their content, and pushed
+	// changes are logged for ComplianceReport. The hold is stored with the
+	// data and stays on until ReleaseLegalHold.
+	LegalHold bool
+
+	// SoftQuota sets sync backlog and change_log thresholds. Stats reports
+	// Health as Degraded past a threshold and Critical past twice it.
+	SoftQuota SoftQuota
+
+	// OnQuotaWarning is called when the Health state changes, as seen by
+	// Stats, after each sync, and after writes (at most once a minute).
+	OnQuotaWarning QuotaEventFunc
+
+	// Telemetry, if set, receives aggregate usage counts (queries, records,
+	// feedback, sync failures; never content) every TelemetryInterval and
+	// when the client closes. Nil, the default, disables telemetry.
+	Telemetry TelemetryReporter
+
+	// TelemetryInterval is how often Telemetry receives a report.
+	// Defaults to DefaultTelemetryInterval (24 hours).
+	TelemetryInterval time.Duration
+
+	// Summarizer, if set, compresses text that exceeds a length limit
+	// instead of truncating it, e.g. pages clipped by RecordFromURL.
+	Summarizer Summarizer
+
+	// SummarizeContext makes Record pass context over MaxContextLength
+	// through Summarizer instead of rejecting it. A summary still over the
+	// limit is truncated. See Client.ContextSummary.
+	SummarizeContext bool
+
+	// ValidationStaleAfter is how long after its last validation a query
+	// result is flagged in QueryResult.NeedsValidation. Entries never
+	// validated are always flagged. Defaults to DefaultValidationStaleAfter
+	// (90 days).
+	ValidationStaleAfter time.Duration
+
+	// DeltaPageSize is the number of entries requested per delta sync page.
+	// Each page is applied in its own transaction. Defaults to 500.
+	DeltaPageSize int
+
+	// HTTPTransport optionally overrides the transport used for Engram requests,
+	// e.g. to inject authentication headers or route through a custom proxy.
+	// If nil, a default transport honoring HTTP_PROXY/HTTPS_PROXY/NO_PROXY is used.
+	HTTPTransport http.RoundTripper
+
+	// UnsafeFaultInjection injects faults (dropped, delayed and corrupted
+	// requests) into every Engram request, on top of HTTPTransport, for
+	// resilience testing. Never set it against a production Engram.
+	UnsafeFaultInjection *FaultInjector
+
 	// SourceID identifies this client instance.
 	// Defaults to hostname if not set.
 	SourceID string
@@ -33,10 +278,77 @@ type Config struct {
 	// Defaults to 5 minutes.
 	SyncInterval time.Duration
 
-	// AutoSync enables automatic background syncing.
-	// Defaults to true.
+	// SyncJitter spreads background syncs by a random ±SyncJitter share of
+	// each wait, so clients started together don't sync in lockstep. Range
+	// [0, 1); zero, the default, syncs on the exact interval.
+	SyncJitter float64
+
+	// ParallelSync makes SyncNow fetch the first delta page while the push
+	// is in flight. The page is still applied after the push, so ordering
+	// is unchanged; only the network round trips overlap.
+	ParallelSync bool
+
+	// ConfirmFeedback makes Feedback two-phase for strict teams: feedback on
+	// lore Engram has seen is sent to Engram first, and the server's
+	// resulting confidence is applied locally. Feedback Engram rejects is
+	// not applied. When Engram is unreachable, feedback is applied locally
+	// and queued; Sync then confirms it and adopts the server's values.
+	ConfirmFeedback bool
+
+	// FeedbackWindow skips repeated feedback, so an agent that reports the
+	// same entry twice does not inflate its validation count: feedback an
+	// actor already gave an entry within the window, with the same
+	// outcome, is ignored (ErrFeedbackSkipped, or FeedbackResult.Skipped).
+	// Zero, the default, applies every call.
+	FeedbackWindow time.Duration
+
+	// FeedbackCoalesceWindow batches rapid-fire feedback: Feedback calls
+	// made within this window of the first are applied together in one
+	// transaction, with one change_log entry per entry touched. Each call
+	// waits for its window to be applied and still returns its own result.
+	// Zero, the default, applies each call at once. Ignored with
+	// ConfirmFeedback.
+	FeedbackCoalesceWindow time.Duration
+
+	// FeedbackAffinity personalizes ranking: entries this store has given
+	// more helpful than incorrect or outdated feedback have their scores
+	// multiplied by up to 1 + FeedbackAffinity, reached at 3 net helpful
+	// marks. Zero selects DefaultFeedbackAffinity; negative disables it.
+	FeedbackAffinity float64
+
+	// RemoteFallbackScore makes Query ask Engram directly when local
+	// matches are weak: none at all, a best similarity below this score,
+	// or, without a query embedding, fewer than K. Engram's matches are
+	// merged into the result flagged Remote and cached locally, so fresh
+	// lore is available before the next delta sync. The fallback is
+	// best-effort and needs EngramURL; zero, the default, disables it.
+	RemoteFallbackScore float64
+
+	// AutoSync enables automatic background syncing: Sync runs every
+	// SyncInterval, backing off after failures. See Client.PauseSync and
+	// StoreStats.AutoSync. Defaults to true.
 	AutoSync bool
 
+	// AutoRollover rolls a rolling store (see RolloverPolicy) over when its
+	// period ends: once when the client opens, then every
+	// RolloverCheckInterval.
+	AutoRollover bool
+
+	// MaintenanceWait is how long Record, Query, Feedback and sync queue
+	// behind exclusive maintenance (Bootstrap, Reinitialize, Maintain)
+	// before failing with ErrMaintenance. Defaults to
+	// DefaultMaintenanceWait; negative fails them immediately.
+	MaintenanceWait time.Duration
+
+	// Clock supplies timestamps for lore, change_log entries and other
+	// records the client writes. Nil uses SystemClock. Inject a fixed
+	// clock for golden tests or replay tooling.
+	Clock Clock
+
+	// IDGenerator supplies IDs for recorded lore. Nil uses ULIDGenerator.
+	// Generated IDs must be unique within the store.
+	IDGenerator IDGenerator
+
 	// Debug enables verbose logging of all Engram API communications.
 	// When enabled, requests, responses, and full error details are logged.
 	Debug bool
@@ -46,6 +358,9 @@ type Config struct {
 	DebugLogPath string
 }
 
+// CredentialRefreshFunc returns a fresh Engram API key.
+type CredentialRefreshFunc func(ctx context.Context) (string, error)
+
 // DefaultConfig returns a Config with sensible defaults.
 // Store defaults to "default", and LocalPath is derived from Store.
 func DefaultConfig() Config {
@@ -61,22 +376,38 @@ func DefaultConfig() Config {
 
 // ConfigFromEnv reads configuration from environment variables.
 //
-//	RECALL_DB_PATH     → LocalPath (deprecated, for backward compatibility)
-//	ENGRAM_STORE       → Store
-//	ENGRAM_URL         → EngramURL
-//	ENGRAM_API_KEY     → APIKey
-//	RECALL_SOURCE_ID   → SourceID
-//	RECALL_DEBUG       → Debug (any non-empty value enables)
-//	RECALL_DEBUG_LOG   → DebugLogPath
+//	RECALL_DB_PATH         → LocalPath (deprecated, for backward compatibility)
+//	ENGRAM_STORE           → Store
+//	ENGRAM_URL             → EngramURL
+//	ENGRAM_API_KEY         → APIKey
+//	RECALL_SOURCE_ID       → SourceID
+//	RECALL_DEBUG           → Debug (any non-empty value enables)
+//	RECALL_DEBUG_LOG       → DebugLogPath
+//	RECALL_SIGNING_SECRET  → SigningSecret
+//	RECALL_CONFLICT_POLICY → ConflictPolicy
+//	RECALL_NAMESPACE       → Namespace
+//	RECALL_DEDUP_EXACT     → DedupExact (any non-empty value enables)
+//	RECALL_ACTOR           → Actor
+//	RECALL_QUERY_LOG       → QueryLog (any non-empty value enables)
+//	RECALL_INBOX           → Inbox (any non-empty value enables)
+//	RECALL_SYNC_PAUSE_FILE → SyncPauseFile
 func ConfigFromEnv() Config {
 	return Config{
-		LocalPath:    os.Getenv("RECALL_DB_PATH"),
-		Store:        os.Getenv("ENGRAM_STORE"),
-		EngramURL:    os.Getenv("ENGRAM_URL"),
-		APIKey:       os.Getenv("ENGRAM_API_KEY"),
-		SourceID:     os.Getenv("RECALL_SOURCE_ID"),
-		Debug:        os.Getenv("RECALL_DEBUG") != "",
-		DebugLogPath: os.Getenv("RECALL_DEBUG_LOG"),
+		LocalPath:      os.Getenv("RECALL_DB_PATH"),
+		Store:          os.Getenv("ENGRAM_STORE"),
+		EngramURL:      os.Getenv("ENGRAM_URL"),
+		APIKey:         os.Getenv("ENGRAM_API_KEY"),
+		SourceID:       os.Getenv("RECALL_SOURCE_ID"),
+		Debug:          os.Getenv("RECALL_DEBUG") != "",
+		DebugLogPath:   os.Getenv("RECALL_DEBUG_LOG"),
+		SigningSecret:  os.Getenv("RECALL_SIGNING_SECRET"),
+		ConflictPolicy: ConflictPolicy(os.Getenv("RECALL_CONFLICT_POLICY")),
+		Namespace:      os.Getenv("RECALL_NAMESPACE"),
+		DedupExact:     os.Getenv("RECALL_DEDUP_EXACT") != "",
+		Actor:          os.Getenv("RECALL_ACTOR"),
+		QueryLog:       os.Getenv("RECALL_QUERY_LOG") != "",
+		Inbox:          os.Getenv("RECALL_INBOX") != "",
+		SyncPauseFile:  os.Getenv("RECALL_SYNC_PAUSE_FILE"),
 	}
 }
 
@@ -94,13 +425,89 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	if err := ValidateNamespace(c.Namespace); err != nil {
+		return &ValidationError{Field: "Namespace", Message: err.Error()}
+	}
+
 	if c.EngramURL != "" && c.APIKey == "" {
 		return &ValidationError{Field: "APIKey", Message: "required when EngramURL is set"}
 	}
 
+	if c.SyncCanary != "" && !canaryVersionRE.MatchString(c.SyncCanary) {
+		return &ValidationError{Field: "SyncCanary", Message: "must be an API version like v2"}
+	}
+	if c.SyncRetry.MaxAttempts < 0 || c.SyncRetry.MaxWait < 0 {
+		return &ValidationError{Field: "SyncRetry", Message: "must be non-negative"}
+	}
 	if c.SyncInterval < 0 {
 		return &ValidationError{Field: "SyncInterval", Message: "must be non-negative"}
 	}
+	if c.SyncJitter < 0 || c.SyncJitter >= 1 {
+		return &ValidationError{Field: "SyncJitter", Message: "must be at least 0 and less than 1"}
+	}
+
+	if c.RemoteFallbackScore < 0 || c.RemoteFallbackScore > 1 {
+		return &ValidationError{Field: "RemoteFallbackScore", Message: "must be between 0 and 1"}
+	}
+
+	if c.ContentOverflow && c.ChunkLongContent {
+		return &ValidationError{Field: "ContentOverflow", Message: "cannot be combined with ChunkLongContent"}
+	}
+
+	if c.RemoteAuditInterval < 0 {
+		return &ValidationError{Field: "RemoteAuditInterval", Message: "must be non-negative"}
+	}
+
+	if c.FeedbackWindow < 0 {
+		return &ValidationError{Field: "FeedbackWindow", Message: "must be non-negative"}
+	}
+
+	if c.UnsafeFaultInjection != nil {
+		if err := c.UnsafeFaultInjection.validate(); err != nil {
+			return err
+		}
+	}
+
+	if c.EmbedInterval < 0 {
+		return &ValidationError{Field: "EmbedInterval", Message: "must be non-negative"}
+	}
+	if c.EmbeddingProvider != nil && c.EmbeddingModel == "" {
+		return &ValidationError{Field: "EmbeddingModel", Message: "is required with EmbeddingProvider"}
+	}
+
+	if c.FeedbackCoalesceWindow < 0 {
+		return &ValidationError{Field: "FeedbackCoalesceWindow", Message: "must be non-negative"}
+	}
+
+	if c.DeltaPageSize < 0 {
+		return &ValidationError{Field: "DeltaPageSize", Message: "must be non-negative"}
+	}
+
+	if c.SyncBudget.MaxBytesPerDay < 0 || c.SyncBudget.MaxRequestsPerHour < 0 {
+		return &ValidationError{Field: "SyncBudget", Message: "limits must be non-negative"}
+	}
+
+	if c.ConflictPolicy != "" && !c.ConflictPolicy.IsValid() {
+		return &ValidationError{Field: "ConflictPolicy", Message: "must be remote_wins or review"}
+	}
+
+	if err := validateCategoryMinConfidence(c.CategoryMinConfidence); err != nil {
+		return err
+	}
+
+	if err := validateSourceTrust(c.SourceTrust); err != nil {
+		return err
+	}
+
+	if err := c.SoftQuota.validate(); err != nil {
+		return err
+	}
+
+	if c.AccessPolicy != nil {
+		if err := c.AccessPolicy.Validate(); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
@@ -147,6 +554,9 @@ func (c Config) WithDefaults() Config {
 	if c.SyncInterval == 0 {
 		c.SyncInterval = defaults.SyncInterval
 	}
+	if c.SyncPauseFile == "" {
+		c.SyncPauseFile = DefaultSyncPauseFile()
+	}
 	if c.SourceID == "" {
 		c.SourceID = defaults.SourceID
 	}
@@ -107,8 +107,8 @@ func TestConfig_WithDefaults_SetsLocalPathFromStore(t *testing.T) {
 	}.WithDefaults()
 
 	// LocalPath should be set to store-based path
-	if !strings.Contains(cfg.LocalPath, ".recall") {
-		t.Errorf("LocalPath = %q, should contain .recall", cfg.LocalPath)
+	if !strings.Contains(cfg.LocalPath, filepath.Join("recall", "stores")) {
+		t.Errorf("LocalPath = %q, should contain recall/stores", cfg.LocalPath)
 	}
 	if !strings.Contains(cfg.LocalPath, "my-project") {
 		t.Errorf("LocalPath = %q, should contain store ID", cfg.LocalPath)
@@ -203,6 +203,7 @@ func TestConfig_WithDefaults_AutoMigration_SetsMigratedFromMetadata(t *testing.T
 
 	// Point HOME to tmpDir so DefaultStoreRoot uses our temp location
 	os.Setenv("HOME", tmpDir)
+	t.Setenv("XDG_DATA_HOME", filepath.Join(tmpDir, "data"))
 	os.Setenv("RECALL_DB_PATH", legacyDBPath)
 	os.Unsetenv("ENGRAM_STORE")
 
@@ -226,7 +227,7 @@ func TestConfig_WithDefaults_AutoMigration_SetsMigratedFromMetadata(t *testing.T
 	cfg := recall.Config{}.WithDefaults()
 
 	// Verify the new database exists
-	expectedPath := filepath.Join(tmpDir, ".recall", "stores", "default", "lore.db")
+	expectedPath := filepath.Join(tmpDir, "data", "recall", "stores", "default", "lore.db")
 	if _, err := os.Stat(expectedPath); os.IsNotExist(err) {
 		t.Fatalf("migrated database not created at %s", expectedPath)
 	}
@@ -245,6 +246,14 @@ func TestConfig_WithDefaults_AutoMigration_SetsMigratedFromMetadata(t *testing.T
 	if migratedFrom != legacyDBPath {
 		t.Errorf("migrated_from = %q, want %q", migratedFrom, legacyDBPath)
 	}
+
+	stats, err := newStore.Stats()
+	if err != nil {
+		t.Fatalf("Stats: %v", err)
+	}
+	if stats.Path != expectedPath || stats.MigratedFrom != legacyDBPath {
+		t.Errorf("Stats location = %q (from %q), want %q (from %q)", stats.Path, stats.MigratedFrom, expectedPath, legacyDBPath)
+	}
 }
 
 // TestConfig_WithDefaults_AutoMigration_SkipsIfDefaultExists tests that migration
@@ -3,6 +3,7 @@ package recall_test
 import (
 	"errors"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -140,9 +141,9 @@ func TestConfigFromEnv_UnsetVarsDefaultToEmpty(t *testing.T) {
 // Story 7.1: Multi-store support. Default store is "default".
 func TestDefaultConfig_IncludesLocalPath(t *testing.T) {
 	cfg := recall.DefaultConfig()
-	// Default path should be store-based: ~/.recall/stores/default/lore.db
-	if !strings.Contains(cfg.LocalPath, ".recall") || !strings.Contains(cfg.LocalPath, "stores") {
-		t.Errorf("DefaultConfig().LocalPath = %q, should contain .recall/stores", cfg.LocalPath)
+	// Default path should be store-based: <data home>/recall/stores/default/lore.db
+	if !strings.Contains(cfg.LocalPath, filepath.Join("recall", "stores")) {
+		t.Errorf("DefaultConfig().LocalPath = %q, should contain recall/stores", cfg.LocalPath)
 	}
 	if !strings.HasSuffix(cfg.LocalPath, "lore.db") {
 		t.Errorf("DefaultConfig().LocalPath = %q, should end with lore.db", cfg.LocalPath)
@@ -166,8 +167,8 @@ func TestWithDefaults_AppliesLocalPath(t *testing.T) {
 
 	cfg := recall.Config{}.WithDefaults()
 	// LocalPath should be derived from resolved store (default)
-	if !strings.Contains(cfg.LocalPath, ".recall") || !strings.Contains(cfg.LocalPath, "stores") {
-		t.Errorf("WithDefaults().LocalPath = %q, should contain .recall/stores", cfg.LocalPath)
+	if !strings.Contains(cfg.LocalPath, filepath.Join("recall", "stores")) {
+		t.Errorf("WithDefaults().LocalPath = %q, should contain recall/stores", cfg.LocalPath)
 	}
 	if cfg.Store != "default" {
 		t.Errorf("WithDefaults().Store = %q, want %q", cfg.Store, "default")
@@ -206,8 +207,8 @@ func TestConfigFromEnv_WithDefaults_AppliesLocalPath(t *testing.T) {
 
 	cfg := recall.ConfigFromEnv().WithDefaults()
 	// LocalPath should be store-based (default store)
-	if !strings.Contains(cfg.LocalPath, ".recall") || !strings.Contains(cfg.LocalPath, "stores") {
-		t.Errorf("ConfigFromEnv().WithDefaults().LocalPath = %q, should contain .recall/stores", cfg.LocalPath)
+	if !strings.Contains(cfg.LocalPath, filepath.Join("recall", "stores")) {
+		t.Errorf("ConfigFromEnv().WithDefaults().LocalPath = %q, should contain recall/stores", cfg.LocalPath)
 	}
 	if cfg.Store != "default" {
 		t.Errorf("ConfigFromEnv().WithDefaults().Store = %q, want %q", cfg.Store, "default")
@@ -0,0 +1,342 @@
+package recall
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// maxFeedbackBatch is the most entries Engram accepts per feedback request.
+const maxFeedbackBatch = 50
+
+// feedbackRequest is the body of POST /stores/{store_id}/lore/feedback.
+type feedbackRequest struct {
+	SourceID string                 `json:"source_id"`
+	Feedback []feedbackRequestEntry `json:"feedback"`
+}
+
+type feedbackRequestEntry struct {
+	LoreID string       `json:"lore_id"`
+	Type   FeedbackType `json:"type"`
+}
+
+// feedbackResponse is Engram's authoritative result for a feedback request.
+type feedbackResponse struct {
+	Updates []struct {
+		LoreID             string  `json:"lore_id"`
+		PreviousConfidence float64 `json:"previous_confidence"`
+		CurrentConfidence  float64 `json:"current_confidence"`
+		ValidationCount    int     `json:"validation_count"`
+	} `json:"updates"`
+}
+
+// ReconcileResult reports feedback confirmed by Engram after being applied
+// locally while offline (Config.ConfirmFeedback).
+type ReconcileResult struct {
+	// Confirmed lists the server's values, which replaced the local ones.
+	Confirmed []FeedbackUpdate `json:"confirmed"`
+
+	// Rejected lists lore IDs whose queued feedback Engram refused. Their
+	// local values stand until the next delta sync.
+	Rejected []string `json:"rejected,omitempty"`
+}
+
+// feedbackPath returns the API path for store-scoped feedback.
+func (s *Syncer) feedbackPath() string {
+	if s.storeID == "" {
+		panic("recall: feedbackPath requires storeID to be set")
+	}
+	return fmt.Sprintf("/api/v1/stores/%s/lore/feedback", encodeStoreID(s.storeID))
+}
+
+// SubmitFeedback sends feedback to Engram and returns the server's
+// resulting confidence for each entry. Unlike push it does not retry: the
+// caller falls back to applying feedback locally.
+//
+// Errors with a 4xx status other than 408 and 429 are rejections of the
+// feedback itself; see isFeedbackRejection.
+func (s *Syncer) SubmitFeedback(ctx context.Context, entries []feedbackRequestEntry) ([]FeedbackUpdate, error) {
+	body, err := json.Marshal(feedbackRequest{SourceID: s.sourceID, Feedback: entries})
+	if err != nil {
+		return nil, fmt.Errorf("feedback: marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", s.engramURL+s.feedbackPath(), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("feedback: create request: %w", err)
+	}
+	s.setHeaders(req)
+	req.Header.Set("Content-Type", "application/json")
+	if len(s.signingSecret) > 0 {
+		req.Header.Set(SignatureHeader, SignBody(s.signingSecret, body))
+	}
+
+	resp, err := s.do(req)
+	if err != nil {
+		return nil, &SyncError{Operation: "feedback", Err: err}
+	}
+	respBody, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &SyncError{Operation: "feedback", StatusCode: resp.StatusCode, Err: errors.New(truncate(string(respBody), 200))}
+	}
+
+	var fr feedbackResponse
+	if err := json.Unmarshal(respBody, &fr); err != nil {
+		return nil, fmt.Errorf("feedback: decode response: %w", err)
+	}
+	updates := make([]FeedbackUpdate, len(fr.Updates))
+	for i, u := range fr.Updates {
+		updates[i] = FeedbackUpdate{
+			ID:              u.LoreID,
+			Previous:        u.PreviousConfidence,
+			Current:         u.CurrentConfidence,
+			ValidationCount: u.ValidationCount,
+		}
+	}
+	return updates, nil
+}
+
+// isFeedbackRejection reports whether Engram refused the feedback, as
+// opposed to being unreachable.
+func isFeedbackRejection(err error) bool {
+	var se *SyncError
+	if !errors.As(err, &se) {
+		return false
+	}
+	return se.StatusCode >= 400 && se.StatusCode < 500 &&
+		se.StatusCode != http.StatusRequestTimeout && se.StatusCode != http.StatusTooManyRequests
+}
+
+// confirmFeedback implements Feedback under Config.ConfirmFeedback. Lore
+// Engram has seen is confirmed with the server first and the server's
+// values are applied locally. When Engram is unreachable the feedback is
+// applied tentatively and queued for ReconcileFeedback. Lore waiting to
+// be pushed has nothing to confirm: its feedback is applied locally and
+// deferred until the push gives the lore a synced_at. Other lore without
+// synced_at came from Engram and takes the usual local path.
+func (c *Client) confirmFeedback(loreID string, ft FeedbackType) (*Lore, error) {
+	lore, err := c.store.Get(loreID)
+	if err != nil {
+		return nil, err
+	}
+	if lore.SyncedAt == nil {
+		unpushed, err := c.store.HasUnpushedChanges(loreID)
+		if err != nil {
+			return nil, err
+		}
+		if unpushed {
+			return c.store.deferFeedback(loreID, ft)
+		}
+		return c.store.applyFeedback(loreID, feedbackDelta(ft), ft == Helpful, c.actorLevel())
+	}
+	if c.syncer == nil {
+		return c.store.applyTentativeFeedback(loreID, ft)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	updates, err := c.syncer.SubmitFeedback(ctx, []feedbackRequestEntry{{LoreID: loreID, Type: ft}})
+	if isFeedbackRejection(err) {
+		return nil, err
+	}
+	if err != nil {
+		c.debug.LogError("feedback confirm", err)
+		return c.store.applyTentativeFeedback(loreID, ft)
+	}
+	for _, u := range updates {
+		if u.ID == loreID {
+			return c.store.applyConfirmedFeedback(u)
+		}
+	}
+	return nil, &SyncError{Operation: "feedback", StatusCode: http.StatusOK, Err: fmt.Errorf("no update for %s", loreID)}
+}
+
+// ReconcileFeedback sends feedback queued while Engram was unreachable,
+// and deferred feedback whose lore has been pushed since, and replaces
+// the local values with the server's. Sync calls it when
+// Config.ConfirmFeedback is set.
+func (c *Client) ReconcileFeedback(ctx context.Context) (*ReconcileResult, error) {
+	if c.syncer == nil {
+		return nil, ErrOffline
+	}
+	result, err := c.reconcileFeedback(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("client: reconcile feedback: %w", err)
+	}
+	return result, nil
+}
+
+func (c *Client) reconcileFeedback(ctx context.Context) (*ReconcileResult, error) {
+	result := &ReconcileResult{Confirmed: []FeedbackUpdate{}}
+	queued, err := c.store.PendingSyncEntries()
+	if err != nil {
+		return nil, err
+	}
+	var pending []SyncQueueEntry
+	for _, e := range queued {
+		if e.Operation == "FEEDBACK" {
+			pending = append(pending, e)
+		}
+	}
+
+	for start := 0; start < len(pending); start += maxFeedbackBatch {
+		batch := pending[start:min(start+maxFeedbackBatch, len(pending))]
+		entries := make([]feedbackRequestEntry, len(batch))
+		queueIDs := make([]int64, len(batch))
+		for i, e := range batch {
+			var payload FeedbackQueuePayload
+			_ = json.Unmarshal([]byte(e.Payload), &payload)
+			entries[i] = feedbackRequestEntry{LoreID: e.LoreID, Type: FeedbackType(payload.Outcome)}
+			queueIDs[i] = e.ID
+		}
+
+		updates, err := c.syncer.SubmitFeedback(ctx, entries)
+		if isFeedbackRejection(err) {
+			for _, e := range batch {
+				result.Rejected = append(result.Rejected, e.LoreID)
+			}
+			if err := c.store.CompleteSyncEntries(queueIDs, nil); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if err != nil {
+			_ = c.store.FailSyncEntries(queueIDs, err.Error())
+			return nil, err
+		}
+
+		for _, u := range updates {
+			if _, err := c.store.applyConfirmedFeedback(u); err != nil && !errors.Is(err, ErrNotFound) {
+				return nil, err
+			}
+			result.Confirmed = append(result.Confirmed, u)
+		}
+		if err := c.store.CompleteSyncEntries(queueIDs, nil); err != nil {
+			return nil, err
+		}
+	}
+	if err := c.replayDeferredFeedback(ctx, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// applyTentativeFeedback applies feedback locally without a change_log
+// entry, which would push the local confidence over the server's, and
+// queues it for ReconcileFeedback.
+func (s *Store) applyTentativeFeedback(loreID string, ft FeedbackType) (*Lore, error) {
+	return s.applyQueuedFeedback(loreID, ft, func(tx *sql.Tx) error {
+		payload, _ := json.Marshal(FeedbackQueuePayload{Outcome: string(ft)})
+		if _, err := tx.Exec(`
+			INSERT INTO sync_queue (lore_id, operation, payload, queued_at)
+			VALUES (?, 'FEEDBACK', ?, ?)
+		`, loreID, payload, s.now().Format(time.RFC3339)); err != nil {
+			return fmt.Errorf("store: queue feedback: %w", err)
+		}
+		return nil
+	})
+}
+
+// applyQueuedFeedback applies feedback locally without a change_log entry
+// and calls queue in the same transaction to record it for Engram.
+func (s *Store) applyQueuedFeedback(loreID string, ft FeedbackType, queue func(tx *sql.Tx) error) (*Lore, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil, ErrStoreClosed
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("store: begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	lore, err := s.getLoreTx(tx, loreID)
+	if err != nil {
+		return nil, err
+	}
+	confidence := min(max(lore.Confidence+feedbackDelta(ft), ConfidenceMin), ConfidenceMax)
+	validations := lore.ValidationCount
+	if ft == Helpful {
+		validations++
+	}
+	if err := s.updateFeedbackTx(tx, s.namespace, loreID, confidence, validations, validations > lore.ValidationCount); err != nil {
+		return nil, err
+	}
+
+	if err := queue(tx); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.getLoreTx(tx, loreID)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("store: commit: %w", err)
+	}
+	return updated, nil
+}
+
+// applyConfirmedFeedback sets the confidence and validation count Engram
+// reported. No change_log entry is written: the server already has them.
+func (s *Store) applyConfirmedFeedback(u FeedbackUpdate) (*Lore, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil, ErrStoreClosed
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("store: begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	lore, err := s.getLoreTx(tx, u.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.updateFeedbackTx(tx, s.namespace, u.ID, u.Current, u.ValidationCount, u.ValidationCount > lore.ValidationCount); err != nil {
+		return nil, err
+	}
+	updated, err := s.getLoreTx(tx, u.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("store: commit: %w", err)
+	}
+	return updated, nil
+}
+
+// updateFeedbackTx writes a feedback result, stamping last_validated_at
+// when validated is set.
+func (s *Store) updateFeedbackTx(tx *sql.Tx, namespace, loreID string, confidence float64, validations int, validated bool) error {
+	now := s.now().Format(time.RFC3339)
+	var lastValidated *string
+	if validated {
+		lastValidated = &now
+	}
+	_, err := tx.Exec(`
+		UPDATE lore_entries
+		SET confidence = ?, validation_count = ?, last_validated_at = COALESCE(?, last_validated_at),
+		    confidence_updated_at = ?, updated_at = ?
+		WHERE id = ? AND deleted_at IS NULL AND namespace = ?
+	`, confidence, validations, lastValidated, now, now, loreID, namespace)
+	if err != nil {
+		return fmt.Errorf("store: update confidence: %w", err)
+	}
+	return nil
+}
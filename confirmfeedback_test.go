@@ -0,0 +1,163 @@
+package recall
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// feedbackServer fakes Engram's feedback endpoint. status is the response
+// code; on 200 each entry is answered with confidence 0.9 and 5 validations.
+type feedbackServer struct {
+	*httptest.Server
+	status   atomic.Int32
+	requests atomic.Int32
+	last     feedbackRequest
+}
+
+func newFeedbackServer(t *testing.T) *feedbackServer {
+	t.Helper()
+	fs := &feedbackServer{}
+	fs.status.Store(http.StatusOK)
+	fs.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/stores/test-store/lore/feedback" {
+			_, _ = w.Write([]byte(`{}`))
+			return
+		}
+		fs.requests.Add(1)
+		_ = json.NewDecoder(r.Body).Decode(&fs.last)
+		status := int(fs.status.Load())
+		if status != http.StatusOK {
+			w.WriteHeader(status)
+			return
+		}
+		var resp feedbackResponse
+		for _, e := range fs.last.Feedback {
+			resp.Updates = append(resp.Updates, struct {
+				LoreID             string  `json:"lore_id"`
+				PreviousConfidence float64 `json:"previous_confidence"`
+				CurrentConfidence  float64 `json:"current_confidence"`
+				ValidationCount    int     `json:"validation_count"`
+			}{LoreID: e.LoreID, PreviousConfidence: 0.8, CurrentConfidence: 0.9, ValidationCount: 5})
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(fs.Close)
+	return fs
+}
+
+// newConfirmClient returns a ConfirmFeedback client with one entry, marked
+// synced when synced is set.
+func newConfirmClient(t *testing.T, url string, synced bool) *Client {
+	t.Helper()
+	client := newTestClient(t, Config{
+		Store:           "test-store",
+		EngramURL:       url,
+		APIKey:          "key",
+		ConfirmFeedback: true,
+	})
+
+	now := time.Now().UTC()
+	if err := client.store.InsertLore(&Lore{
+		ID: "01CONFIRM0000000000000000", Content: "Confirm me", Category: CategoryPatternOutcome,
+		Confidence: 0.5, SourceID: "test", CreatedAt: now, UpdatedAt: now,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if synced {
+		if err := client.store.MarkSynced([]string{"01CONFIRM0000000000000000"}, now); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return client
+}
+
+func TestConfirmFeedback_AppliesServerValues(t *testing.T) {
+	server := newFeedbackServer(t)
+	client := newConfirmClient(t, server.URL, true)
+	before := getChangeLogCount(t, client.store)
+
+	lore, err := client.Feedback("01CONFIRM0000000000000000", Helpful)
+	if err != nil {
+		t.Fatalf("Feedback() error = %v", err)
+	}
+	if lore.Confidence != 0.9 || lore.ValidationCount != 5 {
+		t.Errorf("lore = %.2f/%d, want the server's 0.90/5", lore.Confidence, lore.ValidationCount)
+	}
+	if server.last.SourceID == "" || len(server.last.Feedback) != 1 || server.last.Feedback[0].Type != FeedbackHelpful {
+		t.Errorf("request = %+v, want one helpful entry with a source ID", server.last)
+	}
+	if after := getChangeLogCount(t, client.store); after != before {
+		t.Errorf("change_log grew from %d to %d; confirmed feedback should not be pushed back", before, after)
+	}
+}
+
+func TestConfirmFeedback_RejectedIsNotApplied(t *testing.T) {
+	server := newFeedbackServer(t)
+	server.status.Store(http.StatusNotFound)
+	client := newConfirmClient(t, server.URL, true)
+
+	_, err := client.Feedback("01CONFIRM0000000000000000", Incorrect)
+	var se *SyncError
+	if !errors.As(err, &se) || se.StatusCode != http.StatusNotFound {
+		t.Fatalf("Feedback() error = %v, want SyncError 404", err)
+	}
+	lore, _ := client.store.Get("01CONFIRM0000000000000000")
+	if lore.Confidence != 0.5 {
+		t.Errorf("confidence = %.2f, want unchanged 0.50", lore.Confidence)
+	}
+}
+
+func TestConfirmFeedback_UnreachableQueuesAndReconciles(t *testing.T) {
+	server := newFeedbackServer(t)
+	server.status.Store(http.StatusServiceUnavailable)
+	client := newConfirmClient(t, server.URL, true)
+
+	lore, err := client.Feedback("01CONFIRM0000000000000000", Helpful)
+	if err != nil {
+		t.Fatalf("Feedback() error = %v", err)
+	}
+	if lore.Confidence != 0.5+ConfidenceHelpfulDelta || lore.ValidationCount != 1 {
+		t.Errorf("tentative lore = %.2f/%d, want local delta applied", lore.Confidence, lore.ValidationCount)
+	}
+	if n := getSyncQueueCount(t, client.store); n != 1 {
+		t.Fatalf("sync_queue has %d entries, want 1 queued feedback", n)
+	}
+
+	server.status.Store(http.StatusOK)
+	result, err := client.ReconcileFeedback(context.Background())
+	if err != nil {
+		t.Fatalf("ReconcileFeedback() error = %v", err)
+	}
+	if len(result.Confirmed) != 1 {
+		t.Errorf("Confirmed = %v, want 1 update", result.Confirmed)
+	}
+	lore, _ = client.store.Get("01CONFIRM0000000000000000")
+	if lore.Confidence != 0.9 || lore.ValidationCount != 5 {
+		t.Errorf("reconciled lore = %.2f/%d, want the server's 0.90/5", lore.Confidence, lore.ValidationCount)
+	}
+	if n := getSyncQueueCount(t, client.store); n != 0 {
+		t.Errorf("sync_queue has %d entries after reconcile, want 0", n)
+	}
+}
+
+func TestConfirmFeedback_UnsyncedLoreStaysLocal(t *testing.T) {
+	server := newFeedbackServer(t)
+	client := newConfirmClient(t, server.URL, false)
+
+	lore, err := client.Feedback("01CONFIRM0000000000000000", Helpful)
+	if err != nil {
+		t.Fatalf("Feedback() error = %v", err)
+	}
+	if server.requests.Load() != 0 {
+		t.Error("feedback on lore Engram has not seen should not be sent")
+	}
+	if lore.Confidence != 0.5+ConfidenceHelpfulDelta {
+		t.Errorf("confidence = %.2f, want local delta", lore.Confidence)
+	}
+}
@@ -0,0 +1,325 @@
+package recall
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// ConflictPolicy controls how delta sync handles remote changes to lore
+// that also has unpushed local changes.
+type ConflictPolicy string
+
+const (
+	// ConflictPolicyRemoteWins applies remote changes over local edits (default).
+	ConflictPolicyRemoteWins ConflictPolicy = "remote_wins"
+	// ConflictPolicyReview records conflicts for manual resolution and leaves
+	// the local entry untouched until resolved.
+	ConflictPolicyReview ConflictPolicy = "review"
+)
+
+// IsValid checks if the policy is a known conflict policy.
+func (p ConflictPolicy) IsValid() bool {
+	return p == ConflictPolicyRemoteWins || p == ConflictPolicyReview
+}
+
+// ConflictResolution records which side won a resolved conflict.
+type ConflictResolution string
+
+const (
+	ConflictResolutionLocal  ConflictResolution = "local"
+	ConflictResolutionRemote ConflictResolution = "remote"
+	ConflictResolutionMerged ConflictResolution = "merged"
+)
+
+// SyncConflict is a divergence between local and remote state for one lore entry.
+type SyncConflict struct {
+	ID             int64              `json:"id"`
+	LoreID         string             `json:"lore_id"`
+	Local          Lore               `json:"local"`
+	Remote         Lore               `json:"remote"`
+	RemoteSequence int64              `json:"remote_sequence"`
+	DetectedAt     time.Time          `json:"detected_at"`
+	ResolvedAt     *time.Time         `json:"resolved_at,omitempty"`
+	Resolution     ConflictResolution `json:"resolution,omitempty"`
+}
+
+// FieldDiff describes a single field that differs between local and remote.
+type FieldDiff struct {
+	Field  string `json:"field"`
+	Local  string `json:"local"`
+	Remote string `json:"remote"`
+}
+
+// FieldDiffs returns the user-editable fields that differ between the local
+// and remote versions, in a stable order.
+func (c *SyncConflict) FieldDiffs() []FieldDiff {
+	return loreFieldDiffs(&c.Local, &c.Remote)
+}
+
+// loreFieldDiffs compares the user-editable fields of two lore entries.
+func loreFieldDiffs(local, remote *Lore) []FieldDiff {
+	var diffs []FieldDiff
+	add := func(field, l, r string) {
+		if l != r {
+			diffs = append(diffs, FieldDiff{Field: field, Local: l, Remote: r})
+		}
+	}
+	add("content", local.Content, remote.Content)
+	add("context", local.Context, remote.Context)
+	add("category", string(local.Category), string(remote.Category))
+	add("confidence", strconv.FormatFloat(local.Confidence, 'f', 2, 64), strconv.FormatFloat(remote.Confidence, 'f', 2, 64))
+	return diffs
+}
+
+// HasUnpushedChanges reports whether the lore entry has local change_log
+// entries that have not yet been pushed to Engram.
+func (s *Store) HasUnpushedChanges(loreID string) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return false, ErrStoreClosed
+	}
+
+	var count int
+	err := s.db.QueryRow(`
+		SELECT COUNT(*) FROM change_log
+		WHERE table_name = 'lore_entries' AND entity_id = ? AND source_id = ?
+		  AND sequence > COALESCE((SELECT CAST(value AS INTEGER) FROM sync_meta WHERE key = 'last_push_seq'), 0)
+	`, loreID, s.sourceID).Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("store: check unpushed changes: %w", err)
+	}
+	return count > 0, nil
+}
+
+// RecordConflict stores an unresolved conflict between the local entry and a
+// remote payload received at remoteSeq.
+func (s *Store) RecordConflict(local *Lore, remotePayload json.RawMessage, remoteSeq int64) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return 0, ErrStoreClosed
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("store: begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	id, err := s.recordConflictTx(tx, local, remotePayload, remoteSeq)
+	if err != nil {
+		return 0, err
+	}
+	return id, tx.Commit()
+}
+
+// recordConflictTx inserts a sync_conflicts row within a transaction.
+func (s *Store) recordConflictTx(tx *sql.Tx, local *Lore, remotePayload json.RawMessage, remoteSeq int64) (int64, error) {
+	localPayload, err := lorePayloadJSON(local)
+	if err != nil {
+		return 0, fmt.Errorf("store: marshal local payload: %w", err)
+	}
+
+	res, err := tx.Exec(`
+		INSERT INTO sync_conflicts (lore_id, local_payload, remote_payload, remote_sequence, detected_at)
+		VALUES (?, ?, ?, ?, ?)
+	`, local.ID, string(localPayload), string(remotePayload), remoteSeq, s.now().Format(time.RFC3339))
+	if err != nil {
+		return 0, fmt.Errorf("store: record conflict: %w", err)
+	}
+	return res.LastInsertId()
+}
+
+// ListConflicts returns sync conflicts ordered by detection time.
+// If includeResolved is false, only unresolved conflicts are returned.
+func (s *Store) ListConflicts(includeResolved bool) ([]SyncConflict, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil, ErrStoreClosed
+	}
+
+	query := `
+		SELECT id, lore_id, local_payload, remote_payload, remote_sequence, detected_at, resolved_at, resolution
+		FROM sync_conflicts
+	`
+	if !includeResolved {
+		query += " WHERE resolved_at IS NULL"
+	}
+	query += " ORDER BY id ASC"
+
+	rows, err := s.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("store: list conflicts: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var conflicts []SyncConflict
+	for rows.Next() {
+		c, err := scanConflict(rows)
+		if err != nil {
+			return nil, err
+		}
+		conflicts = append(conflicts, *c)
+	}
+	return conflicts, rows.Err()
+}
+
+// GetConflict returns a single conflict by ID.
+// Returns ErrNotFound if no conflict with that ID exists.
+func (s *Store) GetConflict(id int64) (*SyncConflict, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil, ErrStoreClosed
+	}
+
+	row := s.db.QueryRow(`
+		SELECT id, lore_id, local_payload, remote_payload, remote_sequence, detected_at, resolved_at, resolution
+		FROM sync_conflicts WHERE id = ?
+	`, id)
+	c, err := scanConflict(row)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	return c, err
+}
+
+// ResolveConflict applies the winning lore state and marks the conflict resolved.
+// All operations occur in a single transaction:
+//  1. UPDATE lore_entries with the winner's editable fields
+//  2. Write a change_log upsert so the resolution propagates on next push
+//  3. Mark the conflict resolved with the given resolution
+//
+// Returns the updated Lore entry.
+func (s *Store) ResolveConflict(id int64, winner *Lore, resolution ConflictResolution) (*Lore, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil, ErrStoreClosed
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("store: begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	now := s.now().Format(time.RFC3339)
+	res, err := tx.Exec(`
+		UPDATE sync_conflicts SET resolved_at = ?, resolution = ?
+		WHERE id = ? AND resolved_at IS NULL
+	`, now, string(resolution), id)
+	if err != nil {
+		return nil, fmt.Errorf("store: mark conflict resolved: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return nil, ErrNotFound
+	}
+
+	_, err = tx.Exec(`
+		UPDATE lore_entries SET content = ?, fingerprint = ?, checksum = ?, context = ?, category = ?, confidence = ?, updated_at = ?
+		WHERE id = ?
+	`, winner.Content, Fingerprint(winner.Content), loreChecksum(winner.ID, winner.Content, winner.Context, winner.Category), nullString(winner.Context), string(winner.Category), winner.Confidence, now, winner.ID)
+	if err != nil {
+		return nil, fmt.Errorf("store: apply resolution: %w", err)
+	}
+
+	updated, err := s.getLoreTx(tx, winner.ID)
+	if err != nil {
+		return nil, fmt.Errorf("store: read resolved lore: %w", err)
+	}
+	payload, err := lorePayloadJSON(updated)
+	if err != nil {
+		return nil, fmt.Errorf("store: marshal change_log payload: %w", err)
+	}
+	if err := s.appendChangeLog(tx, "lore_entries", winner.ID, "upsert", payload, s.sourceID); err != nil {
+		return nil, err
+	}
+	if err := incrementMetricTx(tx, metricConflictsResolvedPrefix+string(resolution), 1); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("store: commit: %w", err)
+	}
+	return updated, nil
+}
+
+// scanConflict scans a sync_conflicts row and decodes both payloads.
+func scanConflict(sc scanner) (*SyncConflict, error) {
+	var (
+		c             SyncConflict
+		localPayload  string
+		remotePayload string
+		detectedAt    string
+		resolvedAt    sql.NullString
+		resolution    sql.NullString
+	)
+	if err := sc.Scan(&c.ID, &c.LoreID, &localPayload, &remotePayload, &c.RemoteSequence, &detectedAt, &resolvedAt, &resolution); err != nil {
+		return nil, err
+	}
+
+	local, err := parseLorePayload(json.RawMessage(localPayload))
+	if err != nil {
+		return nil, fmt.Errorf("store: decode local payload for conflict %d: %w", c.ID, err)
+	}
+	remote, err := parseLorePayload(json.RawMessage(remotePayload))
+	if err != nil {
+		return nil, fmt.Errorf("store: decode remote payload for conflict %d: %w", c.ID, err)
+	}
+	c.Local = *local
+	c.Remote = *remote
+	c.DetectedAt, _ = time.Parse(time.RFC3339, detectedAt)
+	if resolvedAt.Valid {
+		t, _ := time.Parse(time.RFC3339, resolvedAt.String)
+		c.ResolvedAt = &t
+	}
+	c.Resolution = ConflictResolution(resolution.String)
+	return &c, nil
+}
+
+// locallyModified checks whether a remote upsert collides with unpushed local
+// edits. If so, the local entry is returned; under the review policy the
+// caller must record the conflict and skip applying the remote change.
+func (s *Syncer) locallyModified(entry DeltaEntry) (*Lore, error) {
+	pending, err := s.store.HasUnpushedChanges(entry.EntityID)
+	if err != nil || !pending {
+		return nil, err
+	}
+
+	local, err := s.store.Get(entry.EntityID)
+	if err == ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	remote, err := parseLorePayload(entry.Payload)
+	if err != nil {
+		return nil, err
+	}
+	if len(loreFieldDiffs(local, remote)) == 0 {
+		return nil, nil
+	}
+	return local, nil
+}
+
+// SetConflictPolicy sets how delta sync handles conflicting remote changes.
+// An empty policy is treated as ConflictPolicyRemoteWins.
+func (s *Syncer) SetConflictPolicy(policy ConflictPolicy) {
+	if policy == "" {
+		policy = ConflictPolicyRemoteWins
+	}
+	s.conflictPolicy = policy
+}
@@ -0,0 +1,220 @@
+package recall
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// =============================================================================
+// Sync conflicts: detection under ConflictPolicyReview and resolution
+// =============================================================================
+
+// setupConflict inserts a local lore entry with an unpushed change and runs a
+// delta sync that returns a divergent remote version under the given policy.
+func setupConflict(t *testing.T, policy ConflictPolicy) (*Store, *DeltaResult) {
+	t.Helper()
+	store := newTestStore(t)
+
+	now := time.Now().UTC()
+	local := &Lore{
+		ID:         "lore-conflict-001",
+		Content:    "local content",
+		Category:   CategoryPatternOutcome,
+		Confidence: 0.6,
+		SourceID:   store.SourceID(),
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if err := store.InsertLore(local); err != nil {
+		t.Fatalf("InsertLore: %v", err)
+	}
+
+	ts := now.Format(time.RFC3339)
+	entries := []DeltaEntry{{
+		Sequence:   7,
+		TableName:  "lore_entries",
+		EntityID:   local.ID,
+		Operation:  "upsert",
+		Payload:    makeDeltaPayload(local.ID, "remote content", "PATTERN_OUTCOME", "remote-source", ts, ts),
+		SourceID:   "remote-source",
+		CreatedAt:  ts,
+		ReceivedAt: ts,
+	}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(SyncDeltaResponse{Entries: entries, LastSequence: 7, LatestSequence: 7})
+	}))
+	defer server.Close()
+
+	syncer := newTestSyncer(t, store, server.URL)
+	syncer.SetConflictPolicy(policy)
+
+	result, err := syncer.SyncDelta(context.Background())
+	if err != nil {
+		t.Fatalf("SyncDelta: %v", err)
+	}
+	return store, result
+}
+
+func TestSyncDelta_ReviewPolicy_RecordsConflict(t *testing.T) {
+	store, result := setupConflict(t, ConflictPolicyReview)
+
+	if result.EntriesConflicted != 1 || result.EntriesApplied != 0 {
+		t.Errorf("conflicted=%d applied=%d, want 1 and 0", result.EntriesConflicted, result.EntriesApplied)
+	}
+
+	lore, err := store.Get("lore-conflict-001")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if lore.Content != "local content" {
+		t.Errorf("Content = %q, local edit should be preserved", lore.Content)
+	}
+
+	conflicts, err := store.ListConflicts(false)
+	if err != nil {
+		t.Fatalf("ListConflicts: %v", err)
+	}
+	if len(conflicts) != 1 {
+		t.Fatalf("len(conflicts) = %d, want 1", len(conflicts))
+	}
+	c := conflicts[0]
+	if c.RemoteSequence != 7 || c.Remote.Content != "remote content" || c.Local.Content != "local content" {
+		t.Errorf("unexpected conflict: %+v", c)
+	}
+
+	diffs := c.FieldDiffs()
+	if len(diffs) != 2 || diffs[0].Field != "content" || diffs[1].Field != "confidence" {
+		t.Errorf("FieldDiffs = %+v, want content and confidence", diffs)
+	}
+}
+
+func TestSyncDelta_RemoteWinsPolicy_Overwrites(t *testing.T) {
+	store, result := setupConflict(t, ConflictPolicyRemoteWins)
+
+	if result.EntriesConflicted != 0 || result.EntriesApplied != 1 {
+		t.Errorf("conflicted=%d applied=%d, want 0 and 1", result.EntriesConflicted, result.EntriesApplied)
+	}
+	lore, _ := store.Get("lore-conflict-001")
+	if lore.Content != "remote content" {
+		t.Errorf("Content = %q, want remote content", lore.Content)
+	}
+	conflicts, _ := store.ListConflicts(true)
+	if len(conflicts) != 0 {
+		t.Errorf("len(conflicts) = %d, want 0", len(conflicts))
+	}
+}
+
+func TestSyncDelta_ReviewPolicy_NoUnpushedChanges_Applies(t *testing.T) {
+	store := newTestStore(t)
+	now := time.Now().UTC().Format(time.RFC3339)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(SyncDeltaResponse{
+			Entries: []DeltaEntry{{
+				Sequence: 1, TableName: "lore_entries", EntityID: "e1", Operation: "upsert",
+				Payload:  makeDeltaPayload("e1", "remote", "PATTERN_OUTCOME", "remote-source", now, now),
+				SourceID: "remote-source", CreatedAt: now, ReceivedAt: now,
+			}},
+			LastSequence: 1, LatestSequence: 1,
+		})
+	}))
+	defer server.Close()
+
+	syncer := newTestSyncer(t, store, server.URL)
+	syncer.SetConflictPolicy(ConflictPolicyReview)
+
+	result, err := syncer.SyncDelta(context.Background())
+	if err != nil {
+		t.Fatalf("SyncDelta: %v", err)
+	}
+	if result.EntriesApplied != 1 || result.EntriesConflicted != 0 {
+		t.Errorf("applied=%d conflicted=%d, want 1 and 0", result.EntriesApplied, result.EntriesConflicted)
+	}
+}
+
+func TestStore_ResolveConflict_RemoteQueuesChange(t *testing.T) {
+	store, _ := setupConflict(t, ConflictPolicyReview)
+	conflicts, _ := store.ListConflicts(false)
+	c := conflicts[0]
+
+	before, _ := store.UnpushedChanges(store.SourceID(), 0, 100)
+
+	lore, err := store.ResolveConflict(c.ID, &c.Remote, ConflictResolutionRemote)
+	if err != nil {
+		t.Fatalf("ResolveConflict: %v", err)
+	}
+	if lore.Content != "remote content" {
+		t.Errorf("Content = %q, want remote content", lore.Content)
+	}
+
+	after, _ := store.UnpushedChanges(store.SourceID(), 0, 100)
+	if len(after) != len(before)+1 {
+		t.Errorf("change_log entries = %d, want %d", len(after), len(before)+1)
+	}
+
+	resolved, err := store.GetConflict(c.ID)
+	if err != nil {
+		t.Fatalf("GetConflict: %v", err)
+	}
+	if resolved.ResolvedAt == nil || resolved.Resolution != ConflictResolutionRemote {
+		t.Errorf("conflict not marked resolved: %+v", resolved)
+	}
+	if open, _ := store.ListConflicts(false); len(open) != 0 {
+		t.Errorf("unresolved conflicts = %d, want 0", len(open))
+	}
+
+	if _, err := store.ResolveConflict(c.ID, &c.Remote, ConflictResolutionRemote); !errors.Is(err, ErrNotFound) {
+		t.Errorf("second resolve error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestClient_ResolveConflict_Merged(t *testing.T) {
+	store, _ := setupConflict(t, ConflictPolicyReview)
+	client := &Client{store: store}
+	conflicts, _ := client.Conflicts(false)
+
+	merged := conflicts[0].Local
+	merged.Content = "merged content"
+	lore, err := client.ResolveConflict(conflicts[0].ID, ConflictResolutionMerged, &merged)
+	if err != nil {
+		t.Fatalf("ResolveConflict: %v", err)
+	}
+	if lore.Content != "merged content" || lore.ID != "lore-conflict-001" {
+		t.Errorf("lore = %+v, want merged content", lore)
+	}
+}
+
+func TestClient_ResolveConflict_Validation(t *testing.T) {
+	store, _ := setupConflict(t, ConflictPolicyReview)
+	client := &Client{store: store}
+	conflicts, _ := client.Conflicts(false)
+	id := conflicts[0].ID
+
+	var ve *ValidationError
+	if _, err := client.ResolveConflict(id, ConflictResolutionMerged, nil); !errors.As(err, &ve) {
+		t.Errorf("merged without lore: err = %v, want ValidationError", err)
+	}
+	if _, err := client.ResolveConflict(id, "bogus", nil); !errors.As(err, &ve) {
+		t.Errorf("unknown resolution: err = %v, want ValidationError", err)
+	}
+	if _, err := client.ResolveConflict(999, ConflictResolutionLocal, nil); !errors.Is(err, ErrNotFound) {
+		t.Errorf("missing conflict: err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestConfig_Validate_ConflictPolicy(t *testing.T) {
+	cfg := Config{LocalPath: "x.db", ConflictPolicy: "first_wins"}
+	var ve *ValidationError
+	if err := cfg.Validate(); !errors.As(err, &ve) || ve.Field != "ConflictPolicy" {
+		t.Errorf("Validate = %v, want ConflictPolicy error", err)
+	}
+	cfg.ConflictPolicy = ConflictPolicyReview
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate = %v, want nil", err)
+	}
+}
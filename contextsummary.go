@@ -0,0 +1,112 @@
+package recall
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// ContextSummary records that Record summarized an entry's context to fit
+// MaxContextLength.
+type ContextSummary struct {
+	LoreID         string    `json:"lore_id"`
+	OriginalLength int       `json:"original_length"` // Length of the context as submitted
+	Truncated      bool      `json:"truncated"`       // The summary was still too long and was truncated
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// SetContextSummary stores how loreID's context was summarized. Summaries
+// are local-only.
+func (s *Store) SetContextSummary(summary *ContextSummary) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return ErrStoreClosed
+	}
+
+	_, err := s.db.Exec(`
+		INSERT INTO lore_context_summaries (lore_id, original_length, truncated, created_at) VALUES (?, ?, ?, ?)
+		ON CONFLICT(lore_id) DO UPDATE SET original_length = excluded.original_length, truncated = excluded.truncated, created_at = excluded.created_at
+	`, summary.LoreID, summary.OriginalLength, summary.Truncated, summary.CreatedAt.UTC().Format(time.RFC3339))
+	if err != nil {
+		return fmt.Errorf("store: set context summary: %w", err)
+	}
+	return nil
+}
+
+// ContextSummary returns how loreID's context was summarized, or nil if it
+// was stored as submitted.
+func (s *Store) ContextSummary(loreID string) (*ContextSummary, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil, ErrStoreClosed
+	}
+
+	summary := &ContextSummary{LoreID: loreID}
+	var createdAt string
+	err := s.db.QueryRow(`SELECT original_length, truncated, created_at FROM lore_context_summaries WHERE lore_id = ?`, loreID).
+		Scan(&summary.OriginalLength, &summary.Truncated, &createdAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("store: context summary: %w", err)
+	}
+	summary.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+	return summary, nil
+}
+
+// ContextSummary returns how Record summarized ref's context because it
+// exceeded MaxContextLength, or nil if the context was stored as
+// submitted. For chunked content, the summary is kept with the first part.
+// ref may be a lore ID or a session ref.
+func (c *Client) ContextSummary(ref string) (*ContextSummary, error) {
+	id, err := c.resolveRef(ref)
+	if err != nil {
+		return nil, err
+	}
+	summary, err := c.store.ContextSummary(id)
+	if err != nil {
+		return nil, fmt.Errorf("client: context summary: %w", err)
+	}
+	return summary, nil
+}
+
+// summarizeContext compresses context over MaxContextLength with
+// Config.Summarizer when Config.SummarizeContext is set, truncating a
+// summary that is still too long. It returns nil when the context is left
+// as submitted.
+func (c *Client) summarizeContext(options *recordOptions) (*ContextSummary, error) {
+	if len(options.context) <= MaxContextLength || !c.config.SummarizeContext || c.config.Summarizer == nil {
+		return nil, nil
+	}
+	summary := &ContextSummary{OriginalLength: len(options.context)}
+	text, err := c.config.Summarizer.Summarize(context.Background(), options.context, MaxContextLength)
+	if err != nil {
+		return nil, fmt.Errorf("client: record: summarize context: %w", err)
+	}
+	if len(text) > MaxContextLength {
+		text = truncateText(text, MaxContextLength)
+		summary.Truncated = true
+	}
+	options.context = text
+	return summary, nil
+}
+
+// keepContextSummary stores summary for lore. Like preserveOriginal it is
+// best-effort; a failed write is reported to the debug log and does not
+// fail Record.
+func (c *Client) keepContextSummary(lore *Lore, summary *ContextSummary) {
+	if summary == nil {
+		return
+	}
+	summary.LoreID = lore.ID
+	summary.CreatedAt = lore.CreatedAt
+	if err := c.store.SetContextSummary(summary); err != nil {
+		c.debug.LogError("keep context summary", err)
+	}
+}
@@ -0,0 +1,72 @@
+package recall
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// funcSummarizer adapts a function to Summarizer.
+type funcSummarizer func(text string, maxLen int) (string, error)
+
+func (f funcSummarizer) Summarize(ctx context.Context, text string, maxLen int) (string, error) {
+	return f(text, maxLen)
+}
+
+func TestClient_Record_SummarizeContext(t *testing.T) {
+	long := strings.Repeat("step ", 300)
+	client := newTestClient(t, Config{AutoSync: false})
+
+	// Without SummarizeContext, long context is still rejected.
+	client.config.Summarizer = funcSummarizer(func(string, int) (string, error) { return "short", nil })
+	var verr *ValidationError
+	if _, err := client.Record("Content", CategoryPatternOutcome, WithContext(long)); !errors.As(err, &verr) || verr.Field != "Context" {
+		t.Fatalf("Record() error = %v, want a Context validation error", err)
+	}
+
+	client.config.SummarizeContext = true
+	var gotMax int
+	client.config.Summarizer = funcSummarizer(func(text string, maxLen int) (string, error) {
+		gotMax = maxLen
+		return "the build steps, summarized", nil
+	})
+	lore, err := client.Record("Content", CategoryPatternOutcome, WithContext(long))
+	if err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+	if lore.Context != "the build steps, summarized" || gotMax != MaxContextLength {
+		t.Errorf("Context = %q (maxLen %d), want the summary", lore.Context, gotMax)
+	}
+	summary, err := client.ContextSummary(lore.ID)
+	if err != nil || summary == nil || summary.OriginalLength != len(long) || summary.Truncated {
+		t.Errorf("ContextSummary() = %+v, %v; want the original length and not truncated", summary, err)
+	}
+
+	// A summary still over the limit is truncated and flagged.
+	client.config.Summarizer = funcSummarizer(func(text string, maxLen int) (string, error) { return text, nil })
+	lore, err = client.Record("Other content", CategoryPatternOutcome, WithContext(long))
+	if err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+	if len(lore.Context) > MaxContextLength || !strings.HasSuffix(lore.Context, "...") {
+		t.Errorf("Context has %d chars, want truncated under the limit", len(lore.Context))
+	}
+	if summary, _ := client.ContextSummary(lore.ID); summary == nil || !summary.Truncated {
+		t.Errorf("ContextSummary() = %+v, want Truncated", summary)
+	}
+
+	// Context within the limit is stored as submitted.
+	lore, err = client.Record("Third content", CategoryPatternOutcome, WithContext("short"))
+	if err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+	if summary, err := client.ContextSummary(lore.ID); summary != nil || err != nil {
+		t.Errorf("ContextSummary() = %+v, %v; want nil", summary, err)
+	}
+
+	client.config.Summarizer = funcSummarizer(func(string, int) (string, error) { return "", errors.New("model unavailable") })
+	if _, err := client.Record("Fourth content", CategoryPatternOutcome, WithContext(long)); err == nil || !strings.Contains(err.Error(), "summarize context: model unavailable") {
+		t.Errorf("Record() error = %v, want the summarizer error", err)
+	}
+}
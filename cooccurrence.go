@@ -0,0 +1,200 @@
+package recall
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// DefaultCooccurrenceLimit is how many rows each list of a
+// CooccurrenceReport holds when Cooccurrence is given no limit.
+const DefaultCooccurrenceLimit = 10
+
+// ContextActivity is a context and the lore recorded under it.
+type ContextActivity struct {
+	Context    string     `json:"context"`
+	Entries    int        `json:"entries"`
+	Categories []Category `json:"categories"` // Most entries first
+}
+
+// CategoryPair is two categories recorded under the same contexts.
+type CategoryPair struct {
+	A        Category `json:"a"`
+	B        Category `json:"b"`
+	Contexts int      `json:"contexts"` // Contexts with entries of both
+}
+
+// TagCategory is a tag and a category it is used with. Tags are the
+// entries' Sources.
+type TagCategory struct {
+	Tag      string   `json:"tag"`
+	Category Category `json:"category"`
+	Entries  int      `json:"entries"`
+}
+
+// TagPair is two tags carried by the same entries.
+type TagPair struct {
+	A       string `json:"a"`
+	B       string `json:"b"`
+	Entries int    `json:"entries"`
+}
+
+// CooccurrenceReport shows how the store's taxonomy is used: the contexts
+// generating the most lore, the categories recorded under the same
+// contexts, and the tags used with each category and with each other.
+// Each list is ordered by count, highest first.
+type CooccurrenceReport struct {
+	Entries       int               `json:"entries"` // Live entries analyzed
+	Contexts      []ContextActivity `json:"contexts"`
+	CategoryPairs []CategoryPair    `json:"category_pairs"`
+	TagCategories []TagCategory     `json:"tag_categories"`
+	TagPairs      []TagPair         `json:"tag_pairs"`
+}
+
+// loreTaxonomy is the part of a lore entry a co-occurrence report needs.
+type loreTaxonomy struct {
+	category Category
+	context  string
+	tags     []string
+}
+
+// taxonomy returns the category, context and sources of every live entry
+// in the namespace.
+func (s *Store) taxonomy() ([]loreTaxonomy, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil, ErrStoreClosed
+	}
+
+	rows, err := s.db.Query(`
+		SELECT category, COALESCE(context, ''), sources
+		FROM lore_entries WHERE deleted_at IS NULL AND namespace = ?
+	`, s.namespace)
+	if err != nil {
+		return nil, fmt.Errorf("store: taxonomy: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var entries []loreTaxonomy
+	for rows.Next() {
+		var e loreTaxonomy
+		var sources string
+		if err := rows.Scan(&e.category, &e.context, &sources); err != nil {
+			return nil, fmt.Errorf("store: scan taxonomy: %w", err)
+		}
+		if sources != "" && sources != "[]" {
+			e.tags = strings.Split(sources, ",")
+		}
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
+
+// Cooccurrence reports which categories are recorded under the same
+// contexts, which tags are used with which categories and with each
+// other, and which contexts generate the most lore, to help teams refine
+// their taxonomy and capture habits. Tags are the entries' Sources.
+// Contexts are compared after trimming surrounding space, and entries
+// without one are left out of the context lists. Each list holds at most
+// limit rows; zero or less selects DefaultCooccurrenceLimit.
+func (c *Client) Cooccurrence(ctx context.Context, limit int) (*CooccurrenceReport, error) {
+	if limit <= 0 {
+		limit = DefaultCooccurrenceLimit
+	}
+	release, err := c.store.enter(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	entries, err := c.store.taxonomy()
+	if err != nil {
+		return nil, fmt.Errorf("client: cooccurrence: %w", err)
+	}
+	report := analyzeTaxonomy(entries)
+	report.Contexts = report.Contexts[:min(limit, len(report.Contexts))]
+	report.CategoryPairs = report.CategoryPairs[:min(limit, len(report.CategoryPairs))]
+	report.TagCategories = report.TagCategories[:min(limit, len(report.TagCategories))]
+	report.TagPairs = report.TagPairs[:min(limit, len(report.TagPairs))]
+	return report, nil
+}
+
+// analyzeTaxonomy builds the full, sorted co-occurrence report of entries.
+func analyzeTaxonomy(entries []loreTaxonomy) *CooccurrenceReport {
+	type tagCategory struct {
+		tag      string
+		category Category
+	}
+	contexts := make(map[string]map[Category]int)
+	tagCategories := make(map[tagCategory]int)
+	tagPairs := make(map[[2]string]int)
+	for _, e := range entries {
+		if context := strings.TrimSpace(e.context); context != "" {
+			if contexts[context] == nil {
+				contexts[context] = make(map[Category]int)
+			}
+			contexts[context][e.category]++
+		}
+		tags := slices.Compact(slices.Sorted(slices.Values(e.tags)))
+		for i, tag := range tags {
+			tagCategories[tagCategory{tag, e.category}]++
+			for _, other := range tags[i+1:] {
+				tagPairs[[2]string{tag, other}]++
+			}
+		}
+	}
+
+	report := &CooccurrenceReport{
+		Entries:       len(entries),
+		Contexts:      []ContextActivity{},
+		CategoryPairs: []CategoryPair{},
+		TagCategories: []TagCategory{},
+		TagPairs:      []TagPair{},
+	}
+	categoryPairs := make(map[[2]Category]int)
+	for context, categories := range contexts {
+		activity := ContextActivity{Context: context}
+		for category, n := range categories {
+			activity.Entries += n
+			activity.Categories = append(activity.Categories, category)
+		}
+		slices.SortFunc(activity.Categories, func(a, b Category) int {
+			return cmp.Or(cmp.Compare(categories[b], categories[a]), cmp.Compare(a, b))
+		})
+		report.Contexts = append(report.Contexts, activity)
+
+		sorted := slices.Sorted(slices.Values(activity.Categories))
+		for i, a := range sorted {
+			for _, b := range sorted[i+1:] {
+				categoryPairs[[2]Category{a, b}]++
+			}
+		}
+	}
+	for pair, n := range categoryPairs {
+		report.CategoryPairs = append(report.CategoryPairs, CategoryPair{A: pair[0], B: pair[1], Contexts: n})
+	}
+	for tc, n := range tagCategories {
+		report.TagCategories = append(report.TagCategories, TagCategory{Tag: tc.tag, Category: tc.category, Entries: n})
+	}
+	for pair, n := range tagPairs {
+		report.TagPairs = append(report.TagPairs, TagPair{A: pair[0], B: pair[1], Entries: n})
+	}
+
+	slices.SortFunc(report.Contexts, func(a, b ContextActivity) int {
+		return cmp.Or(cmp.Compare(b.Entries, a.Entries), cmp.Compare(a.Context, b.Context))
+	})
+	slices.SortFunc(report.CategoryPairs, func(a, b CategoryPair) int {
+		return cmp.Or(cmp.Compare(b.Contexts, a.Contexts), cmp.Compare(a.A, b.A), cmp.Compare(a.B, b.B))
+	})
+	slices.SortFunc(report.TagCategories, func(a, b TagCategory) int {
+		return cmp.Or(cmp.Compare(b.Entries, a.Entries), cmp.Compare(a.Tag, b.Tag), cmp.Compare(a.Category, b.Category))
+	})
+	slices.SortFunc(report.TagPairs, func(a, b TagPair) int {
+		return cmp.Or(cmp.Compare(b.Entries, a.Entries), cmp.Compare(a.A, b.A), cmp.Compare(a.B, b.B))
+	})
+	return report
+}
@@ -0,0 +1,74 @@
+package recall
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestClient_Cooccurrence(t *testing.T) {
+	client := newTestClient(t, Config{AutoSync: false})
+
+	records := []struct {
+		content  string
+		category Category
+		context  string
+		tags     []string
+	}{
+		{"Cache keys need the tenant ID.", CategoryPerformanceInsight, "checkout service", []string{"runbook", "oncall"}},
+		{"Checkout retries must be idempotent.", CategoryPatternOutcome, "checkout service", []string{"runbook", "oncall"}},
+		{"Payment webhooks arrive out of order.", CategoryEdgeCaseDiscovery, " checkout service ", []string{"runbook"}},
+		{"Search reindex takes an hour.", CategoryPerformanceInsight, "search", nil},
+		{"Search results page from zero.", CategoryEdgeCaseDiscovery, "search", nil},
+		{"Use UTC everywhere.", CategoryPatternOutcome, "", []string{"oncall"}},
+	}
+	for _, r := range records {
+		opts := []RecordOption{WithContext(r.context)}
+		if r.tags != nil {
+			opts = append(opts, WithSources(r.tags...))
+		}
+		if _, err := client.Record(r.content, r.category, opts...); err != nil {
+			t.Fatalf("Record: %v", err)
+		}
+	}
+
+	report, err := client.Cooccurrence(context.Background(), 0)
+	if err != nil {
+		t.Fatalf("Cooccurrence: %v", err)
+	}
+	if report.Entries != 6 {
+		t.Errorf("Entries = %d, want 6", report.Entries)
+	}
+
+	wantContexts := []ContextActivity{
+		{Context: "checkout service", Entries: 3, Categories: []Category{CategoryEdgeCaseDiscovery, CategoryPatternOutcome, CategoryPerformanceInsight}},
+		{Context: "search", Entries: 2, Categories: []Category{CategoryEdgeCaseDiscovery, CategoryPerformanceInsight}},
+	}
+	if !reflect.DeepEqual(report.Contexts, wantContexts) {
+		t.Errorf("Contexts = %+v, want %+v", report.Contexts, wantContexts)
+	}
+	if len(report.CategoryPairs) != 3 || report.CategoryPairs[0] != (CategoryPair{A: CategoryEdgeCaseDiscovery, B: CategoryPerformanceInsight, Contexts: 2}) {
+		t.Errorf("CategoryPairs = %+v, want EDGE_CASE_DISCOVERY + PERFORMANCE_INSIGHT first with 2 of 3", report.CategoryPairs)
+	}
+	wantTags := []TagCategory{
+		{Tag: "oncall", Category: CategoryPatternOutcome, Entries: 2},
+		{Tag: "oncall", Category: CategoryPerformanceInsight, Entries: 1},
+		{Tag: "runbook", Category: CategoryEdgeCaseDiscovery, Entries: 1},
+		{Tag: "runbook", Category: CategoryPatternOutcome, Entries: 1},
+		{Tag: "runbook", Category: CategoryPerformanceInsight, Entries: 1},
+	}
+	if !reflect.DeepEqual(report.TagCategories, wantTags) {
+		t.Errorf("TagCategories = %+v, want %+v", report.TagCategories, wantTags)
+	}
+	if want := []TagPair{{A: "oncall", B: "runbook", Entries: 2}}; !reflect.DeepEqual(report.TagPairs, want) {
+		t.Errorf("TagPairs = %+v, want %+v", report.TagPairs, want)
+	}
+
+	limited, err := client.Cooccurrence(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("Cooccurrence: %v", err)
+	}
+	if len(limited.Contexts) != 1 || len(limited.CategoryPairs) != 1 || len(limited.TagCategories) != 1 {
+		t.Errorf("limit 1 = %+v, want one row per list", limited)
+	}
+}
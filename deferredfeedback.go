@@ -0,0 +1,183 @@
+package recall
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// deferredFeedback is feedback waiting for its lore to be pushed.
+type deferredFeedback struct {
+	id      int64
+	loreID  string
+	outcome FeedbackType
+}
+
+// deferFeedback applies feedback on lore Engram has not seen yet. Like
+// tentative feedback it writes no change_log entry, so the pushed entry
+// carries the confidence it was recorded with; the feedback itself is
+// kept for replayDeferredFeedback to send once the lore is pushed.
+func (s *Store) deferFeedback(loreID string, ft FeedbackType) (*Lore, error) {
+	return s.applyQueuedFeedback(loreID, ft, func(tx *sql.Tx) error {
+		if _, err := tx.Exec(`
+			INSERT INTO deferred_feedback (lore_id, outcome, queued_at) VALUES (?, ?, ?)
+		`, loreID, string(ft), s.now().Format(time.RFC3339)); err != nil {
+			return fmt.Errorf("store: defer feedback: %w", err)
+		}
+		return nil
+	})
+}
+
+// readyDeferredFeedback drops deferred feedback whose lore was deleted and
+// returns, oldest first, the feedback whose lore has since been pushed.
+func (s *Store) readyDeferredFeedback() ([]deferredFeedback, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil, ErrStoreClosed
+	}
+
+	if _, err := s.db.Exec(`
+		DELETE FROM deferred_feedback
+		WHERE lore_id NOT IN (SELECT id FROM lore_entries WHERE deleted_at IS NULL)
+	`); err != nil {
+		return nil, fmt.Errorf("store: prune deferred feedback: %w", err)
+	}
+
+	rows, err := s.db.Query(`
+		SELECT f.id, f.lore_id, f.outcome FROM deferred_feedback f
+		JOIN lore_entries l ON l.id = f.lore_id
+		WHERE l.synced_at IS NOT NULL
+		ORDER BY f.id ASC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("store: query deferred feedback: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var ready []deferredFeedback
+	for rows.Next() {
+		var f deferredFeedback
+		if err := rows.Scan(&f.id, &f.loreID, &f.outcome); err != nil {
+			return nil, fmt.Errorf("store: scan deferred feedback: %w", err)
+		}
+		ready = append(ready, f)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("store: iterate deferred feedback: %w", err)
+	}
+	return ready, nil
+}
+
+// finishDeferredFeedback removes replayed feedback, or, with a non-empty
+// lastError, counts a failed attempt and keeps it for the next sync.
+func (s *Store) finishDeferredFeedback(ids []int64, lastError string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return ErrStoreClosed
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, len(ids))
+	args := make([]any, 0, len(ids)+1)
+	if lastError != "" {
+		args = append(args, lastError)
+	}
+	for i, id := range ids {
+		placeholders[i] = "?"
+		args = append(args, id)
+	}
+	query := fmt.Sprintf(`DELETE FROM deferred_feedback WHERE id IN (%s)`, strings.Join(placeholders, ","))
+	if lastError != "" {
+		query = fmt.Sprintf(`UPDATE deferred_feedback SET attempts = attempts + 1, last_error = ? WHERE id IN (%s)`, strings.Join(placeholders, ","))
+	}
+	if _, err := s.db.Exec(query, args...); err != nil {
+		return fmt.Errorf("store: finish deferred feedback: %w", err)
+	}
+	return nil
+}
+
+// DeferredFeedbackCount returns how much feedback is waiting for its lore
+// to be pushed before it is sent to Engram.
+func (s *Store) DeferredFeedbackCount() (int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return 0, ErrStoreClosed
+	}
+
+	var count int
+	if err := s.db.QueryRow(`SELECT COUNT(*) FROM deferred_feedback`).Scan(&count); err != nil {
+		return 0, fmt.Errorf("store: count deferred feedback: %w", err)
+	}
+	return count, nil
+}
+
+// replayDeferredFeedback sends deferred feedback whose lore has been
+// pushed since, and applies the server's resulting values, so central
+// validation counts catch up with local ones. Feedback Engram refuses is
+// dropped and listed in result.Rejected; when Engram is unreachable the
+// rest waits for the next sync.
+func (c *Client) replayDeferredFeedback(ctx context.Context, result *ReconcileResult) error {
+	ready, err := c.store.readyDeferredFeedback()
+	if err != nil {
+		return err
+	}
+
+	for start := 0; start < len(ready); start += maxFeedbackBatch {
+		batch := ready[start:min(start+maxFeedbackBatch, len(ready))]
+		entries := make([]feedbackRequestEntry, len(batch))
+		ids := make([]int64, len(batch))
+		for i, f := range batch {
+			entries[i] = feedbackRequestEntry{LoreID: f.loreID, Type: f.outcome}
+			ids[i] = f.id
+		}
+
+		updates, err := c.syncer.SubmitFeedback(ctx, entries)
+		if isFeedbackRejection(err) {
+			for _, f := range batch {
+				result.Rejected = append(result.Rejected, f.loreID)
+			}
+			if err := c.store.finishDeferredFeedback(ids, ""); err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			_ = c.store.finishDeferredFeedback(ids, err.Error())
+			return err
+		}
+
+		for _, u := range updates {
+			if _, err := c.store.applyConfirmedFeedback(u); err != nil && !errors.Is(err, ErrNotFound) {
+				return err
+			}
+			result.Confirmed = append(result.Confirmed, u)
+		}
+		if err := c.store.finishDeferredFeedback(ids, ""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// replayAfterPush replays deferred feedback once a push has stamped
+// synced_at. It is best-effort: failures are logged and retried on the
+// next sync.
+func (c *Client) replayAfterPush(ctx context.Context) {
+	if !c.config.ConfirmFeedback {
+		return
+	}
+	if err := c.replayDeferredFeedback(ctx, &ReconcileResult{}); err != nil {
+		c.debug.LogError("deferred feedback", err)
+	}
+}
@@ -0,0 +1,87 @@
+package recall
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestConfirmFeedback_DeferredUntilPushed(t *testing.T) {
+	server := newFeedbackServer(t)
+	client := newConfirmClient(t, server.URL, false)
+	before := getChangeLogCount(t, client.store)
+
+	lore, err := client.Feedback("01CONFIRM0000000000000000", Helpful)
+	if err != nil {
+		t.Fatalf("Feedback() error = %v", err)
+	}
+	if lore.Confidence != 0.5+ConfidenceHelpfulDelta || lore.ValidationCount != 1 || server.requests.Load() != 0 {
+		t.Errorf("lore = %.2f/%d after %d requests, want the local delta and nothing sent",
+			lore.Confidence, lore.ValidationCount, server.requests.Load())
+	}
+	if after := getChangeLogCount(t, client.store); after != before {
+		t.Errorf("change_log grew from %d to %d; deferred feedback reaches Engram by replay only", before, after)
+	}
+	if status, err := client.SyncStatus(); err != nil || status.DeferredFeedback != 1 {
+		t.Fatalf("SyncStatus() = %+v, %v; want 1 deferred feedback", status, err)
+	}
+
+	// Engram is unreachable for feedback when the push lands: the
+	// feedback waits for the next sync.
+	server.status.Store(http.StatusServiceUnavailable)
+	if _, err := client.SyncPush(context.Background()); err != nil {
+		t.Fatalf("SyncPush() error = %v", err)
+	}
+	if status, _ := client.SyncStatus(); status.DeferredFeedback != 1 {
+		t.Errorf("deferred feedback = %d after a failed replay, want it kept", status.DeferredFeedback)
+	}
+
+	server.status.Store(http.StatusOK)
+	result, err := client.ReconcileFeedback(context.Background())
+	if err != nil {
+		t.Fatalf("ReconcileFeedback() error = %v", err)
+	}
+	if len(result.Confirmed) != 1 || len(server.last.Feedback) != 1 || server.last.Feedback[0].Type != FeedbackHelpful {
+		t.Errorf("Confirmed = %v, request = %+v; want the helpful feedback replayed", result.Confirmed, server.last)
+	}
+	lore, _ = client.store.Get("01CONFIRM0000000000000000")
+	if lore.Confidence != 0.9 || lore.ValidationCount != 5 {
+		t.Errorf("replayed lore = %.2f/%d, want the server's 0.90/5", lore.Confidence, lore.ValidationCount)
+	}
+	if status, _ := client.SyncStatus(); status.DeferredFeedback != 0 {
+		t.Errorf("deferred feedback = %d after replay, want 0", status.DeferredFeedback)
+	}
+}
+
+func TestConfirmFeedback_DeferredReplayedAfterPush(t *testing.T) {
+	server := newFeedbackServer(t)
+	client := newConfirmClient(t, server.URL, false)
+
+	if _, err := client.Feedback("01CONFIRM0000000000000000", Incorrect); err != nil {
+		t.Fatalf("Feedback() error = %v", err)
+	}
+	if _, err := client.SyncPush(context.Background()); err != nil {
+		t.Fatalf("SyncPush() error = %v", err)
+	}
+	if server.requests.Load() != 1 || server.last.Feedback[0].Type != FeedbackIncorrect {
+		t.Errorf("%d feedback requests, last %+v; want the deferred feedback sent after the push", server.requests.Load(), server.last)
+	}
+	if status, _ := client.SyncStatus(); status.DeferredFeedback != 0 {
+		t.Errorf("deferred feedback = %d, want 0", status.DeferredFeedback)
+	}
+
+	// Deferred feedback on lore deleted since is dropped unsent.
+	if _, err := client.store.deferFeedback("01CONFIRM0000000000000000", Helpful); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.store.DeleteLoreByID("01CONFIRM0000000000000000"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.ReconcileFeedback(context.Background()); err != nil {
+		t.Fatalf("ReconcileFeedback() error = %v", err)
+	}
+	if status, _ := client.SyncStatus(); status.DeferredFeedback != 0 || server.requests.Load() != 1 {
+		t.Errorf("deferred feedback = %d after %d requests, want deleted lore's feedback dropped unsent",
+			status.DeferredFeedback, server.requests.Load())
+	}
+}
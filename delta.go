@@ -0,0 +1,110 @@
+package recall
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// deltaCursorKey stores the server cursor of an interrupted delta in sync_meta.
+const deltaCursorKey = "delta_cursor"
+
+// deltaOp is one remote change prepared for atomic page application.
+// Exactly one of upsert, deleteID, conflict, attach or detach is set.
+type deltaOp struct {
+	upsert    *Lore
+	deleteID  string
+	deletedAt string
+	conflict  *Lore       // local entry conflicting with remotePayload
+	attach    *Attachment // synced attachment link, with Data
+	detach    *Attachment // synced attachment link to remove (LoreID, ID)
+	payload   []byte
+	sequence  int64
+	overwrite bool // upsert or delete replaces unpushed local edits
+}
+
+// SetDeltaPageSize sets the number of entries requested per delta page.
+// Values <= 0 restore the default of 500.
+func (s *Syncer) SetDeltaPageSize(n int) {
+	s.deltaPageSize = n
+}
+
+// pageSize returns the configured delta page size.
+func (s *Syncer) pageSize() int {
+	if s.deltaPageSize > 0 {
+		return s.deltaPageSize
+	}
+	return syncDeltaPageLimit
+}
+
+// applyDeltaPage applies one page of remote changes and advances
+// last_pull_seq in a single transaction, so an interrupted delta never leaves
+// a partially applied page behind. cursor is persisted for resumption and
+// cleared once the final page is applied.
+func (s *Store) applyDeltaPage(ops []deltaOp, lastSeq int64, cursor string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return ErrStoreClosed
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("store: begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	for _, op := range ops {
+		if op.overwrite {
+			if err := incrementMetricTx(tx, metricLocalOverwrites, 1); err != nil {
+				return err
+			}
+		}
+		switch {
+		case op.conflict != nil:
+			if _, err := s.recordConflictTx(tx, op.conflict, op.payload, op.sequence); err != nil {
+				return err
+			}
+			if err := incrementMetricTx(tx, metricConflictsDetected, 1); err != nil {
+				return err
+			}
+		case op.upsert != nil:
+			if err := s.upsertLoreTx(tx, op.upsert); err != nil {
+				return err
+			}
+		case op.attach != nil:
+			if err := upsertAttachmentTx(tx, op.attach, op.attach.Data); err != nil {
+				return err
+			}
+		case op.detach != nil:
+			if _, _, err := removeAttachmentTx(tx, op.detach.LoreID, op.detach.ID); err != nil {
+				return err
+			}
+		case op.deleteID != "":
+			if _, err := tx.Exec(`
+				UPDATE lore_entries SET deleted_at = ?, updated_at = ?
+				WHERE id = ?
+			`, op.deletedAt, op.deletedAt, op.deleteID); err != nil {
+				return fmt.Errorf("store: soft delete lore at: %w", err)
+			}
+			if err := s.holdLoreTx(tx, HoldOriginRemote, "id = ? AND deleted_at = ?", op.deleteID, op.deletedAt); err != nil {
+				return err
+			}
+		}
+	}
+
+	if _, err := tx.Exec("INSERT OR REPLACE INTO sync_meta (key, value) VALUES ('last_pull_seq', ?)",
+		strconv.FormatInt(lastSeq, 10)); err != nil {
+		return fmt.Errorf("store: update last_pull_seq: %w", err)
+	}
+	if cursor == "" {
+		_, err = tx.Exec("DELETE FROM sync_meta WHERE key = ?", deltaCursorKey)
+	} else {
+		_, err = tx.Exec("INSERT OR REPLACE INTO sync_meta (key, value) VALUES (?, ?)", deltaCursorKey, cursor)
+	}
+	if err != nil {
+		return fmt.Errorf("store: update delta cursor: %w", err)
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,149 @@
+package recall
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func deltaUpsertEntry(seq int64, id, now string) DeltaEntry {
+	return DeltaEntry{
+		Sequence:   seq,
+		TableName:  "lore_entries",
+		EntityID:   id,
+		Operation:  "upsert",
+		Payload:    makeDeltaPayload(id, "Content "+id, "TESTING_STRATEGY", "remote", now, now),
+		SourceID:   "remote",
+		CreatedAt:  now,
+		ReceivedAt: now,
+	}
+}
+
+func TestSyncDelta_ResumesInterruptedDelta(t *testing.T) {
+	store := newTestStore(t)
+	now := time.Now().UTC().Format(time.RFC3339)
+
+	failSecondPage := true
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.RequestURI())
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.URL.Query().Get("cursor") == "" {
+			json.NewEncoder(w).Encode(SyncDeltaResponse{
+				Entries:      []DeltaEntry{deltaUpsertEntry(1, "lore-1", now)},
+				LastSequence: 1,
+				HasMore:      true,
+				NextCursor:   "page-2",
+			})
+			return
+		}
+		if failSecondPage {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		json.NewEncoder(w).Encode(SyncDeltaResponse{
+			Entries:      []DeltaEntry{deltaUpsertEntry(2, "lore-2", now)},
+			LastSequence: 2,
+		})
+	}))
+	defer server.Close()
+
+	syncer := newTestSyncer(t, store, server.URL)
+
+	if _, err := syncer.SyncDelta(context.Background()); err == nil {
+		t.Fatal("expected error when second page fails")
+	}
+
+	// The first page was committed along with its progress.
+	if _, err := store.Get("lore-1"); err != nil {
+		t.Errorf("lore-1 not applied: %v", err)
+	}
+	if seq, _ := store.GetSyncMeta("last_pull_seq"); seq != "1" {
+		t.Errorf("last_pull_seq = %q, want 1", seq)
+	}
+	if cursor, _ := store.GetSyncMeta(deltaCursorKey); cursor != "page-2" {
+		t.Errorf("delta_cursor = %q, want page-2", cursor)
+	}
+
+	failSecondPage = false
+	requests = nil
+	result, err := syncer.SyncDelta(context.Background())
+	if err != nil {
+		t.Fatalf("SyncDelta resume: %v", err)
+	}
+	if !result.Resumed || result.PagesApplied != 1 || result.LastSequence != 2 {
+		t.Errorf("result = %+v, want resumed single page to sequence 2", result)
+	}
+	if len(requests) != 1 || !strings.Contains(requests[0], "after=1") || !strings.Contains(requests[0], "cursor=page-2") {
+		t.Errorf("resume requests = %v, want one request after=1 with cursor", requests)
+	}
+	if _, err := store.Get("lore-2"); err != nil {
+		t.Errorf("lore-2 not applied: %v", err)
+	}
+	if cursor, _ := store.GetSyncMeta(deltaCursorKey); cursor != "" {
+		t.Errorf("delta_cursor = %q, want cleared", cursor)
+	}
+}
+
+func TestSyncDelta_PageAppliedAtomically(t *testing.T) {
+	store := newTestStore(t)
+	now := time.Now().UTC().Format(time.RFC3339)
+
+	bad := deltaUpsertEntry(2, "lore-bad", now)
+	bad.Payload = json.RawMessage(`{"id":"lore-bad","created_at":"not-a-time"}`)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(SyncDeltaResponse{
+			Entries:      []DeltaEntry{deltaUpsertEntry(1, "lore-good", now), bad},
+			LastSequence: 2,
+		})
+	}))
+	defer server.Close()
+
+	syncer := newTestSyncer(t, store, server.URL)
+	if _, err := syncer.SyncDelta(context.Background()); err == nil {
+		t.Fatal("expected error for invalid payload")
+	}
+
+	if _, err := store.Get("lore-good"); err != ErrNotFound {
+		t.Errorf("lore-good should not be applied from a failed page, got err = %v", err)
+	}
+	if seq, _ := store.GetSyncMeta("last_pull_seq"); seq != "0" {
+		t.Errorf("last_pull_seq = %q, want 0", seq)
+	}
+}
+
+func TestSyncDelta_PageSize(t *testing.T) {
+	store := newTestStore(t)
+
+	var limit string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limit = r.URL.Query().Get("limit")
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(SyncDeltaResponse{})
+	}))
+	defer server.Close()
+
+	syncer := newTestSyncer(t, store, server.URL)
+	syncer.SetDeltaPageSize(50)
+	if _, err := syncer.SyncDelta(context.Background()); err != nil {
+		t.Fatalf("SyncDelta: %v", err)
+	}
+	if limit != "50" {
+		t.Errorf("limit = %q, want 50", limit)
+	}
+
+	syncer.SetDeltaPageSize(0)
+	if _, err := syncer.SyncDelta(context.Background()); err != nil {
+		t.Fatalf("SyncDelta: %v", err)
+	}
+	if limit != "500" {
+		t.Errorf("limit = %q, want default 500", limit)
+	}
+}
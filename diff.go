@@ -0,0 +1,102 @@
+package recall
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// DiffResult describes divergence between the local store and Engram.
+type DiffResult struct {
+	// LocalOnly contains active entries that exist locally but not on Engram.
+	LocalOnly []Lore `json:"local_only"`
+	// RemoteOnly contains entries that exist on Engram but not locally.
+	RemoteOnly []Lore `json:"remote_only"`
+	// Differing contains entries present on both sides with differing fields.
+	Differing []LoreDiff `json:"differing"`
+	// Identical is the number of entries that match on both sides.
+	Identical int `json:"identical"`
+	// RemoteSequence is the Engram sequence the comparison was taken at.
+	RemoteSequence int64 `json:"remote_sequence"`
+}
+
+// LoreDiff is a lore entry whose local and remote versions differ.
+type LoreDiff struct {
+	ID     string      `json:"id"`
+	Local  Lore        `json:"local"`
+	Remote Lore        `json:"remote"`
+	Fields []FieldDiff `json:"fields"`
+}
+
+// InSync reports whether no divergence was found.
+func (r *DiffResult) InSync() bool {
+	return len(r.LocalOnly) == 0 && len(r.RemoteOnly) == 0 && len(r.Differing) == 0
+}
+
+// Diff compares local lore with the state on Engram.
+//
+// Remote state is reconstructed by replaying the full delta feed from
+// sequence 0; the last upsert or delete per entity wins. Local state is the
+// set of active (non-deleted) entries. Neither side is modified.
+func (s *Syncer) Diff(ctx context.Context) (*DiffResult, error) {
+	if s.engramURL == "" {
+		return nil, ErrOffline
+	}
+
+	remote := make(map[string]*Lore)
+	result := &DiffResult{}
+
+	after, cursor := int64(0), ""
+	for {
+		page, err := s.fetchDeltaPage(ctx, after, cursor)
+		if err != nil {
+			return nil, fmt.Errorf("diff: %w", err)
+		}
+		for _, entry := range page.Entries {
+			switch entry.Operation {
+			case "upsert":
+				lore, err := parseLorePayload(entry.Payload)
+				if err != nil {
+					return nil, fmt.Errorf("diff: parse %s: %w", entry.EntityID, err)
+				}
+				remote[entry.EntityID] = lore
+			case "delete":
+				delete(remote, entry.EntityID)
+			}
+		}
+		after, cursor = page.LastSequence, page.NextCursor
+		if !page.HasMore {
+			break
+		}
+	}
+	result.RemoteSequence = after
+
+	local, err := s.store.Query(QueryParams{})
+	if err != nil {
+		return nil, fmt.Errorf("diff: read local: %w", err)
+	}
+
+	for i := range local {
+		l := &local[i]
+		r, ok := remote[l.ID]
+		if !ok {
+			result.LocalOnly = append(result.LocalOnly, *l)
+			continue
+		}
+		delete(remote, l.ID)
+		if fields := loreFieldDiffs(l, r); len(fields) > 0 {
+			result.Differing = append(result.Differing, LoreDiff{ID: l.ID, Local: *l, Remote: *r, Fields: fields})
+		} else {
+			result.Identical++
+		}
+	}
+	for _, r := range remote {
+		result.RemoteOnly = append(result.RemoteOnly, *r)
+	}
+
+	sort.Slice(result.LocalOnly, func(i, j int) bool { return result.LocalOnly[i].ID < result.LocalOnly[j].ID })
+	sort.Slice(result.RemoteOnly, func(i, j int) bool { return result.RemoteOnly[i].ID < result.RemoteOnly[j].ID })
+	sort.Slice(result.Differing, func(i, j int) bool { return result.Differing[i].ID < result.Differing[j].ID })
+
+	return result, nil
+}
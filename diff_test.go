@@ -0,0 +1,110 @@
+package recall
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestSyncer_Diff_ClassifiesEntries(t *testing.T) {
+	store := newTestStore(t)
+	now := time.Now().UTC()
+	ts := now.Format(time.RFC3339)
+
+	for _, l := range []*Lore{
+		{ID: "same", Content: "same content", Category: CategoryPatternOutcome, Confidence: 0.8},
+		{ID: "changed", Content: "local edit", Category: CategoryPatternOutcome, Confidence: 0.8},
+		{ID: "local-only", Content: "never pushed", Category: CategoryPatternOutcome, Confidence: 0.8},
+	} {
+		l.CreatedAt, l.UpdatedAt = now, now
+		if err := store.InsertLore(l); err != nil {
+			t.Fatalf("InsertLore: %v", err)
+		}
+	}
+
+	pages := [][]DeltaEntry{
+		{
+			{Sequence: 1, Operation: "upsert", EntityID: "same", Payload: makeDeltaPayload("same", "same content", "PATTERN_OUTCOME", "r", ts, ts)},
+			{Sequence: 2, Operation: "upsert", EntityID: "changed", Payload: makeDeltaPayload("changed", "old", "PATTERN_OUTCOME", "r", ts, ts)},
+		},
+		{
+			{Sequence: 3, Operation: "upsert", EntityID: "changed", Payload: makeDeltaPayload("changed", "remote edit", "PATTERN_OUTCOME", "r", ts, ts)},
+			{Sequence: 4, Operation: "upsert", EntityID: "remote-only", Payload: makeDeltaPayload("remote-only", "from elsewhere", "PATTERN_OUTCOME", "r", ts, ts)},
+			{Sequence: 5, Operation: "upsert", EntityID: "gone", Payload: makeDeltaPayload("gone", "deleted later", "PATTERN_OUTCOME", "r", ts, ts)},
+			{Sequence: 6, Operation: "delete", EntityID: "gone"},
+		},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		after, _ := strconv.Atoi(r.URL.Query().Get("after"))
+		page := 0
+		if after > 0 {
+			page = 1
+		}
+		_ = json.NewEncoder(w).Encode(SyncDeltaResponse{
+			Entries:      pages[page],
+			LastSequence: pages[page][len(pages[page])-1].Sequence,
+			HasMore:      page == 0,
+		})
+	}))
+	defer server.Close()
+
+	syncer := newTestSyncer(t, store, server.URL)
+	result, err := syncer.Diff(context.Background())
+	if err != nil {
+		t.Fatalf("Diff: %v", err)
+	}
+
+	if result.RemoteSequence != 6 {
+		t.Errorf("RemoteSequence = %d, want 6", result.RemoteSequence)
+	}
+	if result.Identical != 1 {
+		t.Errorf("Identical = %d, want 1", result.Identical)
+	}
+	if len(result.LocalOnly) != 1 || result.LocalOnly[0].ID != "local-only" {
+		t.Errorf("LocalOnly = %+v", result.LocalOnly)
+	}
+	if len(result.RemoteOnly) != 1 || result.RemoteOnly[0].ID != "remote-only" {
+		t.Errorf("RemoteOnly = %+v", result.RemoteOnly)
+	}
+	if len(result.Differing) != 1 || result.Differing[0].ID != "changed" {
+		t.Fatalf("Differing = %+v", result.Differing)
+	}
+	if f := result.Differing[0].Fields; len(f) != 1 || f[0].Field != "content" || f[0].Remote != "remote edit" {
+		t.Errorf("Fields = %+v, want content diff", f)
+	}
+	if result.InSync() {
+		t.Error("InSync = true, want false")
+	}
+
+	// Diff must not modify local state.
+	if lore, _ := store.Get("changed"); lore.Content != "local edit" {
+		t.Errorf("local content modified: %q", lore.Content)
+	}
+	if seq, _ := store.GetSyncMeta("last_pull_seq"); seq != "" && seq != "0" {
+		t.Errorf("last_pull_seq = %q, Diff must not advance it", seq)
+	}
+}
+
+func TestSyncer_Diff_HTTPError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	syncer := newTestSyncer(t, newTestStore(t), server.URL)
+	if _, err := syncer.Diff(context.Background()); err == nil {
+		t.Fatal("expected error for HTTP 500")
+	}
+}
+
+func TestClient_Diff_Offline(t *testing.T) {
+	client := &Client{store: newTestStore(t)}
+	if _, err := client.Diff(context.Background()); !errors.Is(err, ErrOffline) {
+		t.Errorf("err = %v, want ErrOffline", err)
+	}
+}
@@ -0,0 +1,117 @@
+// Package embedder provides recall.EmbeddingProvider adapters for local
+// embedding models, so a client can run semantic queries without Engram.
+//
+// OpenAI speaks the OpenAI embeddings API, which most local model servers
+// also serve (Ollama, llama.cpp, vLLM, LM Studio):
+//
+//	cfg.EmbeddingModel = "nomic-embed-text"
+//	cfg.EmbeddingProvider = &embedder.OpenAI{
+//		BaseURL: "http://localhost:11434/v1",
+//		Model:   cfg.EmbeddingModel,
+//	}
+package embedder
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hyperengineering/recall"
+)
+
+// DefaultTimeout bounds an embeddings request when HTTPClient is nil.
+const DefaultTimeout = 60 * time.Second
+
+// OpenAI embeds texts with an OpenAI-compatible POST {BaseURL}/embeddings
+// endpoint.
+type OpenAI struct {
+	BaseURL    string       // e.g. https://api.openai.com/v1 or http://localhost:11434/v1
+	APIKey     string       // Sent as a bearer token; empty sends none
+	Model      string       // Model name, e.g. text-embedding-3-small
+	Dimensions int          // Requested vector size, for models that support it (0 = model default)
+	HTTPClient *http.Client // nil uses a client with DefaultTimeout
+}
+
+var _ recall.EmbeddingProvider = (*OpenAI)(nil)
+
+type embeddingsRequest struct {
+	Model      string   `json:"model"`
+	Input      []string `json:"input"`
+	Dimensions int      `json:"dimensions,omitempty"`
+}
+
+type embeddingsResponse struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+}
+
+// Embed returns one embedding per text, in order.
+func (o *OpenAI) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	if o.BaseURL == "" || o.Model == "" {
+		return nil, fmt.Errorf("embedder: BaseURL and Model are required")
+	}
+	if len(texts) == 0 {
+		return nil, nil
+	}
+
+	body, err := json.Marshal(embeddingsRequest{Model: o.Model, Input: texts, Dimensions: o.Dimensions})
+	if err != nil {
+		return nil, fmt.Errorf("embedder: marshal request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimRight(o.BaseURL, "/")+"/embeddings", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("embedder: create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if o.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+o.APIKey)
+	}
+
+	client := o.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: DefaultTimeout}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("embedder: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("embedder: read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("embedder: HTTP %d: %s", resp.StatusCode, truncate(string(respBody), 200))
+	}
+
+	var parsed embeddingsResponse
+	if err := json.Unmarshal(respBody, &parsed); err != nil {
+		return nil, fmt.Errorf("embedder: decode response: %w", err)
+	}
+	if len(parsed.Data) != len(texts) {
+		return nil, fmt.Errorf("embedder: got %d embeddings for %d texts", len(parsed.Data), len(texts))
+	}
+	vectors := make([][]float32, len(texts))
+	for _, d := range parsed.Data {
+		if d.Index < 0 || d.Index >= len(texts) || vectors[d.Index] != nil {
+			return nil, fmt.Errorf("embedder: unexpected embedding index %d", d.Index)
+		}
+		vectors[d.Index] = d.Embedding
+	}
+	return vectors, nil
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "..."
+}
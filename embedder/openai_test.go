@@ -0,0 +1,66 @@
+package embedder
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestOpenAI_Embed(t *testing.T) {
+	var got embeddingsRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/embeddings" {
+			t.Errorf("path = %s, want /v1/embeddings", r.URL.Path)
+		}
+		if auth := r.Header.Get("Authorization"); auth != "Bearer key" {
+			t.Errorf("Authorization = %q", auth)
+		}
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		// Out of order, as the API allows
+		_, _ = w.Write([]byte(`{"data":[{"index":1,"embedding":[0,1]},{"index":0,"embedding":[1,0]}]}`))
+	}))
+	defer server.Close()
+
+	o := &OpenAI{BaseURL: server.URL + "/v1/", APIKey: "key", Model: "nomic-embed-text", Dimensions: 2}
+	vectors, err := o.Embed(context.Background(), []string{"first", "second"})
+	if err != nil {
+		t.Fatalf("Embed: %v", err)
+	}
+	if want := [][]float32{{1, 0}, {0, 1}}; !reflect.DeepEqual(vectors, want) {
+		t.Errorf("vectors = %v, want %v", vectors, want)
+	}
+	if want := (embeddingsRequest{Model: "nomic-embed-text", Input: []string{"first", "second"}, Dimensions: 2}); !reflect.DeepEqual(got, want) {
+		t.Errorf("request = %+v, want %+v", got, want)
+	}
+}
+
+func TestOpenAI_Embed_Errors(t *testing.T) {
+	for name, tc := range map[string]struct {
+		status int
+		body   string
+		want   string
+	}{
+		"http error":      {http.StatusUnauthorized, `{"error":"bad key"}`, "HTTP 401"},
+		"missing vectors": {http.StatusOK, `{"data":[{"index":0,"embedding":[1]}]}`, "got 1 embeddings for 2 texts"},
+		"bad index":       {http.StatusOK, `{"data":[{"index":0,"embedding":[1]},{"index":0,"embedding":[1]}]}`, "unexpected embedding index"},
+		"bad json":        {http.StatusOK, `{"data":`, "decode response"},
+	} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(tc.status)
+			_, _ = w.Write([]byte(tc.body))
+		}))
+		o := &OpenAI{BaseURL: server.URL, Model: "m"}
+		if _, err := o.Embed(context.Background(), []string{"a", "b"}); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: err = %v, want %q", name, err, tc.want)
+		}
+		server.Close()
+	}
+
+	if _, err := (&OpenAI{}).Embed(context.Background(), []string{"a"}); err == nil {
+		t.Error("Embed without BaseURL and Model succeeded")
+	}
+}
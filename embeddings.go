@@ -0,0 +1,235 @@
+package recall
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// metadataKeyEmbeddingModel is the metadata key naming the model of the
+// vectors in lore_entries.embedding, as reported by Engram at bootstrap.
+const metadataKeyEmbeddingModel = "embedding_model"
+
+// modelEmbeddingColumn selects an entry's vector for the model bound to
+// both placeholders: its lore_embeddings row, else lore_entries.embedding
+// when that model is the store's own.
+const modelEmbeddingColumn = `COALESCE(
+		(SELECT e.embedding FROM lore_embeddings e WHERE e.lore_id = lore_entries.id AND e.model = ?),
+		CASE WHEN ? = (SELECT value FROM metadata WHERE key = 'embedding_model') THEN embedding END
+	) AS embedding`
+
+// EmbeddingModelCount reports how many live entries have a vector for an
+// embedding model.
+type EmbeddingModelCount struct {
+	Model   string `json:"model"`
+	Entries int    `json:"entries"`
+	Primary bool   `json:"primary"` // Held in lore_entries.embedding
+}
+
+// EmbeddingPruneResult reports the outcome of PruneEmbeddings.
+type EmbeddingPruneResult struct {
+	// Removed counts lore_embeddings rows dropped: other models' vectors
+	// and vectors of deleted entries.
+	Removed int `json:"removed"`
+	// PrimaryCleared counts lore_entries vectors cleared because they
+	// belonged to another model.
+	PrimaryCleared int `json:"primary_cleared"`
+}
+
+// SetEmbedding stores loreID's vector for model. Vectors are local-only
+// and kept alongside lore_entries.embedding, so an entry can carry
+// vectors from an old and a new model while queries move between them.
+func (s *Store) SetEmbedding(loreID, model string, embedding []float32) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return ErrStoreClosed
+	}
+
+	_, err := s.db.Exec(`
+		INSERT INTO lore_embeddings (lore_id, model, embedding, created_at) VALUES (?, ?, ?, ?)
+		ON CONFLICT(lore_id, model) DO UPDATE SET embedding = excluded.embedding, created_at = excluded.created_at
+	`, loreID, model, PackFloat32(embedding), s.now().Format(time.RFC3339))
+	if err != nil {
+		return fmt.Errorf("store: set embedding: %w", err)
+	}
+	return nil
+}
+
+// EmbeddingModels counts the live entries with a vector for each model,
+// including the store's own model, in the store's namespace.
+func (s *Store) EmbeddingModels() ([]EmbeddingModelCount, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil, ErrStoreClosed
+	}
+
+	var primary string
+	_ = s.db.QueryRow(`SELECT value FROM metadata WHERE key = ?`, metadataKeyEmbeddingModel).Scan(&primary)
+
+	rows, err := s.db.Query(`
+		SELECT model, COUNT(DISTINCT lore_id) FROM (
+			SELECT e.model, e.lore_id FROM lore_embeddings e
+			JOIN lore_entries l ON l.id = e.lore_id
+			WHERE l.deleted_at IS NULL AND l.namespace = ?
+			UNION ALL
+			SELECT ?, id FROM lore_entries
+			WHERE embedding IS NOT NULL AND deleted_at IS NULL AND namespace = ?
+		) GROUP BY model ORDER BY model
+	`, s.namespace, primary, s.namespace)
+	if err != nil {
+		return nil, fmt.Errorf("store: embedding models: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var counts []EmbeddingModelCount
+	for rows.Next() {
+		var c EmbeddingModelCount
+		if err := rows.Scan(&c.Model, &c.Entries); err != nil {
+			return nil, fmt.Errorf("store: scan embedding models: %w", err)
+		}
+		c.Primary = c.Model == primary
+		counts = append(counts, c)
+	}
+	return counts, rows.Err()
+}
+
+// PruneEmbeddings ends a model transition: it drops every vector not
+// produced by model, across all namespaces, along with vectors of deleted
+// entries. When the store's own vectors belong to another model they are
+// cleared and model becomes the store's embedding model, so the next
+// bootstrap from an Engram still on the old model is refused with
+// ErrModelMismatch. Run Maintain afterwards to return the space.
+func (s *Store) PruneEmbeddings(model string) (*EmbeddingPruneResult, error) {
+	if model == "" {
+		return nil, &ValidationError{Field: "model", Message: "is required"}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil, ErrStoreClosed
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("store: begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	result := &EmbeddingPruneResult{}
+	res, err := tx.Exec(`
+		DELETE FROM lore_embeddings
+		WHERE model != ? OR lore_id NOT IN (SELECT id FROM lore_entries WHERE deleted_at IS NULL)
+	`, model)
+	if err != nil {
+		return nil, fmt.Errorf("store: prune embeddings: %w", err)
+	}
+	removed, _ := res.RowsAffected()
+	result.Removed = int(removed)
+
+	var primary string
+	_ = tx.QueryRow(`SELECT value FROM metadata WHERE key = ?`, metadataKeyEmbeddingModel).Scan(&primary)
+	if primary != model {
+		res, err := tx.Exec(`UPDATE lore_entries SET embedding = NULL WHERE embedding IS NOT NULL`)
+		if err != nil {
+			return nil, fmt.Errorf("store: clear embeddings: %w", err)
+		}
+		cleared, _ := res.RowsAffected()
+		result.PrimaryCleared = int(cleared)
+		if _, err := tx.Exec(`
+			INSERT INTO metadata (key, value) VALUES (?, ?)
+			ON CONFLICT(key) DO UPDATE SET value = excluded.value
+		`, metadataKeyEmbeddingModel, model); err != nil {
+			return nil, fmt.Errorf("store: set embedding model: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("store: commit: %w", err)
+	}
+	return result, nil
+}
+
+// SetEmbedding stores ref's vector for model, e.g. while re-embedding lore
+// with a new model ahead of switching Config.EmbeddingModel to it. ref may
+// be a lore ID or a session ref.
+func (c *Client) SetEmbedding(ref, model string, embedding []float32) error {
+	if model == "" {
+		return &ValidationError{Field: "model", Message: "is required"}
+	}
+	if len(embedding) == 0 {
+		return &ValidationError{Field: "embedding", Message: "is empty"}
+	}
+	id, err := c.resolveRef(ref)
+	if err != nil {
+		return err
+	}
+	if _, err := c.store.Get(id); err != nil {
+		return fmt.Errorf("client: set embedding: %w", err)
+	}
+	if err := c.store.SetEmbedding(id, model, embedding); err != nil {
+		return fmt.Errorf("client: set embedding: %w", err)
+	}
+	return nil
+}
+
+// BackfillEmbeddings embeds every live entry of the namespace that has no
+// vector for model yet, storing the results with SetEmbedding. It returns
+// how many entries were embedded; on error, the entries embedded so far
+// are kept, so it can be resumed.
+func (c *Client) BackfillEmbeddings(ctx context.Context, model string, embed EmbedFunc) (int, error) {
+	if model == "" {
+		return 0, &ValidationError{Field: "model", Message: "is required"}
+	}
+	release, err := c.store.enter(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer release()
+
+	lore, err := c.store.Query(QueryParams{})
+	if err != nil {
+		return 0, fmt.Errorf("client: backfill embeddings: %w", err)
+	}
+	embedded, err := c.store.QueryWithModelEmbeddings(QueryParams{}, model)
+	if err != nil {
+		return 0, fmt.Errorf("client: backfill embeddings: %w", err)
+	}
+	have := make(map[string]bool, len(embedded))
+	for _, l := range embedded {
+		have[l.ID] = true
+	}
+
+	count := 0
+	for _, l := range lore {
+		if have[l.ID] {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return count, err
+		}
+		vector, err := embed(ctx, l.Content)
+		if err != nil {
+			return count, fmt.Errorf("client: backfill embeddings: embed %s: %w", l.ID, err)
+		}
+		if err := c.store.SetEmbedding(l.ID, model, vector); err != nil {
+			return count, fmt.Errorf("client: backfill embeddings: %w", err)
+		}
+		count++
+	}
+	return count, nil
+}
+
+// queryEmbeddings reads the candidates for a similarity query, with the
+// vectors of Config.EmbeddingModel when one is set.
+func (c *Client) queryEmbeddings(params QueryParams) ([]Lore, error) {
+	if c.config.EmbeddingModel == "" {
+		return c.store.QueryWithEmbeddings(params)
+	}
+	return c.store.QueryWithModelEmbeddings(params, c.config.EmbeddingModel)
+}
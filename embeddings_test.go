@@ -0,0 +1,101 @@
+package recall
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestClient_EmbeddingModelTransition(t *testing.T) {
+	client := newTestClient(t, Config{})
+	store := client.store
+
+	if err := store.SetMetadata("embedding_model", "old"); err != nil {
+		t.Fatalf("SetMetadata: %v", err)
+	}
+	now := time.Now().UTC()
+	for _, l := range []*Lore{
+		{ID: "a", Content: "alpha", Embedding: PackFloat32([]float32{1, 0})},
+		{ID: "b", Content: "beta", Embedding: PackFloat32([]float32{0, 1})},
+	} {
+		l.Category, l.Confidence, l.CreatedAt, l.UpdatedAt = CategoryPatternOutcome, 0.8, now, now
+		if err := store.UpsertLore(l); err != nil {
+			t.Fatalf("UpsertLore: %v", err)
+		}
+	}
+	// In the new model's space, b is the better match for the query.
+	if err := client.SetEmbedding("a", "new", []float32{0, 1}); err != nil {
+		t.Fatalf("SetEmbedding: %v", err)
+	}
+
+	query := func(model string) []string {
+		t.Helper()
+		client.config.EmbeddingModel = model
+		result, err := client.Query(context.Background(), QueryParams{Query: "q", QueryEmbedding: []float32{1, 0}})
+		if err != nil {
+			t.Fatalf("Query(model=%q): %v", model, err)
+		}
+		var ids []string
+		for _, l := range result.Lore {
+			ids = append(ids, l.ID)
+		}
+		return ids
+	}
+
+	if got := query(""); len(got) != 2 || got[0] != "a" {
+		t.Errorf("store model: got %v, want a first", got)
+	}
+	if got := query("old"); len(got) != 2 || got[0] != "a" {
+		t.Errorf("old model: got %v, want a first", got)
+	}
+	if got := query("new"); len(got) != 1 || got[0] != "a" {
+		t.Errorf("new model before backfill: got %v, want only a", got)
+	}
+
+	embedded, err := client.BackfillEmbeddings(context.Background(), "new", func(_ context.Context, text string) ([]float32, error) {
+		if text != "beta" {
+			t.Errorf("backfill embedded %q, want only beta", text)
+		}
+		return []float32{1, 0}, nil
+	})
+	if err != nil || embedded != 1 {
+		t.Fatalf("BackfillEmbeddings = %d, %v; want 1", embedded, err)
+	}
+	if got := query("new"); len(got) != 2 || got[0] != "b" {
+		t.Errorf("new model after backfill: got %v, want b first", got)
+	}
+
+	counts, err := store.EmbeddingModels()
+	if err != nil {
+		t.Fatalf("EmbeddingModels: %v", err)
+	}
+	if len(counts) != 2 || counts[0] != (EmbeddingModelCount{Model: "new", Entries: 2}) || counts[1] != (EmbeddingModelCount{Model: "old", Entries: 2, Primary: true}) {
+		t.Errorf("EmbeddingModels = %+v", counts)
+	}
+
+	pruned, err := store.PruneEmbeddings("new")
+	if err != nil {
+		t.Fatalf("PruneEmbeddings: %v", err)
+	}
+	if pruned.Removed != 0 || pruned.PrimaryCleared != 2 {
+		t.Errorf("PruneEmbeddings = %+v, want 2 old vectors cleared", pruned)
+	}
+	if model, _ := store.GetMetadata("embedding_model"); model != "new" {
+		t.Errorf("embedding_model = %q, want new", model)
+	}
+	if got := query(""); len(got) != 0 {
+		t.Errorf("store column after prune: got %v, want none", got)
+	}
+	if got := query("new"); len(got) != 2 || got[0] != "b" {
+		t.Errorf("new model after prune: got %v, want b first", got)
+	}
+
+	var ve *ValidationError
+	if err := client.SetEmbedding("a", "", []float32{1}); !errors.As(err, &ve) {
+		t.Errorf("SetEmbedding without model: err = %v, want ValidationError", err)
+	}
+	if err := client.SetEmbedding("missing", "new", []float32{1}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("SetEmbedding on missing lore: err = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,281 @@
+package recall
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MaxEpisodeEntries is the most entries RecordEpisode records at once.
+const MaxEpisodeEntries = 50
+
+// EpisodeParams configures RecordEpisode.
+type EpisodeParams struct {
+	// Context is shared by the entries that have no Context of their own,
+	// e.g. the task the lessons came from.
+	Context string `json:"context,omitempty"`
+
+	// Entries are the lessons to record, in order. Confidence 0 means
+	// ConfidenceDefault.
+	Entries []RecordParams `json:"entries"`
+}
+
+// Episode is lore recorded together by RecordEpisode.
+type Episode struct {
+	ID   string `json:"id"`
+	Lore []Lore `json:"lore"` // Live entries, in the order they were recorded
+}
+
+// InsertEpisode inserts lore, in order, as the entries of one episode in
+// a single transaction. Each entry gets its own change_log entry and syncs
+// as ordinary lore; the episode link is local-only.
+func (s *Store) InsertEpisode(episodeID string, lore []*Lore) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return ErrStoreClosed
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("store: begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	now := s.now().Format(time.RFC3339)
+	for i, l := range lore {
+		if err := s.recordLoreTx(tx, l); err != nil {
+			return err
+		}
+		_, err := tx.Exec(`INSERT INTO lore_episodes (lore_id, episode_id, position, created_at) VALUES (?, ?, ?, ?)`,
+			l.ID, episodeID, i+1, now)
+		if err != nil {
+			return fmt.Errorf("store: insert episode entry: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("store: commit: %w", err)
+	}
+	return nil
+}
+
+// EpisodeIDs returns the episode of each of the given lore IDs that was
+// recorded in one.
+func (s *Store) EpisodeIDs(loreIDs []string) (map[string]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil, ErrStoreClosed
+	}
+
+	episodes := make(map[string]string)
+	if len(loreIDs) == 0 {
+		return episodes, nil
+	}
+
+	placeholders := make([]string, len(loreIDs))
+	args := make([]any, len(loreIDs))
+	for i, id := range loreIDs {
+		placeholders[i] = "?"
+		args[i] = id
+	}
+	rows, err := s.db.Query(fmt.Sprintf(`
+		SELECT lore_id, episode_id FROM lore_episodes WHERE lore_id IN (%s)
+	`, strings.Join(placeholders, ",")), args...)
+	if err != nil {
+		return nil, fmt.Errorf("store: query episodes: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var id, episodeID string
+		if err := rows.Scan(&id, &episodeID); err != nil {
+			return nil, fmt.Errorf("store: scan episode: %w", err)
+		}
+		episodes[id] = episodeID
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("store: iterate episodes: %w", err)
+	}
+	return episodes, nil
+}
+
+// EpisodeLore returns the IDs of the live entries of episodeID in the
+// namespace, in order.
+func (s *Store) EpisodeLore(episodeID string) ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil, ErrStoreClosed
+	}
+
+	rows, err := s.db.Query(`
+		SELECT e.lore_id FROM lore_episodes e
+		JOIN lore_entries l ON l.id = e.lore_id
+		WHERE e.episode_id = ? AND l.deleted_at IS NULL AND l.namespace = ?
+		ORDER BY e.position
+	`, episodeID, s.namespace)
+	if err != nil {
+		return nil, fmt.Errorf("store: query episode: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("store: scan episode: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("store: iterate episode: %w", err)
+	}
+	return ids, nil
+}
+
+// RecordEpisode records several related lessons, e.g. everything an agent
+// learned finishing one task, in one transaction: either all entries are
+// recorded or none. The entries share an episode ID, which Episode and
+// QueryResult.Episodes expose. Their change_log entries are consecutive,
+// so a push sends them in one batch unless the batch size limit falls
+// inside the episode. Each entry is normalized and
+// validated as Record would; content over MaxContentLength is rejected
+// rather than chunked, and Config.DedupExact does not apply.
+func (c *Client) RecordEpisode(ctx context.Context, params EpisodeParams) (*Episode, error) {
+	if len(params.Entries) == 0 {
+		return nil, &ValidationError{Field: "Entries", Message: "cannot be empty"}
+	}
+	if len(params.Entries) > MaxEpisodeEntries {
+		return nil, &ValidationError{Field: "Entries", Message: fmt.Sprintf("has %d entries; at most %d are allowed", len(params.Entries), MaxEpisodeEntries)}
+	}
+	if len(params.Context) > MaxContextLength {
+		return nil, &ValidationError{Field: "Context", Message: "exceeds 1000 character limit"}
+	}
+
+	type submission struct {
+		content    string
+		normalized []string
+	}
+	now := c.store.now()
+	lore := make([]*Lore, len(params.Entries))
+	submitted := make([]submission, len(params.Entries))
+	for i, entry := range params.Entries {
+		field := fmt.Sprintf("Entries[%d].", i)
+		content, normalized := c.normalize(entry.Content)
+		switch {
+		case content == "":
+			return nil, &ValidationError{Field: field + "Content", Message: "cannot be empty"}
+		case len(content) > MaxContentLength:
+			return nil, &ValidationError{Field: field + "Content", Message: "exceeds 4000 character limit"}
+		case len(entry.Context) > MaxContextLength:
+			return nil, &ValidationError{Field: field + "Context", Message: "exceeds 1000 character limit"}
+		case !entry.Category.IsValid():
+			return nil, &ValidationError{Field: field + "Category", Message: "invalid: must be one of " + validCategoriesString()}
+		case entry.Confidence < ConfidenceMin || entry.Confidence > ConfidenceMax:
+			return nil, &ValidationError{Field: field + "Confidence", Message: "must be between 0.0 and 1.0"}
+		}
+		if err := c.authorize(ActionRecord, entry.Category); err != nil {
+			return nil, err
+		}
+
+		confidence := entry.Confidence
+		if confidence == 0 {
+			confidence = ConfidenceDefault
+		}
+		entryContext := entry.Context
+		if entryContext == "" {
+			entryContext = params.Context
+		}
+		lore[i] = &Lore{
+			ID:         c.store.newID(),
+			Content:    content,
+			Category:   entry.Category,
+			Context:    entryContext,
+			Confidence: confidence,
+			SourceID:   c.config.SourceID,
+			CreatedAt:  now,
+			UpdatedAt:  now,
+		}
+		submitted[i] = submission{entry.Content, normalized}
+	}
+
+	release, err := c.store.enter(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	episode := &Episode{ID: c.store.newID(), Lore: make([]Lore, len(lore))}
+	if err := c.store.InsertEpisode(episode.ID, lore); err != nil {
+		return nil, fmt.Errorf("client: record episode: %w", err)
+	}
+	for i, l := range lore {
+		c.preserveOriginal(l, submitted[i].content, submitted[i].normalized)
+		episode.Lore[i] = *l
+	}
+	c.telemetry.records.Add(int64(len(lore)))
+	c.storeTokenCounts(lore...)
+	c.checkQuota(false)
+	return episode, nil
+}
+
+// Episode returns the episode ref was recorded in, with its live entries
+// in order. If ref was not recorded by RecordEpisode, the result has no ID
+// and holds just ref. ref may be a lore ID, a session ref, or an episode
+// ID.
+func (c *Client) Episode(ref string) (*Episode, error) {
+	ids, err := c.store.EpisodeLore(ref)
+	if err != nil {
+		return nil, fmt.Errorf("client: episode: %w", err)
+	}
+	episode := &Episode{ID: ref}
+	if len(ids) == 0 {
+		id, err := c.resolveRef(ref)
+		if err != nil {
+			return nil, err
+		}
+		episodes, err := c.store.EpisodeIDs([]string{id})
+		if err != nil {
+			return nil, fmt.Errorf("client: episode: %w", err)
+		}
+		episode.ID = episodes[id]
+		if episode.ID != "" {
+			ids, err = c.store.EpisodeLore(episode.ID)
+			if err != nil {
+				return nil, fmt.Errorf("client: episode: %w", err)
+			}
+		}
+		if len(ids) == 0 {
+			ids = []string{id}
+		}
+	}
+
+	episode.Lore = make([]Lore, 0, len(ids))
+	for _, id := range ids {
+		l, err := c.store.Get(id)
+		if err != nil {
+			return nil, fmt.Errorf("client: episode: %w", err)
+		}
+		episode.Lore = append(episode.Lore, *l)
+	}
+	return episode, nil
+}
+
+// episodeIDs maps each entry of lore recorded in an episode to its
+// episode ID, or returns nil if none was.
+func (c *Client) episodeIDs(lore []Lore) (map[string]string, error) {
+	ids := make([]string, len(lore))
+	for i, l := range lore {
+		ids[i] = l.ID
+	}
+	episodes, err := c.store.EpisodeIDs(ids)
+	if err != nil || len(episodes) == 0 {
+		return nil, err
+	}
+	return episodes, nil
+}
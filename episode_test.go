@@ -0,0 +1,92 @@
+package recall
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestClient_RecordEpisode(t *testing.T) {
+	client := newTestClient(t, Config{SourceID: "test"})
+
+	// One invalid entry records nothing
+	var verr *ValidationError
+	_, err := client.RecordEpisode(context.Background(), EpisodeParams{Entries: []RecordParams{
+		{Content: "Retry idempotent calls only", Category: CategoryPatternOutcome},
+		{Content: "Bad category", Category: "NOPE"},
+	}})
+	if !errors.As(err, &verr) || verr.Field != "Entries[1].Category" {
+		t.Fatalf("RecordEpisode() error = %v, want an Entries[1].Category validation error", err)
+	}
+	if stats, _ := client.store.Stats(); stats.LoreCount != 0 {
+		t.Fatalf("LoreCount = %d after a rejected episode, want 0", stats.LoreCount)
+	}
+
+	before, _ := client.store.UnpushedChanges(client.store.SourceID(), 0, 100)
+	episode, err := client.RecordEpisode(context.Background(), EpisodeParams{
+		Context: "migrating the billing service",
+		Entries: []RecordParams{
+			{Content: "Backfill before adding NOT NULL", Category: CategoryPatternOutcome},
+			{Content: "Lock timeouts need a retry", Category: CategoryDependencyBehavior, Context: "postgres 15", Confidence: 0.8},
+			{Content: "Run the migration in its own deploy", Category: CategoryArchitecturalDecision},
+		},
+	})
+	if err != nil {
+		t.Fatalf("RecordEpisode() error = %v", err)
+	}
+	if episode.ID == "" || len(episode.Lore) != 3 {
+		t.Fatalf("RecordEpisode() = %+v", episode)
+	}
+	if l := episode.Lore[0]; l.Context != "migrating the billing service" || l.Confidence != ConfidenceDefault {
+		t.Errorf("first entry Context = %q, Confidence = %v; want the shared context and default confidence", l.Context, l.Confidence)
+	}
+	if l := episode.Lore[1]; l.Context != "postgres 15" || l.Confidence != 0.8 {
+		t.Errorf("second entry Context = %q, Confidence = %v; want its own", l.Context, l.Confidence)
+	}
+
+	// The entries' change_log entries are consecutive, so they push together
+	after, _ := client.store.UnpushedChanges(client.store.SourceID(), 0, 100)
+	added := after[len(before):]
+	if len(added) != 3 {
+		t.Fatalf("change_log gained %d entries, want 3", len(added))
+	}
+	for i, entry := range added {
+		if entry.EntityID != episode.Lore[i].ID || entry.Operation != "upsert" {
+			t.Errorf("change %d = %s %s, want upsert of %s", i, entry.Operation, entry.EntityID, episode.Lore[i].ID)
+		}
+	}
+
+	result, err := client.Query(context.Background(), QueryParams{Query: "migration retry backfill", K: 10})
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+	for _, l := range result.Lore {
+		if result.Episodes[l.ID] != episode.ID {
+			t.Errorf("Episodes[%s] = %q, want %q", l.ID, result.Episodes[l.ID], episode.ID)
+		}
+	}
+	var sessionRef string
+	for ref := range result.SessionRefs {
+		sessionRef = ref
+	}
+	if sessionRef == "" {
+		t.Fatal("Query() returned no session refs")
+	}
+
+	for _, ref := range []string{episode.ID, episode.Lore[1].ID, sessionRef} {
+		got, err := client.Episode(ref)
+		if err != nil {
+			t.Fatalf("Episode(%s) error = %v", ref, err)
+		}
+		if got.ID != episode.ID || len(got.Lore) != 3 || got.Lore[0].ID != episode.Lore[0].ID {
+			t.Errorf("Episode(%s) = %+v", ref, got)
+		}
+	}
+
+	// Lore recorded alone is its own one-entry group
+	single, _ := client.Record("Standalone lesson", CategoryPatternOutcome)
+	got, err := client.Episode(single.ID)
+	if err != nil || got.ID != "" || len(got.Lore) != 1 || got.Lore[0].ID != single.ID {
+		t.Errorf("Episode(single) = %+v, %v", got, err)
+	}
+}
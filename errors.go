@@ -40,8 +40,42 @@ var (
 	// ErrSessionRefNotFound is returned when a session reference cannot be resolved.
 	ErrSessionRefNotFound = errors.New("session reference not found")
 
+	// ErrSyncBudgetExceeded is returned when a request is deferred by Config.SyncBudget.
+	ErrSyncBudgetExceeded = errors.New("sync budget exceeded")
+
 	// ErrPendingSyncExists is returned when reinit is attempted with unsynced changes.
 	ErrPendingSyncExists = errors.New("pending sync entries exist; push changes first or clear queue")
+
+	// ErrRecovered is returned, with a usable store, when NewStore recovered
+	// a corrupt database. See RecoveredError.
+	ErrRecovered = errors.New("database was corrupt and has been recovered")
+
+	// ErrMaintenance is returned when an operation waited longer than
+	// Config.MaintenanceWait for exclusive maintenance (Bootstrap,
+	// Reinitialize, Maintain) to finish.
+	ErrMaintenance = errors.New("store is under exclusive maintenance")
+
+	// ErrLegalHold is returned when deleting a store that is under legal hold.
+	ErrLegalHold = errors.New("store is under legal hold")
+
+	// ErrEngramMaintenance is returned when Engram responds that it is in a
+	// maintenance window; auto-sync pauses until the window ends.
+	ErrEngramMaintenance = errors.New("engram is under maintenance")
+
+	// ErrSyncDisabled is returned for Engram requests while the sync
+	// kill-switch is on: SyncDisabledEnv is set or Config.SyncPauseFile
+	// exists.
+	ErrSyncDisabled = errors.New("sync disabled by kill-switch")
+
+	// ErrFeedbackSkipped is returned by Feedback when the actor already gave
+	// the entry the same feedback within Config.FeedbackWindow. The entry
+	// is unchanged.
+	ErrFeedbackSkipped = errors.New("feedback already given within the feedback window")
+
+	// ErrChecksumMismatch is returned when a lore row fails its checksum:
+	// the SQLite file was corrupted or edited outside Recall. See
+	// ChecksumError.
+	ErrChecksumMismatch = errors.New("lore checksum mismatch")
 )
 
 // ValidationError is returned when configuration validation fails.
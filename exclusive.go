@@ -0,0 +1,134 @@
+package recall
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultMaintenanceWait is how long an operation waits for exclusive
+// maintenance to finish before failing with ErrMaintenance.
+const DefaultMaintenanceWait = 5 * time.Second
+
+// exclusiveKey marks a context as running inside Store.Exclusive, so
+// nested calls neither wait for nor re-enter maintenance.
+type exclusiveKey struct{}
+
+// opGate tracks in-flight operations on a store and lets one exclusive
+// maintenance task at a time drain them and run alone.
+type opGate struct {
+	mu      sync.Mutex
+	wait    time.Duration
+	active  int
+	done    chan struct{} // non-nil during maintenance; closed when it ends
+	drained chan struct{} // closed when active reaches zero during maintenance
+}
+
+// SetMaintenanceWait sets how long operations queue behind exclusive
+// maintenance before failing with ErrMaintenance. Zero selects
+// DefaultMaintenanceWait; a negative wait fails them immediately.
+func (s *Store) SetMaintenanceWait(wait time.Duration) {
+	s.gate.mu.Lock()
+	defer s.gate.mu.Unlock()
+	s.gate.wait = wait
+}
+
+// Exclusive runs fn while no other gated operation is in flight. New
+// operations queue (see SetMaintenanceWait) until fn returns. Exclusive
+// waits for in-flight operations and for any other maintenance to finish,
+// or for ctx to be done. fn receives a context under which nested
+// Exclusive calls run fn directly.
+func (s *Store) Exclusive(ctx context.Context, fn func(ctx context.Context) error) error {
+	if ctx.Value(exclusiveKey{}) == s {
+		return fn(ctx)
+	}
+
+	g := &s.gate
+	var drained chan struct{}
+	for {
+		g.mu.Lock()
+		if g.done == nil {
+			g.done = make(chan struct{})
+			if g.active > 0 {
+				g.drained = make(chan struct{})
+				drained = g.drained
+			}
+			g.mu.Unlock()
+			break
+		}
+		done := g.done
+		g.mu.Unlock()
+
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	defer func() {
+		g.mu.Lock()
+		close(g.done)
+		g.done, g.drained = nil, nil
+		g.mu.Unlock()
+	}()
+
+	if drained != nil {
+		select {
+		case <-drained:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return fn(context.WithValue(ctx, exclusiveKey{}, s))
+}
+
+// enter registers an operation, queueing behind exclusive maintenance for
+// up to the maintenance wait. The returned release must be called when
+// the operation ends. Inside Exclusive, enter does nothing.
+func (s *Store) enter(ctx context.Context) (release func(), err error) {
+	if ctx.Value(exclusiveKey{}) == s {
+		return func() {}, nil
+	}
+
+	g := &s.gate
+	var timeout <-chan time.Time
+	for {
+		g.mu.Lock()
+		if g.done == nil {
+			g.active++
+			g.mu.Unlock()
+			return g.release, nil
+		}
+		done, wait := g.done, g.wait
+		g.mu.Unlock()
+
+		if wait < 0 {
+			return nil, ErrMaintenance
+		}
+		if timeout == nil {
+			if wait == 0 {
+				wait = DefaultMaintenanceWait
+			}
+			timer := time.NewTimer(wait)
+			defer timer.Stop()
+			timeout = timer.C
+		}
+		select {
+		case <-done:
+		case <-timeout:
+			return nil, ErrMaintenance
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func (g *opGate) release() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.active--
+	if g.active == 0 && g.drained != nil {
+		close(g.drained)
+		g.drained = nil
+	}
+}
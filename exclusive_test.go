@@ -0,0 +1,220 @@
+package recall
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestStore_Exclusive_DrainsInFlight(t *testing.T) {
+	store := newTestStore(t)
+
+	release, err := store.enter(context.Background())
+	if err != nil {
+		t.Fatalf("enter: %v", err)
+	}
+
+	ran := make(chan struct{})
+	done := make(chan error)
+	go func() {
+		done <- store.Exclusive(context.Background(), func(ctx context.Context) error {
+			close(ran)
+			// Nested calls neither wait nor deadlock.
+			inner, err := store.enter(ctx)
+			if err != nil {
+				return err
+			}
+			inner()
+			return store.Exclusive(ctx, func(context.Context) error { return nil })
+		})
+	}()
+
+	select {
+	case <-ran:
+		t.Fatal("Exclusive ran before the in-flight operation finished")
+	case <-time.After(50 * time.Millisecond):
+	}
+	release()
+	if err := <-done; err != nil {
+		t.Fatalf("Exclusive: %v", err)
+	}
+	select {
+	case <-ran:
+	default:
+		t.Fatal("Exclusive did not run fn")
+	}
+}
+
+func TestStore_Exclusive_BlocksNewOperations(t *testing.T) {
+	store := newTestStore(t)
+
+	inside := make(chan struct{})
+	finish := make(chan struct{})
+	done := make(chan error)
+	go func() {
+		done <- store.Exclusive(context.Background(), func(context.Context) error {
+			close(inside)
+			<-finish
+			return nil
+		})
+	}()
+	<-inside
+
+	store.SetMaintenanceWait(-1)
+	if _, err := store.enter(context.Background()); !errors.Is(err, ErrMaintenance) {
+		t.Errorf("enter with negative wait = %v, want ErrMaintenance", err)
+	}
+	store.SetMaintenanceWait(20 * time.Millisecond)
+	if _, err := store.enter(context.Background()); !errors.Is(err, ErrMaintenance) {
+		t.Errorf("enter after wait = %v, want ErrMaintenance", err)
+	}
+
+	// A queued operation proceeds once maintenance ends.
+	store.SetMaintenanceWait(5 * time.Second)
+	entered := make(chan error)
+	go func() {
+		release, err := store.enter(context.Background())
+		if err == nil {
+			release()
+		}
+		entered <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+	close(finish)
+	if err := <-done; err != nil {
+		t.Fatalf("Exclusive: %v", err)
+	}
+	if err := <-entered; err != nil {
+		t.Errorf("queued enter = %v, want success", err)
+	}
+}
+
+func TestStore_Exclusive_ContextDone(t *testing.T) {
+	store := newTestStore(t)
+
+	release, err := store.enter(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err = store.Exclusive(ctx, func(context.Context) error {
+		t.Error("fn ran while an operation was in flight")
+		return nil
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Exclusive = %v, want DeadlineExceeded", err)
+	}
+	release()
+
+	// The abandoned maintenance does not block later operations.
+	store.SetMaintenanceWait(-1)
+	if release, err := store.enter(context.Background()); err != nil {
+		t.Errorf("enter after abandoned Exclusive = %v", err)
+	} else {
+		release()
+	}
+}
+
+func TestClient_Reinitialize_BlocksQueries(t *testing.T) {
+	client := newTestClient(t, Config{AutoSync: false, MaintenanceWait: -1})
+
+	if _, err := client.Record("Survives until reinit", CategoryPatternOutcome); err != nil {
+		t.Fatal(err)
+	}
+	// Pretend the entry was pushed, so Reinitialize may discard it.
+	if _, err := client.store.db.Exec("DELETE FROM change_log"); err != nil {
+		t.Fatal(err)
+	}
+
+	err := client.store.Exclusive(context.Background(), func(ctx context.Context) error {
+		if _, err := client.Query(context.Background(), QueryParams{}); !errors.Is(err, ErrMaintenance) {
+			t.Errorf("Query during maintenance = %v, want ErrMaintenance", err)
+		}
+		if _, err := client.Record("blocked", CategoryPatternOutcome); !errors.Is(err, ErrMaintenance) {
+			t.Errorf("Record during maintenance = %v, want ErrMaintenance", err)
+		}
+		// Reinitialize nests inside the same maintenance.
+		_, err := client.Reinitialize(ctx, ReinitOptions{AllowEmpty: true})
+		return err
+	})
+	if err != nil {
+		t.Fatalf("Exclusive: %v", err)
+	}
+
+	result, err := client.Query(context.Background(), QueryParams{})
+	if err != nil {
+		t.Fatalf("Query after maintenance: %v", err)
+	}
+	if len(result.Lore) != 0 {
+		t.Errorf("Query after reinit returned %d entries, want 0", len(result.Lore))
+	}
+}
+
+func TestClient_Reinitialize_DownloadsOutsideLock(t *testing.T) {
+	downloading := make(chan struct{})
+	proceed := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/health":
+			_, _ = w.Write([]byte(`{"status":"healthy","embedding_model":"m"}`))
+		case "/api/v1/stores/test-store/sync/snapshot":
+			close(downloading)
+			<-proceed
+			// An empty file is an empty SQLite database.
+		default:
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client := newTestClient(t, Config{
+		Store:           "test-store",
+		EngramURL:       server.URL,
+		APIKey:          "key",
+		MaintenanceWait: -1,
+	})
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := client.Reinitialize(context.Background(), ReinitOptions{})
+		done <- err
+	}()
+
+	// The store stays usable during the download...
+	<-downloading
+	if _, err := client.Record("Recorded during the download", CategoryPatternOutcome); err != nil {
+		t.Errorf("Record during reinit download = %v", err)
+	}
+	close(proceed)
+
+	// ...and the change recorded meanwhile stops the replace.
+	if err := <-done; !errors.Is(err, ErrPendingSyncExists) {
+		t.Fatalf("Reinitialize = %v, want ErrPendingSyncExists", err)
+	}
+	stats, err := client.Stats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.LoreCount != 1 {
+		t.Errorf("LoreCount after refused reinit = %d, want 1", stats.LoreCount)
+	}
+}
+
+func TestClient_Maintain_ContextDone(t *testing.T) {
+	client := newTestClient(t, Config{})
+
+	release, err := client.store.enter(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer release()
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := client.Maintain(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Maintain with an operation in flight = %v, want DeadlineExceeded", err)
+	}
+}
@@ -2,7 +2,10 @@ package recall
 
 import (
 	"context"
+	"crypto/sha256"
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -15,11 +18,21 @@ const ExportVersion = "1.0"
 
 // ExportFormat is the top-level structure for JSON exports.
 type ExportFormat struct {
-	Version    string         `json:"version"`
-	ExportedAt time.Time      `json:"exported_at"`
-	StoreID    string         `json:"store_id"`
-	Metadata   ExportMetadata `json:"metadata"`
-	Lore       []ExportLore   `json:"lore"`
+	Version    string            `json:"version"`
+	ExportedAt time.Time         `json:"exported_at"`
+	SnapshotAt time.Time         `json:"snapshot_at"` // When the point-in-time view was taken
+	StoreID    string            `json:"store_id"`
+	Metadata   ExportMetadata    `json:"metadata"`
+	Lore       []ExportLore      `json:"lore"`
+	Redactions *RedactionSummary `json:"redactions,omitempty"` // Set when the export was redacted
+}
+
+// ExportOptions controls a JSON export.
+type ExportOptions struct {
+	// Redact masks matches of each rule in exported content and context,
+	// for exports shared with third parties. See RedactEmails,
+	// RedactSecrets and RedactTerms.
+	Redact []RedactionRule
 }
 
 // ExportMetadata contains store metadata in exports.
@@ -68,85 +81,139 @@ type ImportResult struct {
 
 // ExportJSON streams store data as JSON to the writer.
 // This uses cursor-based iteration to avoid loading all data into memory.
+// Under legal hold, the export is recorded with the SHA-256 of its content.
 func (s *Store) ExportJSON(ctx context.Context, storeID string, w io.Writer) error {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
+	_, err := s.ExportJSONWithOptions(ctx, storeID, w, ExportOptions{})
+	return err
+}
+
+// ExportJSONWithOptions is ExportJSON with redaction rules. With rules,
+// the export ends with a "redactions" summary, which is also returned.
+func (s *Store) ExportJSONWithOptions(ctx context.Context, storeID string, w io.Writer, opts ExportOptions) (*RedactionSummary, error) {
+	var redact *redactor
+	if len(opts.Redact) > 0 {
+		var err error
+		if redact, err = newRedactor(opts.Redact); err != nil {
+			return nil, err
+		}
+	}
+
+	h := sha256.New()
+	entries, err := s.exportJSON(ctx, storeID, io.MultiWriter(w, h), redact)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
 	if s.closed {
-		return ErrStoreClosed
+		return nil, ErrStoreClosed
+	}
+	if err := s.logExport("json", exportDestination(w), entries, h.Sum(nil)); err != nil {
+		return nil, err
+	}
+	if redact == nil {
+		return nil, nil
+	}
+	return &redact.summary, nil
+}
+
+// exportJSON writes the JSON export, redacted when redact is non-nil, and
+// returns the number of entries. It reads from a snapshot, so writes made
+// while it streams neither block nor show up in the export.
+func (s *Store) exportJSON(ctx context.Context, storeID string, w io.Writer, redact *redactor) (int, error) {
+	tx, snapshotAt, err := s.beginSnapshot(ctx)
+	if err != nil {
+		return 0, err
 	}
+	defer func() { _ = tx.Rollback() }()
 
 	// Get metadata
-	desc, _ := s.GetMetadata(metadataKeyDescription)
-	createdAtStr, _ := s.GetMetadata(metadataKeyCreatedAt)
+	desc := snapshotMetadata(ctx, tx, metadataKeyDescription)
+	createdAtStr := snapshotMetadata(ctx, tx, metadataKeyCreatedAt)
 	var createdAt time.Time
 	if createdAtStr != "" {
 		createdAt, _ = time.Parse(time.RFC3339, createdAtStr)
 	}
 
 	// Write opening structure manually for streaming
-	header := fmt.Sprintf(`{"version":"%s","exported_at":"%s","store_id":"%s","metadata":{"description":%s,"created_at":"%s"},"lore":[`,
+	header := fmt.Sprintf(`{"version":"%s","exported_at":"%s","snapshot_at":"%s","store_id":"%s","metadata":{"description":%s,"created_at":"%s"},"lore":[`,
 		ExportVersion,
-		time.Now().UTC().Format(time.RFC3339),
+		s.now().Format(time.RFC3339),
+		snapshotAt.Format(time.RFC3339),
 		storeID,
 		jsonString(desc),
 		createdAt.Format(time.RFC3339),
 	)
 	if _, err := io.WriteString(w, header); err != nil {
-		return fmt.Errorf("write header: %w", err)
+		return 0, fmt.Errorf("write header: %w", err)
 	}
 
 	// Stream lore entries using cursor-based iteration
-	rows, err := s.db.QueryContext(ctx, `
+	rows, err := tx.QueryContext(ctx, `
 		SELECT id, content, context, category, confidence, embedding, embedding_status,
 		       source_id, sources, validation_count, created_at, updated_at, synced_at
 		FROM lore_entries
-		WHERE deleted_at IS NULL
+		WHERE deleted_at IS NULL AND namespace = ?
 		ORDER BY created_at
-	`)
+	`, s.namespace)
 	if err != nil {
-		return fmt.Errorf("query lore: %w", err)
+		return 0, fmt.Errorf("query lore: %w", err)
 	}
 	defer func() { _ = rows.Close() }()
 
 	enc := json.NewEncoder(w)
 	first := true
+	entries := 0
 
 	for rows.Next() {
 		// Check for context cancellation
 		select {
 		case <-ctx.Done():
-			return ctx.Err()
+			return 0, ctx.Err()
 		default:
 		}
 
 		lore, err := s.scanExportLoreRows(rows)
 		if err != nil {
-			return fmt.Errorf("scan lore: %w", err)
+			return 0, fmt.Errorf("scan lore: %w", err)
+		}
+		if redact != nil {
+			redact.apply(lore)
 		}
 
 		if !first {
 			if _, err := io.WriteString(w, ","); err != nil {
-				return fmt.Errorf("write separator: %w", err)
+				return 0, fmt.Errorf("write separator: %w", err)
 			}
 		}
 		first = false
 
 		if err := enc.Encode(lore); err != nil {
-			return fmt.Errorf("encode lore: %w", err)
+			return 0, fmt.Errorf("encode lore: %w", err)
 		}
+		entries++
 	}
 
 	if err := rows.Err(); err != nil {
-		return fmt.Errorf("iterate lore: %w", err)
+		return 0, fmt.Errorf("iterate lore: %w", err)
 	}
 
-	// Close JSON structure
-	if _, err := io.WriteString(w, "]}"); err != nil {
-		return fmt.Errorf("write footer: %w", err)
+	// Close JSON structure, appending the redaction summary
+	footer := "]}"
+	if redact != nil {
+		summary, err := json.Marshal(redact.summary)
+		if err != nil {
+			return 0, fmt.Errorf("encode redactions: %w", err)
+		}
+		footer = `],"redactions":` + string(summary) + "}"
+	}
+	if _, err := io.WriteString(w, footer); err != nil {
+		return 0, fmt.Errorf("write footer: %w", err)
 	}
 
-	return nil
+	return entries, nil
 }
 
 // scanExportLoreRows scans a row into ExportLore format.
@@ -227,40 +294,111 @@ func jsonString(s string) string {
 	return string(b)
 }
 
-// ExportSQLite exports the store to a SQLite database file.
-// It performs a WAL checkpoint first to ensure consistency, then copies the database file.
+// ExportSQLite exports the store to a SQLite database file, replacing any
+// file at destPath. The copy is a consistent point-in-time snapshot taken
+// with VACUUM INTO, without blocking writes; its metadata records the
+// snapshot time under "snapshot_at". Under legal hold, the export is
+// recorded with the SHA-256 of the copy.
 func (s *Store) ExportSQLite(ctx context.Context, destPath string) error {
+	snapshotAt, err := s.vacuumInto(ctx, destPath)
+	if err != nil {
+		return err
+	}
+	entries, err := stampSnapshot(ctx, destPath, snapshotAt)
+	if err != nil {
+		_ = os.Remove(destPath)
+		return err
+	}
+	sum, err := fileSHA256(destPath)
+	if err != nil {
+		return fmt.Errorf("hash export: %w", err)
+	}
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	if s.closed {
 		return ErrStoreClosed
 	}
+	return s.logExport("sqlite", destPath, entries, sum)
+}
+
+// vacuumInto copies the database to destPath as of one point in time and
+// returns that time.
+func (s *Store) vacuumInto(ctx context.Context, destPath string) (time.Time, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 
-	// Perform WAL checkpoint to flush pending writes
-	if _, err := s.db.ExecContext(ctx, "PRAGMA wal_checkpoint(TRUNCATE)"); err != nil {
-		return fmt.Errorf("checkpoint WAL: %w", err)
+	if s.closed {
+		return time.Time{}, ErrStoreClosed
+	}
+	// VACUUM INTO only writes to a new or empty file
+	if err := os.Remove(destPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return time.Time{}, fmt.Errorf("replace destination: %w", err)
+	}
+	snapshotAt := s.now()
+	if _, err := s.db.ExecContext(ctx, `VACUUM INTO ?`, destPath); err != nil {
+		return time.Time{}, fmt.Errorf("copy database: %w", err)
 	}
+	return snapshotAt, nil
+}
 
-	// Copy the database file
-	srcFile, err := os.Open(s.path)
+// stampSnapshot records snapshotAt in the metadata of the copy at path,
+// rebuilds its keyword index and returns its number of active lore entries.
+func stampSnapshot(ctx context.Context, path string, snapshotAt time.Time) (int, error) {
+	db, err := sql.Open("sqlite", path)
 	if err != nil {
-		return fmt.Errorf("open source: %w", err)
+		return 0, fmt.Errorf("open export: %w", err)
 	}
-	defer func() { _ = srcFile.Close() }()
+	defer func() { _ = db.Close() }()
 
-	destFile, err := os.Create(destPath)
-	if err != nil {
-		return fmt.Errorf("create destination: %w", err)
+	if _, err := db.ExecContext(ctx, `
+		INSERT INTO metadata (key, value) VALUES (?, ?)
+		ON CONFLICT(key) DO UPDATE SET value = excluded.value
+	`, metadataKeySnapshotAt, snapshotAt.UTC().Format(time.RFC3339)); err != nil {
+		return 0, fmt.Errorf("stamp snapshot: %w", err)
+	}
+	// VACUUM INTO may renumber rowids, which key the keyword index
+	if _, err := db.ExecContext(ctx, rebuildKeywordIndex); err != nil {
+		return 0, fmt.Errorf("rebuild keyword index: %w", err)
+	}
+	var entries int
+	if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM lore_entries WHERE deleted_at IS NULL").Scan(&entries); err != nil {
+		return 0, fmt.Errorf("count lore: %w", err)
 	}
-	defer func() { _ = destFile.Close() }()
+	return entries, nil
+}
 
-	if _, err := io.Copy(destFile, srcFile); err != nil {
-		_ = os.Remove(destPath)
-		return fmt.Errorf("copy database: %w", err)
+// beginSnapshot starts a read transaction and takes its snapshot, returning
+// the time it was taken. In WAL mode the transaction sees the database as
+// of its first read, whatever is written later. s.mu is held only while
+// the snapshot is taken, so a long read does not hold up writers. The
+// caller must roll the transaction back.
+func (s *Store) beginSnapshot(ctx context.Context) (*sql.Tx, time.Time, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil, time.Time{}, ErrStoreClosed
 	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("begin snapshot: %w", err)
+	}
+	snapshotAt := s.now()
+	var n int
+	if err := tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM metadata`).Scan(&n); err != nil {
+		_ = tx.Rollback()
+		return nil, time.Time{}, fmt.Errorf("begin snapshot: %w", err)
+	}
+	return tx, snapshotAt, nil
+}
 
-	return destFile.Sync()
+// snapshotMetadata returns the metadata value for key within tx, or "".
+func snapshotMetadata(ctx context.Context, tx *sql.Tx, key string) string {
+	var value sql.NullString
+	_ = tx.QueryRowContext(ctx, "SELECT value FROM metadata WHERE key = ?", key).Scan(&value)
+	return value.String
 }
 
 // LoreCount returns the number of active lore entries.
@@ -273,6 +411,6 @@ func (s *Store) LoreCount() (int, error) {
 	}
 
 	var count int
-	err := s.db.QueryRow("SELECT COUNT(*) FROM lore_entries WHERE deleted_at IS NULL").Scan(&count)
+	err := s.db.QueryRow("SELECT COUNT(*) FROM lore_entries WHERE deleted_at IS NULL AND namespace = ?", s.namespace).Scan(&count)
 	return count, err
 }
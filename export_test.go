@@ -203,6 +203,61 @@ func TestExportSQLite(t *testing.T) {
 	if count != 1 {
 		t.Errorf("LoreCount() = %d, want 1", count)
 	}
+	if snapshotAt, _ := exportedStore.GetMetadata("snapshot_at"); snapshotAt == "" {
+		t.Error("exported database should record snapshot_at")
+	}
+}
+
+// recordingWriter records a lore entry on its first write, while the
+// export is under way.
+type recordingWriter struct {
+	bytes.Buffer
+	store    *recall.Store
+	recorded bool
+	err      error
+}
+
+func (w *recordingWriter) Write(p []byte) (int, error) {
+	if !w.recorded {
+		w.recorded = true
+		_, w.err = w.store.Record(recall.Lore{Content: "Written during export", Category: recall.CategoryPatternOutcome, Confidence: 0.5})
+	}
+	return w.Buffer.Write(p)
+}
+
+func TestExportJSON_Snapshot(t *testing.T) {
+	store, err := recall.NewStore(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("NewStore() returned error: %v", err)
+	}
+	defer store.Close()
+
+	if _, err := store.Record(recall.Lore{Content: "Before export", Category: recall.CategoryPatternOutcome, Confidence: 0.5}); err != nil {
+		t.Fatalf("Record() returned error: %v", err)
+	}
+
+	// Writes during the export neither block nor appear in it
+	w := &recordingWriter{store: store}
+	if err := store.ExportJSON(context.Background(), "test", w); err != nil {
+		t.Fatalf("ExportJSON() returned error: %v", err)
+	}
+	if w.err != nil {
+		t.Fatalf("Record() during export returned error: %v", w.err)
+	}
+
+	var export recall.ExportFormat
+	if err := json.Unmarshal(w.Bytes(), &export); err != nil {
+		t.Fatalf("unmarshal export: %v", err)
+	}
+	if len(export.Lore) != 1 || export.Lore[0].Content != "Before export" {
+		t.Errorf("exported lore = %+v, want only the entry recorded before the export", export.Lore)
+	}
+	if export.SnapshotAt.IsZero() {
+		t.Error("SnapshotAt should not be zero")
+	}
+	if count, _ := store.LoreCount(); count != 2 {
+		t.Errorf("LoreCount() after export = %d, want 2", count)
+	}
 }
 
 func TestLoreCount(t *testing.T) {
@@ -0,0 +1,213 @@
+package recall
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// FeedbackFileFormat is the encoding of a bulk feedback file.
+type FeedbackFileFormat string
+
+const (
+	// FeedbackFileCSV has columns id_or_ref, outcome and an optional
+	// reason. A header row naming the first column id_or_ref is skipped.
+	FeedbackFileCSV FeedbackFileFormat = "csv"
+
+	// FeedbackFileJSON is an array of FeedbackRow objects.
+	FeedbackFileJSON FeedbackFileFormat = "json"
+)
+
+// FeedbackRow is one line of a bulk feedback file.
+type FeedbackRow struct {
+	// Ref is a lore ID or a session ref (L1, L2, ...).
+	Ref     string `json:"id_or_ref"`
+	Outcome string `json:"outcome"`
+
+	// Reason, if set, is attached to the entry as a private note.
+	Reason string `json:"reason,omitempty"`
+}
+
+// FeedbackFileOptions controls FeedbackFromReader.
+type FeedbackFileOptions struct {
+	// Format defaults to FeedbackFileCSV.
+	Format FeedbackFileFormat
+
+	// DryRun reports each row's effect without applying it.
+	DryRun bool
+}
+
+// FeedbackRowResult reports the outcome of one row. Rows are numbered from
+// 1, excluding a CSV header.
+type FeedbackRowResult struct {
+	Row             int          `json:"row"`
+	Ref             string       `json:"id_or_ref"`
+	LoreID          string       `json:"lore_id,omitempty"`
+	Outcome         FeedbackType `json:"outcome,omitempty"`
+	Previous        float64      `json:"previous"`
+	Current         float64      `json:"current"`
+	ValidationCount int          `json:"validation_count"`
+	Noted           bool         `json:"noted,omitempty"`
+	Skipped         bool         `json:"skipped,omitempty"` // Repeated within Config.FeedbackWindow
+	Error           string       `json:"error,omitempty"`
+}
+
+// FeedbackFileResult reports a bulk feedback run.
+type FeedbackFileResult struct {
+	Rows    []FeedbackRowResult `json:"rows"`
+	Applied int                 `json:"applied"`
+	Skipped int                 `json:"skipped"`
+	Failed  int                 `json:"failed"`
+	DryRun  bool                `json:"dry_run,omitempty"`
+}
+
+// ParseFeedbackFile reads bulk feedback rows. Malformed files are errors;
+// invalid values in a row are reported per row by FeedbackFromReader.
+func ParseFeedbackFile(r io.Reader, format FeedbackFileFormat) ([]FeedbackRow, error) {
+	switch format {
+	case FeedbackFileJSON:
+		var rows []FeedbackRow
+		if err := json.NewDecoder(r).Decode(&rows); err != nil {
+			return nil, fmt.Errorf("feedback file: %w", err)
+		}
+		return rows, nil
+	case FeedbackFileCSV, "":
+		cr := csv.NewReader(r)
+		cr.FieldsPerRecord = -1
+		cr.TrimLeadingSpace = true
+		cr.Comment = '#'
+		records, err := cr.ReadAll()
+		if err != nil {
+			return nil, fmt.Errorf("feedback file: %w", err)
+		}
+		if len(records) > 0 && strings.EqualFold(strings.TrimSpace(records[0][0]), "id_or_ref") {
+			records = records[1:]
+		}
+		rows := make([]FeedbackRow, 0, len(records))
+		for _, rec := range records {
+			var row FeedbackRow
+			row.Ref = strings.TrimSpace(rec[0])
+			if len(rec) > 1 {
+				row.Outcome = strings.TrimSpace(rec[1])
+			}
+			if len(rec) > 2 {
+				row.Reason = strings.TrimSpace(rec[2])
+			}
+			rows = append(rows, row)
+		}
+		return rows, nil
+	}
+	return nil, &ValidationError{Field: "Format", Message: fmt.Sprintf("unknown feedback file format %q", format)}
+}
+
+// parseFeedbackOutcome accepts helpful, incorrect, not_relevant (or
+// not-relevant) and outdated, case-insensitively.
+func parseFeedbackOutcome(s string) (FeedbackType, error) {
+	switch strings.ReplaceAll(strings.ToLower(strings.TrimSpace(s)), "-", "_") {
+	case "helpful":
+		return Helpful, nil
+	case "incorrect":
+		return Incorrect, nil
+	case "not_relevant":
+		return NotRelevant, nil
+	case "outdated":
+		return Outdated, nil
+	}
+	return "", fmt.Errorf("invalid outcome %q: want helpful, incorrect, not_relevant or outdated", s)
+}
+
+// FeedbackFromReader applies feedback from a CSV or JSON file, for
+// example after a retro. Each row is applied like Feedback; a row that
+// fails is reported and the rest continue, and a row repeating feedback
+// within Config.FeedbackWindow is reported as Skipped. Reasons are attached as
+// private notes. With DryRun, rows are resolved and their effect
+// computed, but nothing is written.
+func (c *Client) FeedbackFromReader(r io.Reader, opts FeedbackFileOptions) (*FeedbackFileResult, error) {
+	if err := c.authorize(ActionFeedback, ""); err != nil {
+		return nil, err
+	}
+	rows, err := ParseFeedbackFile(r, opts.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &FeedbackFileResult{Rows: make([]FeedbackRowResult, 0, len(rows)), DryRun: opts.DryRun}
+	for i, row := range rows {
+		res := c.applyFeedbackRow(row, opts.DryRun)
+		res.Row = i + 1
+		if res.Error != "" {
+			result.Failed++
+		} else if res.Skipped {
+			result.Skipped++
+		} else {
+			result.Applied++
+		}
+		result.Rows = append(result.Rows, res)
+	}
+	return result, nil
+}
+
+func (c *Client) applyFeedbackRow(row FeedbackRow, dryRun bool) FeedbackRowResult {
+	res := FeedbackRowResult{Ref: row.Ref}
+	fail := func(err error) FeedbackRowResult {
+		res.Error = err.Error()
+		return res
+	}
+
+	if row.Ref == "" {
+		return fail(errors.New("missing id_or_ref"))
+	}
+	ft, err := parseFeedbackOutcome(row.Outcome)
+	if err != nil {
+		return fail(err)
+	}
+	res.Outcome = ft
+	if len(row.Reason) > MaxNoteLength {
+		return fail(fmt.Errorf("reason exceeds %d character limit", MaxNoteLength))
+	}
+
+	loreID, err := c.resolveRef(row.Ref)
+	if err != nil {
+		return fail(err)
+	}
+	before, err := c.store.Get(loreID)
+	if err != nil {
+		return fail(err)
+	}
+	res.LoreID = loreID
+	res.Previous = before.Confidence
+
+	if dryRun {
+		res.Current = min(max(before.Confidence+feedbackDelta(ft), ConfidenceMin), ConfidenceMax)
+		res.ValidationCount = before.ValidationCount
+		if ft == Helpful {
+			res.ValidationCount++
+		}
+		res.Noted = row.Reason != ""
+		return res
+	}
+
+	after, err := c.Feedback(loreID, ft)
+	if errors.Is(err, ErrFeedbackSkipped) {
+		res.Current = before.Confidence
+		res.ValidationCount = before.ValidationCount
+		res.Skipped = true
+		return res
+	}
+	if err != nil {
+		return fail(err)
+	}
+	res.Current = after.Confidence
+	res.ValidationCount = after.ValidationCount
+
+	if row.Reason != "" {
+		if _, err := c.Annotate(loreID, fmt.Sprintf("%s: %s", ft, row.Reason)); err != nil {
+			return fail(fmt.Errorf("feedback applied, but reason not saved: %w", err))
+		}
+		res.Noted = true
+	}
+	return res
+}
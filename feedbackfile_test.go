@@ -0,0 +1,100 @@
+package recall
+
+import (
+	"strings"
+	"testing"
+)
+
+func newFeedbackFileClient(t *testing.T) (*Client, []*Lore) {
+	t.Helper()
+	client := newTestClient(t, Config{})
+
+	var entries []*Lore
+	for _, content := range []string{"Retry with backoff", "Pin the base image", "Mock the clock"} {
+		lore, err := client.Record(content, CategoryPatternOutcome)
+		if err != nil {
+			t.Fatalf("Record: %v", err)
+		}
+		entries = append(entries, lore)
+	}
+	return client, entries
+}
+
+func TestClient_FeedbackFromReader_CSV(t *testing.T) {
+	client, entries := newFeedbackFileClient(t)
+
+	csv := "id_or_ref,outcome,reason\n" +
+		entries[0].ID + ",helpful,saved the deploy\n" +
+		"# skipped in the retro\n" +
+		entries[1].ID + ",not-relevant\n" +
+		entries[2].ID + ",wrong\n" +
+		"01MISSING0000000000000000,incorrect\n"
+
+	result, err := client.FeedbackFromReader(strings.NewReader(csv), FeedbackFileOptions{})
+	if err != nil {
+		t.Fatalf("FeedbackFromReader: %v", err)
+	}
+	if result.Applied != 2 || result.Failed != 2 || len(result.Rows) != 4 {
+		t.Fatalf("result = %d applied, %d failed, %d rows", result.Applied, result.Failed, len(result.Rows))
+	}
+
+	first := result.Rows[0]
+	if first.Row != 1 || first.Outcome != Helpful || first.Current <= first.Previous || !first.Noted {
+		t.Errorf("row 1 = %+v", first)
+	}
+	if result.Rows[1].Outcome != NotRelevant || result.Rows[1].Noted {
+		t.Errorf("row 2 = %+v", result.Rows[1])
+	}
+	if !strings.Contains(result.Rows[2].Error, "invalid outcome") {
+		t.Errorf("row 3 error = %q, want invalid outcome", result.Rows[2].Error)
+	}
+	if result.Rows[3].Error == "" {
+		t.Error("row 4 should fail for an unknown ID")
+	}
+
+	notes, err := client.Notes(entries[0].ID)
+	if err != nil {
+		t.Fatalf("Notes: %v", err)
+	}
+	if len(notes) != 1 || notes[0].Note != "helpful: saved the deploy" {
+		t.Errorf("notes = %+v", notes)
+	}
+	got, _ := client.store.Get(entries[2].ID)
+	if got.Confidence != entries[2].Confidence {
+		t.Errorf("invalid row changed confidence to %.2f", got.Confidence)
+	}
+}
+
+func TestClient_FeedbackFromReader_DryRun(t *testing.T) {
+	client, entries := newFeedbackFileClient(t)
+
+	json := `[{"id_or_ref": "` + entries[0].ID + `", "outcome": "incorrect", "reason": "outdated"}]`
+	result, err := client.FeedbackFromReader(strings.NewReader(json), FeedbackFileOptions{Format: FeedbackFileJSON, DryRun: true})
+	if err != nil {
+		t.Fatalf("FeedbackFromReader: %v", err)
+	}
+	if !result.DryRun || result.Applied != 1 {
+		t.Fatalf("result = %+v", result)
+	}
+	row := result.Rows[0]
+	if row.Current != entries[0].Confidence+ConfidenceIncorrectDelta || !row.Noted {
+		t.Errorf("row = %+v, want the incorrect delta previewed", row)
+	}
+
+	got, _ := client.store.Get(entries[0].ID)
+	if got.Confidence != entries[0].Confidence {
+		t.Errorf("dry run changed confidence to %.2f", got.Confidence)
+	}
+	if notes, _ := client.Notes(entries[0].ID); len(notes) != 0 {
+		t.Errorf("dry run added notes: %+v", notes)
+	}
+}
+
+func TestParseFeedbackFile_Malformed(t *testing.T) {
+	if _, err := ParseFeedbackFile(strings.NewReader(`{"not": "an array"}`), FeedbackFileJSON); err == nil {
+		t.Error("JSON object should be rejected")
+	}
+	if _, err := ParseFeedbackFile(strings.NewReader("a,b\n"), "xml"); err == nil {
+		t.Error("unknown format should be rejected")
+	}
+}
@@ -0,0 +1,139 @@
+package recall
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// SourceFeedback counts the feedback given, within a report's window, on
+// lore recorded by one source.
+type SourceFeedback struct {
+	SourceID    string `json:"source_id"`
+	Entries     int    `json:"entries"` // Distinct entries that received feedback
+	Helpful     int    `json:"helpful"`
+	Incorrect   int    `json:"incorrect"`
+	Outdated    int    `json:"outdated"`
+	NotRelevant int    `json:"not_relevant"`
+	Total       int    `json:"total"`
+	// HelpfulRate is helpful over helpful, incorrect and outdated feedback,
+	// or 0 if there was none. NotRelevant says nothing about correctness
+	// and is left out.
+	HelpfulRate float64 `json:"helpful_rate"`
+}
+
+// SourceReport groups feedback outcomes by the source that recorded the
+// lore, most feedback first.
+type SourceReport struct {
+	Window  time.Duration    `json:"window"`
+	Since   time.Time        `json:"since"`
+	Sources []SourceFeedback `json:"sources"`
+}
+
+// logFeedback records an outcome given on lore id by actor, under the
+// source that recorded the entry.
+func (s *Store) logFeedback(id string, ft FeedbackType, actor string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return ErrStoreClosed
+	}
+	return s.logFeedbackLocked(id, ft, actor)
+}
+
+// logFeedbackLocked is logFeedback for callers holding s.mu.
+func (s *Store) logFeedbackLocked(id string, ft FeedbackType, actor string) error {
+	return s.logFeedbackTo(s.db, id, ft, actor)
+}
+
+// execer is satisfied by *sql.DB and *sql.Tx.
+type execer interface {
+	Exec(query string, args ...any) (sql.Result, error)
+}
+
+// logFeedbackTo logs feedback through e, which may be a transaction.
+func (s *Store) logFeedbackTo(e execer, id string, ft FeedbackType, actor string) error {
+	_, err := e.Exec(`
+		INSERT INTO feedback_log (namespace, lore_id, source_id, outcome, actor, created_at)
+		SELECT namespace, id, source_id, ?, ?, ? FROM lore_entries WHERE id = ? AND namespace = ?
+	`, string(ft), actor, s.now().UTC().Format(time.RFC3339), id, s.namespace)
+	if err != nil {
+		return fmt.Errorf("store: log feedback: %w", err)
+	}
+	return nil
+}
+
+// sourceFeedbackSince counts logged feedback since start, by source.
+func (s *Store) sourceFeedbackSince(start time.Time) ([]SourceFeedback, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil, ErrStoreClosed
+	}
+
+	rows, err := s.db.Query(`
+		SELECT source_id, COUNT(DISTINCT lore_id),
+		       SUM(outcome = ?), SUM(outcome = ?), SUM(outcome = ?), SUM(outcome = ?), COUNT(*)
+		FROM feedback_log
+		WHERE namespace = ? AND created_at >= ?
+		GROUP BY source_id
+	`, string(FeedbackHelpful), string(FeedbackIncorrect), string(FeedbackOutdated), string(FeedbackNotRelevant),
+		s.namespace, start.UTC().Format(time.RFC3339))
+	if err != nil {
+		return nil, fmt.Errorf("store: source feedback: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var sources []SourceFeedback
+	for rows.Next() {
+		var f SourceFeedback
+		if err := rows.Scan(&f.SourceID, &f.Entries, &f.Helpful, &f.Incorrect, &f.Outdated, &f.NotRelevant, &f.Total); err != nil {
+			return nil, fmt.Errorf("store: scan source feedback: %w", err)
+		}
+		sources = append(sources, f)
+	}
+	return sources, rows.Err()
+}
+
+// SourceReport summarizes the feedback given through this client's store in
+// the last window by the source_id of the lore it was given on, so leads can
+// see which agents record helpful lore and which record incorrect lore, and
+// weigh their trust accordingly. Feedback is counted from Feedback and
+// FeedbackBatch; feedback skipped by Config.FeedbackWindow is not counted.
+func (c *Client) SourceReport(ctx context.Context, window time.Duration) (*SourceReport, error) {
+	if window <= 0 {
+		return nil, &ValidationError{Field: "Window", Message: "must be positive"}
+	}
+	release, err := c.store.enter(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	report := &SourceReport{
+		Window:  window,
+		Since:   c.store.now().Add(-window),
+		Sources: []SourceFeedback{},
+	}
+	sources, err := c.store.sourceFeedbackSince(report.Since)
+	if err != nil {
+		return nil, fmt.Errorf("client: source report: %w", err)
+	}
+	for _, f := range sources {
+		if judged := f.Helpful + f.Incorrect + f.Outdated; judged > 0 {
+			f.HelpfulRate = float64(f.Helpful) / float64(judged)
+		}
+		report.Sources = append(report.Sources, f)
+	}
+	sort.SliceStable(report.Sources, func(i, j int) bool {
+		if report.Sources[i].Total != report.Sources[j].Total {
+			return report.Sources[i].Total > report.Sources[j].Total
+		}
+		return report.Sources[i].SourceID < report.Sources[j].SourceID
+	})
+	return report, nil
+}
@@ -0,0 +1,62 @@
+package recall
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestClient_SourceReport(t *testing.T) {
+	client := newTestClient(t, Config{SourceID: "test", Actor: "lead"})
+
+	now := time.Now().UTC()
+	for _, l := range []*Lore{
+		{ID: "a1", SourceID: "agent-a"},
+		{ID: "a2", SourceID: "agent-a"},
+		{ID: "b1", SourceID: "agent-b"},
+	} {
+		l.Content, l.Category, l.Confidence, l.CreatedAt, l.UpdatedAt = "entry "+l.ID, CategoryPatternOutcome, 0.5, now, now
+		if err := client.store.InsertLore(l); err != nil {
+			t.Fatalf("InsertLore() error = %v", err)
+		}
+	}
+	for _, fb := range []struct {
+		id string
+		ft FeedbackType
+	}{
+		{"a1", Helpful}, {"a1", Helpful}, {"a2", Incorrect}, {"a2", NotRelevant}, {"b1", Outdated},
+	} {
+		if _, err := client.Feedback(fb.id, fb.ft); err != nil {
+			t.Fatalf("Feedback(%s, %s) error = %v", fb.id, fb.ft, err)
+		}
+	}
+
+	report, err := client.SourceReport(context.Background(), time.Hour)
+	if err != nil {
+		t.Fatalf("SourceReport() error = %v", err)
+	}
+	if len(report.Sources) != 2 {
+		t.Fatalf("Sources = %+v, want 2", report.Sources)
+	}
+	a, b := report.Sources[0], report.Sources[1]
+	if a.SourceID != "agent-a" || a.Entries != 2 || a.Helpful != 2 || a.Incorrect != 1 || a.NotRelevant != 1 || a.Total != 4 {
+		t.Errorf("Sources[0] = %+v", a)
+	}
+	if want := 2.0 / 3.0; a.HelpfulRate != want {
+		t.Errorf("agent-a HelpfulRate = %v, want %v", a.HelpfulRate, want)
+	}
+	if b.SourceID != "agent-b" || b.Outdated != 1 || b.Total != 1 || b.HelpfulRate != 0 {
+		t.Errorf("Sources[1] = %+v", b)
+	}
+
+	client.store.SetClock(ClockFunc(func() time.Time { return now.Add(2 * time.Hour) }))
+	if later, _ := client.SourceReport(context.Background(), time.Hour); len(later.Sources) != 0 {
+		t.Errorf("SourceReport() outside the window = %+v, want empty", later.Sources)
+	}
+
+	var verr *ValidationError
+	if _, err := client.SourceReport(context.Background(), 0); !errors.As(err, &verr) {
+		t.Errorf("SourceReport(0) error = %v, want a ValidationError", err)
+	}
+}
@@ -0,0 +1,105 @@
+package recall
+
+import (
+	"fmt"
+	"time"
+)
+
+// feedbackDedup identifies who gives batch feedback and the window
+// repeats of it are skipped within.
+type feedbackDedup struct {
+	actor  string
+	window time.Duration
+}
+
+// claimFeedback records that actor gave loreID feedback of type ft, unless
+// it already did within window. It returns the event's ID, or 0 when the
+// feedback is a repeat and should be skipped.
+func (s *Store) claimFeedback(loreID, actor string, ft FeedbackType, window time.Duration) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return 0, ErrStoreClosed
+	}
+	return s.claimFeedbackLocked(loreID, actor, ft, window)
+}
+
+// claimFeedbackLocked is claimFeedback for callers holding s.mu. Events
+// older than window are pruned first.
+func (s *Store) claimFeedbackLocked(loreID, actor string, ft FeedbackType, window time.Duration) (int64, error) {
+	now := s.now()
+	cutoff := now.Add(-window).Format(time.RFC3339)
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("store: begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.Exec(`DELETE FROM feedback_events WHERE created_at < ?`, cutoff); err != nil {
+		return 0, fmt.Errorf("store: prune feedback events: %w", err)
+	}
+	var seen int
+	if err := tx.QueryRow(`
+		SELECT COUNT(*) FROM feedback_events
+		WHERE lore_id = ? AND actor = ? AND outcome = ? AND created_at >= ?
+	`, loreID, actor, string(ft), cutoff).Scan(&seen); err != nil {
+		return 0, fmt.Errorf("store: check feedback events: %w", err)
+	}
+	if seen > 0 {
+		return 0, nil
+	}
+	res, err := tx.Exec(`
+		INSERT INTO feedback_events (lore_id, actor, outcome, created_at) VALUES (?, ?, ?, ?)
+	`, loreID, actor, string(ft), now.Format(time.RFC3339))
+	if err != nil {
+		return 0, fmt.Errorf("store: record feedback event: %w", err)
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("store: record feedback event: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("store: commit: %w", err)
+	}
+	return id, nil
+}
+
+// releaseFeedback deletes a claimed event whose feedback was not applied,
+// so a retry is not skipped.
+func (s *Store) releaseFeedback(id int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return
+	}
+	_, _ = s.db.Exec(`DELETE FROM feedback_events WHERE id = ?`, id)
+}
+
+// claimFeedback claims feedback on loreID for the client's actor under
+// Config.FeedbackWindow. It returns the event to release if applying the
+// feedback fails (0 without a window), or ErrFeedbackSkipped for a repeat.
+func (c *Client) claimFeedback(loreID string, ft FeedbackType) (int64, error) {
+	if c.config.FeedbackWindow <= 0 {
+		return 0, nil
+	}
+	id, err := c.store.claimFeedback(loreID, c.actor(), ft, c.config.FeedbackWindow)
+	if err != nil {
+		return 0, err
+	}
+	if id == 0 {
+		return 0, ErrFeedbackSkipped
+	}
+	return id, nil
+}
+
+// feedbackDedup returns the dedup settings for batch feedback, or nil
+// without a Config.FeedbackWindow.
+func (c *Client) feedbackDedup() *feedbackDedup {
+	if c.config.FeedbackWindow <= 0 {
+		return nil
+	}
+	return &feedbackDedup{actor: c.actor(), window: c.config.FeedbackWindow}
+}
@@ -0,0 +1,91 @@
+package recall
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClient_FeedbackWindow(t *testing.T) {
+	client := newTestClient(t, Config{Actor: "alice", FeedbackWindow: time.Hour})
+	lore, err := client.Record("Retry with backoff", CategoryPatternOutcome)
+	if err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+
+	if _, err := client.Feedback(lore.ID, Helpful); err != nil {
+		t.Fatalf("first Feedback: %v", err)
+	}
+	if _, err := client.Feedback(lore.ID, Helpful); !errors.Is(err, ErrFeedbackSkipped) {
+		t.Fatalf("repeated Feedback: err = %v, want ErrFeedbackSkipped", err)
+	}
+	got, _ := client.store.Get(lore.ID)
+	if got.ValidationCount != 1 {
+		t.Errorf("ValidationCount = %d, want 1", got.ValidationCount)
+	}
+
+	// Another outcome, or another actor, is not a repeat.
+	if _, err := client.Feedback(lore.ID, Incorrect); err != nil {
+		t.Errorf("Incorrect after Helpful: %v", err)
+	}
+	client.config.Actor = "bob"
+	if _, err := client.Feedback(lore.ID, Helpful); err != nil {
+		t.Errorf("Helpful from another actor: %v", err)
+	}
+
+	// Once the window has passed, the same feedback applies again.
+	client.config.Actor = "alice"
+	if _, err := client.store.db.Exec(`UPDATE feedback_events SET created_at = ?`, time.Now().Add(-2*time.Hour).UTC().Format(time.RFC3339)); err != nil {
+		t.Fatalf("age feedback events: %v", err)
+	}
+	if _, err := client.Feedback(lore.ID, Helpful); err != nil {
+		t.Errorf("Helpful after the window: %v", err)
+	}
+	var events int
+	_ = client.store.db.QueryRow(`SELECT COUNT(*) FROM feedback_events`).Scan(&events)
+	if events != 1 {
+		t.Errorf("feedback_events = %d rows, want aged-out events pruned", events)
+	}
+
+	// Failed feedback does not count as given.
+	if _, err := client.Feedback("01MISSING0000000000000000", Helpful); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("missing lore: err = %v, want ErrNotFound", err)
+	}
+	_ = client.store.db.QueryRow(`SELECT COUNT(*) FROM feedback_events WHERE lore_id = '01MISSING0000000000000000'`).Scan(&events)
+	if events != 0 {
+		t.Errorf("failed feedback left %d events", events)
+	}
+}
+
+func TestClient_FeedbackWindow_BatchAndFile(t *testing.T) {
+	client, entries := newFeedbackFileClient(t)
+	client.config.FeedbackWindow = time.Hour
+	if _, err := client.Query(context.Background(), QueryParams{Query: "backoff"}); err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+
+	if _, err := client.Feedback(entries[0].ID, Helpful); err != nil {
+		t.Fatalf("Feedback: %v", err)
+	}
+	result, err := client.FeedbackBatch(context.Background(), FeedbackParams{Helpful: []string{"Retry with backoff", "Pin the base image"}})
+	if err != nil {
+		t.Fatalf("FeedbackBatch: %v", err)
+	}
+	if len(result.Updated) != 1 || result.Updated[0].ID != entries[1].ID {
+		t.Errorf("Updated = %+v, want only %s", result.Updated, entries[1].ID)
+	}
+	if len(result.Skipped) != 1 || result.Skipped[0] != "Retry with backoff" {
+		t.Errorf("Skipped = %v", result.Skipped)
+	}
+
+	csv := entries[1].ID + ",helpful\n" + entries[2].ID + ",helpful\n"
+	file, err := client.FeedbackFromReader(strings.NewReader(csv), FeedbackFileOptions{})
+	if err != nil {
+		t.Fatalf("FeedbackFromReader: %v", err)
+	}
+	if file.Applied != 1 || file.Skipped != 1 || file.Failed != 0 || !file.Rows[0].Skipped {
+		t.Errorf("file result = %+v", file)
+	}
+}
@@ -0,0 +1,111 @@
+package recall
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// Fingerprint returns the deduplication fingerprint of lore content: a
+// SHA-256 of its lowercased words joined by single spaces, so differences in
+// case, whitespace and punctuation do not change it.
+func Fingerprint(content string) string {
+	words := strings.FieldsFunc(strings.ToLower(content), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	sum := sha256.Sum256([]byte(strings.Join(words, " ")))
+	return hex.EncodeToString(sum[:])
+}
+
+// findByFingerprintTx returns the oldest live entry in the store's namespace
+// with the given fingerprint, or ErrNotFound.
+func (s *Store) findByFingerprintTx(tx *sql.Tx, fingerprint string) (*Lore, error) {
+	var id string
+	err := tx.QueryRow(`
+		SELECT id FROM lore_entries
+		WHERE namespace = ? AND fingerprint = ? AND deleted_at IS NULL
+		ORDER BY created_at, id
+		LIMIT 1
+	`, s.namespace, fingerprint).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("store: find by fingerprint: %w", err)
+	}
+	return s.getLoreTx(tx, id)
+}
+
+// findFingerprintClaim returns the live entry in the store's namespace
+// that claimed fingerprint as a deduplicating Record.
+func (s *Store) findFingerprintClaim(fingerprint string) (*Lore, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("store: begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var id string
+	err = tx.QueryRow(`
+		SELECT id FROM lore_entries
+		WHERE namespace = ? AND dedup_fingerprint = ? AND deleted_at IS NULL
+	`, s.namespace, fingerprint).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("store: find fingerprint claim: %w", err)
+	}
+	return s.getLoreTx(tx, id)
+}
+
+// isUniqueViolation reports whether err is a UNIQUE constraint failure.
+func isUniqueViolation(err error) bool {
+	var se *sqlite.Error
+	return errors.As(err, &se) && se.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE
+}
+
+// backfillFingerprints computes fingerprints for rows written before
+// migration 008.
+func (s *Store) backfillFingerprints() error {
+	rows, err := s.db.Query(`SELECT id, content FROM lore_entries WHERE fingerprint = ''`)
+	if err != nil {
+		return fmt.Errorf("store: backfill fingerprints: %w", err)
+	}
+	fingerprints := make(map[string]string)
+	for rows.Next() {
+		var id, content string
+		if err := rows.Scan(&id, &content); err != nil {
+			rows.Close()
+			return fmt.Errorf("store: backfill fingerprints: %w", err)
+		}
+		fingerprints[id] = Fingerprint(content)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("store: backfill fingerprints: %w", err)
+	}
+	if len(fingerprints) == 0 {
+		return nil
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("store: begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	for id, fp := range fingerprints {
+		if _, err := tx.Exec(`UPDATE lore_entries SET fingerprint = ? WHERE id = ?`, fp, id); err != nil {
+			return fmt.Errorf("store: backfill fingerprints: %w", err)
+		}
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,140 @@
+package recall
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+)
+
+func TestFingerprint_Normalizes(t *testing.T) {
+	base := Fingerprint("Use connection pooling for Postgres.")
+	for _, variant := range []string{
+		"use connection pooling for postgres",
+		"  Use   connection\tpooling, for Postgres!",
+		"USE CONNECTION-POOLING FOR POSTGRES",
+	} {
+		if got := Fingerprint(variant); got != base {
+			t.Errorf("Fingerprint(%q) differs from the canonical form", variant)
+		}
+	}
+	if Fingerprint("Use connection pooling for MySQL") == base {
+		t.Error("different content should have a different fingerprint")
+	}
+}
+
+func TestClient_Record_DedupExact(t *testing.T) {
+	client := newTestClient(t, Config{DedupExact: true})
+
+	first, err := client.Record("Retry webhooks with idempotency keys", CategoryPatternOutcome)
+	if err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+	if first.Merged {
+		t.Error("first record should not be Merged")
+	}
+
+	again, err := client.Record("retry webhooks with idempotency keys.", CategoryPatternOutcome)
+	if err != nil {
+		t.Fatalf("Record duplicate: %v", err)
+	}
+	if !again.Merged || again.ID != first.ID {
+		t.Errorf("duplicate = {ID: %s, Merged: %v}, want existing %s merged", again.ID, again.Merged, first.ID)
+	}
+
+	stats, err := client.store.Stats()
+	if err != nil {
+		t.Fatalf("Stats: %v", err)
+	}
+	if stats.LoreCount != 1 {
+		t.Errorf("LoreCount = %d, want 1", stats.LoreCount)
+	}
+
+	// Other namespaces are independent.
+	client.store.SetNamespace("team-b")
+	other, err := client.Record("Retry webhooks with idempotency keys", CategoryPatternOutcome)
+	if err != nil {
+		t.Fatalf("Record in namespace: %v", err)
+	}
+	if other.Merged {
+		t.Error("record in another namespace should not merge")
+	}
+}
+
+func TestClient_Record_DedupExact_UniqueClaim(t *testing.T) {
+	client := newTestClient(t, Config{DedupExact: true})
+
+	first, err := client.Record("Pin the base image", CategoryDependencyBehavior)
+	if err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+	// Hide the entry from the lookup, as if another writer committed it
+	// after this one looked: the unique claim still catches it.
+	if _, err := client.store.db.Exec(`UPDATE lore_entries SET fingerprint = '' WHERE id = ?`, first.ID); err != nil {
+		t.Fatal(err)
+	}
+	again, err := client.Record("Pin the base image", CategoryDependencyBehavior)
+	if err != nil {
+		t.Fatalf("Record racing duplicate: %v", err)
+	}
+	if !again.Merged || again.ID != first.ID {
+		t.Errorf("racing duplicate = {ID: %s, Merged: %v}, want existing %s merged", again.ID, again.Merged, first.ID)
+	}
+
+	// Deleting the entry releases its claim.
+	if err := client.store.DeleteLoreByID(first.ID); err != nil {
+		t.Fatalf("DeleteLoreByID: %v", err)
+	}
+	var claim sql.NullString
+	if err := client.store.db.QueryRow(`SELECT dedup_fingerprint FROM lore_entries WHERE id = ?`, first.ID).Scan(&claim); err != nil || claim.Valid {
+		t.Errorf("claim after delete = %v, %v; want released", claim, err)
+	}
+	fresh, err := client.Record("Pin the base image", CategoryDependencyBehavior)
+	if err != nil {
+		t.Fatalf("Record after delete: %v", err)
+	}
+	if fresh.Merged || fresh.ID == first.ID {
+		t.Errorf("record after delete = {ID: %s, Merged: %v}, want a new entry", fresh.ID, fresh.Merged)
+	}
+}
+
+func TestClient_Record_DedupDisabled(t *testing.T) {
+	client := newTestClient(t, Config{})
+
+	a, _ := client.Record("Same content", CategoryPatternOutcome)
+	b, err := client.Record("Same content", CategoryPatternOutcome)
+	if err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+	if b.Merged || a.ID == b.ID {
+		t.Error("without DedupExact, re-records should insert new entries")
+	}
+}
+
+func TestStore_BackfillFingerprints(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lore.db")
+	s, err := NewStore(path)
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	if _, err := s.Record(Lore{Content: "Legacy entry", Category: CategoryPatternOutcome, Confidence: 0.5}); err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+	if _, err := s.db.Exec(`UPDATE lore_entries SET fingerprint = ''`); err != nil {
+		t.Fatalf("clear fingerprints: %v", err)
+	}
+	s.Close()
+
+	s, err = NewStore(path)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	defer s.Close()
+
+	var fp string
+	if err := s.db.QueryRow(`SELECT fingerprint FROM lore_entries`).Scan(&fp); err != nil {
+		t.Fatalf("read fingerprint: %v", err)
+	}
+	if fp != Fingerprint("Legacy entry") {
+		t.Errorf("fingerprint = %q, want backfilled value", fp)
+	}
+}
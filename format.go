@@ -0,0 +1,206 @@
+package recall
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// ConfidenceBucket is a coarse confidence label for compact output.
+type ConfidenceBucket string
+
+const (
+	ConfidenceHigh ConfidenceBucket = "high"
+	ConfidenceMed  ConfidenceBucket = "med"
+	ConfidenceLow  ConfidenceBucket = "low"
+)
+
+// Confidence bucket thresholds (inclusive lower bounds).
+const (
+	ConfidenceHighThreshold = 0.7
+	ConfidenceMedThreshold  = 0.4
+)
+
+// BucketConfidence maps a confidence score to high (>= 0.7), med (>= 0.4) or low.
+func BucketConfidence(c float64) ConfidenceBucket {
+	switch {
+	case c >= ConfidenceHighThreshold:
+		return ConfidenceHigh
+	case c >= ConfidenceMedThreshold:
+		return ConfidenceMed
+	default:
+		return ConfidenceLow
+	}
+}
+
+// defaultMinSharedPrefixWords is the shortest shared prefix, in words, worth
+// factoring out when DedupPrefixes is enabled.
+const defaultMinSharedPrefixWords = 3
+
+// FormatOptions controls compact rendering of query results for prompt injection.
+type FormatOptions struct {
+	// MaxContentLength truncates content to this many characters, ending
+	// with an ellipsis. Zero disables truncation.
+	MaxContentLength int
+
+	// DedupPrefixes groups entries that start with the same words and emits
+	// the shared prefix once.
+	DedupPrefixes bool
+
+	// MinSharedPrefixWords is the shortest prefix factored out by
+	// DedupPrefixes. Defaults to 3.
+	MinSharedPrefixWords int
+
+	// IncludeCategory adds the lore category to each entry.
+	IncludeCategory bool
+}
+
+// CompactLore is a reduced view of a lore entry for prompt injection.
+type CompactLore struct {
+	Ref        string           `json:"ref"`
+	ID         string           `json:"id"`
+	Category   Category         `json:"category,omitempty"`
+	Content    string           `json:"content"`
+	Confidence ConfidenceBucket `json:"confidence"`
+}
+
+// CompactGroup is a set of entries sharing a content prefix. Prefix is empty
+// for entries that share nothing with their neighbours.
+type CompactGroup struct {
+	Prefix string        `json:"prefix,omitempty"`
+	Items  []CompactLore `json:"items"`
+}
+
+// CompactResult converts a QueryResult into compact groups, preserving result
+// order. Grouped entries have the shared prefix removed from their content.
+func CompactResult(result *QueryResult, opts FormatOptions) []CompactGroup {
+	if result == nil || len(result.Lore) == 0 {
+		return nil
+	}
+
+	idToRef := make(map[string]string, len(result.SessionRefs))
+	for ref, id := range result.SessionRefs {
+		idToRef[id] = ref
+	}
+
+	minWords := opts.MinSharedPrefixWords
+	if minWords <= 0 {
+		minWords = defaultMinSharedPrefixWords
+	}
+
+	words := make([][]string, len(result.Lore))
+	for i, l := range result.Lore {
+		words[i] = strings.Fields(l.Content)
+	}
+
+	grouped := make([]bool, len(result.Lore))
+	var groups []CompactGroup
+	for i := range result.Lore {
+		if grouped[i] {
+			continue
+		}
+		grouped[i] = true
+		members := []int{i}
+		prefixLen := 0
+
+		if opts.DedupPrefixes {
+			prefixLen = len(words[i])
+			for j := i + 1; j < len(result.Lore); j++ {
+				if grouped[j] {
+					continue
+				}
+				// Every member keeps at least one word of its own.
+				n := min(commonWordPrefix(words[i][:prefixLen], words[j]), len(words[i])-1, len(words[j])-1)
+				if n < minWords {
+					continue
+				}
+				prefixLen = n
+				members = append(members, j)
+				grouped[j] = true
+			}
+			if len(members) == 1 {
+				prefixLen = 0
+			}
+		}
+
+		group := CompactGroup{Prefix: strings.Join(words[i][:prefixLen], " ")}
+		for _, idx := range members {
+			l := result.Lore[idx]
+			content := l.Content
+			if prefixLen > 0 {
+				content = strings.Join(words[idx][prefixLen:], " ")
+			}
+			item := CompactLore{
+				Ref:        idToRef[l.ID],
+				ID:         l.ID,
+				Content:    TruncateContent(content, opts.MaxContentLength),
+				Confidence: BucketConfidence(l.Confidence),
+			}
+			if item.Ref == "" {
+				item.Ref = shortLoreID(l.ID)
+			}
+			if opts.IncludeCategory {
+				item.Category = l.Category
+			}
+			group.Items = append(group.Items, item)
+		}
+		groups = append(groups, group)
+	}
+	return groups
+}
+
+// FormatBullets renders a QueryResult as a compact bulleted list. Each entry
+// is tagged [ref|confidence], e.g. "- [L1|high] Use idempotency keys". With
+// DedupPrefixes, a shared prefix becomes its own bullet ending in an ellipsis
+// and the grouped entries are nested beneath it.
+func FormatBullets(result *QueryResult, opts FormatOptions) string {
+	var sb strings.Builder
+	for _, g := range CompactResult(result, opts) {
+		indent := ""
+		if g.Prefix != "" {
+			sb.WriteString("- " + g.Prefix + "…\n")
+			indent = "  "
+		}
+		for _, item := range g.Items {
+			sb.WriteString(indent + "- " + formatCompactTag(item) + " " + item.Content + "\n")
+		}
+	}
+	return sb.String()
+}
+
+// TruncateContent shortens s to at most max characters, replacing the tail
+// with an ellipsis. max <= 0 returns s unchanged.
+func TruncateContent(s string, max int) string {
+	if max <= 0 || utf8.RuneCountInString(s) <= max {
+		return s
+	}
+	if max == 1 {
+		return "…"
+	}
+	runes := []rune(s)
+	return strings.TrimRight(string(runes[:max-1]), " ") + "…"
+}
+
+func formatCompactTag(item CompactLore) string {
+	if item.Category != "" {
+		return fmt.Sprintf("[%s|%s|%s]", item.Ref, item.Confidence, item.Category)
+	}
+	return fmt.Sprintf("[%s|%s]", item.Ref, item.Confidence)
+}
+
+// commonWordPrefix returns the number of leading words a and b share.
+func commonWordPrefix(a, b []string) int {
+	n := 0
+	for n < len(a) && n < len(b) && a[n] == b[n] {
+		n++
+	}
+	return n
+}
+
+// shortLoreID returns the first 8 characters of a lore ID.
+func shortLoreID(id string) string {
+	if len(id) > 8 {
+		return id[:8]
+	}
+	return id
+}
@@ -0,0 +1,105 @@
+package recall
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBucketConfidence(t *testing.T) {
+	tests := []struct {
+		confidence float64
+		want       ConfidenceBucket
+	}{
+		{1.0, ConfidenceHigh},
+		{0.7, ConfidenceHigh},
+		{0.69, ConfidenceMed},
+		{0.4, ConfidenceMed},
+		{0.39, ConfidenceLow},
+		{0.0, ConfidenceLow},
+	}
+	for _, tt := range tests {
+		if got := BucketConfidence(tt.confidence); got != tt.want {
+			t.Errorf("BucketConfidence(%v) = %q, want %q", tt.confidence, got, tt.want)
+		}
+	}
+}
+
+func TestTruncateContent(t *testing.T) {
+	tests := []struct {
+		in   string
+		max  int
+		want string
+	}{
+		{"short", 0, "short"},
+		{"short", 10, "short"},
+		{"exactly ten", 11, "exactly ten"},
+		{"truncate this sentence", 10, "truncate…"},
+		{"héllo wörld", 6, "héllo…"},
+		{"abc", 1, "…"},
+	}
+	for _, tt := range tests {
+		if got := TruncateContent(tt.in, tt.max); got != tt.want {
+			t.Errorf("TruncateContent(%q, %d) = %q, want %q", tt.in, tt.max, got, tt.want)
+		}
+	}
+}
+
+func compactTestResult() *QueryResult {
+	return &QueryResult{
+		Lore: []Lore{
+			{ID: "01AAAAAAAAAAAAAAAAAAAAAAAA", Content: "When retrying HTTP calls add jitter to the backoff", Category: CategoryPatternOutcome, Confidence: 0.5},
+			{ID: "01BBBBBBBBBBBBBBBBBBBBBBBB", Content: "Use idempotency keys for queue consumers", Category: CategoryInterfaceLesson, Confidence: 0.9},
+			{ID: "01CCCCCCCCCCCCCCCCCCCCCCCC", Content: "When retrying HTTP calls cap total attempts", Category: CategoryPatternOutcome, Confidence: 0.2},
+		},
+		SessionRefs: map[string]string{
+			"L1": "01AAAAAAAAAAAAAAAAAAAAAAAA",
+			"L2": "01BBBBBBBBBBBBBBBBBBBBBBBB",
+		},
+	}
+}
+
+func TestFormatBullets_Plain(t *testing.T) {
+	got := FormatBullets(compactTestResult(), FormatOptions{})
+	want := "- [L1|med] When retrying HTTP calls add jitter to the backoff\n" +
+		"- [L2|high] Use idempotency keys for queue consumers\n" +
+		"- [01CCCCCC|low] When retrying HTTP calls cap total attempts\n"
+	if got != want {
+		t.Errorf("FormatBullets =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestFormatBullets_DedupAndTruncate(t *testing.T) {
+	got := FormatBullets(compactTestResult(), FormatOptions{DedupPrefixes: true, MaxContentLength: 12, IncludeCategory: true})
+	want := "- When retrying HTTP calls…\n" +
+		"  - [L1|med|PATTERN_OUTCOME] add jitter…\n" +
+		"  - [01CCCCCC|low|PATTERN_OUTCOME] cap total a…\n" +
+		"- [L2|high|INTERFACE_LESSON] Use idempot…\n"
+	if got != want {
+		t.Errorf("FormatBullets =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestCompactResult_PrefixKeepsAWordPerEntry(t *testing.T) {
+	result := &QueryResult{Lore: []Lore{
+		{ID: "a", Content: "Always pin dependency versions", Confidence: 0.5},
+		{ID: "b", Content: "Always pin dependency versions in CI", Confidence: 0.5},
+	}}
+	groups := CompactResult(result, FormatOptions{DedupPrefixes: true})
+	if len(groups) != 1 || groups[0].Prefix != "Always pin dependency" {
+		t.Fatalf("groups = %+v", groups)
+	}
+	for _, item := range groups[0].Items {
+		if strings.TrimSpace(item.Content) == "" {
+			t.Errorf("item %s lost all content", item.ID)
+		}
+	}
+}
+
+func TestCompactResult_Empty(t *testing.T) {
+	if groups := CompactResult(&QueryResult{}, FormatOptions{}); groups != nil {
+		t.Errorf("groups = %+v, want nil", groups)
+	}
+	if got := FormatBullets(nil, FormatOptions{}); got != "" {
+		t.Errorf("FormatBullets(nil) = %q, want empty", got)
+	}
+}
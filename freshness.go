@@ -0,0 +1,87 @@
+package recall
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Freshness says whether Engram, and so every other client, has a lore
+// entry as this store holds it. Query sets it on each returned entry.
+type Freshness string
+
+const (
+	// FreshnessSynced means the entry has no local changes waiting to be
+	// pushed.
+	FreshnessSynced Freshness = "synced"
+	// FreshnessUnpushed means the entry, or a change to it, exists only in
+	// this store until the next push.
+	FreshnessUnpushed Freshness = "unpushed"
+)
+
+// unpushedLoreCondition matches lore_entries rows with a change_log entry
+// from this store after last_push_seq, or a legacy sync_queue INSERT.
+const unpushedLoreCondition = `(
+	EXISTS (
+		SELECT 1 FROM change_log c
+		WHERE c.table_name = 'lore_entries' AND c.entity_id = lore_entries.id
+		  AND c.source_id = (SELECT value FROM sync_meta WHERE key = 'source_id')
+		  AND c.sequence > COALESCE((SELECT CAST(value AS INTEGER) FROM sync_meta WHERE key = 'last_push_seq'), 0)
+	)
+	OR EXISTS (SELECT 1 FROM sync_queue q WHERE q.lore_id = lore_entries.id AND q.operation = 'INSERT')
+)`
+
+// unpushedLoreIDs returns which of ids have local changes Engram does not
+// have yet.
+func (s *Store) unpushedLoreIDs(ids []string) (map[string]bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil, ErrStoreClosed
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	placeholders := make([]string, len(ids))
+	args := make([]any, len(ids))
+	for i, id := range ids {
+		placeholders[i] = "?"
+		args[i] = id
+	}
+	rows, err := s.db.Query(fmt.Sprintf(`SELECT id FROM lore_entries WHERE id IN (%s) AND %s`,
+		strings.Join(placeholders, ","), unpushedLoreCondition), args...)
+	if err != nil {
+		return nil, fmt.Errorf("store: unpushed lore: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	unpushed := make(map[string]bool)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("store: scan unpushed lore: %w", err)
+		}
+		unpushed[id] = true
+	}
+	return unpushed, rows.Err()
+}
+
+// setFreshness sets Freshness on each entry.
+func (c *Client) setFreshness(lore []Lore) error {
+	ids := make([]string, len(lore))
+	for i, l := range lore {
+		ids[i] = l.ID
+	}
+	unpushed, err := c.store.unpushedLoreIDs(ids)
+	if err != nil {
+		return err
+	}
+	for i := range lore {
+		lore[i].Freshness = FreshnessSynced
+		if unpushed[lore[i].ID] {
+			lore[i].Freshness = FreshnessUnpushed
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package recall
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestClient_Query_Freshness(t *testing.T) {
+	client := newTestClient(t, Config{})
+
+	local, err := client.Record("Recorded here, not pushed yet", CategoryPatternOutcome)
+	if err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+	now := time.Now().UTC()
+	remote := &Lore{ID: "remote", Content: "Received from Engram", Category: CategoryPatternOutcome, Confidence: 0.7, CreatedAt: now, UpdatedAt: now}
+	if err := client.store.UpsertLore(remote); err != nil {
+		t.Fatalf("UpsertLore: %v", err)
+	}
+
+	freshness := func(params QueryParams) map[string]Lore {
+		t.Helper()
+		params.Query = "q"
+		result, err := client.Query(context.Background(), params)
+		if err != nil {
+			t.Fatalf("Query: %v", err)
+		}
+		got := make(map[string]Lore)
+		for _, l := range result.Lore {
+			got[l.ID] = l
+		}
+		return got
+	}
+
+	got := freshness(QueryParams{})
+	if got[local.ID].Freshness != FreshnessUnpushed || got[local.ID].EmbeddingStatus != "pending" || got[local.ID].SyncedAt != nil {
+		t.Errorf("local entry = %+v, want unpushed with pending embedding", got[local.ID])
+	}
+	if got["remote"].Freshness != FreshnessSynced {
+		t.Errorf("remote entry freshness = %q, want synced", got["remote"].Freshness)
+	}
+	if got := freshness(QueryParams{OnlySynced: true}); len(got) != 1 || got["remote"].ID == "" {
+		t.Errorf("OnlySynced = %v, want only remote", got)
+	}
+
+	changes, err := client.store.UnpushedChanges(client.store.SourceID(), 0, 100)
+	if err != nil || len(changes) == 0 {
+		t.Fatalf("UnpushedChanges = %v, %v", changes, err)
+	}
+	if err := client.store.completePush(changes[len(changes)-1].Sequence); err != nil {
+		t.Fatalf("completePush: %v", err)
+	}
+
+	got = freshness(QueryParams{OnlySynced: true})
+	if got[local.ID].Freshness != FreshnessSynced || got[local.ID].SyncedAt == nil {
+		t.Errorf("after push: local entry = %+v, want synced with SyncedAt", got[local.ID])
+	}
+
+	// A later local change makes it unpushed again.
+	if _, err := client.Feedback(local.ID, Helpful); err != nil {
+		t.Fatalf("Feedback: %v", err)
+	}
+	if got := freshness(QueryParams{}); got[local.ID].Freshness != FreshnessUnpushed {
+		t.Errorf("after feedback: freshness = %q, want unpushed", got[local.ID].Freshness)
+	}
+}
@@ -0,0 +1,40 @@
+package recall
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// newTestClient opens a client on cfg, with a database in a temp dir unless
+// cfg.LocalPath is set, and closes it at cleanup.
+func newTestClient(t *testing.T, cfg Config) *Client {
+	t.Helper()
+	if cfg.LocalPath == "" {
+		cfg.LocalPath = filepath.Join(t.TempDir(), "lore.db")
+	}
+	client, err := New(cfg)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	t.Cleanup(func() { _ = client.Close() })
+	return client
+}
+
+// eventually retries fn until it succeeds or timeout passes, for state
+// applied asynchronously (e.g. background workers, or Engram generating a
+// snapshot).
+func eventually(t *testing.T, timeout time.Duration, fn func() error) {
+	t.Helper()
+	deadline := time.Now().Add(timeout)
+	for {
+		err := fn()
+		if err == nil {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("not satisfied after %s: %v", timeout, err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
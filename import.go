@@ -175,7 +175,7 @@ func (s *Store) importLoreArray(ctx context.Context, dec *json.Decoder, strategy
 // loreExistsUnlocked checks if a lore entry exists (caller must hold lock).
 func (s *Store) loreExistsUnlocked(id string) (bool, error) {
 	var count int
-	err := s.db.QueryRow("SELECT COUNT(*) FROM lore_entries WHERE id = ? AND deleted_at IS NULL", id).Scan(&count)
+	err := s.db.QueryRow("SELECT COUNT(*) FROM lore_entries WHERE id = ? AND deleted_at IS NULL AND namespace = ?", id, s.namespace).Scan(&count)
 	if err != nil {
 		return false, err
 	}
@@ -270,8 +270,8 @@ func (s *Store) insertLoreForImport(lore *Lore) error {
 
 	_, err := s.db.Exec(`
 		INSERT INTO lore_entries (id, content, context, category, confidence, embedding, embedding_status,
-		                 source_id, sources, validation_count, created_at, updated_at, synced_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		                 source_id, sources, validation_count, created_at, updated_at, synced_at, namespace, fingerprint, checksum)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`,
 		lore.ID,
 		lore.Content,
@@ -286,6 +286,9 @@ func (s *Store) insertLoreForImport(lore *Lore) error {
 		lore.CreatedAt.Format(time.RFC3339),
 		lore.UpdatedAt.Format(time.RFC3339),
 		p.syncedAtStr,
+		s.namespace,
+		Fingerprint(lore.Content),
+		loreChecksum(lore.ID, lore.Content, lore.Context, lore.Category),
 	)
 	return err
 }
@@ -308,6 +311,9 @@ func (s *Store) replaceLoreForImport(lore *Lore) error {
 			created_at = ?,
 			updated_at = ?,
 			synced_at = ?,
+			namespace = ?,
+			fingerprint = ?,
+			checksum = ?,
 			deleted_at = NULL
 		WHERE id = ?
 	`,
@@ -323,6 +329,9 @@ func (s *Store) replaceLoreForImport(lore *Lore) error {
 		lore.CreatedAt.Format(time.RFC3339),
 		lore.UpdatedAt.Format(time.RFC3339),
 		p.syncedAtStr,
+		s.namespace,
+		Fingerprint(lore.Content),
+		loreChecksum(lore.ID, lore.Content, lore.Context, lore.Category),
 		lore.ID,
 	)
 	return err
@@ -336,8 +345,8 @@ func (s *Store) mergeLoreForImport(lore *Lore) error {
 	// Upsert: insert or update
 	_, err := s.db.Exec(`
 		INSERT INTO lore_entries (id, content, context, category, confidence, embedding, embedding_status,
-		                 source_id, sources, validation_count, created_at, updated_at, synced_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		                 source_id, sources, validation_count, created_at, updated_at, synced_at, namespace, fingerprint, checksum)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		ON CONFLICT(id) DO UPDATE SET
 			content = excluded.content,
 			context = excluded.context,
@@ -350,6 +359,9 @@ func (s *Store) mergeLoreForImport(lore *Lore) error {
 			validation_count = excluded.validation_count,
 			updated_at = excluded.updated_at,
 			synced_at = excluded.synced_at,
+			namespace = excluded.namespace,
+			fingerprint = excluded.fingerprint,
+			checksum = excluded.checksum,
 			deleted_at = NULL
 	`,
 		lore.ID,
@@ -365,6 +377,9 @@ func (s *Store) mergeLoreForImport(lore *Lore) error {
 		lore.CreatedAt.Format(time.RFC3339),
 		lore.UpdatedAt.Format(time.RFC3339),
 		p.syncedAtStr,
+		s.namespace,
+		Fingerprint(lore.Content),
+		loreChecksum(lore.ID, lore.Content, lore.Context, lore.Category),
 	)
 	return err
 }
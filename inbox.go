@@ -0,0 +1,222 @@
+package recall
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// InboxEntry is lore staged by Record under Config.Inbox, awaiting
+// approval. Staged entries are local-only: they are not queryable and not
+// pushed until approved.
+type InboxEntry struct {
+	ID         string    `json:"id"` // Becomes the lore ID on approval
+	Content    string    `json:"content"`
+	Context    string    `json:"context,omitempty"`
+	Category   Category  `json:"category"`
+	Confidence float64   `json:"confidence"`
+	Sources    []string  `json:"sources,omitempty"`
+	Actor      string    `json:"actor,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// Stage adds e to the namespace's inbox.
+func (s *Store) Stage(e *InboxEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return ErrStoreClosed
+	}
+
+	_, err := s.db.Exec(`
+		INSERT INTO lore_inbox (id, namespace, content, context, category, confidence, sources, actor, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, e.ID, s.namespace, e.Content, e.Context, string(e.Category), e.Confidence,
+		strings.Join(e.Sources, ","), e.Actor, e.CreatedAt.UTC().Format(time.RFC3339))
+	if err != nil {
+		return fmt.Errorf("store: stage lore: %w", err)
+	}
+	return nil
+}
+
+// Inbox returns the namespace's staged entries, oldest first.
+func (s *Store) Inbox() ([]InboxEntry, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil, ErrStoreClosed
+	}
+
+	rows, err := s.db.Query(`
+		SELECT id, content, context, category, confidence, sources, actor, created_at
+		FROM lore_inbox WHERE namespace = ? ORDER BY created_at, id
+	`, s.namespace)
+	if err != nil {
+		return nil, fmt.Errorf("store: query inbox: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var entries []InboxEntry
+	for rows.Next() {
+		e, err := scanInboxEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, *e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("store: iterate inbox: %w", err)
+	}
+	return entries, nil
+}
+
+// GetInboxEntry returns the staged entry with the given ID.
+// Returns ErrNotFound if there is none.
+func (s *Store) GetInboxEntry(id string) (*InboxEntry, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil, ErrStoreClosed
+	}
+
+	row := s.db.QueryRow(`
+		SELECT id, content, context, category, confidence, sources, actor, created_at
+		FROM lore_inbox WHERE id = ? AND namespace = ?
+	`, id, s.namespace)
+	e, err := scanInboxEntry(row)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	return e, err
+}
+
+// Unstage removes the staged entry with the given ID and reports whether
+// there was one.
+func (s *Store) Unstage(id string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return false, ErrStoreClosed
+	}
+
+	res, err := s.db.Exec(`DELETE FROM lore_inbox WHERE id = ? AND namespace = ?`, id, s.namespace)
+	if err != nil {
+		return false, fmt.Errorf("store: unstage lore: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	return n > 0, nil
+}
+
+func scanInboxEntry(row interface{ Scan(...any) error }) (*InboxEntry, error) {
+	var e InboxEntry
+	var category, sources, createdAt string
+	if err := row.Scan(&e.ID, &e.Content, &e.Context, &category, &e.Confidence, &sources, &e.Actor, &createdAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, err
+		}
+		return nil, fmt.Errorf("store: scan inbox entry: %w", err)
+	}
+	e.Category = Category(category)
+	if sources != "" {
+		e.Sources = strings.Split(sources, ",")
+	}
+	e.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+	return &e, nil
+}
+
+// stage stages validated lore in the inbox instead of recording it, and
+// returns it as Record would, with Staged set.
+func (c *Client) stage(content string, category Category, options recordOptions, confidence float64) (*Lore, error) {
+	e := &InboxEntry{
+		ID:         c.store.newID(),
+		Content:    content,
+		Context:    options.context,
+		Category:   category,
+		Confidence: confidence,
+		Sources:    options.sources,
+		Actor:      c.actor(),
+		CreatedAt:  c.store.now().UTC().Truncate(time.Second),
+	}
+	if err := c.store.Stage(e); err != nil {
+		return nil, fmt.Errorf("client: record: %w", err)
+	}
+	return &Lore{
+		ID:         e.ID,
+		Content:    e.Content,
+		Context:    e.Context,
+		Category:   e.Category,
+		Confidence: e.Confidence,
+		SourceID:   c.config.SourceID,
+		Sources:    e.Sources,
+		CreatedAt:  e.CreatedAt,
+		UpdatedAt:  e.CreatedAt,
+		Staged:     true,
+	}, nil
+}
+
+// Inbox returns the lore awaiting approval, oldest first.
+func (c *Client) Inbox() ([]InboxEntry, error) {
+	entries, err := c.store.Inbox()
+	if err != nil {
+		return nil, fmt.Errorf("client: inbox: %w", err)
+	}
+	return entries, nil
+}
+
+// ApproveInbox records the staged entry id, under the same ID, so it enters
+// the change_log and syncs like any other lore. It is recorded as Record
+// would record it with the approving client's SourceID; Config.DedupExact
+// may return an existing entry instead. Under an AccessPolicy, the actor
+// needs ActionCurate and permission to record the entry's category.
+func (c *Client) ApproveInbox(id string) (*Lore, error) {
+	if err := c.authorize(ActionCurate, ""); err != nil {
+		return nil, err
+	}
+	e, err := c.store.GetInboxEntry(id)
+	if err != nil {
+		return nil, fmt.Errorf("client: approve: %w", err)
+	}
+
+	opts := []RecordOption{WithContext(e.Context), WithConfidence(e.Confidence), WithSources(e.Sources...), withInboxID(e.ID)}
+	lore, err := c.Record(e.Content, e.Category, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := c.store.Unstage(e.ID); err != nil {
+		return nil, fmt.Errorf("client: approve: %w", err)
+	}
+	return lore, nil
+}
+
+// RejectInbox discards the staged entry id and reports whether there was
+// one. Under an AccessPolicy, the actor needs ActionCurate.
+func (c *Client) RejectInbox(id string) (bool, error) {
+	if err := c.authorize(ActionCurate, ""); err != nil {
+		return false, err
+	}
+	release, err := c.store.enter(context.Background())
+	if err != nil {
+		return false, err
+	}
+	defer release()
+
+	removed, err := c.store.Unstage(id)
+	if err != nil {
+		return false, fmt.Errorf("client: reject: %w", err)
+	}
+	return removed, nil
+}
+
+// withInboxID records an approved inbox entry under its inbox ID, bypassing
+// Config.Inbox.
+func withInboxID(id string) RecordOption {
+	return func(o *recordOptions) {
+		o.inboxID = id
+	}
+}
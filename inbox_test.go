@@ -0,0 +1,85 @@
+package recall
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestClient_Inbox(t *testing.T) {
+	client := newTestClient(t, Config{SourceID: "test", Actor: "agent-3", Inbox: true})
+
+	kept, err := client.Record("Pool sizes above 50 starve the replica", CategoryPerformanceInsight,
+		WithContext("load test"), WithConfidence(0.7), WithSources("ci-bot"))
+	if err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+	if !kept.Staged {
+		t.Fatalf("Record() = %+v, want Staged", kept)
+	}
+	dropped, _ := client.Record("Everything is slow", CategoryPerformanceInsight)
+
+	// Staged lore is neither queryable nor queued for push
+	if stats, _ := client.store.Stats(); stats.LoreCount != 0 {
+		t.Errorf("LoreCount = %d with only staged lore, want 0", stats.LoreCount)
+	}
+	if changes, _ := client.store.UnpushedChanges(client.store.SourceID(), 0, 100); len(changes) != 0 {
+		t.Errorf("change_log has %d entries for staged lore, want 0", len(changes))
+	}
+
+	inbox, err := client.Inbox()
+	if err != nil || len(inbox) != 2 {
+		t.Fatalf("Inbox() = %v, %v; want 2 entries", inbox, err)
+	}
+	if e := inbox[0]; e.ID != kept.ID || e.Actor != "agent-3" || e.Context != "load test" || e.Confidence != 0.7 || len(e.Sources) != 1 {
+		t.Errorf("Inbox()[0] = %+v", e)
+	}
+
+	approved, err := client.ApproveInbox(kept.ID)
+	if err != nil {
+		t.Fatalf("ApproveInbox() error = %v", err)
+	}
+	if approved.ID != kept.ID || approved.Staged || approved.Confidence != 0.7 {
+		t.Errorf("ApproveInbox() = %+v, want the entry recorded under its inbox ID", approved)
+	}
+	if removed, err := client.RejectInbox(dropped.ID); err != nil || !removed {
+		t.Errorf("RejectInbox() = %v, %v", removed, err)
+	}
+	if _, err := client.ApproveInbox(dropped.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("ApproveInbox(rejected) error = %v, want ErrNotFound", err)
+	}
+
+	if inbox, _ := client.Inbox(); len(inbox) != 0 {
+		t.Errorf("Inbox() after curation = %v, want empty", inbox)
+	}
+	changes, _ := client.store.UnpushedChanges(client.store.SourceID(), 0, 100)
+	if len(changes) != 1 || changes[0].EntityID != kept.ID {
+		t.Errorf("change_log = %v, want one upsert of the approved entry", changes)
+	}
+	result, err := client.Query(context.Background(), QueryParams{Query: "pool sizes"})
+	if err != nil || len(result.Lore) != 1 || result.Lore[0].ID != kept.ID {
+		t.Errorf("Query() = %v, %v; want the approved entry", result, err)
+	}
+}
+
+func TestClient_Inbox_RequiresCurate(t *testing.T) {
+	policy := &AccessPolicy{Actors: map[string]ActorPolicy{
+		"agent":  {},
+		"editor": {Curator: true},
+	}}
+	client := newTestClient(t, Config{SourceID: "test", Actor: "agent", Inbox: true, AccessPolicy: policy})
+
+	staged, err := client.Record("Staged by an agent", CategoryPatternOutcome)
+	if err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+	var perr *PermissionError
+	if _, err := client.ApproveInbox(staged.ID); !errors.As(err, &perr) || perr.Action != ActionCurate {
+		t.Errorf("ApproveInbox() error = %v, want a curate PermissionError", err)
+	}
+
+	client.config.Actor = "editor"
+	if _, err := client.ApproveInbox(staged.ID); err != nil {
+		t.Errorf("ApproveInbox() by a curator error = %v", err)
+	}
+}
@@ -0,0 +1,238 @@
+// Package inference proposes lore feedback from completed agent transcripts.
+//
+// The analyzer is heuristic: it looks for injected lore that the agent
+// referenced (by session ref, lore ID, or close paraphrase) and for language
+// contradicting or dismissing it. Its output is a proposal to be confirmed
+// before calling Client.FeedbackBatch, not feedback to apply blindly.
+package inference
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/hyperengineering/recall"
+)
+
+// Message is one turn of an agent transcript.
+type Message struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// Options tunes the analyzer.
+type Options struct {
+	// Roles whose messages are analyzed. Defaults to "assistant"; other
+	// turns (system prompts, tool output) usually contain the injected lore
+	// verbatim and would otherwise count as references.
+	Roles []string
+
+	// MinParaphraseOverlap is the fraction of an entry's significant words a
+	// single sentence must contain to count as a paraphrase. Defaults to 0.6.
+	MinParaphraseOverlap float64
+}
+
+// Evidence explains one proposed feedback item.
+type Evidence struct {
+	Ref     string              `json:"ref"`
+	ID      string              `json:"id"`
+	Outcome recall.FeedbackType `json:"outcome"`
+	Reason  string              `json:"reason"`
+	Excerpt string              `json:"excerpt"` // Sentence that triggered the proposal
+}
+
+// Proposal is inferred feedback awaiting confirmation.
+type Proposal struct {
+	Feedback recall.FeedbackParams `json:"feedback"`
+	Evidence []Evidence            `json:"evidence"`
+}
+
+// Empty reports whether no feedback was inferred.
+func (p *Proposal) Empty() bool {
+	return len(p.Evidence) == 0
+}
+
+// Cue phrases, matched case-insensitively within a sentence that references
+// an entry.
+var (
+	contradictionCues = []string{
+		"wrong", "incorrect", "outdated", "no longer", "not true", "isn't true",
+		"contrary to", "contradict", "is false", "misleading", "doesn't work",
+		"does not work", "didn't work", "did not work", "stale", "actually not",
+	}
+	dismissalCues = []string{
+		"not relevant", "irrelevant", "doesn't apply", "does not apply",
+		"not applicable", "unrelated",
+	}
+)
+
+var sentenceSplit = regexp.MustCompile(`[.!?;\n]+`)
+
+// Analyze infers feedback for the injected lore from the transcript.
+//
+// Outcomes, strongest first:
+//   - incorrect: a referencing sentence contradicts the entry
+//   - not_relevant: a referencing sentence dismisses the entry
+//   - helpful: the entry is referenced without contradiction
+//
+// Entries never referenced get no proposal.
+func Analyze(transcript []Message, injected []recall.SessionLore, opts Options) *Proposal {
+	roles := opts.Roles
+	if len(roles) == 0 {
+		roles = []string{"assistant"}
+	}
+	minOverlap := opts.MinParaphraseOverlap
+	if minOverlap <= 0 {
+		minOverlap = 0.6
+	}
+
+	var sentences []string
+	for _, m := range transcript {
+		if !containsFold(roles, m.Role) {
+			continue
+		}
+		for _, s := range sentenceSplit.Split(m.Content, -1) {
+			if s = strings.TrimSpace(s); s != "" {
+				sentences = append(sentences, s)
+			}
+		}
+	}
+
+	ordered := append([]recall.SessionLore(nil), injected...)
+	sort.Slice(ordered, func(i, j int) bool { return refLess(ordered[i].SessionRef, ordered[j].SessionRef) })
+
+	proposal := &Proposal{}
+	for _, l := range ordered {
+		ev, ok := analyzeEntry(l, sentences, minOverlap)
+		if !ok {
+			continue
+		}
+		proposal.Evidence = append(proposal.Evidence, ev)
+		switch ev.Outcome {
+		case recall.Incorrect:
+			proposal.Feedback.Incorrect = append(proposal.Feedback.Incorrect, ev.Ref)
+		case recall.NotRelevant:
+			proposal.Feedback.NotRelevant = append(proposal.Feedback.NotRelevant, ev.Ref)
+		default:
+			proposal.Feedback.Helpful = append(proposal.Feedback.Helpful, ev.Ref)
+		}
+	}
+	return proposal
+}
+
+// AnalyzeText analyzes a plain-text transcript, treating it as agent output.
+func AnalyzeText(transcript string, injected []recall.SessionLore) *Proposal {
+	return Analyze([]Message{{Role: "assistant", Content: transcript}}, injected, Options{})
+}
+
+func analyzeEntry(l recall.SessionLore, sentences []string, minOverlap float64) (Evidence, bool) {
+	refPattern := regexp.MustCompile(`(?i)\b` + regexp.QuoteMeta(l.SessionRef) + `\b`)
+	terms := significantTerms(strings.TrimSuffix(l.Content, "..."))
+
+	var found *Evidence
+	for _, s := range sentences {
+		reason := ""
+		switch {
+		case l.SessionRef != "" && refPattern.MatchString(s):
+			reason = "referenced by " + l.SessionRef
+		case l.ID != "" && strings.Contains(s, l.ID):
+			reason = "referenced by ID"
+		case paraphrases(s, terms, minOverlap):
+			reason = "paraphrased"
+		default:
+			continue
+		}
+
+		lower := strings.ToLower(s)
+		ev := Evidence{Ref: l.SessionRef, ID: l.ID, Outcome: recall.Helpful, Reason: reason, Excerpt: s}
+		if cue := firstCue(lower, contradictionCues); cue != "" {
+			ev.Outcome = recall.Incorrect
+			ev.Reason = reason + `, contradicted ("` + cue + `")`
+		} else if cue := firstCue(lower, dismissalCues); cue != "" {
+			ev.Outcome = recall.NotRelevant
+			ev.Reason = reason + `, dismissed ("` + cue + `")`
+		}
+
+		if found == nil || outcomeRank(ev.Outcome) > outcomeRank(found.Outcome) {
+			found = &ev
+		}
+	}
+	if found == nil {
+		return Evidence{}, false
+	}
+	return *found, true
+}
+
+// paraphrases reports whether sentence contains enough of the entry's terms.
+// Entries with fewer than three significant words are never matched this way.
+func paraphrases(sentence string, terms []string, minOverlap float64) bool {
+	if len(terms) < 3 {
+		return false
+	}
+	words := make(map[string]bool)
+	for _, w := range significantTerms(sentence) {
+		words[w] = true
+	}
+	hits := 0
+	for _, t := range terms {
+		if words[t] {
+			hits++
+		}
+	}
+	return float64(hits)/float64(len(terms)) >= minOverlap
+}
+
+// significantTerms returns distinct lowercase words of four or more letters
+// or digits.
+func significantTerms(text string) []string {
+	seen := make(map[string]bool)
+	var terms []string
+	for _, w := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if len([]rune(w)) < 4 || seen[w] {
+			continue
+		}
+		seen[w] = true
+		terms = append(terms, w)
+	}
+	return terms
+}
+
+func firstCue(lower string, cues []string) string {
+	for _, cue := range cues {
+		if strings.Contains(lower, cue) {
+			return cue
+		}
+	}
+	return ""
+}
+
+func outcomeRank(ft recall.FeedbackType) int {
+	switch ft {
+	case recall.Incorrect:
+		return 2
+	case recall.NotRelevant:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// refLess orders session refs numerically (L2 before L10).
+func refLess(a, b string) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+	return a < b
+}
@@ -0,0 +1,69 @@
+package inference
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hyperengineering/recall"
+)
+
+var injected = []recall.SessionLore{
+	{SessionRef: "L1", ID: "01AAAAAAAAAAAAAAAAAAAAAAA1", Content: "Use idempotency keys when retrying payment webhooks"},
+	{SessionRef: "L2", ID: "01AAAAAAAAAAAAAAAAAAAAAAA2", Content: "The orders table is sharded by customer region"},
+	{SessionRef: "L3", ID: "01AAAAAAAAAAAAAAAAAAAAAAA3", Content: "Integration tests need the local Kafka broker"},
+	{SessionRef: "L10", ID: "01AAAAAAAAAAAAAAAAAAAAAA10", Content: "Prefer table-driven tests"},
+	{SessionRef: "L4", ID: "01AAAAAAAAAAAAAAAAAAAAAAA4", Content: "Never surfaced in the transcript at all"},
+}
+
+func TestAnalyze_InfersOutcomes(t *testing.T) {
+	transcript := []Message{
+		{Role: "system", Content: "Relevant lore: [L1] Use idempotency keys when retrying payment webhooks. [L4] Never surfaced."},
+		{Role: "user", Content: "Fix the duplicate charge bug."},
+		{Role: "assistant", Content: "Following L1, I added an idempotency key to the retry path. " +
+			"L2 is outdated: orders moved to a single global table last quarter. " +
+			"The Kafka tip does not apply here since this change has no integration tests needing the local broker."},
+		{Role: "assistant", Content: "Wrote table-driven tests per l10."},
+	}
+
+	p := Analyze(transcript, injected, Options{})
+
+	want := recall.FeedbackParams{
+		Helpful:     []string{"L1", "L10"},
+		Incorrect:   []string{"L2"},
+		NotRelevant: []string{"L3"},
+	}
+	if !reflect.DeepEqual(p.Feedback, want) {
+		t.Errorf("Feedback = %+v, want %+v", p.Feedback, want)
+	}
+	if len(p.Evidence) != 4 {
+		t.Fatalf("evidence = %d, want 4", len(p.Evidence))
+	}
+	if p.Evidence[2].Ref != "L3" || p.Evidence[2].Reason != `paraphrased, dismissed ("does not apply")` {
+		t.Errorf("L3 evidence = %+v, want paraphrase dismissal", p.Evidence[2])
+	}
+}
+
+func TestAnalyze_IgnoresNonAgentTurns(t *testing.T) {
+	transcript := []Message{
+		{Role: "tool", Content: "[L1] Use idempotency keys when retrying payment webhooks"},
+	}
+	if p := Analyze(transcript, injected, Options{}); !p.Empty() {
+		t.Errorf("proposal = %+v, want empty for tool-only mentions", p)
+	}
+
+	p := Analyze(transcript, injected, Options{Roles: []string{"tool"}})
+	if !reflect.DeepEqual(p.Feedback.Helpful, []string{"L1"}) {
+		t.Errorf("Helpful = %v, want [L1] when tool turns are analyzed", p.Feedback.Helpful)
+	}
+}
+
+func TestAnalyzeText_ContradictionOutranksReference(t *testing.T) {
+	text := "I checked L1 first. Later it turned out L1 was wrong for this provider."
+	p := AnalyzeText(text, injected)
+	if !reflect.DeepEqual(p.Feedback.Incorrect, []string{"L1"}) || len(p.Feedback.Helpful) != 0 {
+		t.Errorf("Feedback = %+v, want L1 incorrect only", p.Feedback)
+	}
+	if p.Evidence[0].Excerpt != "Later it turned out L1 was wrong for this provider" {
+		t.Errorf("Excerpt = %q", p.Evidence[0].Excerpt)
+	}
+}
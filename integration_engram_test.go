@@ -0,0 +1,186 @@
+//go:build integration
+
+package recall
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Integration tests run against a real Engram:
+//
+//	go test -tags integration -run Integration ./...
+//
+// By default TestMain starts ENGRAM_TEST_IMAGE with docker and removes the
+// container when the tests finish. Set ENGRAM_TEST_URL to use an Engram
+// that is already running instead.
+const (
+	defaultEngramTestImage  = "ghcr.io/hyperengineering/engram:latest"
+	defaultEngramTestAPIKey = "recall-integration"
+	engramContainerPort     = "8080/tcp"
+	engramStartTimeout      = 60 * time.Second
+)
+
+// engramHarness is the Engram instance shared by the integration tests.
+type engramHarness struct {
+	URL         string
+	APIKey      string
+	containerID string // empty when ENGRAM_TEST_URL is used
+}
+
+var (
+	engram      *engramHarness
+	storeSerial atomic.Int64
+)
+
+func TestMain(m *testing.M) {
+	h, err := startEngram()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "integration: %v\n", err)
+		os.Exit(1)
+	}
+	engram = h
+	code := m.Run()
+	h.stop()
+	os.Exit(code)
+}
+
+// startEngram returns ENGRAM_TEST_URL if set, or starts a container and
+// waits for its health endpoint.
+func startEngram() (*engramHarness, error) {
+	apiKey := os.Getenv("ENGRAM_TEST_API_KEY")
+	if apiKey == "" {
+		apiKey = defaultEngramTestAPIKey
+	}
+	if url := os.Getenv("ENGRAM_TEST_URL"); url != "" {
+		h := &engramHarness{URL: strings.TrimSuffix(url, "/"), APIKey: apiKey}
+		return h, h.waitHealthy()
+	}
+
+	image := os.Getenv("ENGRAM_TEST_IMAGE")
+	if image == "" {
+		image = defaultEngramTestImage
+	}
+	if _, err := exec.LookPath("docker"); err != nil {
+		return nil, fmt.Errorf("docker not found; install it or set ENGRAM_TEST_URL")
+	}
+	out, err := exec.Command("docker", "run", "-d", "--rm",
+		"-p", "127.0.0.1::"+strings.TrimSuffix(engramContainerPort, "/tcp"),
+		"-e", "ENGRAM_API_KEY="+apiKey,
+		image).Output()
+	if err != nil {
+		return nil, fmt.Errorf("start %s: %w", image, commandError(err))
+	}
+	h := &engramHarness{APIKey: apiKey, containerID: strings.TrimSpace(string(out))}
+
+	out, err = exec.Command("docker", "port", h.containerID, engramContainerPort).Output()
+	if err != nil {
+		h.stop()
+		return nil, fmt.Errorf("read container port: %w", commandError(err))
+	}
+	// docker port may list IPv4 and IPv6 bindings; the first is enough.
+	addr := strings.TrimSpace(strings.SplitN(string(out), "\n", 2)[0])
+	h.URL = "http://" + addr
+
+	if err := h.waitHealthy(); err != nil {
+		logs, _ := exec.Command("docker", "logs", h.containerID).CombinedOutput()
+		h.stop()
+		return nil, fmt.Errorf("%w\ncontainer logs:\n%s", err, logs)
+	}
+	return h, nil
+}
+
+// waitHealthy polls /api/v1/health until Engram answers 200.
+func (h *engramHarness) waitHealthy() error {
+	deadline := time.Now().Add(engramStartTimeout)
+	for {
+		resp, err := http.Get(h.URL + "/api/v1/health")
+		if err == nil {
+			_ = resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				return nil
+			}
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("engram at %s not healthy after %s (last error: %v)", h.URL, engramStartTimeout, err)
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+}
+
+func (h *engramHarness) stop() {
+	if h.containerID != "" {
+		_ = exec.Command("docker", "rm", "-f", h.containerID).Run()
+	}
+}
+
+// newStore creates a fresh Engram store for one test, so tests do not see
+// each other's lore.
+func (h *engramHarness) newStore(t *testing.T) string {
+	t.Helper()
+	name := strings.Trim(regexp.MustCompile(`[^a-z0-9]+`).ReplaceAllString(strings.ToLower(t.Name()), "-"), "-")
+	if len(name) > 40 {
+		name = name[:40]
+	}
+	storeID := fmt.Sprintf("recall-it/%s-%d-%d", strings.Trim(name, "-"), time.Now().Unix(), storeSerial.Add(1))
+
+	body, _ := json.Marshal(map[string]string{"store_id": storeID, "description": "recall integration test"})
+	req, err := http.NewRequest(http.MethodPost, h.URL+"/api/v1/stores", bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+h.APIKey)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("create store %s: %v", storeID, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		t.Fatalf("create store %s: %s", storeID, resp.Status)
+	}
+	return storeID
+}
+
+// newClient returns an offline-by-default client (no background sync) on a
+// fresh local database, connected to storeID on the shared Engram.
+func (h *engramHarness) newClient(t *testing.T, storeID, sourceID string, opts ...func(*Config)) *Client {
+	t.Helper()
+	cfg := Config{
+		LocalPath: filepath.Join(t.TempDir(), sourceID+".db"),
+		Store:     storeID,
+		EngramURL: h.URL,
+		APIKey:    h.APIKey,
+		SourceID:  sourceID,
+		AutoSync:  false,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return newTestClient(t, cfg)
+}
+
+// integrationContext bounds each network call in a scenario.
+func integrationContext(t *testing.T) context.Context {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	t.Cleanup(cancel)
+	return ctx
+}
+
+func commandError(err error) error {
+	if ee, ok := err.(*exec.ExitError); ok && len(ee.Stderr) > 0 {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(ee.Stderr)))
+	}
+	return err
+}
@@ -0,0 +1,180 @@
+//go:build integration
+
+package recall
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestIntegration_PushThenBootstrap(t *testing.T) {
+	storeID := engram.newStore(t)
+	alice := engram.newClient(t, storeID, "alice")
+	bob := engram.newClient(t, storeID, "bob")
+	ctx := integrationContext(t)
+
+	first, err := alice.Record("Integration: pooled connections need a max lifetime", CategoryPatternOutcome)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := alice.Record("Integration: table tests keep cases readable", CategoryTestingStrategy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := alice.SyncPush(ctx)
+	if err != nil {
+		t.Fatalf("SyncPush: %v", err)
+	}
+	if result.EntriesPushed < 2 {
+		t.Errorf("EntriesPushed = %d, want at least 2", result.EntriesPushed)
+	}
+
+	eventually(t, 30*time.Second, func() error {
+		if err := bob.Bootstrap(ctx); err != nil {
+			return err
+		}
+		for _, want := range []*Lore{first, second} {
+			got, err := bob.store.Get(want.ID)
+			if err != nil {
+				return fmt.Errorf("%s after bootstrap: %w", want.ID, err)
+			}
+			if got.Content != want.Content || got.Category != want.Category {
+				return fmt.Errorf("bootstrapped %s = %q/%s, want %q/%s", want.ID, got.Content, got.Category, want.Content, want.Category)
+			}
+		}
+		return nil
+	})
+}
+
+func TestIntegration_DeltaAppliesRecordsFeedbackAndDeletes(t *testing.T) {
+	storeID := engram.newStore(t)
+	alice := engram.newClient(t, storeID, "alice")
+	bob := engram.newClient(t, storeID, "bob")
+	ctx := integrationContext(t)
+
+	kept, err := alice.Record("Integration: delta carries new lore", CategoryPatternOutcome)
+	if err != nil {
+		t.Fatal(err)
+	}
+	doomed, err := alice.Record("Integration: delta carries deletes", CategoryPatternOutcome)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := alice.SyncPush(ctx); err != nil {
+		t.Fatalf("SyncPush: %v", err)
+	}
+	if _, err := bob.SyncDelta(ctx); err != nil {
+		t.Fatalf("SyncDelta: %v", err)
+	}
+	if _, err := bob.store.Get(doomed.ID); err != nil {
+		t.Fatalf("bob missing %s after first delta: %v", doomed.ID, err)
+	}
+
+	helped, err := alice.Feedback(kept.ID, Helpful)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := alice.store.DeleteLoreByID(doomed.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := alice.SyncPush(ctx); err != nil {
+		t.Fatalf("SyncPush: %v", err)
+	}
+
+	delta, err := bob.SyncDelta(ctx)
+	if err != nil {
+		t.Fatalf("SyncDelta: %v", err)
+	}
+	if delta.EntriesApplied < 2 {
+		t.Errorf("EntriesApplied = %d, want the feedback and the delete", delta.EntriesApplied)
+	}
+	got, err := bob.store.Get(kept.ID)
+	if err != nil {
+		t.Fatalf("bob missing %s: %v", kept.ID, err)
+	}
+	if got.Confidence != helped.Confidence || got.ValidationCount != helped.ValidationCount {
+		t.Errorf("bob %s = confidence %.2f, validations %d; want %.2f, %d",
+			kept.ID, got.Confidence, got.ValidationCount, helped.Confidence, helped.ValidationCount)
+	}
+	if _, err := bob.store.Get(doomed.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("bob Get(%s) after delete = %v, want ErrNotFound", doomed.ID, err)
+	}
+
+	// A second delta has nothing new.
+	again, err := bob.SyncDelta(ctx)
+	if err != nil {
+		t.Fatalf("SyncDelta: %v", err)
+	}
+	if again.EntriesApplied != 0 || again.LastSequence != delta.LastSequence {
+		t.Errorf("repeat delta = %+v, want nothing applied at sequence %d", again, delta.LastSequence)
+	}
+}
+
+func TestIntegration_ConflictReview(t *testing.T) {
+	storeID := engram.newStore(t)
+	alice := engram.newClient(t, storeID, "alice")
+	bob := engram.newClient(t, storeID, "bob", func(cfg *Config) { cfg.ConflictPolicy = ConflictPolicyReview })
+	ctx := integrationContext(t)
+
+	shared, err := alice.Record("Integration: both sides edit this entry", CategoryPatternOutcome)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := alice.SyncPush(ctx); err != nil {
+		t.Fatalf("SyncPush: %v", err)
+	}
+	if _, err := bob.SyncDelta(ctx); err != nil {
+		t.Fatalf("SyncDelta: %v", err)
+	}
+
+	// Bob edits locally without pushing while Alice's edit reaches Engram.
+	local, err := bob.Feedback(shared.ID, Incorrect)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := alice.Feedback(shared.ID, Helpful); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := alice.SyncPush(ctx); err != nil {
+		t.Fatalf("SyncPush: %v", err)
+	}
+
+	delta, err := bob.SyncDelta(ctx)
+	if err != nil {
+		t.Fatalf("SyncDelta: %v", err)
+	}
+	if delta.EntriesConflicted != 1 {
+		t.Fatalf("EntriesConflicted = %d, want 1", delta.EntriesConflicted)
+	}
+	conflicts, err := bob.Conflicts(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(conflicts) != 1 || conflicts[0].LoreID != shared.ID {
+		t.Fatalf("Conflicts = %+v, want one for %s", conflicts, shared.ID)
+	}
+	if got, _ := bob.store.Get(shared.ID); got == nil || got.Confidence != local.Confidence {
+		t.Errorf("bob kept %+v, want the local edit until resolved", got)
+	}
+
+	// Resolving for the remote side is pushed, so Alice converges too.
+	resolved, err := bob.ResolveConflict(conflicts[0].ID, ConflictResolutionRemote, nil)
+	if err != nil {
+		t.Fatalf("ResolveConflict: %v", err)
+	}
+	if _, err := bob.SyncPush(ctx); err != nil {
+		t.Fatalf("SyncPush: %v", err)
+	}
+	if _, err := alice.SyncDelta(ctx); err != nil {
+		t.Fatalf("SyncDelta: %v", err)
+	}
+	got, err := alice.store.Get(shared.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Confidence != resolved.Confidence {
+		t.Errorf("alice confidence = %.2f, want resolved %.2f", got.Confidence, resolved.Confidence)
+	}
+}
@@ -0,0 +1,340 @@
+package recall
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// ConsistencyIssueKind classifies a change_log/lore_entries inconsistency.
+type ConsistencyIssueKind string
+
+const (
+	// IssueOrphanChange is an unpushed change whose entity does not exist,
+	// not even as a tombstone.
+	IssueOrphanChange ConsistencyIssueKind = "orphan_change"
+	// IssueInvalidPayload is an unpushed upsert whose payload cannot be decoded.
+	IssueInvalidPayload ConsistencyIssueKind = "invalid_payload"
+	// IssueStalePayload is a latest unpushed upsert whose payload does not
+	// match the current row state.
+	IssueStalePayload ConsistencyIssueKind = "stale_payload"
+	// IssueMissingTombstone is a latest unpushed delete whose row is still active.
+	IssueMissingTombstone ConsistencyIssueKind = "missing_tombstone"
+	// IssueSequenceRegression means the sync cursor or SQLite's sequence
+	// counter is behind or ahead of the change_log itself.
+	IssueSequenceRegression ConsistencyIssueKind = "sequence_regression"
+	// IssueChecksumMismatch is a lore row that fails its checksum. It is
+	// never repaired: restore the entry from a backup or Engram, or
+	// rewrite its content with UpdateContent.
+	IssueChecksumMismatch ConsistencyIssueKind = "checksum_mismatch"
+)
+
+// ConsistencyIssue is a single problem found by VerifyConsistency.
+type ConsistencyIssue struct {
+	Kind     ConsistencyIssueKind `json:"kind"`
+	Sequence int64                `json:"sequence,omitempty"`
+	EntityID string               `json:"entity_id,omitempty"`
+	Detail   string               `json:"detail"`
+	Repaired bool                 `json:"repaired"`
+}
+
+// ConsistencyReport summarizes a VerifyConsistency run.
+type ConsistencyReport struct {
+	ChangesChecked int                `json:"changes_checked"`
+	Issues         []ConsistencyIssue `json:"issues"`
+	Repaired       int                `json:"repaired"`
+}
+
+// OK reports whether no unrepaired issues remain.
+func (r *ConsistencyReport) OK() bool {
+	return len(r.Issues) == r.Repaired
+}
+
+// VerifyConsistency checks that change_log and lore_entries agree:
+//  1. Every unpushed change references an existing entity (live or tombstoned)
+//  2. The latest unpushed upsert per entity matches the current row state
+//  3. The latest unpushed delete per entity has a tombstoned row
+//  4. last_push_seq and SQLite's AUTOINCREMENT counter do not trail or
+//     exceed the highest change_log sequence
+//  5. Every lore row matches its checksum
+//
+// When repair is true, all fixes are applied in a single transaction:
+// orphan changes and undecodable payloads are removed, stale upserts are
+// superseded by a fresh change_log entry of the current row, missing
+// tombstones are applied, and sequence counters are realigned. Checksum
+// mismatches are reported, and passed to the checksum handler, but not
+// repaired.
+func (s *Store) VerifyConsistency(repair bool) (*ConsistencyReport, error) {
+	report, mismatches, err := s.verifyConsistency(repair)
+	s.notifyChecksum(mismatches...)
+	return report, err
+}
+
+func (s *Store) verifyConsistency(repair bool) (*ConsistencyReport, []*ChecksumError, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil, nil, ErrStoreClosed
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, nil, fmt.Errorf("store: begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	report := &ConsistencyReport{Issues: []ConsistencyIssue{}}
+
+	if err := s.verifySequences(tx, report, repair); err != nil {
+		return nil, nil, err
+	}
+
+	lastPushSeq, err := readLastPushSeqTx(tx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rows, err := tx.Query(`
+		SELECT sequence, entity_id, operation, payload
+		FROM change_log
+		WHERE table_name = 'lore_entries' AND source_id = ? AND sequence > ?
+		ORDER BY sequence ASC
+	`, s.sourceID, lastPushSeq)
+	if err != nil {
+		return nil, nil, fmt.Errorf("store: query unpushed changes: %w", err)
+	}
+
+	type change struct {
+		sequence  int64
+		entityID  string
+		operation string
+		payload   sql.NullString
+	}
+	var changes []change
+	latest := make(map[string]int)
+	for rows.Next() {
+		var c change
+		if err := rows.Scan(&c.sequence, &c.entityID, &c.operation, &c.payload); err != nil {
+			_ = rows.Close()
+			return nil, nil, fmt.Errorf("store: scan change_log: %w", err)
+		}
+		latest[c.entityID] = len(changes)
+		changes = append(changes, c)
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("store: iterate change_log: %w", err)
+	}
+	report.ChangesChecked = len(changes)
+
+	// A row that fails its checksum cannot be trusted, so it is not
+	// compared with, or copied into, its change_log payload.
+	mismatches, err := checksumMismatchesTx(tx)
+	if err != nil {
+		return nil, nil, err
+	}
+	untrusted := make(map[string]bool, len(mismatches))
+	for _, m := range mismatches {
+		untrusted[m.LoreID] = true
+	}
+
+	for i, c := range changes {
+		row, err := s.getLoreIncludingDeletedTx(tx, c.entityID)
+		if err != nil && err != ErrNotFound {
+			return nil, nil, err
+		}
+
+		if row == nil {
+			issue := ConsistencyIssue{Kind: IssueOrphanChange, Sequence: c.sequence, EntityID: c.entityID,
+				Detail: fmt.Sprintf("%s references missing entity", c.operation)}
+			if repair {
+				if err := deleteChangeTx(tx, c.sequence); err != nil {
+					return nil, nil, err
+				}
+				issue.Repaired = true
+			}
+			report.add(issue)
+			continue
+		}
+
+		if c.operation == "upsert" {
+			payloadLore, perr := parseLorePayload([]byte(c.payload.String))
+			if !c.payload.Valid || perr != nil {
+				issue := ConsistencyIssue{Kind: IssueInvalidPayload, Sequence: c.sequence, EntityID: c.entityID,
+					Detail: "upsert payload cannot be decoded"}
+				if repair {
+					if err := deleteChangeTx(tx, c.sequence); err != nil {
+						return nil, nil, err
+					}
+					if latest[c.entityID] == i && !untrusted[c.entityID] {
+						if err := s.appendRowStateTx(tx, row); err != nil {
+							return nil, nil, err
+						}
+					}
+					issue.Repaired = true
+				}
+				report.add(issue)
+				continue
+			}
+			if latest[c.entityID] != i || untrusted[c.entityID] {
+				continue
+			}
+			if stale := payloadMismatch(payloadLore, row); stale != "" {
+				issue := ConsistencyIssue{Kind: IssueStalePayload, Sequence: c.sequence, EntityID: c.entityID, Detail: stale}
+				if repair {
+					if err := s.appendRowStateTx(tx, row); err != nil {
+						return nil, nil, err
+					}
+					issue.Repaired = true
+				}
+				report.add(issue)
+			}
+			continue
+		}
+
+		if c.operation == "delete" && latest[c.entityID] == i && row.DeletedAt == nil {
+			issue := ConsistencyIssue{Kind: IssueMissingTombstone, Sequence: c.sequence, EntityID: c.entityID,
+				Detail: "entity is deleted in change_log but still active"}
+			if repair {
+				now := s.now().Format(time.RFC3339)
+				if _, err := tx.Exec(`UPDATE lore_entries SET deleted_at = ?, updated_at = ? WHERE id = ?`, now, now, c.entityID); err != nil {
+					return nil, nil, fmt.Errorf("store: apply tombstone: %w", err)
+				}
+				issue.Repaired = true
+			}
+			report.add(issue)
+		}
+	}
+
+	for _, m := range mismatches {
+		report.add(ConsistencyIssue{Kind: IssueChecksumMismatch, EntityID: m.LoreID,
+			Detail: "row does not match its checksum; it was corrupted or edited outside Recall"})
+	}
+
+	if repair {
+		if err := tx.Commit(); err != nil {
+			return nil, nil, fmt.Errorf("store: commit: %w", err)
+		}
+	}
+	return report, mismatches, nil
+}
+
+func (r *ConsistencyReport) add(issue ConsistencyIssue) {
+	r.Issues = append(r.Issues, issue)
+	if issue.Repaired {
+		r.Repaired++
+	}
+}
+
+// verifySequences checks last_push_seq and sqlite_sequence against the
+// highest change_log sequence.
+func (s *Store) verifySequences(tx *sql.Tx, report *ConsistencyReport, repair bool) error {
+	var maxSeq int64
+	if err := tx.QueryRow(`SELECT COALESCE(MAX(sequence), 0) FROM change_log`).Scan(&maxSeq); err != nil {
+		return fmt.Errorf("store: read max sequence: %w", err)
+	}
+
+	lastPushSeq, err := readLastPushSeqTx(tx)
+	if err != nil {
+		return err
+	}
+	if lastPushSeq > maxSeq {
+		issue := ConsistencyIssue{Kind: IssueSequenceRegression,
+			Detail: fmt.Sprintf("last_push_seq %d is ahead of change_log max %d", lastPushSeq, maxSeq)}
+		if repair {
+			if _, err := tx.Exec(`INSERT OR REPLACE INTO sync_meta (key, value) VALUES ('last_push_seq', ?)`,
+				strconv.FormatInt(maxSeq, 10)); err != nil {
+				return fmt.Errorf("store: reset last_push_seq: %w", err)
+			}
+			issue.Repaired = true
+		}
+		report.add(issue)
+	}
+
+	var counter sql.NullInt64
+	err = tx.QueryRow(`SELECT seq FROM sqlite_sequence WHERE name = 'change_log'`).Scan(&counter)
+	if err != nil && err != sql.ErrNoRows {
+		return fmt.Errorf("store: read sqlite_sequence: %w", err)
+	}
+	if counter.Int64 < maxSeq {
+		issue := ConsistencyIssue{Kind: IssueSequenceRegression,
+			Detail: fmt.Sprintf("sequence counter %d trails change_log max %d", counter.Int64, maxSeq)}
+		if repair {
+			if _, err := tx.Exec(`UPDATE sqlite_sequence SET seq = ? WHERE name = 'change_log'`, maxSeq); err != nil {
+				return fmt.Errorf("store: realign sqlite_sequence: %w", err)
+			}
+			issue.Repaired = true
+		}
+		report.add(issue)
+	}
+	return nil
+}
+
+// payloadMismatch describes how a change_log payload differs from the row,
+// or returns "" if they agree.
+func payloadMismatch(payload, row *Lore) string {
+	if row.DeletedAt != nil {
+		return "entity is deleted but latest change is an upsert"
+	}
+	if diffs := loreFieldDiffs(payload, row); len(diffs) > 0 {
+		return fmt.Sprintf("%s differs from row", diffs[0].Field)
+	}
+	if payload.ValidationCount != row.ValidationCount {
+		return "validation_count differs from row"
+	}
+	return ""
+}
+
+// appendRowStateTx appends a change_log entry reflecting the row's current state.
+func (s *Store) appendRowStateTx(tx *sql.Tx, row *Lore) error {
+	if row.DeletedAt != nil {
+		return s.appendChangeLog(tx, "lore_entries", row.ID, "delete", nil, s.sourceID)
+	}
+	payload, err := lorePayloadJSON(row)
+	if err != nil {
+		return fmt.Errorf("store: marshal change_log payload: %w", err)
+	}
+	return s.appendChangeLog(tx, "lore_entries", row.ID, "upsert", payload, s.sourceID)
+}
+
+// getLoreIncludingDeletedTx reads a lore row regardless of deleted_at.
+func (s *Store) getLoreIncludingDeletedTx(tx *sql.Tx, id string) (*Lore, error) {
+	row := tx.QueryRow(`
+		SELECT id, content, context, category, confidence, embedding, embedding_status, source_id, sources,
+		       validation_count, last_validated_at, created_at, updated_at, deleted_at, synced_at, namespace
+		FROM lore_entries WHERE id = ?
+	`, id)
+	lore, err := s.scanLore(row)
+	if err == ErrNotFound {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("store: read lore %s: %w", id, err)
+	}
+	return lore, nil
+}
+
+func readLastPushSeqTx(tx *sql.Tx) (int64, error) {
+	var value string
+	err := tx.QueryRow(`SELECT value FROM sync_meta WHERE key = 'last_push_seq'`).Scan(&value)
+	if err == sql.ErrNoRows || value == "" {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("store: read last_push_seq: %w", err)
+	}
+	seq, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("store: parse last_push_seq: %w", err)
+	}
+	return seq, nil
+}
+
+func deleteChangeTx(tx *sql.Tx, sequence int64) error {
+	if _, err := tx.Exec(`DELETE FROM change_log WHERE sequence = ?`, sequence); err != nil {
+		return fmt.Errorf("store: delete change_log entry: %w", err)
+	}
+	return nil
+}
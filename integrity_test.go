@@ -0,0 +1,117 @@
+package recall
+
+import (
+	"testing"
+	"time"
+)
+
+func insertVerifyLore(t *testing.T, store *Store, id string) {
+	t.Helper()
+	now := time.Now().UTC()
+	if err := store.InsertLore(&Lore{ID: id, Content: "content " + id, Category: CategoryPatternOutcome,
+		Confidence: 0.5, CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatalf("InsertLore: %v", err)
+	}
+}
+
+func issueKinds(r *ConsistencyReport) map[ConsistencyIssueKind]int {
+	kinds := make(map[ConsistencyIssueKind]int)
+	for _, i := range r.Issues {
+		kinds[i.Kind]++
+	}
+	return kinds
+}
+
+func TestVerifyConsistency_CleanStore(t *testing.T) {
+	store := newTestStore(t)
+	insertVerifyLore(t, store, "a")
+	if _, err := store.ApplyFeedback("a", ConfidenceHelpfulDelta, true); err != nil {
+		t.Fatalf("ApplyFeedback: %v", err)
+	}
+	if err := store.DeleteLoreByID("a"); err != nil {
+		t.Fatalf("DeleteLoreByID: %v", err)
+	}
+	insertVerifyLore(t, store, "b")
+
+	report, err := store.VerifyConsistency(false)
+	if err != nil {
+		t.Fatalf("VerifyConsistency: %v", err)
+	}
+	if len(report.Issues) != 0 {
+		t.Errorf("Issues = %+v, want none", report.Issues)
+	}
+	if report.ChangesChecked != 4 {
+		t.Errorf("ChangesChecked = %d, want 4", report.ChangesChecked)
+	}
+}
+
+func TestVerifyConsistency_DetectsAndRepairs(t *testing.T) {
+	store := newTestStore(t)
+	insertVerifyLore(t, store, "stale")
+	insertVerifyLore(t, store, "undeleted")
+	insertVerifyLore(t, store, "orphan")
+
+	// Desynchronize lore_entries from change_log behind the store's back.
+	mustExec := func(q string, args ...any) {
+		t.Helper()
+		if _, err := store.db.Exec(q, args...); err != nil {
+			t.Fatalf("exec %q: %v", q, err)
+		}
+	}
+	mustExec(`UPDATE lore_entries SET content = 'edited without change_log', checksum = ? WHERE id = 'stale'`,
+		loreChecksum("stale", "edited without change_log", "", CategoryPatternOutcome))
+	mustExec(`INSERT INTO change_log (table_name, entity_id, operation, source_id, created_at) VALUES ('lore_entries', 'undeleted', 'delete', ?, ?)`,
+		store.SourceID(), time.Now().UTC().Format(time.RFC3339))
+	mustExec(`DELETE FROM lore_entries WHERE id = 'orphan'`)
+
+	report, err := store.VerifyConsistency(false)
+	if err != nil {
+		t.Fatalf("VerifyConsistency: %v", err)
+	}
+	kinds := issueKinds(report)
+	if kinds[IssueStalePayload] != 1 || kinds[IssueMissingTombstone] != 1 || kinds[IssueOrphanChange] != 1 {
+		t.Fatalf("issue kinds = %v", kinds)
+	}
+	if report.OK() {
+		t.Error("OK() = true before repair")
+	}
+
+	repaired, err := store.VerifyConsistency(true)
+	if err != nil {
+		t.Fatalf("VerifyConsistency(repair): %v", err)
+	}
+	if !repaired.OK() || repaired.Repaired != 3 {
+		t.Errorf("Repaired = %d, OK = %v; want 3 repaired", repaired.Repaired, repaired.OK())
+	}
+
+	if _, err := store.Get("undeleted"); err != ErrNotFound {
+		t.Errorf("undeleted should be tombstoned, Get err = %v", err)
+	}
+
+	after, err := store.VerifyConsistency(false)
+	if err != nil {
+		t.Fatalf("VerifyConsistency: %v", err)
+	}
+	if len(after.Issues) != 0 {
+		t.Errorf("issues after repair = %+v", after.Issues)
+	}
+}
+
+func TestVerifyConsistency_LastPushSeqAhead(t *testing.T) {
+	store := newTestStore(t)
+	insertVerifyLore(t, store, "a")
+	if err := store.SetSyncMeta("last_push_seq", "99"); err != nil {
+		t.Fatalf("SetSyncMeta: %v", err)
+	}
+
+	report, err := store.VerifyConsistency(true)
+	if err != nil {
+		t.Fatalf("VerifyConsistency: %v", err)
+	}
+	if issueKinds(report)[IssueSequenceRegression] != 1 || !report.OK() {
+		t.Errorf("report = %+v", report)
+	}
+	if seq, _ := store.GetSyncMeta("last_push_seq"); seq != "1" {
+		t.Errorf("last_push_seq = %q, want 1", seq)
+	}
+}
@@ -0,0 +1,168 @@
+package store
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// GitStoreEnv controls store selection from the enclosing git repository.
+// Values: "store" (default) selects a store named after the origin remote,
+// "namespace" keeps the resolved store and uses the derived name as the
+// default namespace, and "off" disables detection.
+const GitStoreEnv = "RECALL_GIT_STORE"
+
+// Git store modes.
+const (
+	GitStoreModeStore     = "store"
+	GitStoreModeNamespace = "namespace"
+	GitStoreModeOff       = "off"
+)
+
+// GitStoreMode returns the mode configured by RECALL_GIT_STORE.
+// Unrecognized values disable detection.
+func GitStoreMode() string {
+	switch v := strings.ToLower(os.Getenv(GitStoreEnv)); v {
+	case "", GitStoreModeStore:
+		return GitStoreModeStore
+	case GitStoreModeNamespace:
+		return GitStoreModeNamespace
+	default:
+		return GitStoreModeOff
+	}
+}
+
+// invalidSegmentChars matches runs of characters not allowed in store ID segments.
+var invalidSegmentChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// GitStoreID derives a store ID from the origin remote of the git repository
+// enclosing dir. Returns false if dir is not inside a repository, the
+// repository has no origin remote, or no valid ID can be derived.
+func GitStoreID(dir string) (string, bool) {
+	gitDir, ok := findGitDir(dir)
+	if !ok {
+		return "", false
+	}
+	url, ok := originURL(filepath.Join(gitDir, "config"))
+	if !ok {
+		return "", false
+	}
+	return RemoteStoreID(url)
+}
+
+// RemoteStoreID derives a store ID of the form "owner/repo" from a git
+// remote URL. Both URL and scp-style (git@host:owner/repo.git) remotes are
+// supported. Names are lowercased and other characters become hyphens.
+func RemoteStoreID(url string) (string, bool) {
+	path := strings.TrimSpace(url)
+	if i := strings.Index(path, "://"); i >= 0 {
+		path = path[i+3:]
+		// Drop the host (and any credentials or port).
+		j := strings.Index(path, "/")
+		if j < 0 {
+			return "", false
+		}
+		path = path[j+1:]
+	} else if i := strings.Index(path, ":"); i >= 0 && !strings.HasPrefix(path, "/") {
+		path = path[i+1:]
+	}
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+
+	parts := strings.Split(path, "/")
+	if len(parts) > 2 {
+		parts = parts[len(parts)-2:]
+	}
+	var segments []string
+	for _, p := range parts {
+		seg := strings.Trim(invalidSegmentChars.ReplaceAllString(strings.ToLower(p), "-"), "-")
+		if len(seg) > 64 {
+			seg = strings.TrimRight(seg[:64], "-")
+		}
+		if seg != "" {
+			segments = append(segments, seg)
+		}
+	}
+	id := strings.Join(segments, "/")
+	if ValidateStoreID(id) != nil || IsReservedStoreID(id) {
+		return "", false
+	}
+	return id, true
+}
+
+// findGitDir walks up from dir to the repository's git directory, following
+// "gitdir:" files and commondir links used by worktrees and submodules.
+func findGitDir(dir string) (string, bool) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", false
+	}
+	for {
+		candidate := filepath.Join(dir, ".git")
+		info, err := os.Stat(candidate)
+		if err == nil {
+			if info.IsDir() {
+				return candidate, true
+			}
+			return resolveGitFile(candidate)
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// resolveGitFile reads a ".git" file pointing at the real git directory.
+func resolveGitFile(path string) (string, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", false
+	}
+	target, ok := strings.CutPrefix(strings.TrimSpace(string(data)), "gitdir:")
+	if !ok {
+		return "", false
+	}
+	gitDir := strings.TrimSpace(target)
+	if !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(filepath.Dir(path), gitDir)
+	}
+	// Worktrees keep the shared config in the common directory.
+	if common, err := os.ReadFile(filepath.Join(gitDir, "commondir")); err == nil {
+		commonDir := strings.TrimSpace(string(common))
+		if !filepath.IsAbs(commonDir) {
+			commonDir = filepath.Join(gitDir, commonDir)
+		}
+		gitDir = commonDir
+	}
+	return gitDir, true
+}
+
+// originURL returns the url of [remote "origin"] from a git config file.
+func originURL(configPath string) (string, bool) {
+	f, err := os.Open(configPath)
+	if err != nil {
+		return "", false
+	}
+	defer func() { _ = f.Close() }()
+
+	inOrigin := false
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if strings.HasPrefix(line, "[") {
+			inOrigin = line == `[remote "origin"]`
+			continue
+		}
+		if !inOrigin {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if ok && strings.TrimSpace(key) == "url" {
+			return strings.TrimSpace(value), true
+		}
+	}
+	return "", false
+}
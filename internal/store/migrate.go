@@ -28,7 +28,7 @@ type MigrationResult struct {
 //
 // Parameters:
 //   - envPath: value of RECALL_DB_PATH env var (empty if not set)
-//   - storeRoot: root directory for stores (typically ~/.recall/stores)
+//   - storeRoot: root directory for stores (see DefaultStoreRoot)
 //
 // Returns:
 //   - result: migration result containing migrated flag and paths
@@ -0,0 +1,21 @@
+-- +goose Up
+-- Sync conflicts detected during delta pull when the conflict policy is "review".
+-- Each row captures the local and remote entity state for human resolution.
+CREATE TABLE IF NOT EXISTS sync_conflicts (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    lore_id TEXT NOT NULL,
+    local_payload TEXT NOT NULL,
+    remote_payload TEXT NOT NULL,
+    remote_sequence INTEGER NOT NULL,
+    detected_at TEXT NOT NULL,
+    resolved_at TEXT,
+    resolution TEXT CHECK (resolution IN ('local', 'remote', 'merged'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_sync_conflicts_lore_id ON sync_conflicts(lore_id);
+CREATE INDEX IF NOT EXISTS idx_sync_conflicts_resolved_at ON sync_conflicts(resolved_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_sync_conflicts_resolved_at;
+DROP INDEX IF EXISTS idx_sync_conflicts_lore_id;
+DROP TABLE IF EXISTS sync_conflicts;
@@ -0,0 +1,9 @@
+-- +goose Up
+-- Local-only usage statistics. These columns never leave this machine: they are
+-- excluded from change_log payloads and preserved across snapshot bootstrap.
+ALTER TABLE lore_entries ADD COLUMN usage_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE lore_entries ADD COLUMN last_used_at TEXT;
+
+-- +goose Down
+ALTER TABLE lore_entries DROP COLUMN last_used_at;
+ALTER TABLE lore_entries DROP COLUMN usage_count;
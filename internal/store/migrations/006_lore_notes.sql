@@ -0,0 +1,15 @@
+-- +goose Up
+-- Private annotations attached to lore. Notes are local-only: they are never
+-- written to change_log and survive snapshot bootstrap.
+CREATE TABLE IF NOT EXISTS lore_notes (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    lore_id TEXT NOT NULL,
+    note TEXT NOT NULL,
+    created_at TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_lore_notes_lore_id ON lore_notes(lore_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_lore_notes_lore_id;
+DROP TABLE IF EXISTS lore_notes;
@@ -0,0 +1,10 @@
+-- +goose Up
+-- Namespaces partition lore within one database file. The empty string is the
+-- default namespace, so existing rows remain visible to unscoped clients.
+ALTER TABLE lore_entries ADD COLUMN namespace TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_lore_entries_namespace ON lore_entries(namespace);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_lore_entries_namespace;
+ALTER TABLE lore_entries DROP COLUMN namespace;
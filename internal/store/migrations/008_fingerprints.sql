@@ -0,0 +1,11 @@
+-- +goose Up
+-- Fingerprints are hashes of normalized content used to detect exact
+-- re-records. Existing rows are backfilled by the store on open, since
+-- normalization is not expressible in SQL.
+ALTER TABLE lore_entries ADD COLUMN fingerprint TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_lore_entries_fingerprint ON lore_entries(namespace, fingerprint);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_lore_entries_fingerprint;
+ALTER TABLE lore_entries DROP COLUMN fingerprint;
@@ -0,0 +1,9 @@
+-- +goose Up
+-- confidence_updated_at records when confidence (with validation_count and
+-- last_validated_at) was last changed locally, so delta sync can keep local
+-- feedback that is newer than the incoming server version. NULL means the
+-- values came from the server.
+ALTER TABLE lore_entries ADD COLUMN confidence_updated_at TEXT;
+
+-- +goose Down
+ALTER TABLE lore_entries DROP COLUMN confidence_updated_at;
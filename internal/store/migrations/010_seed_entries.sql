@@ -0,0 +1,15 @@
+-- +goose Up
+-- seed_entries links declarative seed definitions (.recall/seed/) to the lore
+-- they produced. hash identifies the applied definition, so re-seeding only
+-- touches entries whose definition changed.
+CREATE TABLE IF NOT EXISTS seed_entries (
+    namespace TEXT NOT NULL DEFAULT '',
+    key TEXT NOT NULL,
+    lore_id TEXT NOT NULL,
+    hash TEXT NOT NULL,
+    applied_at TEXT NOT NULL,
+    PRIMARY KEY (namespace, key)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS seed_entries;
@@ -0,0 +1,68 @@
+-- +goose Up
+-- Read-only views for external dashboards (Grafana's SQLite data source and
+-- the like). Their columns are a stable contract, independent of the table
+-- layout: columns may be added, but renaming or removing one requires a new
+-- migration and a release note. Timestamps are RFC 3339 text; flags are 0/1.
+
+-- One row per live lore entry.
+CREATE VIEW IF NOT EXISTS v_lore_overview AS
+SELECT
+    id,
+    namespace,
+    category,
+    confidence,
+    validation_count,
+    usage_count,
+    source_id,
+    substr(content, 1, 120) AS summary,
+    length(content) AS content_length,
+    embedding IS NOT NULL AS has_embedding,
+    embedding_status,
+    synced_at IS NOT NULL AS synced,
+    created_at,
+    updated_at,
+    last_validated_at,
+    last_used_at
+FROM lore_entries
+WHERE deleted_at IS NULL;
+
+-- One row per kind of pending sync work: unpushed changes, legacy queue
+-- entries, unresolved conflicts and never-synced lore.
+CREATE VIEW IF NOT EXISTS v_sync_backlog AS
+SELECT 'unpushed_changes' AS kind, COUNT(*) AS pending, MIN(created_at) AS oldest_at
+FROM change_log
+WHERE sequence > COALESCE((SELECT CAST(value AS INTEGER) FROM sync_meta WHERE key = 'last_push_seq'), 0)
+UNION ALL
+SELECT 'queued_operations', COUNT(*), MIN(queued_at)
+FROM sync_queue
+UNION ALL
+SELECT 'unresolved_conflicts', COUNT(*), MIN(detected_at)
+FROM sync_conflicts
+WHERE resolved_at IS NULL
+UNION ALL
+SELECT 'unsynced_lore', COUNT(*), MIN(created_at)
+FROM lore_entries
+WHERE synced_at IS NULL AND deleted_at IS NULL;
+
+-- One row per namespace and category of live lore.
+CREATE VIEW IF NOT EXISTS v_category_health AS
+SELECT
+    namespace,
+    category,
+    COUNT(*) AS entries,
+    AVG(confidence) AS avg_confidence,
+    MIN(confidence) AS min_confidence,
+    MAX(confidence) AS max_confidence,
+    SUM(validation_count > 0) AS validated,
+    SUM(confidence < 0.3) AS low_confidence,
+    SUM(embedding IS NOT NULL) AS with_embedding,
+    SUM(usage_count) AS total_usage,
+    MAX(updated_at) AS last_updated_at
+FROM lore_entries
+WHERE deleted_at IS NULL
+GROUP BY namespace, category;
+
+-- +goose Down
+DROP VIEW IF EXISTS v_category_health;
+DROP VIEW IF EXISTS v_sync_backlog;
+DROP VIEW IF EXISTS v_lore_overview;
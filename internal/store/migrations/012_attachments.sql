@@ -0,0 +1,28 @@
+-- +goose Up
+-- Small artifacts (config diffs, log excerpts) attached to lore. Content is
+-- stored once per SHA-256 hash in attachments; lore_attachments links it to
+-- entries. Links are local-only unless marked synced, in which case they
+-- are written to change_log under table_name 'lore_attachments'.
+CREATE TABLE IF NOT EXISTS attachments (
+    hash TEXT PRIMARY KEY,
+    size INTEGER NOT NULL,
+    data BLOB NOT NULL,
+    created_at TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS lore_attachments (
+    lore_id TEXT NOT NULL,
+    hash TEXT NOT NULL,
+    name TEXT NOT NULL,
+    media_type TEXT NOT NULL,
+    synced INTEGER NOT NULL DEFAULT 0,
+    created_at TEXT NOT NULL,
+    PRIMARY KEY (lore_id, hash)
+);
+
+CREATE INDEX IF NOT EXISTS idx_lore_attachments_hash ON lore_attachments(hash);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_lore_attachments_hash;
+DROP TABLE IF EXISTS lore_attachments;
+DROP TABLE IF EXISTS attachments;
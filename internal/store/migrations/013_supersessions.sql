@@ -0,0 +1,14 @@
+-- +goose Up
+-- Links an outdated lore entry to the entry that replaces it. Queries rank
+-- superseded entries below others, or hide them on request. Local-only.
+CREATE TABLE IF NOT EXISTS lore_supersessions (
+    lore_id TEXT PRIMARY KEY,
+    superseded_by TEXT NOT NULL,
+    created_at TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_lore_supersessions_by ON lore_supersessions(superseded_by);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_lore_supersessions_by;
+DROP TABLE IF EXISTS lore_supersessions;
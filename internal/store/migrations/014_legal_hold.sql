@@ -0,0 +1,48 @@
+-- +goose Up
+-- Compliance mode. While a store is under legal hold (metadata key
+-- 'legal_hold'), every lore row that is deleted or replaced is copied to
+-- legal_hold_tombstones and never removed, each export is recorded in
+-- export_log with the SHA-256 of its content, and each pushed change_log
+-- entry is recorded in sync_egress_log.
+CREATE TABLE IF NOT EXISTS legal_hold_tombstones (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    lore_id TEXT NOT NULL,
+    origin TEXT NOT NULL,
+    payload TEXT NOT NULL,
+    held_at TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_legal_hold_tombstones_lore ON legal_hold_tombstones(lore_id);
+
+CREATE TABLE IF NOT EXISTS export_log (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    exported_at TEXT NOT NULL,
+    format TEXT NOT NULL,
+    destination TEXT NOT NULL,
+    entries INTEGER NOT NULL,
+    sha256 TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_export_log_exported_at ON export_log(exported_at);
+
+CREATE TABLE IF NOT EXISTS sync_egress_log (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    push_id TEXT NOT NULL,
+    pushed_at TEXT NOT NULL,
+    sequence INTEGER NOT NULL,
+    table_name TEXT NOT NULL,
+    entity_id TEXT NOT NULL,
+    operation TEXT NOT NULL,
+    payload_sha256 TEXT NOT NULL,
+    payload_bytes INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_sync_egress_log_pushed_at ON sync_egress_log(pushed_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_sync_egress_log_pushed_at;
+DROP TABLE IF EXISTS sync_egress_log;
+DROP INDEX IF EXISTS idx_export_log_exported_at;
+DROP TABLE IF EXISTS export_log;
+DROP INDEX IF EXISTS idx_legal_hold_tombstones_lore;
+DROP TABLE IF EXISTS legal_hold_tombstones;
@@ -0,0 +1,16 @@
+-- +goose Up
+-- Links the parts of content that was too long for one entry and was
+-- recorded in chunks. Parts share a group_id and are numbered from 1.
+-- Queries reassemble a group into one result. Local-only.
+CREATE TABLE IF NOT EXISTS lore_chunks (
+    lore_id TEXT PRIMARY KEY,
+    group_id TEXT NOT NULL,
+    part INTEGER NOT NULL,
+    created_at TEXT NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_lore_chunks_group ON lore_chunks(group_id, part);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_lore_chunks_group;
+DROP TABLE IF EXISTS lore_chunks;
@@ -0,0 +1,21 @@
+-- +goose Up
+-- Audit log of queries, written when Config.QueryLog is set: the query text,
+-- a hash of its embedding, the effective parameters, and the returned lore
+-- IDs with their scores. Pruned by age and row count on each write.
+-- Local-only.
+CREATE TABLE IF NOT EXISTS query_log (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    queried_at TEXT NOT NULL,
+    namespace TEXT NOT NULL DEFAULT '',
+    actor TEXT NOT NULL DEFAULT '',
+    query TEXT NOT NULL,
+    embedding_sha256 TEXT NOT NULL DEFAULT '',
+    params TEXT NOT NULL,
+    results TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_query_log_queried_at ON query_log(queried_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_query_log_queried_at;
+DROP TABLE IF EXISTS query_log;
@@ -0,0 +1,14 @@
+-- +goose Up
+-- Estimated token counts of lore content, per token estimator (model
+-- family). A count applies only while the entry's fingerprint matches, so
+-- edited content is re-estimated. Local-only.
+CREATE TABLE IF NOT EXISTS lore_tokens (
+    lore_id TEXT NOT NULL,
+    estimator TEXT NOT NULL,
+    fingerprint TEXT NOT NULL,
+    tokens INTEGER NOT NULL,
+    PRIMARY KEY (lore_id, estimator)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS lore_tokens;
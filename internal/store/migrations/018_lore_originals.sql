@@ -0,0 +1,13 @@
+-- +goose Up
+-- Content of lore entries as submitted, before Record's normalizers
+-- rewrote it, kept for audit when Config.PreserveOriginal is set.
+-- Local-only.
+CREATE TABLE IF NOT EXISTS lore_originals (
+    lore_id TEXT PRIMARY KEY,
+    content TEXT NOT NULL,
+    normalizers TEXT NOT NULL,
+    created_at TEXT NOT NULL
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS lore_originals;
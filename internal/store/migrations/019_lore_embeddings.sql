@@ -0,0 +1,18 @@
+-- +goose Up
+-- Additional embeddings per lore entry, keyed by model name, so entries can
+-- carry vectors from an old and a new model during a model transition.
+-- lore_entries.embedding keeps the vector of the store's embedding_model.
+-- Local-only.
+CREATE TABLE IF NOT EXISTS lore_embeddings (
+    lore_id TEXT NOT NULL,
+    model TEXT NOT NULL,
+    embedding BLOB NOT NULL,
+    created_at TEXT NOT NULL,
+    PRIMARY KEY (lore_id, model)
+);
+
+CREATE INDEX IF NOT EXISTS idx_lore_embeddings_model ON lore_embeddings(model);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_lore_embeddings_model;
+DROP TABLE IF EXISTS lore_embeddings;
@@ -0,0 +1,19 @@
+-- +goose Up
+-- Feedback given per lore entry and actor, so repeats within
+-- Config.FeedbackWindow can be skipped. Only written while a window is
+-- configured, and pruned as events age out of it. Local-only.
+CREATE TABLE IF NOT EXISTS feedback_events (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    lore_id TEXT NOT NULL,
+    actor TEXT NOT NULL,
+    outcome TEXT NOT NULL,
+    created_at TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_feedback_events_lore_actor ON feedback_events(lore_id, actor, outcome, created_at);
+CREATE INDEX IF NOT EXISTS idx_feedback_events_created_at ON feedback_events(created_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_feedback_events_created_at;
+DROP INDEX IF EXISTS idx_feedback_events_lore_actor;
+DROP TABLE IF EXISTS feedback_events;
@@ -0,0 +1,17 @@
+-- +goose Up
+-- Content edits made with Client.Update, as compact word diffs from the
+-- previous content, so History can show what changed between versions.
+-- Local-only: Engram receives the new content through the change_log.
+CREATE TABLE IF NOT EXISTS lore_revisions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    lore_id TEXT NOT NULL,
+    actor TEXT NOT NULL,
+    diff TEXT NOT NULL,
+    created_at TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_lore_revisions_lore_id ON lore_revisions(lore_id, id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_lore_revisions_lore_id;
+DROP TABLE IF EXISTS lore_revisions;
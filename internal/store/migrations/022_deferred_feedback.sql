@@ -0,0 +1,18 @@
+-- +goose Up
+-- Feedback given under Config.ConfirmFeedback on lore that had not been
+-- pushed yet, so Engram had nothing to confirm. It is applied locally and
+-- replayed to Engram once the lore has a synced_at. Local-only.
+CREATE TABLE IF NOT EXISTS deferred_feedback (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    lore_id TEXT NOT NULL,
+    outcome TEXT NOT NULL,
+    queued_at TEXT NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_deferred_feedback_lore_id ON deferred_feedback(lore_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_deferred_feedback_lore_id;
+DROP TABLE IF EXISTS deferred_feedback;
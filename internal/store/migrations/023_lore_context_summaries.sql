@@ -0,0 +1,13 @@
+-- +goose Up
+-- Context that Record summarized to fit MaxContextLength because
+-- Config.SummarizeContext was set: the submitted length, and whether the
+-- summary still had to be truncated. Local-only.
+CREATE TABLE IF NOT EXISTS lore_context_summaries (
+    lore_id TEXT PRIMARY KEY,
+    original_length INTEGER NOT NULL,
+    truncated INTEGER NOT NULL DEFAULT 0,
+    created_at TEXT NOT NULL
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS lore_context_summaries;
@@ -0,0 +1,16 @@
+-- +goose Up
+-- Links lore recorded together by RecordEpisode: related lessons from one
+-- task, inserted atomically. Entries share an episode_id and are numbered
+-- from 1 in the order they were given. Local-only.
+CREATE TABLE IF NOT EXISTS lore_episodes (
+    lore_id TEXT PRIMARY KEY,
+    episode_id TEXT NOT NULL,
+    position INTEGER NOT NULL,
+    created_at TEXT NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_lore_episodes_episode ON lore_episodes(episode_id, position);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_lore_episodes_episode;
+DROP TABLE IF EXISTS lore_episodes;
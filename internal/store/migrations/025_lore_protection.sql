@@ -0,0 +1,13 @@
+-- +goose Up
+-- Protected lore: feedback cannot lower confidence below floor unless the
+-- actor's access policy level is at least min_level. Local-only.
+CREATE TABLE IF NOT EXISTS lore_protection (
+    lore_id TEXT PRIMARY KEY,
+    floor REAL NOT NULL,
+    min_level INTEGER NOT NULL,
+    protected_by TEXT NOT NULL DEFAULT '',
+    created_at TEXT NOT NULL
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS lore_protection;
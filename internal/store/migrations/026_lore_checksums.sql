@@ -0,0 +1,8 @@
+-- +goose Up
+-- Content checksum of each lore row, rewritten on every write and
+-- verified on read to detect corruption or tampering. Empty until
+-- backfilled on open.
+ALTER TABLE lore_entries ADD COLUMN checksum TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE lore_entries DROP COLUMN checksum;
@@ -0,0 +1,13 @@
+-- +goose Up
+-- Pinned query results: the IDs and scores a query returned, in rank
+-- order (JSON), kept so a run can be reproduced or audited. Local-only.
+CREATE TABLE IF NOT EXISTS query_pins (
+    id TEXT PRIMARY KEY,
+    namespace TEXT NOT NULL DEFAULT '',
+    actor TEXT NOT NULL DEFAULT '',
+    entries TEXT NOT NULL,
+    created_at TEXT NOT NULL
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS query_pins;
@@ -0,0 +1,20 @@
+-- +goose Up
+-- Lore staged by Config.Inbox, awaiting human approval. Staged entries
+-- are local-only: they reach lore_entries and change_log when approved.
+CREATE TABLE IF NOT EXISTS lore_inbox (
+    id TEXT PRIMARY KEY,
+    namespace TEXT NOT NULL DEFAULT '',
+    content TEXT NOT NULL,
+    context TEXT NOT NULL DEFAULT '',
+    category TEXT NOT NULL,
+    confidence REAL NOT NULL,
+    sources TEXT NOT NULL DEFAULT '',
+    actor TEXT NOT NULL DEFAULT '',
+    created_at TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_lore_inbox_namespace ON lore_inbox(namespace, created_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_lore_inbox_namespace;
+DROP TABLE IF EXISTS lore_inbox;
@@ -0,0 +1,19 @@
+-- +goose Up
+-- Every feedback outcome given through this store, with the source_id
+-- that recorded the lore, for per-source reports. Unlike
+-- feedback_events, it is not pruned. Local-only.
+CREATE TABLE IF NOT EXISTS feedback_log (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    namespace TEXT NOT NULL DEFAULT '',
+    lore_id TEXT NOT NULL,
+    source_id TEXT NOT NULL,
+    outcome TEXT NOT NULL,
+    actor TEXT NOT NULL DEFAULT '',
+    created_at TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_feedback_log_created_at ON feedback_log(namespace, created_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_feedback_log_created_at;
+DROP TABLE IF EXISTS feedback_log;
@@ -0,0 +1,18 @@
+-- +goose Up
+-- Owner and reviewer of a lore entry, so teams can distribute maintenance
+-- of the knowledge base. An empty value is unassigned. Local-only.
+CREATE TABLE IF NOT EXISTS lore_assignments (
+    lore_id TEXT PRIMARY KEY,
+    owner TEXT NOT NULL DEFAULT '',
+    reviewer TEXT NOT NULL DEFAULT '',
+    assigned_by TEXT NOT NULL DEFAULT '',
+    updated_at TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_lore_assignments_owner ON lore_assignments(owner);
+CREATE INDEX IF NOT EXISTS idx_lore_assignments_reviewer ON lore_assignments(reviewer);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_lore_assignments_reviewer;
+DROP INDEX IF EXISTS idx_lore_assignments_owner;
+DROP TABLE IF EXISTS lore_assignments;
@@ -0,0 +1,35 @@
+-- +goose Up
+-- Full-text index of lore content and context for keyword queries, which
+-- also reach entries without embeddings. An external-content table over
+-- lore_entries keyed by rowid, so the triggers below update it by rowid
+-- rather than scanning it; VACUUM may renumber rowids, so Store.Maintain
+-- rebuilds it afterwards. Soft-deleted entries are filtered when querying.
+-- Local-only.
+CREATE VIRTUAL TABLE IF NOT EXISTS lore_fts USING fts5(content, context, content='lore_entries', content_rowid='rowid');
+
+INSERT INTO lore_fts (lore_fts) VALUES ('rebuild');
+
+-- +goose StatementBegin
+CREATE TRIGGER IF NOT EXISTS lore_fts_insert AFTER INSERT ON lore_entries BEGIN
+    INSERT INTO lore_fts (rowid, content, context) VALUES (new.rowid, new.content, new.context);
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER IF NOT EXISTS lore_fts_update AFTER UPDATE OF content, context ON lore_entries BEGIN
+    INSERT INTO lore_fts (lore_fts, rowid, content, context) VALUES ('delete', old.rowid, old.content, old.context);
+    INSERT INTO lore_fts (rowid, content, context) VALUES (new.rowid, new.content, new.context);
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER IF NOT EXISTS lore_fts_delete AFTER DELETE ON lore_entries BEGIN
+    INSERT INTO lore_fts (lore_fts, rowid, content, context) VALUES ('delete', old.rowid, old.content, old.context);
+END;
+-- +goose StatementEnd
+
+-- +goose Down
+DROP TRIGGER IF EXISTS lore_fts_delete;
+DROP TRIGGER IF EXISTS lore_fts_update;
+DROP TRIGGER IF EXISTS lore_fts_insert;
+DROP TABLE IF EXISTS lore_fts;
@@ -0,0 +1,13 @@
+-- +goose Up
+-- Model vectors describe an entry's content, so drop them when it changes,
+-- whichever path changed it (edits, delta sync, imports). The local
+-- embedding worker then embeds the new content. Local-only.
+-- +goose StatementBegin
+CREATE TRIGGER IF NOT EXISTS lore_embeddings_stale AFTER UPDATE OF content ON lore_entries
+WHEN old.content IS NOT new.content BEGIN
+    DELETE FROM lore_embeddings WHERE lore_id = new.id;
+END;
+-- +goose StatementEnd
+
+-- +goose Down
+DROP TRIGGER IF EXISTS lore_embeddings_stale;
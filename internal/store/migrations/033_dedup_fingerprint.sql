@@ -0,0 +1,27 @@
+-- +goose Up
+-- dedup_fingerprint is the fingerprint claimed by a deduplicating Record
+-- (Config.DedupExact). The unique index makes the claim atomic, so two
+-- writers racing to record the same content cannot both insert it. Other
+-- writers (sync, imports, plain Record) leave it null, since duplicates
+-- are allowed there. Local-only.
+ALTER TABLE lore_entries ADD COLUMN dedup_fingerprint TEXT;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_lore_entries_dedup_fingerprint
+    ON lore_entries(namespace, dedup_fingerprint)
+    WHERE dedup_fingerprint IS NOT NULL AND deleted_at IS NULL;
+
+-- A claim lapses when the entry's content or namespace changes or it is
+-- deleted, so a later restore or move cannot collide with a newer claim.
+-- +goose StatementBegin
+CREATE TRIGGER IF NOT EXISTS lore_entries_dedup_release AFTER UPDATE OF content, namespace, deleted_at ON lore_entries
+WHEN new.dedup_fingerprint IS NOT NULL AND (
+    old.content IS NOT new.content OR old.namespace IS NOT new.namespace OR new.deleted_at IS NOT NULL
+) BEGIN
+    UPDATE lore_entries SET dedup_fingerprint = NULL WHERE id = new.id;
+END;
+-- +goose StatementEnd
+
+-- +goose Down
+DROP TRIGGER IF EXISTS lore_entries_dedup_release;
+DROP INDEX IF EXISTS idx_lore_entries_dedup_fingerprint;
+ALTER TABLE lore_entries DROP COLUMN dedup_fingerprint;
@@ -1,14 +1,26 @@
 package store
 
 import (
+	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 )
 
 // DefaultStoreRoot returns the root directory for all stores.
-// If RECALL_HOME is set, uses $RECALL_HOME/stores.
-// Otherwise defaults to ~/.recall/stores, falls back to ./.recall/stores if home dir unavailable.
+//
+// Resolution order:
+//  1. $RECALL_HOME/stores, if RECALL_HOME is set
+//  2. <data home>/recall/stores, where the data home is $XDG_DATA_HOME,
+//     ~/Library/Application Support on macOS, %LocalAppData% on Windows,
+//     or ~/.local/share
+//
+// A pre-XDG ~/.recall/stores is first moved there (see MoveLegacyStoreRoot).
+// If that move fails, the legacy root is returned so its stores stay usable.
+//
+// Falls back to ./.recall/stores if the home directory is unavailable.
 func DefaultStoreRoot() string {
 	if recallHome := os.Getenv("RECALL_HOME"); recallHome != "" {
 		return filepath.Join(recallHome, "stores")
@@ -19,9 +31,120 @@ func DefaultStoreRoot() string {
 		cwd, _ := os.Getwd()
 		return filepath.Join(cwd, ".recall", "stores")
 	}
+	root := filepath.Join(dataHome(home), "recall", "stores")
+	if _, err := MoveLegacyStoreRoot(home, root); err != nil {
+		return LegacyStoreRoot(home)
+	}
+	return root
+}
+
+// LegacyStoreRoot returns the pre-XDG store root under home: ~/.recall/stores.
+func LegacyStoreRoot(home string) string {
 	return filepath.Join(home, ".recall", "stores")
 }
 
+// syncPauseFile is the name of the default sync pause file beside the
+// store root (see recall.DefaultSyncPauseFile).
+const syncPauseFile = "sync.disabled"
+
+// LegacyMovedMarker is the file left in ~/.recall once its stores have
+// been moved, naming their new root.
+const LegacyMovedMarker = "stores.moved"
+
+// MoveLegacyStoreRoot moves the pre-XDG store root under home to root and
+// reports whether it did. It does nothing if there is no legacy root, or if
+// root already exists; the legacy root is then left as it is.
+//
+// The move is a rename, falling back to a copy when the two are on
+// different filesystems. The copy is made beside root and renamed into
+// place, so an interrupted copy never leaves a partial root.
+func MoveLegacyStoreRoot(home, root string) (bool, error) {
+	legacy := LegacyStoreRoot(home)
+	if info, err := os.Stat(legacy); err != nil || !info.IsDir() {
+		return false, nil
+	}
+	if _, err := os.Stat(root); err == nil {
+		return false, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(root), 0755); err != nil {
+		return false, fmt.Errorf("move legacy stores: %w", err)
+	}
+
+	if err := os.Rename(legacy, root); err != nil {
+		// Another process may have moved it first
+		if _, statErr := os.Stat(root); statErr == nil {
+			return false, nil
+		}
+		partial := root + ".partial"
+		_ = os.RemoveAll(partial)
+		if err := copyTree(legacy, partial); err != nil {
+			_ = os.RemoveAll(partial)
+			return false, fmt.Errorf("move legacy stores: %w", err)
+		}
+		if err := os.Rename(partial, root); err != nil {
+			_ = os.RemoveAll(partial)
+			return false, fmt.Errorf("move legacy stores: %w", err)
+		}
+		// root holds the stores now; a leftover legacy root is ignored
+		_ = os.RemoveAll(legacy)
+	}
+
+	// The sync pause file sits beside the store root and moves with it, so
+	// a paused install stays paused
+	oldPause := filepath.Join(filepath.Dir(legacy), syncPauseFile)
+	if _, err := os.Stat(oldPause); err == nil {
+		newPause := filepath.Join(filepath.Dir(root), syncPauseFile)
+		if err := os.Rename(oldPause, newPause); err != nil && copyFile(oldPause, newPause) == nil {
+			_ = os.Remove(oldPause)
+		}
+	}
+
+	marker := filepath.Join(filepath.Dir(legacy), LegacyMovedMarker)
+	_ = os.WriteFile(marker, []byte("Recall stores moved to "+root+"\n"), 0644)
+	return true, nil
+}
+
+// copyTree copies the directories and regular files under src to dst.
+func copyTree(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		switch {
+		case d.IsDir():
+			return os.MkdirAll(target, 0755)
+		case d.Type().IsRegular():
+			return copyFile(path, target)
+		default:
+			return nil
+		}
+	})
+}
+
+// dataHome returns the per-user data directory for the current platform.
+func dataHome(home string) string {
+	// The XDG spec ignores relative paths
+	if xdg := os.Getenv("XDG_DATA_HOME"); filepath.IsAbs(xdg) {
+		return xdg
+	}
+	switch runtime.GOOS {
+	case "darwin":
+		return filepath.Join(home, "Library", "Application Support")
+	case "windows":
+		if local := os.Getenv("LocalAppData"); local != "" {
+			return local
+		}
+		return filepath.Join(home, "AppData", "Local")
+	default:
+		return filepath.Join(home, ".local", "share")
+	}
+}
+
 // EncodeStorePath encodes a store ID for filesystem use.
 // Replaces "/" with "__" for path-style store IDs.
 func EncodeStorePath(storeID string) string {
@@ -34,7 +157,7 @@ func DecodeStorePath(encoded string) string {
 }
 
 // StoreDBPath returns the full path to a store's database file.
-// Example: StoreDBPath("org/team") -> ~/.recall/stores/org__team/lore.db
+// Example: StoreDBPath("org/team") -> ~/.local/share/recall/stores/org__team/lore.db
 func StoreDBPath(storeID string) string {
 	encoded := EncodeStorePath(storeID)
 	return filepath.Join(DefaultStoreRoot(), encoded, "lore.db")
@@ -3,6 +3,7 @@ package store_test
 import (
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
 
@@ -74,12 +75,25 @@ func TestEncodeDecodeRoundTrip(t *testing.T) {
 	}
 }
 
+// tempDataHome points HOME and XDG_DATA_HOME at temp directories and returns
+// the home directory and the store root under the data home.
+func tempDataHome(t *testing.T) (home, root string) {
+	t.Helper()
+	home = t.TempDir()
+	xdg := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("RECALL_HOME", "")
+	t.Setenv("XDG_DATA_HOME", xdg)
+	return home, filepath.Join(xdg, "recall", "stores")
+}
+
 func TestDefaultStoreRoot(t *testing.T) {
+	tempDataHome(t)
 	root := store.DefaultStoreRoot()
 
-	// Should contain ".recall/stores"
-	if !strings.Contains(root, ".recall") {
-		t.Errorf("DefaultStoreRoot() = %q, should contain .recall", root)
+	// Should contain "recall/stores"
+	if !strings.Contains(root, "recall") {
+		t.Errorf("DefaultStoreRoot() = %q, should contain recall", root)
 	}
 	if !strings.HasSuffix(root, "stores") {
 		t.Errorf("DefaultStoreRoot() = %q, should end with stores", root)
@@ -104,14 +118,9 @@ func TestDefaultStoreRoot_RECALL_HOME_Override(t *testing.T) {
 }
 
 func TestDefaultStoreRoot_RECALL_HOME_Empty_FallsBack(t *testing.T) {
-	t.Setenv("RECALL_HOME", "")
+	_, expected := tempDataHome(t)
 
 	root := store.DefaultStoreRoot()
-	home, err := os.UserHomeDir()
-	if err != nil {
-		t.Skipf("cannot determine home directory: %v", err)
-	}
-	expected := filepath.Join(home, ".recall", "stores")
 
 	if root != expected {
 		t.Errorf("DefaultStoreRoot() = %q, want %q", root, expected)
@@ -131,24 +140,23 @@ func TestStoreDBPath_RECALL_HOME_Override(t *testing.T) {
 }
 
 func TestDefaultStoreRoot_UsesHomeDir(t *testing.T) {
-	home, err := os.UserHomeDir()
-	if err != nil {
-		t.Skipf("cannot determine home directory: %v", err)
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("RECALL_HOME", "")
+	t.Setenv("XDG_DATA_HOME", "")
+	if runtime.GOOS == "windows" {
+		t.Setenv("LocalAppData", "")
 	}
 
 	root := store.DefaultStoreRoot()
-	expected := filepath.Join(home, ".recall", "stores")
 
-	if root != expected {
-		t.Errorf("DefaultStoreRoot() = %q, want %q", root, expected)
+	if !strings.HasPrefix(root, home) {
+		t.Errorf("DefaultStoreRoot() = %q, want a path under %q", root, home)
 	}
 }
 
 func TestStoreDBPath(t *testing.T) {
-	home, err := os.UserHomeDir()
-	if err != nil {
-		t.Skipf("cannot determine home directory: %v", err)
-	}
+	_, root := tempDataHome(t)
 
 	tests := []struct {
 		name     string
@@ -158,22 +166,22 @@ func TestStoreDBPath(t *testing.T) {
 		{
 			"simple",
 			"my-project",
-			filepath.Join(home, ".recall", "stores", "my-project", "lore.db"),
+			filepath.Join(root, "my-project", "lore.db"),
 		},
 		{
 			"with slash",
 			"org/team",
-			filepath.Join(home, ".recall", "stores", "org__team", "lore.db"),
+			filepath.Join(root, "org__team", "lore.db"),
 		},
 		{
 			"deep path",
 			"a/b/c/d",
-			filepath.Join(home, ".recall", "stores", "a__b__c__d", "lore.db"),
+			filepath.Join(root, "a__b__c__d", "lore.db"),
 		},
 		{
 			"default store",
 			"default",
-			filepath.Join(home, ".recall", "stores", "default", "lore.db"),
+			filepath.Join(root, "default", "lore.db"),
 		},
 	}
 
@@ -193,3 +201,110 @@ func TestStoreDBPath_EndsWithLoreDB(t *testing.T) {
 		t.Errorf("StoreDBPath() = %q, should end with lore.db", path)
 	}
 }
+
+func TestDefaultStoreRoot_XDGDataHome(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("RECALL_HOME", "")
+	xdg := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", xdg)
+
+	root := store.DefaultStoreRoot()
+	expected := filepath.Join(xdg, "recall", "stores")
+	if root != expected {
+		t.Errorf("DefaultStoreRoot() = %q, want %q", root, expected)
+	}
+}
+
+func TestDefaultStoreRoot_PlatformDataHome(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("windows uses LocalAppData")
+	}
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("RECALL_HOME", "")
+	t.Setenv("XDG_DATA_HOME", "relative/ignored")
+
+	expected := filepath.Join(home, ".local", "share", "recall", "stores")
+	if runtime.GOOS == "darwin" {
+		expected = filepath.Join(home, "Library", "Application Support", "recall", "stores")
+	}
+	if root := store.DefaultStoreRoot(); root != expected {
+		t.Errorf("DefaultStoreRoot() = %q, want %q", root, expected)
+	}
+}
+
+// legacyStore creates a pre-XDG ~/.recall/stores under home holding one
+// store, and returns the store's database path relative to the root.
+func legacyStore(t *testing.T, home string) string {
+	t.Helper()
+	db := filepath.Join("org__team", "lore.db")
+	path := filepath.Join(store.LegacyStoreRoot(home), db)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("lore"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func TestDefaultStoreRoot_MovesLegacyRoot(t *testing.T) {
+	home, expected := tempDataHome(t)
+	db := legacyStore(t, home)
+	if err := os.WriteFile(filepath.Join(home, ".recall", "sync.disabled"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if root := store.DefaultStoreRoot(); root != expected {
+		t.Fatalf("DefaultStoreRoot() = %q, want %q", root, expected)
+	}
+	if data, err := os.ReadFile(filepath.Join(expected, db)); err != nil || string(data) != "lore" {
+		t.Errorf("moved store = %q, %v; want its database", data, err)
+	}
+	if _, err := os.Stat(store.LegacyStoreRoot(home)); !os.IsNotExist(err) {
+		t.Errorf("legacy root still exists after the move: %v", err)
+	}
+	// A paused install stays paused
+	if _, err := os.Stat(filepath.Join(filepath.Dir(expected), "sync.disabled")); err != nil {
+		t.Errorf("sync pause file not moved: %v", err)
+	}
+	marker, err := os.ReadFile(filepath.Join(home, ".recall", store.LegacyMovedMarker))
+	if err != nil || !strings.Contains(string(marker), expected) {
+		t.Errorf("marker = %q, %v; want it to name %s", marker, err, expected)
+	}
+
+	// Later calls find nothing left to move
+	if moved, err := store.MoveLegacyStoreRoot(home, expected); moved || err != nil {
+		t.Errorf("MoveLegacyStoreRoot() again = %v, %v; want false, nil", moved, err)
+	}
+}
+
+func TestDefaultStoreRoot_FreshInstall(t *testing.T) {
+	home, expected := tempDataHome(t)
+
+	if root := store.DefaultStoreRoot(); root != expected {
+		t.Errorf("DefaultStoreRoot() = %q, want %q", root, expected)
+	}
+	if _, err := os.Stat(filepath.Join(home, ".recall")); !os.IsNotExist(err) {
+		t.Errorf("fresh install created ~/.recall: %v", err)
+	}
+}
+
+func TestDefaultStoreRoot_LegacyAndDataHomeBothPresent(t *testing.T) {
+	home, expected := tempDataHome(t)
+	db := legacyStore(t, home)
+	if err := os.MkdirAll(expected, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	// The data home wins and the legacy root is left alone
+	if root := store.DefaultStoreRoot(); root != expected {
+		t.Errorf("DefaultStoreRoot() = %q, want %q", root, expected)
+	}
+	if _, err := os.Stat(filepath.Join(store.LegacyStoreRoot(home), db)); err != nil {
+		t.Errorf("legacy store after resolving: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(expected, db)); !os.IsNotExist(err) {
+		t.Errorf("legacy store copied into an existing root: %v", err)
+	}
+}
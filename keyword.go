@@ -0,0 +1,167 @@
+package recall
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// QueryMode selects how Query matches lore; see QueryParams.Mode.
+type QueryMode string
+
+const (
+	// QueryModeSemantic ranks lore with embeddings by similarity to
+	// QueryEmbedding, which is required.
+	QueryModeSemantic QueryMode = "semantic"
+
+	// QueryModeKeyword matches the words of Query against lore content and
+	// context with a full-text index, ranked by BM25 relevance. It reaches
+	// entries without embeddings, such as lore recorded locally and still
+	// pending embedding. QueryEmbedding is ignored.
+	QueryModeKeyword QueryMode = "keyword"
+
+	// QueryModeHybrid fuses the semantic ranking with the keyword ranking,
+	// so entries pending embedding are found alongside similar ones.
+	// Without QueryEmbedding it is keyword matching alone.
+	QueryModeHybrid QueryMode = "hybrid"
+)
+
+// maxKeywordMatches caps the entries a keyword match reads from the
+// store, best first, bounding the cost of common words in large stores.
+const maxKeywordMatches = 1000
+
+// rebuildKeywordIndex re-reads the keyword index from lore_entries. The
+// index is keyed by rowid, which VACUUM may renumber.
+const rebuildKeywordIndex = `INSERT INTO lore_fts (lore_fts) VALUES ('rebuild')`
+
+// hybridRankConstant damps the weight of top ranks in reciprocal rank
+// fusion: an entry scores 1/(hybridRankConstant+rank) per ranking.
+const hybridRankConstant = 60
+
+// validateQueryMode checks params.Mode against the rest of params.
+func validateQueryMode(params QueryParams) error {
+	switch params.Mode {
+	case "", QueryModeKeyword, QueryModeHybrid:
+		return nil
+	case QueryModeSemantic:
+		if len(params.QueryEmbedding) == 0 {
+			return &ValidationError{Field: "QueryEmbedding", Message: "required in semantic mode"}
+		}
+		return nil
+	default:
+		return &ValidationError{Field: "Mode", Message: fmt.Sprintf("unknown mode %q (use semantic, keyword or hybrid)", params.Mode)}
+	}
+}
+
+// usesSimilarity reports whether params rank purely by similarity, so
+// similarity cutoffs and the remote fallback apply.
+func (p QueryParams) usesSimilarity() bool {
+	return p.Mode == "" || p.Mode == QueryModeSemantic
+}
+
+// ftsMatchExpression turns free text into an FTS5 expression matching any
+// of its words. Each word is quoted, so FTS5 syntax in the text is taken
+// literally. Returns "" if the text has no words.
+func ftsMatchExpression(text string) string {
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, w := range words {
+		words[i] = `"` + w + `"`
+	}
+	return strings.Join(words, " OR ")
+}
+
+// queryKeyword matches params.Query with the keyword index; the store
+// returns the matches by relevance, and superseded entries go last.
+// Match counts and phase timings are recorded in stats. The store reads
+// at most maxKeywordMatches entries before they are counted, so
+// stats.TotalMatched is capped at 1000.
+func (c *Client) queryKeyword(params QueryParams, stats *QueryStats) ([]Lore, error) {
+	params.keywordMatch = ftsMatchExpression(params.Query)
+	if params.keywordMatch == "" {
+		return nil, &ValidationError{Field: "Query", Message: "needs at least one word in keyword mode"}
+	}
+
+	start := time.Now()
+	lore, err := c.store.Query(params)
+	stats.FilterTime = time.Since(start)
+	if err != nil {
+		return nil, fmt.Errorf("client: query: %w", err)
+	}
+	superseded, err := c.supersededIDs(lore)
+	if err != nil {
+		return nil, fmt.Errorf("client: query: %w", err)
+	}
+	if params.HideSuperseded {
+		lore = withoutSuperseded(lore, superseded)
+	}
+	stats.countMatches(lore)
+
+	start = time.Now()
+	rankSupersededLast(lore, superseded)
+	if params.K > 0 && len(lore) > params.K {
+		lore = lore[:params.K]
+	}
+	stats.RankTime = time.Since(start)
+	return lore, nil
+}
+
+// queryHybrid fuses the semantic and keyword rankings of params by
+// reciprocal rank fusion. Also returns the similarity of the entries that
+// were scored semantically. Without QueryEmbedding, or when Query has no
+// words, only the other ranking is used.
+func (c *Client) queryHybrid(params QueryParams, stats *QueryStats) ([]Lore, map[string]float64, error) {
+	k := params.K
+	params.K = 0
+
+	var semantic, keyword []Lore
+	var similarity map[string]float64
+	var err error
+	if len(params.QueryEmbedding) > 0 {
+		semantic, similarity, err = c.queryWithSimilarity(params, stats)
+		if err != nil {
+			return nil, nil, err
+		}
+		if ftsMatchExpression(params.Query) == "" {
+			if k > 0 && len(semantic) > k {
+				semantic = semantic[:k]
+			}
+			return semantic, similarity, nil
+		}
+	}
+	var keywordStats QueryStats
+	keyword, err = c.queryKeyword(params, &keywordStats)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	start := time.Now()
+	scores := make(map[string]float64, len(semantic)+len(keyword))
+	var fused []Lore
+	for _, ranking := range [][]Lore{semantic, keyword} {
+		for i, l := range ranking {
+			if _, ok := scores[l.ID]; !ok {
+				fused = append(fused, l)
+			}
+			scores[l.ID] += 1 / float64(hybridRankConstant+i+1)
+		}
+	}
+	superseded, err := c.supersededIDs(fused)
+	if err != nil {
+		return nil, nil, fmt.Errorf("client: query: %w", err)
+	}
+	stats.countMatches(fused)
+	sort.SliceStable(fused, func(i, j int) bool {
+		return scores[fused[i].ID] > scores[fused[j].ID]
+	})
+	rankSupersededLast(fused, superseded)
+	if k > 0 && len(fused) > k {
+		fused = fused[:k]
+	}
+	stats.FilterTime += keywordStats.FilterTime
+	stats.RankTime += keywordStats.RankTime + time.Since(start)
+	return fused, similarity, nil
+}
@@ -0,0 +1,203 @@
+package recall
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func newKeywordClient(t *testing.T) *Client {
+	t.Helper()
+	client := newTestClient(t, Config{})
+
+	now := time.Now().UTC()
+	for _, l := range []Lore{
+		{ID: "embedded", Content: "Connection pools need warming before traffic shifts.", Embedding: PackFloat32([]float32{1, 0})},
+		{ID: "other", Content: "Feature flags belong in config, not code.", Embedding: PackFloat32([]float32{0, 1})},
+		{ID: "pending", Content: "Kafka consumer rebalances stall when the connection pool is exhausted.", Context: "pool sizing"},
+		{ID: "unrelated", Content: "Use UTC in every log line."},
+	} {
+		l.Category, l.Confidence, l.SourceID, l.CreatedAt, l.UpdatedAt = CategoryPerformanceInsight, 0.6, "test", now, now
+		if err := client.store.InsertLore(&l); err != nil {
+			t.Fatalf("InsertLore: %v", err)
+		}
+	}
+	return client
+}
+
+func loreIDs(lore []Lore) []string {
+	ids := make([]string, len(lore))
+	for i, l := range lore {
+		ids[i] = l.ID
+	}
+	return ids
+}
+
+func TestClient_Query_KeywordMode(t *testing.T) {
+	client := newKeywordClient(t)
+	ctx := context.Background()
+
+	result, err := client.Query(ctx, QueryParams{Query: "connection pool", Mode: QueryModeKeyword})
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	got := loreIDs(result.Lore)
+	if len(got) != 2 || got[0] != "pending" || got[1] != "embedded" {
+		t.Errorf("keyword results = %v, want [pending embedded]", got)
+	}
+	if result.Stats.TotalMatched != 2 {
+		t.Errorf("TotalMatched = %d, want 2", result.Stats.TotalMatched)
+	}
+	if len(result.SessionRefs) != 2 {
+		t.Errorf("SessionRefs = %v, want refs for feedback", result.SessionRefs)
+	}
+
+	// FTS5 syntax in the query is taken literally
+	if _, err := client.Query(ctx, QueryParams{Query: `pool" OR (NEAR`, Mode: QueryModeKeyword}); err != nil {
+		t.Errorf("Query with FTS5 syntax: %v", err)
+	}
+
+	// Edits are reindexed and deleted entries drop out
+	if _, err := client.Update("pending", "Kafka consumers stall when brokers throttle."); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if err := client.store.DeleteLoreByID("embedded"); err != nil {
+		t.Fatalf("DeleteLoreByID: %v", err)
+	}
+	result, err = client.Query(ctx, QueryParams{Query: "connection", Mode: QueryModeKeyword})
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	if len(result.Lore) != 0 {
+		t.Errorf("after edit and delete = %v, want none", loreIDs(result.Lore))
+	}
+	result, err = client.Query(ctx, QueryParams{Query: "brokers", Mode: QueryModeKeyword})
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	if got := loreIDs(result.Lore); len(got) != 1 || got[0] != "pending" {
+		t.Errorf("edited content = %v, want [pending]", got)
+	}
+
+	// Matches stay in the store's namespace
+	client.store.SetNamespace("team-b")
+	result, err = client.Query(ctx, QueryParams{Query: "brokers", Mode: QueryModeKeyword})
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	if len(result.Lore) != 0 {
+		t.Errorf("other namespace = %v, want none", loreIDs(result.Lore))
+	}
+}
+
+func TestClient_Query_HybridMode(t *testing.T) {
+	client := newKeywordClient(t)
+
+	semantic, err := client.Query(context.Background(), QueryParams{Query: "connection pool", QueryEmbedding: []float32{1, 0}, K: 10})
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	for _, l := range semantic.Lore {
+		if l.ID == "pending" {
+			t.Fatal("semantic query returned an entry without an embedding")
+		}
+	}
+
+	result, err := client.Query(context.Background(), QueryParams{
+		Query: "connection pool", QueryEmbedding: []float32{1, 0}, K: 3, Mode: QueryModeHybrid,
+	})
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	got := loreIDs(result.Lore)
+	// embedded ranks high in both; pending only by keyword
+	if len(got) != 3 || got[0] != "embedded" || got[1] != "pending" || got[2] != "other" {
+		t.Errorf("hybrid results = %v, want [embedded pending other]", got)
+	}
+	if _, ok := result.SessionRefs["L1"]; !ok {
+		t.Errorf("SessionRefs = %v", result.SessionRefs)
+	}
+}
+
+func TestClient_Query_ModeValidation(t *testing.T) {
+	client := newKeywordClient(t)
+
+	for name, tc := range map[string]struct {
+		params QueryParams
+		field  string
+	}{
+		"semantic without embedding": {QueryParams{Query: "pool", Mode: QueryModeSemantic}, "QueryEmbedding"},
+		"unknown mode":               {QueryParams{Query: "pool", Mode: "fuzzy"}, "Mode"},
+		"keyword without words":      {QueryParams{Query: "?!", Mode: QueryModeKeyword}, "Query"},
+	} {
+		_, err := client.Query(context.Background(), tc.params)
+		var ve *ValidationError
+		if !errors.As(err, &ve) || ve.Field != tc.field {
+			t.Errorf("%s: err = %v, want a %s ValidationError", name, err, tc.field)
+		}
+	}
+}
+
+func TestFTSMatchExpression(t *testing.T) {
+	for text, want := range map[string]string{
+		"connection pool": `"connection" OR "pool"`,
+		`pool" OR (NEAR`:  `"pool" OR "OR" OR "NEAR"`,
+		"naïve café, v2":  `"naïve" OR "café" OR "v2"`,
+		"  ?!  ":          "",
+	} {
+		if got := ftsMatchExpression(text); got != want {
+			t.Errorf("ftsMatchExpression(%q) = %q, want %q", text, got, want)
+		}
+	}
+}
+
+func TestClient_KeywordIndex_BulkDelete(t *testing.T) {
+	client := newTestClient(t, Config{})
+	ctx := context.Background()
+
+	// Thousands of rows, so a delete trigger that scanned the index per
+	// row would take many seconds
+	const n = 4000
+	if _, err := client.store.db.Exec(`
+		WITH RECURSIVE seq(i) AS (SELECT 1 UNION ALL SELECT i + 1 FROM seq WHERE i < ?)
+		INSERT INTO lore_entries (id, content, category, source_id, created_at, updated_at, namespace)
+		SELECT printf('bulk-%05d', i), printf('bulk entry %d', i), 'PATTERN_OUTCOME', 'test', '2024-01-01T00:00:00Z', '2024-01-01T00:00:00Z', ?
+		FROM seq
+	`, n, client.store.namespace); err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+
+	// Hard-delete the older half, then let Maintain vacuum and reindex
+	start := time.Now()
+	if _, err := client.store.db.Exec(`DELETE FROM lore_entries WHERE id < 'bulk-02001'`); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if _, err := client.Maintain(ctx); err != nil {
+		t.Fatalf("Maintain: %v", err)
+	}
+	if _, err := client.store.db.Exec(`INSERT INTO lore_fts (lore_fts, rank) VALUES ('integrity-check', 1)`); err != nil {
+		t.Errorf("keyword index after delete and vacuum: %v", err)
+	}
+	result, err := client.Query(ctx, QueryParams{Query: "3999", Mode: QueryModeKeyword})
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	if got := loreIDs(result.Lore); len(got) != 1 || got[0] != "bulk-03999" {
+		t.Errorf("after vacuum = %v, want [bulk-03999]", got)
+	}
+
+	if err := client.store.ClearAllLore(); err != nil {
+		t.Fatalf("ClearAllLore: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("bulk deletes took %v", elapsed)
+	}
+	var indexed int
+	if err := client.store.db.QueryRow(`SELECT COUNT(*) FROM lore_fts WHERE lore_fts MATCH 'bulk'`).Scan(&indexed); err != nil {
+		t.Fatalf("count index: %v", err)
+	}
+	if indexed != 0 {
+		t.Errorf("indexed after clear = %d, want 0", indexed)
+	}
+}
@@ -0,0 +1,69 @@
+package recall
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/hyperengineering/recall/internal/store"
+)
+
+// SyncDisabledEnv names the environment variable that stops all sync with
+// Engram. Any value except one strconv.ParseBool reads as false disables
+// sync.
+const SyncDisabledEnv = "RECALL_SYNC_DISABLED"
+
+// DefaultSyncPauseFile returns the pause file used when
+// Config.SyncPauseFile is empty: sync.disabled beside the store root, e.g.
+// ~/.local/share/recall/sync.disabled.
+func DefaultSyncPauseFile() string {
+	return filepath.Join(filepath.Dir(store.DefaultStoreRoot()), "sync.disabled")
+}
+
+// syncDisabledBy returns what disables sync, SyncDisabledEnv or the path of
+// an existing pause file, or "" if sync may run.
+func syncDisabledBy(pauseFile string) string {
+	if v := os.Getenv(SyncDisabledEnv); v != "" {
+		if disabled, err := strconv.ParseBool(v); err != nil || disabled {
+			return SyncDisabledEnv
+		}
+	}
+	if pauseFile != "" {
+		if _, err := os.Stat(pauseFile); err == nil {
+			return pauseFile
+		}
+	}
+	return ""
+}
+
+// SetPauseFile sets the kill-switch file: while it exists, every Engram
+// request fails with ErrSyncDisabled. Empty checks only SyncDisabledEnv.
+func (s *Syncer) SetPauseFile(path string) {
+	s.pauseFile = path
+}
+
+// DisabledBy returns what disables sync (see syncDisabledBy), or "".
+func (s *Syncer) DisabledBy() string {
+	return syncDisabledBy(s.pauseFile)
+}
+
+// checkKillSwitch fails a request while the kill-switch is on.
+func (s *Syncer) checkKillSwitch() error {
+	by := s.DisabledBy()
+	if by == "" {
+		return nil
+	}
+	return fmt.Errorf("%w by %s", ErrSyncDisabled, by)
+}
+
+// syncDisabled reports whether background sync should skip this tick,
+// logging why.
+func (c *Client) syncDisabled() bool {
+	by := c.syncer.DisabledBy()
+	if by == "" {
+		return false
+	}
+	c.debug.LogSync("kill-switch", "auto-sync disabled by "+by)
+	return true
+}
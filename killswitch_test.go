@@ -0,0 +1,73 @@
+package recall
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+)
+
+func TestClient_SyncKillSwitch(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	t.Cleanup(server.Close)
+
+	dir := t.TempDir()
+	pauseFile := filepath.Join(dir, "sync.disabled")
+	client := newTestClient(t, Config{LocalPath: filepath.Join(dir, "test.db"), EngramURL: server.URL, APIKey: "k", SyncPauseFile: pauseFile})
+
+	if status, _ := client.SyncStatus(); status.Paused || status.DisabledBy != "" {
+		t.Errorf("status = %+v, want sync enabled", status)
+	}
+
+	if err := os.WriteFile(pauseFile, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.SyncPull(context.Background()); !errors.Is(err, ErrSyncDisabled) {
+		t.Errorf("SyncPull error = %v, want ErrSyncDisabled", err)
+	}
+	if _, err := client.SyncPush(context.Background()); err != nil && !errors.Is(err, ErrSyncDisabled) {
+		t.Errorf("SyncPush error = %v, want nil or ErrSyncDisabled", err)
+	}
+	if n := requests.Load(); n != 0 {
+		t.Errorf("requests = %d with the pause file present, want 0", n)
+	}
+	status, err := client.SyncStatus()
+	if err != nil {
+		t.Fatalf("SyncStatus: %v", err)
+	}
+	if !status.Paused || status.PauseReason != "sync_disabled" || status.DisabledBy != pauseFile {
+		t.Errorf("status = %+v, want disabled by %s", status, pauseFile)
+	}
+	if !client.syncDisabled() {
+		t.Error("background sync not disabled")
+	}
+
+	// Removing the file re-enables sync; the environment variable disables it again
+	if err := os.Remove(pauseFile); err != nil {
+		t.Fatal(err)
+	}
+	_ = client.SyncPull(context.Background())
+	if requests.Load() == 0 {
+		t.Error("no request sent after the pause file was removed")
+	}
+
+	t.Setenv(SyncDisabledEnv, "1")
+	if err := client.SyncPull(context.Background()); !errors.Is(err, ErrSyncDisabled) {
+		t.Errorf("SyncPull error = %v with %s set, want ErrSyncDisabled", err, SyncDisabledEnv)
+	}
+	if status, _ := client.SyncStatus(); status.DisabledBy != SyncDisabledEnv {
+		t.Errorf("DisabledBy = %q, want %s", status.DisabledBy, SyncDisabledEnv)
+	}
+	t.Setenv(SyncDisabledEnv, "false")
+	if by := client.syncer.DisabledBy(); by != "" {
+		t.Errorf("DisabledBy = %q with %s=false, want enabled", by, SyncDisabledEnv)
+	}
+}
@@ -0,0 +1,103 @@
+package recall
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// lazyConnector opens connections to a store's database, preparing the
+// database (see Store.prepare) before the first one. A failed preparation
+// is retried by the next connection.
+type lazyConnector struct {
+	driver  driver.Driver
+	path    string
+	prepare func() error
+
+	mu    sync.Mutex
+	ready bool
+}
+
+func (c *lazyConnector) Connect(context.Context) (driver.Conn, error) {
+	c.mu.Lock()
+	if !c.ready {
+		if err := c.prepare(); err != nil {
+			c.mu.Unlock()
+			return nil, err
+		}
+		c.ready = true
+	}
+	c.mu.Unlock()
+	return c.driver.Open(c.path)
+}
+
+func (c *lazyConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+// NewLazyStore returns a store whose database is opened, checked and
+// migrated on first use instead of up front; EnsureReady does it
+// explicitly. Unlike NewStore, a corrupt database is not recovered: the
+// error is returned by the first use.
+func NewLazyStore(path string) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("create store directory: %w", err)
+	}
+	probe, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("open database: %w", err)
+	}
+	drv := probe.Driver()
+	_ = probe.Close()
+
+	s := &Store{path: path, lazy: true}
+	s.db = sql.OpenDB(&lazyConnector{driver: drv, path: path, prepare: func() error {
+		// Prepare through a separate handle: the pool is waiting on
+		// this connection.
+		db, err := sql.Open("sqlite", path)
+		if err != nil {
+			return fmt.Errorf("open database: %w", err)
+		}
+		defer func() { _ = db.Close() }()
+		staging := &Store{db: db, path: path}
+		if err := staging.prepare(); err != nil {
+			return err
+		}
+		s.sourceID = staging.sourceID
+		return nil
+	}})
+	return s, nil
+}
+
+// EnsureReady opens, checks and migrates a store created by NewLazyStore
+// now rather than on first use. It returns nil at once for other stores.
+func (s *Store) EnsureReady(ctx context.Context) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return ErrStoreClosed
+	}
+	if !s.lazy {
+		return nil
+	}
+	if err := s.db.PingContext(ctx); err != nil {
+		return fmt.Errorf("store: open: %w", err)
+	}
+	return nil
+}
+
+// EnsureReady opens, checks and migrates the store now when
+// Config.LazyOpen deferred it, so startup errors surface here instead of
+// on first use. Without LazyOpen, New already did this and EnsureReady
+// returns nil.
+func (c *Client) EnsureReady(ctx context.Context) error {
+	if err := c.store.EnsureReady(ctx); err != nil {
+		return fmt.Errorf("client: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package recall
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNew_LazyOpen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lazy.db")
+	client := newTestClient(t, Config{LocalPath: path, AutoSync: false, LazyOpen: true})
+
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("database exists before first use: %v", err)
+	}
+
+	lore, err := client.Record("Warm connection pools before traffic shifts.", CategoryPerformanceInsight)
+	if err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+	if client.store.SourceID() == "" {
+		t.Error("SourceID is empty after first use")
+	}
+	if err := client.EnsureReady(context.Background()); err != nil {
+		t.Fatalf("EnsureReady: %v", err)
+	}
+	got, err := client.store.Get(lore.ID)
+	if err != nil || got.Content != lore.Content {
+		t.Errorf("Get = %+v, %v; want the recorded entry", got, err)
+	}
+}
+
+func TestClient_EnsureReady(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lazy.db")
+	client := newTestClient(t, Config{LocalPath: path, AutoSync: false, LazyOpen: true})
+
+	if err := client.EnsureReady(context.Background()); err != nil {
+		t.Fatalf("EnsureReady: %v", err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("database not created by EnsureReady: %v", err)
+	}
+	if client.store.SourceID() == "" {
+		t.Error("SourceID is empty after EnsureReady")
+	}
+
+	eager := newTestClient(t, Config{AutoSync: false})
+	if err := eager.EnsureReady(context.Background()); err != nil {
+		t.Errorf("EnsureReady without LazyOpen: %v", err)
+	}
+}
+
+func TestNewStore_SkipsMigrationsWhenCurrent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lore.db")
+	store, err := NewStore(path)
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	if v, _ := store.GetMetadata(metadataKeyMigrationVersion); v != latestMigration() {
+		t.Errorf("migration_version = %q, want %q", v, latestMigration())
+	}
+	// Without goose's version table, goose.Up would fail or re-create it.
+	if _, err := store.db.Exec(`DROP TABLE goose_db_version`); err != nil {
+		t.Fatal(err)
+	}
+	_ = store.Close()
+
+	store, err = NewStore(path)
+	if err != nil {
+		t.Fatalf("NewStore of a current store: %v", err)
+	}
+	var tables int
+	if err := store.db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE name = 'goose_db_version'`).Scan(&tables); err != nil {
+		t.Fatal(err)
+	}
+	if tables != 0 {
+		t.Error("goose ran against a store whose migrations were current")
+	}
+	_ = store.Close()
+}
+
+func TestSyncer_LazyStoreOpenError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lazy.db")
+	if err := os.WriteFile(path, []byte("not a sqlite database, just text padding it past the header size"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	store, err := NewLazyStore(path)
+	if err != nil {
+		t.Fatalf("NewLazyStore: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	// Sync must not run with an empty source_id when the store can't open.
+	syncer := NewSyncer(store, "http://unused", "key", "")
+	syncer.SetStoreID("test-store")
+	if _, err := syncer.SyncPush(context.Background()); err == nil || !strings.Contains(err.Error(), "store: open") {
+		t.Errorf("SyncPush on an unopenable store: err = %v, want the open error", err)
+	}
+	if _, err := syncer.SyncDelta(context.Background()); err == nil || !strings.Contains(err.Error(), "store: open") {
+		t.Errorf("SyncDelta on an unopenable store: err = %v, want the open error", err)
+	}
+}
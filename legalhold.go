@@ -0,0 +1,326 @@
+package recall
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+)
+
+// metadataKeyLegalHold marks a store as under legal hold.
+const metadataKeyLegalHold = "legal_hold"
+
+// Origins of legal-hold tombstones.
+const (
+	HoldOriginDelete    = "delete"    // Local delete
+	HoldOriginRemote    = "remote"    // Delete applied by delta sync
+	HoldOriginBootstrap = "bootstrap" // Replaced by a snapshot
+	HoldOriginClear     = "clear"     // Cleared by Reinitialize
+)
+
+// HeldTombstone is the retained state of a lore entry removed while the
+// store was under legal hold.
+type HeldTombstone struct {
+	LoreID  string          `json:"lore_id"`
+	Origin  string          `json:"origin"`
+	Payload json.RawMessage `json:"payload"` // Full entry state, as pushed to Engram
+	HeldAt  time.Time       `json:"held_at"`
+}
+
+// ExportRecord describes one export made while the store was under legal
+// hold. SHA256 is the hash of the exported bytes, so a copy found later
+// can be matched to its export.
+type ExportRecord struct {
+	ExportedAt  time.Time `json:"exported_at"`
+	Format      string    `json:"format"`
+	Destination string    `json:"destination,omitempty"` // File path, if known
+	Entries     int       `json:"entries"`
+	SHA256      string    `json:"sha256"`
+}
+
+// EgressRecord describes one change_log entry pushed to Engram while the
+// store was under legal hold.
+type EgressRecord struct {
+	PushID        string    `json:"push_id"`
+	PushedAt      time.Time `json:"pushed_at"`
+	Sequence      int64     `json:"sequence"`
+	TableName     string    `json:"table_name"`
+	EntityID      string    `json:"entity_id"`
+	Operation     string    `json:"operation"`
+	PayloadSHA256 string    `json:"payload_sha256"`
+	PayloadBytes  int       `json:"payload_bytes"`
+}
+
+// ComplianceReport lists the data that left the machine in a time window:
+// changes pushed to Engram and exports, plus the tombstones held in it.
+type ComplianceReport struct {
+	Since      time.Time       `json:"since"`
+	Until      time.Time       `json:"until"`
+	LegalHold  bool            `json:"legal_hold"`
+	Egress     []EgressRecord  `json:"egress"`
+	Exports    []ExportRecord  `json:"exports"`
+	Tombstones []HeldTombstone `json:"tombstones"`
+}
+
+// LegalHold reports whether the store is under legal hold.
+func (s *Store) LegalHold() (bool, error) {
+	value, err := s.GetMetadata(metadataKeyLegalHold)
+	return value == "true", err
+}
+
+// SetLegalHold places the store under legal hold or releases it.
+func (s *Store) SetLegalHold(on bool) error {
+	return s.SetMetadata(metadataKeyLegalHold, strconv.FormatBool(on))
+}
+
+// legalHoldTx reports whether the store is under legal hold, within tx.
+func legalHoldTx(tx *sql.Tx) (bool, error) {
+	var value string
+	err := tx.QueryRow("SELECT value FROM metadata WHERE key = ?", metadataKeyLegalHold).Scan(&value)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("store: read legal hold: %w", err)
+	}
+	return value == "true", nil
+}
+
+// holdLoreTx copies the lore rows matching where into legal_hold_tombstones
+// if the store is under legal hold. Callers run it before removing rows,
+// or after soft-deleting them.
+func (s *Store) holdLoreTx(tx *sql.Tx, origin, where string, args ...any) error {
+	held, err := legalHoldTx(tx)
+	if err != nil || !held {
+		return err
+	}
+
+	rows, err := tx.Query(`
+		SELECT id, content, context, category, confidence, embedding, embedding_status, source_id, sources,
+		       validation_count, last_validated_at, created_at, updated_at, deleted_at, synced_at, namespace
+		FROM lore_entries WHERE `+where, args...)
+	if err != nil {
+		return fmt.Errorf("store: read lore for legal hold: %w", err)
+	}
+	var payloads [][2]string
+	for rows.Next() {
+		lore, err := s.scanLoreRows(rows)
+		if err != nil {
+			_ = rows.Close()
+			return fmt.Errorf("store: scan lore for legal hold: %w", err)
+		}
+		payload, err := lorePayloadJSON(lore)
+		if err != nil {
+			_ = rows.Close()
+			return fmt.Errorf("store: marshal legal hold payload: %w", err)
+		}
+		payloads = append(payloads, [2]string{lore.ID, string(payload)})
+	}
+	if err := rows.Close(); err != nil {
+		return fmt.Errorf("store: read lore for legal hold: %w", err)
+	}
+
+	now := s.now().Format(time.RFC3339)
+	for _, p := range payloads {
+		if _, err := tx.Exec(`INSERT INTO legal_hold_tombstones (lore_id, origin, payload, held_at) VALUES (?, ?, ?, ?)`,
+			p[0], origin, p[1], now); err != nil {
+			return fmt.Errorf("store: hold tombstone: %w", err)
+		}
+	}
+	return nil
+}
+
+// logEgressTx records the pushed change_log entries first..last of this
+// store's source if the store is under legal hold.
+func (s *Store) logEgressTx(tx *sql.Tx, pushID string, first, last int64) error {
+	held, err := legalHoldTx(tx)
+	if err != nil || !held {
+		return err
+	}
+
+	rows, err := tx.Query(`
+		SELECT sequence, table_name, entity_id, operation, COALESCE(payload, '')
+		FROM change_log WHERE sequence BETWEEN ? AND ? AND source_id = ? ORDER BY sequence
+	`, first, last, s.sourceID)
+	if err != nil {
+		return fmt.Errorf("store: read pushed changes: %w", err)
+	}
+	var records []EgressRecord
+	for rows.Next() {
+		var r EgressRecord
+		var payload string
+		if err := rows.Scan(&r.Sequence, &r.TableName, &r.EntityID, &r.Operation, &payload); err != nil {
+			_ = rows.Close()
+			return fmt.Errorf("store: scan pushed change: %w", err)
+		}
+		sum := sha256.Sum256([]byte(payload))
+		r.PayloadSHA256 = hex.EncodeToString(sum[:])
+		r.PayloadBytes = len(payload)
+		records = append(records, r)
+	}
+	if err := rows.Close(); err != nil {
+		return fmt.Errorf("store: read pushed changes: %w", err)
+	}
+
+	now := s.now().Format(time.RFC3339)
+	for _, r := range records {
+		if _, err := tx.Exec(`
+			INSERT INTO sync_egress_log (push_id, pushed_at, sequence, table_name, entity_id, operation, payload_sha256, payload_bytes)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		`, pushID, now, r.Sequence, r.TableName, r.EntityID, r.Operation, r.PayloadSHA256, r.PayloadBytes); err != nil {
+			return fmt.Errorf("store: log egress: %w", err)
+		}
+	}
+	return nil
+}
+
+// logExport records an export if the store is under legal hold. The caller
+// must hold s.mu.
+func (s *Store) logExport(format, destination string, entries int, sum []byte) error {
+	var value string
+	err := s.db.QueryRow("SELECT value FROM metadata WHERE key = ?", metadataKeyLegalHold).Scan(&value)
+	if err == sql.ErrNoRows || (err == nil && value != "true") {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("store: read legal hold: %w", err)
+	}
+	_, err = s.db.Exec(`INSERT INTO export_log (exported_at, format, destination, entries, sha256) VALUES (?, ?, ?, ?, ?)`,
+		s.now().Format(time.RFC3339), format, destination, entries, hex.EncodeToString(sum))
+	if err != nil {
+		return fmt.Errorf("store: log export: %w", err)
+	}
+	return nil
+}
+
+// exportDestination returns the file name of w, if it is a file.
+func exportDestination(w io.Writer) string {
+	if f, ok := w.(*os.File); ok {
+		return f.Name()
+	}
+	return ""
+}
+
+// ComplianceReport returns the egress, exports and held tombstones
+// recorded between since and until (inclusive). A zero until means now.
+func (s *Store) ComplianceReport(since, until time.Time) (*ComplianceReport, error) {
+	if until.IsZero() {
+		until = s.now()
+	}
+	held, err := s.LegalHold()
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil, ErrStoreClosed
+	}
+
+	report := &ComplianceReport{
+		Since: since.UTC(), Until: until.UTC(), LegalHold: held,
+		Egress: []EgressRecord{}, Exports: []ExportRecord{}, Tombstones: []HeldTombstone{},
+	}
+	lo, hi := since.UTC().Format(time.RFC3339), until.UTC().Format(time.RFC3339)
+
+	rows, err := s.db.Query(`
+		SELECT push_id, pushed_at, sequence, table_name, entity_id, operation, payload_sha256, payload_bytes
+		FROM sync_egress_log WHERE pushed_at BETWEEN ? AND ? ORDER BY id
+	`, lo, hi)
+	if err != nil {
+		return nil, fmt.Errorf("store: query egress log: %w", err)
+	}
+	for rows.Next() {
+		var r EgressRecord
+		var pushedAt string
+		if err := rows.Scan(&r.PushID, &pushedAt, &r.Sequence, &r.TableName, &r.EntityID, &r.Operation, &r.PayloadSHA256, &r.PayloadBytes); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("store: scan egress log: %w", err)
+		}
+		r.PushedAt, _ = time.Parse(time.RFC3339, pushedAt)
+		report.Egress = append(report.Egress, r)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, fmt.Errorf("store: query egress log: %w", err)
+	}
+
+	rows, err = s.db.Query(`
+		SELECT exported_at, format, destination, entries, sha256
+		FROM export_log WHERE exported_at BETWEEN ? AND ? ORDER BY id
+	`, lo, hi)
+	if err != nil {
+		return nil, fmt.Errorf("store: query export log: %w", err)
+	}
+	for rows.Next() {
+		var r ExportRecord
+		var exportedAt string
+		if err := rows.Scan(&exportedAt, &r.Format, &r.Destination, &r.Entries, &r.SHA256); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("store: scan export log: %w", err)
+		}
+		r.ExportedAt, _ = time.Parse(time.RFC3339, exportedAt)
+		report.Exports = append(report.Exports, r)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, fmt.Errorf("store: query export log: %w", err)
+	}
+
+	rows, err = s.db.Query(`
+		SELECT lore_id, origin, payload, held_at
+		FROM legal_hold_tombstones WHERE held_at BETWEEN ? AND ? ORDER BY id
+	`, lo, hi)
+	if err != nil {
+		return nil, fmt.Errorf("store: query tombstones: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	for rows.Next() {
+		var t HeldTombstone
+		var payload, heldAt string
+		if err := rows.Scan(&t.LoreID, &t.Origin, &payload, &heldAt); err != nil {
+			return nil, fmt.Errorf("store: scan tombstone: %w", err)
+		}
+		t.Payload = json.RawMessage(payload)
+		t.HeldAt, _ = time.Parse(time.RFC3339, heldAt)
+		report.Tombstones = append(report.Tombstones, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("store: query tombstones: %w", err)
+	}
+	return report, nil
+}
+
+// LegalHold reports whether the client's store is under legal hold.
+func (c *Client) LegalHold() (bool, error) {
+	return c.store.LegalHold()
+}
+
+// ReleaseLegalHold takes the store out of legal hold. Held tombstones and
+// logs are kept. Requires the delete permission.
+func (c *Client) ReleaseLegalHold() error {
+	if err := c.authorize(ActionDelete, ""); err != nil {
+		return err
+	}
+	if err := c.store.SetLegalHold(false); err != nil {
+		return fmt.Errorf("client: release legal hold: %w", err)
+	}
+	return nil
+}
+
+// ComplianceReport lists the data that left the machine between since and
+// until: changes pushed to Engram and exports, with the tombstones held in
+// that window. Only activity while the store was under legal hold is
+// recorded.
+func (c *Client) ComplianceReport(since, until time.Time) (*ComplianceReport, error) {
+	report, err := c.store.ComplianceReport(since, until)
+	if err != nil {
+		return nil, fmt.Errorf("client: compliance report: %w", err)
+	}
+	return report, nil
+}
@@ -0,0 +1,177 @@
+package recall
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLegalHold_OffRecordsNothing(t *testing.T) {
+	store := newTestStore(t)
+	lore := &Lore{ID: "a", Content: "entry", Category: CategoryPatternOutcome, Confidence: 0.5,
+		CreatedAt: time.Now().UTC(), UpdatedAt: time.Now().UTC()}
+	if err := store.InsertLore(lore); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.DeleteLoreByID("a"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.ExportJSON(context.Background(), "s", &bytes.Buffer{}); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := store.ComplianceReport(time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatalf("ComplianceReport: %v", err)
+	}
+	if report.LegalHold || len(report.Tombstones) != 0 || len(report.Exports) != 0 {
+		t.Errorf("report = %+v, want nothing recorded without a hold", report)
+	}
+}
+
+func TestLegalHold_RetainsTombstonesAndLogsExports(t *testing.T) {
+	client := newTestClient(t, Config{AutoSync: false, LegalHold: true})
+	start := time.Now().UTC().Add(-time.Second)
+
+	a, err := client.Record("Deleted lore stays on hold", CategoryPatternOutcome)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Record("Cleared lore stays on hold", CategoryPatternOutcome); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.store.DeleteLoreByID(a.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	var export bytes.Buffer
+	if err := client.store.ExportJSON(context.Background(), "s", &export); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.store.ClearAllLore(); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := client.ComplianceReport(start, time.Time{})
+	if err != nil {
+		t.Fatalf("ComplianceReport: %v", err)
+	}
+	if !report.LegalHold {
+		t.Error("LegalHold = false, want true")
+	}
+
+	// The deleted entry is held once when deleted and again when cleared.
+	origins := map[string]int{}
+	for _, tomb := range report.Tombstones {
+		origins[tomb.Origin]++
+		if tomb.LoreID == a.ID && tomb.Origin == HoldOriginDelete {
+			var payload struct {
+				Content   string  `json:"content"`
+				DeletedAt *string `json:"deleted_at"`
+			}
+			if err := json.Unmarshal(tomb.Payload, &payload); err != nil || payload.Content != a.Content || payload.DeletedAt == nil {
+				t.Errorf("tombstone payload = %s (%v)", tomb.Payload, err)
+			}
+		}
+	}
+	if origins[HoldOriginDelete] != 1 || origins[HoldOriginClear] != 2 {
+		t.Errorf("tombstone origins = %v, want 1 delete and 2 clear", origins)
+	}
+
+	sum := sha256.Sum256(export.Bytes())
+	if len(report.Exports) != 1 || report.Exports[0].SHA256 != hex.EncodeToString(sum[:]) ||
+		report.Exports[0].Entries != 1 || report.Exports[0].Format != "json" {
+		t.Errorf("Exports = %+v, want one JSON export of 1 entry with the content hash", report.Exports)
+	}
+
+	// Outside the window, nothing is reported.
+	later, err := client.ComplianceReport(time.Now().Add(time.Hour), time.Now().Add(2*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(later.Tombstones)+len(later.Exports)+len(later.Egress) != 0 {
+		t.Errorf("later window = %+v, want empty", later)
+	}
+}
+
+func TestLegalHold_LogsPushedChanges(t *testing.T) {
+	store := newTestStore(t)
+	if err := store.SetLegalHold(true); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now().UTC()
+	for _, id := range []string{"a", "b"} {
+		if err := store.InsertLore(&Lore{ID: id, Content: "pushed " + id, Category: CategoryPatternOutcome,
+			Confidence: 0.5, CreatedAt: now, UpdatedAt: now}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var pushed []ChangeLogEntry
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req SyncPushRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		pushed = append(pushed, req.Entries...)
+		_ = json.NewEncoder(w).Encode(SyncPushResponse{Accepted: len(req.Entries)})
+	}))
+	defer server.Close()
+
+	if _, err := newTestSyncer(t, store, server.URL).SyncPush(context.Background()); err != nil {
+		t.Fatalf("SyncPush: %v", err)
+	}
+
+	report, err := store.ComplianceReport(now.Add(-time.Minute), time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Egress) != len(pushed) || len(pushed) == 0 {
+		t.Fatalf("Egress = %d records, want one per pushed entry (%d)", len(report.Egress), len(pushed))
+	}
+	for i, e := range report.Egress {
+		sum := sha256.Sum256([]byte(pushed[i].Payload))
+		if e.Sequence != pushed[i].Sequence || e.EntityID != pushed[i].EntityID || e.PayloadSHA256 != hex.EncodeToString(sum[:]) {
+			t.Errorf("Egress[%d] = %+v, want entry %d with its payload hash", i, e, pushed[i].Sequence)
+		}
+		if e.PushID == "" || !strings.HasPrefix(e.TableName, "lore") {
+			t.Errorf("Egress[%d] = %+v", i, e)
+		}
+	}
+}
+
+func TestClient_ReleaseLegalHold(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lore.db")
+	client, err := New(Config{LocalPath: path, AutoSync: false, LegalHold: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.ReleaseLegalHold(); err != nil {
+		t.Fatalf("ReleaseLegalHold: %v", err)
+	}
+	if held, _ := client.LegalHold(); held {
+		t.Error("LegalHold = true after release")
+	}
+	_ = client.Close()
+
+	// The hold is stored with the data, not the config.
+	client, err = New(Config{LocalPath: path, AutoSync: false, LegalHold: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = client.Close()
+	client, err = New(Config{LocalPath: path, AutoSync: false})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = client.Close() }()
+	if held, _ := client.LegalHold(); !held {
+		t.Error("LegalHold = false, want the hold to persist without Config.LegalHold")
+	}
+}
@@ -0,0 +1,237 @@
+package recall
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// DefaultEmbedInterval is how often the background worker embeds pending
+// lore when Config.EmbedInterval is unset.
+const DefaultEmbedInterval = 30 * time.Second
+
+// embedBatchSize is the number of entries sent to the EmbeddingProvider
+// in one call.
+const embedBatchSize = 32
+
+// EmbeddingProvider turns texts into embeddings locally, e.g. with a
+// model served on the same machine, so semantic queries work without
+// Engram. It must return one vector per text, in order, from the model
+// named by Config.EmbeddingModel. See the embedder package for an adapter
+// to OpenAI-compatible HTTP endpoints.
+type EmbeddingProvider interface {
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+// unembeddedLore returns up to limit live entries of the namespace with
+// no vector for model, oldest first. Only ID and Content are set.
+func (s *Store) unembeddedLore(model string, limit int) ([]Lore, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil, ErrStoreClosed
+	}
+
+	rows, err := s.db.Query(`
+		SELECT id, content FROM lore_entries
+		WHERE deleted_at IS NULL AND namespace = ?
+		AND NOT EXISTS (SELECT 1 FROM lore_embeddings e WHERE e.lore_id = lore_entries.id AND e.model = ?)
+		AND NOT (embedding IS NOT NULL AND ? IS (SELECT value FROM metadata WHERE key = 'embedding_model'))
+		ORDER BY created_at, id LIMIT ?
+	`, s.namespace, model, model, limit)
+	if err != nil {
+		return nil, fmt.Errorf("store: query unembedded lore: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var lore []Lore
+	for rows.Next() {
+		var l Lore
+		if err := rows.Scan(&l.ID, &l.Content); err != nil {
+			return nil, fmt.Errorf("store: scan unembedded lore: %w", err)
+		}
+		lore = append(lore, l)
+	}
+	return lore, rows.Err()
+}
+
+// setLocalEmbedding stores a locally generated vector of l for model,
+// unless l has since been edited, deleted or given a vector for model. It
+// reports whether the vector was stored. Local-only: nothing is written
+// to the change_log, and Engram still embeds the entry.
+func (s *Store) setLocalEmbedding(l Lore, model string, embedding []float32) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return false, ErrStoreClosed
+	}
+
+	res, err := s.db.Exec(`
+		INSERT INTO lore_embeddings (lore_id, model, embedding, created_at)
+		SELECT id, ?, ?, ? FROM lore_entries
+		WHERE id = ? AND content = ? AND deleted_at IS NULL
+		AND NOT EXISTS (SELECT 1 FROM lore_embeddings e WHERE e.lore_id = lore_entries.id AND e.model = ?)
+	`, model, PackFloat32(embedding), s.now().Format(time.RFC3339), l.ID, l.Content, model)
+	if err != nil {
+		return false, fmt.Errorf("store: set local embedding: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	return n > 0, nil
+}
+
+// EmbedPending embeds the namespace's lore that has no vector for
+// Config.EmbeddingModel yet, such as lore recorded locally or pulled by
+// delta sync, with Config.EmbeddingProvider. It returns how many entries
+// were embedded; on error, the entries embedded so far are kept. The
+// background worker calls it every Config.EmbedInterval.
+//
+// The store is only held while reading a batch and writing its vectors,
+// not during provider calls, so maintenance is not held up by a slow
+// model. Entries edited or deleted meanwhile are skipped.
+func (c *Client) EmbedPending(ctx context.Context) (int, error) {
+	provider := c.config.EmbeddingProvider
+	if provider == nil {
+		return 0, &ValidationError{Field: "EmbeddingProvider", Message: "is not configured"}
+	}
+	model := c.config.EmbeddingModel
+
+	count := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return count, err
+		}
+		release, err := c.store.enter(ctx)
+		if err != nil {
+			return count, err
+		}
+		batch, err := c.store.unembeddedLore(model, embedBatchSize)
+		release()
+		if err != nil {
+			return count, fmt.Errorf("client: embed pending: %w", err)
+		}
+		if len(batch) == 0 {
+			return count, nil
+		}
+		texts := make([]string, len(batch))
+		for i, l := range batch {
+			texts[i] = l.Content
+		}
+		vectors, err := provider.Embed(ctx, texts)
+		if err != nil {
+			return count, fmt.Errorf("client: embed pending: %w", err)
+		}
+		if len(vectors) != len(batch) {
+			return count, fmt.Errorf("client: embed pending: provider returned %d embeddings for %d texts", len(vectors), len(batch))
+		}
+
+		written, err := c.setLocalEmbeddings(ctx, batch, model, vectors)
+		count += written
+		if err != nil {
+			return count, err
+		}
+		if len(batch) < embedBatchSize {
+			return count, nil
+		}
+	}
+}
+
+// setLocalEmbeddings writes a batch of provider vectors under the store
+// gate, returning how many were stored.
+func (c *Client) setLocalEmbeddings(ctx context.Context, batch []Lore, model string, vectors [][]float32) (int, error) {
+	release, err := c.store.enter(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer release()
+
+	count := 0
+	for i, l := range batch {
+		if len(vectors[i]) == 0 {
+			return count, fmt.Errorf("client: embed pending: provider returned an empty embedding for %s", l.ID)
+		}
+		stored, err := c.store.setLocalEmbedding(l, model, vectors[i])
+		if err != nil {
+			return count, fmt.Errorf("client: embed pending: %w", err)
+		}
+		if stored {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// embedQuery fills in params.QueryEmbedding from params.Query with the
+// EmbeddingProvider, when the query needs one and has none. It also
+// supplies the provider as params.Embed for the working context. If the
+// provider fails, the query goes ahead without an embedding, except in
+// semantic mode, which needs one.
+func (c *Client) embedQuery(ctx context.Context, params *QueryParams) error {
+	provider := c.config.EmbeddingProvider
+	if provider == nil {
+		return nil
+	}
+	embed := func(ctx context.Context, text string) ([]float32, error) {
+		vectors, err := provider.Embed(ctx, []string{text})
+		if err != nil {
+			return nil, err
+		}
+		if len(vectors) != 1 || len(vectors[0]) == 0 {
+			return nil, fmt.Errorf("provider returned %d embeddings for 1 text", len(vectors))
+		}
+		return vectors[0], nil
+	}
+	if params.Embed == nil {
+		params.Embed = embed
+	}
+	if len(params.QueryEmbedding) > 0 || params.Query == "" || params.Mode == QueryModeKeyword {
+		return nil
+	}
+	embedding, err := embed(ctx, params.Query)
+	if err != nil {
+		if params.Mode == QueryModeSemantic {
+			return fmt.Errorf("client: query: embed query: %w", err)
+		}
+		c.debug.LogError("embed query", err)
+		return nil
+	}
+	params.QueryEmbedding = embedding
+	return nil
+}
+
+// embedLoop embeds pending lore in the background, at start and then
+// every Config.EmbedInterval, until the client closes.
+func (c *Client) embedLoop() {
+	defer close(c.embedDone)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-c.stopSync:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	interval := c.config.EmbedInterval
+	if interval <= 0 {
+		interval = DefaultEmbedInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if n, err := c.EmbedPending(ctx); err != nil && ctx.Err() == nil {
+			c.debug.LogError("embed pending", err)
+		} else if n > 0 {
+			c.debug.Log("embedded %d pending entries", n)
+		}
+		select {
+		case <-c.stopSync:
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,238 @@
+package recall
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// wordEmbedder embeds texts as counts of a few words, so texts sharing
+// words are similar.
+type wordEmbedder struct {
+	mu    sync.Mutex
+	calls int
+	texts int
+	err   error
+}
+
+func (e *wordEmbedder) Embed(_ context.Context, texts []string) ([][]float32, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.calls++
+	e.texts += len(texts)
+	if e.err != nil {
+		return nil, e.err
+	}
+	vectors := make([][]float32, len(texts))
+	for i, text := range texts {
+		v := []float32{0.01, 0.01, 0.01}
+		for _, w := range strings.Fields(strings.ToLower(text)) {
+			switch strings.Trim(w, ".,") {
+			case "kafka":
+				v[0]++
+			case "pool":
+				v[1]++
+			case "utc":
+				v[2]++
+			}
+		}
+		vectors[i] = v
+	}
+	return vectors, nil
+}
+
+func TestClient_EmbedPending(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "localembed.db")
+	offline, err := New(Config{LocalPath: path})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	var ids []string
+	for _, content := range []string{"Kafka consumers stall on rebalance.", "Size the pool for peak load.", "Log in UTC."} {
+		l, err := offline.Record(content, CategoryPatternOutcome)
+		if err != nil {
+			t.Fatalf("Record: %v", err)
+		}
+		ids = append(ids, l.ID)
+	}
+	_ = offline.Close()
+
+	provider := &wordEmbedder{}
+	client := newTestClient(t, Config{LocalPath: path, EmbeddingModel: "words", EmbeddingProvider: provider, EmbedInterval: time.Hour})
+	ctx := context.Background()
+
+	// The worker's first run embeds the backlog in one batch
+	eventually(t, 5*time.Second, func() error {
+		if pending, err := client.store.unembeddedLore("words", 10); err != nil || len(pending) > 0 {
+			return fmt.Errorf("%d entries unembedded (err %v)", len(pending), err)
+		}
+		return nil
+	})
+	provider.mu.Lock()
+	if provider.calls != 1 || provider.texts != 3 {
+		t.Errorf("provider saw %d calls, %d texts; want 1 call with 3", provider.calls, provider.texts)
+	}
+	provider.mu.Unlock()
+	if n, err := client.EmbedPending(ctx); err != nil || n != 0 {
+		t.Errorf("EmbedPending = %d, %v; want nothing left", n, err)
+	}
+	models, err := client.store.EmbeddingModels()
+	if err != nil {
+		t.Fatalf("EmbeddingModels: %v", err)
+	}
+	if len(models) != 1 || models[0].Model != "words" || models[0].Entries != 3 || models[0].Primary {
+		t.Errorf("EmbeddingModels = %+v, want 3 vectors tagged words", models)
+	}
+	l, err := client.store.Get(ids[0])
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if len(l.Embedding) != 0 || l.EmbeddingStatus != "pending" {
+		t.Errorf("embedding = %d bytes, status %q; want none, still pending for Engram", len(l.Embedding), l.EmbeddingStatus)
+	}
+
+	// Query embeds its text with the provider
+	result, err := client.Query(ctx, QueryParams{Query: "pool exhaustion", K: 1})
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	if got := loreIDs(result.Lore); len(got) != 1 || got[0] != ids[1] {
+		t.Errorf("Query = %v, want the pool entry", got)
+	}
+
+	// Edits clear the vector; the next run embeds the new content
+	if _, err := client.Update(ids[2], "Kafka offsets are per partition."); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if n, err := client.EmbedPending(ctx); err != nil || n != 1 {
+		t.Errorf("EmbedPending after edit = %d, %v; want 1", n, err)
+	}
+	result, err = client.Query(ctx, QueryParams{Query: "kafka", K: 2})
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	if got := loreIDs(result.Lore); len(got) != 2 || got[1] == ids[1] {
+		t.Errorf("Query kafka = %v, want both kafka entries", got)
+	}
+
+	// A vector for content changed during the provider call is dropped
+	if stored, err := client.store.setLocalEmbedding(Lore{ID: ids[0], Content: "Stale text."}, "words", []float32{1, 0, 0}); err != nil || stored {
+		t.Errorf("setLocalEmbedding with stale content = %v, %v; want not stored", stored, err)
+	}
+	// Content changed outside Update, e.g. by delta sync, drops the vector too
+	if _, err := client.store.db.Exec(`UPDATE lore_entries SET content = ? WHERE id = ?`, "Log in UTC, always.", ids[1]); err != nil {
+		t.Fatalf("update content: %v", err)
+	}
+	if pending, err := client.store.unembeddedLore("words", 10); err != nil || len(pending) != 1 || pending[0].ID != ids[1] {
+		t.Errorf("unembeddedLore after content change = %v, %v; want %s", pending, err, ids[1])
+	}
+
+	provider.mu.Lock()
+	provider.err = errors.New("model not loaded")
+	provider.mu.Unlock()
+	if _, err := client.Record("Kafka again.", CategoryPatternOutcome); err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+	if _, err := client.EmbedPending(ctx); err == nil || !strings.Contains(err.Error(), "model not loaded") {
+		t.Errorf("EmbedPending with failing provider: err = %v", err)
+	}
+	// Queries degrade without an embedding, except in semantic mode
+	if _, err := client.Query(ctx, QueryParams{Query: "kafka"}); err != nil {
+		t.Errorf("Query with failing provider: %v", err)
+	}
+	if _, err := client.Query(ctx, QueryParams{Query: "kafka", Mode: QueryModeSemantic}); err == nil || !strings.Contains(err.Error(), "model not loaded") {
+		t.Errorf("semantic Query with failing provider: err = %v", err)
+	}
+	if _, err := client.Query(ctx, QueryParams{Query: "kafka", Mode: QueryModeKeyword}); err != nil {
+		t.Errorf("keyword Query needs no embedding: %v", err)
+	}
+}
+
+func TestClient_EmbedPending_Background(t *testing.T) {
+	provider := &wordEmbedder{}
+	client := newTestClient(t, Config{
+		EmbeddingModel:    "words",
+		EmbeddingProvider: provider,
+		EmbedInterval:     10 * time.Millisecond,
+	})
+
+	if _, err := client.Record("Kafka consumers stall on rebalance.", CategoryPatternOutcome); err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+	eventually(t, 5*time.Second, func() error {
+		result, err := client.Query(context.Background(), QueryParams{QueryEmbedding: []float32{1, 0, 0}})
+		if err != nil {
+			return err
+		}
+		if len(result.Lore) != 1 {
+			return fmt.Errorf("query found %d embedded entries, want 1", len(result.Lore))
+		}
+		return nil
+	})
+}
+
+func TestClient_EmbedPending_NoProvider(t *testing.T) {
+	client := newTestClient(t, Config{})
+
+	var ve *ValidationError
+	if _, err := client.EmbedPending(context.Background()); !errors.As(err, &ve) || ve.Field != "EmbeddingProvider" {
+		t.Errorf("EmbedPending without provider: err = %v", err)
+	}
+	cfg := Config{LocalPath: "/tmp/test.db", EmbedInterval: -time.Second}
+	if err := cfg.Validate(); !errors.As(err, &ve) || ve.Field != "EmbedInterval" {
+		t.Errorf("Validate() = %v, want an EmbedInterval ValidationError", err)
+	}
+	cfg = Config{LocalPath: "/tmp/test.db", EmbeddingProvider: &wordEmbedder{}}
+	if err := cfg.Validate(); !errors.As(err, &ve) || ve.Field != "EmbeddingModel" {
+		t.Errorf("Validate() = %v, want an EmbeddingModel ValidationError", err)
+	}
+}
+
+// blockingEmbedder signals started and then blocks until unblock closes.
+type blockingEmbedder struct {
+	started chan struct{}
+	unblock chan struct{}
+	once    sync.Once
+}
+
+func (e *blockingEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	e.once.Do(func() { close(e.started) })
+	select {
+	case <-e.unblock:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	vectors := make([][]float32, len(texts))
+	for i := range texts {
+		vectors[i] = []float32{1, 0}
+	}
+	return vectors, nil
+}
+
+func TestClient_Query_EmbedsOutsideGate(t *testing.T) {
+	provider := &blockingEmbedder{started: make(chan struct{}), unblock: make(chan struct{})}
+	client := newTestClient(t, Config{EmbeddingProvider: provider, EmbeddingModel: "blocking"})
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := client.Query(context.Background(), QueryParams{Query: "connection pools"})
+		done <- err
+	}()
+	<-provider.started
+
+	// Maintenance proceeds while the provider is still embedding the query
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if _, err := client.Maintain(ctx); err != nil {
+		t.Errorf("Maintain during query embedding: %v", err)
+	}
+	close(provider.unblock)
+	if err := <-done; err != nil {
+		t.Errorf("Query: %v", err)
+	}
+}
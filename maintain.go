@@ -0,0 +1,97 @@
+package recall
+
+import (
+	"fmt"
+	"time"
+)
+
+// MaintenanceResult reports the outcome of a Maintain run.
+type MaintenanceResult struct {
+	// EmbeddingsCleared is the number of embedding blobs dropped from
+	// soft-deleted or removed entries, including per-model vectors.
+	EmbeddingsCleared int `json:"embeddings_cleared"`
+	// SizeBefore and SizeAfter are the database size in bytes (page_count * page_size).
+	SizeBefore int64 `json:"size_before"`
+	SizeAfter  int64 `json:"size_after"`
+	// ReclaimedBytes is SizeBefore - SizeAfter, floored at zero.
+	ReclaimedBytes int64         `json:"reclaimed_bytes"`
+	Duration       time.Duration `json:"duration"`
+}
+
+// Maintain runs storage maintenance on the local database.
+//
+// The garbage-collection pass:
+//  1. Drops embedding blobs, including per-model vectors, from soft-deleted
+//     entries (tombstones keep their metadata for sync but never need
+//     vectors again) and from entries no longer in the store
+//  2. VACUUMs the database so pages freed by dropped blobs and earlier
+//     updates/deletes are returned to the filesystem
+//  3. Rebuilds the keyword index, which is keyed by rowid and so goes
+//     stale if VACUUM renumbered lore_entries
+//  4. Truncates the WAL so the reclaimed space is visible on disk
+//
+// Maintain holds the store's write lock for its duration.
+func (s *Store) Maintain() (*MaintenanceResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil, ErrStoreClosed
+	}
+
+	start := time.Now()
+	result := &MaintenanceResult{}
+
+	before, err := s.dbSize()
+	if err != nil {
+		return nil, err
+	}
+	result.SizeBefore = before
+
+	res, err := s.db.Exec(`UPDATE lore_entries SET embedding = NULL WHERE deleted_at IS NOT NULL AND embedding IS NOT NULL`)
+	if err != nil {
+		return nil, fmt.Errorf("store: clear dead embeddings: %w", err)
+	}
+	cleared, _ := res.RowsAffected()
+	result.EmbeddingsCleared = int(cleared)
+
+	res, err = s.db.Exec(`DELETE FROM lore_embeddings WHERE lore_id NOT IN (SELECT id FROM lore_entries WHERE deleted_at IS NULL)`)
+	if err != nil {
+		return nil, fmt.Errorf("store: clear dead model embeddings: %w", err)
+	}
+	cleared, _ = res.RowsAffected()
+	result.EmbeddingsCleared += int(cleared)
+
+	if _, err := s.db.Exec(`VACUUM`); err != nil {
+		return nil, fmt.Errorf("store: vacuum: %w", err)
+	}
+	if _, err := s.db.Exec(rebuildKeywordIndex); err != nil {
+		return nil, fmt.Errorf("store: rebuild keyword index: %w", err)
+	}
+	if _, err := s.db.Exec(`PRAGMA wal_checkpoint(TRUNCATE)`); err != nil {
+		return nil, fmt.Errorf("store: checkpoint wal: %w", err)
+	}
+
+	after, err := s.dbSize()
+	if err != nil {
+		return nil, err
+	}
+	result.SizeAfter = after
+	if before > after {
+		result.ReclaimedBytes = before - after
+	}
+	result.Duration = time.Since(start)
+	return result, nil
+}
+
+// dbSize returns the logical database size in bytes.
+func (s *Store) dbSize() (int64, error) {
+	var pageCount, pageSize int64
+	if err := s.db.QueryRow(`PRAGMA page_count`).Scan(&pageCount); err != nil {
+		return 0, fmt.Errorf("store: read page_count: %w", err)
+	}
+	if err := s.db.QueryRow(`PRAGMA page_size`).Scan(&pageSize); err != nil {
+		return 0, fmt.Errorf("store: read page_size: %w", err)
+	}
+	return pageCount * pageSize, nil
+}
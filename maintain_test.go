@@ -0,0 +1,64 @@
+package recall
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestStore_Maintain_ReclaimsDeadEmbeddings(t *testing.T) {
+	store := newTestStore(t)
+	now := time.Now().UTC()
+
+	embedding := PackFloat32(make([]float32, 1536))
+	for i := 0; i < 50; i++ {
+		id := fmt.Sprintf("gc-%02d", i)
+		if err := store.UpsertLore(&Lore{ID: id, Content: "entry", Category: CategoryPatternOutcome,
+			Confidence: 0.5, Embedding: embedding, EmbeddingStatus: "complete", CreatedAt: now, UpdatedAt: now}); err != nil {
+			t.Fatalf("UpsertLore: %v", err)
+		}
+		if i%2 == 0 {
+			if err := store.DeleteLoreByID(id); err != nil {
+				t.Fatalf("DeleteLoreByID: %v", err)
+			}
+		}
+	}
+
+	result, err := store.Maintain()
+	if err != nil {
+		t.Fatalf("Maintain: %v", err)
+	}
+	if result.EmbeddingsCleared != 25 {
+		t.Errorf("EmbeddingsCleared = %d, want 25", result.EmbeddingsCleared)
+	}
+	if result.ReclaimedBytes <= 0 || result.SizeAfter >= result.SizeBefore {
+		t.Errorf("expected reclaimed space, got before=%d after=%d reclaimed=%d",
+			result.SizeBefore, result.SizeAfter, result.ReclaimedBytes)
+	}
+
+	// Live entries keep their embeddings.
+	live, err := store.QueryWithEmbeddings(QueryParams{})
+	if err != nil {
+		t.Fatalf("QueryWithEmbeddings: %v", err)
+	}
+	if len(live) != 25 {
+		t.Errorf("live entries with embeddings = %d, want 25", len(live))
+	}
+
+	// A second run has nothing left to clear.
+	again, err := store.Maintain()
+	if err != nil {
+		t.Fatalf("Maintain: %v", err)
+	}
+	if again.EmbeddingsCleared != 0 {
+		t.Errorf("second EmbeddingsCleared = %d, want 0", again.EmbeddingsCleared)
+	}
+}
+
+func TestStore_Maintain_Closed(t *testing.T) {
+	store := newTestStore(t)
+	_ = store.Close()
+	if _, err := store.Maintain(); err != ErrStoreClosed {
+		t.Errorf("err = %v, want ErrStoreClosed", err)
+	}
+}
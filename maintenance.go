@@ -0,0 +1,150 @@
+package recall
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// MaintenanceHeader marks an Engram 503 response as a planned maintenance
+// window. Its value is the advertised end time in RFC 3339; when it is not
+// a time, Retry-After gives the end instead.
+const MaintenanceHeader = "X-Engram-Maintenance"
+
+// DefaultMaintenancePause is how long auto-sync pauses when a maintenance
+// response advertises no end time.
+const DefaultMaintenancePause = 5 * time.Minute
+
+// maxMaintenancePause caps an advertised window, so a bad header cannot
+// stop auto-sync indefinitely.
+const maxMaintenancePause = 24 * time.Hour
+
+// maintenanceUntilKey is the sync_meta key holding the end of the current
+// maintenance pause.
+const maintenanceUntilKey = "maintenance_until"
+
+// SyncStatus reports the state of sync with Engram.
+type SyncStatus struct {
+	Online          bool      `json:"online"`
+	LastSync        time.Time `json:"last_sync"`
+	UnpushedChanges int       `json:"unpushed_changes"`
+
+	// DeferredFeedback counts Config.ConfirmFeedback feedback waiting for
+	// its lore to be pushed before it is sent to Engram.
+	DeferredFeedback int `json:"deferred_feedback,omitempty"`
+
+	// Paused is set while auto-sync waits out an Engram maintenance
+	// window that ends at PausedUntil.
+	Paused      bool      `json:"paused"`
+	PausedUntil time.Time `json:"paused_until,omitempty"`
+	PauseReason string    `json:"pause_reason,omitempty"`
+
+	// DisabledBy names what turned the sync kill-switch on:
+	// SyncDisabledEnv or the path of the pause file. Paused is then set,
+	// with PauseReason "sync_disabled" and no PausedUntil.
+	DisabledBy string `json:"disabled_by,omitempty"`
+}
+
+// SyncStatus returns the sync state, including any maintenance pause.
+func (c *Client) SyncStatus() (*SyncStatus, error) {
+	stats, err := c.store.Stats()
+	if err != nil {
+		return nil, fmt.Errorf("client: sync status: %w", err)
+	}
+	status := &SyncStatus{
+		Online:          c.syncer != nil,
+		LastSync:        stats.LastSync,
+		UnpushedChanges: stats.UnpushedChanges,
+	}
+	if status.DeferredFeedback, err = c.store.DeferredFeedbackCount(); err != nil {
+		return nil, fmt.Errorf("client: sync status: %w", err)
+	}
+	if c.syncer != nil {
+		if by := c.syncer.DisabledBy(); by != "" {
+			status.Paused = true
+			status.PauseReason = "sync_disabled"
+			status.DisabledBy = by
+		} else if until := c.syncer.MaintenanceUntil(); !until.IsZero() {
+			status.Paused = true
+			status.PausedUntil = until
+			status.PauseReason = "engram_maintenance"
+		}
+	}
+	return status, nil
+}
+
+// MaintenanceUntil returns the end of the maintenance window Engram last
+// advertised, or the zero time if none is in effect.
+func (s *Syncer) MaintenanceUntil() time.Time {
+	value, err := s.store.GetSyncMeta(maintenanceUntilKey)
+	if err != nil || value == "" {
+		return time.Time{}
+	}
+	until, err := time.Parse(time.RFC3339, value)
+	if err != nil || !s.now().Before(until) {
+		return time.Time{}
+	}
+	return until
+}
+
+// checkMaintenance inspects a response. A maintenance response pauses
+// auto-sync, emits SyncEventMaintenanceDetected and becomes an error
+// wrapping ErrEngramMaintenance; any other response below 500 ends a pause
+// early.
+func (s *Syncer) checkMaintenance(req *http.Request, resp *http.Response) (*http.Response, error) {
+	if resp.StatusCode != http.StatusServiceUnavailable || resp.Header.Get(MaintenanceHeader) == "" {
+		if resp.StatusCode < 500 && !s.MaintenanceUntil().IsZero() {
+			if err := s.store.SetSyncMeta(maintenanceUntilKey, ""); err != nil {
+				s.debug.LogError("clear maintenance pause", err)
+			}
+		}
+		return resp, nil
+	}
+	_ = resp.Body.Close()
+
+	until := maintenanceEnd(resp.Header, s.now())
+	if err := s.store.SetSyncMeta(maintenanceUntilKey, until.Format(time.RFC3339)); err != nil {
+		s.debug.LogError("record maintenance pause", err)
+	}
+	if err := s.store.incrementMetrics(map[string]int64{metricMaintenanceDetected: 1}); err != nil {
+		s.debug.LogError("record maintenance", err)
+	}
+	s.debug.LogSync("maintenance", fmt.Sprintf("%s: Engram under maintenance until %s", req.URL.Path, until.Format(time.RFC3339)))
+	s.emitSyncEvent(SyncEvent{Type: SyncEventMaintenanceDetected, Until: until})
+
+	return nil, fmt.Errorf("%w until %s", ErrEngramMaintenance, until.Format(time.RFC3339))
+}
+
+// maintenanceEnd reads the end of a maintenance window from the
+// MaintenanceHeader or Retry-After, defaulting to DefaultMaintenancePause.
+func maintenanceEnd(h http.Header, now time.Time) time.Time {
+	until := now.Add(DefaultMaintenancePause)
+	if t, err := time.Parse(time.RFC3339, h.Get(MaintenanceHeader)); err == nil {
+		until = t
+	} else if retry := h.Get("Retry-After"); retry != "" {
+		if secs, err := strconv.Atoi(retry); err == nil && secs > 0 {
+			until = now.Add(time.Duration(secs) * time.Second)
+		} else if t, err := http.ParseTime(retry); err == nil {
+			until = t
+		}
+	}
+	if limit := now.Add(maxMaintenancePause); until.After(limit) {
+		until = limit
+	}
+	if !until.After(now) {
+		until = now.Add(DefaultMaintenancePause)
+	}
+	return until.UTC().Truncate(time.Second)
+}
+
+// pausedForMaintenance reports whether background sync should skip this
+// tick, logging the pause.
+func (c *Client) pausedForMaintenance() bool {
+	until := c.syncer.MaintenanceUntil()
+	if until.IsZero() {
+		return false
+	}
+	c.debug.LogSync("maintenance", "auto-sync paused until "+until.Format(time.RFC3339))
+	return true
+}
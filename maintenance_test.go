@@ -0,0 +1,128 @@
+package recall
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMaintenanceEnd(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		header http.Header
+		want   time.Time
+	}{
+		{"advertised end", http.Header{MaintenanceHeader: {"2026-03-01T13:30:00Z"}}, now.Add(90 * time.Minute)},
+		{"retry-after seconds", http.Header{MaintenanceHeader: {"true"}, "Retry-After": {"600"}}, now.Add(10 * time.Minute)},
+		{"retry-after date", http.Header{MaintenanceHeader: {"1"}, "Retry-After": {"Sun, 01 Mar 2026 12:20:00 GMT"}}, now.Add(20 * time.Minute)},
+		{"no end", http.Header{MaintenanceHeader: {"true"}}, now.Add(DefaultMaintenancePause)},
+		{"past end", http.Header{MaintenanceHeader: {"2026-03-01T11:00:00Z"}}, now.Add(DefaultMaintenancePause)},
+		{"capped", http.Header{MaintenanceHeader: {"2026-04-01T00:00:00Z"}}, now.Add(maxMaintenancePause)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := maintenanceEnd(tt.header, now); !got.Equal(tt.want) {
+				t.Errorf("maintenanceEnd = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSyncer_MaintenancePausesWithoutRetrying(t *testing.T) {
+	store := newTestStore(t)
+	now := time.Now().UTC()
+	if err := store.InsertLore(&Lore{ID: "lore-maint-001", Content: "x", Category: CategoryPatternOutcome, Confidence: 0.5,
+		SourceID: store.SourceID(), CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatalf("InsertLore: %v", err)
+	}
+
+	end := now.Add(30 * time.Minute).Truncate(time.Second)
+	var requests atomic.Int32
+	var down atomic.Bool
+	down.Store(true)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if down.Load() {
+			w.Header().Set(MaintenanceHeader, end.Format(time.RFC3339))
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"accepted":1}`))
+	}))
+	defer server.Close()
+
+	var events []SyncEvent
+	syncer := newTestSyncer(t, store, server.URL)
+	syncer.SetSyncEventHandler(func(ev SyncEvent) { events = append(events, ev) })
+
+	_, err := syncer.SyncPush(context.Background())
+	if !errors.Is(err, ErrEngramMaintenance) {
+		t.Fatalf("SyncPush error = %v, want ErrEngramMaintenance", err)
+	}
+	if n := requests.Load(); n != 1 {
+		t.Errorf("requests = %d, want 1 (no retries during maintenance)", n)
+	}
+	if len(events) != 1 || events[0].Type != SyncEventMaintenanceDetected || !events[0].Until.Equal(end) {
+		t.Errorf("events = %+v, want maintenance_detected until %s", events, end)
+	}
+	if got := syncer.MaintenanceUntil(); !got.Equal(end) {
+		t.Errorf("MaintenanceUntil = %s, want %s", got, end)
+	}
+	if m, _ := store.SyncMetrics(); m.MaintenanceDetected != 1 {
+		t.Errorf("MaintenanceDetected = %d, want 1", m.MaintenanceDetected)
+	}
+
+	// The window ends once the advertised time passes.
+	syncer.nowFn = func() time.Time { return end.Add(time.Second) }
+	if got := syncer.MaintenanceUntil(); !got.IsZero() {
+		t.Errorf("MaintenanceUntil after end = %s, want zero", got)
+	}
+
+	// A successful response ends the pause early.
+	syncer.nowFn = nil
+	down.Store(false)
+	if _, err := syncer.SyncPush(context.Background()); err != nil {
+		t.Fatalf("SyncPush after maintenance: %v", err)
+	}
+	if got := syncer.MaintenanceUntil(); !got.IsZero() {
+		t.Errorf("MaintenanceUntil after recovery = %s, want zero", got)
+	}
+}
+
+func TestClient_SyncStatus_Maintenance(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(MaintenanceHeader, "true")
+		w.Header().Set("Retry-After", "120")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(server.Close)
+
+	client := newTestClient(t, Config{EngramURL: server.URL, APIKey: "k"})
+
+	status, err := client.SyncStatus()
+	if err != nil {
+		t.Fatalf("SyncStatus: %v", err)
+	}
+	if !status.Online || status.Paused {
+		t.Errorf("status = %+v, want online and not paused", status)
+	}
+
+	if err := client.SyncPull(context.Background()); !errors.Is(err, ErrEngramMaintenance) {
+		t.Fatalf("SyncPull error = %v, want ErrEngramMaintenance", err)
+	}
+	status, err = client.SyncStatus()
+	if err != nil {
+		t.Fatalf("SyncStatus: %v", err)
+	}
+	if !status.Paused || status.PauseReason != "engram_maintenance" || time.Until(status.PausedUntil) <= time.Minute {
+		t.Errorf("status = %+v, want paused for about 2 minutes", status)
+	}
+	if !client.pausedForMaintenance() {
+		t.Error("background sync not paused")
+	}
+}
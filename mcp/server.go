@@ -113,6 +113,13 @@ func (s *Server) registerTools() {
 			mcp.Description("Filter by specific categories"),
 			mcp.WithStringItems(),
 		),
+		mcp.WithString("mode",
+			mcp.Description("keyword matches words in content and context, reaching lore not yet embedded; hybrid combines it with semantic ranking (default: semantic when available)"),
+			mcp.Enum("semantic", "keyword", "hybrid"),
+		),
+		mcp.WithNumber("temperature",
+			mcp.Description("Sample results weighted by confidence and similarity instead of taking the top k, to surface less-validated lore; 0.1 stays near the top, 1 is close to random (default: 0, top k)"),
+		),
 		mcp.WithString("store",
 			mcp.Description("Target store ID (default: resolved via env/config/default)"),
 		),
@@ -155,6 +162,10 @@ func (s *Server) registerTools() {
 			mcp.Description("Session refs or lore IDs of incorrect lore (-0.15 confidence)"),
 			mcp.WithStringItems(),
 		),
+		mcp.WithArray("outdated",
+			mcp.Description("Session refs or lore IDs of lore that was right but no longer is (-0.30 confidence)"),
+			mcp.WithStringItems(),
+		),
 		mcp.WithString("store",
 			mcp.Description("Target store ID (only needed for direct lore IDs, not session refs)"),
 		),
@@ -287,6 +298,14 @@ func (s *Server) handleQuery(ctx context.Context, args map[string]any) (*ToolRes
 		}
 	}
 
+	if mode, ok := args["mode"].(string); ok {
+		qp.Mode = recall.QueryMode(mode)
+	}
+
+	if temperature, ok := args["temperature"].(float64); ok {
+		qp.Temperature = temperature
+	}
+
 	result, err := s.client.Query(ctx, qp)
 	if err != nil {
 		return &ToolResult{Content: fmt.Sprintf("query failed: %v", err), IsError: true}, nil
@@ -338,13 +357,14 @@ func (s *Server) handleRecord(ctx context.Context, args map[string]any) (*ToolRe
 }
 
 func (s *Server) handleFeedback(ctx context.Context, args map[string]any) (*ToolResult, error) {
-	var helpful, notRelevant, incorrect []string
+	var helpful, notRelevant, incorrect, outdated []string
 
 	helpful = toStringSlice(args["helpful"])
 	notRelevant = toStringSlice(args["not_relevant"])
 	incorrect = toStringSlice(args["incorrect"])
+	outdated = toStringSlice(args["outdated"])
 
-	if len(helpful) == 0 && len(notRelevant) == 0 && len(incorrect) == 0 {
+	if len(helpful) == 0 && len(notRelevant) == 0 && len(incorrect) == 0 && len(outdated) == 0 {
 		return &ToolResult{Content: "at least one feedback type must be provided", IsError: true}, nil
 	}
 
@@ -353,9 +373,10 @@ func (s *Server) handleFeedback(ctx context.Context, args map[string]any) (*Tool
 	helpfulRefs := s.resolveSessionRefsWithStore(helpful)
 	notRelevantRefs := s.resolveSessionRefsWithStore(notRelevant)
 	incorrectRefs := s.resolveSessionRefsWithStore(incorrect)
+	outdatedRefs := s.resolveSessionRefsWithStore(outdated)
 
 	// Group feedback by store for routing
-	feedbackByStore := s.groupFeedbackByStore(helpfulRefs, notRelevantRefs, incorrectRefs)
+	feedbackByStore := s.groupFeedbackByStore(helpfulRefs, notRelevantRefs, incorrectRefs, outdatedRefs)
 
 	// Apply feedback for each store and aggregate results
 	result := &recall.FeedbackResult{Updated: []recall.FeedbackUpdate{}}
@@ -376,7 +397,8 @@ func (s *Server) handleFeedback(ctx context.Context, args map[string]any) (*Tool
 }
 
 // resolveSessionRefsWithStore converts session refs (L1, L2) to StoreRefs,
-// preserving the store context for each lore entry.
+// preserving the store context for each lore entry. Refs qualified with a
+// store ("store-a:L1", "store-a:<lore ID>") route to that store.
 // If a ref is already a lore ID (not a session ref), it's returned with an empty store ID.
 func (s *Server) resolveSessionRefsWithStore(refs []string) []StoreRef {
 	if len(refs) == 0 {
@@ -387,6 +409,10 @@ func (s *Server) resolveSessionRefsWithStore(refs []string) []StoreRef {
 	for _, ref := range refs {
 		if storeRef, ok := s.session.Resolve(ref); ok {
 			resolved = append(resolved, storeRef)
+		} else if storeID, loreID, ok := ParseQualifiedRef(ref); ok && !isSessionRef(loreID) {
+			// A lore ID qualified with its store. A session ref qualified
+			// with the wrong store falls through and is not found.
+			resolved = append(resolved, StoreRef{StoreID: storeID, LoreID: loreID})
 		} else {
 			// Not a session ref, pass through as-is with empty store ID
 			// Direct lore IDs will use the default/resolved store
@@ -401,11 +427,12 @@ type storeFeedback struct {
 	Helpful     []string
 	NotRelevant []string
 	Incorrect   []string
+	Outdated    []string
 }
 
 // groupFeedbackByStore groups resolved refs by their store ID.
 // Refs without a store ID (direct lore IDs) are grouped under an empty string key.
-func (s *Server) groupFeedbackByStore(helpful, notRelevant, incorrect []StoreRef) map[string]*storeFeedback {
+func (s *Server) groupFeedbackByStore(helpful, notRelevant, incorrect, outdated []StoreRef) map[string]*storeFeedback {
 	result := make(map[string]*storeFeedback)
 
 	getOrCreate := func(storeID string) *storeFeedback {
@@ -432,6 +459,11 @@ func (s *Server) groupFeedbackByStore(helpful, notRelevant, incorrect []StoreRef
 		sf.Incorrect = append(sf.Incorrect, ref.LoreID)
 	}
 
+	for _, ref := range outdated {
+		sf := getOrCreate(ref.StoreID)
+		sf.Outdated = append(sf.Outdated, ref.LoreID)
+	}
+
 	return result
 }
 
@@ -445,6 +477,7 @@ func (s *Server) applyStoreFeedback(ctx context.Context, storeID string, feedbac
 		Helpful:     feedback.Helpful,
 		NotRelevant: feedback.NotRelevant,
 		Incorrect:   feedback.Incorrect,
+		Outdated:    feedback.Outdated,
 	})
 }
 
@@ -466,7 +499,11 @@ func (s *Server) formatQueryResultWithSession(result *recall.QueryResult, sessio
 	}
 
 	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf("Found %d matching lore entries:\n\n", len(result.Lore)))
+	if total := result.Stats.TotalMatched; total > len(result.Lore) {
+		sb.WriteString(fmt.Sprintf("Showing %d of %d matching lore entries (raise k or narrow the query):\n\n", len(result.Lore), total))
+	} else {
+		sb.WriteString(fmt.Sprintf("Found %d matching lore entries:\n\n", len(result.Lore)))
+	}
 
 	// Build reverse map from lore ID to session ref
 	idToRef := make(map[string]string)
@@ -481,10 +518,17 @@ func (s *Server) formatQueryResultWithSession(result *recall.QueryResult, sessio
 		}
 		sb.WriteString(fmt.Sprintf("[%s] %s\n", ref, l.Category))
 		sb.WriteString(fmt.Sprintf("    %s\n", l.Content))
-		sb.WriteString(fmt.Sprintf("    Confidence: %.2f\n\n", l.Confidence))
+		if result.NeedsValidation[l.ID] {
+			sb.WriteString(fmt.Sprintf("    Confidence: %.2f (needs validation)\n\n", l.Confidence))
+		} else {
+			sb.WriteString(fmt.Sprintf("    Confidence: %.2f\n\n", l.Confidence))
+		}
 	}
 
 	sb.WriteString("Use recall_feedback with session refs (L1, L2, ...) to rate helpfulness.")
+	if len(result.NeedsValidation) > 0 {
+		sb.WriteString(" Entries marked (needs validation) are unconfirmed: give feedback once your task shows whether they hold.")
+	}
 	return sb.String()
 }
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/hyperengineering/recall"
@@ -102,6 +103,30 @@ func TestTool_Query_Success(t *testing.T) {
 	}
 }
 
+// TestTool_Query_NeedsValidation tests that unvalidated lore is marked.
+func TestTool_Query_NeedsValidation(t *testing.T) {
+	client, err := recall.New(recall.Config{LocalPath: filepath.Join(t.TempDir(), "test.db")})
+	if err != nil {
+		t.Fatalf("recall.New() returned error: %v", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	if _, err := client.Record("Error handling patterns in Go", recall.CategoryPatternOutcome); err != nil {
+		t.Fatalf("Record() returned error: %v", err)
+	}
+
+	server := recallmcp.NewServer(client)
+	result, err := server.CallTool(context.Background(), "recall_query", map[string]any{
+		"query": "error handling",
+	})
+	if err != nil {
+		t.Fatalf("CallTool() returned error: %v", err)
+	}
+	if !strings.Contains(result.Content, "(needs validation)") {
+		t.Errorf("content = %q, want unvalidated entry marked", result.Content)
+	}
+}
+
 // TestTool_Query_NoResults tests query with no matching results.
 func TestTool_Query_NoResults(t *testing.T) {
 	dir := t.TempDir()
@@ -828,6 +853,40 @@ func TestFeedback_GroupsByStore(t *testing.T) {
 	}
 }
 
+// TestFeedback_QualifiedRefs tests that refs qualified with their origin
+// store route to that store, and that a qualifier naming another store is
+// not resolved to a different entry.
+func TestFeedback_QualifiedRefs(t *testing.T) {
+	client, err := recall.New(recall.Config{LocalPath: filepath.Join(t.TempDir(), "test.db")})
+	if err != nil {
+		t.Fatalf("recall.New() returned error: %v", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	server := recallmcp.NewServer(client)
+	lore, err := client.Record("Qualified refs route feedback to their store", recall.CategoryPatternOutcome)
+	if err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+	if _, err := server.CallTool(context.Background(), "recall_query", map[string]any{
+		"query": "qualified refs",
+		"store": "team-a",
+	}); err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+
+	result, err := server.CallTool(context.Background(), "recall_feedback", map[string]any{
+		"helpful":   []string{"team-a:L1", "team-a:" + lore.ID},
+		"incorrect": []string{"team-b:L1"},
+	})
+	if err != nil {
+		t.Fatalf("Feedback failed: %v", err)
+	}
+	if !strings.Contains(result.Content, "Updated: 2 entries") || !strings.Contains(result.Content, "- team-b:L1") {
+		t.Errorf("Feedback result = %q, want the team-a refs applied and team-b:L1 not found", result.Content)
+	}
+}
+
 // containsString checks if a string contains a substring.
 func containsString(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(s) > 0 && containsSubstring(s, substr))
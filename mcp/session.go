@@ -2,6 +2,7 @@ package mcp
 
 import (
 	"fmt"
+	"strings"
 	"sync"
 )
 
@@ -55,16 +56,55 @@ func (s *MultiStoreSession) Track(storeID, loreID string) string {
 	return ref
 }
 
-// Resolve converts a session reference to a store/lore pair.
+// Resolve converts a session reference to a store/lore pair. The ref may
+// be qualified with its origin store ("store-a:L1"), in which case the
+// store must match the one the ref was tracked from.
 // Returns false if the ref doesn't exist in this session.
 func (s *MultiStoreSession) Resolve(ref string) (StoreRef, bool) {
+	storeID, ref, qualified := ParseQualifiedRef(ref)
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	storeRef, ok := s.refs[ref]
+	if ok && qualified && storeRef.StoreID != storeID {
+		return StoreRef{}, false
+	}
 	return storeRef, ok
 }
 
+// QualifiedRef returns ref qualified with the store it was tracked from
+// ("store-a:L1"), for output that mixes lore from several stores.
+// Returns false if the ref doesn't exist in this session.
+func (s *MultiStoreSession) QualifiedRef(ref string) (string, bool) {
+	storeRef, ok := s.Resolve(ref)
+	if !ok {
+		return "", false
+	}
+	_, ref, _ = ParseQualifiedRef(ref)
+	return QualifyRef(storeRef.StoreID, ref), true
+}
+
+// QualifyRef prefixes ref, a session ref or lore ID, with storeID.
+// Store IDs never contain a colon, so the result parses unambiguously.
+func QualifyRef(storeID, ref string) string {
+	if storeID == "" {
+		return ref
+	}
+	return storeID + ":" + ref
+}
+
+// ParseQualifiedRef splits a ref qualified by QualifyRef into its store ID
+// and the session ref or lore ID. Unqualified refs are returned unchanged
+// with qualified false.
+func ParseQualifiedRef(ref string) (storeID, rest string, qualified bool) {
+	storeID, rest, qualified = strings.Cut(ref, ":")
+	if !qualified || storeID == "" || rest == "" {
+		return "", ref, false
+	}
+	return storeID, rest, true
+}
+
 // ResolveByLore gets the session reference for a specific store/lore combination.
 // Returns false if the combination hasn't been tracked in this session.
 func (s *MultiStoreSession) ResolveByLore(storeID, loreID string) (string, bool) {
@@ -98,6 +138,19 @@ func (s *MultiStoreSession) Clear() {
 	s.counter = 0
 }
 
+// isSessionRef reports whether ref has the session ref form (L1, L2).
+func isSessionRef(ref string) bool {
+	if len(ref) < 2 || ref[0] != 'L' {
+		return false
+	}
+	for _, ch := range ref[1:] {
+		if ch < '0' || ch > '9' {
+			return false
+		}
+	}
+	return true
+}
+
 // reverseKey generates a unique key for the reverse lookup map.
 func reverseKey(storeID, loreID string) string {
 	return storeID + ":" + loreID
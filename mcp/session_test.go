@@ -165,6 +165,44 @@ func TestMultiStoreSession_ResolveByLore_NotFound(t *testing.T) {
 	}
 }
 
+func TestMultiStoreSession_QualifiedRefs(t *testing.T) {
+	session := mcp.NewMultiStoreSession()
+
+	session.Track("store-a", "lore-abc")
+	session.Track("team/store-b", "lore-xyz")
+
+	if ref, ok := session.QualifiedRef("L2"); !ok || ref != "team/store-b:L2" {
+		t.Errorf("QualifiedRef(L2) = %q, %v; want team/store-b:L2", ref, ok)
+	}
+	if ref, ok := session.Resolve("store-a:L1"); !ok || ref.StoreID != "store-a" || ref.LoreID != "lore-abc" {
+		t.Errorf("Resolve(store-a:L1) = %+v, %v; want {store-a, lore-abc}", ref, ok)
+	}
+	if ref, ok := session.QualifiedRef("store-a:L1"); !ok || ref != "store-a:L1" {
+		t.Errorf("QualifiedRef(store-a:L1) = %q, %v; want it unchanged", ref, ok)
+	}
+
+	// The qualifier must name the store the ref was tracked from
+	if _, ok := session.Resolve("store-a:L2"); ok {
+		t.Error("Resolve(store-a:L2) should return false for a ref from team/store-b")
+	}
+	if _, ok := session.QualifiedRef("L3"); ok {
+		t.Error("QualifiedRef(L3) should return false for an untracked ref")
+	}
+
+	for _, tt := range []struct{ ref, store, rest string }{
+		{"store-a:L1", "store-a", "L1"},
+		{"store-a:01HQXYZ", "store-a", "01HQXYZ"},
+		{"L1", "", "L1"},
+		{":L1", "", ":L1"},
+		{"store-a:", "", "store-a:"},
+	} {
+		store, rest, qualified := mcp.ParseQualifiedRef(tt.ref)
+		if store != tt.store || rest != tt.rest || qualified != (tt.store != "") {
+			t.Errorf("ParseQualifiedRef(%q) = %q, %q, %v", tt.ref, store, rest, qualified)
+		}
+	}
+}
+
 func TestMultiStoreSession_All_ReturnsAllEntries(t *testing.T) {
 	session := mcp.NewMultiStoreSession()
 
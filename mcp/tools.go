@@ -148,6 +148,11 @@ func RegisterTools(registry Registry, client *recall.Client) {
 				Description: "Session refs of lore that was wrong or misleading",
 				Items:       map[string]string{"type": "string"},
 			},
+			"outdated": {
+				Type:        "array",
+				Description: "Session refs of lore that was right but no longer is",
+				Items:       map[string]string{"type": "string"},
+			},
 		},
 		Handler: makeFeedbackHandler(client),
 	})
@@ -228,6 +233,7 @@ type feedbackParams struct {
 	Helpful     []string `json:"helpful"`
 	NotRelevant []string `json:"not_relevant"`
 	Incorrect   []string `json:"incorrect"`
+	Outdated    []string `json:"outdated"`
 }
 
 func makeFeedbackHandler(client *recall.Client) Handler {
@@ -241,6 +247,7 @@ func makeFeedbackHandler(client *recall.Client) Handler {
 			Helpful:     params.Helpful,
 			NotRelevant: params.NotRelevant,
 			Incorrect:   params.Incorrect,
+			Outdated:    params.Outdated,
 		})
 	}
 }
@@ -0,0 +1,179 @@
+package recall
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hyperengineering/recall/internal/store"
+)
+
+// ErrInvalidNamespace indicates a namespace name is malformed.
+var ErrInvalidNamespace = errors.New("invalid namespace: must be lowercase alphanumeric with hyphens, 1-4 path segments")
+
+// ValidateNamespace checks a namespace name. Namespaces follow the store ID
+// format; the empty string is the default namespace and is always valid.
+func ValidateNamespace(ns string) error {
+	if ns == "" {
+		return nil
+	}
+	if err := store.ValidateStoreID(ns); err != nil {
+		return ErrInvalidNamespace
+	}
+	return nil
+}
+
+// NamespaceStats describes a namespace present in the store.
+type NamespaceStats struct {
+	Namespace string `json:"namespace"` // Empty for the default namespace
+	LoreCount int    `json:"lore_count"`
+}
+
+// NamespaceMoveFilter selects the entries moved by MoveNamespace.
+// An empty filter moves every entry in the source namespace (a merge);
+// a filter splits the matching entries out.
+type NamespaceMoveFilter struct {
+	IDs        []string
+	Categories []Category
+}
+
+// SetNamespace scopes subsequent reads and writes to ns.
+// Returns ErrInvalidNamespace if ns is malformed.
+func (s *Store) SetNamespace(ns string) error {
+	if err := ValidateNamespace(ns); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.namespace = ns
+	return nil
+}
+
+// Namespace returns the namespace the store is scoped to.
+func (s *Store) Namespace() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.namespace
+}
+
+// Namespaces lists every namespace holding live lore, ordered by name.
+// Unlike other reads it is not scoped to the current namespace.
+func (s *Store) Namespaces() ([]NamespaceStats, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil, ErrStoreClosed
+	}
+
+	rows, err := s.db.Query(`
+		SELECT namespace, COUNT(*)
+		FROM lore_entries
+		WHERE deleted_at IS NULL
+		GROUP BY namespace
+		ORDER BY namespace
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("store: list namespaces: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var stats []NamespaceStats
+	for rows.Next() {
+		var ns NamespaceStats
+		if err := rows.Scan(&ns.Namespace, &ns.LoreCount); err != nil {
+			return nil, fmt.Errorf("store: scan namespace: %w", err)
+		}
+		stats = append(stats, ns)
+	}
+	return stats, rows.Err()
+}
+
+// MoveNamespace reassigns live lore from one namespace to another in a
+// single transaction, returning the number of entries moved. Each moved
+// entry gets a change_log upsert carrying its new namespace so the move
+// syncs like any other edit.
+func (s *Store) MoveNamespace(from, to string, filter NamespaceMoveFilter) (int, error) {
+	if err := ValidateNamespace(from); err != nil {
+		return 0, err
+	}
+	if err := ValidateNamespace(to); err != nil {
+		return 0, err
+	}
+	if from == to {
+		return 0, &ValidationError{Field: "to", Message: "must differ from source namespace"}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return 0, ErrStoreClosed
+	}
+
+	query := `
+		SELECT id, content, context, category, confidence, embedding, embedding_status, source_id, sources,
+		       validation_count, last_validated_at, created_at, updated_at, deleted_at, synced_at, namespace
+		FROM lore_entries WHERE deleted_at IS NULL AND namespace = ?
+	`
+	args := []any{from}
+	if len(filter.IDs) > 0 {
+		query += fmt.Sprintf(" AND id IN (%s)", strings.TrimSuffix(strings.Repeat("?,", len(filter.IDs)), ","))
+		for _, id := range filter.IDs {
+			args = append(args, id)
+		}
+	}
+	if len(filter.Categories) > 0 {
+		query += fmt.Sprintf(" AND category IN (%s)", strings.TrimSuffix(strings.Repeat("?,", len(filter.Categories)), ","))
+		for _, cat := range filter.Categories {
+			args = append(args, string(cat))
+		}
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("store: begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	rows, err := tx.Query(query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("store: query namespace: %w", err)
+	}
+	var moved []*Lore
+	for rows.Next() {
+		lore, err := s.scanLoreRows(rows)
+		if err != nil {
+			_ = rows.Close()
+			return 0, err
+		}
+		moved = append(moved, lore)
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("store: query namespace: %w", err)
+	}
+
+	now := s.now()
+	for _, lore := range moved {
+		lore.Namespace = to
+		lore.UpdatedAt = now
+		if _, err := tx.Exec(`UPDATE lore_entries SET namespace = ?, updated_at = ? WHERE id = ?`,
+			to, now.Format(time.RFC3339), lore.ID); err != nil {
+			return 0, fmt.Errorf("store: move lore: %w", err)
+		}
+		payload, err := lorePayloadJSON(lore)
+		if err != nil {
+			return 0, fmt.Errorf("store: marshal change_log payload: %w", err)
+		}
+		if err := s.appendChangeLog(tx, "lore_entries", lore.ID, "upsert", payload, s.sourceID); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("store: commit namespace move: %w", err)
+	}
+	return len(moved), nil
+}
@@ -0,0 +1,152 @@
+package recall
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+)
+
+func TestStore_Namespace_IsolatesReadsAndWrites(t *testing.T) {
+	store := newTestStore(t)
+
+	shared := &Lore{ID: "NSDEFAULT0000000000000001", Content: "default lore", Category: CategoryPatternOutcome, Confidence: 0.5}
+	if err := store.InsertLore(shared); err != nil {
+		t.Fatalf("InsertLore: %v", err)
+	}
+
+	if err := store.SetNamespace("team-a"); err != nil {
+		t.Fatalf("SetNamespace: %v", err)
+	}
+	scoped := &Lore{ID: "NSTEAMA000000000000000001", Content: "team a lore", Category: CategoryPatternOutcome, Confidence: 0.5}
+	if err := store.InsertLore(scoped); err != nil {
+		t.Fatalf("InsertLore: %v", err)
+	}
+
+	if _, err := store.Get(shared.ID); err != ErrNotFound {
+		t.Errorf("Get(default entry) in team-a err = %v, want ErrNotFound", err)
+	}
+	got, err := store.Query(QueryParams{})
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	if len(got) != 1 || got[0].ID != scoped.ID || got[0].Namespace != "team-a" {
+		t.Errorf("Query in team-a = %+v, want only the team-a entry", got)
+	}
+	if _, err := store.ApplyFeedback(shared.ID, 0.1, true); err != ErrNotFound {
+		t.Errorf("ApplyFeedback across namespaces err = %v, want ErrNotFound", err)
+	}
+
+	if err := store.SetNamespace(""); err != nil {
+		t.Fatalf("SetNamespace: %v", err)
+	}
+	stats, err := store.Stats()
+	if err != nil {
+		t.Fatalf("Stats: %v", err)
+	}
+	if stats.LoreCount != 1 {
+		t.Errorf("default namespace LoreCount = %d, want 1", stats.LoreCount)
+	}
+
+	namespaces, err := store.Namespaces()
+	if err != nil {
+		t.Fatalf("Namespaces: %v", err)
+	}
+	if len(namespaces) != 2 || namespaces[0].Namespace != "" || namespaces[1].Namespace != "team-a" {
+		t.Errorf("Namespaces = %+v, want default and team-a", namespaces)
+	}
+}
+
+func TestStore_SetNamespace_Invalid(t *testing.T) {
+	store := newTestStore(t)
+	if err := store.SetNamespace("Bad_Name"); err != ErrInvalidNamespace {
+		t.Errorf("SetNamespace err = %v, want ErrInvalidNamespace", err)
+	}
+
+	cfg := Config{LocalPath: filepath.Join(t.TempDir(), "lore.db"), Namespace: "Bad_Name"}
+	if _, err := New(cfg); err == nil {
+		t.Error("New with invalid namespace should fail")
+	}
+}
+
+func TestStore_Namespace_ChangeLogPayload(t *testing.T) {
+	store := newTestStore(t)
+	if err := store.SetNamespace("team-a"); err != nil {
+		t.Fatalf("SetNamespace: %v", err)
+	}
+	lore := &Lore{ID: "NSPAYLOAD0000000000000001", Content: "scoped", Category: CategoryPatternOutcome, Confidence: 0.5}
+	if err := store.InsertLore(lore); err != nil {
+		t.Fatalf("InsertLore: %v", err)
+	}
+
+	entries, err := store.UnpushedChanges(store.SourceID(), 0, 100)
+	if err != nil {
+		t.Fatalf("UnpushedChanges: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("change_log entries = %d, want 1", len(entries))
+	}
+	var payload struct {
+		Namespace string `json:"namespace"`
+	}
+	if err := json.Unmarshal(entries[0].Payload, &payload); err != nil {
+		t.Fatalf("unmarshal payload: %v", err)
+	}
+	if payload.Namespace != "team-a" {
+		t.Errorf("payload namespace = %q, want team-a", payload.Namespace)
+	}
+
+	parsed, err := parseLorePayload(entries[0].Payload)
+	if err != nil {
+		t.Fatalf("parseLorePayload: %v", err)
+	}
+	if parsed.Namespace != "team-a" {
+		t.Errorf("parsed namespace = %q, want team-a", parsed.Namespace)
+	}
+}
+
+func TestStore_MoveNamespace_SplitAndMerge(t *testing.T) {
+	store := newTestStore(t)
+	for _, l := range []*Lore{
+		{ID: "NSMOVE0000000000000000001", Content: "pattern", Category: CategoryPatternOutcome, Confidence: 0.5},
+		{ID: "NSMOVE0000000000000000002", Content: "testing", Category: CategoryTestingStrategy, Confidence: 0.5},
+		{ID: "NSMOVE0000000000000000003", Content: "testing too", Category: CategoryTestingStrategy, Confidence: 0.5},
+	} {
+		if err := store.InsertLore(l); err != nil {
+			t.Fatalf("InsertLore: %v", err)
+		}
+	}
+
+	moved, err := store.MoveNamespace("", "qa", NamespaceMoveFilter{Categories: []Category{CategoryTestingStrategy}})
+	if err != nil {
+		t.Fatalf("MoveNamespace (split): %v", err)
+	}
+	if moved != 2 {
+		t.Errorf("split moved = %d, want 2", moved)
+	}
+	if count, _ := store.LoreCount(); count != 1 {
+		t.Errorf("default namespace count = %d, want 1", count)
+	}
+
+	entries, err := store.UnpushedChanges(store.SourceID(), 0, 100)
+	if err != nil {
+		t.Fatalf("UnpushedChanges: %v", err)
+	}
+	if len(entries) != 5 {
+		t.Errorf("change_log entries = %d, want 3 inserts + 2 moves", len(entries))
+	}
+
+	moved, err = store.MoveNamespace("qa", "", NamespaceMoveFilter{})
+	if err != nil {
+		t.Fatalf("MoveNamespace (merge): %v", err)
+	}
+	if moved != 2 {
+		t.Errorf("merge moved = %d, want 2", moved)
+	}
+	if count, _ := store.LoreCount(); count != 3 {
+		t.Errorf("default namespace count after merge = %d, want 3", count)
+	}
+
+	if _, err := store.MoveNamespace("qa", "qa", NamespaceMoveFilter{}); err == nil {
+		t.Error("MoveNamespace to the same namespace should fail")
+	}
+}
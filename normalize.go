@@ -0,0 +1,254 @@
+package recall
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Normalizer rewrites content before Record validates and stores it.
+// Name identifies the normalizer in RECALL_NORMALIZE and in preserved
+// originals.
+type Normalizer interface {
+	Name() string
+	Normalize(content string) string
+}
+
+// Built-in normalizers.
+var (
+	// NormalizeANSI strips ANSI escape sequences and other control
+	// characters from terminal captures. Text overwritten by a carriage
+	// return, such as progress bars, keeps only its final state.
+	NormalizeANSI Normalizer = normalizer{"ansi", stripANSI}
+
+	// NormalizeMarkdown trims artifacts left by copying one item out of a
+	// markdown document: a code fence around the whole content, a leading
+	// list, quote or heading marker, bold around the whole content, and
+	// horizontal rules.
+	NormalizeMarkdown Normalizer = normalizer{"markdown", trimMarkdown}
+
+	// NormalizeUnicode converts content to NFC, drops zero-width
+	// characters and turns non-breaking spaces into spaces.
+	NormalizeUnicode Normalizer = normalizer{"unicode", normalizeUnicode}
+
+	// NormalizeWhitespace collapses runs of spaces and tabs inside lines,
+	// drops trailing whitespace, keeps at most one blank line between
+	// paragraphs, and trims the content. Indentation is kept.
+	NormalizeWhitespace Normalizer = normalizer{"whitespace", collapseWhitespace}
+)
+
+// AllNormalizers returns the built-in normalizers in the order "all"
+// selects them.
+func AllNormalizers() []Normalizer {
+	return []Normalizer{NormalizeANSI, NormalizeMarkdown, NormalizeUnicode, NormalizeWhitespace}
+}
+
+// ParseNormalizers parses a comma-separated list of built-in normalizer
+// names, e.g. "ansi,whitespace", as used by RECALL_NORMALIZE. "all"
+// selects every built-in normalizer. Normalizers run in the order given.
+func ParseNormalizers(s string) ([]Normalizer, error) {
+	var normalizers []Normalizer
+	for _, name := range strings.Split(s, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if name == "all" {
+			normalizers = append(normalizers, AllNormalizers()...)
+			continue
+		}
+		found := false
+		for _, n := range AllNormalizers() {
+			if n.Name() == name {
+				normalizers = append(normalizers, n)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, &ValidationError{Field: "Normalizers", Message: fmt.Sprintf("unknown normalizer %q: want ansi, markdown, unicode, whitespace or all", name)}
+		}
+	}
+	return normalizers, nil
+}
+
+type normalizer struct {
+	name string
+	fn   func(string) string
+}
+
+func (n normalizer) Name() string                    { return n.name }
+func (n normalizer) Normalize(content string) string { return n.fn(content) }
+
+var (
+	ansiEscape     = regexp.MustCompile(`\x1b(\[[0-?]*[ -/]*[@-~]|\][^\x07\x1b]*(\x07|\x1b\\)|[@-Z\\-_])`)
+	horizontalRule = regexp.MustCompile(`(?m)^[ \t]*(?:(?:-[ \t]*){3,}|(?:\*[ \t]*){3,}|(?:_[ \t]*){3,})(?:\n|$)`)
+	leadingMarker  = regexp.MustCompile(`^(#{1,6}|[-*+>]|\d+[.)])[ \t]+`)
+	blankRun       = regexp.MustCompile(`[ \t]{2,}`)
+	blankLines     = regexp.MustCompile(`\n{3,}`)
+)
+
+func stripANSI(s string) string {
+	s = ansiEscape.ReplaceAllString(s, "")
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if j := strings.LastIndexByte(line, '\r'); j >= 0 {
+			line = line[j+1:]
+		}
+		lines[i] = strings.Map(func(r rune) rune {
+			if r < 0x20 && r != '\t' || r == 0x7f {
+				return -1
+			}
+			return r
+		}, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func trimMarkdown(s string) string {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "```") && strings.HasSuffix(s, "```") && len(s) > 6 {
+		if i := strings.IndexByte(s, '\n'); i >= 0 && i < len(s)-3 {
+			s = strings.TrimSpace(s[i+1 : len(s)-3])
+		}
+	}
+	s = strings.TrimSpace(blankLines.ReplaceAllString(horizontalRule.ReplaceAllString(s, ""), "\n\n"))
+	s = leadingMarker.ReplaceAllString(s, "")
+	if len(s) > 4 && strings.HasPrefix(s, "**") && strings.HasSuffix(s, "**") && !strings.Contains(s[2:len(s)-2], "**") {
+		s = s[2 : len(s)-2]
+	}
+	return s
+}
+
+func normalizeUnicode(s string) string {
+	s = norm.NFC.String(s)
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '\u200b', '\u200c', '\u200d', '\u2060', '\ufeff': // zero-width
+			return -1
+		case '\u00a0', '\u202f': // non-breaking spaces
+			return ' '
+		}
+		return r
+	}, s)
+}
+
+func collapseWhitespace(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		line = strings.TrimRight(line, " \t")
+		body := strings.TrimLeft(line, " \t")
+		indent := line[:len(line)-len(body)]
+		lines[i] = indent + blankRun.ReplaceAllString(body, " ")
+	}
+	s = strings.Join(lines, "\n")
+	return strings.TrimSpace(blankLines.ReplaceAllString(s, "\n\n"))
+}
+
+// normalize applies Config.Normalizers to content in order and returns
+// the result with the names of the normalizers that changed it.
+func (c *Client) normalize(content string) (string, []string) {
+	var applied []string
+	for _, n := range c.config.Normalizers {
+		if normalized := n.Normalize(content); normalized != content {
+			content = normalized
+			applied = append(applied, n.Name())
+		}
+	}
+	return content, applied
+}
+
+// OriginalContent is the content of a lore entry as submitted to Record,
+// before normalizers rewrote it.
+type OriginalContent struct {
+	LoreID      string    `json:"lore_id"`
+	Content     string    `json:"content"`
+	Normalizers []string  `json:"normalizers"` // Normalizers that changed the content, in order
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// SetOriginalContent stores the content an entry was submitted with.
+// Originals are local-only.
+func (s *Store) SetOriginalContent(original *OriginalContent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return ErrStoreClosed
+	}
+
+	_, err := s.db.Exec(`
+		INSERT INTO lore_originals (lore_id, content, normalizers, created_at) VALUES (?, ?, ?, ?)
+		ON CONFLICT(lore_id) DO UPDATE SET content = excluded.content, normalizers = excluded.normalizers, created_at = excluded.created_at
+	`, original.LoreID, original.Content, strings.Join(original.Normalizers, ","), original.CreatedAt.UTC().Format(time.RFC3339))
+	if err != nil {
+		return fmt.Errorf("store: set original content: %w", err)
+	}
+	return nil
+}
+
+// OriginalContent returns the content loreID was submitted with, or nil if
+// it was stored as submitted.
+func (s *Store) OriginalContent(loreID string) (*OriginalContent, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil, ErrStoreClosed
+	}
+
+	original := &OriginalContent{LoreID: loreID}
+	var normalizers, createdAt string
+	err := s.db.QueryRow(`SELECT content, normalizers, created_at FROM lore_originals WHERE lore_id = ?`, loreID).
+		Scan(&original.Content, &normalizers, &createdAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("store: original content: %w", err)
+	}
+	original.Normalizers = strings.Split(normalizers, ",")
+	original.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+	return original, nil
+}
+
+// OriginalContent returns the content ref was submitted with before
+// Config.Normalizers rewrote it, or nil if it was stored as submitted or
+// Config.PreserveOriginal was not set. For chunked content, the original
+// is kept with the first part. ref may be a lore ID or a session ref.
+func (c *Client) OriginalContent(ref string) (*OriginalContent, error) {
+	id, err := c.resolveRef(ref)
+	if err != nil {
+		return nil, err
+	}
+	original, err := c.store.OriginalContent(id)
+	if err != nil {
+		return nil, fmt.Errorf("client: original content: %w", err)
+	}
+	return original, nil
+}
+
+// preserveOriginal keeps the submitted content of a normalized entry when
+// Config.PreserveOriginal is set. Preservation is best-effort; a failed
+// write is reported to the debug log and does not fail Record.
+func (c *Client) preserveOriginal(lore *Lore, content string, applied []string) {
+	if !c.config.PreserveOriginal || len(applied) == 0 {
+		return
+	}
+	err := c.store.SetOriginalContent(&OriginalContent{
+		LoreID:      lore.ID,
+		Content:     content,
+		Normalizers: applied,
+		CreatedAt:   lore.CreatedAt,
+	})
+	if err != nil {
+		c.debug.LogError("preserve original content", err)
+	}
+}
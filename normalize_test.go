@@ -0,0 +1,122 @@
+package recall
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNormalizers(t *testing.T) {
+	tests := []struct {
+		normalizer Normalizer
+		in, want   string
+	}{
+		{NormalizeANSI, "\x1b[1;32mPASS\x1b[0m ok", "PASS ok"},
+		{NormalizeANSI, "\x1b]0;title\x07build done", "build done"},
+		{NormalizeANSI, "10%\r50%\r100% done\r\nnext", "100% done\nnext"},
+		{NormalizeANSI, "bell\x07 and tab\tkept", "bell and tab\tkept"},
+
+		{NormalizeMarkdown, "```go\nuse pgx v5\n```", "use pgx v5"},
+		{NormalizeMarkdown, "- Retry with jitter", "Retry with jitter"},
+		{NormalizeMarkdown, "## Retries", "Retries"},
+		{NormalizeMarkdown, "**Always pin versions**", "Always pin versions"},
+		{NormalizeMarkdown, "**Pin** and **test**", "**Pin** and **test**"},
+		{NormalizeMarkdown, "First\n\n---\n\nSecond", "First\n\nSecond"},
+		{NormalizeMarkdown, "a - b - c", "a - b - c"},
+
+		{NormalizeUnicode, "cafe\u0301", "caf\u00e9"},
+		{NormalizeUnicode, "zero\u200bwidth\u00a0space\ufeff", "zerowidth space"},
+
+		{NormalizeWhitespace, "  Use   pgx \t v5  \n\n\n\nfor  pooling  ", "Use pgx v5\n\nfor pooling"},
+		{NormalizeWhitespace, "code:\n    if  x {\n    }", "code:\n    if x {\n    }"},
+		{NormalizeWhitespace, "windows\r\nline", "windows\nline"},
+	}
+	for _, tt := range tests {
+		if got := tt.normalizer.Normalize(tt.in); got != tt.want {
+			t.Errorf("%s.Normalize(%q) = %q, want %q", tt.normalizer.Name(), tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseNormalizers(t *testing.T) {
+	got, err := ParseNormalizers(" ANSI, whitespace ,")
+	if err != nil {
+		t.Fatalf("ParseNormalizers: %v", err)
+	}
+	if len(got) != 2 || got[0].Name() != "ansi" || got[1].Name() != "whitespace" {
+		t.Errorf("got %v, want ansi, whitespace", got)
+	}
+
+	all, err := ParseNormalizers("all")
+	if err != nil || len(all) != len(AllNormalizers()) {
+		t.Errorf("all = %v, %v", all, err)
+	}
+
+	var ve *ValidationError
+	if _, err := ParseNormalizers("ansi,emoji"); !errors.As(err, &ve) || ve.Field != "Normalizers" {
+		t.Errorf("unknown normalizer error = %v", err)
+	}
+}
+
+func TestClient_Record_Normalizers(t *testing.T) {
+	client := newTestClient(t, Config{
+		Normalizers:      AllNormalizers(),
+		PreserveOriginal: true,
+	})
+
+	submitted := "\x1b[33m- Retry   with jitter\x1b[0m  "
+	lore, err := client.Record(submitted, CategoryPatternOutcome)
+	if err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+	if lore.Content != "Retry with jitter" {
+		t.Errorf("Content = %q, want normalized", lore.Content)
+	}
+	stored, _ := client.store.Get(lore.ID)
+	if stored.Content != lore.Content {
+		t.Errorf("stored Content = %q", stored.Content)
+	}
+
+	original, err := client.OriginalContent(lore.ID)
+	if err != nil {
+		t.Fatalf("OriginalContent: %v", err)
+	}
+	if original == nil || original.Content != submitted || !reflect.DeepEqual(original.Normalizers, []string{"ansi", "markdown", "whitespace"}) {
+		t.Errorf("original = %+v", original)
+	}
+
+	// Content already in normal form keeps no original.
+	clean, err := client.Record("Already clean", CategoryPatternOutcome)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if original, _ := client.OriginalContent(clean.ID); original != nil {
+		t.Errorf("original kept for unchanged content: %+v", original)
+	}
+
+	// Content that normalizes to nothing is rejected.
+	var ve *ValidationError
+	if _, err := client.Record("\x1b[0m  ", CategoryPatternOutcome); !errors.As(err, &ve) || ve.Field != "Content" {
+		t.Errorf("Record(blank after normalizing) error = %v", err)
+	}
+}
+
+func TestClient_Record_NormalizersBeforeLengthCheck(t *testing.T) {
+	client := newTestClient(t, Config{
+		Normalizers: []Normalizer{NormalizeANSI},
+	})
+
+	// Over the limit only because of escape codes.
+	content := strings.Repeat("\x1b[2Kline\n", 700)
+	lore, err := client.Record(content, CategoryPatternOutcome)
+	if err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+	if len(lore.Content) > MaxContentLength {
+		t.Errorf("content length = %d", len(lore.Content))
+	}
+	if original, _ := client.OriginalContent(lore.ID); original != nil {
+		t.Error("original kept without PreserveOriginal")
+	}
+}
@@ -0,0 +1,153 @@
+package recall
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Note is a private annotation attached to a lore entry. Notes never modify
+// the entry's content and are excluded from sync.
+type Note struct {
+	ID        int64     `json:"id"`
+	LoreID    string    `json:"lore_id"`
+	Note      string    `json:"note"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AddNote attaches a note to an existing lore entry.
+// Returns ErrNotFound if the entry does not exist or is deleted.
+func (s *Store) AddNote(loreID, note string) (*Note, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil, ErrStoreClosed
+	}
+
+	var exists int
+	if err := s.db.QueryRow(`SELECT COUNT(*) FROM lore_entries WHERE id = ? AND deleted_at IS NULL AND namespace = ?`, loreID, s.namespace).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("store: add note: %w", err)
+	}
+	if exists == 0 {
+		return nil, ErrNotFound
+	}
+
+	n := &Note{LoreID: loreID, Note: note, CreatedAt: s.now().Truncate(time.Second)}
+	res, err := s.db.Exec(`INSERT INTO lore_notes (lore_id, note, created_at) VALUES (?, ?, ?)`,
+		loreID, note, n.CreatedAt.Format(time.RFC3339))
+	if err != nil {
+		return nil, fmt.Errorf("store: add note: %w", err)
+	}
+	n.ID, err = res.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("store: add note: %w", err)
+	}
+	return n, nil
+}
+
+// DeleteNote removes a note by ID. Returns ErrNotFound if it does not exist.
+func (s *Store) DeleteNote(id int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return ErrStoreClosed
+	}
+
+	res, err := s.db.Exec(`DELETE FROM lore_notes WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("store: delete note: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// NotesFor returns notes for the given lore IDs, keyed by lore ID and
+// ordered oldest first. Entries without notes are absent from the map.
+func (s *Store) NotesFor(loreIDs []string) (map[string][]Note, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil, ErrStoreClosed
+	}
+
+	notes := make(map[string][]Note)
+	if len(loreIDs) == 0 {
+		return notes, nil
+	}
+
+	placeholders := make([]string, len(loreIDs))
+	args := make([]any, len(loreIDs))
+	for i, id := range loreIDs {
+		placeholders[i] = "?"
+		args[i] = id
+	}
+
+	rows, err := s.db.Query(fmt.Sprintf(`
+		SELECT id, lore_id, note, created_at FROM lore_notes
+		WHERE lore_id IN (%s) ORDER BY id ASC
+	`, strings.Join(placeholders, ",")), args...)
+	if err != nil {
+		return nil, fmt.Errorf("store: query notes: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var n Note
+		var createdAt string
+		if err := rows.Scan(&n.ID, &n.LoreID, &n.Note, &createdAt); err != nil {
+			return nil, fmt.Errorf("store: scan note: %w", err)
+		}
+		n.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+		notes[n.LoreID] = append(notes[n.LoreID], n)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("store: iterate notes: %w", err)
+	}
+	return notes, nil
+}
+
+// Annotate attaches a private note to lore. ref may be a lore ID or a session
+// ref (L1, L2, ...). Notes are never synced to Engram.
+func (c *Client) Annotate(ref, note string) (*Note, error) {
+	if err := c.authorize(ActionAnnotate, ""); err != nil {
+		return nil, err
+	}
+	note = strings.TrimSpace(note)
+	if note == "" {
+		return nil, &ValidationError{Field: "Note", Message: "cannot be empty"}
+	}
+	if len(note) > MaxNoteLength {
+		return nil, &ValidationError{Field: "Note", Message: "exceeds 1000 character limit"}
+	}
+
+	loreID, err := c.resolveRef(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := c.store.AddNote(loreID, note)
+	if err != nil {
+		return nil, fmt.Errorf("client: annotate: %w", err)
+	}
+	return n, nil
+}
+
+// Notes returns the notes attached to lore, oldest first.
+// ref may be a lore ID or a session ref.
+func (c *Client) Notes(ref string) ([]Note, error) {
+	loreID, err := c.resolveRef(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	notes, err := c.store.NotesFor([]string{loreID})
+	if err != nil {
+		return nil, fmt.Errorf("client: notes: %w", err)
+	}
+	return notes[loreID], nil
+}
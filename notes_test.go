@@ -0,0 +1,112 @@
+package recall
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestClient_Annotate(t *testing.T) {
+	client := newTestClient(t, Config{AutoSync: false})
+
+	lore, err := client.Record("Cache invalidation needs TTLs", CategoryPatternOutcome)
+	if err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+	changesBefore, err := client.store.HasPendingSync()
+	if err != nil {
+		t.Fatalf("HasPendingSync: %v", err)
+	}
+
+	if _, err := client.Annotate(lore.ID, "verify with platform team"); err != nil {
+		t.Fatalf("Annotate: %v", err)
+	}
+	if _, err := client.Annotate(lore.ID, "  second note  "); err != nil {
+		t.Fatalf("Annotate: %v", err)
+	}
+
+	notes, err := client.Notes(lore.ID)
+	if err != nil {
+		t.Fatalf("Notes: %v", err)
+	}
+	if len(notes) != 2 || notes[0].Note != "verify with platform team" || notes[1].Note != "second note" {
+		t.Errorf("notes = %+v", notes)
+	}
+
+	// Content is untouched and nothing is queued for sync.
+	got, err := client.store.Get(lore.ID)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Content != lore.Content {
+		t.Errorf("Content changed to %q", got.Content)
+	}
+	changesAfter, err := client.store.HasPendingSync()
+	if err != nil {
+		t.Fatalf("HasPendingSync: %v", err)
+	}
+	if changesAfter != changesBefore {
+		t.Errorf("pending changes = %d, want %d (notes must not sync)", changesAfter, changesBefore)
+	}
+}
+
+func TestClient_Annotate_Validation(t *testing.T) {
+	client := newTestClient(t, Config{AutoSync: false})
+
+	var ve *ValidationError
+	if _, err := client.Annotate("any", "   "); !errors.As(err, &ve) {
+		t.Errorf("empty note err = %v, want ValidationError", err)
+	}
+	if _, err := client.Annotate("any", strings.Repeat("x", MaxNoteLength+1)); !errors.As(err, &ve) {
+		t.Errorf("long note err = %v, want ValidationError", err)
+	}
+	if _, err := client.Annotate("missing", "note"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("missing lore err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestClient_Query_IncludeNotes(t *testing.T) {
+	client := newTestClient(t, Config{AutoSync: false})
+
+	lore, err := client.Record("annotated", CategoryPatternOutcome)
+	if err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+	if _, err := client.Annotate(lore.ID, "private"); err != nil {
+		t.Fatalf("Annotate: %v", err)
+	}
+
+	result, err := client.Query(context.Background(), QueryParams{})
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	if result.Notes != nil {
+		t.Errorf("Notes = %v, want nil without IncludeNotes", result.Notes)
+	}
+
+	result, err = client.Query(context.Background(), QueryParams{IncludeNotes: true})
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	if notes := result.Notes[lore.ID]; len(notes) != 1 || notes[0].Note != "private" {
+		t.Errorf("Notes[%s] = %+v, want the private note", lore.ID, notes)
+	}
+}
+
+func TestStore_DeleteNote(t *testing.T) {
+	store := newTestStore(t)
+	if err := store.InsertLore(&Lore{ID: "NOTE0000000000000000000001", Content: "x", Category: CategoryPatternOutcome, Confidence: 0.5}); err != nil {
+		t.Fatalf("InsertLore: %v", err)
+	}
+	note, err := store.AddNote("NOTE0000000000000000000001", "temp")
+	if err != nil {
+		t.Fatalf("AddNote: %v", err)
+	}
+	if err := store.DeleteNote(note.ID); err != nil {
+		t.Fatalf("DeleteNote: %v", err)
+	}
+	if err := store.DeleteNote(note.ID); err != ErrNotFound {
+		t.Errorf("second DeleteNote err = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,54 @@
+package recall
+
+import (
+	"fmt"
+)
+
+// Overflow attachment settings. With Config.ContentOverflow, the full
+// content of an entry recorded over MaxContentLength is kept as an
+// attachment with this name and media type.
+const (
+	OverflowAttachmentName = "overflow.txt"
+	overflowMediaType      = "text/plain; charset=utf-8"
+)
+
+// keepOverflow stores content, the full text of lore recorded truncated, as
+// its overflow attachment. The attachment syncs only with
+// Config.SyncOverflow. Like preserveOriginal it is best-effort; a failed
+// write is reported to the debug log and does not fail Record.
+func (c *Client) keepOverflow(lore *Lore, content string) {
+	a := &Attachment{Name: OverflowAttachmentName, MediaType: overflowMediaType}
+	if err := c.store.AddAttachment(lore.ID, a, []byte(content), c.config.SyncOverflow); err != nil {
+		c.debug.LogError("keep overflow content", err)
+	}
+}
+
+// FullContent returns the content of ref as recorded: for an entry truncated
+// under Config.ContentOverflow, the full text kept in its overflow
+// attachment, and otherwise the entry's content. ref may be a lore ID or a
+// session ref.
+func (c *Client) FullContent(ref string) (string, error) {
+	id, err := c.resolveRef(ref)
+	if err != nil {
+		return "", err
+	}
+	lore, err := c.store.Get(id)
+	if err != nil {
+		return "", fmt.Errorf("client: full content: %w", err)
+	}
+	attachments, err := c.store.Attachments(id)
+	if err != nil {
+		return "", fmt.Errorf("client: full content: %w", err)
+	}
+	for _, a := range attachments {
+		if a.Name != OverflowAttachmentName {
+			continue
+		}
+		full, err := c.store.GetAttachment(a.ID)
+		if err != nil {
+			return "", fmt.Errorf("client: full content: %w", err)
+		}
+		return string(full.Data), nil
+	}
+	return lore.Content, nil
+}
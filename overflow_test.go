@@ -0,0 +1,83 @@
+package recall
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestClient_Record_ContentOverflow(t *testing.T) {
+	long := strings.Repeat("Replaying the outage timeline showed the retry storm started at the edge. ", 80)
+
+	client := newTestClient(t, Config{AutoSync: false, ContentOverflow: true})
+
+	lore, err := client.Record(long, CategoryEdgeCaseDiscovery)
+	if err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+	if len(lore.Content) > MaxContentLength || !strings.HasSuffix(lore.Content, "...") {
+		t.Errorf("Content has %d bytes ending %q, want truncated at the limit", len(lore.Content), lore.Content[len(lore.Content)-10:])
+	}
+	stored, err := client.store.Get(lore.ID)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if stored.Content != lore.Content {
+		t.Error("stored content differs from the returned truncated content")
+	}
+
+	full, err := client.FullContent(lore.ID)
+	if err != nil {
+		t.Fatalf("FullContent: %v", err)
+	}
+	if full != long {
+		t.Errorf("FullContent has %d bytes, want %d", len(full), len(long))
+	}
+
+	attachments, err := client.Attachments(lore.ID)
+	if err != nil {
+		t.Fatalf("Attachments: %v", err)
+	}
+	if len(attachments) != 1 || attachments[0].Name != OverflowAttachmentName || attachments[0].Synced {
+		t.Errorf("Attachments = %+v, want one local %s", attachments, OverflowAttachmentName)
+	}
+
+	short, err := client.Record("Retry storms start at the edge.", CategoryEdgeCaseDiscovery)
+	if err != nil {
+		t.Fatalf("Record short: %v", err)
+	}
+	if full, err := client.FullContent(short.ID); err != nil || full != short.Content {
+		t.Errorf("FullContent(short) = %q, %v; want the entry's content", full, err)
+	}
+
+	var ve *ValidationError
+	if _, err := client.Record(strings.Repeat("x", MaxAttachmentSize+1), CategoryEdgeCaseDiscovery); !errors.As(err, &ve) {
+		t.Errorf("Record over the overflow limit = %v, want ValidationError", err)
+	}
+}
+
+func TestClient_Record_SyncOverflow(t *testing.T) {
+	long := strings.Repeat("Warm the cache before shifting traffic. ", 150)
+
+	client := newTestClient(t, Config{AutoSync: false, ContentOverflow: true, SyncOverflow: true})
+
+	lore, err := client.Record(long, CategoryPatternOutcome)
+	if err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+	attachments, err := client.Attachments(lore.ID)
+	if err != nil {
+		t.Fatalf("Attachments: %v", err)
+	}
+	if len(attachments) != 1 || !attachments[0].Synced {
+		t.Errorf("Attachments = %+v, want one synced overflow attachment", attachments)
+	}
+}
+
+func TestConfig_Validate_ContentOverflowWithChunking(t *testing.T) {
+	cfg := Config{LocalPath: "lore.db", ContentOverflow: true, ChunkLongContent: true}
+	var ve *ValidationError
+	if err := cfg.Validate(); !errors.As(err, &ve) || ve.Field != "ContentOverflow" {
+		t.Errorf("Validate = %v, want ContentOverflow ValidationError", err)
+	}
+}
@@ -0,0 +1,249 @@
+package recall
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// EmbedFunc turns text into a query embedding.
+type EmbedFunc func(ctx context.Context, text string) ([]float32, error)
+
+// PackParams configures a context pack.
+type PackParams struct {
+	// Topics each become a section of the pack, in order.
+	Topics []string `json:"topics"`
+
+	// Embed, if set, embeds each topic for similarity ranking. Without it,
+	// entries are ranked by how many topic words they contain.
+	Embed EmbedFunc `json:"-"`
+
+	// K is the maximum number of entries per topic. Defaults to 5.
+	K int `json:"k,omitempty"`
+
+	// MinConfidence filters entries below this confidence. Defaults to 0.5.
+	MinConfidence *float64 `json:"min_confidence,omitempty"`
+
+	// Categories restricts every topic to these categories.
+	Categories []Category `json:"categories,omitempty"`
+
+	// Budget caps the total characters of content in the pack. Entries that
+	// would exceed it are skipped. Zero means unlimited.
+	Budget int `json:"budget,omitempty"`
+
+	// TokenBudget caps the total tokens of content in the pack, counted with
+	// Config.TokenEstimator. Entries that would exceed it are skipped. Zero
+	// means unlimited. Budget and TokenBudget may be combined.
+	TokenBudget int `json:"token_budget,omitempty"`
+}
+
+// ContextPack is a deduplicated set of lore grouped by topic, ready to seed
+// a new agent session.
+type ContextPack struct {
+	Sections    []PackSection     `json:"sections"`
+	SessionRefs map[string]string `json:"session_refs"`        // L1 -> lore ID
+	Truncated   bool              `json:"truncated,omitempty"` // Budget excluded some matches
+	Tokens      int               `json:"tokens"`              // Total tokens of packed content
+}
+
+// PackSection holds the entries selected for one topic.
+type PackSection struct {
+	Topic   string      `json:"topic"`
+	Entries []PackEntry `json:"entries"`
+}
+
+// PackEntry is a lore entry with its session ref.
+type PackEntry struct {
+	Ref  string `json:"ref"`
+	Lore Lore   `json:"lore"`
+}
+
+// ContextPack runs one query per topic and assembles the results into a
+// single pack. An entry matching several topics appears only under the
+// first. Included entries are tracked in the session so their refs work
+// with Feedback.
+func (c *Client) ContextPack(ctx context.Context, params PackParams) (*ContextPack, error) {
+	if len(params.Topics) == 0 {
+		return nil, &ValidationError{Field: "Topics", Message: "at least one topic is required"}
+	}
+	if params.K < 0 {
+		return nil, &ValidationError{Field: "K", Message: "must be non-negative"}
+	}
+	if params.Budget < 0 {
+		return nil, &ValidationError{Field: "Budget", Message: "must be non-negative"}
+	}
+	if params.TokenBudget < 0 {
+		return nil, &ValidationError{Field: "TokenBudget", Message: "must be non-negative"}
+	}
+	if params.K == 0 {
+		params.K = 5
+	}
+	if params.MinConfidence == nil {
+		defaultConfidence := 0.5
+		params.MinConfidence = &defaultConfidence
+	}
+
+	pack := &ContextPack{SessionRefs: make(map[string]string)}
+	seen := make(map[string]bool)
+	used := 0
+	var ids []string
+
+	for _, topic := range params.Topics {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		candidates, err := c.packCandidates(ctx, topic, params, params.K+len(seen))
+		if err != nil {
+			return nil, err
+		}
+
+		tokens, _ := c.tokenCounts(candidates, nil)
+
+		section := PackSection{Topic: topic, Entries: []PackEntry{}}
+		for _, l := range candidates {
+			if len(section.Entries) >= params.K {
+				break
+			}
+			if seen[l.ID] {
+				continue
+			}
+			size := utf8.RuneCountInString(l.Content)
+			if (params.Budget > 0 && used+size > params.Budget) ||
+				(params.TokenBudget > 0 && pack.Tokens+tokens[l.ID] > params.TokenBudget) {
+				pack.Truncated = true
+				continue
+			}
+			seen[l.ID] = true
+			used += size
+			pack.Tokens += tokens[l.ID]
+
+			ref := c.session.Track(l.ID)
+			pack.SessionRefs[ref] = l.ID
+			section.Entries = append(section.Entries, PackEntry{Ref: ref, Lore: l})
+			ids = append(ids, l.ID)
+		}
+		pack.Sections = append(pack.Sections, section)
+	}
+
+	// Usage stats are best-effort; a failed write must not fail the pack
+	if err := c.store.RecordUsage(ids); err != nil {
+		c.debug.LogError("record usage", err)
+	}
+	c.telemetry.queries.Add(1)
+	return pack, nil
+}
+
+// packCandidates returns up to k ranked entries for a topic.
+func (c *Client) packCandidates(ctx context.Context, topic string, params PackParams, k int) ([]Lore, error) {
+	query := QueryParams{
+		Query:         topic,
+		K:             k,
+		MinConfidence: params.MinConfidence,
+		Categories:    params.Categories,
+	}
+
+	if params.Embed != nil {
+		embedding, err := params.Embed(ctx, topic)
+		if err != nil {
+			return nil, fmt.Errorf("client: context pack: embed %q: %w", topic, err)
+		}
+		query.QueryEmbedding = embedding
+		lore, _, err := c.queryWithSimilarity(query, &QueryStats{})
+		return lore, err
+	}
+
+	query.K = 0
+	lore, err := c.store.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("client: context pack: %w", err)
+	}
+	ranked := rankByTopicTerms(lore, topic)
+	if len(ranked) > k {
+		ranked = ranked[:k]
+	}
+	return ranked, nil
+}
+
+// rankByTopicTerms keeps entries whose content or context contains at least
+// one topic word, ordered by the number of distinct words matched, then by
+// confidence.
+func rankByTopicTerms(lore []Lore, topic string) []Lore {
+	terms := topicTerms(topic)
+	if len(terms) == 0 {
+		return nil
+	}
+
+	type scoredEntry struct {
+		lore  Lore
+		score int
+	}
+	var scored []scoredEntry
+	for _, l := range lore {
+		words := make(map[string]bool)
+		for _, w := range topicTerms(l.Content + " " + l.Context) {
+			words[w] = true
+		}
+		score := 0
+		for _, t := range terms {
+			if words[t] {
+				score++
+			}
+		}
+		if score > 0 {
+			scored = append(scored, scoredEntry{lore: l, score: score})
+		}
+	}
+
+	sort.SliceStable(scored, func(i, j int) bool {
+		if scored[i].score != scored[j].score {
+			return scored[i].score > scored[j].score
+		}
+		return scored[i].lore.Confidence > scored[j].lore.Confidence
+	})
+
+	result := make([]Lore, len(scored))
+	for i, s := range scored {
+		result[i] = s.lore
+	}
+	return result
+}
+
+// topicTerms splits text into distinct lowercase words of three or more
+// letters or digits.
+func topicTerms(text string) []string {
+	seen := make(map[string]bool)
+	var terms []string
+	for _, w := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if utf8.RuneCountInString(w) < 3 || seen[w] {
+			continue
+		}
+		seen[w] = true
+		terms = append(terms, w)
+	}
+	return terms
+}
+
+// Markdown renders the pack as one heading per topic with bulleted entries
+// tagged by session ref. Topics without entries are omitted.
+func (p *ContextPack) Markdown() string {
+	var sb strings.Builder
+	for _, s := range p.Sections {
+		if len(s.Entries) == 0 {
+			continue
+		}
+		if sb.Len() > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString("## " + s.Topic + "\n")
+		for _, e := range s.Entries {
+			fmt.Fprintf(&sb, "- [%s] %s\n", e.Ref, e.Lore.Content)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,96 @@
+package recall
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestClient_ContextPack_SectionsAndDedup(t *testing.T) {
+	client := newTestClient(t, Config{})
+
+	for _, content := range []string{
+		"Retry payment webhooks with idempotency keys",
+		"Payment retries must respect the database lock timeout",
+		"Database migrations run before deploy",
+		"Unrelated frontend styling note",
+	} {
+		if _, err := client.Record(content, CategoryPatternOutcome); err != nil {
+			t.Fatalf("Record: %v", err)
+		}
+	}
+
+	pack, err := client.ContextPack(context.Background(), PackParams{Topics: []string{"payment retries", "database"}})
+	if err != nil {
+		t.Fatalf("ContextPack: %v", err)
+	}
+	if len(pack.Sections) != 2 {
+		t.Fatalf("sections = %d, want 2", len(pack.Sections))
+	}
+
+	payments := pack.Sections[0]
+	if payments.Topic != "payment retries" || len(payments.Entries) != 2 {
+		t.Fatalf("payments section = %+v, want 2 entries", payments)
+	}
+	// Two matched words outrank one.
+	if !strings.HasPrefix(payments.Entries[0].Lore.Content, "Payment retries") {
+		t.Errorf("first payments entry = %q, want the best keyword match", payments.Entries[0].Lore.Content)
+	}
+
+	// The lock-timeout entry also matches "database" but is already packed.
+	database := pack.Sections[1]
+	if len(database.Entries) != 1 || !strings.HasPrefix(database.Entries[0].Lore.Content, "Database migrations") {
+		t.Errorf("database section = %+v, want only the migrations entry", database)
+	}
+
+	if len(pack.SessionRefs) != 3 {
+		t.Errorf("session refs = %d, want 3", len(pack.SessionRefs))
+	}
+	ref := database.Entries[0].Ref
+	if _, err := client.Feedback(ref, Helpful); err != nil {
+		t.Errorf("Feedback(%s): %v", ref, err)
+	}
+
+	md := pack.Markdown()
+	if !strings.Contains(md, "## payment retries\n- [") || !strings.Contains(md, "## database\n") {
+		t.Errorf("Markdown = %q, want a heading per topic", md)
+	}
+}
+
+func TestClient_ContextPack_BudgetAndEmbed(t *testing.T) {
+	client := newTestClient(t, Config{})
+
+	short := &Lore{ID: "PACKSHORT0000000000000001", Content: "short", Category: CategoryPatternOutcome, Confidence: 0.6, Embedding: PackFloat32([]float32{1, 0})}
+	long := &Lore{ID: "PACKLONG00000000000000001", Content: strings.Repeat("x", 50), Category: CategoryPatternOutcome, Confidence: 0.6, Embedding: PackFloat32([]float32{0.9, 0.1})}
+	for _, l := range []*Lore{short, long} {
+		if err := client.store.InsertLore(l); err != nil {
+			t.Fatalf("InsertLore: %v", err)
+		}
+	}
+
+	embed := func(ctx context.Context, text string) ([]float32, error) {
+		return []float32{1, 0}, nil
+	}
+	pack, err := client.ContextPack(context.Background(), PackParams{Topics: []string{"anything"}, Embed: embed, Budget: 20})
+	if err != nil {
+		t.Fatalf("ContextPack: %v", err)
+	}
+	entries := pack.Sections[0].Entries
+	if len(entries) != 1 || entries[0].Lore.ID != short.ID {
+		t.Errorf("entries = %+v, want only the entry that fits the budget", entries)
+	}
+	if !pack.Truncated {
+		t.Error("Truncated = false, want true when the budget drops a match")
+	}
+
+	failing := func(ctx context.Context, text string) ([]float32, error) {
+		return nil, errors.New("embedder down")
+	}
+	if _, err := client.ContextPack(context.Background(), PackParams{Topics: []string{"x"}, Embed: failing}); err == nil {
+		t.Error("ContextPack should surface embed errors")
+	}
+	if _, err := client.ContextPack(context.Background(), PackParams{}); err == nil {
+		t.Error("ContextPack without topics should fail validation")
+	}
+}
@@ -0,0 +1,496 @@
+package recall
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/hyperengineering/recall/schema"
+)
+
+// pushSchemaVersion is the schema_version sent with sync push requests.
+const pushSchemaVersion = 2
+
+// PayloadIssue is a problem ValidatePayload found in a payload. Path is a
+// JSON pointer to the offending value ("" for the document itself); Line
+// and Column locate the value in the input, both 1-based.
+type PayloadIssue struct {
+	Path    string `json:"path"`
+	Line    int    `json:"line"`
+	Column  int    `json:"column"`
+	Message string `json:"message"`
+
+	offset int
+}
+
+func (i PayloadIssue) String() string {
+	if i.Path == "" {
+		return fmt.Sprintf("%d:%d: %s", i.Line, i.Column, i.Message)
+	}
+	return fmt.Sprintf("%d:%d %s: %s", i.Line, i.Column, i.Path, i.Message)
+}
+
+// ValidatePayload checks change_log payloads built outside recall against
+// the Engram-compatible schemas in the schema package, before they are
+// pushed. data may be a single lore or attachment payload, a change_log
+// entry wrapping one ({table_name, entity_id, operation, payload}), a
+// JSON array of either, or a whole sync push request. A bare payload is
+// an attachment when it has a lore_id field and lore otherwise.
+//
+// Fields, types, formats and limits are checked, along with the rules
+// recall itself enforces: namespaces must be valid, sources must not
+// contain commas, and an entry's entity_id must name its payload. The
+// issues are ordered by position; none means the payload is valid.
+func ValidatePayload(data []byte) []PayloadIssue {
+	v := &payloadValidator{data: data, loreIDs: make(map[string]bool)}
+	v.run()
+	return v.result()
+}
+
+// ValidatePayload is the package-level ValidatePayload, additionally
+// checking that every attachment's lore_id names lore in the store or in
+// the payload.
+func (c *Client) ValidatePayload(data []byte) ([]PayloadIssue, error) {
+	v := &payloadValidator{data: data, loreIDs: make(map[string]bool)}
+	v.run()
+	for _, ref := range v.refs {
+		if v.loreIDs[ref.loreID] {
+			continue
+		}
+		_, err := c.store.Get(ref.loreID)
+		switch {
+		case errors.Is(err, ErrNotFound):
+			v.report(ref.offset, ref.path, "lore %s is not in the store or the payload", ref.loreID)
+		case err != nil:
+			return nil, fmt.Errorf("client: validate payload: %w", err)
+		}
+	}
+	return v.result(), nil
+}
+
+// jsonNode is a parsed JSON value with its offset in the input.
+type jsonNode struct {
+	offset int
+	kind   string // object, array, string, number, boolean or null
+	str    string
+	num    json.Number
+	keys   []string // object keys in document order
+	fields map[string]*jsonNode
+	items  []*jsonNode
+}
+
+// payloadRef is an attachment's reference to its lore entry.
+type payloadRef struct {
+	loreID string
+	path   string
+	offset int
+}
+
+type payloadValidator struct {
+	data    []byte
+	dec     *json.Decoder
+	issues  []PayloadIssue
+	loreIDs map[string]bool // IDs of the lore payloads seen so far
+	refs    []payloadRef    // lore_id of attachment payloads not preceded by their lore
+}
+
+func (v *payloadValidator) report(offset int, path, format string, args ...any) {
+	v.issues = append(v.issues, PayloadIssue{Path: path, Message: fmt.Sprintf(format, args...), offset: offset})
+}
+
+// result returns the issues ordered by position, with lines and columns.
+func (v *payloadValidator) result() []PayloadIssue {
+	sort.SliceStable(v.issues, func(i, j int) bool { return v.issues[i].offset < v.issues[j].offset })
+	for i := range v.issues {
+		prefix := v.data[:min(v.issues[i].offset, len(v.data))]
+		lineStart := strings.LastIndexByte(string(prefix), '\n') + 1
+		v.issues[i].Line = strings.Count(string(prefix), "\n") + 1
+		v.issues[i].Column = utf8.RuneCount(prefix[lineStart:]) + 1
+	}
+	return v.issues
+}
+
+func (v *payloadValidator) run() {
+	v.dec = json.NewDecoder(strings.NewReader(string(v.data)))
+	v.dec.UseNumber()
+
+	root, err := v.parse("")
+	if err != nil {
+		v.syntaxError()
+		return
+	}
+	if offset := v.valueStart(); offset < len(v.data) {
+		v.report(offset, "", "unexpected data after the payload")
+		return
+	}
+
+	switch {
+	case root.kind == "array":
+		for i, item := range root.items {
+			v.item(item, "/"+strconv.Itoa(i))
+		}
+	case root.kind == "object" && root.fields["entries"] != nil:
+		v.pushRequest(root)
+	default:
+		v.item(root, "")
+	}
+}
+
+// syntaxError reports malformed input. The Token API's errors can name
+// the wrong character, so the input is decoded again for the error.
+func (v *payloadValidator) syntaxError() {
+	if len(strings.TrimSpace(string(v.data))) == 0 {
+		v.report(0, "", "empty payload")
+		return
+	}
+	var value any
+	var syntax *json.SyntaxError
+	if err := json.Unmarshal(v.data, &value); errors.As(err, &syntax) && !strings.HasPrefix(syntax.Error(), "unexpected end") {
+		v.report(max(int(syntax.Offset)-1, 0), "", "invalid JSON: %s", syntax)
+		return
+	}
+	v.report(len(v.data), "", "invalid JSON: unexpected end of input")
+}
+
+// valueStart returns the offset of the next value, skipping the
+// whitespace and separators the decoder has not consumed yet.
+func (v *payloadValidator) valueStart() int {
+	off := int(v.dec.InputOffset())
+	for off < len(v.data) && strings.IndexByte(" \t\r\n:,", v.data[off]) >= 0 {
+		off++
+	}
+	return off
+}
+
+// parse reads the next value. Duplicate object keys are reported; as
+// with encoding/json, the last one wins.
+func (v *payloadValidator) parse(path string) (*jsonNode, error) {
+	n := &jsonNode{offset: v.valueStart()}
+	tok, err := v.dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch t := tok.(type) {
+	case json.Delim:
+		if t == '{' {
+			n.kind, n.fields = "object", make(map[string]*jsonNode)
+			for v.dec.More() {
+				keyOffset := v.valueStart()
+				tok, err := v.dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				key := tok.(string)
+				child, err := v.parse(path + "/" + escapePointer(key))
+				if err != nil {
+					return nil, err
+				}
+				if _, dup := n.fields[key]; dup {
+					v.report(keyOffset, path+"/"+escapePointer(key), "duplicate field %q", key)
+				} else {
+					n.keys = append(n.keys, key)
+				}
+				n.fields[key] = child
+			}
+		} else {
+			n.kind = "array"
+			for v.dec.More() {
+				child, err := v.parse(path + "/" + strconv.Itoa(len(n.items)))
+				if err != nil {
+					return nil, err
+				}
+				n.items = append(n.items, child)
+			}
+		}
+		if _, err := v.dec.Token(); err != nil {
+			return nil, err
+		}
+	case string:
+		n.kind, n.str = "string", t
+	case json.Number:
+		n.kind, n.num = "number", t
+	case bool:
+		n.kind = "boolean"
+	case nil:
+		n.kind = "null"
+	}
+	return n, nil
+}
+
+// escapePointer escapes a key for use as a JSON pointer segment.
+func escapePointer(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
+
+// item validates a change_log entry or a bare payload.
+func (v *payloadValidator) item(n *jsonNode, path string) {
+	if n.kind == "object" && n.fields["table_name"] != nil {
+		v.entry(n, path)
+		return
+	}
+	table := "lore_entries"
+	if n.kind == "object" && n.fields["lore_id"] != nil {
+		table = attachmentsTable
+	}
+	v.payload(table, n, path)
+}
+
+// pushRequest validates a SyncPushRequest body.
+func (v *payloadValidator) pushRequest(n *jsonNode) {
+	v.fields(n, "", envelopeSchema(map[string]*jsonSchema{
+		"push_id":        {Type: schemaTypes{"string"}, MinLength: intPtr(1)},
+		"source_id":      {Type: schemaTypes{"string"}, MinLength: intPtr(1)},
+		"schema_version": {Type: schemaTypes{"integer"}},
+		"entries":        {Type: schemaTypes{"array"}},
+	}))
+	if version := n.fields["schema_version"]; version != nil && version.kind == "number" && version.num.String() != strconv.Itoa(pushSchemaVersion) {
+		v.report(version.offset, "/schema_version", "must be %d", pushSchemaVersion)
+	}
+	if entries := n.fields["entries"]; entries.kind == "array" {
+		for i, entry := range entries.items {
+			v.entry(entry, "/entries/"+strconv.Itoa(i))
+		}
+	}
+}
+
+// entry validates a change_log entry and its payload.
+func (v *payloadValidator) entry(n *jsonNode, path string) {
+	tables := slices.Sorted(maps.Keys(schema.PayloadSchemas))
+	if !v.fields(n, path, &jsonSchema{
+		Required: []string{"table_name", "entity_id", "operation"},
+		Properties: map[string]*jsonSchema{
+			"sequence":   {Type: schemaTypes{"integer"}, Minimum: floatPtr(0)},
+			"table_name": {Type: schemaTypes{"string"}, Enum: tables},
+			"entity_id":  {Type: schemaTypes{"string"}, MinLength: intPtr(1)},
+			"operation":  {Type: schemaTypes{"string"}, Enum: schema.ChangeLogOperations},
+			"payload":    {},
+			"source_id":  {Type: schemaTypes{"string"}},
+			"created_at": {Type: schemaTypes{"string"}, Format: "date-time"},
+		},
+	}) {
+		return
+	}
+
+	table, operation, entityID := n.fields["table_name"], n.fields["operation"], n.fields["entity_id"]
+	if table == nil || table.kind != "string" || schema.PayloadSchemas[table.str] == nil {
+		return
+	}
+	if operation == nil || !slices.Contains(schema.ChangeLogOperations, operation.str) {
+		return
+	}
+	payload := n.fields["payload"]
+	if operation.str == "delete" {
+		if payload != nil && payload.kind != "null" {
+			v.report(payload.offset, path+"/payload", "must be null for a delete")
+		}
+		return
+	}
+	if payload == nil {
+		v.report(n.offset, path, "missing required field %q", "payload")
+		return
+	}
+	if !v.payload(table.str, payload, path+"/payload") || entityID == nil || entityID.kind != "string" {
+		return
+	}
+
+	want := payload.fields["id"].str
+	if table.str == attachmentsTable {
+		want = attachmentEntityID(payload.fields["lore_id"].str, want)
+	}
+	if entityID.str != want {
+		v.report(entityID.offset, path+"/entity_id", "is %q but the payload is %q", entityID.str, want)
+	}
+}
+
+// payload validates a payload against the schema of its table, reporting
+// whether it is valid.
+func (v *payloadValidator) payload(table string, n *jsonNode, path string) bool {
+	before := len(v.issues)
+	if !v.fields(n, path, payloadSchemas()[table]) {
+		return false
+	}
+
+	switch table {
+	case "lore_entries":
+		if ns := n.fields["namespace"]; ns != nil && ns.kind == "string" && ValidateNamespace(ns.str) != nil {
+			v.report(ns.offset, path+"/namespace", "%s", ErrInvalidNamespace)
+		}
+		if sources := n.fields["sources"]; sources != nil {
+			for i, source := range sources.items {
+				if strings.Contains(source.str, ",") {
+					v.report(source.offset, path+"/sources/"+strconv.Itoa(i), "must not contain commas")
+				}
+			}
+		}
+		if id := n.fields["id"]; id != nil && id.kind == "string" {
+			v.loreIDs[id.str] = true
+		}
+	case attachmentsTable:
+		if loreID := n.fields["lore_id"]; loreID != nil && loreID.kind == "string" && loreID.str != "" && !v.loreIDs[loreID.str] {
+			v.refs = append(v.refs, payloadRef{loreID: loreID.str, path: path + "/lore_id", offset: loreID.offset})
+		}
+	}
+	return len(v.issues) == before
+}
+
+// fields validates an object against s, reporting whether n is an object
+// at all.
+func (v *payloadValidator) fields(n *jsonNode, path string, s *jsonSchema) bool {
+	if n.kind != "object" {
+		v.report(n.offset, path, "must be an object, got %s", n.kind)
+		return false
+	}
+	for _, key := range n.keys {
+		prop, ok := s.Properties[key]
+		if !ok {
+			v.report(n.fields[key].offset, path+"/"+escapePointer(key), "unknown field %q", key)
+			continue
+		}
+		v.check(prop, n.fields[key], path+"/"+escapePointer(key))
+	}
+	for _, key := range s.Required {
+		if n.fields[key] == nil {
+			v.report(n.offset, path, "missing required field %q", key)
+		}
+	}
+	return true
+}
+
+// check validates a value against the subset of JSON Schema the payload
+// schemas use.
+func (v *payloadValidator) check(s *jsonSchema, n *jsonNode, path string) {
+	if len(s.Type) > 0 && !slices.ContainsFunc(s.Type, n.is) {
+		v.report(n.offset, path, "must be %s, got %s", strings.Join(s.Type, " or "), n.kind)
+		return
+	}
+	if len(s.Enum) > 0 && (n.kind != "string" || !slices.Contains(s.Enum, n.str)) {
+		if n.kind == "string" {
+			v.report(n.offset, path, "%q is not one of %s", n.str, strings.Join(s.Enum, ", "))
+		} else {
+			v.report(n.offset, path, "must be one of %s, got %s", strings.Join(s.Enum, ", "), n.kind)
+		}
+		return
+	}
+
+	switch n.kind {
+	case "string":
+		length := utf8.RuneCountInString(n.str)
+		switch {
+		case s.MinLength != nil && length < *s.MinLength:
+			v.report(n.offset, path, "must not be empty")
+		case s.MaxLength != nil && length > *s.MaxLength:
+			v.report(n.offset, path, "is %d characters, over the %d character limit", length, *s.MaxLength)
+		case s.pattern != nil && !s.pattern.MatchString(n.str):
+			v.report(n.offset, path, "must match %s", s.Pattern)
+		case s.Format == "date-time":
+			if _, err := time.Parse(time.RFC3339, n.str); err != nil {
+				v.report(n.offset, path, "%q is not an RFC 3339 date-time", n.str)
+			}
+		case s.ContentEncoding == "base64":
+			if _, err := base64.StdEncoding.DecodeString(n.str); err != nil {
+				v.report(n.offset, path, "is not valid base64")
+			}
+		}
+	case "number":
+		f, _ := n.num.Float64()
+		switch {
+		case s.Minimum != nil && f < *s.Minimum:
+			v.report(n.offset, path, "must be at least %v, got %s", *s.Minimum, n.num)
+		case s.Maximum != nil && f > *s.Maximum:
+			v.report(n.offset, path, "must be at most %v, got %s", *s.Maximum, n.num)
+		}
+	case "array":
+		if s.Items != nil {
+			for i, item := range n.items {
+				v.check(s.Items, item, path+"/"+strconv.Itoa(i))
+			}
+		}
+	}
+}
+
+// is reports whether the value is of a JSON Schema type.
+func (n *jsonNode) is(typ string) bool {
+	if typ == "integer" {
+		f, err := n.num.Float64()
+		return n.kind == "number" && err == nil && f == math.Trunc(f)
+	}
+	return n.kind == typ
+}
+
+// jsonSchema is the subset of JSON Schema used by the payload schemas.
+type jsonSchema struct {
+	Type            schemaTypes            `json:"type"`
+	Enum            []string               `json:"enum"`
+	MinLength       *int                   `json:"minLength"`
+	MaxLength       *int                   `json:"maxLength"`
+	Minimum         *float64               `json:"minimum"`
+	Maximum         *float64               `json:"maximum"`
+	Pattern         string                 `json:"pattern"`
+	Format          string                 `json:"format"`
+	ContentEncoding string                 `json:"contentEncoding"`
+	Items           *jsonSchema            `json:"items"`
+	Required        []string               `json:"required"`
+	Properties      map[string]*jsonSchema `json:"properties"`
+
+	pattern *regexp.Regexp
+}
+
+// schemaTypes is a JSON Schema type: a single name or a list of names.
+type schemaTypes []string
+
+func (t *schemaTypes) UnmarshalJSON(data []byte) error {
+	var one string
+	if err := json.Unmarshal(data, &one); err == nil {
+		*t = schemaTypes{one}
+		return nil
+	}
+	var many []string
+	if err := json.Unmarshal(data, &many); err != nil {
+		return err
+	}
+	*t = many
+	return nil
+}
+
+// envelopeSchema describes an object whose fields are all required.
+func envelopeSchema(properties map[string]*jsonSchema) *jsonSchema {
+	s := &jsonSchema{Properties: properties}
+	for name := range properties {
+		s.Required = append(s.Required, name)
+	}
+	sort.Strings(s.Required)
+	return s
+}
+
+func intPtr(n int) *int { return &n }
+
+func floatPtr(f float64) *float64 { return &f }
+
+// payloadSchemas parses the embedded payload schemas once.
+var payloadSchemas = sync.OnceValue(func() map[string]*jsonSchema {
+	schemas := make(map[string]*jsonSchema, len(schema.PayloadSchemas))
+	for table, raw := range schema.PayloadSchemas {
+		var s jsonSchema
+		if err := json.Unmarshal(raw, &s); err != nil {
+			panic(fmt.Sprintf("recall: %s payload schema: %v", table, err))
+		}
+		for _, prop := range s.Properties {
+			if prop.Pattern != "" {
+				prop.pattern = regexp.MustCompile(prop.Pattern)
+			}
+		}
+		schemas[table] = &s
+	}
+	return schemas
+})
@@ -0,0 +1,140 @@
+package recall
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const validLorePayload = `{
+  "id": "01HQXYZ0000000000000000000",
+  "content": "Pin the base image",
+  "category": "DEPENDENCY_BEHAVIOR",
+  "confidence": 0.7,
+  "embedding_status": "pending",
+  "source_id": "integrator",
+  "sources": ["integrator"],
+  "validation_count": 0,
+  "created_at": "2026-01-02T03:04:05Z",
+  "updated_at": "2026-01-02T03:04:05Z",
+  "deleted_at": null,
+  "last_validated_at": null
+}`
+
+func issueStrings(issues []PayloadIssue) []string {
+	out := make([]string, len(issues))
+	for i, issue := range issues {
+		out[i] = issue.String()
+	}
+	return out
+}
+
+func TestValidatePayload_Valid(t *testing.T) {
+	for name, data := range map[string]string{
+		"lore":    validLorePayload,
+		"array":   "[" + validLorePayload + "]",
+		"entry":   `{"table_name": "lore_entries", "entity_id": "01HQXYZ0000000000000000000", "operation": "upsert", "payload": ` + validLorePayload + `}`,
+		"delete":  `{"table_name": "lore_entries", "entity_id": "01HQXYZ0000000000000000000", "operation": "delete", "payload": null}`,
+		"push":    `{"push_id": "p1", "source_id": "integrator", "schema_version": 2, "entries": [{"table_name": "lore_entries", "entity_id": "01HQXYZ0000000000000000000", "operation": "upsert", "payload": ` + validLorePayload + `}]}`,
+		"attach":  `{"lore_id": "01HQXYZ0000000000000000000", "id": "` + AttachmentID([]byte("x")) + `", "name": "x.txt", "media_type": "text/plain", "data": "eA=="}`,
+		"as-sent": mustLorePayload(t),
+	} {
+		if issues := ValidatePayload([]byte(data)); len(issues) != 0 {
+			t.Errorf("%s: ValidatePayload() = %v, want no issues", name, issueStrings(issues))
+		}
+	}
+}
+
+// mustLorePayload returns the payload recall itself writes for an entry.
+func mustLorePayload(t *testing.T) string {
+	t.Helper()
+	client := newTestClient(t, Config{SourceID: "payload-test", Namespace: "team"})
+	lore, err := client.Record("Pin the base image", CategoryDependencyBehavior, WithContext("docker builds"))
+	if err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+	payload, err := lorePayloadJSON(lore)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(payload)
+}
+
+func TestValidatePayload_ReportsLocations(t *testing.T) {
+	data := strings.NewReplacer(
+		`"confidence": 0.7`, `"confidence": 1.5`,
+		`"category": "DEPENDENCY_BEHAVIOR"`, `"category": "GOSSIP"`,
+		`"created_at": "2026-01-02T03:04:05Z"`, `"created_at": "yesterday"`,
+		`"sources": ["integrator"]`, `"sources": ["a,b"], "tags": []`,
+		`  "deleted_at": null,`+"\n", ``,
+	).Replace(validLorePayload)
+
+	got := issueStrings(ValidatePayload([]byte(data)))
+	want := []string{
+		`1:1: missing required field "deleted_at"`,
+		`4:15 /category: "GOSSIP" is not one of ARCHITECTURAL_DECISION, PATTERN_OUTCOME, INTERFACE_LESSON, EDGE_CASE_DISCOVERY, IMPLEMENTATION_FRICTION, TESTING_STRATEGY, DEPENDENCY_BEHAVIOR, PERFORMANCE_INSIGHT`,
+		`5:17 /confidence: must be at most 1, got 1.5`,
+		`8:15 /sources/0: must not contain commas`,
+		`8:31 /tags: unknown field "tags"`,
+		`10:17 /created_at: "yesterday" is not an RFC 3339 date-time`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("ValidatePayload() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestValidatePayload_Entries(t *testing.T) {
+	long := strings.Repeat("x", MaxContentLength+1)
+	tests := []struct {
+		name, data, want string
+	}{
+		{"syntax", `{"id": "a",}`, `1:12: invalid JSON: invalid character '}' looking for beginning of object key string`},
+		{"truncated", `{"id": "a"`, `1:11: invalid JSON: unexpected end of input`},
+		{"empty", "  ", `1:1: empty payload`},
+		{"trailing", `[] []`, `1:4: unexpected data after the payload`},
+		{"not an object", `[1]`, `1:2 /0: must be an object, got number`},
+		{"duplicate", `[` + strings.Replace(validLorePayload, `"id"`, `"id": "x", "id"`, 1) + `]`, `2:14 /0/id: duplicate field "id"`},
+		{"too long", strings.Replace(validLorePayload, `"Pin the base image"`, `"`+long+`"`, 1), `3:14 /content: is 4001 characters, over the 4000 character limit`},
+		{"namespace", strings.Replace(validLorePayload, `"deleted_at"`, `"namespace": "Team", "deleted_at"`, 1), `12:16 /namespace: ` + ErrInvalidNamespace.Error()},
+		{"operation", `{"table_name": "lore_entries", "entity_id": "a", "operation": "merge", "payload": null}`, `1:63 /operation: "merge" is not one of upsert, delete`},
+		{"table", `{"table_name": "notes", "entity_id": "a", "operation": "delete"}`, `1:16 /table_name: "notes" is not one of lore_attachments, lore_entries`},
+		{"entity", `{"table_name": "lore_entries", "entity_id": "other", "operation": "upsert", "payload": ` + validLorePayload + `}`, `1:45 /entity_id: is "other" but the payload is "01HQXYZ0000000000000000000"`},
+		{"delete payload", `{"table_name": "lore_entries", "entity_id": "a", "operation": "delete", "payload": {}}`, `1:84 /payload: must be null for a delete`},
+		{"schema version", `{"push_id": "p", "source_id": "s", "schema_version": 1, "entries": []}`, `1:54 /schema_version: must be 2`},
+		{"attachment", `{"lore_id": "a", "id": "abc", "name": "x", "media_type": "text/plain"}`, `1:24 /id: must match ^[0-9a-f]{64}$`},
+		{"base64", `{"lore_id": "a", "id": "` + AttachmentID(nil) + `", "name": "x", "media_type": "text/plain", "data": "!!"}`, `1:141 /data: is not valid base64`},
+	}
+	for _, tt := range tests {
+		got := issueStrings(ValidatePayload([]byte(tt.data)))
+		if len(got) != 1 || got[0] != tt.want {
+			t.Errorf("%s: ValidatePayload() = %q, want [%q]", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestClient_ValidatePayloadChecksAttachmentLore(t *testing.T) {
+	client := newTestClient(t, Config{SourceID: "test"})
+	lore, err := client.Record("Pin the base image", CategoryDependencyBehavior)
+	if err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+
+	attachment := func(loreID string) string {
+		id := AttachmentID([]byte("x"))
+		return `{"table_name": "lore_attachments", "entity_id": "` + loreID + "/" + id + `", "operation": "upsert",` +
+			` "payload": {"lore_id": "` + loreID + `", "id": "` + id + `", "name": "x", "media_type": "text/plain"}}`
+	}
+	data := "[\n" + attachment(lore.ID) + ",\n" + validLorePayload + ",\n" + attachment("01HQXYZ0000000000000000000") + ",\n" + attachment("01MISSING") + "\n]"
+
+	issues, err := client.ValidatePayload([]byte(data))
+	if err != nil {
+		t.Fatalf("ValidatePayload() error = %v", err)
+	}
+	if len(issues) != 1 || issues[0].Path != "/3/payload/lore_id" || issues[0].Line != 18 ||
+		issues[0].Message != "lore 01MISSING is not in the store or the payload" {
+		t.Errorf("ValidatePayload() = %q, want only the missing lore reported", issueStrings(issues))
+	}
+	if b, err := json.Marshal(issues[0]); err != nil || !strings.Contains(string(b), `"path":"/3/payload/lore_id"`) {
+		t.Errorf("issue JSON = %s (%v)", b, err)
+	}
+}
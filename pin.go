@@ -0,0 +1,144 @@
+package recall
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Pin is a query result set kept for reproducibility: the IDs a query
+// returned, in rank order, with their scores at the time. Pins are
+// local-only.
+type Pin struct {
+	ID        string        `json:"id"`
+	Actor     string        `json:"actor,omitempty"`
+	CreatedAt time.Time     `json:"created_at"`
+	Entries   []PinnedEntry `json:"entries"`
+}
+
+// PinnedEntry is one entry of a pinned result. Score is the ranking score
+// from QueryResult.Explanations, set only when the query used Explain.
+type PinnedEntry struct {
+	ID         string   `json:"id"`
+	Confidence float64  `json:"confidence"`
+	Score      *float64 `json:"score,omitempty"`
+}
+
+// PinnedResult is a pinned result set as of now.
+type PinnedResult struct {
+	Pin
+
+	// Lore holds the pinned entries that are still live, in pinned order,
+	// with their current state.
+	Lore []Lore `json:"lore"`
+
+	// Missing lists the pinned entries deleted since, in pinned order.
+	Missing []string `json:"missing,omitempty"`
+}
+
+// InsertPin stores p in the namespace.
+func (s *Store) InsertPin(p *Pin) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return ErrStoreClosed
+	}
+
+	entries, err := json.Marshal(p.Entries)
+	if err != nil {
+		return fmt.Errorf("store: marshal pin: %w", err)
+	}
+	_, err = s.db.Exec(`INSERT INTO query_pins (id, namespace, actor, entries, created_at) VALUES (?, ?, ?, ?, ?)`,
+		p.ID, s.namespace, p.Actor, string(entries), p.CreatedAt.UTC().Format(time.RFC3339))
+	if err != nil {
+		return fmt.Errorf("store: insert pin: %w", err)
+	}
+	return nil
+}
+
+// GetPin returns the pin with the given ID in the namespace.
+// Returns ErrNotFound if there is none.
+func (s *Store) GetPin(id string) (*Pin, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil, ErrStoreClosed
+	}
+
+	p := &Pin{ID: id}
+	var entries, createdAt string
+	err := s.db.QueryRow(`SELECT actor, entries, created_at FROM query_pins WHERE id = ? AND namespace = ?`, id, s.namespace).
+		Scan(&p.Actor, &entries, &createdAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("store: get pin: %w", err)
+	}
+	if err := json.Unmarshal([]byte(entries), &p.Entries); err != nil {
+		return nil, fmt.Errorf("store: decode pin: %w", err)
+	}
+	p.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+	return p, nil
+}
+
+// PinResult pins result, e.g. so an agent run can be reproduced or
+// audited, and returns the pin ID for GetPinned. The ID is stable and
+// safe to quote in transcripts.
+func (c *Client) PinResult(result *QueryResult) (string, error) {
+	if result == nil {
+		return "", &ValidationError{Field: "result", Message: "cannot be nil"}
+	}
+	p := &Pin{
+		ID:        c.store.newID(),
+		Actor:     c.actor(),
+		CreatedAt: c.store.now().UTC().Truncate(time.Second),
+		Entries:   make([]PinnedEntry, len(result.Lore)),
+	}
+	for i, l := range result.Lore {
+		p.Entries[i] = PinnedEntry{ID: l.ID, Confidence: l.Confidence}
+		if e, ok := result.Explanations[l.ID]; ok {
+			score := e.Score
+			p.Entries[i].Score = &score
+		}
+	}
+	if err := c.store.InsertPin(p); err != nil {
+		return "", fmt.Errorf("client: pin result: %w", err)
+	}
+	return p.ID, nil
+}
+
+// GetPinned returns the result set pinned by PinResult: the same entries
+// in the same order, as of the data still present. Returns ErrNotFound
+// for an unknown pin ID.
+func (c *Client) GetPinned(pinID string) (*PinnedResult, error) {
+	p, err := c.store.GetPin(pinID)
+	if err != nil {
+		return nil, fmt.Errorf("client: get pinned: %w", err)
+	}
+	ids := make([]string, len(p.Entries))
+	for i, e := range p.Entries {
+		ids[i] = e.ID
+	}
+	live, err := c.store.GetLoreByIDs(ids)
+	if err != nil {
+		return nil, fmt.Errorf("client: get pinned: %w", err)
+	}
+	byID := make(map[string]Lore, len(live))
+	for _, l := range live {
+		byID[l.ID] = l
+	}
+
+	result := &PinnedResult{Pin: *p, Lore: make([]Lore, 0, len(ids))}
+	for _, id := range ids {
+		if l, ok := byID[id]; ok {
+			result.Lore = append(result.Lore, l)
+		} else {
+			result.Missing = append(result.Missing, id)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,57 @@
+package recall
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestClient_PinResult(t *testing.T) {
+	client := newTestClient(t, Config{SourceID: "test", Actor: "agent-7"})
+
+	for _, content := range []string{"Retry idempotent calls", "Retry with jitter", "Retry budgets cap load"} {
+		if _, err := client.Record(content, CategoryPatternOutcome); err != nil {
+			t.Fatalf("Record() error = %v", err)
+		}
+	}
+	result, err := client.Query(context.Background(), QueryParams{Query: "retry", K: 3, Explain: true})
+	if err != nil || len(result.Lore) != 3 {
+		t.Fatalf("Query() = %v, %v", result, err)
+	}
+
+	pinID, err := client.PinResult(result)
+	if err != nil {
+		t.Fatalf("PinResult() error = %v", err)
+	}
+
+	// Later changes do not alter the pinned set or order
+	if _, err := client.Record("Retry storms take down dependencies", CategoryPatternOutcome); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.store.DeleteLoreByID(result.Lore[1].ID); err != nil {
+		t.Fatal(err)
+	}
+
+	pinned, err := client.GetPinned(pinID)
+	if err != nil {
+		t.Fatalf("GetPinned() error = %v", err)
+	}
+	if pinned.ID != pinID || pinned.Actor != "agent-7" || len(pinned.Entries) != 3 {
+		t.Fatalf("GetPinned() = %+v", pinned)
+	}
+	for i, e := range pinned.Entries {
+		if e.ID != result.Lore[i].ID || e.Score == nil || *e.Score != result.Explanations[e.ID].Score {
+			t.Errorf("Entries[%d] = %+v, want %s with its explained score", i, e, result.Lore[i].ID)
+		}
+	}
+	if len(pinned.Lore) != 2 || pinned.Lore[0].ID != result.Lore[0].ID || pinned.Lore[1].ID != result.Lore[2].ID {
+		t.Errorf("Lore = %v, want the live entries in pinned order", pinned.Lore)
+	}
+	if len(pinned.Missing) != 1 || pinned.Missing[0] != result.Lore[1].ID {
+		t.Errorf("Missing = %v, want [%s]", pinned.Missing, result.Lore[1].ID)
+	}
+
+	if _, err := client.GetPinned("no-such-pin"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetPinned(unknown) error = %v, want ErrNotFound", err)
+	}
+}
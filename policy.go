@@ -0,0 +1,199 @@
+package recall
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+)
+
+// ErrPermissionDenied is returned when the AccessPolicy forbids an action.
+// Match with errors.Is; *PermissionError carries the details.
+var ErrPermissionDenied = errors.New("permission denied")
+
+// Action is a write operation governed by an AccessPolicy. Reads are never
+// restricted.
+type Action string
+
+const (
+	ActionRecord   Action = "record"   // Record new lore
+	ActionFeedback Action = "feedback" // Feedback, FeedbackBatch
+	ActionAnnotate Action = "annotate" // Annotate
+	ActionResolve  Action = "resolve"  // ResolveConflict (admin)
+	ActionReinit   Action = "reinit"   // Bootstrap, Reinitialize (admin)
+	ActionDelete   Action = "delete"   // Deleting stores, moving namespaces, removing starter packs (admin)
+	ActionImport   Action = "import"   // Bulk import, which bypasses category checks (admin)
+	ActionRollover Action = "rollover" // Rolling a store over into its long-term store (admin)
+	ActionProtect  Action = "protect"  // Protect, Unprotect (up to the actor's Level)
+	ActionCurate   Action = "curate"   // ApproveInbox, RejectInbox (curators and admins)
+)
+
+// adminActions are allowed only to actors with Admin set.
+var adminActions = map[Action]bool{ActionResolve: true, ActionReinit: true, ActionDelete: true, ActionImport: true, ActionRollover: true}
+
+// AccessPolicy declares per-actor write permissions for a shared store. It
+// is usually loaded from JSON with LoadAccessPolicy:
+//
+//	{
+//	  "default": {"read_only": true},
+//	  "actors": {
+//	    "ci-bot":  {"categories": ["TESTING_STRATEGY"]},
+//	    "alice":   {"admin": true},
+//	    "lead":    {"level": 2, "curator": true}
+//	  }
+//	}
+type AccessPolicy struct {
+	// Default applies to actors not listed in Actors. Nil makes unlisted
+	// actors read-only.
+	Default *ActorPolicy `json:"default,omitempty"`
+
+	Actors map[string]ActorPolicy `json:"actors,omitempty"`
+}
+
+// ActorPolicy is the set of permissions granted to one actor.
+type ActorPolicy struct {
+	// ReadOnly forbids every write action.
+	ReadOnly bool `json:"read_only,omitempty"`
+
+	// Categories restricts Record to these categories. Empty allows all.
+	Categories []Category `json:"categories,omitempty"`
+
+	// Admin allows resolving conflicts, bootstrap/reinitialize, bulk
+	// import, and deleting stores or moving namespaces.
+	Admin bool `json:"admin,omitempty"`
+
+	// Level ranks the actor for protected lore: feedback from actors below
+	// a Protection's MinLevel cannot lower confidence below its Floor, and
+	// only actors at MinLevel or above may set or remove the protection.
+	// Admins meet every level.
+	Level int `json:"level,omitempty"`
+
+	// Curator allows approving and rejecting lore staged by Config.Inbox.
+	// Admins are always curators.
+	Curator bool `json:"curator,omitempty"`
+}
+
+// PermissionError describes a denied action. It matches ErrPermissionDenied.
+type PermissionError struct {
+	Actor  string
+	Action Action
+	Reason string
+}
+
+func (e *PermissionError) Error() string {
+	return fmt.Sprintf("permission denied: actor %q cannot %s: %s", e.Actor, e.Action, e.Reason)
+}
+
+func (e *PermissionError) Is(target error) bool { return target == ErrPermissionDenied }
+
+// LoadAccessPolicy reads and validates a JSON access policy file.
+func LoadAccessPolicy(path string) (*AccessPolicy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("access policy: %w", err)
+	}
+	var p AccessPolicy
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&p); err != nil {
+		return nil, fmt.Errorf("access policy: parse %s: %w", path, err)
+	}
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// Validate checks that every category named in the policy is valid.
+func (p *AccessPolicy) Validate() error {
+	check := func(field string, ap ActorPolicy) error {
+		for _, c := range ap.Categories {
+			if !c.IsValid() {
+				return &ValidationError{Field: field, Message: fmt.Sprintf("invalid category %q", c)}
+			}
+		}
+		return nil
+	}
+	if p.Default != nil {
+		if err := check("policy.default.categories", *p.Default); err != nil {
+			return err
+		}
+	}
+	for actor, ap := range p.Actors {
+		if err := check("policy.actors."+actor+".categories", ap); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// For returns the permissions of actor.
+func (p *AccessPolicy) For(actor string) ActorPolicy {
+	if ap, ok := p.Actors[actor]; ok {
+		return ap
+	}
+	if p.Default != nil {
+		return *p.Default
+	}
+	return ActorPolicy{ReadOnly: true}
+}
+
+// Check reports whether actor may perform action. For ActionRecord,
+// category is the category being recorded; it is ignored otherwise.
+// A nil policy allows everything.
+func (p *AccessPolicy) Check(actor string, action Action, category Category) error {
+	if p == nil {
+		return nil
+	}
+	ap := p.For(actor)
+	deny := func(reason string) error {
+		return &PermissionError{Actor: actor, Action: action, Reason: reason}
+	}
+
+	if ap.ReadOnly {
+		return deny("actor is read-only")
+	}
+	if adminActions[action] && !ap.Admin {
+		return deny("admin only")
+	}
+	if action == ActionCurate && !ap.Curator && !ap.Admin {
+		return deny("curators only")
+	}
+	if action == ActionRecord && len(ap.Categories) > 0 {
+		for _, c := range ap.Categories {
+			if c == category {
+				return nil
+			}
+		}
+		return deny(fmt.Sprintf("category %s not allowed", category))
+	}
+	return nil
+}
+
+// authorize checks the configured AccessPolicy for the client's actor.
+func (c *Client) authorize(action Action, category Category) error {
+	return c.config.AccessPolicy.Check(c.actor(), action, category)
+}
+
+// actorLevel returns the client's actor's ActorPolicy.Level: the highest
+// level for admins, and 0 without an AccessPolicy.
+func (c *Client) actorLevel() int {
+	if c.config.AccessPolicy == nil {
+		return 0
+	}
+	ap := c.config.AccessPolicy.For(c.actor())
+	if ap.Admin {
+		return math.MaxInt
+	}
+	return ap.Level
+}
+
+// actor returns Config.Actor, defaulting to SourceID.
+func (c *Client) actor() string {
+	if c.config.Actor != "" {
+		return c.config.Actor
+	}
+	return c.config.SourceID
+}
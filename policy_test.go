@@ -0,0 +1,94 @@
+package recall
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAccessPolicy_Check(t *testing.T) {
+	policy := &AccessPolicy{
+		Default: &ActorPolicy{ReadOnly: true},
+		Actors: map[string]ActorPolicy{
+			"ci-bot": {Categories: []Category{CategoryTestingStrategy}},
+			"alice":  {Admin: true},
+		},
+	}
+
+	tests := []struct {
+		actor    string
+		action   Action
+		category Category
+		allowed  bool
+	}{
+		{"ci-bot", ActionRecord, CategoryTestingStrategy, true},
+		{"ci-bot", ActionRecord, CategoryPatternOutcome, false},
+		{"ci-bot", ActionFeedback, "", true},
+		{"ci-bot", ActionReinit, "", false},
+		{"alice", ActionRecord, CategoryPatternOutcome, true},
+		{"alice", ActionDelete, "", true},
+		{"mallory", ActionRecord, CategoryPatternOutcome, false},
+		{"mallory", ActionFeedback, "", false},
+	}
+	for _, tt := range tests {
+		err := policy.Check(tt.actor, tt.action, tt.category)
+		if allowed := err == nil; allowed != tt.allowed {
+			t.Errorf("Check(%s, %s, %s) = %v, want allowed=%v", tt.actor, tt.action, tt.category, err, tt.allowed)
+		}
+		if err != nil && !errors.Is(err, ErrPermissionDenied) {
+			t.Errorf("Check error %v should match ErrPermissionDenied", err)
+		}
+	}
+
+	var none *AccessPolicy
+	if err := none.Check("anyone", ActionDelete, ""); err != nil {
+		t.Errorf("nil policy should allow everything, got %v", err)
+	}
+}
+
+func TestLoadAccessPolicy(t *testing.T) {
+	dir := t.TempDir()
+	good := filepath.Join(dir, "policy.json")
+	if err := os.WriteFile(good, []byte(`{"actors": {"bot": {"categories": ["TESTING_STRATEGY"]}}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	p, err := LoadAccessPolicy(good)
+	if err != nil {
+		t.Fatalf("LoadAccessPolicy: %v", err)
+	}
+	if got := p.For("bot").Categories; len(got) != 1 || got[0] != CategoryTestingStrategy {
+		t.Errorf("bot categories = %v", got)
+	}
+	if !p.For("someone-else").ReadOnly {
+		t.Error("unlisted actors should be read-only without a default")
+	}
+
+	bad := filepath.Join(dir, "bad.json")
+	if err := os.WriteFile(bad, []byte(`{"actors": {"bot": {"categories": ["NOPE"]}}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var ve *ValidationError
+	if _, err := LoadAccessPolicy(bad); !errors.As(err, &ve) {
+		t.Errorf("err = %v, want ValidationError for unknown category", err)
+	}
+}
+
+func TestClient_AccessPolicy(t *testing.T) {
+	client := newTestClient(t, Config{
+		Actor: "ci-bot",
+		AccessPolicy: &AccessPolicy{Actors: map[string]ActorPolicy{
+			"ci-bot": {Categories: []Category{CategoryTestingStrategy}},
+		}},
+	})
+
+	if _, err := client.Record("allowed", CategoryTestingStrategy); err != nil {
+		t.Errorf("Record in allowed category: %v", err)
+	}
+	if _, err := client.Record("denied", CategoryPatternOutcome); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("Record in other category: err = %v, want ErrPermissionDenied", err)
+	}
+	if _, err := client.ResolveConflict(1, ConflictResolutionLocal, nil); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("ResolveConflict: err = %v, want ErrPermissionDenied", err)
+	}
+}
@@ -0,0 +1,249 @@
+package recall
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultProtectedFloor is the confidence floor Protect uses when
+// ProtectOptions.Floor is unset.
+const DefaultProtectedFloor = 0.5
+
+// Protection marks foundational lore whose confidence feedback cannot
+// lower below Floor, unless it comes from an actor whose
+// ActorPolicy.Level is at least MinLevel. Protections are local-only.
+type Protection struct {
+	LoreID      string    `json:"lore_id"`
+	Floor       float64   `json:"floor"`
+	MinLevel    int       `json:"min_level"`
+	ProtectedBy string    `json:"protected_by,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// ProtectOptions configures Protect.
+type ProtectOptions struct {
+	// Floor is the lowest confidence feedback can leave the entry at
+	// (default: DefaultProtectedFloor).
+	Floor float64
+
+	// MinLevel is the ActorPolicy.Level whose feedback may take the entry
+	// below Floor, and which is needed to unprotect it (minimum and
+	// default: 1).
+	MinLevel int
+}
+
+// Protect protects loreID, replacing any earlier protection.
+// Returns ErrNotFound if the entry does not exist.
+func (s *Store) Protect(p Protection) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return ErrStoreClosed
+	}
+	if _, err := s.getLore(p.LoreID); err != nil {
+		return err
+	}
+
+	_, err := s.db.Exec(`
+		INSERT INTO lore_protection (lore_id, floor, min_level, protected_by, created_at) VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(lore_id) DO UPDATE SET
+			floor = excluded.floor, min_level = excluded.min_level,
+			protected_by = excluded.protected_by, created_at = excluded.created_at
+	`, p.LoreID, p.Floor, p.MinLevel, p.ProtectedBy, p.CreatedAt.UTC().Format(time.RFC3339))
+	if err != nil {
+		return fmt.Errorf("store: protect: %w", err)
+	}
+	return nil
+}
+
+// Unprotect removes loreID's protection and reports whether it had one.
+func (s *Store) Unprotect(loreID string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return false, ErrStoreClosed
+	}
+	res, err := s.db.Exec(`DELETE FROM lore_protection WHERE lore_id = ?`, loreID)
+	if err != nil {
+		return false, fmt.Errorf("store: unprotect: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	return n > 0, nil
+}
+
+// Protections returns the protection of each of the given lore IDs that
+// has one.
+func (s *Store) Protections(loreIDs []string) (map[string]Protection, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil, ErrStoreClosed
+	}
+	return s.protections(s.db, loreIDs)
+}
+
+// protections implements Protections on q.
+func (s *Store) protections(q queryer, loreIDs []string) (map[string]Protection, error) {
+	protected := make(map[string]Protection)
+	if len(loreIDs) == 0 {
+		return protected, nil
+	}
+
+	placeholders := make([]string, len(loreIDs))
+	args := make([]any, len(loreIDs))
+	for i, id := range loreIDs {
+		placeholders[i] = "?"
+		args[i] = id
+	}
+	rows, err := q.Query(fmt.Sprintf(`
+		SELECT lore_id, floor, min_level, protected_by, created_at FROM lore_protection WHERE lore_id IN (%s)
+	`, strings.Join(placeholders, ",")), args...)
+	if err != nil {
+		return nil, fmt.Errorf("store: query protection: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var p Protection
+		var createdAt string
+		if err := rows.Scan(&p.LoreID, &p.Floor, &p.MinLevel, &p.ProtectedBy, &createdAt); err != nil {
+			return nil, fmt.Errorf("store: scan protection: %w", err)
+		}
+		p.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+		protected[p.LoreID] = p
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("store: iterate protection: %w", err)
+	}
+	return protected, nil
+}
+
+// protectedConfidence applies loreID's protection to feedback that would
+// move its confidence from current to next, given by an actor at level:
+// below the floor, next is held at the floor, or at current if current is
+// already lower.
+func (s *Store) protectedConfidence(q queryer, loreID string, current, next float64, level int) (float64, error) {
+	protected, err := s.protections(q, []string{loreID})
+	if err != nil {
+		return 0, err
+	}
+	p, ok := protected[loreID]
+	if !ok || level >= p.MinLevel || next >= p.Floor || next >= current {
+		return next, nil
+	}
+	return min(current, p.Floor), nil
+}
+
+// Protect protects ref: feedback from actors below opts.MinLevel cannot
+// lower its confidence below opts.Floor. Without an AccessPolicy every
+// actor is below it. Under an AccessPolicy, the actor needs ActionProtect
+// and a Level of at least opts.MinLevel, and of the MinLevel of any
+// protection being replaced. ref may be a lore ID or a session ref.
+func (c *Client) Protect(ref string, opts ProtectOptions) (*Protection, error) {
+	if err := c.authorize(ActionProtect, ""); err != nil {
+		return nil, err
+	}
+	if opts.Floor == 0 {
+		opts.Floor = DefaultProtectedFloor
+	}
+	if opts.Floor < ConfidenceMin || opts.Floor > ConfidenceMax {
+		return nil, &ValidationError{Field: "Floor", Message: "must be between 0.0 and 1.0"}
+	}
+	if opts.MinLevel < 1 {
+		opts.MinLevel = 1
+	}
+	if c.config.AccessPolicy != nil && c.actorLevel() < opts.MinLevel {
+		return nil, &PermissionError{Actor: c.actor(), Action: ActionProtect, Reason: fmt.Sprintf("level %d is below %d", c.actorLevel(), opts.MinLevel)}
+	}
+
+	loreID, err := c.resolveRef(ref)
+	if err != nil {
+		return nil, err
+	}
+	release, err := c.store.enter(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	if err := c.checkProtectionLevel(loreID); err != nil {
+		return nil, err
+	}
+	p := &Protection{
+		LoreID:      loreID,
+		Floor:       opts.Floor,
+		MinLevel:    opts.MinLevel,
+		ProtectedBy: c.actor(),
+		CreatedAt:   c.store.now().UTC().Truncate(time.Second),
+	}
+	if err := c.store.Protect(*p); err != nil {
+		return nil, fmt.Errorf("client: protect: %w", err)
+	}
+	return p, nil
+}
+
+// Unprotect removes ref's protection and reports whether it had one.
+// Under an AccessPolicy, the actor needs ActionProtect and the
+// protection's MinLevel.
+func (c *Client) Unprotect(ref string) (bool, error) {
+	if err := c.authorize(ActionProtect, ""); err != nil {
+		return false, err
+	}
+	loreID, err := c.resolveRef(ref)
+	if err != nil {
+		return false, err
+	}
+	release, err := c.store.enter(context.Background())
+	if err != nil {
+		return false, err
+	}
+	defer release()
+
+	if err := c.checkProtectionLevel(loreID); err != nil {
+		return false, err
+	}
+	removed, err := c.store.Unprotect(loreID)
+	if err != nil {
+		return false, fmt.Errorf("client: unprotect: %w", err)
+	}
+	return removed, nil
+}
+
+// checkProtectionLevel denies changing loreID's protection to actors below
+// its MinLevel. Without an AccessPolicy there are no levels to check.
+func (c *Client) checkProtectionLevel(loreID string) error {
+	if c.config.AccessPolicy == nil {
+		return nil
+	}
+	protected, err := c.store.Protections([]string{loreID})
+	if err != nil {
+		return fmt.Errorf("client: protect: %w", err)
+	}
+	if p, ok := protected[loreID]; ok && c.actorLevel() < p.MinLevel {
+		return &PermissionError{Actor: c.actor(), Action: ActionProtect, Reason: fmt.Sprintf("protection requires level %d", p.MinLevel)}
+	}
+	return nil
+}
+
+// protectedFloors returns the confidence floor of each protected entry of
+// lore, or nil if none is protected.
+func (c *Client) protectedFloors(lore []Lore) (map[string]float64, error) {
+	ids := make([]string, len(lore))
+	for i, l := range lore {
+		ids[i] = l.ID
+	}
+	protected, err := c.store.Protections(ids)
+	if err != nil || len(protected) == 0 {
+		return nil, err
+	}
+	floors := make(map[string]float64, len(protected))
+	for id, p := range protected {
+		floors[id] = p.Floor
+	}
+	return floors, nil
+}
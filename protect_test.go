@@ -0,0 +1,103 @@
+package recall
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestStore_ApplyFeedback_ProtectedFloor(t *testing.T) {
+	store := newTestStore(t)
+	lore := &Lore{ID: "protected", Content: "Never log tokens", Category: CategoryArchitecturalDecision, Confidence: 0.7, SourceID: "test"}
+	if err := store.InsertLore(lore); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Protect(Protection{LoreID: lore.ID, Floor: 0.6, MinLevel: 2}); err != nil {
+		t.Fatalf("Protect() error = %v", err)
+	}
+
+	got, err := store.ApplyFeedback(lore.ID, ConfidenceOutdatedDelta, false)
+	if err != nil || got.Confidence != 0.6 {
+		t.Fatalf("ApplyFeedback() = %v, %v; want confidence held at the floor", got, err)
+	}
+	if got, _ := store.applyFeedback(lore.ID, ConfidenceHelpfulDelta, true, 0); got.Confidence-0.68 > 1e-9 || got.Confidence-0.68 < -1e-9 {
+		t.Errorf("helpful feedback confidence = %v, want 0.68", got.Confidence)
+	}
+	if got, _ := store.applyFeedback(lore.ID, ConfidenceOutdatedDelta, false, 2); got.Confidence > 0.39 {
+		t.Errorf("feedback at MinLevel confidence = %v, want below the floor", got.Confidence)
+	}
+	// Already below the floor: lower-level feedback cannot raise or lower it
+	if got, _ := store.applyFeedback(lore.ID, ConfidenceIncorrectDelta, false, 1); got.Confidence > 0.39 || got.Confidence < 0.37 {
+		t.Errorf("confidence = %v, want unchanged at 0.38", got.Confidence)
+	}
+
+	if removed, err := store.Unprotect(lore.ID); err != nil || !removed {
+		t.Fatalf("Unprotect() = %v, %v", removed, err)
+	}
+	if got, _ := store.ApplyFeedback(lore.ID, ConfidenceIncorrectDelta, false); got.Confidence > 0.24 {
+		t.Errorf("unprotected confidence = %v, want lowered", got.Confidence)
+	}
+}
+
+func TestClient_Protect_Levels(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lore.db")
+	policy := &AccessPolicy{Actors: map[string]ActorPolicy{
+		"junior": {},
+		"lead":   {Level: 2},
+		"alice":  {Admin: true},
+	}}
+	open := func(actor string) *Client {
+		client, err := New(Config{LocalPath: path, SourceID: "test", Actor: actor, AccessPolicy: policy})
+		if err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+		return client
+	}
+
+	lead := open("lead")
+	lore, err := lead.Record("Payments writes go through the ledger service", CategoryArchitecturalDecision, WithConfidence(0.9))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := lead.Protect(lore.ID, ProtectOptions{MinLevel: 3}); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("Protect() above the actor's level error = %v, want ErrPermissionDenied", err)
+	}
+	p, err := lead.Protect(lore.ID, ProtectOptions{Floor: 0.8, MinLevel: 2})
+	if err != nil || p.ProtectedBy != "lead" {
+		t.Fatalf("Protect() = %+v, %v", p, err)
+	}
+	_ = lead.Close()
+
+	junior := open("junior")
+	for range 3 {
+		if _, err := junior.Feedback(lore.ID, Incorrect); err != nil {
+			t.Fatalf("Feedback() error = %v", err)
+		}
+	}
+	result, err := junior.Query(context.Background(), QueryParams{Query: "payments ledger"})
+	if err != nil || len(result.Lore) != 1 {
+		t.Fatalf("Query() = %v, %v", result, err)
+	}
+	if result.Lore[0].Confidence != 0.8 || result.Protected[lore.ID] != 0.8 {
+		t.Errorf("confidence = %v, Protected = %v; want held at the 0.8 floor", result.Lore[0].Confidence, result.Protected)
+	}
+	batch, err := junior.FeedbackBatch(context.Background(), FeedbackParams{Outdated: []string{"L1"}})
+	if err != nil || len(batch.Updated) != 1 || batch.Updated[0].Current != 0.8 {
+		t.Errorf("FeedbackBatch() = %+v, %v; want held at the floor", batch, err)
+	}
+	if _, err := junior.Unprotect(lore.ID); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("Unprotect() by a junior actor error = %v, want ErrPermissionDenied", err)
+	}
+	_ = junior.Close()
+
+	admin := open("alice")
+	defer func() { _ = admin.Close() }()
+	updated, err := admin.Feedback(lore.ID, Outdated)
+	if err != nil || updated.Confidence >= 0.8 {
+		t.Errorf("admin Feedback() = %v, %v; want below the floor", updated, err)
+	}
+	if removed, err := admin.Unprotect(lore.ID); err != nil || !removed {
+		t.Errorf("admin Unprotect() = %v, %v", removed, err)
+	}
+}
@@ -0,0 +1,135 @@
+package recall
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// pushJournalKey stores the in-flight push batch in sync_meta.
+const pushJournalKey = "push_inflight"
+
+// PushJournal records a push batch before it is sent. If the process dies
+// after Engram accepts the batch but before last_push_seq is advanced, the
+// next push re-sends exactly this batch under the same push_id, which
+// Engram recognizes as already applied instead of applying it twice.
+type PushJournal struct {
+	PushID    string    `json:"push_id"`
+	FirstSeq  int64     `json:"first_seq"`
+	LastSeq   int64     `json:"last_seq"`
+	Entries   int       `json:"entries"`
+	StartedAt time.Time `json:"started_at"`
+}
+
+// InFlightPush returns the journaled push batch, or nil if the last push
+// completed or was refused.
+func (s *Store) InFlightPush() (*PushJournal, error) {
+	value, err := s.GetSyncMeta(pushJournalKey)
+	if err != nil || value == "" {
+		return nil, err
+	}
+	var j PushJournal
+	if err := json.Unmarshal([]byte(value), &j); err != nil {
+		return nil, fmt.Errorf("store: decode push journal: %w", err)
+	}
+	return &j, nil
+}
+
+// beginPush journals a batch before it is sent.
+func (s *Store) beginPush(j PushJournal) error {
+	data, err := json.Marshal(j)
+	if err != nil {
+		return fmt.Errorf("store: encode push journal: %w", err)
+	}
+	return s.SetSyncMeta(pushJournalKey, string(data))
+}
+
+// completePush advances last_push_seq and clears the journal in a single
+// transaction, so a crash leaves either the journal or the new sequence.
+func (s *Store) completePush(lastSeq int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return ErrStoreClosed
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("store: begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// Stamp synced_at on the lore this batch carried.
+	if _, err := tx.Exec(`
+		UPDATE lore_entries SET synced_at = ?
+		WHERE id IN (
+			SELECT entity_id FROM change_log
+			WHERE table_name = 'lore_entries' AND source_id = ? AND sequence <= ?
+			  AND sequence > COALESCE((SELECT CAST(value AS INTEGER) FROM sync_meta WHERE key = 'last_push_seq'), 0)
+		)
+	`, s.now().Format(time.RFC3339), s.sourceID, lastSeq); err != nil {
+		return fmt.Errorf("store: mark lore synced: %w", err)
+	}
+
+	if _, err := tx.Exec("INSERT OR REPLACE INTO sync_meta (key, value) VALUES ('last_push_seq', ?)",
+		strconv.FormatInt(lastSeq, 10)); err != nil {
+		return fmt.Errorf("store: update last_push_seq: %w", err)
+	}
+
+	// Under legal hold, record what the journaled batch sent.
+	var value string
+	if err := tx.QueryRow("SELECT value FROM sync_meta WHERE key = ?", pushJournalKey).Scan(&value); err == nil {
+		var j PushJournal
+		if err := json.Unmarshal([]byte(value), &j); err != nil {
+			return fmt.Errorf("store: decode push journal: %w", err)
+		}
+		if err := s.logEgressTx(tx, j.PushID, j.FirstSeq, lastSeq); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.Exec("DELETE FROM sync_meta WHERE key = ?", pushJournalKey); err != nil {
+		return fmt.Errorf("store: clear push journal: %w", err)
+	}
+	return tx.Commit()
+}
+
+// clearPushJournal discards the journal once Engram has answered the batch
+// definitively, or when push state is reset.
+func (s *Store) clearPushJournal() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return ErrStoreClosed
+	}
+
+	if _, err := s.db.Exec("DELETE FROM sync_meta WHERE key = ?", pushJournalKey); err != nil {
+		return fmt.Errorf("store: clear push journal: %w", err)
+	}
+	return nil
+}
+
+// journaledBatch returns the entries of an interrupted push still to be
+// confirmed, or nil when there is none. A journal at or behind
+// last_push_seq, or whose entries are gone, is stale and discarded.
+func (s *Syncer) journaledBatch(sourceID string, lastPushSeq int64) (*PushJournal, []ChangeLogEntry, error) {
+	j, err := s.store.InFlightPush()
+	if err != nil || j == nil {
+		return nil, nil, err
+	}
+	if j.LastSeq > lastPushSeq {
+		entries, err := s.store.UnpushedChanges(sourceID, j.FirstSeq-1, j.Entries)
+		if err != nil {
+			return nil, nil, err
+		}
+		for len(entries) > 0 && entries[len(entries)-1].Sequence > j.LastSeq {
+			entries = entries[:len(entries)-1]
+		}
+		if len(entries) > 0 {
+			return j, entries, nil
+		}
+	}
+	return nil, nil, s.store.clearPushJournal()
+}
@@ -0,0 +1,103 @@
+package recall
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestSyncPush_RecoversJournaledBatch simulates a crash after Engram
+// accepted a batch but before last_push_seq was saved: the batch is re-sent
+// under its original push_id, then newer changes follow in a new push.
+func TestSyncPush_RecoversJournaledBatch(t *testing.T) {
+	store := newTestStore(t)
+	insertTestChangeLogEntries(t, store, 3)
+
+	var pushes []SyncPushRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req SyncPushRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		pushes = append(pushes, req)
+		_ = json.NewEncoder(w).Encode(SyncPushResponse{Accepted: len(req.Entries)})
+	}))
+	defer server.Close()
+
+	// The crashed push: journaled, never completed.
+	if err := store.beginPush(PushJournal{PushID: "crashed-push", FirstSeq: 1, LastSeq: 2, Entries: 2, StartedAt: time.Now().UTC()}); err != nil {
+		t.Fatal(err)
+	}
+
+	syncer := newTestSyncer(t, store, server.URL)
+	result, err := syncer.SyncPush(context.Background())
+	if err != nil {
+		t.Fatalf("SyncPush: %v", err)
+	}
+	if !result.Recovered || result.EntriesPushed != 3 {
+		t.Errorf("result = %+v, want recovered with 3 entries", result)
+	}
+	if len(pushes) != 2 {
+		t.Fatalf("got %d pushes, want the journaled batch and one new push", len(pushes))
+	}
+	if pushes[0].PushID != "crashed-push" || len(pushes[0].Entries) != 2 || pushes[0].Entries[1].Sequence != 2 {
+		t.Errorf("first push = %s with %d entries, want crashed-push with sequences 1-2", pushes[0].PushID, len(pushes[0].Entries))
+	}
+	if pushes[1].PushID == "crashed-push" || len(pushes[1].Entries) != 1 || pushes[1].Entries[0].Sequence != 3 {
+		t.Errorf("second push = %s with %d entries, want a new push_id for sequence 3", pushes[1].PushID, len(pushes[1].Entries))
+	}
+
+	if j, err := store.InFlightPush(); err != nil || j != nil {
+		t.Errorf("InFlightPush() = %+v, %v; want cleared", j, err)
+	}
+	if seq, _ := store.GetSyncMeta("last_push_seq"); seq != "3" {
+		t.Errorf("last_push_seq = %s, want 3", seq)
+	}
+}
+
+func TestSyncPush_JournalOutcomes(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		cancel      bool
+		wantJournal bool
+	}{
+		{name: "interrupted keeps journal", cancel: true, wantJournal: true},
+		{name: "validation error clears journal", status: http.StatusUnprocessableEntity},
+		{name: "schema mismatch clears journal", status: http.StatusConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newTestStore(t)
+			insertTestChangeLogEntries(t, store, 2)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.cancel {
+				cancel()
+			}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(`{}`))
+			}))
+			defer server.Close()
+
+			syncer := newTestSyncer(t, store, server.URL)
+			if _, err := syncer.SyncPush(ctx); err == nil {
+				t.Fatal("SyncPush() should fail")
+			}
+
+			j, err := store.InFlightPush()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if (j != nil) != tt.wantJournal {
+				t.Fatalf("journal = %+v, want present=%v", j, tt.wantJournal)
+			}
+			if j != nil && (j.FirstSeq != 1 || j.LastSeq != 2 || j.Entries != 2 || j.PushID == "") {
+				t.Errorf("journal = %+v, want sequences 1-2", j)
+			}
+		})
+	}
+}
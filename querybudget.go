@@ -0,0 +1,62 @@
+package recall
+
+import (
+	"sort"
+	"time"
+)
+
+// budgetBatchSize is how many candidates a latency-budgeted query scores
+// between deadline checks.
+const budgetBatchSize = 1024
+
+// queryDeadline returns the earlier of params.Deadline and start plus
+// params.MaxLatency, or the zero time if neither is set.
+func queryDeadline(params QueryParams, start time.Time) time.Time {
+	deadline := params.Deadline
+	if params.MaxLatency > 0 {
+		if d := start.Add(params.MaxLatency); deadline.IsZero() || d.Before(deadline) {
+			deadline = d
+		}
+	}
+	return deadline
+}
+
+// searchWithin ranks candidates like Searcher.Search, but scores them in
+// batches of budgetBatchSize, in the order given, and stops once deadline
+// has passed. The first batch is always scored. It returns the ranking of
+// the candidates scored, and how many were.
+func (c *Client) searchWithin(query []float32, candidates []CandidateLore, k int, deadline time.Time) ([]ScoredLore, int) {
+	if deadline.IsZero() {
+		return c.searcher.Search(query, candidates, k), len(candidates)
+	}
+
+	var scored []ScoredLore
+	n := 0
+	for n < len(candidates) {
+		if n > 0 && !time.Now().Before(deadline) {
+			break
+		}
+		batch := candidates[n:min(n+budgetBatchSize, len(candidates))]
+		scored = append(scored, c.searcher.Search(query, batch, k)...)
+		n += len(batch)
+	}
+	sortScored(scored)
+	return truncateScored(scored, k), n
+}
+
+// sortScored merges batch rankings into one, highest score first.
+func sortScored(scored []ScoredLore) {
+	sort.SliceStable(scored, func(i, j int) bool {
+		return scored[i].Score > scored[j].Score
+	})
+}
+
+func truncateScored(scored []ScoredLore, k int) []ScoredLore {
+	if scored == nil {
+		return []ScoredLore{}
+	}
+	if k > 0 && len(scored) > k {
+		return scored[:k]
+	}
+	return scored
+}
@@ -0,0 +1,57 @@
+package recall
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestClient_Query_LatencyBudget(t *testing.T) {
+	client := newTestClient(t, Config{SourceID: "test"})
+
+	now := time.Now().UTC()
+	total := budgetBatchSize + 100
+	for i := range total {
+		l := &Lore{ID: fmt.Sprintf("l%05d", i), Content: "entry", Category: CategoryPatternOutcome, Confidence: 0.5,
+			SourceID: "test", CreatedAt: now, UpdatedAt: now, Embedding: PackFloat32([]float32{1, float32(i) / float32(total)})}
+		if err := client.store.InsertLore(l); err != nil {
+			t.Fatalf("InsertLore() error = %v", err)
+		}
+	}
+	query := []float32{0, 1}
+
+	full, err := client.Query(context.Background(), QueryParams{Query: "q", QueryEmbedding: query, K: 3})
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+	if full.Stats.Partial || full.Stats.Scored != 0 {
+		t.Errorf("unbounded Query() Stats = %+v, want complete", full.Stats)
+	}
+
+	// A passed deadline still scores the first batch
+	partial, err := client.Query(context.Background(), QueryParams{Query: "q", QueryEmbedding: query, K: 3, Deadline: now.Add(-time.Second)})
+	if err != nil {
+		t.Fatalf("Query(Deadline) error = %v", err)
+	}
+	if !partial.Stats.Partial || partial.Stats.Scored != budgetBatchSize {
+		t.Fatalf("Query(Deadline) Stats = %+v, want Partial with %d scored", partial.Stats, budgetBatchSize)
+	}
+	if len(partial.Lore) != 3 || partial.Lore[0].ID != fmt.Sprintf("l%05d", budgetBatchSize-1) {
+		t.Errorf("Query(Deadline) Lore[0] = %v, want the best of the first batch", partial.Lore)
+	}
+	if full.Lore[0].ID != fmt.Sprintf("l%05d", total-1) {
+		t.Errorf("unbounded Query() Lore[0] = %s, want the best overall", full.Lore[0].ID)
+	}
+
+	relaxed, err := client.Query(context.Background(), QueryParams{Query: "q", QueryEmbedding: query, K: 3, MaxLatency: time.Minute})
+	if err != nil || relaxed.Stats.Partial {
+		t.Errorf("Query(MaxLatency: 1m) = %+v, %v; want complete", relaxed.Stats, err)
+	}
+
+	var verr *ValidationError
+	if _, err := client.Query(context.Background(), QueryParams{Query: "q", MaxLatency: -time.Second}); !errors.As(err, &verr) {
+		t.Errorf("Query(negative MaxLatency) error = %v, want a ValidationError", err)
+	}
+}
@@ -0,0 +1,180 @@
+package recall
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Query log retention defaults, used when Config.QueryLog is set and the
+// matching limit is zero.
+const (
+	DefaultQueryLogRetention  = 30 * 24 * time.Hour
+	DefaultQueryLogMaxEntries = 10000
+)
+
+// QueryLogEntry is one logged query. Params holds the effective parameters,
+// after QueryProfile and defaults were applied, without the embedding;
+// EmbeddingSHA256 identifies the embedding instead.
+type QueryLogEntry struct {
+	ID              int64            `json:"id"`
+	QueriedAt       time.Time        `json:"queried_at"`
+	Actor           string           `json:"actor,omitempty"`
+	Query           string           `json:"query"`
+	EmbeddingSHA256 string           `json:"embedding_sha256,omitempty"`
+	Params          QueryParams      `json:"params"`
+	Results         []QueryLogResult `json:"results"`
+}
+
+// QueryLogResult is one entry a logged query returned, in rank order.
+// Similarity is set only for queries with an embedding.
+type QueryLogResult struct {
+	ID         string   `json:"id"`
+	Confidence float64  `json:"confidence"`
+	Similarity *float64 `json:"similarity,omitempty"`
+}
+
+// LogQuery appends entry to the query log, then deletes entries older than
+// retention and all but the newest maxEntries. Zero limits select the
+// defaults; negative ones disable that limit.
+func (s *Store) LogQuery(entry *QueryLogEntry, retention time.Duration, maxEntries int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return ErrStoreClosed
+	}
+
+	params, err := json.Marshal(entry.Params)
+	if err != nil {
+		return fmt.Errorf("store: marshal query params: %w", err)
+	}
+	results, err := json.Marshal(entry.Results)
+	if err != nil {
+		return fmt.Errorf("store: marshal query results: %w", err)
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("store: begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	res, err := tx.Exec(`
+		INSERT INTO query_log (queried_at, namespace, actor, query, embedding_sha256, params, results)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, entry.QueriedAt.UTC().Format(time.RFC3339Nano), s.namespace, entry.Actor, entry.Query, entry.EmbeddingSHA256, string(params), string(results))
+	if err != nil {
+		return fmt.Errorf("store: insert query log: %w", err)
+	}
+	entry.ID, _ = res.LastInsertId()
+
+	if retention == 0 {
+		retention = DefaultQueryLogRetention
+	}
+	if retention > 0 {
+		cutoff := s.now().Add(-retention).Format(time.RFC3339Nano)
+		if _, err := tx.Exec(`DELETE FROM query_log WHERE queried_at < ?`, cutoff); err != nil {
+			return fmt.Errorf("store: prune query log: %w", err)
+		}
+	}
+	if maxEntries == 0 {
+		maxEntries = DefaultQueryLogMaxEntries
+	}
+	if maxEntries > 0 {
+		_, err := tx.Exec(`
+			DELETE FROM query_log WHERE id <= (SELECT id FROM query_log ORDER BY id DESC LIMIT 1 OFFSET ?)
+		`, maxEntries)
+		if err != nil {
+			return fmt.Errorf("store: prune query log: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("store: commit: %w", err)
+	}
+	return nil
+}
+
+// QueryLog returns the namespace's logged queries made at or after since,
+// newest first. A positive limit caps the number returned.
+func (s *Store) QueryLog(since time.Time, limit int) ([]QueryLogEntry, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil, ErrStoreClosed
+	}
+	if limit <= 0 {
+		limit = -1
+	}
+
+	rows, err := s.db.Query(`
+		SELECT id, queried_at, actor, query, embedding_sha256, params, results
+		FROM query_log WHERE namespace = ? AND queried_at >= ?
+		ORDER BY id DESC LIMIT ?
+	`, s.namespace, since.UTC().Format(time.RFC3339Nano), limit)
+	if err != nil {
+		return nil, fmt.Errorf("store: query log: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var entries []QueryLogEntry
+	for rows.Next() {
+		var e QueryLogEntry
+		var queriedAt, params, results string
+		if err := rows.Scan(&e.ID, &queriedAt, &e.Actor, &e.Query, &e.EmbeddingSHA256, &params, &results); err != nil {
+			return nil, fmt.Errorf("store: scan query log: %w", err)
+		}
+		e.QueriedAt, _ = time.Parse(time.RFC3339Nano, queriedAt)
+		if err := json.Unmarshal([]byte(params), &e.Params); err != nil {
+			return nil, fmt.Errorf("store: decode query params %d: %w", e.ID, err)
+		}
+		if err := json.Unmarshal([]byte(results), &e.Results); err != nil {
+			return nil, fmt.Errorf("store: decode query results %d: %w", e.ID, err)
+		}
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("store: query log: %w", err)
+	}
+	return entries, nil
+}
+
+// QueryLog returns the queries logged under Config.QueryLog at or after
+// since, newest first. A positive limit caps the number returned.
+func (c *Client) QueryLog(since time.Time, limit int) ([]QueryLogEntry, error) {
+	entries, err := c.store.QueryLog(since, limit)
+	if err != nil {
+		return nil, fmt.Errorf("client: query log: %w", err)
+	}
+	return entries, nil
+}
+
+// logQuery records a query and what it returned. Logging is best-effort; a
+// failed write is reported to the debug log and does not fail the query.
+func (c *Client) logQuery(params QueryParams, lore []Lore, similarity map[string]float64) {
+	entry := &QueryLogEntry{
+		QueriedAt: c.store.now(),
+		Actor:     c.actor(),
+		Query:     params.Query,
+		Results:   make([]QueryLogResult, len(lore)),
+	}
+	if len(params.QueryEmbedding) > 0 {
+		sum := sha256.Sum256(PackFloat32(params.QueryEmbedding))
+		entry.EmbeddingSHA256 = hex.EncodeToString(sum[:])
+		params.QueryEmbedding = nil
+	}
+	entry.Params = params
+	for i, l := range lore {
+		entry.Results[i] = QueryLogResult{ID: l.ID, Confidence: l.Confidence}
+		if score, ok := similarity[l.ID]; ok {
+			entry.Results[i].Similarity = &score
+		}
+	}
+	if err := c.store.LogQuery(entry, c.config.QueryLogRetention, c.config.QueryLogMaxEntries); err != nil {
+		c.debug.LogError("log query", err)
+	}
+}
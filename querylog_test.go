@@ -0,0 +1,90 @@
+package recall
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestClient_QueryLog(t *testing.T) {
+	client := newTestClient(t, Config{AutoSync: false, QueryLog: true, Actor: "auditor"})
+
+	near := &Lore{ID: "QLOGNEAR00000000000000001", Content: "near", Category: CategoryPatternOutcome, Confidence: 0.7,
+		Embedding: PackFloat32([]float32{1, 0}), CreatedAt: time.Now().UTC(), UpdatedAt: time.Now().UTC()}
+	if err := client.store.InsertLore(near); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.Query(context.Background(), QueryParams{Query: "retries", Categories: []Category{CategoryPatternOutcome}}); err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	if _, err := client.Query(context.Background(), QueryParams{QueryEmbedding: []float32{1, 0}, K: 3}); err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+
+	entries, err := client.QueryLog(time.Time{}, 0)
+	if err != nil {
+		t.Fatalf("QueryLog: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("QueryLog returned %d entries, want 2", len(entries))
+	}
+
+	// Newest first: the embedding query.
+	emb := entries[0]
+	if emb.EmbeddingSHA256 == "" || emb.Params.QueryEmbedding != nil || emb.Params.K != 3 || emb.Actor != "auditor" {
+		t.Errorf("embedding entry = %+v", emb)
+	}
+	if len(emb.Results) != 1 || emb.Results[0].ID != near.ID || emb.Results[0].Similarity == nil || *emb.Results[0].Similarity < 0.99 {
+		t.Errorf("embedding results = %+v, want %s with similarity 1", emb.Results, near.ID)
+	}
+
+	text := entries[1]
+	if text.Query != "retries" || text.EmbeddingSHA256 != "" || text.Params.K != 5 || *text.Params.MinConfidence != 0.5 ||
+		len(text.Params.Categories) != 1 {
+		t.Errorf("text entry = %+v, want effective params", text)
+	}
+	if len(text.Results) != 1 || text.Results[0].Similarity != nil || text.Results[0].Confidence != 0.7 {
+		t.Errorf("text results = %+v", text.Results)
+	}
+
+	if entries, _ := client.QueryLog(time.Now().Add(time.Hour), 0); len(entries) != 0 {
+		t.Errorf("QueryLog(future) = %d entries, want 0", len(entries))
+	}
+	if entries, _ := client.QueryLog(time.Time{}, 1); len(entries) != 1 || entries[0].ID != emb.ID {
+		t.Errorf("QueryLog(limit 1) = %+v, want the newest", entries)
+	}
+}
+
+func TestStore_LogQuery_Retention(t *testing.T) {
+	store := newTestStore(t)
+
+	old := &QueryLogEntry{QueriedAt: time.Now().Add(-48 * time.Hour), Query: "old"}
+	if err := store.LogQuery(old, -1, -1); err != nil {
+		t.Fatal(err)
+	}
+	for _, q := range []string{"a", "b", "c"} {
+		if err := store.LogQuery(&QueryLogEntry{QueriedAt: time.Now(), Query: q}, 24*time.Hour, 2); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	entries, err := store.QueryLog(time.Time{}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[0].Query != "c" || entries[1].Query != "b" {
+		t.Errorf("entries = %+v, want c and b", entries)
+	}
+}
+
+func TestClient_QueryLog_Disabled(t *testing.T) {
+	client := newTestClient(t, Config{AutoSync: false})
+
+	if _, err := client.Query(context.Background(), QueryParams{Query: "x"}); err != nil {
+		t.Fatal(err)
+	}
+	if entries, err := client.QueryLog(time.Time{}, 0); err != nil || len(entries) != 0 {
+		t.Errorf("QueryLog = %d entries, %v; want none when disabled", len(entries), err)
+	}
+}
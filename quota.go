@@ -0,0 +1,58 @@
+package recall
+
+import "fmt"
+
+// validateCategoryQuotas checks quotas and returns their total.
+func validateCategoryQuotas(quotas map[Category]int) (int, error) {
+	total := 0
+	for cat, n := range quotas {
+		if !cat.IsValid() {
+			return 0, &ValidationError{Field: "CategoryQuotas", Message: fmt.Sprintf("invalid category %q", cat)}
+		}
+		if n < 0 {
+			return 0, &ValidationError{Field: "CategoryQuotas", Message: fmt.Sprintf("quota for %s must be non-negative", cat)}
+		}
+		total += n
+	}
+	return total, nil
+}
+
+// applyCategoryQuotas selects up to k entries from ranked lore, first taking
+// the best-ranked entries of each category up to its quota, then backfilling
+// remaining slots with the best-ranked entries of any category. The result
+// keeps ranking order.
+func applyCategoryQuotas(ranked []Lore, quotas map[Category]int, k int) []Lore {
+	selected := make([]bool, len(ranked))
+	taken := make(map[Category]int, len(quotas))
+	count := 0
+
+	for i, l := range ranked {
+		if count >= k {
+			break
+		}
+		if taken[l.Category] < quotas[l.Category] {
+			selected[i] = true
+			taken[l.Category]++
+			count++
+		}
+	}
+
+	// Backfill slots left by categories with too few matches
+	for i := range ranked {
+		if count >= k {
+			break
+		}
+		if !selected[i] {
+			selected[i] = true
+			count++
+		}
+	}
+
+	result := make([]Lore, 0, count)
+	for i, l := range ranked {
+		if selected[i] {
+			result = append(result, l)
+		}
+	}
+	return result
+}
@@ -0,0 +1,110 @@
+package recall
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestApplyCategoryQuotas_FillsAndBackfills(t *testing.T) {
+	ranked := []Lore{
+		{ID: "p1", Category: CategoryPatternOutcome},
+		{ID: "p2", Category: CategoryPatternOutcome},
+		{ID: "p3", Category: CategoryPatternOutcome},
+		{ID: "t1", Category: CategoryTestingStrategy},
+		{ID: "e1", Category: CategoryEdgeCaseDiscovery},
+		{ID: "t2", Category: CategoryTestingStrategy},
+	}
+	quotas := map[Category]int{
+		CategoryPatternOutcome:    2,
+		CategoryEdgeCaseDiscovery: 2, // only one match: one slot backfilled
+		CategoryTestingStrategy:   1,
+	}
+
+	got := applyCategoryQuotas(ranked, quotas, 5)
+	want := []string{"p1", "p2", "p3", "t1", "e1"}
+	if len(got) != len(want) {
+		t.Fatalf("got %d entries, want %d", len(got), len(want))
+	}
+	for i, id := range want {
+		if got[i].ID != id {
+			t.Errorf("got[%d] = %s, want %s (rank order)", i, got[i].ID, id)
+		}
+	}
+}
+
+func TestApplyCategoryQuotas_QuotaBeatsRank(t *testing.T) {
+	ranked := []Lore{
+		{ID: "p1", Category: CategoryPatternOutcome},
+		{ID: "p2", Category: CategoryPatternOutcome},
+		{ID: "p3", Category: CategoryPatternOutcome},
+		{ID: "t1", Category: CategoryTestingStrategy},
+	}
+	got := applyCategoryQuotas(ranked, map[Category]int{CategoryPatternOutcome: 1, CategoryTestingStrategy: 1}, 2)
+	if len(got) != 2 || got[0].ID != "p1" || got[1].ID != "t1" {
+		t.Errorf("got %+v, want p1 and t1", got)
+	}
+}
+
+func TestClient_Query_CategoryQuotas(t *testing.T) {
+	client := newTestClient(t, Config{AutoSync: false})
+
+	for _, rec := range []struct {
+		content  string
+		category Category
+	}{
+		{"pattern one", CategoryPatternOutcome},
+		{"pattern two", CategoryPatternOutcome},
+		{"pattern three", CategoryPatternOutcome},
+		{"edge one", CategoryEdgeCaseDiscovery},
+		{"testing one", CategoryTestingStrategy},
+	} {
+		if _, err := client.Record(rec.content, rec.category); err != nil {
+			t.Fatalf("Record: %v", err)
+		}
+	}
+
+	result, err := client.Query(context.Background(), QueryParams{
+		CategoryQuotas: map[Category]int{
+			CategoryEdgeCaseDiscovery: 1,
+			CategoryTestingStrategy:   1,
+		},
+	})
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+
+	// K defaults to the quota total, so both reserved categories appear
+	// even though patterns were recorded first.
+	if len(result.Lore) != 2 {
+		t.Fatalf("got %d entries, want 2", len(result.Lore))
+	}
+	got := map[Category]bool{}
+	for _, l := range result.Lore {
+		got[l.Category] = true
+	}
+	if !got[CategoryEdgeCaseDiscovery] || !got[CategoryTestingStrategy] {
+		t.Errorf("categories = %v, want edge case and testing strategy", got)
+	}
+}
+
+func TestClient_Query_CategoryQuotasValidation(t *testing.T) {
+	client := newTestClient(t, Config{AutoSync: false})
+
+	tests := []struct {
+		name   string
+		params QueryParams
+	}{
+		{"invalid category", QueryParams{CategoryQuotas: map[Category]int{"NOPE": 1}}},
+		{"negative quota", QueryParams{CategoryQuotas: map[Category]int{CategoryPatternOutcome: -1}}},
+		{"exceeds K", QueryParams{K: 2, CategoryQuotas: map[Category]int{CategoryPatternOutcome: 3}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ve *ValidationError
+			if _, err := client.Query(context.Background(), tt.params); !errors.As(err, &ve) {
+				t.Errorf("err = %v, want ValidationError", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,311 @@
+package recall
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+const metadataKeyRecoveredFrom = "recovered_from"
+
+// errIntegrityCheck marks a failed PRAGMA quick_check at open.
+var errIntegrityCheck = errors.New("integrity check failed")
+
+// RecoveredError is returned by NewStore, together with a usable store,
+// when the database was corrupt. The corrupt file was moved aside to
+// QuarantinePath and whatever rows could still be read were copied into a
+// fresh database at Path. Extractable via errors.As(); errors.Is reports
+// ErrRecovered.
+type RecoveredError struct {
+	Path           string
+	QuarantinePath string
+	Recovered      int   // Live lore entries salvaged
+	Cause          error // The corruption error that triggered recovery
+}
+
+func (e *RecoveredError) Error() string {
+	return fmt.Sprintf("store: recovered %d lore entries from corrupt database (moved to %s): %v",
+		e.Recovered, e.QuarantinePath, e.Cause)
+}
+
+func (e *RecoveredError) Unwrap() []error { return []error{ErrRecovered, e.Cause} }
+
+// Recovered returns the recovery NewStore performed when the client was
+// created, or nil if the database opened cleanly.
+func (c *Client) Recovered() *RecoveredError {
+	return c.recovered
+}
+
+// isCorrupt reports whether err means the database file is damaged or is
+// not a SQLite database at all.
+func isCorrupt(err error) bool {
+	if errors.Is(err, errIntegrityCheck) {
+		return true
+	}
+	var se *sqlite.Error
+	if errors.As(err, &se) {
+		code := se.Code() & 0xff
+		return code == sqlite3.SQLITE_CORRUPT || code == sqlite3.SQLITE_NOTADB
+	}
+	// goose does not always wrap driver errors.
+	msg := err.Error()
+	return strings.Contains(msg, "database disk image is malformed") || strings.Contains(msg, "file is not a database")
+}
+
+// quickCheck runs SQLite's quick_check so corruption in pages the
+// migrations never touch is found at open rather than mid-query.
+func quickCheck(db *sql.DB) error {
+	var result string
+	if err := db.QueryRow("PRAGMA quick_check(1)").Scan(&result); err != nil {
+		return err
+	}
+	if result != "ok" {
+		return fmt.Errorf("%w: %s", errIntegrityCheck, result)
+	}
+	return nil
+}
+
+// recoverStore quarantines the corrupt database at path, opens a fresh
+// one in its place, and salvages what it can from the quarantined copy.
+func recoverStore(path string, cause error) (*Store, error) {
+	quarantine := fmt.Sprintf("%s.corrupt-%s", path, time.Now().UTC().Format("20060102T150405Z"))
+	for _, suffix := range []string{"", "-wal", "-shm"} {
+		if err := os.Rename(path+suffix, quarantine+suffix); err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("store: quarantine corrupt database: %w (corruption: %v)", err, cause)
+		}
+	}
+
+	store, err := openStore(path)
+	if err != nil {
+		return nil, fmt.Errorf("store: recreate corrupt database: %w", err)
+	}
+	recovered, err := store.salvage(quarantine)
+	if err == nil {
+		err = store.SetMetadata(metadataKeyRecoveredFrom, quarantine)
+	}
+	if err != nil {
+		_ = store.Close()
+		return nil, fmt.Errorf("store: salvage corrupt database: %w", err)
+	}
+
+	return store, &RecoveredError{Path: path, QuarantinePath: quarantine, Recovered: recovered, Cause: cause}
+}
+
+// salvageSkipTables are not copied by salvage: sync state is rebuilt
+// rather than trusted, the keyword index is rebuilt by lore_entries'
+// triggers, and SQLite and goose manage their own tables.
+var salvageSkipTables = map[string]bool{
+	"change_log":       true,
+	"sync_meta":        true,
+	"goose_db_version": true,
+	"sqlite_sequence":  true,
+	"lore_fts":         true,
+	"lore_fts_config":  true,
+	"lore_fts_data":    true,
+	"lore_fts_docsize": true,
+	"lore_fts_idx":     true,
+}
+
+// salvage copies every readable row from the database at src into s and
+// returns the number of live lore entries recovered. Rows are read one at
+// a time by rowid, so a damaged page loses only the rows stored on it.
+//
+// The store keeps its source_id and re-queues the changes that were not
+// yet pushed. last_pull_seq starts over, so the next delta sync re-fetches
+// anything salvage could not read.
+func (s *Store) salvage(src string) (int, error) {
+	old, err := sql.Open("sqlite", "file:"+src+"?mode=ro")
+	if err != nil {
+		return 0, nil
+	}
+	defer func() { _ = old.Close() }()
+
+	tables, err := tableNames(s.db)
+	if err != nil {
+		return 0, err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("store: begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	for _, table := range tables {
+		if salvageSkipTables[table] {
+			continue
+		}
+		if err := salvageTable(old, tx, table); err != nil {
+			return 0, err
+		}
+	}
+	if err := s.salvageSyncState(old, tx); err != nil {
+		return 0, err
+	}
+
+	var recovered int
+	if err := tx.QueryRow("SELECT COUNT(*) FROM lore_entries WHERE deleted_at IS NULL").Scan(&recovered); err != nil {
+		return 0, fmt.Errorf("store: count recovered lore: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("store: commit: %w", err)
+	}
+	return recovered, s.loadSourceID()
+}
+
+// salvageTable copies the readable rows of table from old, restricted to
+// the columns both schemas share. Read errors in old are skipped.
+func salvageTable(old *sql.DB, tx *sql.Tx, table string) error {
+	oldCols, err := columnNames(old, table)
+	if err != nil || len(oldCols) == 0 {
+		return nil
+	}
+	newCols, err := columnNames(tx, table)
+	if err != nil {
+		return fmt.Errorf("store: read %s columns: %w", table, err)
+	}
+	have := make(map[string]bool, len(oldCols))
+	for _, c := range oldCols {
+		have[c] = true
+	}
+	var cols []string
+	for _, c := range newCols {
+		if have[c] {
+			cols = append(cols, c)
+		}
+	}
+	if len(cols) == 0 {
+		return nil
+	}
+
+	// NOT INDEXED reads the table itself, so a damaged index loses nothing.
+	var rowids []int64
+	if rows, err := old.Query(fmt.Sprintf("SELECT rowid FROM %q NOT INDEXED ORDER BY rowid", table)); err == nil {
+		for rows.Next() {
+			var id int64
+			if rows.Scan(&id) != nil {
+				break
+			}
+			rowids = append(rowids, id)
+		}
+		_ = rows.Close()
+	}
+
+	quoted := make([]string, len(cols))
+	for i, c := range cols {
+		quoted[i] = strconv.Quote(c)
+	}
+	selectSQL := fmt.Sprintf("SELECT %s FROM %q WHERE rowid = ?", strings.Join(quoted, ", "), table)
+	insertSQL := fmt.Sprintf("INSERT OR IGNORE INTO %q (%s) VALUES (?%s)",
+		table, strings.Join(quoted, ", "), strings.Repeat(", ?", len(cols)-1))
+
+	values := make([]any, len(cols))
+	ptrs := make([]any, len(cols))
+	for i := range values {
+		ptrs[i] = &values[i]
+	}
+	for _, id := range rowids {
+		if old.QueryRow(selectSQL, id).Scan(ptrs...) != nil {
+			continue
+		}
+		if _, err := tx.Exec(insertSQL, values...); err != nil {
+			return fmt.Errorf("store: salvage %s: %w", table, err)
+		}
+	}
+	return nil
+}
+
+// salvageSyncState keeps the old source_id and re-appends the changes
+// old had not pushed yet.
+func (s *Store) salvageSyncState(old *sql.DB, tx *sql.Tx) error {
+	var sourceID, lastPush string
+	if old.QueryRow("SELECT value FROM sync_meta WHERE key = 'source_id'").Scan(&sourceID) != nil || sourceID == "" {
+		return nil
+	}
+	if _, err := tx.Exec("UPDATE sync_meta SET value = ? WHERE key = 'source_id'", sourceID); err != nil {
+		return fmt.Errorf("store: salvage source_id: %w", err)
+	}
+	_ = old.QueryRow("SELECT value FROM sync_meta WHERE key = 'last_push_seq'").Scan(&lastPush)
+	after, _ := strconv.ParseInt(lastPush, 10, 64)
+
+	rows, err := old.Query(`
+		SELECT table_name, entity_id, operation, payload, created_at FROM change_log
+		WHERE sequence > ? AND source_id = ? ORDER BY sequence
+	`, after, sourceID)
+	if err != nil {
+		return nil
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var table, entity, op, createdAt string
+		var payload sql.NullString
+		if rows.Scan(&table, &entity, &op, &payload, &createdAt) != nil {
+			break
+		}
+		_, err := tx.Exec(`
+			INSERT INTO change_log (table_name, entity_id, operation, payload, source_id, created_at)
+			VALUES (?, ?, ?, ?, ?, ?)
+		`, table, entity, op, payload, sourceID, createdAt)
+		if err != nil {
+			return fmt.Errorf("store: salvage change_log: %w", err)
+		}
+	}
+	return nil
+}
+
+type queryer interface {
+	Query(query string, args ...any) (*sql.Rows, error)
+}
+
+// tableNames lists the ordinary tables in db.
+func tableNames(db queryer) ([]string, error) {
+	rows, err := db.Query("SELECT name FROM sqlite_master WHERE type = 'table' ORDER BY name")
+	if err != nil {
+		return nil, fmt.Errorf("store: list tables: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("store: scan table name: %w", err)
+		}
+		names = append(names, name)
+	}
+	return names, rows.Err()
+}
+
+// columnNames lists the columns of table in db.
+func columnNames(db queryer, table string) ([]string, error) {
+	rows, err := db.Query(fmt.Sprintf("PRAGMA table_info(%q)", table))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var names []string
+	for rows.Next() {
+		var (
+			cid     int
+			name    string
+			typ     string
+			notNull int
+			dflt    sql.NullString
+			pk      int
+		)
+		if err := rows.Scan(&cid, &name, &typ, &notNull, &dflt, &pk); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	return names, rows.Err()
+}
@@ -0,0 +1,161 @@
+package recall
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// corruptStoreFixture creates a store at path with n entries and one unpushed
+// change per entry, closes it, and returns its source_id.
+func corruptStoreFixture(t *testing.T, path string, n int) string {
+	t.Helper()
+	store, err := NewStore(path)
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	now := time.Now().UTC()
+	for i := 0; i < n; i++ {
+		lore := &Lore{ID: fmt.Sprintf("lore-%03d", i), Content: fmt.Sprintf("entry %d %s", i, strings.Repeat("x", 200)),
+			Category: CategoryPatternOutcome, Confidence: 0.5, SourceID: "s", CreatedAt: now, UpdatedAt: now}
+		if err := store.InsertLore(lore); err != nil {
+			t.Fatalf("InsertLore: %v", err)
+		}
+	}
+	if err := store.SetMetadata(metadataKeyLegalHold, "true"); err != nil {
+		t.Fatalf("SetMetadata: %v", err)
+	}
+	sourceID := store.SourceID()
+	// Checkpoint the WAL so the rows live in the main file.
+	if _, err := store.db.Exec("PRAGMA wal_checkpoint(TRUNCATE)"); err != nil {
+		t.Fatalf("checkpoint: %v", err)
+	}
+	if err := store.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	return sourceID
+}
+
+// overwrite writes garbage over len bytes of path at offset.
+func overwrite(t *testing.T, path string, offset int64, length int) {
+	t.Helper()
+	f, err := os.OpenFile(path, os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = f.Close() }()
+	if _, err := f.WriteAt([]byte(strings.Repeat("\xde\xad", length/2)), offset); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNewStore_HealthyDatabaseIsNotRecovered(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lore.db")
+	corruptStoreFixture(t, path, 3)
+
+	store, err := NewStore(path)
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+	if from, _ := store.GetMetadata(metadataKeyRecoveredFrom); from != "" {
+		t.Errorf("recovered_from = %q, want empty", from)
+	}
+}
+
+func TestNewStore_RecoversCorruptPages(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lore.db")
+	sourceID := corruptStoreFixture(t, path, 200)
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Damage one page near the end of the file, leaving the schema intact.
+	overwrite(t, path, info.Size()-4096*3, 4096)
+
+	store, err := NewStore(path)
+	var recovered *RecoveredError
+	if !errors.As(err, &recovered) || !errors.Is(err, ErrRecovered) {
+		t.Fatalf("NewStore err = %v, want *RecoveredError", err)
+	}
+	if store == nil {
+		t.Fatal("NewStore returned nil store with ErrRecovered")
+	}
+	defer func() { _ = store.Close() }()
+
+	if recovered.Recovered == 0 || recovered.Recovered > 200 {
+		t.Errorf("Recovered = %d, want some of 200", recovered.Recovered)
+	}
+	if _, err := os.Stat(recovered.QuarantinePath); err != nil {
+		t.Errorf("quarantined file: %v", err)
+	}
+	stats, err := store.Stats()
+	if err != nil {
+		t.Fatalf("Stats: %v", err)
+	}
+	if stats.LoreCount != recovered.Recovered {
+		t.Errorf("LoreCount = %d, want %d", stats.LoreCount, recovered.Recovered)
+	}
+	if stats.RecoveredFrom != recovered.QuarantinePath {
+		t.Errorf("RecoveredFrom = %q, want %q", stats.RecoveredFrom, recovered.QuarantinePath)
+	}
+
+	// Identity, metadata and unpushed changes survive.
+	if store.SourceID() != sourceID {
+		t.Errorf("SourceID = %q, want %q", store.SourceID(), sourceID)
+	}
+	if held, _ := store.LegalHold(); !held {
+		t.Error("LegalHold = false, want metadata salvaged")
+	}
+	pending, err := store.UnpushedChanges(sourceID, 0, 1000)
+	if err != nil {
+		t.Fatalf("UnpushedChanges: %v", err)
+	}
+	if len(pending) == 0 {
+		t.Error("no unpushed changes after recovery, want salvaged change_log")
+	}
+
+	// The recovered database opens cleanly.
+	_ = store.Close()
+	again, err := NewStore(path)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	_ = again.Close()
+}
+
+func TestNewStore_RecoversUnreadableFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lore.db")
+	corruptStoreFixture(t, path, 3)
+	overwrite(t, path, 0, 100)
+
+	store, err := NewStore(path)
+	var recovered *RecoveredError
+	if !errors.As(err, &recovered) {
+		t.Fatalf("NewStore err = %v, want *RecoveredError", err)
+	}
+	defer func() { _ = store.Close() }()
+	if recovered.Recovered != 0 {
+		t.Errorf("Recovered = %d, want 0 from an unreadable header", recovered.Recovered)
+	}
+	if err := store.InsertLore(&Lore{ID: "new", Content: "c", Category: CategoryPatternOutcome, Confidence: 0.5,
+		CreatedAt: time.Now().UTC(), UpdatedAt: time.Now().UTC()}); err != nil {
+		t.Errorf("InsertLore after recovery: %v", err)
+	}
+}
+
+func TestNew_ContinuesAfterRecovery(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lore.db")
+	corruptStoreFixture(t, path, 3)
+	overwrite(t, path, 0, 100)
+
+	client := newTestClient(t, Config{LocalPath: path, AutoSync: false})
+	if r := client.Recovered(); r == nil || r.Path != path {
+		t.Errorf("Recovered() = %v, want recovery of %s", r, path)
+	}
+}
@@ -0,0 +1,146 @@
+package recall
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// RedactionRule masks matches of Pattern in exported lore content and
+// context.
+type RedactionRule struct {
+	// Name identifies the rule in the export's redaction summary.
+	Name    string
+	Pattern *regexp.Regexp
+	// Replacement defaults to "[REDACTED:<Name>]".
+	Replacement string
+}
+
+// replacement returns the text matches are replaced with.
+func (r RedactionRule) replacement() string {
+	if r.Replacement != "" {
+		return r.Replacement
+	}
+	return "[REDACTED:" + r.Name + "]"
+}
+
+// RedactEmails masks email addresses.
+var RedactEmails = RedactionRule{
+	Name:    "email",
+	Pattern: regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`),
+}
+
+// RedactSecrets masks common credential formats: cloud and forge access
+// tokens, bearer tokens, PEM private keys, and values assigned to keys
+// named like password, secret, token or api_key.
+var RedactSecrets = RedactionRule{
+	Name: "secret",
+	Pattern: regexp.MustCompile(strings.Join([]string{
+		`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`,
+		`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`,
+		`\b(?:ghp|gho|ghu|ghs|ghr|github_pat)_[A-Za-z0-9_]{20,}\b`,
+		`\bxox[abprs]-[A-Za-z0-9-]{10,}\b`,
+		`\bsk-[A-Za-z0-9_-]{20,}\b`,
+		`(?i:\bbearer\s+[A-Za-z0-9._~+/=-]{16,})`,
+		`(?i:\b(?:password|passwd|secret|token|api[_-]?key)\b\s*[:=]\s*["']?[^\s"']+)`,
+	}, "|")),
+}
+
+// RedactTerms masks whole-word, case-insensitive occurrences of terms,
+// such as the names of projects or customers, under the rule name name.
+// It returns an error if no term is given.
+func RedactTerms(name string, terms ...string) (RedactionRule, error) {
+	quoted := make([]string, 0, len(terms))
+	for _, term := range terms {
+		if term = strings.TrimSpace(term); term != "" {
+			quoted = append(quoted, regexp.QuoteMeta(term))
+		}
+	}
+	if len(quoted) == 0 {
+		return RedactionRule{}, &ValidationError{Field: "Terms", Message: "at least one term is required"}
+	}
+	// Longest first, so a term is not masked only in part by a shorter one.
+	sort.Slice(quoted, func(i, j int) bool { return len(quoted[i]) > len(quoted[j]) })
+	return RedactionRule{
+		Name:    name,
+		Pattern: regexp.MustCompile(`(?i)\b(?:` + strings.Join(quoted, "|") + `)\b`),
+	}, nil
+}
+
+// ParseRedactions parses a comma-separated list of built-in rule names:
+// email, secrets, or all.
+func ParseRedactions(spec string) ([]RedactionRule, error) {
+	var rules []RedactionRule
+	for _, name := range strings.Split(spec, ",") {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "":
+		case "email", "emails":
+			rules = append(rules, RedactEmails)
+		case "secret", "secrets":
+			rules = append(rules, RedactSecrets)
+		case "all":
+			rules = append(rules, RedactEmails, RedactSecrets)
+		default:
+			return nil, fmt.Errorf("unknown redaction %q: want email, secrets or all", name)
+		}
+	}
+	return rules, nil
+}
+
+// RedactionCount reports how often one rule matched in an export.
+type RedactionCount struct {
+	Rule    string `json:"rule"`
+	Matches int    `json:"matches"`
+	Entries int    `json:"entries"` // Entries with at least one match
+}
+
+// RedactionSummary reports the redactions applied to an export. It is
+// appended to the JSON export after the lore array.
+type RedactionSummary struct {
+	// Entries counts exported entries with any redaction. Their
+	// embeddings are left out, since they encode the unredacted text.
+	Entries int              `json:"entries"`
+	Rules   []RedactionCount `json:"rules"`
+}
+
+// redactor applies rules to exported lore and tallies the matches.
+type redactor struct {
+	rules   []RedactionRule
+	summary RedactionSummary
+}
+
+func newRedactor(rules []RedactionRule) (*redactor, error) {
+	r := &redactor{rules: rules, summary: RedactionSummary{Rules: make([]RedactionCount, len(rules))}}
+	for i, rule := range rules {
+		if rule.Name == "" || rule.Pattern == nil {
+			return nil, &ValidationError{Field: "Redact", Message: fmt.Sprintf("rule %d needs a name and a pattern", i+1)}
+		}
+		r.summary.Rules[i].Rule = rule.Name
+	}
+	return r, nil
+}
+
+// apply redacts lore's content and context in place.
+func (r *redactor) apply(lore *ExportLore) {
+	redacted := false
+	for i, rule := range r.rules {
+		matches := 0
+		for _, field := range []*string{&lore.Content, &lore.Context} {
+			*field = rule.Pattern.ReplaceAllStringFunc(*field, func(string) string {
+				matches++
+				return rule.replacement()
+			})
+		}
+		if matches > 0 {
+			r.summary.Rules[i].Matches += matches
+			r.summary.Rules[i].Entries++
+			redacted = true
+		}
+	}
+	if redacted {
+		r.summary.Entries++
+		lore.Embedding = nil
+		lore.EmbeddingStatus = "pending"
+	}
+}
@@ -0,0 +1,214 @@
+package recall
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// remoteQueryTimeout bounds the Engram round trip of a remote fallback, so
+// an unresponsive server delays a query by at most this long.
+const remoteQueryTimeout = 5 * time.Second
+
+// remoteQueryRequest is the body of POST /stores/{store_id}/lore/query.
+type remoteQueryRequest struct {
+	Query         string     `json:"query"`
+	Embedding     []float32  `json:"embedding,omitempty"`
+	K             int        `json:"k"`
+	MinConfidence float64    `json:"min_confidence"`
+	Categories    []Category `json:"categories,omitempty"`
+	Namespace     string     `json:"namespace,omitempty"`
+}
+
+// remoteQueryResponse carries Engram's matches as full-entity lore
+// payloads, like delta entries, with the packed embedding and the
+// server's similarity score.
+type remoteQueryResponse struct {
+	Results []struct {
+		Lore      json.RawMessage `json:"lore"`
+		Embedding []byte          `json:"embedding,omitempty"`
+		Score     float64         `json:"score"`
+	} `json:"results"`
+}
+
+// remoteMatch is lore returned by a remote query with its score.
+type remoteMatch struct {
+	Lore  Lore
+	Score float64
+}
+
+// queryPath returns the API path for store-scoped lore queries.
+func (s *Syncer) queryPath() string {
+	if s.storeID == "" {
+		panic("recall: queryPath requires storeID to be set")
+	}
+	return fmt.Sprintf("/api/v1/stores/%s/lore/query", encodeStoreID(s.storeID))
+}
+
+// QueryRemote asks Engram for lore matching req. Like SubmitFeedback it
+// does not retry; callers treat a failure as no remote results.
+func (s *Syncer) QueryRemote(ctx context.Context, req remoteQueryRequest) ([]remoteMatch, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("remote query: marshal request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", s.engramURL+s.queryPath(), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("remote query: create request: %w", err)
+	}
+	s.setHeaders(httpReq)
+	httpReq.Header.Set("Content-Type", "application/json")
+	if len(s.signingSecret) > 0 {
+		httpReq.Header.Set(SignatureHeader, SignBody(s.signingSecret, body))
+	}
+
+	resp, err := s.do(httpReq)
+	if err != nil {
+		return nil, &SyncError{Operation: "remote query", Err: err}
+	}
+	respBody, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &SyncError{Operation: "remote query", StatusCode: resp.StatusCode, Err: errors.New(truncate(string(respBody), 200))}
+	}
+
+	var qr remoteQueryResponse
+	if err := json.Unmarshal(respBody, &qr); err != nil {
+		return nil, fmt.Errorf("remote query: decode response: %w", err)
+	}
+	matches := make([]remoteMatch, 0, len(qr.Results))
+	for _, r := range qr.Results {
+		lore, err := parseLorePayload(r.Lore)
+		if err != nil {
+			return nil, fmt.Errorf("remote query: %w", err)
+		}
+		if lore.DeletedAt != nil {
+			continue
+		}
+		lore.Embedding = r.Embedding
+		if len(lore.Embedding) > 0 {
+			lore.EmbeddingStatus = "complete"
+		}
+		matches = append(matches, remoteMatch{Lore: *lore, Score: r.Score})
+	}
+	return matches, nil
+}
+
+// needsRemoteFallback reports whether a query's local results are weak
+// enough to ask Engram: none at all, a best similarity below
+// Config.RemoteFallbackScore, or, without a query embedding, fewer than K.
+func (c *Client) needsRemoteFallback(params QueryParams, lore []Lore, similarity map[string]float64) bool {
+	if c.syncer == nil || c.config.RemoteFallbackScore <= 0 || len(params.Owners) > 0 || !params.usesSimilarity() {
+		return false
+	}
+	if len(lore) == 0 {
+		return true
+	}
+	if len(params.QueryEmbedding) == 0 {
+		return params.K > 0 && len(lore) < params.K
+	}
+	best := 0.0
+	for _, l := range lore {
+		if s := similarity[l.ID]; s > best {
+			best = s
+		}
+	}
+	return best < c.config.RemoteFallbackScore
+}
+
+// remoteFallback queries Engram and merges matches not already in lore,
+// flagged Remote, into the ranking by similarity, keeping at most k (zero
+// keeps all). Matches are cached locally without a change_log entry, as
+// delta sync would apply them, so later queries and feedback find them.
+// It is best-effort: on failure the local results are returned unchanged.
+// The caller must not hold the maintenance gate, which remoteFallback
+// enters only to cache the matches.
+func (c *Client) remoteFallback(ctx context.Context, params QueryParams, k int, lore []Lore, similarity map[string]float64, stats *QueryStats) ([]Lore, map[string]float64) {
+	ctx, cancel := context.WithTimeout(ctx, remoteQueryTimeout)
+	defer cancel()
+
+	req := remoteQueryRequest{
+		Query:      params.Query,
+		Embedding:  params.QueryEmbedding,
+		K:          params.K,
+		Categories: params.Categories,
+		Namespace:  c.store.namespace,
+	}
+	if params.MinConfidence != nil {
+		req.MinConfidence = *params.MinConfidence
+	}
+	start := time.Now()
+	matches, err := c.syncer.QueryRemote(ctx, req)
+	stats.RemoteTime = time.Since(start)
+	if err != nil {
+		c.debug.LogError("remote query", err)
+		return lore, similarity
+	}
+
+	seen := make(map[string]bool, len(lore))
+	for _, l := range lore {
+		seen[l.ID] = true
+	}
+	if similarity == nil {
+		similarity = make(map[string]float64)
+	}
+	release, err := c.store.enter(ctx)
+	if err != nil {
+		c.debug.LogError("cache remote lore", err)
+		return lore, similarity
+	}
+	defer release()
+	var remote []Lore
+	for _, m := range matches {
+		if seen[m.Lore.ID] {
+			continue
+		}
+		seen[m.Lore.ID] = true
+		cached := m.Lore
+		if err := c.store.UpsertLore(&cached); err != nil {
+			c.debug.LogError("cache remote lore", err)
+		}
+		m.Lore.Remote = true
+		remote = append(remote, m.Lore)
+		similarity[m.Lore.ID] = m.Score
+	}
+	if len(remote) == 0 {
+		return lore, similarity
+	}
+
+	// Without a query embedding there are no scores: remote matches follow
+	// the local ones. Otherwise each is placed ahead of the first local
+	// entry it outscores, keeping the local order.
+	merged := lore
+	if len(params.QueryEmbedding) > 0 {
+		sort.SliceStable(remote, func(i, j int) bool { return similarity[remote[i].ID] > similarity[remote[j].ID] })
+		merged = make([]Lore, 0, len(lore)+len(remote))
+		i := 0
+		for _, l := range lore {
+			for i < len(remote) && similarity[remote[i].ID] > similarity[l.ID] {
+				merged = append(merged, remote[i])
+				i++
+			}
+			merged = append(merged, l)
+		}
+		remote = remote[i:]
+	}
+	merged = append(merged, remote...)
+	if k > 0 && len(merged) > k {
+		merged = merged[:k]
+	}
+	for _, l := range merged {
+		if l.Remote {
+			stats.RemoteResults++
+		}
+	}
+	return merged, similarity
+}
@@ -0,0 +1,144 @@
+package recall
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newRemoteQueryServer returns an Engram stub answering lore queries with
+// one remote entry scored 0.9, and the number of queries it received.
+func newRemoteQueryServer(t *testing.T, status int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var queries atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/stores/test-store/lore/query" {
+			_, _ = w.Write([]byte(`{}`))
+			return
+		}
+		queries.Add(1)
+		if status != http.StatusOK {
+			w.WriteHeader(status)
+			return
+		}
+		payload, _ := lorePayloadJSON(&Lore{
+			ID: "01REMOTE00000000000000000", Content: "Remote knowledge", Category: CategoryPatternOutcome,
+			Confidence: 0.8, SourceID: "peer", CreatedAt: time.Now().UTC(), UpdatedAt: time.Now().UTC(),
+		})
+		_ = json.NewEncoder(w).Encode(map[string]any{"results": []map[string]any{{
+			"lore":      json.RawMessage(payload),
+			"embedding": PackFloat32([]float32{1, 0}),
+			"score":     0.9,
+		}}})
+	}))
+	t.Cleanup(server.Close)
+	return server, &queries
+}
+
+func newRemoteFallbackClient(t *testing.T, url string) *Client {
+	t.Helper()
+	client := newTestClient(t, Config{
+		Store:               "test-store",
+		EngramURL:           url,
+		APIKey:              "key",
+		RemoteFallbackScore: 0.7,
+	})
+
+	now := time.Now().UTC()
+	if err := client.store.InsertLore(&Lore{
+		ID: "01LOCAL000000000000000000", Content: "Local knowledge", Category: CategoryPatternOutcome,
+		Confidence: 0.8, SourceID: "test", CreatedAt: now, UpdatedAt: now,
+		Embedding: PackFloat32([]float32{0.5, 0.866}), EmbeddingStatus: "complete",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func TestQuery_RemoteFallbackMergesAndCaches(t *testing.T) {
+	server, queries := newRemoteQueryServer(t, http.StatusOK)
+	client := newRemoteFallbackClient(t, server.URL)
+	before := getChangeLogCount(t, client.store)
+
+	// The local entry scores 0.5, below the 0.7 threshold.
+	result, err := client.Query(context.Background(), QueryParams{Query: "knowledge", QueryEmbedding: []float32{1, 0}})
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+	if queries.Load() != 1 {
+		t.Fatalf("remote queries = %d, want 1", queries.Load())
+	}
+	if len(result.Lore) != 2 || result.Lore[0].ID != "01REMOTE00000000000000000" || !result.Lore[0].Remote || result.Lore[1].Remote {
+		t.Fatalf("result = %+v, want the remote entry first, flagged, then the local one", result.Lore)
+	}
+	if result.Stats.RemoteResults != 1 {
+		t.Errorf("RemoteResults = %d, want 1", result.Stats.RemoteResults)
+	}
+
+	cached, err := client.store.Get("01REMOTE00000000000000000")
+	if err != nil || cached.Content != "Remote knowledge" || len(cached.Embedding) == 0 {
+		t.Fatalf("cached = %+v, %v; want the remote entry with its embedding", cached, err)
+	}
+	if after := getChangeLogCount(t, client.store); after != before {
+		t.Errorf("change_log grew from %d to %d; cached remote lore should not be pushed back", before, after)
+	}
+
+	// Now the cached entry is a good local match and Engram is not asked.
+	result, err = client.Query(context.Background(), QueryParams{Query: "knowledge", QueryEmbedding: []float32{1, 0}})
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+	if queries.Load() != 1 || result.Lore[0].Remote || result.Stats.RemoteResults != 0 {
+		t.Errorf("second query: remote queries = %d, first remote = %v", queries.Load(), result.Lore[0].Remote)
+	}
+}
+
+func TestQuery_RemoteFallbackFailureKeepsLocalResults(t *testing.T) {
+	server, queries := newRemoteQueryServer(t, http.StatusServiceUnavailable)
+	client := newRemoteFallbackClient(t, server.URL)
+
+	result, err := client.Query(context.Background(), QueryParams{Query: "knowledge", QueryEmbedding: []float32{1, 0}})
+	if err != nil {
+		t.Fatalf("Query() error = %v; remote failures should not fail the query", err)
+	}
+	if queries.Load() != 1 || len(result.Lore) != 1 || result.Lore[0].ID != "01LOCAL000000000000000000" {
+		t.Errorf("result = %+v after %d remote queries, want the local entry only", result.Lore, queries.Load())
+	}
+}
+
+func TestQuery_RemoteFallbackOutsideGate(t *testing.T) {
+	started, unblock := make(chan struct{}), make(chan struct{})
+	var once sync.Once
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/stores/test-store/lore/query" {
+			once.Do(func() { close(started) })
+			<-unblock
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(server.Close)
+	client := newRemoteFallbackClient(t, server.URL)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := client.Query(context.Background(), QueryParams{Query: "knowledge", QueryEmbedding: []float32{1, 0}})
+		done <- err
+	}()
+	<-started
+
+	// Maintenance proceeds while Engram is still answering
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if _, err := client.Maintain(ctx); err != nil {
+		t.Errorf("Maintain during remote fallback: %v", err)
+	}
+	close(unblock)
+	if err := <-done; err != nil {
+		t.Errorf("Query: %v", err)
+	}
+}
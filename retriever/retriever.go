@@ -0,0 +1,107 @@
+// Package retriever adapts Recall to the retriever interfaces of Go LLM
+// frameworks so lore drops into existing RAG pipelines.
+//
+// The package has no framework dependencies. Retriever implements the
+// langchaingo schema.Retriever method set, and Document has the same fields
+// as langchaingo's schema.Document, so a thin wrapper converts directly:
+//
+//	type lcRetriever struct{ *retriever.Retriever }
+//
+//	func (r lcRetriever) GetRelevantDocuments(ctx context.Context, q string) ([]schema.Document, error) {
+//		docs, err := r.Retriever.GetRelevantDocuments(ctx, q)
+//		out := make([]schema.Document, len(docs))
+//		for i, d := range docs {
+//			out[i] = schema.Document(d)
+//		}
+//		return out, err
+//	}
+//
+// For Genkit, register a retriever whose function calls GetRelevantDocuments
+// with the request's query text and wraps each document with
+// ai.DocumentFromText(d.PageContent, d.Metadata).
+package retriever
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hyperengineering/recall"
+)
+
+// Metadata keys set on every Document.
+const (
+	MetaID         = "id"
+	MetaRef        = "ref"
+	MetaCategory   = "category"
+	MetaConfidence = "confidence"
+	MetaContext    = "context"
+	MetaSourceID   = "source_id"
+)
+
+// Document is a retrieved lore entry. Its fields match langchaingo's
+// schema.Document, so the two types convert directly.
+type Document struct {
+	PageContent string
+	Metadata    map[string]any
+	Score       float32
+}
+
+// Options configures a Retriever. Zero values use the Client.ContextPack
+// defaults.
+type Options struct {
+	K             int
+	MinConfidence *float64
+	Categories    []recall.Category
+
+	// Embed, if set, embeds queries for similarity ranking.
+	Embed recall.EmbedFunc
+}
+
+// Retriever retrieves lore documents from a Client.
+type Retriever struct {
+	client *recall.Client
+	opts   Options
+}
+
+// New creates a Retriever backed by client.
+func New(client *recall.Client, opts Options) *Retriever {
+	return &Retriever{client: client, opts: opts}
+}
+
+// GetRelevantDocuments returns lore relevant to query, best first. With
+// Options.Embed entries are ranked by similarity, otherwise by the query
+// words they contain (see Client.ContextPack). Score is the entry's
+// confidence. Retrieved entries are tracked in the client's session, so the
+// "ref" metadata works with Client.Feedback.
+func (r *Retriever) GetRelevantDocuments(ctx context.Context, query string) ([]Document, error) {
+	pack, err := r.client.ContextPack(ctx, recall.PackParams{
+		Topics:        []string{query},
+		Embed:         r.opts.Embed,
+		K:             r.opts.K,
+		MinConfidence: r.opts.MinConfidence,
+		Categories:    r.opts.Categories,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("retriever: %w", err)
+	}
+
+	var docs []Document
+	for _, e := range pack.Sections[0].Entries {
+		meta := map[string]any{
+			MetaID:         e.Lore.ID,
+			MetaRef:        e.Ref,
+			MetaCategory:   string(e.Lore.Category),
+			MetaConfidence: e.Lore.Confidence,
+			MetaSourceID:   e.Lore.SourceID,
+		}
+		if e.Lore.Context != "" {
+			meta[MetaContext] = e.Lore.Context
+		}
+		docs = append(docs, Document{
+			PageContent: e.Lore.Content,
+			Metadata:    meta,
+			Score:       float32(e.Lore.Confidence),
+		})
+	}
+	return docs, nil
+}
@@ -0,0 +1,60 @@
+package retriever
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperengineering/recall"
+)
+
+func TestRetriever_GetRelevantDocuments(t *testing.T) {
+	client, err := recall.New(recall.Config{LocalPath: filepath.Join(t.TempDir(), "lore.db")})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer client.Close()
+
+	if _, err := client.Record("Use contract tests for the billing API", recall.CategoryTestingStrategy,
+		recall.WithContext("story-7"), recall.WithConfidence(0.8)); err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+	if _, err := client.Record("Pool sizes above 50 starve the replica", recall.CategoryPerformanceInsight); err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+
+	r := New(client, Options{Categories: []recall.Category{recall.CategoryTestingStrategy}})
+	docs, err := r.GetRelevantDocuments(context.Background(), "billing tests")
+	if err != nil {
+		t.Fatalf("GetRelevantDocuments: %v", err)
+	}
+	if len(docs) != 1 {
+		t.Fatalf("docs = %d, want 1", len(docs))
+	}
+
+	d := docs[0]
+	if d.PageContent != "Use contract tests for the billing API" || d.Score != float32(0.8) {
+		t.Errorf("doc = %+v", d)
+	}
+	if d.Metadata[MetaRef] != "L1" || d.Metadata[MetaCategory] != "TESTING_STRATEGY" || d.Metadata[MetaContext] != "story-7" {
+		t.Errorf("metadata = %v", d.Metadata)
+	}
+	if _, err := client.Feedback(d.Metadata[MetaRef].(string), recall.Helpful); err != nil {
+		t.Errorf("Feedback via ref metadata: %v", err)
+	}
+}
+
+func TestRetriever_EmbedError(t *testing.T) {
+	client, err := recall.New(recall.Config{LocalPath: filepath.Join(t.TempDir(), "lore.db")})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer client.Close()
+
+	embedErr := errors.New("embedder down")
+	r := New(client, Options{Embed: func(ctx context.Context, text string) ([]float32, error) { return nil, embedErr }})
+	if _, err := r.GetRelevantDocuments(context.Background(), "q"); !errors.Is(err, embedErr) {
+		t.Errorf("err = %v, want wrapped embed error", err)
+	}
+}
@@ -0,0 +1,95 @@
+package recall
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Defaults for RetryPolicy.
+const (
+	DefaultRetryAttempts = 6                // The first push attempt and five retries
+	DefaultRetryMaxWait  = 60 * time.Second // Cap on a single backoff wait
+)
+
+// RetryPolicy bounds how sync retries a push batch that failed with a
+// transient error. Waits double from one second, up to MaxWait.
+type RetryPolicy struct {
+	MaxAttempts int           // Attempts per batch, including the first (default: DefaultRetryAttempts)
+	MaxWait     time.Duration // Longest wait between attempts (default: DefaultRetryMaxWait)
+}
+
+// withDefaults fills unset fields.
+func (p RetryPolicy) withDefaults() RetryPolicy {
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = DefaultRetryAttempts
+	}
+	if p.MaxWait <= 0 {
+		p.MaxWait = DefaultRetryMaxWait
+	}
+	return p
+}
+
+// wait returns the backoff before attempt (2 for the first retry).
+func (p RetryPolicy) wait(attempt int) time.Duration {
+	wait := time.Second
+	for i := 2; i < attempt && wait < p.MaxWait; i++ {
+		wait *= 2
+	}
+	return min(wait, p.MaxWait)
+}
+
+// Classes of transient failure reported in RetryEvent.Class.
+const (
+	RetryClassNetwork   = "network"   // No response: connection refused, reset, timed out
+	RetryClassThrottled = "throttled" // HTTP 429
+	RetryClassServer    = "server"    // HTTP 5xx
+	RetryClassHTTP      = "http"      // Another unexpected HTTP status
+)
+
+// RetryEvent describes a retry about to be made.
+type RetryEvent struct {
+	Operation   string        // "push"
+	Attempt     int           // The attempt about to be made, from 2
+	MaxAttempts int           // RetryPolicy.MaxAttempts
+	Wait        time.Duration // Backoff before the attempt
+	Class       string        // RetryClassNetwork, RetryClassThrottled, RetryClassServer or RetryClassHTTP
+	Err         error         // Why the previous attempt failed
+}
+
+// RetryFunc is called before each retry's backoff wait.
+type RetryFunc func(RetryEvent)
+
+// retryClass classifies a failed attempt from its HTTP status, or 0 when
+// no response was received.
+func retryClass(status int) string {
+	switch {
+	case status == 0:
+		return RetryClassNetwork
+	case status == http.StatusTooManyRequests:
+		return RetryClassThrottled
+	case status >= 500:
+		return RetryClassServer
+	default:
+		return RetryClassHTTP
+	}
+}
+
+// SetRetryPolicy sets how push batches are retried on transient errors.
+// Zero fields use the defaults.
+func (s *Syncer) SetRetryPolicy(policy RetryPolicy) {
+	s.retry = policy.withDefaults()
+}
+
+// SetRetryHandler sets the function called before each retry.
+func (s *Syncer) SetRetryHandler(fn RetryFunc) {
+	s.onRetry = fn
+}
+
+// notifyRetry reports a retry to the debug log and the retry handler.
+func (s *Syncer) notifyRetry(e RetryEvent) {
+	s.debug.LogSync(e.Operation, fmt.Sprintf("attempt %d/%d in %s after %s error: %v", e.Attempt, e.MaxAttempts, e.Wait, e.Class, e.Err))
+	if s.onRetry != nil {
+		s.onRetry(e)
+	}
+}
@@ -0,0 +1,92 @@
+package recall
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicy_Wait(t *testing.T) {
+	policy := RetryPolicy{}.withDefaults()
+	var got []time.Duration
+	for attempt := 2; attempt <= 9; attempt++ {
+		got = append(got, policy.wait(attempt))
+	}
+	want := []time.Duration{1, 2, 4, 8, 16, 32, 60, 60}
+	for i := range want {
+		if got[i] != want[i]*time.Second {
+			t.Errorf("wait(%d) = %v, want %v", i+2, got[i], want[i]*time.Second)
+		}
+	}
+	if w := (RetryPolicy{MaxWait: 1500 * time.Millisecond}).withDefaults().wait(3); w != 1500*time.Millisecond {
+		t.Errorf("capped wait = %v, want 1.5s", w)
+	}
+}
+
+func TestSyncPush_RetryEvents(t *testing.T) {
+	store := newTestStore(t)
+	insertTestChangeLogEntries(t, store, 1)
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch requests.Add(1) {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			_ = json.NewEncoder(w).Encode(SyncPushResponse{Accepted: 1})
+		}
+	}))
+	defer server.Close()
+
+	syncer := newTestSyncer(t, store, server.URL)
+	syncer.SetRetryPolicy(RetryPolicy{MaxAttempts: 3, MaxWait: 1500 * time.Millisecond})
+	var slept []time.Duration
+	syncer.sleepFn = func(ctx context.Context, d time.Duration) error {
+		slept = append(slept, d)
+		return nil
+	}
+	var events []RetryEvent
+	syncer.SetRetryHandler(func(e RetryEvent) { events = append(events, e) })
+
+	if _, err := syncer.SyncPush(context.Background()); err != nil {
+		t.Fatalf("SyncPush() error = %v", err)
+	}
+	if len(events) != 2 || len(slept) != 2 {
+		t.Fatalf("events = %+v, slept %v; want 2 retries", events, slept)
+	}
+	if e := events[0]; e.Attempt != 2 || e.MaxAttempts != 3 || e.Wait != time.Second || e.Class != RetryClassServer || !strings.Contains(e.Err.Error(), "HTTP 503") {
+		t.Errorf("first retry = %+v", e)
+	}
+	if e := events[1]; e.Attempt != 3 || e.Wait != 1500*time.Millisecond || e.Class != RetryClassThrottled || slept[1] != e.Wait {
+		t.Errorf("second retry = %+v (slept %v)", e, slept[1])
+	}
+}
+
+func TestSyncPush_RetryAttemptsExhausted(t *testing.T) {
+	store := newTestStore(t)
+	insertTestChangeLogEntries(t, store, 1)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close() // every attempt fails to connect
+
+	syncer := newTestSyncer(t, store, server.URL)
+	syncer.SetRetryPolicy(RetryPolicy{MaxAttempts: 2})
+	syncer.sleepFn = func(ctx context.Context, d time.Duration) error { return nil }
+	var events []RetryEvent
+	syncer.SetRetryHandler(func(e RetryEvent) { events = append(events, e) })
+
+	_, err := syncer.SyncPush(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "max retries exceeded") {
+		t.Fatalf("SyncPush() error = %v, want max retries exceeded", err)
+	}
+	if len(events) != 1 || events[0].Class != RetryClassNetwork {
+		t.Errorf("events = %+v, want one network retry", events)
+	}
+}
@@ -0,0 +1,37 @@
+package recall
+
+import "time"
+
+// DefaultValidationStaleAfter is how long after its last validation an
+// entry is flagged as needing validation again.
+const DefaultValidationStaleAfter = 90 * 24 * time.Hour
+
+// needsValidation reports whether l has never been validated, or was last
+// validated more than staleAfter before now.
+func needsValidation(l Lore, now time.Time, staleAfter time.Duration) bool {
+	if l.ValidationCount == 0 || l.LastValidatedAt == nil {
+		return true
+	}
+	return now.Sub(*l.LastValidatedAt) > staleAfter
+}
+
+// needsValidationIDs returns the entries of lore that need validation, or
+// nil if none do.
+func (c *Client) needsValidationIDs(lore []Lore) map[string]bool {
+	staleAfter := c.config.ValidationStaleAfter
+	if staleAfter <= 0 {
+		staleAfter = DefaultValidationStaleAfter
+	}
+	now := c.store.now()
+
+	var flagged map[string]bool
+	for _, l := range lore {
+		if needsValidation(l, now, staleAfter) {
+			if flagged == nil {
+				flagged = make(map[string]bool)
+			}
+			flagged[l.ID] = true
+		}
+	}
+	return flagged
+}
@@ -0,0 +1,69 @@
+package recall
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestNeedsValidation(t *testing.T) {
+	now := time.Now().UTC()
+	recent := now.Add(-24 * time.Hour)
+	old := now.Add(-100 * 24 * time.Hour)
+
+	tests := []struct {
+		name string
+		lore Lore
+		want bool
+	}{
+		{"never validated", Lore{}, true},
+		{"count without timestamp", Lore{ValidationCount: 2}, true},
+		{"recently validated", Lore{ValidationCount: 1, LastValidatedAt: &recent}, false},
+		{"validation is stale", Lore{ValidationCount: 3, LastValidatedAt: &old}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := needsValidation(tt.lore, now, DefaultValidationStaleAfter); got != tt.want {
+				t.Errorf("needsValidation() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuery_NeedsValidation(t *testing.T) {
+	client := newTestClient(t, Config{AutoSync: false})
+
+	unvalidated, err := client.Record("Retry idempotent requests only", CategoryPatternOutcome)
+	if err != nil {
+		t.Fatal(err)
+	}
+	validated, err := client.Record("Retry with jittered backoff", CategoryPatternOutcome)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Feedback(validated.ID, Helpful); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := client.Query(context.Background(), QueryParams{Query: "retry", MinConfidence: new(float64)})
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	if len(result.Lore) != 2 {
+		t.Fatalf("Query returned %d entries, want 2", len(result.Lore))
+	}
+	if !result.NeedsValidation[unvalidated.ID] || result.NeedsValidation[validated.ID] || len(result.NeedsValidation) != 1 {
+		t.Errorf("NeedsValidation = %v, want only %s", result.NeedsValidation, unvalidated.ID)
+	}
+
+	// A short staleness window flags the validated entry too.
+	client.config.ValidationStaleAfter = time.Nanosecond
+	time.Sleep(time.Millisecond)
+	result, err = client.Query(context.Background(), QueryParams{Query: "retry", MinConfidence: new(float64)})
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	if len(result.NeedsValidation) != 2 {
+		t.Errorf("NeedsValidation = %v, want both entries", result.NeedsValidation)
+	}
+}
@@ -0,0 +1,257 @@
+package recall
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// diffContextWords is how many unchanged words a content diff keeps on
+// each side of a change; longer unchanged runs are elided.
+const diffContextWords = 3
+
+// Revision is one content edit made with Client.Update. Diff is a compact
+// word diff from the previous content: removed words as [-...-], added
+// words as {+...+}, and unchanged runs longer than a few words elided
+// with "…".
+type Revision struct {
+	Version   int       `json:"version"` // 2 for the first edit; version 1 is the content as recorded
+	Actor     string    `json:"actor"`
+	Diff      string    `json:"diff"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// UpdateContent replaces the content of lore, records the edit as a
+// revision by actor, and appends a change_log upsert so the edit is
+// pushed. The embedding no longer matches the content and is cleared,
+// for every model. Unchanged content writes nothing.
+// Returns ErrNotFound if the entry does not exist or is deleted.
+func (s *Store) UpdateContent(id, content, actor string) (*Lore, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil, ErrStoreClosed
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("store: begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	existing, err := s.getLoreTx(tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if existing.Content == content {
+		return existing, nil
+	}
+
+	now := s.now().Format(time.RFC3339)
+	if _, err := tx.Exec(`
+		UPDATE lore_entries SET content = ?, fingerprint = ?, checksum = ?, embedding = NULL, embedding_status = 'pending', updated_at = ?
+		WHERE id = ?
+	`, content, Fingerprint(content), loreChecksum(id, content, existing.Context, existing.Category), now, id); err != nil {
+		return nil, fmt.Errorf("store: update content: %w", err)
+	}
+	if _, err := tx.Exec(`DELETE FROM lore_embeddings WHERE lore_id = ?`, id); err != nil {
+		return nil, fmt.Errorf("store: clear model embeddings: %w", err)
+	}
+	if _, err := tx.Exec(`
+		INSERT INTO lore_revisions (lore_id, actor, diff, created_at) VALUES (?, ?, ?, ?)
+	`, id, actor, contentDiff(existing.Content, content), now); err != nil {
+		return nil, fmt.Errorf("store: record revision: %w", err)
+	}
+
+	updated, err := s.getLoreTx(tx, id)
+	if err != nil {
+		return nil, fmt.Errorf("store: read updated lore: %w", err)
+	}
+	payload, err := lorePayloadJSON(updated)
+	if err != nil {
+		return nil, fmt.Errorf("store: marshal change_log payload: %w", err)
+	}
+	if err := s.appendChangeLog(tx, "lore_entries", id, "upsert", payload, s.sourceID); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("store: commit: %w", err)
+	}
+	return updated, nil
+}
+
+// Revisions returns the content edits of lore, oldest first.
+func (s *Store) Revisions(loreID string) ([]Revision, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil, ErrStoreClosed
+	}
+
+	rows, err := s.db.Query(`
+		SELECT actor, diff, created_at FROM lore_revisions WHERE lore_id = ? ORDER BY id ASC
+	`, loreID)
+	if err != nil {
+		return nil, fmt.Errorf("store: query revisions: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var revisions []Revision
+	for rows.Next() {
+		r := Revision{Version: len(revisions) + 2}
+		var createdAt string
+		if err := rows.Scan(&r.Actor, &r.Diff, &createdAt); err != nil {
+			return nil, fmt.Errorf("store: scan revision: %w", err)
+		}
+		r.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+		revisions = append(revisions, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("store: iterate revisions: %w", err)
+	}
+	return revisions, nil
+}
+
+// contentDiff returns a compact word diff from old to new: the words of
+// the longest common subsequence are kept, with at most diffContextWords
+// of them on each side of a change.
+func contentDiff(old, new string) string {
+	a, b := strings.Fields(old), strings.Fields(new)
+
+	// lcs[i][j] is the longest common subsequence of a[i:] and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var parts, same, removed, added []string
+	flush := func() {
+		if len(removed) > 0 {
+			parts = append(parts, "[-"+strings.Join(removed, " ")+"-]")
+		}
+		if len(added) > 0 {
+			parts = append(parts, "{+"+strings.Join(added, " ")+"+}")
+		}
+		removed, added = nil, nil
+	}
+	keep := func(last bool) {
+		first := len(parts) == 0
+		switch {
+		case first && last:
+			// No change at all: nothing to show.
+			same = nil
+			return
+		case first && len(same) > diffContextWords:
+			same = append([]string{"…"}, same[len(same)-diffContextWords:]...)
+		case last && len(same) > diffContextWords:
+			same = append(same[:diffContextWords:diffContextWords], "…")
+		case !first && !last && len(same) > 2*diffContextWords:
+			same = append(append(same[:diffContextWords:diffContextWords], "…"), same[len(same)-diffContextWords:]...)
+		}
+		parts = append(parts, same...)
+		same = nil
+	}
+
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			if len(removed) > 0 || len(added) > 0 {
+				flush()
+			}
+			same = append(same, a[i])
+			i++
+			j++
+		case j < len(b) && (i == len(a) || lcs[i][j+1] >= lcs[i+1][j]):
+			if len(same) > 0 {
+				keep(false)
+			}
+			added = append(added, b[j])
+			j++
+		default:
+			if len(same) > 0 {
+				keep(false)
+			}
+			removed = append(removed, a[i])
+			i++
+		}
+	}
+	flush()
+	if len(same) > 0 {
+		keep(true)
+	}
+	if len(parts) == 0 {
+		return "(whitespace only)"
+	}
+	return strings.Join(parts, " ")
+}
+
+// Update replaces the content of lore in place, for corrections that do
+// not warrant a new entry (see ReplaceOutdated for those). ref may be a
+// lore ID or a session ref. The content is normalized and validated as
+// for Record, and the edit is recorded as a compact diff that History
+// returns. The entry's embedding is cleared until it is re-embedded.
+func (c *Client) Update(ref, content string) (*Lore, error) {
+	loreID, err := c.resolveRef(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	submitted := content
+	content, normalized := c.normalize(content)
+	if content == "" {
+		return nil, &ValidationError{Field: "Content", Message: "cannot be empty"}
+	}
+	if len(content) > MaxContentLength {
+		return nil, &ValidationError{Field: "Content", Message: "exceeds 4000 character limit"}
+	}
+
+	release, err := c.store.enter(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	existing, err := c.store.Get(loreID)
+	if err != nil {
+		return nil, fmt.Errorf("client: update: %w", err)
+	}
+	if err := c.authorize(ActionRecord, existing.Category); err != nil {
+		return nil, err
+	}
+
+	lore, err := c.store.UpdateContent(loreID, content, c.actor())
+	if err != nil {
+		return nil, fmt.Errorf("client: update: %w", err)
+	}
+	c.storeTokenCounts(lore)
+	c.preserveOriginal(lore, submitted, normalized)
+	return lore, nil
+}
+
+// History returns the content edits made to lore with Update, oldest
+// first, each as a compact diff from the version before it. ref may be a
+// lore ID or a session ref.
+func (c *Client) History(ref string) ([]Revision, error) {
+	loreID, err := c.resolveRef(ref)
+	if err != nil {
+		return nil, err
+	}
+	revisions, err := c.store.Revisions(loreID)
+	if err != nil {
+		return nil, fmt.Errorf("client: history: %w", err)
+	}
+	return revisions, nil
+}
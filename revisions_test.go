@@ -0,0 +1,80 @@
+package recall
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestContentDiff(t *testing.T) {
+	tests := []struct {
+		old, new, want string
+	}{
+		{"retry three times", "retry five times", "retry [-three-] {+five+} times"},
+		{"use the v2 client", "use the v2 client with backoff", "… the v2 client {+with backoff+}"},
+		{"drop this prefix then keep the rest", "keep the rest", "[-drop this prefix then-] keep the rest"},
+		{
+			"one two three four five six seven eight nine ten",
+			"one two three four 5 six seven eight nine ten",
+			"… two three four [-five-] {+5+} six seven eight …",
+		},
+		{"a  b", "a b", "(whitespace only)"},
+	}
+	for _, tt := range tests {
+		if got := contentDiff(tt.old, tt.new); got != tt.want {
+			t.Errorf("contentDiff(%q, %q) = %q, want %q", tt.old, tt.new, got, tt.want)
+		}
+	}
+}
+
+func TestClient_UpdateRecordsHistory(t *testing.T) {
+	client := newTestClient(t, Config{SourceID: "alice"})
+
+	lore, err := client.Record("Retry the upload three times", CategoryPatternOutcome)
+	if err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+	if err := client.SetEmbedding(lore.ID, "model-a", []float32{1, 0}); err != nil {
+		t.Fatalf("SetEmbedding() error = %v", err)
+	}
+	before := getChangeLogCount(t, client.store)
+
+	updated, err := client.Update(lore.ID, "Retry the upload five times with backoff")
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if updated.Content != "Retry the upload five times with backoff" || updated.EmbeddingStatus != "pending" || len(updated.Embedding) != 0 {
+		t.Errorf("Update() = %q, embedding %s/%d bytes; want new content with the embedding cleared",
+			updated.Content, updated.EmbeddingStatus, len(updated.Embedding))
+	}
+	var vectors int
+	if err := client.store.db.QueryRow(`SELECT COUNT(*) FROM lore_embeddings WHERE lore_id = ?`, lore.ID).Scan(&vectors); err != nil || vectors != 0 {
+		t.Errorf("model embeddings = %d (%v), want cleared", vectors, err)
+	}
+	if after := getChangeLogCount(t, client.store); after != before+1 {
+		t.Errorf("change_log grew from %d to %d, want one upsert", before, after)
+	}
+
+	// Unchanged content is not a revision.
+	if _, err := client.Update(lore.ID, "Retry the upload five times with backoff"); err != nil {
+		t.Fatalf("Update() unchanged error = %v", err)
+	}
+
+	history, err := client.History(lore.ID)
+	if err != nil {
+		t.Fatalf("History() error = %v", err)
+	}
+	if len(history) != 1 {
+		t.Fatalf("History() = %+v, want one revision", history)
+	}
+	if r := history[0]; r.Version != 2 || r.Actor != "alice" || r.Diff != "Retry the upload [-three-] {+five+} times {+with backoff+}" {
+		t.Errorf("revision = %+v", r)
+	}
+
+	var verr *ValidationError
+	if _, err := client.Update(lore.ID, ""); !errors.As(err, &verr) {
+		t.Errorf("Update() empty content error = %v, want ValidationError", err)
+	}
+	if _, err := client.Update("01MISSING0000000000000000", "content"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Update() missing entry error = %v, want ErrNotFound", err)
+	}
+}
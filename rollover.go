@@ -0,0 +1,364 @@
+package recall
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hyperengineering/recall/internal/store"
+)
+
+// RolloverCheckInterval is how often a client with Config.AutoRollover
+// checks whether its store's period has ended.
+const RolloverCheckInterval = time.Hour
+
+// metadataKeyRollover holds the store's RolloverPolicy as JSON.
+const metadataKeyRollover = "rollover"
+
+// ErrNotRolling is returned by Rollover on a store without a RolloverPolicy.
+var ErrNotRolling = errors.New("store is not rolling")
+
+// RolloverPeriod is the lifetime of a rolling store's entries.
+type RolloverPeriod string
+
+const (
+	RolloverWeekly RolloverPeriod = "weekly" // 7 days
+	RolloverSprint RolloverPeriod = "sprint" // 14 days
+)
+
+// Duration returns the length of the period, or 0 if it is unknown.
+func (p RolloverPeriod) Duration() time.Duration {
+	switch p {
+	case RolloverWeekly:
+		return 7 * 24 * time.Hour
+	case RolloverSprint:
+		return 14 * 24 * time.Hour
+	}
+	return 0
+}
+
+// IsValid reports whether p is a known period.
+func (p RolloverPeriod) IsValid() bool {
+	return p.Duration() > 0
+}
+
+// RolloverPolicy marks a store as rolling: at the end of each period its
+// entries are archived, validated entries are promoted into Target, and the
+// store starts the next period empty.
+type RolloverPolicy struct {
+	Period RolloverPeriod `json:"period"`
+
+	// Target is the ID of the long-term store that receives promoted entries.
+	Target string `json:"target"`
+
+	// MinValidations is how many helpful feedbacks an entry needs to be
+	// promoted. Defaults to 1.
+	MinValidations int `json:"min_validations"`
+
+	// PeriodStart is when the current period began.
+	PeriodStart time.Time `json:"period_start"`
+}
+
+// PeriodEnd returns when the current period ends.
+func (p *RolloverPolicy) PeriodEnd() time.Time {
+	return p.PeriodStart.Add(p.Period.Duration())
+}
+
+// Due reports whether the current period has ended at now.
+func (p *RolloverPolicy) Due(now time.Time) bool {
+	return !now.Before(p.PeriodEnd())
+}
+
+// nextPeriodStart returns the start of the period containing now. Periods
+// stay aligned to the original start, so a store rolled over late does
+// not drift.
+func (p *RolloverPolicy) nextPeriodStart(now time.Time) time.Time {
+	d := p.Period.Duration()
+	if !p.Due(now) {
+		return now
+	}
+	return p.PeriodStart.Add(now.Sub(p.PeriodStart) / d * d)
+}
+
+func (p *RolloverPolicy) validate() error {
+	if !p.Period.IsValid() {
+		return &ValidationError{Field: "Period", Message: fmt.Sprintf("must be %s or %s", RolloverWeekly, RolloverSprint)}
+	}
+	if err := store.ValidateStoreID(p.Target); err != nil {
+		return &ValidationError{Field: "Target", Message: err.Error()}
+	}
+	if p.MinValidations < 0 {
+		return &ValidationError{Field: "MinValidations", Message: "must be non-negative"}
+	}
+	return nil
+}
+
+// RolloverOptions controls Rollover.
+type RolloverOptions struct {
+	// DryRun reports what would be promoted without changing either store.
+	DryRun bool
+
+	// Force rolls over before the period has ended.
+	Force bool
+}
+
+// RolloverResult reports what a rollover did.
+type RolloverResult struct {
+	PeriodStart time.Time `json:"period_start"`
+	PeriodEnd   time.Time `json:"period_end"`
+	Target      string    `json:"target"`
+
+	// Promoted lists entries copied into the target store.
+	Promoted []string `json:"promoted"`
+
+	// Merged lists entries whose content the target store already held.
+	Merged []string `json:"merged"`
+
+	// Archived is how many entries the period held; all are written to
+	// ArchivePath and cleared from the rolling store.
+	Archived    int    `json:"archived"`
+	ArchivePath string `json:"archive_path,omitempty"`
+
+	// Skipped is true when the period had not ended and Force was not set.
+	Skipped bool `json:"skipped,omitempty"`
+	DryRun  bool `json:"dry_run,omitempty"`
+}
+
+// RolloverPolicy returns the store's rollover policy, or nil if the store
+// is not rolling.
+func (s *Store) RolloverPolicy() (*RolloverPolicy, error) {
+	raw, err := s.GetMetadata(metadataKeyRollover)
+	if err != nil || raw == "" {
+		return nil, err
+	}
+	var p RolloverPolicy
+	if err := json.Unmarshal([]byte(raw), &p); err != nil {
+		return nil, fmt.Errorf("store: rollover policy: %w", err)
+	}
+	return &p, nil
+}
+
+// SetRolloverPolicy marks the store as rolling. A zero PeriodStart starts
+// the first period now. Nil makes the store permanent again.
+func (s *Store) SetRolloverPolicy(p *RolloverPolicy) error {
+	if p == nil {
+		return s.SetMetadata(metadataKeyRollover, "")
+	}
+	policy := *p
+	if err := policy.validate(); err != nil {
+		return err
+	}
+	if policy.MinValidations == 0 {
+		policy.MinValidations = 1
+	}
+	if policy.PeriodStart.IsZero() {
+		policy.PeriodStart = s.now()
+	}
+	data, err := json.Marshal(policy)
+	if err != nil {
+		return fmt.Errorf("store: rollover policy: %w", err)
+	}
+	return s.SetMetadata(metadataKeyRollover, string(data))
+}
+
+// Rollover ends the current period of a rolling store's namespace. It
+// writes every entry to an archive file beside the database, promotes
+// entries with at least MinValidations into target (entries whose content
+// target already holds are merged, not duplicated), clears the namespace
+// and starts the next period.
+//
+// Steps run in that order, so a rollover interrupted partway can be
+// re-run: promotions already made are found again as merges.
+func (s *Store) Rollover(ctx context.Context, storeID string, target *Store, opts RolloverOptions) (*RolloverResult, error) {
+	policy, err := s.RolloverPolicy()
+	if err != nil {
+		return nil, err
+	}
+	if policy == nil {
+		return nil, ErrNotRolling
+	}
+
+	now := s.now()
+	result := &RolloverResult{
+		PeriodStart: policy.PeriodStart,
+		PeriodEnd:   policy.PeriodEnd(),
+		Target:      policy.Target,
+		Promoted:    []string{},
+		Merged:      []string{},
+		DryRun:      opts.DryRun,
+	}
+	if !policy.Due(now) && !opts.Force {
+		result.Skipped = true
+		return result, nil
+	}
+
+	entries, err := s.Query(QueryParams{})
+	if err != nil {
+		return nil, err
+	}
+	result.Archived = len(entries)
+
+	if opts.DryRun {
+		for _, l := range entries {
+			if l.ValidationCount >= policy.MinValidations {
+				result.Promoted = append(result.Promoted, l.ID)
+			}
+		}
+		return result, nil
+	}
+
+	if len(entries) > 0 {
+		result.ArchivePath = filepath.Join(filepath.Dir(s.path), "archive", filepath.FromSlash(s.Namespace()),
+			policy.PeriodStart.UTC().Format("20060102T150405Z")+".json")
+		if err := s.archive(ctx, storeID, result.ArchivePath); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, l := range entries {
+		if l.ValidationCount < policy.MinValidations {
+			continue
+		}
+		promoted := l
+		existing, err := target.InsertLoreUnique(&promoted)
+		if err != nil {
+			return nil, fmt.Errorf("store: promote %s: %w", l.ID, err)
+		}
+		if existing != nil {
+			result.Merged = append(result.Merged, l.ID)
+		} else {
+			result.Promoted = append(result.Promoted, l.ID)
+		}
+	}
+
+	for _, l := range entries {
+		if err := s.DeleteLoreByID(l.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	policy.PeriodStart = policy.nextPeriodStart(now)
+	if err := s.SetRolloverPolicy(policy); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// archive exports the store's current namespace to path.
+func (s *Store) archive(ctx context.Context, storeID, path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("store: archive: %w", err)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("store: archive: %w", err)
+	}
+	if err := s.ExportJSON(ctx, storeID, f); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("store: archive: %w", err)
+	}
+	return nil
+}
+
+// Rollover ends the current period of the client's rolling store,
+// promoting validated entries into the policy's target store. It does
+// nothing (Skipped) before the period ends unless opts.Force is set.
+// Requires admin under an access policy.
+func (c *Client) Rollover(ctx context.Context, opts RolloverOptions) (*RolloverResult, error) {
+	if err := c.authorize(ActionRollover, ""); err != nil {
+		return nil, err
+	}
+	policy, err := c.store.RolloverPolicy()
+	if err != nil {
+		return nil, fmt.Errorf("client: rollover: %w", err)
+	}
+	if policy == nil {
+		return nil, fmt.Errorf("client: rollover: %w", ErrNotRolling)
+	}
+	if policy.Target == c.config.Store {
+		return nil, &ValidationError{Field: "Target", Message: "a store cannot roll over into itself"}
+	}
+
+	targetPath := store.StoreDBPath(policy.Target)
+	if err := os.MkdirAll(filepath.Dir(targetPath), 0755); err != nil {
+		return nil, fmt.Errorf("client: rollover: %w", err)
+	}
+	target, err := NewStore(targetPath)
+	if err != nil {
+		return nil, fmt.Errorf("client: rollover: %w", err)
+	}
+	defer func() { _ = target.Close() }()
+	if err := target.SetNamespace(c.config.Namespace); err != nil {
+		return nil, fmt.Errorf("client: rollover: %w", err)
+	}
+
+	result, err := c.store.Rollover(ctx, c.config.Store, target, opts)
+	if err != nil {
+		return nil, fmt.Errorf("client: rollover: %w", err)
+	}
+	return result, nil
+}
+
+// rolloverIfDue rolls the store over if it is rolling and its period has
+// ended. Failures are logged; the next check retries.
+func (c *Client) rolloverIfDue() {
+	policy, err := c.store.RolloverPolicy()
+	if err != nil || policy == nil || !policy.Due(c.store.now()) {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	if _, err := c.Rollover(ctx, RolloverOptions{}); err != nil {
+		c.debug.LogError("rollover", err)
+	}
+}
+
+// rolloverLoop checks for the end of the period every
+// RolloverCheckInterval until the client closes.
+func (c *Client) rolloverLoop() {
+	defer close(c.rolloverDone)
+
+	ticker := time.NewTicker(RolloverCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.stopSync:
+			return
+		case <-ticker.C:
+			c.rolloverIfDue()
+		}
+	}
+}
+
+// SetRolloverPolicy marks the client's store as rolling, or permanent
+// again with nil. Requires admin under an access policy.
+func (c *Client) SetRolloverPolicy(p *RolloverPolicy) error {
+	if err := c.authorize(ActionRollover, ""); err != nil {
+		return err
+	}
+	if p != nil && p.Target == c.config.Store {
+		return &ValidationError{Field: "Target", Message: "a store cannot roll over into itself"}
+	}
+	if err := c.store.SetRolloverPolicy(p); err != nil {
+		return fmt.Errorf("client: %w", err)
+	}
+	return nil
+}
+
+// RolloverPolicy returns the client's store rollover policy, or nil if
+// the store is not rolling.
+func (c *Client) RolloverPolicy() (*RolloverPolicy, error) {
+	p, err := c.store.RolloverPolicy()
+	if err != nil {
+		return nil, fmt.Errorf("client: %w", err)
+	}
+	return p, nil
+}
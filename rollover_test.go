@@ -0,0 +1,212 @@
+package recall
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hyperengineering/recall/internal/store"
+)
+
+func insertRolloverLore(t *testing.T, s *Store, id, content string, validations int) {
+	t.Helper()
+	now := time.Now().UTC()
+	err := s.InsertLore(&Lore{
+		ID: id, Content: content, Category: CategoryPatternOutcome, Confidence: 0.7,
+		SourceID: "test", ValidationCount: validations, CreatedAt: now, UpdatedAt: now,
+	})
+	if err != nil {
+		t.Fatalf("InsertLore() error = %v", err)
+	}
+}
+
+func TestStore_SetRolloverPolicy(t *testing.T) {
+	s := newTestStore(t)
+
+	if p, err := s.RolloverPolicy(); err != nil || p != nil {
+		t.Fatalf("RolloverPolicy() = %v, %v; want nil for a new store", p, err)
+	}
+
+	for _, bad := range []RolloverPolicy{
+		{Period: "monthly", Target: "default"},
+		{Period: RolloverWeekly, Target: "Not Valid"},
+		{Period: RolloverWeekly, Target: "default", MinValidations: -1},
+	} {
+		var verr *ValidationError
+		if err := s.SetRolloverPolicy(&bad); !errors.As(err, &verr) {
+			t.Errorf("SetRolloverPolicy(%+v) error = %v, want ValidationError", bad, err)
+		}
+	}
+
+	if err := s.SetRolloverPolicy(&RolloverPolicy{Period: RolloverSprint, Target: "default"}); err != nil {
+		t.Fatalf("SetRolloverPolicy() error = %v", err)
+	}
+	p, err := s.RolloverPolicy()
+	if err != nil || p == nil {
+		t.Fatalf("RolloverPolicy() = %v, %v", p, err)
+	}
+	if p.MinValidations != 1 || p.PeriodStart.IsZero() {
+		t.Errorf("policy = %+v, want MinValidations 1 and PeriodStart set", p)
+	}
+	if got := p.PeriodEnd().Sub(p.PeriodStart); got != 14*24*time.Hour {
+		t.Errorf("sprint period = %v, want 14 days", got)
+	}
+
+	if err := s.SetRolloverPolicy(nil); err != nil {
+		t.Fatalf("SetRolloverPolicy(nil) error = %v", err)
+	}
+	if p, _ := s.RolloverPolicy(); p != nil {
+		t.Errorf("RolloverPolicy() = %+v after clearing, want nil", p)
+	}
+}
+
+func TestStore_Rollover_NotRolling(t *testing.T) {
+	s := newTestStore(t)
+	if _, err := s.Rollover(context.Background(), "sprint", newTestStore(t), RolloverOptions{}); !errors.Is(err, ErrNotRolling) {
+		t.Errorf("Rollover() error = %v, want ErrNotRolling", err)
+	}
+}
+
+func TestStore_Rollover_SkipsBeforePeriodEnd(t *testing.T) {
+	s := newTestStore(t)
+	target := newTestStore(t)
+	insertRolloverLore(t, s, "a", "validated insight", 1)
+	if err := s.SetRolloverPolicy(&RolloverPolicy{Period: RolloverWeekly, Target: "default"}); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := s.Rollover(context.Background(), "sprint", target, RolloverOptions{})
+	if err != nil {
+		t.Fatalf("Rollover() error = %v", err)
+	}
+	if !result.Skipped {
+		t.Error("Rollover() before the period ends should be skipped")
+	}
+	if n, _ := s.LoreCount(); n != 1 {
+		t.Errorf("rolling store has %d entries, want 1", n)
+	}
+}
+
+func TestStore_Rollover_PromotesValidatedEntries(t *testing.T) {
+	s := newTestStore(t)
+	target := newTestStore(t)
+
+	insertRolloverLore(t, s, "validated", "Retry idempotent calls with jitter", 2)
+	insertRolloverLore(t, s, "unvalidated", "Maybe use a bigger pool", 0)
+	insertRolloverLore(t, s, "known", "Pin the linter version", 1)
+	insertRolloverLore(t, target, "existing", "pin the linter version.", 3)
+
+	start := time.Now().UTC().Add(-17 * 24 * time.Hour)
+	if err := s.SetRolloverPolicy(&RolloverPolicy{Period: RolloverWeekly, Target: "default", PeriodStart: start}); err != nil {
+		t.Fatal(err)
+	}
+
+	dry, err := s.Rollover(context.Background(), "sprint", target, RolloverOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("Rollover(dry run) error = %v", err)
+	}
+	if len(dry.Promoted) != 2 || dry.Archived != 3 {
+		t.Errorf("dry run = %+v, want 2 promotable of 3", dry)
+	}
+	if n, _ := s.LoreCount(); n != 3 {
+		t.Fatalf("dry run changed the rolling store: %d entries", n)
+	}
+
+	result, err := s.Rollover(context.Background(), "sprint", target, RolloverOptions{})
+	if err != nil {
+		t.Fatalf("Rollover() error = %v", err)
+	}
+	if len(result.Promoted) != 1 || result.Promoted[0] != "validated" {
+		t.Errorf("Promoted = %v, want [validated]", result.Promoted)
+	}
+	if len(result.Merged) != 1 || result.Merged[0] != "known" {
+		t.Errorf("Merged = %v, want [known]", result.Merged)
+	}
+	if result.Archived != 3 {
+		t.Errorf("Archived = %d, want 3", result.Archived)
+	}
+
+	if n, _ := s.LoreCount(); n != 0 {
+		t.Errorf("rolling store has %d entries after rollover, want 0", n)
+	}
+	if n, _ := target.LoreCount(); n != 2 {
+		t.Errorf("target store has %d entries, want 2", n)
+	}
+	promoted, err := target.Get("validated")
+	if err != nil {
+		t.Fatalf("target.Get(validated) error = %v", err)
+	}
+	if promoted.ValidationCount != 2 {
+		t.Errorf("promoted ValidationCount = %d, want 2", promoted.ValidationCount)
+	}
+
+	data, err := os.ReadFile(result.ArchivePath)
+	if err != nil {
+		t.Fatalf("read archive: %v", err)
+	}
+	var archive ExportFormat
+	if err := json.Unmarshal(data, &archive); err != nil {
+		t.Fatalf("parse archive: %v", err)
+	}
+	if len(archive.Lore) != 3 {
+		t.Errorf("archive holds %d entries, want 3", len(archive.Lore))
+	}
+
+	// Two whole weeks have passed, so the next period starts 14 days in.
+	p, _ := s.RolloverPolicy()
+	if want := start.Add(14 * 24 * time.Hour); !p.PeriodStart.Equal(want) {
+		t.Errorf("PeriodStart = %v, want %v", p.PeriodStart, want)
+	}
+	if p.Due(time.Now()) {
+		t.Error("new period should not be due yet")
+	}
+}
+
+func TestClient_Rollover(t *testing.T) {
+	t.Setenv("RECALL_HOME", t.TempDir())
+
+	sprintPath := store.StoreDBPath("sprint")
+	if err := os.MkdirAll(filepath.Dir(sprintPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	s, err := NewStore(sprintPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	insertRolloverLore(t, s, "a", "Validated sprint insight", 1)
+	err = s.SetRolloverPolicy(&RolloverPolicy{
+		Period: RolloverWeekly, Target: "long-term", PeriodStart: time.Now().Add(-8 * 24 * time.Hour),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = s.Close()
+
+	// Opening the client with AutoRollover rolls the overdue period over.
+	client, err := New(Config{Store: "sprint", AutoRollover: true})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	if n, _ := client.store.LoreCount(); n != 0 {
+		t.Errorf("sprint store has %d entries, want 0", n)
+	}
+	target, err := NewStore(store.StoreDBPath("long-term"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = target.Close() }()
+	if _, err := target.Get("a"); err != nil {
+		t.Errorf("long-term store missing promoted entry: %v", err)
+	}
+
+	var verr *ValidationError
+	if err := client.SetRolloverPolicy(&RolloverPolicy{Period: RolloverWeekly, Target: "sprint"}); !errors.As(err, &verr) {
+		t.Errorf("SetRolloverPolicy(self) error = %v, want ValidationError", err)
+	}
+}
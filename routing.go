@@ -0,0 +1,88 @@
+package recall
+
+import "sort"
+
+// Category routing: a query whose embedding is clearly closest to one
+// category's centroid boosts that category's lore.
+const (
+	// CategoryRoutingBoost is the extra weight given to lore in the routed
+	// category: its score is multiplied by 1 + CategoryRoutingBoost.
+	CategoryRoutingBoost = 0.15
+
+	// categoryRoutingMargin is how much closer the query must be to the
+	// nearest centroid than to the runner-up for the routing to be clear.
+	categoryRoutingMargin = 0.05
+
+	// categoryRoutingMinEntries is the fewest embedded entries a category
+	// needs for its centroid to count.
+	categoryRoutingMinEntries = 3
+)
+
+// routeCategory returns the category whose centroid (the mean of its
+// normalized embeddings among the candidates) is clearly nearest to the
+// query. At least two categories must have centroids.
+func routeCategory(query []float32, candidates []CandidateLore, loreByID map[string]Lore) (Category, bool) {
+	sums := make(map[Category][]float32)
+	counts := make(map[Category]int)
+	for _, cand := range candidates {
+		if len(cand.Embedding) != len(query) {
+			continue
+		}
+		cat := loreByID[cand.ID].Category
+		sum := sums[cat]
+		if sum == nil {
+			sum = make([]float32, len(query))
+			sums[cat] = sum
+		}
+		for i, x := range NormalizeEmbedding(cand.Embedding) {
+			sum[i] += x
+		}
+		counts[cat]++
+	}
+
+	type centroidScore struct {
+		category Category
+		score    float32
+	}
+	var scores []centroidScore
+	for cat, sum := range sums {
+		if counts[cat] >= categoryRoutingMinEntries {
+			scores = append(scores, centroidScore{cat, CosineSimilarity(query, sum)})
+		}
+	}
+	if len(scores) < 2 {
+		return "", false
+	}
+	sort.Slice(scores, func(i, j int) bool { return scores[i].score > scores[j].score })
+	if scores[0].score-scores[1].score < categoryRoutingMargin {
+		return "", false
+	}
+	return scores[0].category, true
+}
+
+// routingEnabled reports whether params may be routed: an embedding is
+// given, the caller did not choose categories, and routing is not disabled
+// in the query or the config.
+func (c *Client) routingEnabled(params QueryParams) bool {
+	return len(params.QueryEmbedding) > 0 && len(params.Categories) == 0 &&
+		len(params.CategoryQuotas) == 0 && !params.NoRouting && !c.config.DisableCategoryRouting
+}
+
+// categoryWeight returns the ranking multiplier of cat under routing.
+func categoryWeight(cat, routed Category) float64 {
+	if routed != "" && cat == routed {
+		return 1 + CategoryRoutingBoost
+	}
+	return 1.0
+}
+
+// weightByCategory boosts scores in the routed category and re-sorts.
+func weightByCategory(scored []ScoredLore, loreByID map[string]Lore, routed Category) {
+	if routed == "" {
+		return
+	}
+	for i := range scored {
+		scored[i].Score *= categoryWeight(loreByID[scored[i].ID].Category, routed)
+	}
+	sort.SliceStable(scored, func(i, j int) bool { return scored[i].Score > scored[j].Score })
+}
@@ -0,0 +1,120 @@
+package recall
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// newRoutingTestClient inserts three TESTING_STRATEGY entries along the
+// first axis and three PATTERN_OUTCOME entries along the second, plus a
+// PATTERN_OUTCOME entry that leans slightly toward the first axis.
+func newRoutingTestClient(t *testing.T, cfg Config) *Client {
+	t.Helper()
+	client := newTestClient(t, cfg)
+
+	now := time.Now().UTC()
+	insert := func(id string, cat Category, emb []float32) {
+		l := &Lore{ID: id, Content: id, Category: cat, Confidence: 0.8, SourceID: "s",
+			Embedding: PackFloat32(emb), CreatedAt: now, UpdatedAt: now}
+		if err := client.store.InsertLore(l); err != nil {
+			t.Fatalf("InsertLore() error = %v", err)
+		}
+	}
+	for i := 0; i < 3; i++ {
+		insert(fmt.Sprintf("test-%d", i), CategoryTestingStrategy, []float32{0.9, 0.1, float32(i) * 0.1})
+		insert(fmt.Sprintf("pattern-%d", i), CategoryPatternOutcome, []float32{0.1, 0.9, float32(i) * 0.1})
+	}
+	insert("pattern-near", CategoryPatternOutcome, []float32{0.95, 0.2, 0})
+	return client
+}
+
+func TestQuery_RoutesToNearestCategory(t *testing.T) {
+	client := newRoutingTestClient(t, Config{})
+
+	result, err := client.Query(context.Background(), QueryParams{
+		Query:          "how should we test this",
+		QueryEmbedding: []float32{0.8, 0.2, 0},
+		K:              1,
+		Explain:        true,
+	})
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+	if result.Stats.RoutedCategory != CategoryTestingStrategy {
+		t.Fatalf("RoutedCategory = %q, want TESTING_STRATEGY", result.Stats.RoutedCategory)
+	}
+	// pattern-near has the best raw similarity; the boost lifts a testing entry past it.
+	if len(result.Lore) != 1 || result.Lore[0].Category != CategoryTestingStrategy {
+		t.Fatalf("Query() = %v, want a TESTING_STRATEGY entry", result.Lore)
+	}
+	e := result.Explanations[result.Lore[0].ID]
+	if e.CategoryWeight != 1+CategoryRoutingBoost || e.Similarity == nil {
+		t.Fatalf("explanation = %+v, want boosted weight", e)
+	}
+	if want := *e.Similarity * e.CategoryWeight; e.Score < want-1e-9 || e.Score > want+1e-9 {
+		t.Errorf("Score = %v, want %v", e.Score, want)
+	}
+}
+
+func TestQuery_RoutingOptOut(t *testing.T) {
+	tests := []struct {
+		name   string
+		cfg    Config
+		params QueryParams
+	}{
+		{"NoRouting", Config{}, QueryParams{NoRouting: true}},
+		{"DisableCategoryRouting", Config{DisableCategoryRouting: true}, QueryParams{}},
+		{"explicit categories", Config{}, QueryParams{Categories: []Category{CategoryTestingStrategy, CategoryPatternOutcome}}},
+		{"category quotas", Config{}, QueryParams{CategoryQuotas: map[Category]int{CategoryPatternOutcome: 1}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newRoutingTestClient(t, tt.cfg)
+			params := tt.params
+			params.Query = "q"
+			params.QueryEmbedding = []float32{0.8, 0.2, 0}
+			params.K = 1
+			params.Explain = true
+
+			result, err := client.Query(context.Background(), params)
+			if err != nil {
+				t.Fatalf("Query() error = %v", err)
+			}
+			if result.Stats.RoutedCategory != "" {
+				t.Errorf("RoutedCategory = %q, want none", result.Stats.RoutedCategory)
+			}
+			if len(result.Lore) != 1 || result.Lore[0].ID != "pattern-near" {
+				t.Fatalf("Query() = %v, want pattern-near by raw similarity", result.Lore)
+			}
+			if e := result.Explanations["pattern-near"]; e.CategoryWeight != 1 {
+				t.Errorf("CategoryWeight = %v, want 1", e.CategoryWeight)
+			}
+		})
+	}
+}
+
+func TestRouteCategory(t *testing.T) {
+	lore := map[string]Lore{}
+	var candidates []CandidateLore
+	add := func(id string, cat Category, emb []float32) {
+		lore[id] = Lore{ID: id, Category: cat}
+		candidates = append(candidates, CandidateLore{ID: id, Embedding: emb})
+	}
+	for i := 0; i < 3; i++ {
+		add(fmt.Sprintf("t%d", i), CategoryTestingStrategy, []float32{1, 0})
+		add(fmt.Sprintf("p%d", i), CategoryPatternOutcome, []float32{0, 1})
+	}
+	add("e0", CategoryEdgeCaseDiscovery, []float32{1, 0}) // too few to count
+
+	if cat, ok := routeCategory([]float32{1, 0.1}, candidates, lore); !ok || cat != CategoryTestingStrategy {
+		t.Errorf("routeCategory(near testing) = %q, %v", cat, ok)
+	}
+	if _, ok := routeCategory([]float32{1, 1}, candidates, lore); ok {
+		t.Error("routeCategory(equidistant) routed, want no clear category")
+	}
+	if _, ok := routeCategory([]float32{1, 0}, candidates[:3], lore); ok {
+		t.Error("routeCategory(one category) routed, want at least two")
+	}
+}
@@ -0,0 +1,77 @@
+package recall
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// samplePoolFactor sizes the pool a sampled query draws from: the top
+// samplePoolFactor×K of the ranking, so sampling reaches past the head
+// without surfacing entries that barely match.
+const samplePoolFactor = 4
+
+// validateSampling checks params.Temperature.
+func validateSampling(params QueryParams) error {
+	if params.Temperature < 0 || math.IsInf(params.Temperature, 0) || math.IsNaN(params.Temperature) {
+		return &ValidationError{Field: "Temperature", Message: "must be a non-negative number"}
+	}
+	return nil
+}
+
+// sampleScore is the score an entry is sampled by: its confidence, times
+// its similarity to the query when it was ranked by similarity.
+func sampleScore(l Lore, similarity map[string]float64) float64 {
+	score := l.Confidence
+	if s, ok := similarity[l.ID]; ok {
+		score *= max(s, 0)
+	}
+	return score
+}
+
+// sampleLore reorders lore into a random order weighted by score, softmax
+// at temperature: an entry is drawn next in proportion to
+// exp(score/temperature) among those not yet drawn. Low temperatures stay
+// close to the score order; high ones approach a uniform shuffle. Ties in
+// score keep no order.
+func sampleLore(lore []Lore, similarity map[string]float64, temperature float64, rng *rand.Rand) []Lore {
+	if len(lore) < 2 {
+		return lore
+	}
+	scores := make([]float64, len(lore))
+	top := math.Inf(-1)
+	for i, l := range lore {
+		scores[i] = sampleScore(l, similarity)
+		top = max(top, scores[i])
+	}
+
+	// Weighted sampling without replacement (Efraimidis–Spirakis): sort by
+	// u^(1/w), compared as log(u)/w to keep tiny weights finite.
+	keys := make([]float64, len(lore))
+	for i := range lore {
+		weight := math.Exp((scores[i] - top) / temperature)
+		keys[i] = math.Log(1-rng.Float64()) / max(weight, math.SmallestNonzeroFloat64)
+	}
+	order := make([]int, len(lore))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return keys[order[a]] > keys[order[b]] })
+
+	sampled := make([]Lore, len(lore))
+	for i, j := range order {
+		sampled[i] = lore[j]
+	}
+	return sampled
+}
+
+// sampleRand returns the random source for a sampled query: seeded by
+// params.Seed, or by the clock when it is zero.
+func sampleRand(params QueryParams) *rand.Rand {
+	seed := params.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return rand.New(rand.NewSource(seed))
+}
@@ -0,0 +1,110 @@
+package recall
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+	"time"
+)
+
+func newSamplingClient(t *testing.T) *Client {
+	t.Helper()
+	client := newTestClient(t, Config{})
+
+	// s00 is the most similar to the query, s15 the least
+	now := time.Now().UTC()
+	for i := range 16 {
+		l := Lore{
+			ID: fmt.Sprintf("s%02d", i), Content: fmt.Sprintf("Lore %d", i), Category: CategoryPatternOutcome,
+			Confidence: 0.9, Embedding: PackFloat32([]float32{1, 0.15 * float32(i)}),
+			SourceID: "test", CreatedAt: now, UpdatedAt: now,
+		}
+		if err := client.store.InsertLore(&l); err != nil {
+			t.Fatalf("InsertLore: %v", err)
+		}
+	}
+	return client
+}
+
+func TestClient_Query_Temperature(t *testing.T) {
+	client := newSamplingClient(t)
+	ctx := context.Background()
+	query := func(temperature float64, seed int64) *QueryResult {
+		t.Helper()
+		result, err := client.Query(ctx, QueryParams{
+			QueryEmbedding: []float32{1, 0}, K: 3, Temperature: temperature, Seed: seed,
+		})
+		if err != nil {
+			t.Fatalf("Query: %v", err)
+		}
+		return result
+	}
+
+	cold := query(0.001, 1)
+	if got := loreIDs(cold.Lore); !slices.Equal(got, []string{"s00", "s01", "s02"}) {
+		t.Errorf("near-zero temperature = %v, want the top 3", got)
+	}
+	if cold.Stats.SampledFrom != 12 {
+		t.Errorf("SampledFrom = %d, want a pool of 4×K", cold.Stats.SampledFrom)
+	}
+	if len(cold.SessionRefs) != 3 {
+		t.Errorf("SessionRefs = %v, want refs for feedback", cold.SessionRefs)
+	}
+
+	seen := make(map[string]bool)
+	for seed := int64(1); seed <= 30; seed++ {
+		result := query(10, seed)
+		if len(result.Lore) != 3 {
+			t.Fatalf("seed %d returned %d results, want 3", seed, len(result.Lore))
+		}
+		for _, l := range result.Lore {
+			seen[l.ID] = true
+		}
+		if again := query(10, seed); !slices.Equal(loreIDs(again.Lore), loreIDs(result.Lore)) {
+			t.Errorf("seed %d drew %v then %v, want the same draw", seed, loreIDs(result.Lore), loreIDs(again.Lore))
+		}
+	}
+	if len(seen) <= 3 {
+		t.Errorf("high temperature only surfaced %v", seen)
+	}
+	for _, id := range []string{"s12", "s13", "s14", "s15"} {
+		if seen[id] {
+			t.Errorf("%s is outside the pool but was sampled", id)
+		}
+	}
+
+	if top := query(0, 0); top.Stats.SampledFrom != 0 || !slices.Equal(loreIDs(top.Lore), []string{"s00", "s01", "s02"}) {
+		t.Errorf("zero temperature = %v (sampled from %d), want the plain top 3", loreIDs(top.Lore), top.Stats.SampledFrom)
+	}
+
+	_, err := client.Query(ctx, QueryParams{QueryEmbedding: []float32{1, 0}, Temperature: -1})
+	var ve *ValidationError
+	if !errors.As(err, &ve) || ve.Field != "Temperature" {
+		t.Errorf("negative temperature: err = %v, want a Temperature ValidationError", err)
+	}
+}
+
+func TestSampleLore_WeightsByConfidence(t *testing.T) {
+	lore := []Lore{{ID: "trusted", Confidence: 0.9}, {ID: "new", Confidence: 0.1}}
+	rng := rand.New(rand.NewSource(7))
+
+	firsts := func(temperature float64) int {
+		n := 0
+		for range 1000 {
+			if sampleLore(lore, nil, temperature, rng)[0].ID == "trusted" {
+				n++
+			}
+		}
+		return n
+	}
+	// exp(0.8/0.1) ≈ 3000:1 at 0.1; exp(0.8/10) ≈ 1.08:1 at 10
+	if n := firsts(0.1); n < 990 {
+		t.Errorf("at 0.1 the trusted entry led %d/1000 draws, want nearly all", n)
+	}
+	if n := firsts(10); n < 420 || n > 620 {
+		t.Errorf("at 10 the trusted entry led %d/1000 draws, want about half", n)
+	}
+}
@@ -0,0 +1,547 @@
+// Package schema describes the tables of a Recall store, the JSON payloads
+// carried in its change_log, and the lore category enum, for tools that
+// read stores or sync traffic directly (ETL jobs, validators, dashboards).
+//
+// The descriptions are Go values, and each payload also has a JSON Schema:
+//
+//	for _, t := range schema.Tables {
+//		fmt.Println(t.Name, len(t.Columns))
+//	}
+//	validate(schema.LorePayloadSchema, payload)
+//
+// The package has no dependencies. Its tests check it against a store
+// created by the recall package, so it changes with each migration;
+// MigrationVersion is the goose version it describes.
+package schema
+
+import (
+	_ "embed"
+	"encoding/json"
+)
+
+// MigrationVersion is the store schema version (the latest goose
+// migration) these descriptions match.
+const MigrationVersion = 33
+
+// Column describes a table or view column. Type is SQLite's declared type,
+// empty for computed view columns.
+type Column struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	NotNull     bool   `json:"not_null,omitempty"`
+	PrimaryKey  bool   `json:"primary_key,omitempty"`
+	Default     string `json:"default,omitempty"` // SQL expression, e.g. '[]' or datetime('now')
+	Description string `json:"description,omitempty"`
+}
+
+// Table describes a store table. Synced tables reach Engram through the
+// change_log; the rest are local to one store file.
+type Table struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Synced      bool     `json:"synced,omitempty"`
+	Columns     []Column `json:"columns"`
+}
+
+// View describes a read-only introspection view. Views are a stable
+// contract for dashboards; columns are only ever added.
+type View struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Columns     []Column `json:"columns"`
+}
+
+// Categories is the lore category enum, in the order recall lists it.
+var Categories = []string{
+	"ARCHITECTURAL_DECISION",
+	"PATTERN_OUTCOME",
+	"INTERFACE_LESSON",
+	"EDGE_CASE_DISCOVERY",
+	"IMPLEMENTATION_FRICTION",
+	"TESTING_STRATEGY",
+	"DEPENDENCY_BEHAVIOR",
+	"PERFORMANCE_INSIGHT",
+}
+
+// ChangeLogOperations are the values of change_log.operation. Deletes
+// carry a null payload.
+var ChangeLogOperations = []string{"upsert", "delete"}
+
+// JSON Schemas (draft 2020-12) for change_log payloads and the category
+// enum. The payloads are also the entries of sync push and delta requests.
+var (
+	//go:embed lore_payload.schema.json
+	LorePayloadSchema []byte
+
+	//go:embed attachment_payload.schema.json
+	AttachmentPayloadSchema []byte
+
+	//go:embed category.schema.json
+	CategorySchema []byte
+)
+
+// PayloadSchemas maps each change_log table_name to the JSON Schema of its
+// upsert payloads.
+var PayloadSchemas = map[string][]byte{
+	"lore_entries":     LorePayloadSchema,
+	"lore_attachments": AttachmentPayloadSchema,
+}
+
+// Document is the full description, as written by JSON.
+type Document struct {
+	MigrationVersion    int                        `json:"migration_version"`
+	Categories          []string                   `json:"categories"`
+	ChangeLogOperations []string                   `json:"change_log_operations"`
+	Tables              []Table                    `json:"tables"`
+	Views               []View                     `json:"views"`
+	PayloadSchemas      map[string]json.RawMessage `json:"payload_schemas"`
+}
+
+// JSON returns the full description as indented JSON, for tools that are
+// not written in Go.
+func JSON() ([]byte, error) {
+	doc := Document{
+		MigrationVersion:    MigrationVersion,
+		Categories:          Categories,
+		ChangeLogOperations: ChangeLogOperations,
+		Tables:              Tables,
+		Views:               Views,
+		PayloadSchemas:      make(map[string]json.RawMessage, len(PayloadSchemas)),
+	}
+	for table, s := range PayloadSchemas {
+		doc.PayloadSchemas[table] = s
+	}
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// TableNamed returns the table named name, or nil.
+func TableNamed(name string) *Table {
+	for i := range Tables {
+		if Tables[i].Name == name {
+			return &Tables[i]
+		}
+	}
+	return nil
+}
+
+// Tables describes every table of a store except goose's version table
+// and the shadow tables SQLite keeps for lore_fts.
+var Tables = []Table{
+	{
+		Name:        "lore_entries",
+		Description: "Lore, one row per entry. Soft-deleted rows keep deleted_at.",
+		Synced:      true,
+		Columns: []Column{
+			{Name: "id", Type: "TEXT", PrimaryKey: true, Description: "ULID"},
+			{Name: "content", Type: "TEXT", NotNull: true, Description: "At most 4000 characters"},
+			{Name: "context", Type: "TEXT", Description: "At most 1000 characters"},
+			{Name: "category", Type: "TEXT", NotNull: true, Description: "One of Categories"},
+			{Name: "confidence", Type: "REAL", NotNull: true, Default: "0.5", Description: "0.0 to 1.0"},
+			{Name: "embedding", Type: "BLOB", Description: "Little-endian float32 vector"},
+			{Name: "embedding_status", Type: "TEXT", NotNull: true, Default: "'complete'", Description: "pending until embedded, then complete"},
+			{Name: "source_id", Type: "TEXT", NotNull: true, Description: "Client that recorded the entry"},
+			{Name: "sources", Type: "TEXT", NotNull: true, Default: "'[]'", Description: "Comma-separated origins, or '[]'"},
+			{Name: "validation_count", Type: "INTEGER", NotNull: true, Default: "0"},
+			{Name: "created_at", Type: "TEXT", NotNull: true, Description: "RFC 3339, UTC"},
+			{Name: "updated_at", Type: "TEXT", NotNull: true, Description: "RFC 3339, UTC"},
+			{Name: "deleted_at", Type: "TEXT", Description: "RFC 3339, UTC; set when soft-deleted"},
+			{Name: "last_validated_at", Type: "TEXT", Description: "RFC 3339, UTC"},
+			{Name: "synced_at", Type: "TEXT", Description: "Last pushed by this client; null for lore received from Engram"},
+			{Name: "usage_count", Type: "INTEGER", NotNull: true, Default: "0", Description: "Local: times returned by a query"},
+			{Name: "last_used_at", Type: "TEXT", Description: "Local: last returned by a query"},
+			{Name: "namespace", Type: "TEXT", NotNull: true, Default: "''", Description: "Empty for the default namespace"},
+			{Name: "fingerprint", Type: "TEXT", NotNull: true, Default: "''", Description: "Hash of the normalized content, for exact deduplication"},
+			{Name: "confidence_updated_at", Type: "TEXT", Description: "Last local confidence change, for conflict detection"},
+			{Name: "checksum", Type: "TEXT", NotNull: true, Default: "''", Description: "Local: SHA-256 of id, content, context and category, verified on read"},
+			{Name: "dedup_fingerprint", Type: "TEXT", Description: "Local: fingerprint claimed by a deduplicating Record; unique among live entries of a namespace"},
+		},
+	},
+	{
+		Name:        "change_log",
+		Description: "Append-only log of synced mutations; push sends entries after the last pushed sequence.",
+		Columns: []Column{
+			{Name: "sequence", Type: "INTEGER", PrimaryKey: true, Description: "Monotonic, autoincrement"},
+			{Name: "table_name", Type: "TEXT", NotNull: true, Description: "A key of PayloadSchemas"},
+			{Name: "entity_id", Type: "TEXT", NotNull: true, Description: "Lore ID, or lore ID/attachment ID"},
+			{Name: "operation", Type: "TEXT", NotNull: true, Description: "One of ChangeLogOperations"},
+			{Name: "payload", Type: "TEXT", Description: "Full entity JSON for upserts; null for deletes"},
+			{Name: "source_id", Type: "TEXT", NotNull: true},
+			{Name: "created_at", Type: "TEXT", NotNull: true, Description: "RFC 3339, UTC"},
+			{Name: "received_at", Type: "TEXT", NotNull: true, Default: "datetime('now')"},
+		},
+	},
+	{
+		Name:        "sync_meta",
+		Description: "Sync protocol state, e.g. last_push_seq and last_pull_seq.",
+		Columns: []Column{
+			{Name: "key", Type: "TEXT", PrimaryKey: true},
+			{Name: "value", Type: "TEXT", NotNull: true},
+		},
+	},
+	{
+		Name:        "push_idempotency",
+		Description: "Responses to recent push IDs, so a retried push is not applied twice.",
+		Columns: []Column{
+			{Name: "push_id", Type: "TEXT", PrimaryKey: true},
+			{Name: "store_id", Type: "TEXT", NotNull: true},
+			{Name: "response", Type: "TEXT", NotNull: true},
+			{Name: "created_at", Type: "TEXT", NotNull: true, Default: "datetime('now')"},
+			{Name: "expires_at", Type: "TEXT", NotNull: true},
+		},
+	},
+	{
+		Name:        "sync_queue",
+		Description: "Legacy push queue, superseded by change_log; read only by migration.",
+		Columns: []Column{
+			{Name: "id", Type: "INTEGER", PrimaryKey: true},
+			{Name: "lore_id", Type: "TEXT", NotNull: true},
+			{Name: "operation", Type: "TEXT", NotNull: true},
+			{Name: "payload", Type: "TEXT"},
+			{Name: "queued_at", Type: "TEXT", NotNull: true},
+			{Name: "attempts", Type: "INTEGER", Default: "0"},
+			{Name: "last_error", Type: "TEXT"},
+		},
+	},
+	{
+		Name:        "lore_context_summaries",
+		Description: "Length of context Record summarized to fit the limit, and whether the summary was truncated.",
+		Columns: []Column{
+			{Name: "lore_id", Type: "TEXT", PrimaryKey: true},
+			{Name: "original_length", Type: "INTEGER", NotNull: true},
+			{Name: "truncated", Type: "INTEGER", NotNull: true, Default: "0"},
+			{Name: "created_at", Type: "TEXT", NotNull: true},
+		},
+	},
+	{
+		Name:        "lore_episodes",
+		Description: "Lore recorded together by RecordEpisode.",
+		Columns: []Column{
+			{Name: "lore_id", Type: "TEXT", PrimaryKey: true},
+			{Name: "episode_id", Type: "TEXT", NotNull: true},
+			{Name: "position", Type: "INTEGER", NotNull: true, Description: "1-based, in the order given"},
+			{Name: "created_at", Type: "TEXT", NotNull: true},
+		},
+	},
+	{
+		Name:        "lore_protection",
+		Description: "Lore whose confidence feedback cannot lower below a floor.",
+		Columns: []Column{
+			{Name: "lore_id", Type: "TEXT", PrimaryKey: true},
+			{Name: "floor", Type: "REAL", NotNull: true},
+			{Name: "min_level", Type: "INTEGER", NotNull: true, Description: "Access policy level needed to go below floor or unprotect"},
+			{Name: "protected_by", Type: "TEXT", NotNull: true, Default: "''", Description: "Actor that protected the entry"},
+			{Name: "created_at", Type: "TEXT", NotNull: true},
+		},
+	},
+	{
+		Name:        "sync_conflicts",
+		Description: "Delta entries that conflicted with unpushed local changes, for review.",
+		Columns: []Column{
+			{Name: "id", Type: "INTEGER", PrimaryKey: true},
+			{Name: "lore_id", Type: "TEXT", NotNull: true},
+			{Name: "local_payload", Type: "TEXT", NotNull: true, Description: "Lore payload JSON"},
+			{Name: "remote_payload", Type: "TEXT", NotNull: true, Description: "Lore payload JSON"},
+			{Name: "remote_sequence", Type: "INTEGER", NotNull: true},
+			{Name: "detected_at", Type: "TEXT", NotNull: true},
+			{Name: "resolved_at", Type: "TEXT"},
+			{Name: "resolution", Type: "TEXT", Description: "local, remote or merged"},
+		},
+	},
+	{
+		Name:        "sync_egress_log",
+		Description: "Audit log of every change_log entry pushed to Engram.",
+		Columns: []Column{
+			{Name: "id", Type: "INTEGER", PrimaryKey: true},
+			{Name: "push_id", Type: "TEXT", NotNull: true},
+			{Name: "pushed_at", Type: "TEXT", NotNull: true},
+			{Name: "sequence", Type: "INTEGER", NotNull: true},
+			{Name: "table_name", Type: "TEXT", NotNull: true},
+			{Name: "entity_id", Type: "TEXT", NotNull: true},
+			{Name: "operation", Type: "TEXT", NotNull: true},
+			{Name: "payload_sha256", Type: "TEXT", NotNull: true},
+			{Name: "payload_bytes", Type: "INTEGER", NotNull: true},
+		},
+	},
+	{
+		Name:        "export_log",
+		Description: "Audit log of exports.",
+		Columns: []Column{
+			{Name: "id", Type: "INTEGER", PrimaryKey: true},
+			{Name: "exported_at", Type: "TEXT", NotNull: true},
+			{Name: "format", Type: "TEXT", NotNull: true},
+			{Name: "destination", Type: "TEXT", NotNull: true},
+			{Name: "entries", Type: "INTEGER", NotNull: true},
+			{Name: "sha256", Type: "TEXT", NotNull: true},
+		},
+	},
+	{
+		Name:        "metadata",
+		Description: "Store metadata, e.g. store ID, description and rollover policy.",
+		Columns: []Column{
+			{Name: "key", Type: "TEXT", PrimaryKey: true},
+			{Name: "value", Type: "TEXT", NotNull: true},
+		},
+	},
+	{
+		Name:        "lore_notes",
+		Description: "Private notes on lore.",
+		Columns: []Column{
+			{Name: "id", Type: "INTEGER", PrimaryKey: true},
+			{Name: "lore_id", Type: "TEXT", NotNull: true},
+			{Name: "note", Type: "TEXT", NotNull: true},
+			{Name: "created_at", Type: "TEXT", NotNull: true},
+		},
+	},
+	{
+		Name:        "seed_entries",
+		Description: "Seed file entries applied to the store, by namespace and key.",
+		Columns: []Column{
+			{Name: "namespace", Type: "TEXT", NotNull: true, PrimaryKey: true, Default: "''"},
+			{Name: "key", Type: "TEXT", NotNull: true, PrimaryKey: true},
+			{Name: "lore_id", Type: "TEXT", NotNull: true},
+			{Name: "hash", Type: "TEXT", NotNull: true},
+			{Name: "applied_at", Type: "TEXT", NotNull: true},
+		},
+	},
+	{
+		Name:        "attachments",
+		Description: "Attachment content, stored once per SHA-256 hash.",
+		Columns: []Column{
+			{Name: "hash", Type: "TEXT", PrimaryKey: true},
+			{Name: "size", Type: "INTEGER", NotNull: true},
+			{Name: "data", Type: "BLOB", NotNull: true},
+			{Name: "created_at", Type: "TEXT", NotNull: true},
+		},
+	},
+	{
+		Name:        "lore_attachments",
+		Description: "Links from lore to attachments. Links with synced set are pushed.",
+		Synced:      true,
+		Columns: []Column{
+			{Name: "lore_id", Type: "TEXT", NotNull: true, PrimaryKey: true},
+			{Name: "hash", Type: "TEXT", NotNull: true, PrimaryKey: true},
+			{Name: "name", Type: "TEXT", NotNull: true},
+			{Name: "media_type", Type: "TEXT", NotNull: true},
+			{Name: "synced", Type: "INTEGER", NotNull: true, Default: "0"},
+			{Name: "created_at", Type: "TEXT", NotNull: true},
+		},
+	},
+	{
+		Name:        "lore_supersessions",
+		Description: "Lore superseded by a newer entry.",
+		Columns: []Column{
+			{Name: "lore_id", Type: "TEXT", PrimaryKey: true},
+			{Name: "superseded_by", Type: "TEXT", NotNull: true},
+			{Name: "created_at", Type: "TEXT", NotNull: true},
+		},
+	},
+	{
+		Name:        "legal_hold_tombstones",
+		Description: "Lore deleted while the store was under legal hold, kept until release.",
+		Columns: []Column{
+			{Name: "id", Type: "INTEGER", PrimaryKey: true},
+			{Name: "lore_id", Type: "TEXT", NotNull: true},
+			{Name: "origin", Type: "TEXT", NotNull: true},
+			{Name: "payload", Type: "TEXT", NotNull: true, Description: "Lore payload JSON"},
+			{Name: "held_at", Type: "TEXT", NotNull: true},
+		},
+	},
+	{
+		Name:        "lore_chunks",
+		Description: "Parts of long content recorded as linked entries.",
+		Columns: []Column{
+			{Name: "lore_id", Type: "TEXT", PrimaryKey: true},
+			{Name: "group_id", Type: "TEXT", NotNull: true, Description: "ID of the first part"},
+			{Name: "part", Type: "INTEGER", NotNull: true, Description: "1-based"},
+			{Name: "created_at", Type: "TEXT", NotNull: true},
+		},
+	},
+	{
+		Name:        "query_log",
+		Description: "Logged queries, when query logging is on.",
+		Columns: []Column{
+			{Name: "id", Type: "INTEGER", PrimaryKey: true},
+			{Name: "queried_at", Type: "TEXT", NotNull: true},
+			{Name: "namespace", Type: "TEXT", NotNull: true, Default: "''"},
+			{Name: "actor", Type: "TEXT", NotNull: true, Default: "''"},
+			{Name: "query", Type: "TEXT", NotNull: true},
+			{Name: "embedding_sha256", Type: "TEXT", NotNull: true, Default: "''"},
+			{Name: "params", Type: "TEXT", NotNull: true, Description: "JSON"},
+			{Name: "results", Type: "TEXT", NotNull: true, Description: "JSON"},
+		},
+	},
+	{
+		Name:        "query_pins",
+		Description: "Query results pinned for reproducible runs and audits.",
+		Columns: []Column{
+			{Name: "id", Type: "TEXT", PrimaryKey: true},
+			{Name: "namespace", Type: "TEXT", NotNull: true, Default: "''"},
+			{Name: "actor", Type: "TEXT", NotNull: true, Default: "''", Description: "Actor that pinned the result"},
+			{Name: "entries", Type: "TEXT", NotNull: true, Description: "JSON: IDs and scores in rank order"},
+			{Name: "created_at", Type: "TEXT", NotNull: true},
+		},
+	},
+	{
+		Name:        "lore_inbox",
+		Description: "Lore staged for human approval before it is recorded and synced.",
+		Columns: []Column{
+			{Name: "id", Type: "TEXT", PrimaryKey: true, Description: "Becomes the lore ID on approval"},
+			{Name: "namespace", Type: "TEXT", NotNull: true, Default: "''"},
+			{Name: "content", Type: "TEXT", NotNull: true},
+			{Name: "context", Type: "TEXT", NotNull: true, Default: "''"},
+			{Name: "category", Type: "TEXT", NotNull: true},
+			{Name: "confidence", Type: "REAL", NotNull: true},
+			{Name: "sources", Type: "TEXT", NotNull: true, Default: "''", Description: "Comma-separated"},
+			{Name: "actor", Type: "TEXT", NotNull: true, Default: "''", Description: "Actor that recorded the entry"},
+			{Name: "created_at", Type: "TEXT", NotNull: true},
+		},
+	},
+	{
+		Name:        "lore_tokens",
+		Description: "Token counts per entry and estimator.",
+		Columns: []Column{
+			{Name: "lore_id", Type: "TEXT", NotNull: true, PrimaryKey: true},
+			{Name: "estimator", Type: "TEXT", NotNull: true, PrimaryKey: true},
+			{Name: "fingerprint", Type: "TEXT", NotNull: true, Description: "Content fingerprint the count is for"},
+			{Name: "tokens", Type: "INTEGER", NotNull: true},
+		},
+	},
+	{
+		Name:        "lore_originals",
+		Description: "Content as submitted, where a normalizer changed it.",
+		Columns: []Column{
+			{Name: "lore_id", Type: "TEXT", PrimaryKey: true},
+			{Name: "content", Type: "TEXT", NotNull: true},
+			{Name: "normalizers", Type: "TEXT", NotNull: true},
+			{Name: "created_at", Type: "TEXT", NotNull: true},
+		},
+	},
+	{
+		Name:        "lore_embeddings",
+		Description: "Embeddings per model, for model transitions.",
+		Columns: []Column{
+			{Name: "lore_id", Type: "TEXT", NotNull: true, PrimaryKey: true},
+			{Name: "model", Type: "TEXT", NotNull: true, PrimaryKey: true},
+			{Name: "embedding", Type: "BLOB", NotNull: true, Description: "Little-endian float32 vector"},
+			{Name: "created_at", Type: "TEXT", NotNull: true},
+		},
+	},
+	{
+		Name:        "feedback_events",
+		Description: "Feedback per entry and actor within the feedback window.",
+		Columns: []Column{
+			{Name: "id", Type: "INTEGER", PrimaryKey: true},
+			{Name: "lore_id", Type: "TEXT", NotNull: true},
+			{Name: "actor", Type: "TEXT", NotNull: true},
+			{Name: "outcome", Type: "TEXT", NotNull: true},
+			{Name: "created_at", Type: "TEXT", NotNull: true},
+		},
+	},
+	{
+		Name:        "feedback_log",
+		Description: "Every feedback outcome, by the source that recorded the lore, for source reports.",
+		Columns: []Column{
+			{Name: "id", Type: "INTEGER", PrimaryKey: true},
+			{Name: "namespace", Type: "TEXT", NotNull: true, Default: "''"},
+			{Name: "lore_id", Type: "TEXT", NotNull: true},
+			{Name: "source_id", Type: "TEXT", NotNull: true, Description: "Source that recorded the lore"},
+			{Name: "outcome", Type: "TEXT", NotNull: true},
+			{Name: "actor", Type: "TEXT", NotNull: true, Default: "''", Description: "Actor that gave the feedback"},
+			{Name: "created_at", Type: "TEXT", NotNull: true},
+		},
+	},
+	{
+		Name:        "lore_assignments",
+		Description: "Owner and reviewer of an entry; empty is unassigned.",
+		Columns: []Column{
+			{Name: "lore_id", Type: "TEXT", PrimaryKey: true},
+			{Name: "owner", Type: "TEXT", NotNull: true, Default: "''"},
+			{Name: "reviewer", Type: "TEXT", NotNull: true, Default: "''"},
+			{Name: "assigned_by", Type: "TEXT", NotNull: true, Default: "''", Description: "Actor that last changed the assignment"},
+			{Name: "updated_at", Type: "TEXT", NotNull: true},
+		},
+	},
+	{
+		Name:        "lore_revisions",
+		Description: "Content edits as word diffs from the previous content.",
+		Columns: []Column{
+			{Name: "id", Type: "INTEGER", PrimaryKey: true},
+			{Name: "lore_id", Type: "TEXT", NotNull: true},
+			{Name: "actor", Type: "TEXT", NotNull: true},
+			{Name: "diff", Type: "TEXT", NotNull: true},
+			{Name: "created_at", Type: "TEXT", NotNull: true},
+		},
+	},
+	{
+		Name:        "deferred_feedback",
+		Description: "Confirmed-mode feedback on unpushed lore, replayed to Engram once the lore is pushed.",
+		Columns: []Column{
+			{Name: "id", Type: "INTEGER", PrimaryKey: true},
+			{Name: "lore_id", Type: "TEXT", NotNull: true},
+			{Name: "outcome", Type: "TEXT", NotNull: true, Description: "helpful, incorrect or not_relevant"},
+			{Name: "queued_at", Type: "TEXT", NotNull: true},
+			{Name: "attempts", Type: "INTEGER", NotNull: true, Default: "0"},
+			{Name: "last_error", Type: "TEXT"},
+		},
+	},
+	{
+		Name:        "lore_fts",
+		Description: "FTS5 external-content index of lore_entries' content and context for keyword queries, keyed by lore_entries' rowid and kept in sync by triggers on lore_entries.",
+		Columns: []Column{
+			{Name: "content"},
+			{Name: "context"},
+		},
+	},
+}
+
+// Views describes the introspection views.
+var Views = []View{
+	{
+		Name:        "v_lore_overview",
+		Description: "One row per live lore entry.",
+		Columns: []Column{
+			{Name: "id", Type: "TEXT"},
+			{Name: "namespace", Type: "TEXT"},
+			{Name: "category", Type: "TEXT"},
+			{Name: "confidence", Type: "REAL"},
+			{Name: "validation_count", Type: "INTEGER"},
+			{Name: "usage_count", Type: "INTEGER"},
+			{Name: "source_id", Type: "TEXT"},
+			{Name: "summary", Description: "First 120 characters of content"},
+			{Name: "content_length"},
+			{Name: "has_embedding"},
+			{Name: "embedding_status", Type: "TEXT"},
+			{Name: "synced"},
+			{Name: "created_at", Type: "TEXT"},
+			{Name: "updated_at", Type: "TEXT"},
+			{Name: "last_validated_at", Type: "TEXT"},
+			{Name: "last_used_at", Type: "TEXT"},
+		},
+	},
+	{
+		Name:        "v_sync_backlog",
+		Description: "One row per kind of pending sync work.",
+		Columns: []Column{
+			{Name: "kind", Description: "unpushed_changes, queued_operations, unresolved_conflicts or unsynced_lore"},
+			{Name: "pending"},
+			{Name: "oldest_at"},
+		},
+	},
+	{
+		Name:        "v_category_health",
+		Description: "One row per namespace and category.",
+		Columns: []Column{
+			{Name: "namespace", Type: "TEXT"},
+			{Name: "category", Type: "TEXT"},
+			{Name: "entries"},
+			{Name: "avg_confidence"},
+			{Name: "min_confidence"},
+			{Name: "max_confidence"},
+			{Name: "validated"},
+			{Name: "low_confidence", Description: "Entries below 0.3"},
+			{Name: "with_embedding"},
+			{Name: "total_usage"},
+			{Name: "last_updated_at"},
+		},
+	},
+}
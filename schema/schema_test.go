@@ -0,0 +1,234 @@
+package schema_test
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hyperengineering/recall"
+	"github.com/hyperengineering/recall/schema"
+)
+
+// openStoreDB creates a store with recall, records lore with a synced
+// attachment, and opens the file directly.
+func openStoreDB(t *testing.T) *sql.DB {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "lore.db")
+	client, err := recall.New(recall.Config{LocalPath: path, SourceID: "schema-test", Namespace: "team"})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	lore, err := client.Record("Pin the base image", recall.CategoryDependencyBehavior, recall.WithContext("docker builds"))
+	if err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+	if _, err := client.Attach(lore.ID, "Dockerfile", []byte("FROM alpine:3.20\n"), recall.WithAttachmentSync()); err != nil {
+		t.Fatalf("Attach() error = %v", err)
+	}
+	if err := client.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+// columns reads the columns of a table or view as schema.Columns, without
+// descriptions.
+func columns(t *testing.T, db *sql.DB, name string) []schema.Column {
+	t.Helper()
+	rows, err := db.Query(`SELECT name, type, "notnull", COALESCE(dflt_value, ''), pk FROM pragma_table_info(?)`, name)
+	if err != nil {
+		t.Fatalf("table_info(%s): %v", name, err)
+	}
+	defer rows.Close()
+	var cols []schema.Column
+	for rows.Next() {
+		var c schema.Column
+		var pk int
+		if err := rows.Scan(&c.Name, &c.Type, &c.NotNull, &c.Default, &pk); err != nil {
+			t.Fatal(err)
+		}
+		c.PrimaryKey = pk > 0
+		cols = append(cols, c)
+	}
+	return cols
+}
+
+func withoutDescriptions(cols []schema.Column) []schema.Column {
+	out := slices.Clone(cols)
+	for i := range out {
+		out[i].Description = ""
+	}
+	return out
+}
+
+func TestSchema_MatchesStore(t *testing.T) {
+	db := openStoreDB(t)
+
+	var version int
+	if err := db.QueryRow(`SELECT MAX(version_id) FROM goose_db_version`).Scan(&version); err != nil {
+		t.Fatal(err)
+	}
+	if version != schema.MigrationVersion {
+		t.Errorf("MigrationVersion = %d, store is at %d", schema.MigrationVersion, version)
+	}
+
+	rows, err := db.Query(`
+		SELECT name, CASE type WHEN 'virtual' THEN 'table' ELSE type END FROM pragma_table_list
+		WHERE schema = 'main' AND type IN ('table', 'view', 'virtual') AND name NOT LIKE 'sqlite_%' AND name != 'goose_db_version'
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var tables, views []string
+	for rows.Next() {
+		var name, kind string
+		if err := rows.Scan(&name, &kind); err != nil {
+			t.Fatal(err)
+		}
+		if kind == "table" {
+			tables = append(tables, name)
+		} else {
+			views = append(views, name)
+		}
+	}
+	_ = rows.Close()
+
+	var described []string
+	for _, table := range schema.Tables {
+		described = append(described, table.Name)
+		if got, want := columns(t, db, table.Name), withoutDescriptions(table.Columns); !slices.Equal(got, want) {
+			t.Errorf("table %s:\n store  %+v\n schema %+v", table.Name, got, want)
+		}
+	}
+	slices.Sort(tables)
+	slices.Sort(described)
+	if !slices.Equal(tables, described) {
+		t.Errorf("tables = %v, schema describes %v", tables, described)
+	}
+
+	described = nil
+	for _, view := range schema.Views {
+		described = append(described, view.Name)
+		if got, want := columns(t, db, view.Name), withoutDescriptions(view.Columns); !slices.Equal(got, want) {
+			t.Errorf("view %s:\n store  %+v\n schema %+v", view.Name, got, want)
+		}
+	}
+	slices.Sort(views)
+	slices.Sort(described)
+	if !slices.Equal(views, described) {
+		t.Errorf("views = %v, schema describes %v", views, described)
+	}
+}
+
+func TestSchema_Categories(t *testing.T) {
+	var want []string
+	for _, c := range recall.ValidCategories() {
+		want = append(want, string(c))
+	}
+	if !slices.Equal(schema.Categories, want) {
+		t.Errorf("Categories = %v, want %v", schema.Categories, want)
+	}
+
+	var category struct {
+		Enum []string `json:"enum"`
+	}
+	if err := json.Unmarshal(schema.CategorySchema, &category); err != nil {
+		t.Fatalf("CategorySchema: %v", err)
+	}
+	if !slices.Equal(category.Enum, want) {
+		t.Errorf("CategorySchema enum = %v, want %v", category.Enum, want)
+	}
+}
+
+// payloadSchema is the subset of a payload JSON Schema the tests check.
+type payloadSchema struct {
+	Required   []string `json:"required"`
+	Properties map[string]struct {
+		Enum []string `json:"enum"`
+	} `json:"properties"`
+}
+
+func TestSchema_PayloadsMatchChangeLog(t *testing.T) {
+	db := openStoreDB(t)
+
+	var lore payloadSchema
+	if err := json.Unmarshal(schema.LorePayloadSchema, &lore); err != nil {
+		t.Fatalf("LorePayloadSchema: %v", err)
+	}
+	if !slices.Equal(lore.Properties["category"].Enum, schema.Categories) {
+		t.Errorf("LorePayloadSchema category enum = %v", lore.Properties["category"].Enum)
+	}
+
+	rows, err := db.Query(`SELECT table_name, operation, payload FROM change_log WHERE operation = 'upsert'`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	seen := map[string]bool{}
+	for rows.Next() {
+		var table, operation, payload string
+		if err := rows.Scan(&table, &operation, &payload); err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Contains(schema.ChangeLogOperations, operation) {
+			t.Errorf("operation %q is not in ChangeLogOperations", operation)
+		}
+		raw, ok := schema.PayloadSchemas[table]
+		if !ok {
+			t.Errorf("no payload schema for change_log table %q", table)
+			continue
+		}
+		var s payloadSchema
+		if err := json.Unmarshal(raw, &s); err != nil {
+			t.Fatalf("%s schema: %v", table, err)
+		}
+		var fields map[string]any
+		if err := json.Unmarshal([]byte(payload), &fields); err != nil {
+			t.Fatalf("%s payload: %v", table, err)
+		}
+		for name := range fields {
+			if _, ok := s.Properties[name]; !ok {
+				t.Errorf("%s payload field %q is not in its schema", table, name)
+			}
+		}
+		for _, name := range s.Required {
+			if _, ok := fields[name]; !ok {
+				t.Errorf("%s payload lacks required field %q", table, name)
+			}
+		}
+		seen[table] = true
+	}
+	for table := range schema.PayloadSchemas {
+		if !seen[table] {
+			t.Errorf("no %s upsert in the change_log to check", table)
+		}
+	}
+}
+
+func TestJSON(t *testing.T) {
+	data, err := schema.JSON()
+	if err != nil {
+		t.Fatalf("JSON() error = %v", err)
+	}
+	var doc schema.Document
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("JSON() is not a Document: %v", err)
+	}
+	if doc.MigrationVersion != schema.MigrationVersion || len(doc.Tables) != len(schema.Tables) ||
+		len(doc.PayloadSchemas) != len(schema.PayloadSchemas) {
+		t.Errorf("JSON() = version %d, %d tables, %d payload schemas", doc.MigrationVersion, len(doc.Tables), len(doc.PayloadSchemas))
+	}
+	if table := schema.TableNamed("lore_entries"); table == nil || !table.Synced || !strings.Contains(fmt.Sprint(table.Columns), "fingerprint") {
+		t.Errorf("TableNamed(lore_entries) = %+v", table)
+	}
+}
@@ -0,0 +1,399 @@
+package recall
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultSeedDir is the repository directory `recall seed` reads by default.
+const DefaultSeedDir = ".recall/seed"
+
+// SeedEntry is a declarative lore definition from a seed file.
+//
+// YAML files (*.yaml, *.yml) hold a list of entries:
+//
+//	# .recall/seed/platform.yaml
+//	- key: connection-pooling
+//	  category: PERFORMANCE_INSIGHT
+//	  content: Use connection pooling for Postgres.
+//	  context: api-service
+//
+// Markdown files (*.md) hold one entry: optional YAML front matter between
+// "---" lines, with the body as content. Key defaults to the file name
+// without its extension.
+type SeedEntry struct {
+	// Key identifies the entry across seed runs. Changing it makes a new
+	// entry.
+	Key        string   `yaml:"key" json:"key"`
+	Content    string   `yaml:"content" json:"content"`
+	Context    string   `yaml:"context,omitempty" json:"context,omitempty"`
+	Category   Category `yaml:"category" json:"category"`
+	Confidence *float64 `yaml:"confidence,omitempty" json:"confidence,omitempty"` // Default 0.5; unset leaves feedback-adjusted confidence alone on update
+
+	// File is the seed file the entry was read from.
+	File string `yaml:"-" json:"file,omitempty"`
+
+	// source marks lore inserted for the entry, e.g. a starter pack's
+	// StarterPackSource.
+	source string
+}
+
+// hash identifies the definition, so unchanged entries are skipped.
+func (e SeedEntry) hash() string {
+	data, _ := json.Marshal(struct {
+		Content    string   `json:"content"`
+		Context    string   `json:"context"`
+		Category   Category `json:"category"`
+		Confidence *float64 `json:"confidence"`
+	}{e.Content, e.Context, e.Category, e.Confidence})
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// validate checks the entry as Client.Record would.
+func (e SeedEntry) validate() error {
+	where := e.File
+	if e.Key != "" {
+		where += ": " + e.Key
+	}
+	switch {
+	case e.Key == "":
+		return &ValidationError{Field: "Key", Message: where + ": cannot be empty"}
+	case strings.TrimSpace(e.Content) == "":
+		return &ValidationError{Field: "Content", Message: where + ": cannot be empty"}
+	case len(e.Content) > MaxContentLength:
+		return &ValidationError{Field: "Content", Message: where + ": exceeds 4000 character limit"}
+	case len(e.Context) > MaxContextLength:
+		return &ValidationError{Field: "Context", Message: where + ": exceeds 1000 character limit"}
+	case !e.Category.IsValid():
+		return &ValidationError{Field: "Category", Message: fmt.Sprintf("%s: invalid category %q", where, e.Category)}
+	case e.Confidence != nil && (*e.Confidence < ConfidenceMin || *e.Confidence > ConfidenceMax):
+		return &ValidationError{Field: "Confidence", Message: where + ": must be between 0.0 and 1.0"}
+	}
+	return nil
+}
+
+// SeedAction is what seeding did with one entry.
+type SeedAction string
+
+const (
+	SeedInserted  SeedAction = "inserted"  // New lore was recorded
+	SeedUpdated   SeedAction = "updated"   // The definition changed and the lore was updated
+	SeedAdopted   SeedAction = "adopted"   // Existing lore with the same content was linked instead of duplicated
+	SeedUnchanged SeedAction = "unchanged" // Already applied
+)
+
+// SeedOutcome reports what happened to one seed entry.
+type SeedOutcome struct {
+	Key    string     `json:"key"`
+	File   string     `json:"file"`
+	LoreID string     `json:"lore_id"`
+	Action SeedAction `json:"action"`
+}
+
+// SeedResult summarizes a seed run.
+type SeedResult struct {
+	Inserted  int           `json:"inserted"`
+	Updated   int           `json:"updated"`
+	Adopted   int           `json:"adopted"`
+	Unchanged int           `json:"unchanged"`
+	DryRun    bool          `json:"dry_run"`
+	Entries   []SeedOutcome `json:"entries"`
+}
+
+// SeedOptions configures Client.Seed.
+type SeedOptions struct {
+	// DryRun reports what would change without writing.
+	DryRun bool
+
+	// Registry is the base URL SeedStarterPack fetches packs from instead
+	// of the embedded ones.
+	Registry string
+}
+
+// LoadSeedDir reads every *.yaml, *.yml and *.md file in dir, in name order,
+// and validates the entries. Keys must be unique across the directory.
+func LoadSeedDir(dir string) ([]SeedEntry, error) {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("seed: %w", err)
+	}
+
+	var entries []SeedEntry
+	seen := make(map[string]string)
+	for _, f := range files {
+		if f.IsDir() {
+			continue
+		}
+		path := filepath.Join(dir, f.Name())
+		var fileEntries []SeedEntry
+		switch strings.ToLower(filepath.Ext(f.Name())) {
+		case ".yaml", ".yml":
+			fileEntries, err = parseSeedYAML(path)
+		case ".md":
+			fileEntries, err = parseSeedMarkdown(path)
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range fileEntries {
+			e.File = path
+			if err := e.validate(); err != nil {
+				return nil, err
+			}
+			if prev, dup := seen[e.Key]; dup {
+				return nil, &ValidationError{Field: "Key", Message: fmt.Sprintf("duplicate key %q in %s and %s", e.Key, prev, path)}
+			}
+			seen[e.Key] = path
+			entries = append(entries, e)
+		}
+	}
+	return entries, nil
+}
+
+func parseSeedYAML(path string) ([]SeedEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("seed: %w", err)
+	}
+	return parseSeedYAMLData(path, data)
+}
+
+func parseSeedYAMLData(path string, data []byte) ([]SeedEntry, error) {
+	var entries []SeedEntry
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&entries); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("seed: parse %s: %w", path, err)
+	}
+	return entries, nil
+}
+
+func parseSeedMarkdown(path string) ([]SeedEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("seed: %w", err)
+	}
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+
+	var entry SeedEntry
+	if rest, ok := strings.CutPrefix(text, "---\n"); ok {
+		front, body, found := strings.Cut(rest, "\n---\n")
+		if !found {
+			return nil, fmt.Errorf("seed: parse %s: unterminated front matter", path)
+		}
+		dec := yaml.NewDecoder(strings.NewReader(front))
+		dec.KnownFields(true)
+		if err := dec.Decode(&entry); err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("seed: parse %s: %w", path, err)
+		}
+		text = body
+	}
+	if entry.Content != "" {
+		return nil, fmt.Errorf("seed: parse %s: content belongs in the body, not front matter", path)
+	}
+	entry.Content = strings.TrimSpace(text)
+	if entry.Key == "" {
+		entry.Key = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	return []SeedEntry{entry}, nil
+}
+
+// ApplySeed applies seed entries idempotently in one transaction. Entries
+// already applied with the same definition are skipped; changed definitions
+// update their lore; new entries adopt live lore with the same Fingerprint
+// or insert new lore. Lore whose seed entry was removed is left alone.
+func (s *Store) ApplySeed(entries []SeedEntry, dryRun bool) (*SeedResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil, ErrStoreClosed
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("store: begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	result := &SeedResult{DryRun: dryRun, Entries: []SeedOutcome{}}
+	now := s.now()
+	for _, e := range entries {
+		outcome, err := s.applySeedEntryTx(tx, e, now)
+		if err != nil {
+			return nil, err
+		}
+		switch outcome.Action {
+		case SeedInserted:
+			result.Inserted++
+		case SeedUpdated:
+			result.Updated++
+		case SeedAdopted:
+			result.Adopted++
+		case SeedUnchanged:
+			result.Unchanged++
+		}
+		result.Entries = append(result.Entries, outcome)
+	}
+	sort.SliceStable(result.Entries, func(i, j int) bool { return result.Entries[i].Key < result.Entries[j].Key })
+
+	if dryRun {
+		return result, nil
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("store: commit: %w", err)
+	}
+	return result, nil
+}
+
+func (s *Store) applySeedEntryTx(tx *sql.Tx, e SeedEntry, now time.Time) (SeedOutcome, error) {
+	outcome := SeedOutcome{Key: e.Key, File: e.File}
+	hash := e.hash()
+
+	var loreID, appliedHash string
+	err := tx.QueryRow(`SELECT lore_id, hash FROM seed_entries WHERE namespace = ? AND key = ?`,
+		s.namespace, e.Key).Scan(&loreID, &appliedHash)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return outcome, fmt.Errorf("store: read seed entry: %w", err)
+	}
+
+	var existing *Lore
+	if loreID != "" {
+		existing, err = s.getLoreTx(tx, loreID)
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			return outcome, err
+		}
+	}
+
+	switch {
+	case existing != nil && appliedHash == hash:
+		outcome.LoreID, outcome.Action = existing.ID, SeedUnchanged
+		return outcome, nil
+
+	case existing != nil:
+		if err := s.updateSeededLoreTx(tx, existing, e, now); err != nil {
+			return outcome, err
+		}
+		outcome.LoreID, outcome.Action = existing.ID, SeedUpdated
+
+	default:
+		// Not applied yet, or its lore was deleted since
+		match, err := s.findByFingerprintTx(tx, Fingerprint(e.Content))
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			return outcome, err
+		}
+		if match != nil {
+			outcome.LoreID, outcome.Action = match.ID, SeedAdopted
+		} else {
+			id, err := s.insertSeededLoreTx(tx, e, now)
+			if err != nil {
+				return outcome, err
+			}
+			outcome.LoreID, outcome.Action = id, SeedInserted
+		}
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO seed_entries (namespace, key, lore_id, hash, applied_at) VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(namespace, key) DO UPDATE SET lore_id = excluded.lore_id, hash = excluded.hash, applied_at = excluded.applied_at
+	`, s.namespace, e.Key, outcome.LoreID, hash, now.Format(time.RFC3339))
+	if err != nil {
+		return outcome, fmt.Errorf("store: record seed entry: %w", err)
+	}
+	return outcome, nil
+}
+
+func (s *Store) insertSeededLoreTx(tx *sql.Tx, e SeedEntry, now time.Time) (string, error) {
+	lore := &Lore{
+		ID:              s.newID(),
+		Content:         e.Content,
+		Context:         e.Context,
+		Category:        e.Category,
+		Confidence:      ConfidenceDefault,
+		EmbeddingStatus: "pending",
+		SourceID:        s.sourceID,
+		Namespace:       s.namespace,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	if e.source != "" {
+		lore.Sources = []string{e.source}
+	}
+	if e.Confidence != nil {
+		lore.Confidence = *e.Confidence
+	}
+	if err := s.insertLoreTx(tx, lore); err != nil {
+		return "", fmt.Errorf("store: insert seeded lore: %w", err)
+	}
+	payload, err := lorePayloadJSON(lore)
+	if err != nil {
+		return "", fmt.Errorf("store: marshal change_log payload: %w", err)
+	}
+	if err := s.appendChangeLog(tx, "lore_entries", lore.ID, "upsert", payload, s.sourceID); err != nil {
+		return "", err
+	}
+	return lore.ID, nil
+}
+
+func (s *Store) updateSeededLoreTx(tx *sql.Tx, existing *Lore, e SeedEntry, now time.Time) error {
+	confidence := existing.Confidence
+	if e.Confidence != nil {
+		confidence = *e.Confidence
+	}
+	_, err := tx.Exec(`
+		UPDATE lore_entries SET content = ?, fingerprint = ?, checksum = ?, context = ?, category = ?, confidence = ?, updated_at = ?
+		WHERE id = ?
+	`, e.Content, Fingerprint(e.Content), loreChecksum(existing.ID, e.Content, e.Context, e.Category), nullString(e.Context), string(e.Category), confidence, now.Format(time.RFC3339), existing.ID)
+	if err != nil {
+		return fmt.Errorf("store: update seeded lore: %w", err)
+	}
+	updated, err := s.getLoreTx(tx, existing.ID)
+	if err != nil {
+		return fmt.Errorf("store: read seeded lore: %w", err)
+	}
+	payload, err := lorePayloadJSON(updated)
+	if err != nil {
+		return fmt.Errorf("store: marshal change_log payload: %w", err)
+	}
+	return s.appendChangeLog(tx, "lore_entries", existing.ID, "upsert", payload, s.sourceID)
+}
+
+// Seed applies the seed files in dir (DefaultSeedDir if empty) to the
+// store. It is idempotent: re-running with unchanged files writes nothing,
+// and entries whose content already exists are linked rather than
+// duplicated. Every seeded category must be allowed by the AccessPolicy.
+func (c *Client) Seed(dir string, opts SeedOptions) (*SeedResult, error) {
+	if dir == "" {
+		dir = DefaultSeedDir
+	}
+	entries, err := LoadSeedDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		if err := c.authorize(ActionRecord, e.Category); err != nil {
+			return nil, err
+		}
+	}
+	result, err := c.store.ApplySeed(entries, opts.DryRun)
+	if err != nil {
+		return nil, fmt.Errorf("client: seed: %w", err)
+	}
+	return result, nil
+}
@@ -0,0 +1,144 @@
+package recall
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeSeedFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadSeedDir(t *testing.T) {
+	dir := t.TempDir()
+	writeSeedFile(t, dir, "base.yaml", `
+- key: pooling
+  category: PERFORMANCE_INSIGHT
+  content: Use connection pooling for Postgres.
+  confidence: 0.8
+- key: retries
+  category: DEPENDENCY_BEHAVIOR
+  content: The payments API retries on 502 only.
+`)
+	writeSeedFile(t, dir, "event-sourcing.md", `---
+category: ARCHITECTURAL_DECISION
+context: orders
+---
+Event sourcing is overkill for simple CRUD.
+`)
+	writeSeedFile(t, dir, "README.txt", "ignored")
+
+	entries, err := LoadSeedDir(dir)
+	if err != nil {
+		t.Fatalf("LoadSeedDir: %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("got %d entries, want 3", len(entries))
+	}
+	if entries[0].Key != "pooling" || *entries[0].Confidence != 0.8 {
+		t.Errorf("entries[0] = %+v", entries[0])
+	}
+	md := entries[2]
+	if md.Key != "event-sourcing" || md.Context != "orders" || md.Content != "Event sourcing is overkill for simple CRUD." {
+		t.Errorf("markdown entry = %+v", md)
+	}
+}
+
+func TestLoadSeedDir_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"bad category":  "- key: a\n  category: NOPE\n  content: x\n",
+		"missing key":   "- category: TESTING_STRATEGY\n  content: x\n",
+		"unknown field": "- key: a\n  category: TESTING_STRATEGY\n  content: x\n  tags: [y]\n",
+		"duplicate key": "- key: a\n  category: TESTING_STRATEGY\n  content: x\n- key: a\n  category: TESTING_STRATEGY\n  content: y\n",
+	}
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeSeedFile(t, dir, "seed.yaml", content)
+			if _, err := LoadSeedDir(dir); err == nil {
+				t.Error("LoadSeedDir should fail")
+			}
+		})
+	}
+}
+
+func TestStore_ApplySeed_Idempotent(t *testing.T) {
+	s := newTestStore(t)
+
+	// Pre-existing lore with the same normalized content is adopted
+	existing := &Lore{ID: "01EXISTING", Content: "use connection pooling for postgres", Category: CategoryPerformanceInsight, Confidence: 0.6}
+	if err := s.InsertLore(existing); err != nil {
+		t.Fatalf("InsertLore: %v", err)
+	}
+
+	entries := []SeedEntry{
+		{Key: "pooling", Content: "Use connection pooling for Postgres.", Category: CategoryPerformanceInsight},
+		{Key: "retries", Content: "The payments API retries on 502 only.", Category: CategoryDependencyBehavior},
+	}
+
+	dry, err := s.ApplySeed(entries, true)
+	if err != nil {
+		t.Fatalf("ApplySeed dry run: %v", err)
+	}
+	if dry.Inserted != 1 || dry.Adopted != 1 {
+		t.Errorf("dry run = %+v", dry)
+	}
+	if stats, _ := s.Stats(); stats.LoreCount != 1 {
+		t.Fatalf("dry run wrote lore: count = %d", stats.LoreCount)
+	}
+
+	first, err := s.ApplySeed(entries, false)
+	if err != nil {
+		t.Fatalf("ApplySeed: %v", err)
+	}
+	if first.Inserted != 1 || first.Adopted != 1 || first.Entries[0].LoreID != "01EXISTING" {
+		t.Errorf("first run = %+v", first)
+	}
+
+	again, err := s.ApplySeed(entries, false)
+	if err != nil {
+		t.Fatalf("ApplySeed again: %v", err)
+	}
+	if again.Unchanged != 2 {
+		t.Errorf("second run = %+v, want 2 unchanged", again)
+	}
+
+	// A changed definition updates the same entry
+	entries[1].Content = "The payments API retries on 502 and 503."
+	changed, err := s.ApplySeed(entries, false)
+	if err != nil {
+		t.Fatalf("ApplySeed changed: %v", err)
+	}
+	if changed.Updated != 1 || changed.Unchanged != 1 {
+		t.Errorf("changed run = %+v", changed)
+	}
+	lore, err := s.Get(changed.Entries[1].LoreID)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if lore.Content != entries[1].Content || lore.ID != first.Entries[1].LoreID {
+		t.Errorf("updated lore = %+v", lore)
+	}
+	if stats, _ := s.Stats(); stats.LoreCount != 2 {
+		t.Errorf("LoreCount = %d, want 2", stats.LoreCount)
+	}
+}
+
+func TestClient_Seed_DeniedCategory(t *testing.T) {
+	dir := t.TempDir()
+	writeSeedFile(t, dir, "seed.yaml", "- key: a\n  category: ARCHITECTURAL_DECISION\n  content: x\n")
+
+	client := newTestClient(t, Config{
+		Actor:        "bot",
+		AccessPolicy: &AccessPolicy{Actors: map[string]ActorPolicy{"bot": {Categories: []Category{CategoryTestingStrategy}}}},
+	})
+
+	if _, err := client.Seed(dir, SeedOptions{}); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("Seed err = %v, want ErrPermissionDenied", err)
+	}
+}
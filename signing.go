@@ -0,0 +1,31 @@
+package recall
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// SignatureHeader carries the HMAC signature of a push request body.
+const SignatureHeader = "X-Recall-Signature"
+
+// signaturePrefix identifies the algorithm used to compute the signature.
+const signaturePrefix = "sha256="
+
+// SignBody computes the X-Recall-Signature value for body using secret.
+// The format is "sha256=" followed by the hex-encoded HMAC-SHA256 digest.
+func SignBody(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature reports whether signature is a valid X-Recall-Signature
+// for body under secret. Comparison is constant-time.
+func VerifySignature(secret, body []byte, signature string) bool {
+	if !strings.HasPrefix(signature, signaturePrefix) {
+		return false
+	}
+	return hmac.Equal([]byte(SignBody(secret, body)), []byte(signature))
+}
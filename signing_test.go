@@ -0,0 +1,140 @@
+package recall
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSignBody_KnownVector(t *testing.T) {
+	// RFC 4231 test case 2
+	got := SignBody([]byte("Jefe"), []byte("what do ya want for nothing?"))
+	want := "sha256=5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843"
+	if got != want {
+		t.Errorf("SignBody = %q, want %q", got, want)
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	secret := []byte("shared-secret")
+	body := []byte(`{"push_id":"abc"}`)
+	sig := SignBody(secret, body)
+
+	if !VerifySignature(secret, body, sig) {
+		t.Error("VerifySignature rejected a valid signature")
+	}
+	if VerifySignature([]byte("other"), body, sig) {
+		t.Error("VerifySignature accepted a signature from a different secret")
+	}
+	if VerifySignature(secret, []byte(`{"push_id":"tampered"}`), sig) {
+		t.Error("VerifySignature accepted a tampered body")
+	}
+	if VerifySignature(secret, body, sig[len("sha256="):]) {
+		t.Error("VerifySignature accepted a signature without algorithm prefix")
+	}
+}
+
+// insertBulkChangeLog writes n change_log rows for the store's source in one transaction.
+func insertBulkChangeLog(t *testing.T, store *Store, n int) {
+	t.Helper()
+	tx, err := store.db.Begin()
+	if err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+	for i := 0; i < n; i++ {
+		payload := []byte(`{"id":"bulk"}`)
+		if err := store.appendChangeLog(tx, "lore_entries", "bulk", "upsert", payload, store.SourceID()); err != nil {
+			_ = tx.Rollback()
+			t.Fatalf("appendChangeLog: %v", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+}
+
+func TestSyncPush_SignsEachChunkedBatch(t *testing.T) {
+	store := newTestStore(t)
+	insertBulkChangeLog(t, store, syncPushBatchSize+5)
+
+	secret := "push-secret"
+	var batches int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if !VerifySignature([]byte(secret), body, r.Header.Get(SignatureHeader)) {
+			t.Errorf("batch %d: invalid signature %q", batches+1, r.Header.Get(SignatureHeader))
+		}
+		var req SyncPushRequest
+		_ = json.Unmarshal(body, &req)
+		batches++
+		_ = json.NewEncoder(w).Encode(SyncPushResponse{Accepted: len(req.Entries)})
+	}))
+	defer server.Close()
+
+	syncer := newTestSyncer(t, store, server.URL)
+	syncer.SetSigningSecret(secret)
+
+	result, err := syncer.SyncPush(context.Background())
+	if err != nil {
+		t.Fatalf("SyncPush: %v", err)
+	}
+	if batches != 2 {
+		t.Errorf("batches = %d, want 2", batches)
+	}
+	if result.EntriesPushed != syncPushBatchSize+5 {
+		t.Errorf("EntriesPushed = %d, want %d", result.EntriesPushed, syncPushBatchSize+5)
+	}
+}
+
+func TestSyncPush_FeedbackChangeIsSigned(t *testing.T) {
+	store := newTestStore(t)
+	insertTestChangeLogEntries(t, store, 1)
+	if _, err := store.ApplyFeedback("01TESTID_PUSH_00001", ConfidenceHelpfulDelta, true); err != nil {
+		t.Fatalf("ApplyFeedback: %v", err)
+	}
+
+	var signature string
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		signature = r.Header.Get(SignatureHeader)
+		_ = json.NewEncoder(w).Encode(SyncPushResponse{Accepted: 2})
+	}))
+	defer server.Close()
+
+	syncer := newTestSyncer(t, store, server.URL)
+	syncer.SetSigningSecret("s3cret")
+
+	if _, err := syncer.SyncPush(context.Background()); err != nil {
+		t.Fatalf("SyncPush: %v", err)
+	}
+	if !VerifySignature([]byte("s3cret"), body, signature) {
+		t.Errorf("feedback push signature %q does not verify", signature)
+	}
+}
+
+func TestSyncPush_NoSigningSecret_NoHeader(t *testing.T) {
+	store := newTestStore(t)
+	insertTestChangeLogEntries(t, store, 1)
+
+	headerPresent := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headerPresent = r.Header.Get(SignatureHeader) != ""
+		_ = json.NewEncoder(w).Encode(SyncPushResponse{Accepted: 1})
+	}))
+	defer server.Close()
+
+	syncer := newTestSyncer(t, store, server.URL)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := syncer.SyncPush(ctx); err != nil {
+		t.Fatalf("SyncPush: %v", err)
+	}
+	if headerPresent {
+		t.Errorf("%s should be omitted when no secret is configured", SignatureHeader)
+	}
+}
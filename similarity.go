@@ -140,3 +140,15 @@ func NormalizeEmbedding(v []float32) []float32 {
 	}
 	return result
 }
+
+// aboveSimilarity keeps the entries of lore whose similarity is at least
+// threshold, in order, and returns how many it dropped.
+func aboveSimilarity(lore []Lore, similarity map[string]float64, threshold float64) ([]Lore, int) {
+	kept := lore[:0]
+	for _, l := range lore {
+		if similarity[l.ID] >= threshold {
+			kept = append(kept, l)
+		}
+	}
+	return kept, len(lore) - len(kept)
+}
@@ -1,8 +1,11 @@
 package recall
 
 import (
+	"context"
+	"errors"
 	"math"
 	"testing"
+	"time"
 )
 
 // =============================================================================
@@ -357,3 +360,45 @@ func TestSearch_ScoreIsFloat64(t *testing.T) {
 		t.Errorf("Score for identical vectors = %v, want 1.0", result[0].Score)
 	}
 }
+
+func TestClient_Query_MinSimilarity(t *testing.T) {
+	client := newTestClient(t, Config{SourceID: "test"})
+
+	now := time.Now().UTC()
+	for id, embedding := range map[string][]float32{
+		"close":   {1, 0.1},
+		"related": {1, 1},
+		"noise":   {0, 1},
+	} {
+		l := &Lore{ID: id, Content: id, Category: CategoryPatternOutcome, Confidence: 0.5, SourceID: "test",
+			CreatedAt: now, UpdatedAt: now, Embedding: PackFloat32(embedding)}
+		if err := client.store.InsertLore(l); err != nil {
+			t.Fatalf("InsertLore() error = %v", err)
+		}
+	}
+
+	threshold := 0.6
+	result, err := client.Query(context.Background(), QueryParams{Query: "q", QueryEmbedding: []float32{1, 0}, K: 3, MinSimilarity: &threshold})
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+	if len(result.Lore) != 2 || result.Lore[0].ID != "close" || result.Lore[1].ID != "related" {
+		t.Errorf("Query() = %v, want close and related only", result.Lore)
+	}
+	if result.Stats.BelowSimilarity != 1 {
+		t.Errorf("BelowSimilarity = %d, want 1", result.Stats.BelowSimilarity)
+	}
+
+	// Nothing relevant: no results rather than noise
+	novel := 0.99
+	result, err = client.Query(context.Background(), QueryParams{Query: "q", QueryEmbedding: []float32{-1, 0}, K: 3, MinSimilarity: &novel})
+	if err != nil || len(result.Lore) != 0 {
+		t.Errorf("Query(novel) = %v, %v; want no results", result, err)
+	}
+
+	bad := 1.5
+	var verr *ValidationError
+	if _, err := client.Query(context.Background(), QueryParams{Query: "q", MinSimilarity: &bad}); !errors.As(err, &verr) {
+		t.Errorf("Query(MinSimilarity 1.5) error = %v, want a ValidationError", err)
+	}
+}
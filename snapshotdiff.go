@@ -0,0 +1,210 @@
+package recall
+
+import (
+	"fmt"
+	"os"
+	"sort"
+)
+
+// SnapshotDiff describes what replacing one snapshot with another would
+// change, for reviewing a bootstrap before applying it.
+type SnapshotDiff struct {
+	OldCount int `json:"old_count"`
+	NewCount int `json:"new_count"`
+
+	// Added and Removed are entries present in only one snapshot.
+	Added   []Lore `json:"added"`
+	Removed []Lore `json:"removed"`
+
+	// Changed are entries present in both whose content, context, category
+	// or confidence differ.
+	Changed []SnapshotChange `json:"changed"`
+
+	// Unchanged is the number of entries identical in both snapshots.
+	Unchanged int `json:"unchanged"`
+
+	ConfidenceDrift ConfidenceDrift `json:"confidence_drift"`
+	CategoryChurn   []CategoryChurn `json:"category_churn"`
+}
+
+// SnapshotChange is an entry whose fields differ between two snapshots.
+type SnapshotChange struct {
+	ID     string   `json:"id"`
+	Old    Lore     `json:"old"`
+	New    Lore     `json:"new"`
+	Fields []string `json:"fields"` // Names of the differing fields
+}
+
+// ConfidenceDrift summarizes confidence changes of entries present in both
+// snapshots.
+type ConfidenceDrift struct {
+	Increased   int           `json:"increased"`
+	Decreased   int           `json:"decreased"`
+	Unchanged   int           `json:"unchanged"`
+	MeanDelta   float64       `json:"mean_delta"`
+	MaxIncrease float64       `json:"max_increase"`
+	MaxDecrease float64       `json:"max_decrease"` // Most negative delta, or zero
+	Buckets     []DriftBucket `json:"buckets"`
+}
+
+// DriftBucket counts entries whose confidence delta falls in [Min, Max).
+type DriftBucket struct {
+	Label string  `json:"label"`
+	Min   float64 `json:"min"`
+	Max   float64 `json:"max"`
+	Count int     `json:"count"`
+}
+
+// CategoryChurn describes how a category's entries change between snapshots.
+type CategoryChurn struct {
+	Category Category `json:"category"`
+	OldCount int      `json:"old_count"`
+	NewCount int      `json:"new_count"`
+	Added    int      `json:"added"`     // New entries in this category
+	Removed  int      `json:"removed"`   // Entries dropped from this category
+	MovedIn  int      `json:"moved_in"`  // Existing entries recategorized into it
+	MovedOut int      `json:"moved_out"` // Existing entries recategorized out of it
+}
+
+// driftEpsilon treats confidence deltas smaller than this as unchanged,
+// matching the two-decimal precision of FieldDiff.
+const driftEpsilon = 0.005
+
+// newDriftBuckets returns the empty confidence-drift histogram.
+func newDriftBuckets() []DriftBucket {
+	return []DriftBucket{
+		{Label: "< -0.20", Min: -1, Max: -0.2},
+		{Label: "-0.20..-0.05", Min: -0.2, Max: -0.05},
+		{Label: "-0.05..+0.05", Min: -0.05, Max: 0.05},
+		{Label: "+0.05..+0.20", Min: 0.05, Max: 0.2},
+		{Label: ">= +0.20", Min: 0.2, Max: 1.01},
+	}
+}
+
+// DiffSnapshots compares two snapshot database files without modifying
+// either. Both Engram snapshots and Recall store databases are accepted;
+// deleted entries are ignored.
+func DiffSnapshots(oldPath, newPath string) (*SnapshotDiff, error) {
+	oldLore, err := loadSnapshotFile(oldPath)
+	if err != nil {
+		return nil, err
+	}
+	newLore, err := loadSnapshotFile(newPath)
+	if err != nil {
+		return nil, err
+	}
+	return diffLoreSets(oldLore, newLore), nil
+}
+
+func loadSnapshotFile(path string) ([]Lore, error) {
+	// sql.Open would create a missing file; fail clearly instead
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("snapshot diff: %w", err)
+	}
+	lore, err := readSnapshotLore(path)
+	if err != nil {
+		return nil, fmt.Errorf("snapshot diff: %s: %w", path, err)
+	}
+	return lore, nil
+}
+
+func diffLoreSets(oldLore, newLore []Lore) *SnapshotDiff {
+	diff := &SnapshotDiff{
+		OldCount: len(oldLore),
+		NewCount: len(newLore),
+		Added:    []Lore{},
+		Removed:  []Lore{},
+		Changed:  []SnapshotChange{},
+	}
+	diff.ConfidenceDrift.Buckets = newDriftBuckets()
+
+	churn := make(map[Category]*CategoryChurn)
+	churnFor := func(c Category) *CategoryChurn {
+		if churn[c] == nil {
+			churn[c] = &CategoryChurn{Category: c}
+		}
+		return churn[c]
+	}
+
+	oldByID := make(map[string]*Lore, len(oldLore))
+	for i := range oldLore {
+		oldByID[oldLore[i].ID] = &oldLore[i]
+		churnFor(oldLore[i].Category).OldCount++
+	}
+
+	var deltaSum float64
+	drift := &diff.ConfidenceDrift
+	for i := range newLore {
+		n := &newLore[i]
+		churnFor(n.Category).NewCount++
+
+		o, ok := oldByID[n.ID]
+		if !ok {
+			diff.Added = append(diff.Added, *n)
+			churnFor(n.Category).Added++
+			continue
+		}
+		delete(oldByID, n.ID)
+
+		if o.Category != n.Category {
+			churnFor(o.Category).MovedOut++
+			churnFor(n.Category).MovedIn++
+		}
+
+		delta := n.Confidence - o.Confidence
+		deltaSum += delta
+		switch {
+		case delta >= driftEpsilon:
+			drift.Increased++
+		case delta <= -driftEpsilon:
+			drift.Decreased++
+		default:
+			drift.Unchanged++
+		}
+		if delta > drift.MaxIncrease {
+			drift.MaxIncrease = delta
+		}
+		if delta < drift.MaxDecrease {
+			drift.MaxDecrease = delta
+		}
+		for b := range drift.Buckets {
+			if delta < drift.Buckets[b].Max || b == len(drift.Buckets)-1 {
+				drift.Buckets[b].Count++
+				break
+			}
+		}
+
+		fields := loreFieldDiffs(o, n)
+		if len(fields) == 0 {
+			diff.Unchanged++
+			continue
+		}
+		change := SnapshotChange{ID: n.ID, Old: *o, New: *n}
+		for _, f := range fields {
+			change.Fields = append(change.Fields, f.Field)
+		}
+		diff.Changed = append(diff.Changed, change)
+	}
+
+	if compared := drift.Increased + drift.Decreased + drift.Unchanged; compared > 0 {
+		drift.MeanDelta = deltaSum / float64(compared)
+	}
+
+	for _, o := range oldByID {
+		diff.Removed = append(diff.Removed, *o)
+		churnFor(o.Category).Removed++
+	}
+
+	sort.Slice(diff.Added, func(i, j int) bool { return diff.Added[i].ID < diff.Added[j].ID })
+	sort.Slice(diff.Removed, func(i, j int) bool { return diff.Removed[i].ID < diff.Removed[j].ID })
+	sort.Slice(diff.Changed, func(i, j int) bool { return diff.Changed[i].ID < diff.Changed[j].ID })
+
+	diff.CategoryChurn = make([]CategoryChurn, 0, len(churn))
+	for _, c := range churn {
+		diff.CategoryChurn = append(diff.CategoryChurn, *c)
+	}
+	sort.Slice(diff.CategoryChurn, func(i, j int) bool {
+		return diff.CategoryChurn[i].Category < diff.CategoryChurn[j].Category
+	})
+	return diff
+}
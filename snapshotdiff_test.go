@@ -0,0 +1,80 @@
+package recall
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeSnapshotFile(t *testing.T, entries ...Lore) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "snapshot.db")
+	s, err := NewStore(path)
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	defer s.Close()
+	now := time.Now().UTC()
+	for i := range entries {
+		entries[i].CreatedAt, entries[i].UpdatedAt = now, now
+		if err := s.InsertLore(&entries[i]); err != nil {
+			t.Fatalf("InsertLore: %v", err)
+		}
+	}
+	return path
+}
+
+func TestDiffSnapshots(t *testing.T) {
+	oldPath := writeSnapshotFile(t,
+		Lore{ID: "A", Content: "kept", Category: CategoryPatternOutcome, Confidence: 0.5},
+		Lore{ID: "B", Content: "dropped", Category: CategoryPatternOutcome, Confidence: 0.5},
+		Lore{ID: "C", Content: "moved", Category: CategoryPatternOutcome, Confidence: 0.8},
+	)
+	newPath := writeSnapshotFile(t,
+		Lore{ID: "A", Content: "kept", Category: CategoryPatternOutcome, Confidence: 0.5},
+		Lore{ID: "C", Content: "moved", Category: CategoryTestingStrategy, Confidence: 0.5},
+		Lore{ID: "D", Content: "new", Category: CategoryTestingStrategy, Confidence: 0.6},
+	)
+
+	diff, err := DiffSnapshots(oldPath, newPath)
+	if err != nil {
+		t.Fatalf("DiffSnapshots: %v", err)
+	}
+
+	if len(diff.Added) != 1 || diff.Added[0].ID != "D" {
+		t.Errorf("Added = %+v, want D", diff.Added)
+	}
+	if len(diff.Removed) != 1 || diff.Removed[0].ID != "B" {
+		t.Errorf("Removed = %+v, want B", diff.Removed)
+	}
+	if len(diff.Changed) != 1 || diff.Changed[0].ID != "C" || len(diff.Changed[0].Fields) != 2 {
+		t.Errorf("Changed = %+v, want C with category and confidence", diff.Changed)
+	}
+	if diff.Unchanged != 1 {
+		t.Errorf("Unchanged = %d, want 1", diff.Unchanged)
+	}
+
+	d := diff.ConfidenceDrift
+	if d.Decreased != 1 || d.Unchanged != 1 || d.MaxDecrease > -0.29 || d.Buckets[0].Count != 1 || d.Buckets[2].Count != 1 {
+		t.Errorf("ConfidenceDrift = %+v", d)
+	}
+
+	churn := make(map[Category]CategoryChurn)
+	for _, c := range diff.CategoryChurn {
+		churn[c.Category] = c
+	}
+	if c := churn[CategoryPatternOutcome]; c.OldCount != 3 || c.NewCount != 1 || c.Removed != 1 || c.MovedOut != 1 {
+		t.Errorf("PATTERN_OUTCOME churn = %+v", c)
+	}
+	if c := churn[CategoryTestingStrategy]; c.NewCount != 2 || c.Added != 1 || c.MovedIn != 1 {
+		t.Errorf("TESTING_STRATEGY churn = %+v", c)
+	}
+}
+
+func TestDiffSnapshots_MissingFile(t *testing.T) {
+	existing := writeSnapshotFile(t)
+	missing := filepath.Join(t.TempDir(), "missing.db")
+	if _, err := DiffSnapshots(existing, missing); err == nil {
+		t.Error("DiffSnapshots should fail for a missing file")
+	}
+}
@@ -0,0 +1,182 @@
+package recall
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HealthState summarizes a store's sync backlog against Config.SoftQuota.
+type HealthState string
+
+const (
+	// HealthOK means every measure is within its soft quota.
+	HealthOK HealthState = "ok"
+
+	// HealthDegraded means a measure exceeds its soft quota.
+	HealthDegraded HealthState = "degraded"
+
+	// HealthCritical means a measure exceeds twice its soft quota.
+	HealthCritical HealthState = "critical"
+)
+
+// severity orders health states from OK to Critical.
+func (h HealthState) severity() int {
+	switch h {
+	case HealthDegraded:
+		return 1
+	case HealthCritical:
+		return 2
+	}
+	return 0
+}
+
+// Measures compared against a SoftQuota, as named in QuotaWarning.Measure
+// and ParseSoftQuota.
+const (
+	QuotaPendingSync      = "pending_sync"
+	QuotaChangeLogEntries = "change_log_entries"
+	QuotaChangeLogBytes   = "change_log_bytes"
+)
+
+// quotaCheckInterval throttles the soft quota check after writes.
+const quotaCheckInterval = time.Minute
+
+// SoftQuota sets thresholds beyond which the store is reported Degraded,
+// and Critical beyond twice the threshold. Nothing is refused; the
+// thresholds let applications nudge users to reconnect or prune. Zero
+// disables a threshold.
+type SoftQuota struct {
+	// MaxPendingSync limits work waiting for Engram: unpushed change_log
+	// entries plus queued operations.
+	MaxPendingSync int
+
+	// MaxChangeLogEntries limits the size of the local change_log.
+	MaxChangeLogEntries int
+
+	// MaxChangeLogBytes limits the payload bytes held in the change_log.
+	MaxChangeLogBytes int64
+}
+
+// QuotaWarning reports one measure over its soft quota.
+type QuotaWarning struct {
+	Measure string      `json:"measure"`
+	Value   int64       `json:"value"`
+	Limit   int64       `json:"limit"`
+	State   HealthState `json:"state"`
+}
+
+// QuotaEvent reports a change of HealthState.
+type QuotaEvent struct {
+	Previous HealthState
+	Current  HealthState
+	Warnings []QuotaWarning
+}
+
+// QuotaEventFunc is called when the store's HealthState changes.
+type QuotaEventFunc func(QuotaEvent)
+
+// ParseSoftQuota parses a comma-separated list of measure=limit pairs,
+// e.g. "pending_sync=500,change_log_bytes=50000000", as used by
+// RECALL_SOFT_QUOTA.
+func ParseSoftQuota(s string) (SoftQuota, error) {
+	var q SoftQuota
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		measure, limit, ok := strings.Cut(pair, "=")
+		if !ok {
+			return SoftQuota{}, &ValidationError{Field: "SoftQuota", Message: fmt.Sprintf("%q: want measure=limit", pair)}
+		}
+		n, err := strconv.ParseInt(strings.TrimSpace(limit), 10, 64)
+		if err != nil {
+			return SoftQuota{}, &ValidationError{Field: "SoftQuota", Message: fmt.Sprintf("%q: invalid limit", pair)}
+		}
+		switch strings.TrimSpace(measure) {
+		case QuotaPendingSync:
+			q.MaxPendingSync = int(n)
+		case QuotaChangeLogEntries:
+			q.MaxChangeLogEntries = int(n)
+		case QuotaChangeLogBytes:
+			q.MaxChangeLogBytes = n
+		default:
+			return SoftQuota{}, &ValidationError{Field: "SoftQuota", Message: fmt.Sprintf("unknown measure %q", measure)}
+		}
+	}
+	return q, q.validate()
+}
+
+// validate rejects negative thresholds.
+func (q SoftQuota) validate() error {
+	if q.MaxPendingSync < 0 || q.MaxChangeLogEntries < 0 || q.MaxChangeLogBytes < 0 {
+		return &ValidationError{Field: "SoftQuota", Message: "limits must be non-negative"}
+	}
+	return nil
+}
+
+// evaluate compares stats against the quota and returns the overall state
+// and a warning per measure over its threshold.
+func (q SoftQuota) evaluate(stats *StoreStats) (HealthState, []QuotaWarning) {
+	state := HealthOK
+	var warnings []QuotaWarning
+	check := func(measure string, value, limit int64) {
+		if limit <= 0 || value <= limit {
+			return
+		}
+		w := QuotaWarning{Measure: measure, Value: value, Limit: limit, State: HealthDegraded}
+		if value > 2*limit {
+			w.State = HealthCritical
+		}
+		if w.State.severity() > state.severity() {
+			state = w.State
+		}
+		warnings = append(warnings, w)
+	}
+	check(QuotaPendingSync, int64(stats.PendingSync+stats.UnpushedChanges), int64(q.MaxPendingSync))
+	check(QuotaChangeLogEntries, int64(stats.ChangeLogEntries), int64(q.MaxChangeLogEntries))
+	check(QuotaChangeLogBytes, stats.ChangeLogBytes, q.MaxChangeLogBytes)
+	return state, warnings
+}
+
+// applyQuota sets the health fields of stats and reports a change of
+// state to Config.OnQuotaWarning.
+func (c *Client) applyQuota(stats *StoreStats) {
+	stats.Health, stats.Warnings = c.config.SoftQuota.evaluate(stats)
+
+	c.quotaMu.Lock()
+	previous := c.quotaState
+	if previous == "" {
+		previous = HealthOK
+	}
+	c.quotaState = stats.Health
+	c.quotaCheckedAt = time.Now()
+	c.quotaMu.Unlock()
+
+	if stats.Health != previous && c.config.OnQuotaWarning != nil {
+		c.config.OnQuotaWarning(QuotaEvent{Previous: previous, Current: stats.Health, Warnings: stats.Warnings})
+	}
+}
+
+// checkQuota re-evaluates the soft quota. Unless force is set, it does so
+// at most once per quotaCheckInterval.
+func (c *Client) checkQuota(force bool) {
+	if c.config.SoftQuota == (SoftQuota{}) {
+		return
+	}
+	c.quotaMu.Lock()
+	due := force || time.Since(c.quotaCheckedAt) >= quotaCheckInterval
+	c.quotaMu.Unlock()
+	if !due {
+		return
+	}
+
+	stats, err := c.store.Stats()
+	if err != nil {
+		c.debug.LogError("quota check", err)
+		return
+	}
+	c.applyQuota(stats)
+}
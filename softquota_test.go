@@ -0,0 +1,85 @@
+package recall
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestParseSoftQuota(t *testing.T) {
+	q, err := ParseSoftQuota("pending_sync=500, change_log_entries=10000,change_log_bytes=5000000")
+	if err != nil {
+		t.Fatalf("ParseSoftQuota: %v", err)
+	}
+	want := SoftQuota{MaxPendingSync: 500, MaxChangeLogEntries: 10000, MaxChangeLogBytes: 5000000}
+	if q != want {
+		t.Errorf("quota = %+v, want %+v", q, want)
+	}
+
+	for _, bad := range []string{"pending_sync", "pending_sync=lots", "disk=5", "pending_sync=-1"} {
+		var ve *ValidationError
+		if _, err := ParseSoftQuota(bad); !errors.As(err, &ve) {
+			t.Errorf("ParseSoftQuota(%q) error = %v, want ValidationError", bad, err)
+		}
+	}
+}
+
+func TestClient_SoftQuota(t *testing.T) {
+	var events []QuotaEvent
+	client := newTestClient(t, Config{
+		SoftQuota:      SoftQuota{MaxPendingSync: 2},
+		OnQuotaWarning: func(ev QuotaEvent) { events = append(events, ev) },
+	})
+
+	record := func(n int) {
+		for i := 0; i < n; i++ {
+			if _, err := client.Record(fmt.Sprintf("Quota lore %d %d", len(events), i), CategoryPatternOutcome); err != nil {
+				t.Fatalf("Record: %v", err)
+			}
+		}
+	}
+
+	record(2)
+	stats, err := client.Stats()
+	if err != nil {
+		t.Fatalf("Stats: %v", err)
+	}
+	if stats.Health != HealthOK || stats.UnpushedChanges != 2 || stats.ChangeLogEntries != 2 || stats.ChangeLogBytes == 0 {
+		t.Errorf("stats = %s, %d unpushed, %d entries, %d bytes", stats.Health, stats.UnpushedChanges, stats.ChangeLogEntries, stats.ChangeLogBytes)
+	}
+	if len(events) != 0 {
+		t.Fatalf("events = %+v, want none within quota", events)
+	}
+
+	record(1)
+	stats, _ = client.Stats()
+	if stats.Health != HealthDegraded || len(stats.Warnings) != 1 || stats.Warnings[0].Measure != QuotaPendingSync {
+		t.Errorf("stats = %s %+v, want degraded on pending_sync", stats.Health, stats.Warnings)
+	}
+
+	record(2)
+	stats, _ = client.Stats()
+	if stats.Health != HealthCritical {
+		t.Errorf("Health = %s, want critical past twice the limit", stats.Health)
+	}
+
+	if len(events) != 2 ||
+		events[0].Previous != HealthOK || events[0].Current != HealthDegraded ||
+		events[1].Previous != HealthDegraded || events[1].Current != HealthCritical {
+		t.Errorf("events = %+v, want ok→degraded→critical", events)
+	}
+
+	// An unchanged state is not reported again.
+	_, _ = client.Stats()
+	if len(events) != 2 {
+		t.Errorf("got %d events, want no repeat for an unchanged state", len(events))
+	}
+}
+
+func TestConfig_Validate_SoftQuota(t *testing.T) {
+	cfg := Config{LocalPath: "lore.db", SoftQuota: SoftQuota{MaxChangeLogBytes: -1}}
+	var ve *ValidationError
+	if err := cfg.Validate(); !errors.As(err, &ve) || ve.Field != "SoftQuota" {
+		t.Errorf("Validate() = %v, want SoftQuota ValidationError", err)
+	}
+}
@@ -0,0 +1,238 @@
+package recall
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// starterPackFS holds the curated packs shipped with recall.
+//
+//go:embed starterpacks/*.yaml
+var starterPackFS embed.FS
+
+// StarterPackSourcePrefix prefixes the source that marks lore inserted
+// from a starter pack; see StarterPackSource.
+const StarterPackSourcePrefix = "starter-pack:"
+
+// MaxStarterPackBytes is the most LoadStarterPack reads from a registry.
+const MaxStarterPackBytes = 1 << 20
+
+// starterPackFetchTimeout bounds a registry fetch when ctx has no deadline.
+const starterPackFetchTimeout = 30 * time.Second
+
+var starterPackNameRE = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// StarterPackSource returns the source recorded in Lore.Sources of lore
+// inserted from the named pack, so it can be found and removed later.
+func StarterPackSource(name string) string {
+	return StarterPackSourcePrefix + name
+}
+
+// StarterPacks returns the names of the embedded starter packs, sorted.
+func StarterPacks() []string {
+	files, _ := fs.Glob(starterPackFS, "starterpacks/*.yaml")
+	names := make([]string, len(files))
+	for i, f := range files {
+		names[i] = strings.TrimSuffix(path.Base(f), ".yaml")
+	}
+	slices.Sort(names)
+	return names
+}
+
+// LoadStarterPack reads the named starter pack: <registry>/<name>.yaml
+// when registry is set, or the embedded pack otherwise. Packs use the seed
+// YAML format. Keys are prefixed with the pack's source so they cannot
+// collide with a repository's own seed entries.
+func LoadStarterPack(ctx context.Context, name, registry string) ([]SeedEntry, error) {
+	if !starterPackNameRE.MatchString(name) {
+		return nil, &ValidationError{Field: "Pack", Message: fmt.Sprintf("invalid name %q: must be lowercase letters, digits and hyphens", name)}
+	}
+
+	var data []byte
+	var file string
+	var err error
+	if registry != "" {
+		file = strings.TrimSuffix(registry, "/") + "/" + name + ".yaml"
+		data, err = fetchStarterPack(ctx, file)
+	} else {
+		file = "starterpacks/" + name + ".yaml"
+		data, err = starterPackFS.ReadFile(file)
+		if err != nil {
+			return nil, &ValidationError{Field: "Pack", Message: fmt.Sprintf("unknown pack %q: must be one of %s", name, strings.Join(StarterPacks(), ", "))}
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := parseSeedYAMLData(file, data)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool, len(entries))
+	for i := range entries {
+		e := &entries[i]
+		e.File = file
+		if err := e.validate(); err != nil {
+			return nil, err
+		}
+		if seen[e.Key] {
+			return nil, &ValidationError{Field: "Key", Message: fmt.Sprintf("duplicate key %q in %s", e.Key, file)}
+		}
+		seen[e.Key] = true
+		e.source = StarterPackSource(name)
+		e.Key = e.source + "/" + e.Key
+	}
+	return entries, nil
+}
+
+// fetchStarterPack GETs a pack from a registry.
+func fetchStarterPack(ctx context.Context, packURL string) ([]byte, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, starterPackFetchTimeout)
+		defer cancel()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, packURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("seed: fetch %s: %w", packURL, err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("seed: fetch %s: %w", packURL, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("seed: fetch %s: %s", packURL, resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, MaxStarterPackBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("seed: fetch %s: %w", packURL, err)
+	}
+	if len(data) > MaxStarterPackBytes {
+		return nil, fmt.Errorf("seed: fetch %s: pack exceeds %d bytes", packURL, MaxStarterPackBytes)
+	}
+	return data, nil
+}
+
+// DeleteLoreBySource soft-deletes the live lore in the namespace whose
+// Sources include source, and forgets the seed entries keyed under it so
+// a later seed inserts them afresh. It returns how many entries were
+// deleted.
+func (s *Store) DeleteLoreBySource(source string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return 0, ErrStoreClosed
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("store: begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	rows, err := tx.Query(`
+		SELECT id FROM lore_entries
+		WHERE deleted_at IS NULL AND namespace = ? AND ',' || sources || ',' LIKE ?
+	`, s.namespace, "%,"+source+",%")
+	if err != nil {
+		return 0, fmt.Errorf("store: query lore by source: %w", err)
+	}
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			_ = rows.Close()
+			return 0, fmt.Errorf("store: scan lore by source: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("store: iterate lore by source: %w", err)
+	}
+
+	now := s.now().Format(time.RFC3339)
+	for _, id := range ids {
+		if _, err := tx.Exec(`
+			UPDATE lore_entries SET deleted_at = ?, updated_at = ? WHERE id = ?
+		`, now, now, id); err != nil {
+			return 0, fmt.Errorf("store: soft delete lore: %w", err)
+		}
+		if err := s.holdLoreTx(tx, HoldOriginDelete, "id = ? AND deleted_at = ?", id, now); err != nil {
+			return 0, err
+		}
+		if err := s.appendChangeLog(tx, "lore_entries", id, "delete", nil, s.sourceID); err != nil {
+			return 0, err
+		}
+	}
+
+	if _, err := tx.Exec(`DELETE FROM seed_entries WHERE namespace = ? AND key LIKE ?`, s.namespace, source+"/%"); err != nil {
+		return 0, fmt.Errorf("store: forget seed entries: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("store: commit: %w", err)
+	}
+	return len(ids), nil
+}
+
+// SeedStarterPack applies the named starter pack like Seed applies a seed
+// directory: idempotently, linking entries whose content already exists.
+// Lore it inserts is marked with StarterPackSource(name), so
+// RemoveStarterPack can take it out again. With opts.Registry set, the
+// pack is fetched from <Registry>/<name>.yaml instead of the packs
+// embedded in recall (see StarterPacks).
+func (c *Client) SeedStarterPack(ctx context.Context, name string, opts SeedOptions) (*SeedResult, error) {
+	entries, err := LoadStarterPack(ctx, name, opts.Registry)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		if err := c.authorize(ActionRecord, e.Category); err != nil {
+			return nil, err
+		}
+	}
+	result, err := c.store.ApplySeed(entries, opts.DryRun)
+	if err != nil {
+		return nil, fmt.Errorf("client: seed starter pack: %w", err)
+	}
+	return result, nil
+}
+
+// RemoveStarterPack deletes the lore the named starter pack inserted in
+// the current namespace and returns how many entries were deleted. Lore
+// the pack adopted because it already existed is kept. The deletes are
+// pushed with the next sync. It requires ActionDelete under an
+// AccessPolicy.
+func (c *Client) RemoveStarterPack(name string) (int, error) {
+	if !starterPackNameRE.MatchString(name) {
+		return 0, &ValidationError{Field: "Pack", Message: fmt.Sprintf("invalid name %q: must be lowercase letters, digits and hyphens", name)}
+	}
+	if err := c.authorize(ActionDelete, ""); err != nil {
+		return 0, err
+	}
+	release, err := c.store.enter(context.Background())
+	if err != nil {
+		return 0, err
+	}
+	defer release()
+
+	n, err := c.store.DeleteLoreBySource(StarterPackSource(name))
+	if err != nil {
+		return 0, fmt.Errorf("client: remove starter pack: %w", err)
+	}
+	return n, nil
+}
@@ -0,0 +1,106 @@
+package recall
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+func TestStarterPacks_Embedded(t *testing.T) {
+	packs := StarterPacks()
+	if !slices.Equal(packs, []string{"go-service", "react-app", "terraform"}) {
+		t.Errorf("StarterPacks() = %v", packs)
+	}
+	for _, name := range packs {
+		entries, err := LoadStarterPack(context.Background(), name, "")
+		if err != nil {
+			t.Errorf("LoadStarterPack(%s) error = %v", name, err)
+			continue
+		}
+		if len(entries) == 0 || entries[0].Key[:len(StarterPackSource(name))+1] != StarterPackSource(name)+"/" {
+			t.Errorf("LoadStarterPack(%s) = %d entries, first key %q", name, len(entries), entries[0].Key)
+		}
+	}
+
+	var verr *ValidationError
+	for _, name := range []string{"rust-cli", "../seed", "Go"} {
+		if _, err := LoadStarterPack(context.Background(), name, ""); !errors.As(err, &verr) || verr.Field != "Pack" {
+			t.Errorf("LoadStarterPack(%q) error = %v, want a Pack validation error", name, err)
+		}
+	}
+}
+
+func TestClient_SeedStarterPack(t *testing.T) {
+	client := newTestClient(t, Config{SourceID: "test"})
+
+	entries, _ := LoadStarterPack(context.Background(), "terraform", "")
+	// The team already knows one of the pack's lessons
+	known, err := client.Record(entries[0].Content, entries[0].Category)
+	if err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+
+	result, err := client.SeedStarterPack(context.Background(), "terraform", SeedOptions{})
+	if err != nil {
+		t.Fatalf("SeedStarterPack() error = %v", err)
+	}
+	if result.Inserted != len(entries)-1 || result.Adopted != 1 {
+		t.Errorf("SeedStarterPack() = %+v", result)
+	}
+	for _, e := range result.Entries {
+		lore, err := client.store.Get(e.LoreID)
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		marked := slices.Contains(lore.Sources, "starter-pack:terraform")
+		if marked != (e.Action == SeedInserted) {
+			t.Errorf("%s (%s): Sources = %v", e.Key, e.Action, lore.Sources)
+		}
+	}
+	if again, _ := client.SeedStarterPack(context.Background(), "terraform", SeedOptions{}); again.Unchanged != len(entries) {
+		t.Errorf("second SeedStarterPack() = %+v, want all unchanged", again)
+	}
+
+	removed, err := client.RemoveStarterPack("terraform")
+	if err != nil || removed != len(entries)-1 {
+		t.Fatalf("RemoveStarterPack() = %d, %v; want %d", removed, err, len(entries)-1)
+	}
+	if stats, _ := client.store.Stats(); stats.LoreCount != 1 {
+		t.Errorf("LoreCount = %d after removal, want only the team's own entry", stats.LoreCount)
+	}
+	if _, err := client.store.Get(known.ID); err != nil {
+		t.Errorf("adopted lore was removed: %v", err)
+	}
+
+	// Re-applying a removed pack inserts it afresh
+	if again, _ := client.SeedStarterPack(context.Background(), "terraform", SeedOptions{}); again.Inserted != len(entries)-1 {
+		t.Errorf("SeedStarterPack() after removal = %+v", again)
+	}
+}
+
+func TestClient_SeedStarterPack_Registry(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/packs/platform.yaml" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte("- key: deploy-window\n  category: ARCHITECTURAL_DECISION\n  content: Deploys freeze on Fridays after 14:00.\n"))
+	}))
+	t.Cleanup(server.Close)
+
+	client := newTestClient(t, Config{})
+
+	result, err := client.SeedStarterPack(context.Background(), "platform", SeedOptions{Registry: server.URL + "/packs/"})
+	if err != nil {
+		t.Fatalf("SeedStarterPack() error = %v", err)
+	}
+	if result.Inserted != 1 || result.Entries[0].Key != "starter-pack:platform/deploy-window" {
+		t.Errorf("SeedStarterPack() = %+v", result)
+	}
+	if _, err := client.SeedStarterPack(context.Background(), "missing", SeedOptions{Registry: server.URL + "/packs"}); err == nil {
+		t.Error("SeedStarterPack() of a pack the registry lacks should fail")
+	}
+}
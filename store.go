@@ -4,16 +4,18 @@ import (
 	"crypto/rand"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/hyperengineering/recall/internal/store/migrations"
-	"github.com/oklog/ulid/v2"
 	"github.com/pressly/goose/v3"
 	_ "modernc.org/sqlite"
 )
@@ -22,15 +24,34 @@ const schemaVersion = "2"
 
 // Store manages the local SQLite lore database.
 type Store struct {
-	db       *sql.DB
-	mu       sync.RWMutex
-	closed   bool
-	path     string
-	sourceID string // cached from sync_meta for change_log writes
+	db        *sql.DB
+	mu        sync.RWMutex
+	closed    bool
+	path      string
+	sourceID  string      // cached from sync_meta for change_log writes
+	namespace string      // scopes reads and writes; empty is the default namespace
+	gate      opGate      // in-flight operations and exclusive maintenance
+	clock     Clock       // timestamps; nil is SystemClock
+	ids       IDGenerator // new lore IDs; nil is ULIDGenerator
+	lazy      bool        // opened by NewLazyStore; prepared on first connection
+
+	onChecksum ChecksumFunc // checksum mismatches; see SetChecksumHandler
 }
 
 // NewStore opens or creates a local lore store.
+//
+// If the database is corrupt, NewStore moves it aside, salvages what it can
+// into a fresh database, and returns the usable store together with a
+// *RecoveredError (errors.Is ErrRecovered) describing the recovery.
 func NewStore(path string) (*Store, error) {
+	store, err := openStore(path)
+	if err != nil && isCorrupt(err) {
+		return recoverStore(path, err)
+	}
+	return store, err
+}
+
+func openStore(path string) (*Store, error) {
 	// Ensure directory exists
 	dir := filepath.Dir(path)
 	if err := os.MkdirAll(dir, 0755); err != nil {
@@ -42,42 +63,79 @@ func NewStore(path string) (*Store, error) {
 		return nil, fmt.Errorf("open database: %w", err)
 	}
 
-	// Enable WAL mode for better concurrent access
-	if _, err := db.Exec("PRAGMA journal_mode=WAL"); err != nil {
+	store := &Store{db: db, path: path}
+	if err := store.prepare(); err != nil {
 		_ = db.Close()
-		return nil, fmt.Errorf("enable WAL mode: %w", err)
+		return nil, err
 	}
+	return store, nil
+}
 
-	store := &Store{db: db, path: path}
-	if err := store.migrate(); err != nil {
-		_ = db.Close()
-		return nil, fmt.Errorf("migrate schema: %w", err)
+// prepare readies a newly opened database: WAL mode, an integrity check,
+// migrations, and the cached source_id.
+func (s *Store) prepare() error {
+	// Enable WAL mode for better concurrent access
+	if _, err := s.db.Exec("PRAGMA journal_mode=WAL"); err != nil {
+		return fmt.Errorf("enable WAL mode: %w", err)
+	}
+	if err := quickCheck(s.db); err != nil {
+		return fmt.Errorf("check database: %w", err)
+	}
+	if err := s.migrate(); err != nil {
+		return fmt.Errorf("migrate schema: %w", err)
 	}
 
 	// Cache source_id for change_log writes
-	if err := store.loadSourceID(); err != nil {
-		_ = db.Close()
-		return nil, fmt.Errorf("load source_id: %w", err)
+	if err := s.loadSourceID(); err != nil {
+		return fmt.Errorf("load source_id: %w", err)
 	}
+	return nil
+}
 
-	return store, nil
+// latestMigration returns the version of the newest embedded migration.
+var latestMigration = sync.OnceValue(func() string {
+	entries, _ := fs.ReadDir(migrations.FS, ".")
+	var latest int64
+	for _, e := range entries {
+		prefix, _, _ := strings.Cut(e.Name(), "_")
+		if v, err := strconv.ParseInt(prefix, 10, 64); err == nil && v > latest {
+			latest = v
+		}
+	}
+	return strconv.FormatInt(latest, 10)
+})
+
+// migrationsCurrent reports whether the migration version recorded by the
+// last migrate is the newest embedded one, so goose can be skipped.
+func (s *Store) migrationsCurrent() bool {
+	var version string
+	err := s.db.QueryRow("SELECT value FROM metadata WHERE key = ?", metadataKeyMigrationVersion).Scan(&version)
+	return err == nil && version == latestMigration()
 }
 
 func (s *Store) migrate() error {
-	goose.SetLogger(goose.NopLogger())
-	goose.SetBaseFS(migrations.FS)
-	if err := goose.SetDialect("sqlite3"); err != nil {
-		return fmt.Errorf("store: set goose dialect: %w", err)
+	if !s.migrationsCurrent() {
+		goose.SetLogger(goose.NopLogger())
+		goose.SetBaseFS(migrations.FS)
+		if err := goose.SetDialect("sqlite3"); err != nil {
+			return fmt.Errorf("store: set goose dialect: %w", err)
+		}
+		if err := goose.Up(s.db, "."); err != nil {
+			return fmt.Errorf("store: run migrations: %w", err)
+		}
+	}
+	if err := s.backfillFingerprints(); err != nil {
+		return err
 	}
-	if err := goose.Up(s.db, "."); err != nil {
-		return fmt.Errorf("store: run migrations: %w", err)
+	if err := s.backfillChecksums(); err != nil {
+		return err
 	}
 
 	// Upsert schema version so existing databases get updated
 	_, err := s.db.Exec(`
-		INSERT INTO metadata (key, value) VALUES ('schema_version', ?)
+		INSERT INTO metadata (key, value) VALUES ('schema_version', ?), (?, ?)
 		ON CONFLICT(key) DO UPDATE SET value = excluded.value
-	`, schemaVersion)
+	`, schemaVersion, metadataKeyMigrationVersion, latestMigration())
 	if err != nil {
 		return err
 	}
@@ -138,14 +196,16 @@ func (s *Store) loadSourceID() error {
 	return nil
 }
 
-// SourceID returns the cached source_id for this store.
+// SourceID returns the cached source_id for this store. It is empty until
+// a lazy store has been opened; callers that need it call EnsureReady
+// first, which returns the open error.
 func (s *Store) SourceID() string {
 	return s.sourceID
 }
 
 // appendChangeLog inserts a change_log entry within a transaction.
-func appendChangeLog(tx *sql.Tx, tableName, entityID, operation string, payload []byte, sourceID string) error {
-	createdAt := time.Now().UTC().Format(time.RFC3339)
+func (s *Store) appendChangeLog(tx *sql.Tx, tableName, entityID, operation string, payload []byte, sourceID string) error {
+	createdAt := s.now().Format(time.RFC3339)
 	var payloadArg any
 	if payload != nil {
 		payloadArg = string(payload)
@@ -177,6 +237,7 @@ func lorePayloadJSON(lore *Lore) ([]byte, error) {
 		UpdatedAt       string   `json:"updated_at"`
 		DeletedAt       *string  `json:"deleted_at"`
 		LastValidatedAt *string  `json:"last_validated_at"`
+		Namespace       string   `json:"namespace,omitempty"`
 	}{
 		ID:              lore.ID,
 		Content:         lore.Content,
@@ -189,6 +250,7 @@ func lorePayloadJSON(lore *Lore) ([]byte, error) {
 		ValidationCount: lore.ValidationCount,
 		CreatedAt:       lore.CreatedAt.Format(time.RFC3339),
 		UpdatedAt:       lore.UpdatedAt.Format(time.RFC3339),
+		Namespace:       lore.Namespace,
 	}
 	if lore.DeletedAt != nil {
 		ts := lore.DeletedAt.Format(time.RFC3339)
@@ -207,20 +269,64 @@ func lorePayloadJSON(lore *Lore) ([]byte, error) {
 // InsertLore atomically inserts a lore entry and a change_log entry in one transaction.
 // This is the primary method for storing new lore (used by Client.Record).
 func (s *Store) InsertLore(lore *Lore) error {
+	_, err := s.insertLore(lore, false)
+	return err
+}
+
+// InsertLoreUnique is InsertLore with exact-duplicate detection. If a live
+// entry in the namespace has the same Fingerprint, it is returned and nothing
+// is inserted; otherwise the entry is inserted and nil is returned.
+func (s *Store) InsertLoreUnique(lore *Lore) (*Lore, error) {
+	return s.insertLore(lore, true)
+}
+
+func (s *Store) insertLore(lore *Lore, unique bool) (*Lore, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	if s.closed {
-		return ErrStoreClosed
+		return nil, ErrStoreClosed
 	}
 
 	// Begin transaction
 	tx, err := s.db.Begin()
 	if err != nil {
-		return fmt.Errorf("store: begin transaction: %w", err)
+		return nil, fmt.Errorf("store: begin transaction: %w", err)
 	}
 	defer func() { _ = tx.Rollback() }() // no-op if committed
 
+	if unique {
+		existing, err := s.findByFingerprintTx(tx, Fingerprint(lore.Content))
+		if err == nil {
+			return existing, nil
+		}
+		if !errors.Is(err, ErrNotFound) {
+			return nil, err
+		}
+	}
+
+	if err := s.recordLoreTx(tx, lore); err != nil {
+		return nil, err
+	}
+	if unique {
+		// Claim the fingerprint. The unique index rejects the claim if
+		// another connection recorded the same content since the lookup.
+		if _, err := tx.Exec(`UPDATE lore_entries SET dedup_fingerprint = fingerprint WHERE id = ?`, lore.ID); err != nil {
+			if !isUniqueViolation(err) {
+				return nil, fmt.Errorf("store: claim fingerprint: %w", err)
+			}
+			_ = tx.Rollback()
+			return s.findFingerprintClaim(Fingerprint(lore.Content))
+		}
+	}
+	return nil, tx.Commit()
+}
+
+// recordLoreTx inserts locally recorded lore, in the store's namespace,
+// and its change_log entry within a transaction.
+func (s *Store) recordLoreTx(tx *sql.Tx, lore *Lore) error {
+	lore.Namespace = s.namespace
+
 	var embeddingBlob []byte
 	if len(lore.Embedding) > 0 {
 		embeddingBlob = lore.Embedding
@@ -238,9 +344,9 @@ func (s *Store) InsertLore(lore *Lore) error {
 	if lore.EmbeddingStatus != "" {
 		embeddingStatus = lore.EmbeddingStatus
 	}
-	_, err = tx.Exec(`
-		INSERT INTO lore_entries (id, content, context, category, confidence, embedding, embedding_status, source_id, sources, validation_count, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	_, err := tx.Exec(`
+		INSERT INTO lore_entries (id, content, context, category, confidence, embedding, embedding_status, source_id, sources, validation_count, created_at, updated_at, namespace, fingerprint, checksum)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`,
 		lore.ID,
 		lore.Content,
@@ -254,6 +360,9 @@ func (s *Store) InsertLore(lore *Lore) error {
 		lore.ValidationCount,
 		lore.CreatedAt.Format(time.RFC3339),
 		lore.UpdatedAt.Format(time.RFC3339),
+		lore.Namespace,
+		Fingerprint(lore.Content),
+		loreChecksum(lore.ID, lore.Content, lore.Context, lore.Category),
 	)
 	if err != nil {
 		return fmt.Errorf("store: insert lore: %w", err)
@@ -266,11 +375,7 @@ func (s *Store) InsertLore(lore *Lore) error {
 	}
 
 	// INSERT change_log
-	if err := appendChangeLog(tx, "lore_entries", lore.ID, "upsert", payloadJSON, s.sourceID); err != nil {
-		return err
-	}
-
-	return tx.Commit()
+	return s.appendChangeLog(tx, "lore_entries", lore.ID, "upsert", payloadJSON, s.sourceID)
 }
 
 // Record stores a new lore entry.
@@ -299,7 +404,7 @@ func (s *Store) Record(lore Lore) (*Lore, error) {
 
 	// Set defaults
 	if lore.ID == "" {
-		lore.ID = ulid.Make().String()
+		lore.ID = s.newID()
 	}
 	if lore.Confidence == 0 {
 		lore.Confidence = ConfidenceDefault
@@ -308,9 +413,10 @@ func (s *Store) Record(lore Lore) (*Lore, error) {
 		return nil, ErrInvalidConfidence
 	}
 
-	now := time.Now().UTC()
+	now := s.now()
 	lore.CreatedAt = now
 	lore.UpdatedAt = now
+	lore.Namespace = s.namespace
 
 	var embeddingBlob []byte
 	if len(lore.Embedding) > 0 {
@@ -329,8 +435,8 @@ func (s *Store) Record(lore Lore) (*Lore, error) {
 		embeddingStatus = lore.EmbeddingStatus
 	}
 	_, err := s.db.Exec(`
-		INSERT INTO lore_entries (id, content, context, category, confidence, embedding, embedding_status, source_id, sources, validation_count, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO lore_entries (id, content, context, category, confidence, embedding, embedding_status, source_id, sources, validation_count, created_at, updated_at, namespace, fingerprint, checksum)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`,
 		lore.ID,
 		lore.Content,
@@ -344,6 +450,9 @@ func (s *Store) Record(lore Lore) (*Lore, error) {
 		lore.ValidationCount,
 		lore.CreatedAt.Format(time.RFC3339),
 		lore.UpdatedAt.Format(time.RFC3339),
+		lore.Namespace,
+		Fingerprint(lore.Content),
+		loreChecksum(lore.ID, lore.Content, lore.Context, lore.Category),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("insert lore: %w", err)
@@ -357,8 +466,18 @@ func (s *Store) Record(lore Lore) (*Lore, error) {
 	return &lore, nil
 }
 
-// Get retrieves a lore entry by ID.
+// Get retrieves a lore entry by ID. It returns a *ChecksumError if the
+// entry fails its checksum.
 func (s *Store) Get(id string) (*Lore, error) {
+	lore, err := s.get(id)
+	var mismatch *ChecksumError
+	if errors.As(err, &mismatch) {
+		s.notifyChecksum(mismatch)
+	}
+	return lore, err
+}
+
+func (s *Store) get(id string) (*Lore, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
@@ -366,15 +485,22 @@ func (s *Store) Get(id string) (*Lore, error) {
 		return nil, ErrStoreClosed
 	}
 
-	return s.getLore(id)
+	lore, err := s.getLore(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyChecksumTx(s.db, lore); err != nil {
+		return nil, err
+	}
+	return lore, nil
 }
 
 func (s *Store) getLore(id string) (*Lore, error) {
 	row := s.db.QueryRow(`
 		SELECT id, content, context, category, confidence, embedding, embedding_status, source_id, sources,
-		       validation_count, last_validated_at, created_at, updated_at, deleted_at, synced_at
-		FROM lore_entries WHERE id = ? AND deleted_at IS NULL
-	`, id)
+		       validation_count, last_validated_at, created_at, updated_at, deleted_at, synced_at, namespace
+		FROM lore_entries WHERE id = ? AND deleted_at IS NULL AND namespace = ?
+	`, id, s.namespace)
 
 	return s.scanLore(row)
 }
@@ -383,9 +509,9 @@ func (s *Store) getLore(id string) (*Lore, error) {
 func (s *Store) getLoreTx(tx *sql.Tx, id string) (*Lore, error) {
 	row := tx.QueryRow(`
 		SELECT id, content, context, category, confidence, embedding, embedding_status, source_id, sources,
-		       validation_count, last_validated_at, created_at, updated_at, deleted_at, synced_at
-		FROM lore_entries WHERE id = ? AND deleted_at IS NULL
-	`, id)
+		       validation_count, last_validated_at, created_at, updated_at, deleted_at, synced_at, namespace
+		FROM lore_entries WHERE id = ? AND deleted_at IS NULL AND namespace = ?
+	`, id, s.namespace)
 
 	return s.scanLore(row)
 }
@@ -393,16 +519,24 @@ func (s *Store) getLoreTx(tx *sql.Tx, id string) (*Lore, error) {
 // Query retrieves lore matching the given parameters.
 // Note: This performs brute-force similarity search when embeddings are present.
 func (s *Store) Query(params QueryParams) ([]Lore, error) {
-	return s.queryLore(params, false)
+	return s.queryLore(params, false, "")
 }
 
 // QueryWithEmbeddings retrieves lore that has embeddings, matching the given parameters.
 // This is used for semantic similarity search where embeddings are required.
 func (s *Store) QueryWithEmbeddings(params QueryParams) ([]Lore, error) {
-	return s.queryLore(params, true)
+	return s.queryLore(params, true, "")
+}
+
+// QueryWithModelEmbeddings is QueryWithEmbeddings for the vectors of the
+// named embedding model (see SetEmbedding).
+func (s *Store) QueryWithModelEmbeddings(params QueryParams, model string) ([]Lore, error) {
+	return s.queryLore(params, true, model)
 }
 
-func (s *Store) queryLore(params QueryParams, requireEmbedding bool) ([]Lore, error) {
+// queryLore reads lore matching params. A non-empty model reads each
+// entry's embedding for that model instead of lore_entries.embedding.
+func (s *Store) queryLore(params QueryParams, requireEmbedding bool, model string) ([]Lore, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
@@ -411,18 +545,40 @@ func (s *Store) queryLore(params QueryParams, requireEmbedding bool) ([]Lore, er
 	}
 
 	// Build query - exclude soft-deleted records
+	embeddingColumn := "embedding"
+	var args []any
+	if model != "" {
+		embeddingColumn = modelEmbeddingColumn
+		args = append(args, model, model)
+	}
+	// Keyword matches join the full-text index by rowid; its content and
+	// context columns shadow lore_entries'.
+	from := "lore_entries WHERE"
+	if params.keywordMatch != "" {
+		from = "lore_fts JOIN lore_entries ON lore_entries.rowid = lore_fts.rowid WHERE lore_fts MATCH ? AND"
+		args = append(args, params.keywordMatch)
+	}
 	query := `
-		SELECT id, content, context, category, confidence, embedding, embedding_status, source_id, sources,
-		       validation_count, last_validated_at, created_at, updated_at, deleted_at, synced_at
-		FROM lore_entries WHERE deleted_at IS NULL
+		SELECT id, lore_entries.content, lore_entries.context, category, confidence, ` + embeddingColumn + `, embedding_status, source_id, sources,
+		       validation_count, last_validated_at, created_at, updated_at, deleted_at, synced_at, namespace
+		FROM ` + from + ` deleted_at IS NULL AND namespace = ?
 	`
-	args := []any{}
+	args = append(args, s.namespace)
 
-	if requireEmbedding {
+	if requireEmbedding && model == "" {
 		query += " AND embedding IS NOT NULL"
 	}
 
-	if params.MinConfidence != nil && *params.MinConfidence > 0 {
+	if len(params.categoryMinConfidence) > 0 {
+		// Per-category defaults: confidence >= CASE category WHEN ... END
+		query += " AND confidence >= CASE category"
+		for category, v := range params.categoryMinConfidence {
+			query += " WHEN ? THEN ?"
+			args = append(args, string(category), v)
+		}
+		query += " ELSE ? END"
+		args = append(args, *params.MinConfidence)
+	} else if params.MinConfidence != nil && *params.MinConfidence > 0 {
 		query += " AND confidence >= ?"
 		args = append(args, *params.MinConfidence)
 	}
@@ -436,6 +592,35 @@ func (s *Store) queryLore(params QueryParams, requireEmbedding bool) ([]Lore, er
 		query += fmt.Sprintf(" AND category IN (%s)", strings.Join(placeholders, ","))
 	}
 
+	if len(params.SourceIDs) > 0 {
+		query += fmt.Sprintf(" AND source_id IN (%s)", strings.TrimSuffix(strings.Repeat("?,", len(params.SourceIDs)), ","))
+		for _, id := range params.SourceIDs {
+			args = append(args, id)
+		}
+	}
+	if len(params.ExcludeSourceIDs) > 0 {
+		query += fmt.Sprintf(" AND source_id NOT IN (%s)", strings.TrimSuffix(strings.Repeat("?,", len(params.ExcludeSourceIDs)), ","))
+		for _, id := range params.ExcludeSourceIDs {
+			args = append(args, id)
+		}
+	}
+	if len(params.Owners) > 0 {
+		query += fmt.Sprintf(" AND id IN (SELECT lore_id FROM lore_assignments WHERE owner IN (%s))", strings.TrimSuffix(strings.Repeat("?,", len(params.Owners)), ","))
+		for _, owner := range params.Owners {
+			args = append(args, owner)
+		}
+	}
+	if params.OnlySynced {
+		query += " AND NOT " + unpushedLoreCondition
+	}
+	if params.keywordMatch != "" {
+		query += " ORDER BY bm25(lore_fts) LIMIT ?"
+		args = append(args, maxKeywordMatches)
+	}
+	if requireEmbedding && model != "" {
+		query = "SELECT * FROM (" + query + ") WHERE embedding IS NOT NULL"
+	}
+
 	rows, err := s.db.Query(query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("query lore: %w", err)
@@ -465,7 +650,15 @@ func (s *Store) queryLore(params QueryParams, requireEmbedding bool) ([]Lore, er
 //
 // Returns the updated Lore entry.
 // Returns ErrNotFound if lore with given ID does not exist.
+//
+// Feedback cannot lower protected lore below its floor; see Protect.
 func (s *Store) ApplyFeedback(loreID string, delta float64, isHelpful bool) (*Lore, error) {
+	return s.applyFeedback(loreID, delta, isHelpful, 0)
+}
+
+// applyFeedback is ApplyFeedback for feedback from an actor at the given
+// ActorPolicy.Level, which protected lore's MinLevel is checked against.
+func (s *Store) applyFeedback(loreID string, delta float64, isHelpful bool, level int) (*Lore, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -473,13 +666,6 @@ func (s *Store) ApplyFeedback(loreID string, delta float64, isHelpful bool) (*Lo
 		return nil, ErrStoreClosed
 	}
 
-	// Verify lore exists first. This check is outside the transaction but safe
-	// because s.mu write lock is held, preventing concurrent modifications.
-	lore, err := s.getLore(loreID)
-	if err != nil {
-		return nil, err
-	}
-
 	// Begin transaction
 	tx, err := s.db.Begin()
 	if err != nil {
@@ -487,6 +673,37 @@ func (s *Store) ApplyFeedback(loreID string, delta float64, isHelpful bool) (*Lo
 	}
 	defer func() { _ = tx.Rollback() }() // no-op if committed
 
+	updatedLore, err := s.applyFeedbackTx(tx, loreID, delta, isHelpful, level)
+	if err != nil {
+		return nil, err
+	}
+
+	// Write full-state upsert to change_log
+	payloadJSON, err := lorePayloadJSON(updatedLore)
+	if err != nil {
+		return nil, fmt.Errorf("store: marshal change_log payload: %w", err)
+	}
+	if err := s.appendChangeLog(tx, "lore_entries", loreID, "upsert", payloadJSON, s.sourceID); err != nil {
+		return nil, err
+	}
+
+	// Commit transaction
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("store: commit: %w", err)
+	}
+
+	return updatedLore, nil
+}
+
+// applyFeedbackTx updates loreID's confidence by delta within tx and
+// returns the updated entry, leaving the change_log entry to the caller.
+// Returns ErrNotFound, having written nothing, if the entry does not exist.
+func (s *Store) applyFeedbackTx(tx *sql.Tx, loreID string, delta float64, isHelpful bool, level int) (*Lore, error) {
+	lore, err := s.getLoreTx(tx, loreID)
+	if err != nil {
+		return nil, err
+	}
+
 	// Calculate new confidence with clamping
 	newConfidence := lore.Confidence + delta
 	if newConfidence < ConfidenceMin {
@@ -495,8 +712,12 @@ func (s *Store) ApplyFeedback(loreID string, delta float64, isHelpful bool) (*Lo
 	if newConfidence > ConfidenceMax {
 		newConfidence = ConfidenceMax
 	}
+	newConfidence, err = s.protectedConfidence(tx, loreID, lore.Confidence, newConfidence, level)
+	if err != nil {
+		return nil, err
+	}
 
-	now := time.Now().UTC()
+	now := s.now()
 	nowStr := now.Format(time.RFC3339)
 
 	// UPDATE lore (with or without validation metadata)
@@ -506,16 +727,18 @@ func (s *Store) ApplyFeedback(loreID string, delta float64, isHelpful bool) (*Lo
 				confidence = ?,
 				validation_count = validation_count + 1,
 				last_validated_at = ?,
+				confidence_updated_at = ?,
 				updated_at = ?
-			WHERE id = ? AND deleted_at IS NULL
-		`, newConfidence, nowStr, nowStr, loreID)
+			WHERE id = ? AND deleted_at IS NULL AND namespace = ?
+		`, newConfidence, nowStr, nowStr, nowStr, loreID, s.namespace)
 	} else {
 		_, err = tx.Exec(`
 			UPDATE lore_entries SET
 				confidence = ?,
+				confidence_updated_at = ?,
 				updated_at = ?
-			WHERE id = ? AND deleted_at IS NULL
-		`, newConfidence, nowStr, loreID)
+			WHERE id = ? AND deleted_at IS NULL AND namespace = ?
+		`, newConfidence, nowStr, nowStr, loreID, s.namespace)
 	}
 	if err != nil {
 		return nil, fmt.Errorf("store: update confidence: %w", err)
@@ -526,27 +749,20 @@ func (s *Store) ApplyFeedback(loreID string, delta float64, isHelpful bool) (*Lo
 	if err != nil {
 		return nil, fmt.Errorf("store: read updated lore: %w", err)
 	}
-
-	// Write full-state upsert to change_log
-	payloadJSON, err := lorePayloadJSON(updatedLore)
-	if err != nil {
-		return nil, fmt.Errorf("store: marshal change_log payload: %w", err)
-	}
-	if err := appendChangeLog(tx, "lore_entries", loreID, "upsert", payloadJSON, s.sourceID); err != nil {
-		return nil, err
-	}
-
-	// Commit transaction
-	if err := tx.Commit(); err != nil {
-		return nil, fmt.Errorf("store: commit: %w", err)
-	}
-
 	return updatedLore, nil
 }
 
 // ApplyFeedbackBatch updates lore confidence based on batch feedback.
 // Deprecated: Use ApplyFeedback() for single-entry atomic feedback.
 func (s *Store) ApplyFeedbackBatch(session *Session, params FeedbackParams) (*FeedbackResult, error) {
+	return s.applyFeedbackBatch(session, params, nil, "", 0)
+}
+
+// applyFeedbackBatch is ApplyFeedbackBatch, skipping refs whose feedback
+// dedup has already seen within its window. A nil dedup applies all.
+// Applied outcomes are logged under actor for SourceReport. level is the
+// actor's ActorPolicy.Level, checked against protected lore.
+func (s *Store) applyFeedbackBatch(session *Session, params FeedbackParams, dedup *feedbackDedup, actor string, level int) (*FeedbackResult, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -555,7 +771,7 @@ func (s *Store) ApplyFeedbackBatch(session *Session, params FeedbackParams) (*Fe
 	}
 
 	result := &FeedbackResult{Updated: []FeedbackUpdate{}}
-	now := time.Now().UTC()
+	now := s.now()
 
 	// Content lookup for fuzzy matching
 	contentLookup := func(id string) string {
@@ -566,6 +782,20 @@ func (s *Store) ApplyFeedbackBatch(session *Session, params FeedbackParams) (*Fe
 		return lore.Content
 	}
 
+	// repeated reports whether the feedback was already given within the
+	// dedup window, recording it otherwise.
+	repeated := func(ref, id string, ft FeedbackType) bool {
+		if dedup == nil {
+			return false
+		}
+		event, err := s.claimFeedbackLocked(id, dedup.actor, ft, dedup.window)
+		if err != nil || event != 0 {
+			return false
+		}
+		result.Skipped = append(result.Skipped, ref)
+		return true
+	}
+
 	// Process helpful feedback
 	for _, ref := range params.Helpful {
 		id, ok := session.FuzzyMatch(ref, contentLookup)
@@ -573,9 +803,13 @@ func (s *Store) ApplyFeedbackBatch(session *Session, params FeedbackParams) (*Fe
 			result.NotFound = append(result.NotFound, ref)
 			continue
 		}
-		update, err := s.adjustConfidence(id, ConfidenceHelpfulDelta, true, now, string(FeedbackHelpful))
+		if repeated(ref, id, FeedbackHelpful) {
+			continue
+		}
+		update, err := s.adjustConfidence(id, ConfidenceHelpfulDelta, true, now, string(FeedbackHelpful), level)
 		if err == nil {
 			result.Updated = append(result.Updated, *update)
+			_ = s.logFeedbackLocked(id, FeedbackHelpful, actor)
 		}
 	}
 
@@ -586,25 +820,48 @@ func (s *Store) ApplyFeedbackBatch(session *Session, params FeedbackParams) (*Fe
 			result.NotFound = append(result.NotFound, ref)
 			continue
 		}
-		update, err := s.adjustConfidence(id, ConfidenceIncorrectDelta, false, now, string(FeedbackIncorrect))
+		if repeated(ref, id, FeedbackIncorrect) {
+			continue
+		}
+		update, err := s.adjustConfidence(id, ConfidenceIncorrectDelta, false, now, string(FeedbackIncorrect), level)
 		if err == nil {
 			result.Updated = append(result.Updated, *update)
+			_ = s.logFeedbackLocked(id, FeedbackIncorrect, actor)
+		}
+	}
+
+	// Process outdated feedback
+	for _, ref := range params.Outdated {
+		id, ok := session.FuzzyMatch(ref, contentLookup)
+		if !ok {
+			result.NotFound = append(result.NotFound, ref)
+			continue
+		}
+		if repeated(ref, id, FeedbackOutdated) {
+			continue
+		}
+		update, err := s.adjustConfidence(id, ConfidenceOutdatedDelta, false, now, string(FeedbackOutdated), level)
+		if err == nil {
+			result.Updated = append(result.Updated, *update)
+			_ = s.logFeedbackLocked(id, FeedbackOutdated, actor)
 		}
 	}
 
 	// Process not_relevant feedback - track as not found if ref doesn't exist
 	for _, ref := range params.NotRelevant {
-		_, ok := session.FuzzyMatch(ref, contentLookup)
+		id, ok := session.FuzzyMatch(ref, contentLookup)
 		if !ok {
 			result.NotFound = append(result.NotFound, ref)
+			continue
 		}
 		// not_relevant: no adjustment needed when found
+		_ = s.logFeedbackLocked(id, FeedbackNotRelevant, actor)
 	}
 
 	return result, nil
 }
 
-func (s *Store) adjustConfidence(id string, delta float64, incrementValidation bool, now time.Time, outcome string) (*FeedbackUpdate, error) {
+func (s *Store) adjustConfidence(id string, delta float64, incrementValidation bool, now time.Time, outcome string, level int) (*FeedbackUpdate, error) {
 	lore, err := s.getLore(id)
 	if err != nil {
 		return nil, err
@@ -618,6 +875,10 @@ func (s *Store) adjustConfidence(id string, delta float64, incrementValidation b
 	if current > ConfidenceMax {
 		current = ConfidenceMax
 	}
+	current, err = s.protectedConfidence(s.db, id, previous, current, level)
+	if err != nil {
+		return nil, err
+	}
 
 	validationCount := lore.ValidationCount
 	var lastValidatedAt *string
@@ -629,9 +890,10 @@ func (s *Store) adjustConfidence(id string, delta float64, incrementValidation b
 
 	_, err = s.db.Exec(`
 		UPDATE lore_entries
-		SET confidence = ?, validation_count = ?, last_validated_at = COALESCE(?, last_validated_at), updated_at = ?
-		WHERE id = ? AND deleted_at IS NULL
-	`, current, validationCount, lastValidatedAt, now.Format(time.RFC3339), id)
+		SET confidence = ?, validation_count = ?, last_validated_at = COALESCE(?, last_validated_at),
+		    confidence_updated_at = ?, updated_at = ?
+		WHERE id = ? AND deleted_at IS NULL AND namespace = ?
+	`, current, validationCount, lastValidatedAt, now.UTC().Format(time.RFC3339), now.Format(time.RFC3339), id, s.namespace)
 	if err != nil {
 		return nil, err
 	}
@@ -662,7 +924,7 @@ func (s *Store) Unsynced() ([]Lore, error) {
 
 	rows, err := s.db.Query(`
 		SELECT id, content, context, category, confidence, embedding, embedding_status, source_id, sources,
-		       validation_count, last_validated_at, created_at, updated_at, deleted_at, synced_at
+		       validation_count, last_validated_at, created_at, updated_at, deleted_at, synced_at, namespace
 		FROM lore_entries WHERE synced_at IS NULL AND deleted_at IS NULL
 	`)
 	if err != nil {
@@ -717,7 +979,7 @@ func (s *Store) Stats() (*StoreStats, error) {
 	}
 
 	var count int
-	if err := s.db.QueryRow("SELECT COUNT(*) FROM lore_entries WHERE deleted_at IS NULL").Scan(&count); err != nil {
+	if err := s.db.QueryRow("SELECT COUNT(*) FROM lore_entries WHERE deleted_at IS NULL AND namespace = ?", s.namespace).Scan(&count); err != nil {
 		return nil, err
 	}
 
@@ -734,11 +996,39 @@ func (s *Store) Stats() (*StoreStats, error) {
 		lastSync, _ = time.Parse(time.RFC3339, lastSyncStr.String)
 	}
 
+	var migratedFrom sql.NullString
+	_ = s.db.QueryRow("SELECT value FROM metadata WHERE key = ?", metadataKeyMigratedFrom).Scan(&migratedFrom)
+	var recoveredFrom sql.NullString
+	_ = s.db.QueryRow("SELECT value FROM metadata WHERE key = ?", metadataKeyRecoveredFrom).Scan(&recoveredFrom)
+
+	metrics, err := s.readSyncMetrics()
+	if err != nil {
+		return nil, err
+	}
+
+	var changeLogEntries, unpushed int
+	var changeLogBytes int64
+	if err := s.db.QueryRow(`
+		SELECT COUNT(*), COALESCE(SUM(length(payload)), 0),
+		       COUNT(CASE WHEN sequence > COALESCE((SELECT CAST(value AS INTEGER) FROM sync_meta WHERE key = 'last_push_seq'), 0) THEN 1 END)
+		FROM change_log
+	`).Scan(&changeLogEntries, &changeLogBytes, &unpushed); err != nil {
+		return nil, fmt.Errorf("store: change_log stats: %w", err)
+	}
+
 	return &StoreStats{
 		LoreCount:     count,
 		PendingSync:   pendingSync,
 		LastSync:      lastSync,
 		SchemaVersion: schemaVersion,
+		Path:          s.path,
+		MigratedFrom:  migratedFrom.String,
+		RecoveredFrom: recoveredFrom.String,
+		SyncMetrics:   *metrics,
+
+		UnpushedChanges:  unpushed,
+		ChangeLogEntries: changeLogEntries,
+		ChangeLogBytes:   changeLogBytes,
 	}, nil
 }
 
@@ -747,6 +1037,11 @@ const (
 	metadataKeyDescription  = "description"
 	metadataKeyCreatedAt    = "created_at"
 	metadataKeyMigratedFrom = "migrated_from"
+	metadataKeySnapshotAt   = "snapshot_at"
+
+	// metadataKeyMigrationVersion caches the goose version of the last
+	// migrate, so opening a current store skips goose.
+	metadataKeyMigrationVersion = "migration_version"
 )
 
 // GetStoreDescription returns the store's human-readable description.
@@ -854,8 +1149,8 @@ func (s *Store) GetDetailedStats() (*DetailedStats, error) {
 	err := s.db.QueryRow(`
 		SELECT COUNT(*), AVG(confidence)
 		FROM lore_entries
-		WHERE deleted_at IS NULL
-	`).Scan(&stats.LoreCount, &avgConf)
+		WHERE deleted_at IS NULL AND namespace = ?
+	`, s.namespace).Scan(&stats.LoreCount, &avgConf)
 	if err != nil {
 		return nil, fmt.Errorf("query lore stats: %w", err)
 	}
@@ -867,9 +1162,9 @@ func (s *Store) GetDetailedStats() (*DetailedStats, error) {
 	rows, err := s.db.Query(`
 		SELECT category, COUNT(*)
 		FROM lore_entries
-		WHERE deleted_at IS NULL
+		WHERE deleted_at IS NULL AND namespace = ?
 		GROUP BY category
-	`)
+	`, s.namespace)
 	if err != nil {
 		return nil, fmt.Errorf("query category distribution: %w", err)
 	}
@@ -889,8 +1184,8 @@ func (s *Store) GetDetailedStats() (*DetailedStats, error) {
 	err = s.db.QueryRow(`
 		SELECT MAX(updated_at)
 		FROM lore_entries
-		WHERE deleted_at IS NULL
-	`).Scan(&lastUpdatedStr)
+		WHERE deleted_at IS NULL AND namespace = ?
+	`, s.namespace).Scan(&lastUpdatedStr)
 	if err != nil && err != sql.ErrNoRows {
 		return nil, fmt.Errorf("query last updated: %w", err)
 	}
@@ -907,10 +1202,13 @@ func (s *Store) GetDetailedStats() (*DetailedStats, error) {
 // This method:
 //  1. Writes snapshot to a temp file
 //  2. Opens temp database and reads all lore
-//  3. In a single transaction: DELETE all lore, INSERT all from snapshot
+//  3. In a single transaction: DELETE all lore, INSERT all from snapshot,
+//     re-applying local-only columns (usage stats) to entries that remain
 //  4. Cleans up temp file
 //
-// If any step fails, the local lore data is preserved.
+// If any step fails, the local lore data is preserved. Callers that update
+// other state along with the replace, as Bootstrap does, should run both
+// under Exclusive.
 func (s *Store) ReplaceFromSnapshot(r io.Reader) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -933,36 +1231,10 @@ func (s *Store) ReplaceFromSnapshot(r io.Reader) error {
 	}
 	_ = tmpFile.Close()
 
-	// 2. Open snapshot database
-	snapshotDB, err := sql.Open("sqlite", tmpPath)
+	// 2-3. Open snapshot database and read all lore
+	loreEntries, err := readSnapshotLore(tmpPath)
 	if err != nil {
-		return fmt.Errorf("open snapshot: %w", err)
-	}
-	defer func() { _ = snapshotDB.Close() }()
-
-	// 3. Read all lore from snapshot (Engram snapshots don't have synced_at column)
-	// Note: synced_at is Recall-only and won't be present in Engram snapshots
-	rows, err := snapshotDB.Query(`
-		SELECT id, content, context, category, confidence, embedding, embedding_status,
-		       source_id, sources, validation_count, last_validated_at,
-		       created_at, updated_at, deleted_at
-		FROM lore_entries WHERE deleted_at IS NULL
-	`)
-	if err != nil {
-		return fmt.Errorf("read snapshot: %w", err)
-	}
-	defer func() { _ = rows.Close() }()
-
-	var loreEntries []Lore
-	for rows.Next() {
-		lore, err := s.scanSnapshotLoreRows(rows)
-		if err != nil {
-			return fmt.Errorf("scan snapshot row: %w", err)
-		}
-		loreEntries = append(loreEntries, *lore)
-	}
-	if err := rows.Err(); err != nil {
-		return fmt.Errorf("iterate snapshot: %w", err)
+		return err
 	}
 
 	// 4. Atomic replacement in local database
@@ -972,7 +1244,16 @@ func (s *Store) ReplaceFromSnapshot(r io.Reader) error {
 	}
 	defer func() { _ = tx.Rollback() }()
 
-	// Delete all existing lore
+	// Save local-only columns (usage stats) so popular entries keep them
+	localState, err := saveLocalOnlyColumnsTx(tx)
+	if err != nil {
+		return err
+	}
+
+	// Delete all existing lore, retaining it first under legal hold
+	if err := s.holdLoreTx(tx, HoldOriginBootstrap, "1 = 1"); err != nil {
+		return err
+	}
 	if _, err := tx.Exec("DELETE FROM lore_entries"); err != nil {
 		return fmt.Errorf("delete existing lore: %w", err)
 	}
@@ -989,6 +1270,11 @@ func (s *Store) ReplaceFromSnapshot(r io.Reader) error {
 		}
 	}
 
+	// Re-apply local-only columns to entries that survived the import
+	if err := restoreLocalOnlyColumnsTx(tx, localState); err != nil {
+		return err
+	}
+
 	return tx.Commit()
 }
 
@@ -1032,8 +1318,8 @@ func (s *Store) insertLoreTx(tx *sql.Tx, lore *Lore) error {
 	_, err := tx.Exec(`
 		INSERT INTO lore_entries (id, content, context, category, confidence, embedding, embedding_status,
 		                 source_id, sources, validation_count, last_validated_at,
-		                 created_at, updated_at, deleted_at, synced_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		                 created_at, updated_at, deleted_at, synced_at, namespace, fingerprint, checksum)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`,
 		lore.ID,
 		lore.Content,
@@ -1050,6 +1336,9 @@ func (s *Store) insertLoreTx(tx *sql.Tx, lore *Lore) error {
 		lore.UpdatedAt.Format(time.RFC3339),
 		deletedAtStr,
 		syncedAtStr,
+		lore.Namespace,
+		Fingerprint(lore.Content),
+		loreChecksum(lore.ID, lore.Content, lore.Context, lore.Category),
 	)
 	return err
 }
@@ -1067,11 +1356,24 @@ func (s *Store) Close() error {
 	return s.db.Close()
 }
 
+// queueSync queues a legacy change. Once the sync_queue is converted
+// (ConvertSyncQueue), the entry's current state goes to change_log instead.
 func (s *Store) queueSync(loreID, operation string, payload []byte) error {
+	if s.syncQueueConverted() {
+		tx, err := s.db.Begin()
+		if err != nil {
+			return err
+		}
+		defer func() { _ = tx.Rollback() }()
+		if _, err := s.convertQueuedTx(tx, loreID, operation, map[string]bool{}); err != nil {
+			return err
+		}
+		return tx.Commit()
+	}
 	_, err := s.db.Exec(`
 		INSERT INTO sync_queue (lore_id, operation, payload, queued_at)
 		VALUES (?, ?, ?, ?)
-	`, loreID, operation, payload, time.Now().UTC().Format(time.RFC3339))
+	`, loreID, operation, payload, s.now().Format(time.RFC3339))
 	return err
 }
 
@@ -1113,6 +1415,7 @@ func (s *Store) scanLoreFrom(sc scanner) (*Lore, error) {
 		&updatedAt,
 		&deletedAt,
 		&syncedAt,
+		&lore.Namespace,
 	)
 	if err == sql.ErrNoRows {
 		return nil, ErrNotFound
@@ -1160,8 +1463,43 @@ func (s *Store) scanLoreRows(rows *sql.Rows) (*Lore, error) {
 	return s.scanLoreFrom(rows)
 }
 
+// readSnapshotLore reads the active lore entries of a snapshot database file.
+func readSnapshotLore(path string) ([]Lore, error) {
+	snapshotDB, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("open snapshot: %w", err)
+	}
+	defer func() { _ = snapshotDB.Close() }()
+
+	// Engram snapshots don't have synced_at column
+	// Note: synced_at is Recall-only and won't be present in Engram snapshots
+	rows, err := snapshotDB.Query(`
+		SELECT id, content, context, category, confidence, embedding, embedding_status,
+		       source_id, sources, validation_count, last_validated_at,
+		       created_at, updated_at, deleted_at
+		FROM lore_entries WHERE deleted_at IS NULL
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("read snapshot: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var loreEntries []Lore
+	for rows.Next() {
+		lore, err := scanSnapshotLoreRows(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan snapshot row: %w", err)
+		}
+		loreEntries = append(loreEntries, *lore)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate snapshot: %w", err)
+	}
+	return loreEntries, nil
+}
+
 // scanSnapshotLoreRows scans a lore row from Engram snapshots (no synced_at column).
-func (s *Store) scanSnapshotLoreRows(rows *sql.Rows) (*Lore, error) {
+func scanSnapshotLoreRows(rows *sql.Rows) (*Lore, error) {
 	var (
 		lore            Lore
 		context         sql.NullString
@@ -1419,7 +1757,7 @@ func (s *Store) CompleteSyncEntries(queueIDs []int64, loreIDs []string) error {
 	}
 	defer func() { _ = tx.Rollback() }()
 
-	now := time.Now().UTC().Format(time.RFC3339)
+	now := s.now().Format(time.RFC3339)
 
 	// Delete from sync_queue
 	queuePlaceholders := make([]string, len(queueIDs))
@@ -1502,7 +1840,11 @@ func (s *Store) DeleteSyncEntry(id int64) error {
 }
 
 // UpsertLore inserts or updates a lore entry.
-// If lore with the same ID exists, it updates all fields.
+// If lore with the same ID exists, server fields (content, context, category,
+// sources) are replaced. Confidence, validation_count and last_validated_at
+// are replaced too, unless they were changed locally (confidence_updated_at)
+// after the incoming entry's updated_at, in which case the local values are
+// kept.
 // If lore doesn't exist, it inserts a new entry.
 // Used by delta sync to apply incremental changes.
 func (s *Store) UpsertLore(lore *Lore) error {
@@ -1513,6 +1855,20 @@ func (s *Store) UpsertLore(lore *Lore) error {
 		return ErrStoreClosed
 	}
 
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("store: begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := s.upsertLoreTx(tx, lore); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// upsertLoreTx performs the UpsertLore write within a transaction.
+func (s *Store) upsertLoreTx(tx *sql.Tx, lore *Lore) error {
 	var embeddingBlob []byte
 	if len(lore.Embedding) > 0 {
 		embeddingBlob = lore.Embedding
@@ -1536,7 +1892,7 @@ func (s *Store) UpsertLore(lore *Lore) error {
 		embeddingStatus = "pending"
 	}
 
-	now := time.Now().UTC()
+	now := s.now()
 	if lore.CreatedAt.IsZero() {
 		lore.CreatedAt = now
 	}
@@ -1544,25 +1900,33 @@ func (s *Store) UpsertLore(lore *Lore) error {
 		lore.UpdatedAt = now
 	}
 
-	_, err := s.db.Exec(`
+	_, err := tx.Exec(`
 		INSERT INTO lore_entries (id, content, context, category, confidence, embedding, embedding_status,
 		                 source_id, sources, validation_count, last_validated_at,
-		                 created_at, updated_at, deleted_at, synced_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, NULL, ?)
+		                 created_at, updated_at, deleted_at, synced_at, namespace, fingerprint, checksum)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, NULL, ?, ?, ?, ?)
 		ON CONFLICT(id) DO UPDATE SET
 			content = excluded.content,
 			context = excluded.context,
 			category = excluded.category,
-			confidence = excluded.confidence,
 			embedding = excluded.embedding,
 			embedding_status = excluded.embedding_status,
 			source_id = excluded.source_id,
 			sources = excluded.sources,
-			validation_count = excluded.validation_count,
-			last_validated_at = excluded.last_validated_at,
+			confidence = CASE WHEN lore_entries.confidence_updated_at > excluded.updated_at
+				THEN lore_entries.confidence ELSE excluded.confidence END,
+			validation_count = CASE WHEN lore_entries.confidence_updated_at > excluded.updated_at
+				THEN lore_entries.validation_count ELSE excluded.validation_count END,
+			last_validated_at = CASE WHEN lore_entries.confidence_updated_at > excluded.updated_at
+				THEN lore_entries.last_validated_at ELSE excluded.last_validated_at END,
+			confidence_updated_at = CASE WHEN lore_entries.confidence_updated_at > excluded.updated_at
+				THEN lore_entries.confidence_updated_at ELSE NULL END,
 			updated_at = excluded.updated_at,
 			deleted_at = NULL,
-			synced_at = excluded.synced_at
+			synced_at = excluded.synced_at,
+			namespace = excluded.namespace,
+			fingerprint = excluded.fingerprint,
+			checksum = excluded.checksum
 	`,
 		lore.ID,
 		lore.Content,
@@ -1576,8 +1940,11 @@ func (s *Store) UpsertLore(lore *Lore) error {
 		lore.ValidationCount,
 		lastValidatedAtStr,
 		lore.CreatedAt.Format(time.RFC3339),
-		lore.UpdatedAt.Format(time.RFC3339),
+		lore.UpdatedAt.UTC().Format(time.RFC3339), // compared with confidence_updated_at
 		nil, // synced_at: NULL because delta-synced entries originate from Engram (already synced)
+		lore.Namespace,
+		Fingerprint(lore.Content),
+		loreChecksum(lore.ID, lore.Content, lore.Context, lore.Category),
 	)
 	if err != nil {
 		return fmt.Errorf("store: upsert lore: %w", err)
@@ -1603,19 +1970,22 @@ func (s *Store) DeleteLoreByID(id string) error {
 	}
 	defer func() { _ = tx.Rollback() }()
 
-	now := time.Now().UTC().Format(time.RFC3339)
+	now := s.now().Format(time.RFC3339)
 
 	// Soft delete: set deleted_at instead of removing the row
 	_, err = tx.Exec(`
 		UPDATE lore_entries SET deleted_at = ?, updated_at = ?
-		WHERE id = ? AND deleted_at IS NULL
-	`, now, now, id)
+		WHERE id = ? AND deleted_at IS NULL AND namespace = ?
+	`, now, now, id, s.namespace)
 	if err != nil {
 		return fmt.Errorf("store: soft delete lore: %w", err)
 	}
+	if err := s.holdLoreTx(tx, HoldOriginDelete, "id = ? AND deleted_at = ? AND namespace = ?", id, now, s.namespace); err != nil {
+		return err
+	}
 
 	// Write change_log entry with operation=delete, payload=NULL
-	if err := appendChangeLog(tx, "lore_entries", id, "delete", nil, s.sourceID); err != nil {
+	if err := s.appendChangeLog(tx, "lore_entries", id, "delete", nil, s.sourceID); err != nil {
 		return err
 	}
 
@@ -1633,18 +2003,29 @@ func (s *Store) SoftDeleteLoreAt(id, deletedAt string) error {
 		return ErrStoreClosed
 	}
 
-	_, err := s.db.Exec(`
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("store: begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	_, err = tx.Exec(`
 		UPDATE lore_entries SET deleted_at = ?, updated_at = ?
 		WHERE id = ?
 	`, deletedAt, deletedAt, id)
 	if err != nil {
 		return fmt.Errorf("store: soft delete lore at: %w", err)
 	}
-	return nil
+	if err := s.holdLoreTx(tx, HoldOriginRemote, "id = ? AND deleted_at = ?", id, deletedAt); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
 // HasPendingSync returns the count of unpushed local changes.
-// Counts entries in both sync_queue (legacy) and change_log (new).
+// Counts entries in both sync_queue (legacy) and change_log (new). After
+// ConvertSyncQueue, only queued FEEDBACK awaiting ReconcileFeedback counts
+// from sync_queue.
 // Returns 0 if no pending changes exist.
 func (s *Store) HasPendingSync() (int, error) {
 	s.mu.RLock()
@@ -1654,10 +2035,14 @@ func (s *Store) HasPendingSync() (int, error) {
 		return 0, ErrStoreClosed
 	}
 
+	operations := `'INSERT', 'FEEDBACK'`
+	if s.syncQueueConverted() {
+		operations = `'FEEDBACK'`
+	}
 	var sqCount int
 	err := s.db.QueryRow(`
 		SELECT COUNT(*) FROM sync_queue
-		WHERE operation IN ('INSERT', 'FEEDBACK')
+		WHERE operation IN (` + operations + `)
 	`).Scan(&sqCount)
 	if err != nil {
 		return 0, fmt.Errorf("store: count pending sync_queue: %w", err)
@@ -1688,6 +2073,9 @@ func (s *Store) ClearAllLore() error {
 	}
 	defer func() { _ = tx.Rollback() }()
 
+	if err := s.holdLoreTx(tx, HoldOriginClear, "1 = 1"); err != nil {
+		return err
+	}
 	if _, err := tx.Exec("DELETE FROM lore_entries"); err != nil {
 		return fmt.Errorf("store: delete lore: %w", err)
 	}
@@ -1701,6 +2089,12 @@ func (s *Store) ClearAllLore() error {
 
 // GetLoreByIDs retrieves multiple lore entries by ID.
 func (s *Store) GetLoreByIDs(ids []string) ([]Lore, error) {
+	return s.getLoreByIDs(ids, "")
+}
+
+// getLoreByIDs reads lore entries by ID. A non-empty model reads each
+// entry's embedding for that model instead of lore_entries.embedding.
+func (s *Store) getLoreByIDs(ids []string, model string) ([]Lore, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
@@ -1712,18 +2106,23 @@ func (s *Store) GetLoreByIDs(ids []string) ([]Lore, error) {
 		return nil, nil
 	}
 
+	embeddingColumn := "embedding"
+	var args []any
+	if model != "" {
+		embeddingColumn = modelEmbeddingColumn
+		args = append(args, model, model)
+	}
 	placeholders := make([]string, len(ids))
-	args := make([]any, len(ids))
 	for i, id := range ids {
 		placeholders[i] = "?"
-		args[i] = id
+		args = append(args, id)
 	}
 
 	rows, err := s.db.Query(fmt.Sprintf(`
-		SELECT id, content, context, category, confidence, embedding, embedding_status, source_id, sources,
-		       validation_count, last_validated_at, created_at, updated_at, deleted_at, synced_at
-		FROM lore_entries WHERE id IN (%s) AND deleted_at IS NULL
-	`, strings.Join(placeholders, ",")), args...)
+		SELECT id, content, context, category, confidence, %s, embedding_status, source_id, sources,
+		       validation_count, last_validated_at, created_at, updated_at, deleted_at, synced_at, namespace
+		FROM lore_entries WHERE id IN (%s) AND deleted_at IS NULL AND namespace = ?
+	`, embeddingColumn, strings.Join(placeholders, ",")), append(args, s.namespace)...)
 	if err != nil {
 		return nil, fmt.Errorf("query lore: %w", err)
 	}
@@ -5,7 +5,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
+	"sort"
 	"testing"
+	"time"
 )
 
 // TestNewStore_CreatesAllTables verifies that NewStore creates all three required tables.
@@ -1790,6 +1793,53 @@ func TestStore_UpsertLore_Update(t *testing.T) {
 	}
 }
 
+// TestStore_UpsertLore_KeepsNewerLocalConfidence verifies the field-level
+// merge: server content always wins, local feedback wins while it is newer.
+func TestStore_UpsertLore_KeepsNewerLocalConfidence(t *testing.T) {
+	store := newTestStore(t)
+
+	past := time.Now().UTC().Add(-time.Hour)
+	if err := store.UpsertLore(&Lore{
+		ID: "01ARZ3NDEKTSV4RRFFQ69G5FAW", Content: "Server v1", Category: CategoryPatternOutcome,
+		Confidence: 0.5, CreatedAt: past, UpdatedAt: past,
+	}); err != nil {
+		t.Fatalf("UpsertLore: %v", err)
+	}
+	if _, err := store.ApplyFeedback("01ARZ3NDEKTSV4RRFFQ69G5FAW", ConfidenceHelpfulDelta, true); err != nil {
+		t.Fatalf("ApplyFeedback: %v", err)
+	}
+
+	// A server version older than the local feedback: content updates,
+	// confidence and validation stay local.
+	older := past.Add(30 * time.Minute)
+	if err := store.UpsertLore(&Lore{
+		ID: "01ARZ3NDEKTSV4RRFFQ69G5FAW", Content: "Server v2", Category: CategoryDependencyBehavior,
+		Confidence: 0.3, CreatedAt: past, UpdatedAt: older,
+	}); err != nil {
+		t.Fatalf("UpsertLore: %v", err)
+	}
+	got, _ := store.Get("01ARZ3NDEKTSV4RRFFQ69G5FAW")
+	if got.Content != "Server v2" || got.Category != CategoryDependencyBehavior {
+		t.Errorf("content/category = %q/%s, want server values", got.Content, got.Category)
+	}
+	if got.Confidence != 0.58 || got.ValidationCount != 1 {
+		t.Errorf("confidence/validation = %.2f/%d, want local 0.58/1", got.Confidence, got.ValidationCount)
+	}
+
+	// A newer server version replaces the local feedback.
+	newer := time.Now().UTC().Add(time.Hour)
+	if err := store.UpsertLore(&Lore{
+		ID: "01ARZ3NDEKTSV4RRFFQ69G5FAW", Content: "Server v3", Category: CategoryDependencyBehavior,
+		Confidence: 0.7, ValidationCount: 4, CreatedAt: past, UpdatedAt: newer,
+	}); err != nil {
+		t.Fatalf("UpsertLore: %v", err)
+	}
+	got, _ = store.Get("01ARZ3NDEKTSV4RRFFQ69G5FAW")
+	if got.Confidence != 0.7 || got.ValidationCount != 4 {
+		t.Errorf("confidence/validation = %.2f/%d, want server 0.70/4", got.Confidence, got.ValidationCount)
+	}
+}
+
 // TestStore_UpsertLore_StoreClosed verifies error when store is closed.
 func TestStore_UpsertLore_StoreClosed(t *testing.T) {
 	dbPath := filepath.Join(t.TempDir(), "test.db")
@@ -2299,3 +2349,42 @@ func TestDeleteSyncEntry_StoreClosed(t *testing.T) {
 		t.Errorf("DeleteSyncEntry on closed store = %v, want ErrStoreClosed", err)
 	}
 }
+
+func TestStore_Query_SourceFilters(t *testing.T) {
+	store := newTestStore(t)
+	now := time.Now().UTC()
+	for _, l := range []*Lore{
+		{ID: "s1", Content: "a", Category: CategoryPatternOutcome, Confidence: 0.5, SourceID: "agent-a"},
+		{ID: "s2", Content: "b", Category: CategoryPatternOutcome, Confidence: 0.5, SourceID: "agent-b"},
+		{ID: "s3", Content: "c", Category: CategoryPatternOutcome, Confidence: 0.5, SourceID: "human"},
+	} {
+		l.CreatedAt, l.UpdatedAt = now, now
+		if err := store.InsertLore(l); err != nil {
+			t.Fatalf("InsertLore: %v", err)
+		}
+	}
+
+	ids := func(params QueryParams) []string {
+		t.Helper()
+		got, err := store.Query(params)
+		if err != nil {
+			t.Fatalf("Query: %v", err)
+		}
+		var ids []string
+		for _, l := range got {
+			ids = append(ids, l.ID)
+		}
+		sort.Strings(ids)
+		return ids
+	}
+
+	if got := ids(QueryParams{SourceIDs: []string{"agent-a", "human"}}); !reflect.DeepEqual(got, []string{"s1", "s3"}) {
+		t.Errorf("SourceIDs: got %v, want [s1 s3]", got)
+	}
+	if got := ids(QueryParams{ExcludeSourceIDs: []string{"human"}}); !reflect.DeepEqual(got, []string{"s1", "s2"}) {
+		t.Errorf("ExcludeSourceIDs: got %v, want [s1 s2]", got)
+	}
+	if got := ids(QueryParams{SourceIDs: []string{"agent-a", "agent-b"}, ExcludeSourceIDs: []string{"agent-b"}}); !reflect.DeepEqual(got, []string{"s1"}) {
+		t.Errorf("both filters: got %v, want [s1]", got)
+	}
+}
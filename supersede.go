@@ -0,0 +1,213 @@
+package recall
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SupersededPenalty multiplies the ranking score of lore that another
+// entry supersedes, so queries prefer the replacement.
+const SupersededPenalty = 0.5
+
+// Supersede records that newID replaces oldID. An entry is superseded by
+// at most one other; a later call replaces the link. Links are local-only.
+// Returns ErrNotFound if either entry does not exist.
+func (s *Store) Supersede(oldID, newID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return ErrStoreClosed
+	}
+	if oldID == newID {
+		return &ValidationError{Field: "SupersededBy", Message: "an entry cannot supersede itself"}
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("store: begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	for _, id := range []string{oldID, newID} {
+		if _, err := s.getLoreTx(tx, id); err != nil {
+			return err
+		}
+	}
+
+	// Refuse cycles: walk the replacement's own chain of replacements.
+	for id, seen := newID, map[string]bool{}; !seen[id]; {
+		seen[id] = true
+		var next string
+		err := tx.QueryRow(`SELECT superseded_by FROM lore_supersessions WHERE lore_id = ?`, id).Scan(&next)
+		if err != nil {
+			break
+		}
+		if next == oldID {
+			return &ValidationError{Field: "SupersededBy", Message: fmt.Sprintf("%s already supersedes %s", oldID, newID)}
+		}
+		id = next
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO lore_supersessions (lore_id, superseded_by, created_at) VALUES (?, ?, ?)
+		ON CONFLICT(lore_id) DO UPDATE SET superseded_by = excluded.superseded_by, created_at = excluded.created_at
+	`, oldID, newID, s.now().Format(time.RFC3339))
+	if err != nil {
+		return fmt.Errorf("store: supersede: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("store: commit: %w", err)
+	}
+	return nil
+}
+
+// SupersededBy returns, for each of the given lore IDs that is superseded
+// by a live entry, the ID of its replacement.
+func (s *Store) SupersededBy(loreIDs []string) (map[string]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil, ErrStoreClosed
+	}
+
+	superseded := make(map[string]string)
+	if len(loreIDs) == 0 {
+		return superseded, nil
+	}
+
+	placeholders := make([]string, len(loreIDs))
+	args := make([]any, 0, len(loreIDs)+1)
+	for i, id := range loreIDs {
+		placeholders[i] = "?"
+		args = append(args, id)
+	}
+	args = append(args, s.namespace)
+
+	rows, err := s.db.Query(fmt.Sprintf(`
+		SELECT ls.lore_id, ls.superseded_by FROM lore_supersessions ls
+		JOIN lore_entries l ON l.id = ls.superseded_by
+		WHERE ls.lore_id IN (%s) AND l.deleted_at IS NULL AND l.namespace = ?
+	`, strings.Join(placeholders, ",")), args...)
+	if err != nil {
+		return nil, fmt.Errorf("store: query supersessions: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var id, by string
+		if err := rows.Scan(&id, &by); err != nil {
+			return nil, fmt.Errorf("store: scan supersession: %w", err)
+		}
+		superseded[id] = by
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("store: iterate supersessions: %w", err)
+	}
+	return superseded, nil
+}
+
+// Supersede links newRef as the replacement of oldRef, so queries prefer
+// it. Both may be lore IDs or session refs (L1, L2, ...).
+func (c *Client) Supersede(oldRef, newRef string) error {
+	if err := c.authorize(ActionFeedback, ""); err != nil {
+		return err
+	}
+	oldID, err := c.resolveRef(oldRef)
+	if err != nil {
+		return err
+	}
+	newID, err := c.resolveRef(newRef)
+	if err != nil {
+		return err
+	}
+	if err := c.store.Supersede(oldID, newID); err != nil {
+		return fmt.Errorf("client: supersede: %w", err)
+	}
+	return nil
+}
+
+// ReplaceOutdated marks ref Outdated, records content as its replacement
+// in the same category, and links the two with Supersede. It returns the
+// replacement entry. Outdated feedback skipped under Config.FeedbackWindow
+// does not stop the replacement.
+func (c *Client) ReplaceOutdated(ref, content string, opts ...RecordOption) (*Lore, error) {
+	oldID, err := c.resolveRef(ref)
+	if err != nil {
+		return nil, err
+	}
+	outdated, err := c.store.Get(oldID)
+	if err != nil {
+		return nil, fmt.Errorf("client: replace outdated: %w", err)
+	}
+	replacement, err := c.Record(content, outdated.Category, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := c.Feedback(oldID, Outdated); err != nil && !errors.Is(err, ErrFeedbackSkipped) {
+		return nil, err
+	}
+	if err := c.Supersede(oldID, replacement.ID); err != nil {
+		return nil, err
+	}
+	return replacement, nil
+}
+
+// supersededIDs returns the entries of lore that are superseded, or nil
+// if none are.
+func (c *Client) supersededIDs(lore []Lore) (map[string]string, error) {
+	ids := make([]string, len(lore))
+	for i, l := range lore {
+		ids[i] = l.ID
+	}
+	superseded, err := c.store.SupersededBy(ids)
+	if err != nil || len(superseded) == 0 {
+		return nil, err
+	}
+	return superseded, nil
+}
+
+// withoutSuperseded drops superseded entries from lore.
+func withoutSuperseded(lore []Lore, superseded map[string]string) []Lore {
+	if len(superseded) == 0 {
+		return lore
+	}
+	kept := lore[:0]
+	for _, l := range lore {
+		if _, ok := superseded[l.ID]; !ok {
+			kept = append(kept, l)
+		}
+	}
+	return kept
+}
+
+// demoteSuperseded applies SupersededPenalty to superseded entries' scores
+// and re-sorts.
+func demoteSuperseded(scored []ScoredLore, superseded map[string]string) {
+	if len(superseded) == 0 {
+		return
+	}
+	for i := range scored {
+		if _, ok := superseded[scored[i].ID]; ok {
+			scored[i].Score *= SupersededPenalty
+		}
+	}
+	sort.SliceStable(scored, func(i, j int) bool { return scored[i].Score > scored[j].Score })
+}
+
+// rankSupersededLast stably moves superseded entries after the others.
+// Used when there is no similarity score to weight.
+func rankSupersededLast(lore []Lore, superseded map[string]string) {
+	if len(superseded) == 0 {
+		return
+	}
+	sort.SliceStable(lore, func(i, j int) bool {
+		_, si := superseded[lore[i].ID]
+		_, sj := superseded[lore[j].ID]
+		return !si && sj
+	})
+}
@@ -0,0 +1,131 @@
+package recall
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestClient_Feedback_Outdated(t *testing.T) {
+	client := newTestClient(t, Config{AutoSync: false})
+
+	l, err := client.Record("Use pgx v4", CategoryDependencyBehavior, WithConfidence(0.8))
+	if err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+	got, err := client.Feedback(l.ID, Outdated)
+	if err != nil {
+		t.Fatalf("Feedback: %v", err)
+	}
+	if want := 0.8 + ConfidenceOutdatedDelta; got.Confidence < want-1e-9 || got.Confidence > want+1e-9 {
+		t.Errorf("Confidence = %v, want %v", got.Confidence, want)
+	}
+	if got.ValidationCount != 0 {
+		t.Errorf("ValidationCount = %d, want 0", got.ValidationCount)
+	}
+
+	// Batch feedback resolves refs from the session.
+	if _, err := client.Query(context.Background(), QueryParams{Query: "pgx"}); err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	result, err := client.FeedbackBatch(context.Background(), FeedbackParams{Outdated: []string{"L1", "L9"}})
+	if err != nil {
+		t.Fatalf("FeedbackBatch: %v", err)
+	}
+	if len(result.Updated) != 1 || result.Updated[0].Current >= got.Confidence || len(result.NotFound) != 1 {
+		t.Errorf("FeedbackBatch = %+v", result)
+	}
+}
+
+func TestClient_ReplaceOutdated(t *testing.T) {
+	client := newTestClient(t, Config{AutoSync: false})
+
+	old, err := client.Record("Use pgx v4 for Postgres", CategoryDependencyBehavior)
+	if err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+	replacement, err := client.ReplaceOutdated(old.ID, "Use pgx v5 for Postgres; v4 is end of life")
+	if err != nil {
+		t.Fatalf("ReplaceOutdated: %v", err)
+	}
+	if replacement.Category != CategoryDependencyBehavior {
+		t.Errorf("replacement category = %s, want the outdated entry's", replacement.Category)
+	}
+
+	superseded, err := client.store.SupersededBy([]string{old.ID, replacement.ID})
+	if err != nil {
+		t.Fatalf("SupersededBy: %v", err)
+	}
+	if len(superseded) != 1 || superseded[old.ID] != replacement.ID {
+		t.Errorf("SupersededBy = %v, want %s -> %s", superseded, old.ID, replacement.ID)
+	}
+
+	var ve *ValidationError
+	if err := client.Supersede(replacement.ID, old.ID); !errors.As(err, &ve) {
+		t.Errorf("reverse Supersede = %v, want ValidationError for the cycle", err)
+	}
+	if err := client.Supersede(old.ID, old.ID); !errors.As(err, &ve) {
+		t.Errorf("self Supersede = %v, want ValidationError", err)
+	}
+	if err := client.Supersede(old.ID, "01MISSING0000000000000000"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Supersede to missing = %v, want ErrNotFound", err)
+	}
+	if _, err := client.ReplaceOutdated("01MISSING0000000000000000", "x"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("ReplaceOutdated missing = %v, want ErrNotFound", err)
+	}
+}
+
+func TestQuery_SupersededRanksLast(t *testing.T) {
+	client := newTestClient(t, Config{AutoSync: false})
+
+	now := time.Now().UTC()
+	insert := func(id string, emb []float32) {
+		l := &Lore{ID: id, Content: id, Category: CategoryDependencyBehavior, Confidence: 0.5,
+			SourceID: "s", Embedding: PackFloat32(emb), CreatedAt: now, UpdatedAt: now}
+		if err := client.store.InsertLore(l); err != nil {
+			t.Fatalf("InsertLore: %v", err)
+		}
+	}
+	insert("old", []float32{1, 0})
+	insert("new", []float32{0.8, 0.6})
+	if err := client.Supersede("old", "new"); err != nil {
+		t.Fatalf("Supersede: %v", err)
+	}
+
+	for _, embedding := range [][]float32{{1, 0}, nil} {
+		result, err := client.Query(context.Background(), QueryParams{Query: "q", QueryEmbedding: embedding, K: 2, Explain: true})
+		if err != nil {
+			t.Fatalf("Query: %v", err)
+		}
+		if len(result.Lore) != 2 || result.Lore[0].ID != "new" {
+			t.Fatalf("Query(embedding=%v) = %v, want new first", embedding, result.Lore)
+		}
+		if result.SupersededBy["old"] != "new" || len(result.SupersededBy) != 1 {
+			t.Errorf("SupersededBy = %v", result.SupersededBy)
+		}
+		if !result.Explanations["old"].Superseded || result.Explanations["new"].Superseded {
+			t.Errorf("Explanations = %+v", result.Explanations)
+		}
+
+		hidden, err := client.Query(context.Background(), QueryParams{Query: "q", QueryEmbedding: embedding, K: 2, HideSuperseded: true})
+		if err != nil {
+			t.Fatalf("Query: %v", err)
+		}
+		if len(hidden.Lore) != 1 || hidden.Lore[0].ID != "new" || hidden.Stats.TotalMatched != 1 {
+			t.Errorf("Query(HideSuperseded) = %v (matched %d), want only new", hidden.Lore, hidden.Stats.TotalMatched)
+		}
+	}
+
+	// Deleting the replacement restores the old entry.
+	if err := client.store.DeleteLoreByID("new"); err != nil {
+		t.Fatalf("DeleteLoreByID: %v", err)
+	}
+	result, err := client.Query(context.Background(), QueryParams{Query: "q", HideSuperseded: true})
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	if len(result.Lore) != 1 || result.Lore[0].ID != "old" {
+		t.Errorf("Query after delete = %v, want old", result.Lore)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"crypto/rand"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -13,6 +14,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -36,13 +38,50 @@ type Syncer struct {
 	store     *Store
 	storeID   string // Store context for multi-store sync (Story 7.5)
 	engramURL string
-	apiKey    string
 	sourceID  string
 	client    *http.Client
 	debug     *DebugLogger
 
+	// credMu guards apiKey and refreshFn, which may be swapped while
+	// requests are in flight.
+	credMu    sync.RWMutex
+	apiKey    string
+	refreshFn CredentialRefreshFunc
+
+	// signingSecret, when non-empty, enables HMAC signing of push bodies.
+	signingSecret []byte
+
+	// conflictPolicy controls how remote upserts that collide with
+	// unpushed local edits are handled during delta sync.
+	conflictPolicy ConflictPolicy
+
+	// budgetMu guards budget accounting; see budget.go.
+	budgetMu         sync.Mutex
+	budget           SyncBudget
+	onBudgetDeferred BudgetDeferredFunc
+
+	// onSyncEvent is called for each event counted in SyncMetrics.
+	onSyncEvent SyncEventFunc
+
+	// deltaPageSize overrides syncDeltaPageLimit when > 0.
+	deltaPageSize int
+
+	// nowFn is used for testable budget windows. If nil, defaults to time.Now.
+	nowFn func() time.Time
+
 	// sleepFn is used for testable retry delays. If nil, defaults to real sleep.
 	sleepFn func(ctx context.Context, d time.Duration) error
+
+	// retry bounds push retries; see retry.go.
+	retry   RetryPolicy
+	onRetry RetryFunc
+
+	// canary, when set, is the API version sync requests are shadowed to;
+	// see canary.go.
+	canary string
+
+	// pauseFile, while it exists, disables sync; see killswitch.go.
+	pauseFile string
 }
 
 // NewSyncer creates a new syncer.
@@ -52,17 +91,70 @@ func NewSyncer(store *Store, engramURL, apiKey, sourceID string) *Syncer {
 		engramURL: engramURL,
 		apiKey:    apiKey,
 		sourceID:  sourceID,
+		retry:     RetryPolicy{}.withDefaults(),
 		client: &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout:   30 * time.Second,
+			Transport: newDefaultTransport(),
 		},
 	}
 }
 
+// newDefaultTransport returns a clone of http.DefaultTransport that honors
+// HTTP_PROXY, HTTPS_PROXY, and NO_PROXY from the environment. The clone keeps
+// the default dial, keep-alive, TLS and HTTP/2 settings; only Proxy is set.
+// A program that replaced http.DefaultTransport gets its replacement as is.
+func newDefaultTransport() http.RoundTripper {
+	base, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return http.DefaultTransport
+	}
+	t := base.Clone()
+	t.Proxy = http.ProxyFromEnvironment
+	return t
+}
+
+// SetTransport replaces the HTTP transport used for Engram requests.
+// Use this to inject authentication, custom TLS, or an explicit proxy.
+// A nil transport restores the default environment-aware transport.
+func (s *Syncer) SetTransport(rt http.RoundTripper) {
+	if rt == nil {
+		rt = newDefaultTransport()
+	}
+	s.client.Transport = rt
+}
+
 // SetDebugLogger sets the debug logger for the syncer.
 func (s *Syncer) SetDebugLogger(logger *DebugLogger) {
 	s.debug = logger
 }
 
+// SetAPIKey replaces the API key used for subsequent Engram requests.
+// Safe to call concurrently with in-flight sync operations.
+func (s *Syncer) SetAPIKey(apiKey string) {
+	s.credMu.Lock()
+	defer s.credMu.Unlock()
+	s.apiKey = apiKey
+}
+
+// SetCredentialRefresh sets the callback used to obtain a fresh API key
+// when Engram rejects a request with 401 Unauthorized.
+func (s *Syncer) SetCredentialRefresh(fn CredentialRefreshFunc) {
+	s.credMu.Lock()
+	defer s.credMu.Unlock()
+	s.refreshFn = fn
+}
+
+// SetSigningSecret enables HMAC-SHA256 signing of push request bodies.
+// Each signed request carries an X-Recall-Signature header. An empty secret
+// disables signing.
+func (s *Syncer) SetSigningSecret(secret string) {
+	if secret == "" {
+		s.signingSecret = nil
+		return
+	}
+	s.signingSecret = []byte(secret)
+}
+
 // SetStoreID sets the store context for sync operations.
 // All sync path helpers require a non-empty storeID and will panic if not set.
 func (s *Syncer) SetStoreID(storeID string) {
@@ -143,6 +235,7 @@ type SyncDeltaResponse struct {
 	LastSequence   int64        `json:"last_sequence"`
 	LatestSequence int64        `json:"latest_sequence"`
 	HasMore        bool         `json:"has_more"`
+	NextCursor     string       `json:"next_cursor,omitempty"`
 }
 
 // DeltaEntry represents a single entry in the delta response.
@@ -202,7 +295,7 @@ func (s *Syncer) Health(ctx context.Context) (*engramHealthResponse, error) {
 	}
 	s.setHeaders(req)
 
-	resp, err := s.client.Do(req)
+	resp, err := s.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -229,8 +322,6 @@ func (s *Syncer) Push(ctx context.Context) error {
 // syncPushBatchSize is the maximum number of change_log entries per push request.
 const syncPushBatchSize = 1000
 
-// syncPushMaxRetries is the maximum number of retries on transient errors.
-const syncPushMaxRetries = 5
 
 // generatePushID returns a new UUID v4 string for push idempotency.
 func generatePushID() string {
@@ -244,7 +335,8 @@ func generatePushID() string {
 
 // PushResult contains the outcome of a SyncPush operation.
 type PushResult struct {
-	EntriesPushed int // Total change_log entries pushed across all batches
+	EntriesPushed int  // Total change_log entries pushed across all batches
+	Recovered     bool // An interrupted push was re-sent under its journaled push_id
 }
 
 // SyncPush pushes local change_log entries to Engram via POST /sync/push.
@@ -253,12 +345,18 @@ type PushResult struct {
 //  1. Read last_push_seq from sync_meta
 //  2. Read up to 1000 entries from change_log where seq > last_push_seq
 //  3. If empty, return nil (no-op)
-//  4. Generate UUID push_id, build SyncPushRequest, POST to /sync/push
-//  5. On 200: update last_push_seq, loop if more entries remain
+//  4. Generate UUID push_id, journal the batch, POST to /sync/push
+//  5. On 200: update last_push_seq and clear the journal, loop if more entries remain
 //  6. On 422: return validation error (no retry)
 //  7. On 409: return schema mismatch error (halt sync)
-//  8. On transient error: retry with same push_id (exponential backoff)
+//  8. On transient error: retry with same push_id (exponential backoff, see RetryPolicy)
+//
+// If a previous push was interrupted (see PushJournal), its batch is re-sent
+// first under the original push_id so Engram can deduplicate it.
 func (s *Syncer) SyncPush(ctx context.Context) (*PushResult, error) {
+	if err := s.store.EnsureReady(ctx); err != nil {
+		return nil, fmt.Errorf("sync push: %w", err)
+	}
 	sourceID := s.store.SourceID()
 	result := &PushResult{}
 
@@ -276,19 +374,41 @@ func (s *Syncer) SyncPush(ctx context.Context) (*PushResult, error) {
 	}
 
 	for {
-		entries, err := s.store.UnpushedChanges(sourceID, lastPushSeq, syncPushBatchSize)
+		journal, entries, err := s.journaledBatch(sourceID, lastPushSeq)
 		if err != nil {
-			return nil, fmt.Errorf("sync push: read changes: %w", err)
+			return nil, fmt.Errorf("sync push: read push journal: %w", err)
 		}
-		if len(entries) == 0 {
-			return result, nil
+
+		var pushID string
+		if journal != nil {
+			pushID = journal.PushID
+			result.Recovered = true
+			s.debug.LogSync("push", fmt.Sprintf("re-sending interrupted push %s (sequences %d-%d)", pushID, journal.FirstSeq, journal.LastSeq))
+		} else {
+			entries, err = s.store.UnpushedChanges(sourceID, lastPushSeq, syncPushBatchSize)
+			if err != nil {
+				return nil, fmt.Errorf("sync push: read changes: %w", err)
+			}
+			if len(entries) == 0 {
+				return result, nil
+			}
+
+			pushID = generatePushID()
+			if err := s.store.beginPush(PushJournal{
+				PushID:    pushID,
+				FirstSeq:  entries[0].Sequence,
+				LastSeq:   entries[len(entries)-1].Sequence,
+				Entries:   len(entries),
+				StartedAt: s.store.now(),
+			}); err != nil {
+				return nil, fmt.Errorf("sync push: journal batch: %w", err)
+			}
 		}
 
-		pushID := generatePushID()
 		req := SyncPushRequest{
 			PushID:        pushID,
 			SourceID:      sourceID,
-			SchemaVersion: 2,
+			SchemaVersion: pushSchemaVersion,
 			Entries:       entries,
 		}
 
@@ -299,7 +419,7 @@ func (s *Syncer) SyncPush(ctx context.Context) (*PushResult, error) {
 
 		// Update last_push_seq to the highest local sequence pushed
 		highestSeq := entries[len(entries)-1].Sequence
-		if err := s.store.SetSyncMeta("last_push_seq", strconv.FormatInt(highestSeq, 10)); err != nil {
+		if err := s.store.completePush(highestSeq); err != nil {
 			return nil, fmt.Errorf("sync push: update last_push_seq: %w", err)
 		}
 		lastPushSeq = highestSeq
@@ -307,8 +427,9 @@ func (s *Syncer) SyncPush(ctx context.Context) (*PushResult, error) {
 		result.EntriesPushed += len(entries)
 		_ = resp // response logged if debug enabled
 
-		// If we got fewer than batch size, we're done
-		if len(entries) < syncPushBatchSize {
+		// If we got fewer than batch size, we're done; a recovered batch
+		// may be short, so look for newer changes after it.
+		if journal == nil && len(entries) < syncPushBatchSize {
 			return result, nil
 		}
 		// Otherwise loop for next batch
@@ -323,17 +444,21 @@ func (s *Syncer) doSyncPush(ctx context.Context, pushReq SyncPushRequest) (*Sync
 	}
 
 	var lastErr error
-	for attempt := 0; attempt <= syncPushMaxRetries; attempt++ {
-		if attempt > 0 {
-			// Exponential backoff: 1s, 2s, 4s, 8s, 16s (capped at 60s)
-			delay := time.Duration(1<<uint(attempt-1)) * time.Second
-			if delay > 60*time.Second {
-				delay = 60 * time.Second
-			}
-			select {
-			case <-ctx.Done():
-				return nil, ctx.Err()
-			case <-time.After(delay):
+	var lastStatus int
+	for attempt := 1; attempt <= s.retry.MaxAttempts; attempt++ {
+		if attempt > 1 {
+			// Exponential backoff: 1s, 2s, 4s, ... capped at MaxWait
+			wait := s.retry.wait(attempt)
+			s.notifyRetry(RetryEvent{
+				Operation:   "push",
+				Attempt:     attempt,
+				MaxAttempts: s.retry.MaxAttempts,
+				Wait:        wait,
+				Class:       retryClass(lastStatus),
+				Err:         lastErr,
+			})
+			if err := s.contextSleep(ctx, wait); err != nil {
+				return nil, err
 			}
 		}
 
@@ -343,10 +468,16 @@ func (s *Syncer) doSyncPush(ctx context.Context, pushReq SyncPushRequest) (*Sync
 		}
 		s.setHeaders(req)
 		req.Header.Set("Content-Type", "application/json")
+		if len(s.signingSecret) > 0 {
+			req.Header.Set(SignatureHeader, SignBody(s.signingSecret, body))
+		}
 
-		resp, err := s.client.Do(req)
+		resp, err := s.do(req)
+		if errors.Is(err, ErrEngramMaintenance) {
+			return nil, fmt.Errorf("sync push: %w", err)
+		}
 		if err != nil {
-			lastErr = fmt.Errorf("sync push: %w", err)
+			lastErr, lastStatus = fmt.Errorf("sync push: %w", err), 0
 			continue // retry with same push_id
 		}
 
@@ -359,17 +490,25 @@ func (s *Syncer) doSyncPush(ctx context.Context, pushReq SyncPushRequest) (*Sync
 			if err := json.Unmarshal(respBody, &pushResp); err != nil {
 				return nil, fmt.Errorf("sync push: decode response: %w", err)
 			}
+			s.shadowPush(ctx, body, &pushResp)
 			return &pushResp, nil
 
 		case http.StatusUnprocessableEntity:
+			// Engram refused the batch; a retry under this push_id
+			// would be refused again.
+			_ = s.store.clearPushJournal()
 			var valErr SyncValidationError
 			if err := json.Unmarshal(respBody, &valErr); err != nil {
+				s.recordPushRejected("validation", 0)
 				return nil, fmt.Errorf("sync push: validation error (decode failed): %s", truncate(string(respBody), 200))
 			}
+			s.recordPushRejected("validation", len(valErr.Errors))
 			return nil, fmt.Errorf("sync push: validation error: %d entries rejected", len(valErr.Errors))
 
 		case http.StatusConflict:
+			_ = s.store.clearPushJournal()
 			var schemaErr SchemaMismatchError
+			s.recordPushRejected("schema_mismatch", 0)
 			if err := json.Unmarshal(respBody, &schemaErr); err != nil {
 				return nil, fmt.Errorf("sync push: schema mismatch (decode failed): %s", truncate(string(respBody), 200))
 			}
@@ -377,7 +516,7 @@ func (s *Syncer) doSyncPush(ctx context.Context, pushReq SyncPushRequest) (*Sync
 
 		default:
 			// Transient error — retry
-			lastErr = fmt.Errorf("sync push: HTTP %d: %s", resp.StatusCode, truncate(string(respBody), 200))
+			lastErr, lastStatus = fmt.Errorf("sync push: HTTP %d: %s", resp.StatusCode, truncate(string(respBody), 200)), resp.StatusCode
 			continue
 		}
 	}
@@ -393,105 +532,164 @@ func truncate(s string, max int) string {
 }
 
 
-// syncDeltaPageLimit is the maximum number of entries requested per delta page.
+// syncDeltaPageLimit is the default number of entries requested per delta page.
 const syncDeltaPageLimit = 500
 
 // DeltaResult contains the outcome of a SyncDelta operation.
 type DeltaResult struct {
-	EntriesApplied int   // Entries applied (upserts + deletes from remote sources)
-	EntriesSkipped int   // Entries skipped (own source_id filtered out)
-	LastSequence   int64 // Current sequence position after pull
+	EntriesApplied    int   // Entries applied (upserts + deletes from remote sources)
+	EntriesSkipped    int   // Entries skipped (own source_id filtered out)
+	EntriesConflicted int   // Entries recorded as conflicts (ConflictPolicyReview only)
+	PagesApplied      int   // Delta pages fetched and committed
+	Resumed           bool  // True if an interrupted delta was resumed from its cursor
+	LastSequence      int64 // Current sequence position after pull
 }
 
 // SyncDelta fetches and applies incremental changes from Engram.
 //
 // Process:
 //  1. Return ErrOffline if engramURL is empty
-//  2. Read last_pull_seq from sync_meta (defaults to 0)
-//  3. GET deltaPath()?after={last_pull_seq}&limit=500
+//  2. Read last_pull_seq and any saved delta_cursor from sync_meta
+//  3. GET deltaPath()?after={last_pull_seq}&limit={page size}[&cursor=...]
 //  4. Parse SyncDeltaResponse
 //  5. For each entry, skip if source_id matches client's own source_id
-//  6. Apply upserts via Store.UpsertLore (embedding_status = pending)
-//  7. Apply deletes via Store.SoftDeleteLoreAt (using received_at)
-//  8. Update sync_meta.last_pull_seq to response.last_sequence
-//  9. If has_more, loop from step 3 with updated last_pull_seq
+//  6. Prepare upserts (embedding_status = pending), deletes (using
+//     received_at) and, under ConflictPolicyReview, conflicts
+//  7. Apply the page and advance last_pull_seq/delta_cursor in one transaction
+//  8. If has_more, loop from step 3 with updated position
+//
+// Because progress is committed per page, an interrupted delta resumes from
+// the last committed page instead of restarting.
 func (s *Syncer) SyncDelta(ctx context.Context) (*DeltaResult, error) {
-	if s.engramURL == "" {
-		return nil, ErrOffline
-	}
-
-	ownSourceID := s.store.SourceID()
-	result := &DeltaResult{}
+	return s.syncDelta(ctx, nil)
+}
 
-	// Read last_pull_seq from sync_meta
+// deltaPosition reads last_pull_seq and any saved delta_cursor from sync_meta.
+func (s *Syncer) deltaPosition() (int64, string, error) {
 	lastPullSeqStr, err := s.store.GetSyncMeta("last_pull_seq")
 	if err != nil {
-		return nil, fmt.Errorf("sync delta: read last_pull_seq: %w", err)
+		return 0, "", fmt.Errorf("read last_pull_seq: %w", err)
 	}
 	lastPullSeq := int64(0)
 	if lastPullSeqStr != "" {
 		lastPullSeq, err = strconv.ParseInt(lastPullSeqStr, 10, 64)
 		if err != nil {
-			return nil, fmt.Errorf("sync delta: parse last_pull_seq: %w", err)
+			return 0, "", fmt.Errorf("parse last_pull_seq: %w", err)
 		}
 	}
 
-	for {
-		// Build request URL
-		reqURL := fmt.Sprintf("%s%s?after=%d&limit=%d",
-			s.engramURL, s.deltaPath(), lastPullSeq, syncDeltaPageLimit)
+	cursor, err := s.store.GetSyncMeta(deltaCursorKey)
+	if err != nil {
+		return 0, "", fmt.Errorf("read %s: %w", deltaCursorKey, err)
+	}
+	return lastPullSeq, cursor, nil
+}
 
-		req, err := http.NewRequestWithContext(ctx, "GET", reqURL, nil)
-		if err != nil {
-			return nil, fmt.Errorf("sync delta: create request: %w", err)
-		}
-		s.setHeaders(req)
+// syncDelta is SyncDelta, using first as the first page when it is not
+// nil. first must have been fetched from the current delta position.
+func (s *Syncer) syncDelta(ctx context.Context, first *SyncDeltaResponse) (*DeltaResult, error) {
+	if s.engramURL == "" {
+		return nil, ErrOffline
+	}
 
-		resp, err := s.client.Do(req)
-		if err != nil {
-			return nil, fmt.Errorf("sync delta: %w", err)
-		}
+	if err := s.store.EnsureReady(ctx); err != nil {
+		return nil, fmt.Errorf("sync delta: %w", err)
+	}
+	ownSourceID := s.store.SourceID()
+	result := &DeltaResult{}
 
-		if resp.StatusCode != http.StatusOK {
-			respBody, _ := io.ReadAll(resp.Body)
-			_ = resp.Body.Close()
-			return nil, fmt.Errorf("sync delta: HTTP %d: %s", resp.StatusCode, truncate(string(respBody), 200))
-		}
+	lastPullSeq, cursor, err := s.deltaPosition()
+	if err != nil {
+		return nil, fmt.Errorf("sync delta: %w", err)
+	}
+	if cursor != "" {
+		result.Resumed = true
+		s.debug.LogSync("delta", fmt.Sprintf("resuming interrupted delta after sequence %d", lastPullSeq))
+	}
 
-		var deltaResp SyncDeltaResponse
-		if err := json.NewDecoder(resp.Body).Decode(&deltaResp); err != nil {
-			_ = resp.Body.Close()
-			return nil, fmt.Errorf("sync delta: decode response: %w", err)
+	for {
+		deltaResp := first
+		first = nil
+		if deltaResp == nil {
+			deltaResp, err = s.fetchDeltaPage(ctx, lastPullSeq, cursor)
+			if err != nil {
+				return nil, fmt.Errorf("sync delta: %w", err)
+			}
 		}
-		_ = resp.Body.Close()
 
-		// Apply entries, filtering out own source_id
+		// Prepare entries, filtering out own source_id
+		ops := make([]deltaOp, 0, len(deltaResp.Entries))
+		applied, conflicted := 0, 0
 		for _, entry := range deltaResp.Entries {
 			if entry.SourceID == ownSourceID {
 				result.EntriesSkipped++
 				continue // skip own entries
 			}
 
+			if entry.TableName == attachmentsTable {
+				op, err := parseAttachmentDelta(entry)
+				if err != nil {
+					return nil, fmt.Errorf("sync delta: apply %s: %w", entry.EntityID, err)
+				}
+				ops = append(ops, op)
+				applied++
+				continue
+			}
+
 			switch entry.Operation {
 			case "upsert":
-				if err := s.applyDeltaUpsert(entry); err != nil {
+				local, err := s.locallyModified(entry)
+				if err != nil {
+					return nil, fmt.Errorf("sync delta: check conflict %s: %w", entry.EntityID, err)
+				}
+				if local != nil && s.conflictPolicy == ConflictPolicyReview {
+					ops = append(ops, deltaOp{conflict: local, payload: entry.Payload, sequence: entry.Sequence})
+					conflicted++
+					continue
+				}
+				lore, err := parseLorePayload(entry.Payload)
+				if err != nil {
 					return nil, fmt.Errorf("sync delta: apply upsert %s: %w", entry.EntityID, err)
 				}
-				result.EntriesApplied++
+				lore.EmbeddingStatus = "pending" // AC #3: embedding_status set to pending
+				ops = append(ops, deltaOp{upsert: lore, overwrite: local != nil})
+				applied++
 			case "delete":
-				if err := s.store.SoftDeleteLoreAt(entry.EntityID, entry.ReceivedAt); err != nil {
-					return nil, fmt.Errorf("sync delta: apply delete %s: %w", entry.EntityID, err)
+				pending, err := s.store.HasUnpushedChanges(entry.EntityID)
+				if err != nil {
+					return nil, fmt.Errorf("sync delta: check conflict %s: %w", entry.EntityID, err)
 				}
-				result.EntriesApplied++
+				ops = append(ops, deltaOp{deleteID: entry.EntityID, deletedAt: entry.ReceivedAt, overwrite: pending})
+				applied++
 			}
 		}
 
-		// Update last_pull_seq
-		lastPullSeq = deltaResp.LastSequence
-		if err := s.store.SetSyncMeta("last_pull_seq", strconv.FormatInt(lastPullSeq, 10)); err != nil {
-			return nil, fmt.Errorf("sync delta: update last_pull_seq: %w", err)
+		cursor = ""
+		if deltaResp.HasMore {
+			cursor = deltaResp.NextCursor
+		}
+		if err := s.store.applyDeltaPage(ops, deltaResp.LastSequence, cursor); err != nil {
+			return nil, fmt.Errorf("sync delta: apply page after %d: %w", lastPullSeq, err)
+		}
+		for _, op := range ops {
+			switch {
+			case op.conflict != nil:
+				s.debug.LogSync("conflict", fmt.Sprintf("recorded conflict for %s at sequence %d", op.conflict.ID, op.sequence))
+				s.emitSyncEvent(SyncEvent{Type: SyncEventConflictDetected, LoreID: op.conflict.ID, Policy: s.conflictPolicy})
+			case op.overwrite:
+				id := op.deleteID
+				if op.upsert != nil {
+					id = op.upsert.ID
+				}
+				s.debug.LogSync("conflict", fmt.Sprintf("remote change overwrote unpushed local edits to %s", id))
+				s.emitSyncEvent(SyncEvent{Type: SyncEventLocalOverwrite, LoreID: id, Policy: s.conflictPolicy})
+			}
 		}
 
+		lastPullSeq = deltaResp.LastSequence
+		result.EntriesApplied += applied
+		result.EntriesConflicted += conflicted
+		result.PagesApplied++
 		result.LastSequence = lastPullSeq
 
 		if !deltaResp.HasMore {
@@ -500,8 +698,44 @@ func (s *Syncer) SyncDelta(ctx context.Context) (*DeltaResult, error) {
 	}
 }
 
-// applyDeltaUpsert parses a delta entry payload and upserts the lore entry.
-func (s *Syncer) applyDeltaUpsert(entry DeltaEntry) error {
+// fetchDeltaPage retrieves one page of change_log entries after the given
+// sequence. A non-empty cursor from a previous page is passed through so
+// Engram can continue a paginated read.
+func (s *Syncer) fetchDeltaPage(ctx context.Context, after int64, cursor string) (*SyncDeltaResponse, error) {
+	reqURL := fmt.Sprintf("%s%s?after=%d&limit=%d",
+		s.engramURL, s.deltaPath(), after, s.pageSize())
+	if cursor != "" {
+		reqURL += "&cursor=" + url.QueryEscape(cursor)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	s.setHeaders(req)
+
+	resp, err := s.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, truncate(string(respBody), 200))
+	}
+
+	var deltaResp SyncDeltaResponse
+	if err := json.NewDecoder(resp.Body).Decode(&deltaResp); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+	s.shadowDelta(ctx, after, cursor, &deltaResp)
+	return &deltaResp, nil
+}
+
+// parseLorePayload decodes a full-entity lore payload as produced by
+// lorePayloadJSON and carried in change_log and delta entries.
+func parseLorePayload(data json.RawMessage) (*Lore, error) {
 	var payload struct {
 		ID              string   `json:"id"`
 		Content         string   `json:"content"`
@@ -516,18 +750,19 @@ func (s *Syncer) applyDeltaUpsert(entry DeltaEntry) error {
 		UpdatedAt       string   `json:"updated_at"`
 		DeletedAt       *string  `json:"deleted_at"`
 		LastValidatedAt *string  `json:"last_validated_at"`
+		Namespace       string   `json:"namespace,omitempty"`
 	}
-	if err := json.Unmarshal(entry.Payload, &payload); err != nil {
-		return fmt.Errorf("unmarshal payload: %w", err)
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, fmt.Errorf("unmarshal payload: %w", err)
 	}
 
 	createdAt, err := time.Parse(time.RFC3339, payload.CreatedAt)
 	if err != nil {
-		return fmt.Errorf("parse created_at: %w", err)
+		return nil, fmt.Errorf("parse created_at: %w", err)
 	}
 	updatedAt, err := time.Parse(time.RFC3339, payload.UpdatedAt)
 	if err != nil {
-		return fmt.Errorf("parse updated_at: %w", err)
+		return nil, fmt.Errorf("parse updated_at: %w", err)
 	}
 
 	lore := &Lore{
@@ -536,23 +771,24 @@ func (s *Syncer) applyDeltaUpsert(entry DeltaEntry) error {
 		Context:         payload.Context,
 		Category:        Category(payload.Category),
 		Confidence:      payload.Confidence,
-		EmbeddingStatus: "pending", // AC #3: embedding_status set to pending
+		EmbeddingStatus: payload.EmbeddingStatus,
 		SourceID:        payload.SourceID,
 		Sources:         payload.Sources,
 		ValidationCount: payload.ValidationCount,
 		CreatedAt:       createdAt,
 		UpdatedAt:       updatedAt,
+		Namespace:       payload.Namespace,
 	}
 
 	if payload.LastValidatedAt != nil {
 		ts, err := time.Parse(time.RFC3339, *payload.LastValidatedAt)
 		if err != nil {
-			return fmt.Errorf("parse last_validated_at: %w", err)
+			return nil, fmt.Errorf("parse last_validated_at: %w", err)
 		}
 		lore.LastValidatedAt = &ts
 	}
 
-	return s.store.UpsertLore(lore)
+	return lore, nil
 }
 
 // bootstrapMaxRetries is the maximum number of snapshot download attempts on 503.
@@ -585,10 +821,16 @@ func (s *Syncer) contextSleep(ctx context.Context, d time.Duration) error {
 //  5. Write snapshot to temp file
 //  6. Verify with PRAGMA integrity_check (discard on failure, preserve existing DB)
 //  7. Read MAX(change_log.sequence) from snapshot for sync sequence tracking
-//  8. Atomically replace local lore via store
+//  8. Atomically replace local lore via store, under Store.Exclusive
 //  9. Initialize sync_meta: last_pull_seq, last_push_seq=0, fresh source_id
 //  10. Update metadata (embedding_model, last_sync)
 func (s *Syncer) Bootstrap(ctx context.Context) error {
+	return s.bootstrap(ctx, nil)
+}
+
+// bootstrap is Bootstrap with a check run under Store.Exclusive just
+// before the replace; if it fails, the store is left as it was.
+func (s *Syncer) bootstrap(ctx context.Context, beforeReplace func() error) error {
 	// 1. Health check
 	health, err := s.Health(ctx)
 	if err != nil {
@@ -653,46 +895,59 @@ func (s *Syncer) Bootstrap(ctx context.Context) error {
 	}
 	_ = snapshotDB.Close()
 
-	// 7. Atomic replacement: open temp file and pass to store
-	snapshotFile, err := os.Open(tmpPath)
-	if err != nil {
-		return fmt.Errorf("bootstrap: reopen snapshot: %w", err)
-	}
-	if err := s.store.ReplaceFromSnapshot(snapshotFile); err != nil {
+	// 7-9 run exclusively, so concurrent operations never see the store
+	// between the replace and the sync state that goes with it.
+	return s.store.Exclusive(ctx, func(ctx context.Context) error {
+		if beforeReplace != nil {
+			if err := beforeReplace(); err != nil {
+				return err
+			}
+		}
+
+		// 7. Atomic replacement: open temp file and pass to store
+		snapshotFile, err := os.Open(tmpPath)
+		if err != nil {
+			return fmt.Errorf("bootstrap: reopen snapshot: %w", err)
+		}
+		if err := s.store.ReplaceFromSnapshot(snapshotFile); err != nil {
+			_ = snapshotFile.Close()
+			return fmt.Errorf("bootstrap: replace store: %w", err)
+		}
 		_ = snapshotFile.Close()
-		return fmt.Errorf("bootstrap: replace store: %w", err)
-	}
-	_ = snapshotFile.Close()
 
-	// 8. Initialize sync_meta
-	// last_pull_seq = MAX(change_log.sequence) from snapshot
-	if err := s.store.SetSyncMeta("last_pull_seq", strconv.FormatInt(maxSeq, 10)); err != nil {
-		return fmt.Errorf("bootstrap: set last_pull_seq: %w", err)
-	}
-	// last_push_seq = 0 (fresh start)
-	if err := s.store.SetSyncMeta("last_push_seq", "0"); err != nil {
-		return fmt.Errorf("bootstrap: set last_push_seq: %w", err)
-	}
-	// Fresh UUIDv4 source_id
-	newSourceID, err := generateUUIDv4()
-	if err != nil {
-		return fmt.Errorf("bootstrap: generate source_id: %w", err)
-	}
-	if err := s.store.SetSyncMeta("source_id", newSourceID); err != nil {
-		return fmt.Errorf("bootstrap: set source_id: %w", err)
-	}
-	// Update cached source_id on store
-	s.store.sourceID = newSourceID
+		// 8. Initialize sync_meta
+		// last_pull_seq = MAX(change_log.sequence) from snapshot
+		if err := s.store.SetSyncMeta("last_pull_seq", strconv.FormatInt(maxSeq, 10)); err != nil {
+			return fmt.Errorf("bootstrap: set last_pull_seq: %w", err)
+		}
+		// last_push_seq = 0 (fresh start)
+		if err := s.store.SetSyncMeta("last_push_seq", "0"); err != nil {
+			return fmt.Errorf("bootstrap: set last_push_seq: %w", err)
+		}
+		if err := s.store.clearPushJournal(); err != nil {
+			return fmt.Errorf("bootstrap: clear push journal: %w", err)
+		}
+		// Fresh UUIDv4 source_id
+		newSourceID, err := generateUUIDv4()
+		if err != nil {
+			return fmt.Errorf("bootstrap: generate source_id: %w", err)
+		}
+		if err := s.store.SetSyncMeta("source_id", newSourceID); err != nil {
+			return fmt.Errorf("bootstrap: set source_id: %w", err)
+		}
+		// Update cached source_id on store
+		s.store.sourceID = newSourceID
 
-	// 9. Update metadata
-	if err := s.store.SetMetadata("embedding_model", health.EmbeddingModel); err != nil {
-		return fmt.Errorf("bootstrap: set embedding_model: %w", err)
-	}
-	if err := s.store.SetMetadata("last_sync", time.Now().UTC().Format(time.RFC3339)); err != nil {
-		return fmt.Errorf("bootstrap: set last_sync: %w", err)
-	}
+		// 9. Update metadata
+		if err := s.store.SetMetadata("embedding_model", health.EmbeddingModel); err != nil {
+			return fmt.Errorf("bootstrap: set embedding_model: %w", err)
+		}
+		if err := s.store.SetMetadata("last_sync", s.store.now().Format(time.RFC3339)); err != nil {
+			return fmt.Errorf("bootstrap: set last_sync: %w", err)
+		}
 
-	return nil
+		return nil
+	})
 }
 
 // downloadSnapshotWithRetry downloads the snapshot, retrying up to 3 times on 503.
@@ -704,7 +959,7 @@ func (s *Syncer) downloadSnapshotWithRetry(ctx context.Context) ([]byte, error)
 		}
 		s.setHeaders(req)
 
-		resp, err := s.client.Do(req)
+		resp, err := s.do(req)
 		if err != nil {
 			return nil, fmt.Errorf("bootstrap: download: %w", err)
 		}
@@ -753,13 +1008,85 @@ func (s *Syncer) Flush(ctx context.Context) error {
 }
 
 func (s *Syncer) setHeaders(req *http.Request) {
-	req.Header.Set("Authorization", "Bearer "+s.apiKey)
+	s.credMu.RLock()
+	apiKey := s.apiKey
+	s.credMu.RUnlock()
+
+	req.Header.Set("Authorization", "Bearer "+apiKey)
 	req.Header.Set("User-Agent", "recall-client/1.0")
 	if strings.TrimSpace(s.sourceID) != "" {
 		req.Header.Set("X-Recall-Source-ID", s.sourceID)
 	}
 }
 
+// do sends an Engram request. If Engram responds with 401 Unauthorized and a
+// credential refresh callback is configured, the callback is invoked and the
+// request is retried once with the refreshed API key. When the refresh fails,
+// the original 401 response is returned so callers report it as usual.
+//
+// Every request, including the retry, counts against the sync budget. A
+// maintenance response (see MaintenanceHeader) is returned as an error
+// wrapping ErrEngramMaintenance.
+func (s *Syncer) do(req *http.Request) (*http.Response, error) {
+	resp, err := s.send(req)
+	if err != nil {
+		return resp, err
+	}
+	return s.checkMaintenance(req, resp)
+}
+
+// send performs do's request, with the credential refresh retry.
+func (s *Syncer) send(req *http.Request) (*http.Response, error) {
+	if err := s.checkKillSwitch(); err != nil {
+		return nil, err
+	}
+	if err := s.reserveBudget(req); err != nil {
+		return nil, err
+	}
+	resp, err := s.client.Do(req)
+	s.meterResponse(resp)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	s.credMu.RLock()
+	refresh := s.refreshFn
+	s.credMu.RUnlock()
+	if refresh == nil {
+		return resp, nil
+	}
+
+	newKey, err := refresh(req.Context())
+	if err != nil {
+		s.debug.LogError("credential_refresh", err)
+		return resp, nil
+	}
+	if newKey == "" {
+		return resp, nil
+	}
+
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return resp, nil
+		}
+		retry.Body = body
+	}
+	_ = resp.Body.Close()
+
+	s.SetAPIKey(newKey)
+	s.setHeaders(retry)
+	s.debug.LogSync("credential_refresh", "retrying "+req.URL.Path+" with refreshed API key")
+
+	if err := s.reserveBudget(retry); err != nil {
+		return nil, err
+	}
+	resp, err = s.client.Do(retry)
+	s.meterResponse(resp)
+	return resp, err
+}
+
 // StoreListItem represents summary information for a store.
 // Used by Syncer.ListStores for remote store listing.
 //
@@ -827,7 +1154,7 @@ func (s *Syncer) ListStores(ctx context.Context, prefix string) (*StoreListResul
 	}
 	s.setHeaders(req)
 
-	resp, err := s.client.Do(req)
+	resp, err := s.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("list stores: %w", err)
 	}
@@ -870,7 +1197,7 @@ func (s *Syncer) GetStoreInfo(ctx context.Context, storeID string) (*StoreInfo,
 	}
 	s.setHeaders(req)
 
-	resp, err := s.client.Do(req)
+	resp, err := s.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("get store info: %w", err)
 	}
@@ -0,0 +1,150 @@
+package recall
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// =============================================================================
+// Credential rotation: SetAPIKey and 401 refresh callback
+// =============================================================================
+
+func TestSyncer_SetAPIKey_UsedOnNextRequest(t *testing.T) {
+	var mu sync.Mutex
+	var auths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		auths = append(auths, r.Header.Get("Authorization"))
+		mu.Unlock()
+		_, _ = w.Write([]byte(`{"status":"ok","embedding_model":"m"}`))
+	}))
+	defer server.Close()
+
+	syncer := newTestSyncer(t, newTestStore(t), server.URL)
+
+	if _, err := syncer.Health(context.Background()); err != nil {
+		t.Fatalf("Health: %v", err)
+	}
+	syncer.SetAPIKey("rotated-key")
+	if _, err := syncer.Health(context.Background()); err != nil {
+		t.Fatalf("Health: %v", err)
+	}
+
+	if auths[0] != "Bearer test-api-key" {
+		t.Errorf("first Authorization = %q, want original key", auths[0])
+	}
+	if auths[1] != "Bearer rotated-key" {
+		t.Errorf("second Authorization = %q, want rotated key", auths[1])
+	}
+}
+
+func TestSyncer_CredentialRefresh_RetriesPushWithNewKey(t *testing.T) {
+	store := newTestStore(t)
+	insertTestChangeLogEntries(t, store, 2)
+
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if r.Header.Get("Authorization") != "Bearer fresh-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"accepted":2,"remote_sequence":2}`))
+	}))
+	defer server.Close()
+
+	syncer := newTestSyncer(t, store, server.URL)
+	var calls int32
+	syncer.SetCredentialRefresh(func(ctx context.Context) (string, error) {
+		atomic.AddInt32(&calls, 1)
+		return "fresh-key", nil
+	})
+
+	result, err := syncer.SyncPush(context.Background())
+	if err != nil {
+		t.Fatalf("SyncPush: %v", err)
+	}
+	if result.EntriesPushed != 2 {
+		t.Errorf("EntriesPushed = %d, want 2", result.EntriesPushed)
+	}
+	if atomic.LoadInt32(&calls) != 1 {
+		t.Errorf("refresh called %d times, want 1", calls)
+	}
+	if len(bodies) != 2 || bodies[0] != bodies[1] || bodies[1] == "" {
+		t.Errorf("retry should replay the identical request body, got %d bodies", len(bodies))
+	}
+}
+
+func TestSyncer_CredentialRefresh_ErrorReturnsOriginal401(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	syncer := newTestSyncer(t, newTestStore(t), server.URL)
+	syncer.SetCredentialRefresh(func(ctx context.Context) (string, error) {
+		return "", errors.New("vault unavailable")
+	})
+
+	_, err := syncer.Health(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "401") {
+		t.Fatalf("Health error = %v, want 401 failure", err)
+	}
+	if atomic.LoadInt32(&requests) != 1 {
+		t.Errorf("requests = %d, want 1 (no retry after failed refresh)", requests)
+	}
+}
+
+func TestSyncer_NoCredentialRefresh_NoRetry(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	syncer := newTestSyncer(t, newTestStore(t), server.URL)
+	if _, err := syncer.Health(context.Background()); err == nil {
+		t.Fatal("expected error for 401")
+	}
+	if atomic.LoadInt32(&requests) != 1 {
+		t.Errorf("requests = %d, want 1", requests)
+	}
+}
+
+func TestClient_SetAPIKey_WiredToSyncer(t *testing.T) {
+	var received atomic.Value
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received.Store(r.Header.Get("Authorization"))
+		_, _ = w.Write([]byte(`{"status":"ok","embedding_model":"m"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client := newTestClient(t, Config{
+		EngramURL: server.URL,
+		APIKey:    "old-key",
+	})
+
+	client.SetAPIKey("new-key")
+	_ = client.HealthCheck(context.Background())
+
+	if got := received.Load(); got != "Bearer new-key" {
+		t.Errorf("Authorization = %v, want %q", got, "Bearer new-key")
+	}
+}
+
+func TestClient_SetAPIKey_OfflineNoop(t *testing.T) {
+	client := newTestClient(t, Config{})
+
+	client.SetAPIKey("ignored") // must not panic
+}
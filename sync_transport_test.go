@@ -0,0 +1,101 @@
+package recall
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+// countingTransport records requests and adds a header before delegating.
+type countingTransport struct {
+	calls int32
+	next  http.RoundTripper
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	atomic.AddInt32(&t.calls, 1)
+	req = req.Clone(req.Context())
+	req.Header.Set("X-Injected", "yes")
+	return t.next.RoundTrip(req)
+}
+
+func TestNewSyncer_DefaultTransportHonorsProxyEnv(t *testing.T) {
+	syncer := NewSyncer(newTestStore(t), "http://engram.example", "key", "src")
+
+	transport, ok := syncer.client.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("Transport = %T, want *http.Transport", syncer.client.Transport)
+	}
+	if transport.Proxy == nil {
+		t.Fatal("default transport should resolve proxies from the environment")
+	}
+
+	// Everything else is http.DefaultTransport's
+	base := http.DefaultTransport.(*http.Transport)
+	if transport == base {
+		t.Fatal("default transport should be a clone, not http.DefaultTransport itself")
+	}
+	if transport.DialContext == nil || !transport.ForceAttemptHTTP2 ||
+		transport.MaxIdleConns != base.MaxIdleConns ||
+		transport.IdleConnTimeout != base.IdleConnTimeout ||
+		transport.TLSHandshakeTimeout != base.TLSHandshakeTimeout ||
+		transport.ExpectContinueTimeout != base.ExpectContinueTimeout {
+		t.Errorf("default transport dropped http.DefaultTransport's settings: %+v", transport)
+	}
+}
+
+func TestSyncer_SetTransport_UsedForRequests(t *testing.T) {
+	var injected string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		injected = r.Header.Get("X-Injected")
+		_, _ = w.Write([]byte(`{"status":"ok","embedding_model":"m"}`))
+	}))
+	defer server.Close()
+
+	rt := &countingTransport{next: http.DefaultTransport}
+	syncer := newTestSyncer(t, newTestStore(t), server.URL)
+	syncer.SetTransport(rt)
+
+	if _, err := syncer.Health(context.Background()); err != nil {
+		t.Fatalf("Health: %v", err)
+	}
+	if atomic.LoadInt32(&rt.calls) != 1 {
+		t.Errorf("transport calls = %d, want 1", rt.calls)
+	}
+	if injected != "yes" {
+		t.Errorf("X-Injected = %q, want %q", injected, "yes")
+	}
+}
+
+func TestSyncer_SetTransport_NilRestoresDefault(t *testing.T) {
+	syncer := newTestSyncer(t, newTestStore(t), "http://engram.example")
+	syncer.SetTransport(&countingTransport{next: http.DefaultTransport})
+	syncer.SetTransport(nil)
+
+	if _, ok := syncer.client.Transport.(*http.Transport); !ok {
+		t.Errorf("Transport = %T, want default *http.Transport", syncer.client.Transport)
+	}
+}
+
+func TestNew_HTTPTransportWired(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"status":"ok","embedding_model":"m"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	rt := &countingTransport{next: http.DefaultTransport}
+	client := newTestClient(t, Config{
+		EngramURL:     server.URL,
+		APIKey:        "key",
+		HTTPTransport: rt,
+	})
+
+	if status := client.HealthCheck(context.Background()); !status.EngramReachable {
+		t.Fatalf("EngramReachable = false: %s", status.Error)
+	}
+	if atomic.LoadInt32(&rt.calls) == 0 {
+		t.Error("custom HTTPTransport was not used")
+	}
+}
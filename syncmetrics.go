@@ -0,0 +1,197 @@
+package recall
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SyncMetrics counts sync events that can lose or shadow knowledge. Counters
+// are persisted in sync_meta and never reset, so they can be exported as
+// monotonic counters.
+type SyncMetrics struct {
+	// ConflictsDetected counts remote changes recorded for review instead of
+	// applied (ConflictPolicyReview).
+	ConflictsDetected int64 `json:"conflicts_detected"`
+
+	// ConflictsResolved counts resolved conflicts by resolution.
+	ConflictsResolved map[ConflictResolution]int64 `json:"conflicts_resolved"`
+
+	// LocalOverwrites counts remote upserts and deletes that replaced
+	// unpushed local edits (ConflictPolicyRemoteWins).
+	LocalOverwrites int64 `json:"local_overwrites"`
+
+	// PushesRejected counts push requests Engram refused with a validation
+	// error or schema mismatch. EntriesRejected counts the entries named in
+	// validation errors.
+	PushesRejected  int64 `json:"pushes_rejected"`
+	EntriesRejected int64 `json:"entries_rejected"`
+
+	// MaintenanceDetected counts Engram maintenance responses.
+	MaintenanceDetected int64 `json:"maintenance_detected"`
+
+	// CanaryChecks counts responses compared under Config.SyncCanary, and
+	// CanaryDiscrepancies those that differed from the trusted response.
+	CanaryChecks        int64 `json:"canary_checks,omitempty"`
+	CanaryDiscrepancies int64 `json:"canary_discrepancies,omitempty"`
+}
+
+// SyncEventType identifies a counted sync event.
+type SyncEventType string
+
+const (
+	SyncEventConflictDetected SyncEventType = "conflict_detected"
+	SyncEventConflictResolved SyncEventType = "conflict_resolved"
+	SyncEventLocalOverwrite   SyncEventType = "local_overwrite"
+	SyncEventPushRejected     SyncEventType = "push_rejected"
+
+	// SyncEventMaintenanceDetected reports an Engram maintenance window;
+	// auto-sync pauses until SyncEvent.Until.
+	SyncEventMaintenanceDetected SyncEventType = "maintenance_detected"
+
+	// SyncEventCanaryDiscrepancy reports a Config.SyncCanary response that
+	// differed from the trusted one; SyncEvent.Canary has the details.
+	SyncEventCanaryDiscrepancy SyncEventType = "canary_discrepancy"
+
+	// SyncEventAuditDrift reports a remote audit that found drift between
+	// the store and Engram; SyncEvent.Audit has the report. It is not
+	// counted in SyncMetrics.
+	SyncEventAuditDrift SyncEventType = "audit_drift"
+)
+
+// SyncEvent describes one event counted in SyncMetrics.
+type SyncEvent struct {
+	Type       SyncEventType
+	LoreID     string             // Affected entry; empty for SyncEventPushRejected
+	Policy     ConflictPolicy     // Policy in effect for detected conflicts and overwrites
+	Resolution ConflictResolution // SyncEventConflictResolved only
+	Reason     string             // SyncEventPushRejected: "validation" or "schema_mismatch"; SyncEventCanaryDiscrepancy: "push" or "delta"
+	Entries    int                // SyncEventPushRejected: entries named in the validation error
+	Until      time.Time          // SyncEventMaintenanceDetected: advertised end of the window
+	Canary     *CanaryDiscrepancy // SyncEventCanaryDiscrepancy only
+	Audit      *AuditReport       // SyncEventAuditDrift only
+}
+
+// SyncEventFunc is called for each counted sync event.
+type SyncEventFunc func(SyncEvent)
+
+// sync_meta keys for persisted sync metrics.
+const (
+	metricPrefix                  = "metric_"
+	metricConflictsDetected       = "metric_conflicts_detected"
+	metricConflictsResolvedPrefix = "metric_conflicts_resolved_"
+	metricLocalOverwrites         = "metric_local_overwrites"
+	metricPushesRejected          = "metric_pushes_rejected"
+	metricEntriesRejected         = "metric_entries_rejected"
+	metricMaintenanceDetected     = "metric_maintenance_detected"
+	metricCanaryChecks            = "metric_canary_checks"
+	metricCanaryDiscrepancies     = "metric_canary_discrepancies"
+)
+
+// incrementMetricTx adds delta to a sync_meta counter.
+func incrementMetricTx(tx *sql.Tx, key string, delta int64) error {
+	_, err := tx.Exec(`
+		INSERT INTO sync_meta (key, value) VALUES (?, ?)
+		ON CONFLICT(key) DO UPDATE SET value = CAST(CAST(value AS INTEGER) + ? AS TEXT)
+	`, key, strconv.FormatInt(delta, 10), delta)
+	if err != nil {
+		return fmt.Errorf("store: increment %s: %w", key, err)
+	}
+	return nil
+}
+
+// incrementMetrics adds to several counters in one transaction.
+func (s *Store) incrementMetrics(deltas map[string]int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return ErrStoreClosed
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("store: begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	for key, delta := range deltas {
+		if err := incrementMetricTx(tx, key, delta); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// SyncMetrics returns the persisted sync counters.
+func (s *Store) SyncMetrics() (*SyncMetrics, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil, ErrStoreClosed
+	}
+	return s.readSyncMetrics()
+}
+
+// readSyncMetrics reads the counters. Caller must hold s.mu.
+func (s *Store) readSyncMetrics() (*SyncMetrics, error) {
+	rows, err := s.db.Query(`SELECT key, value FROM sync_meta WHERE key LIKE ?`, metricPrefix+"%")
+	if err != nil {
+		return nil, fmt.Errorf("store: read sync metrics: %w", err)
+	}
+	defer rows.Close()
+
+	m := &SyncMetrics{ConflictsResolved: make(map[ConflictResolution]int64)}
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			return nil, fmt.Errorf("store: read sync metrics: %w", err)
+		}
+		n, _ := strconv.ParseInt(value, 10, 64)
+		switch {
+		case key == metricConflictsDetected:
+			m.ConflictsDetected = n
+		case key == metricLocalOverwrites:
+			m.LocalOverwrites = n
+		case key == metricPushesRejected:
+			m.PushesRejected = n
+		case key == metricEntriesRejected:
+			m.EntriesRejected = n
+		case key == metricMaintenanceDetected:
+			m.MaintenanceDetected = n
+		case key == metricCanaryChecks:
+			m.CanaryChecks = n
+		case key == metricCanaryDiscrepancies:
+			m.CanaryDiscrepancies = n
+		case strings.HasPrefix(key, metricConflictsResolvedPrefix):
+			m.ConflictsResolved[ConflictResolution(strings.TrimPrefix(key, metricConflictsResolvedPrefix))] = n
+		}
+	}
+	return m, rows.Err()
+}
+
+// SetSyncEventHandler sets the callback invoked for each counted sync event.
+func (s *Syncer) SetSyncEventHandler(fn SyncEventFunc) {
+	s.onSyncEvent = fn
+}
+
+func (s *Syncer) emitSyncEvent(ev SyncEvent) {
+	if s.onSyncEvent != nil {
+		s.onSyncEvent(ev)
+	}
+}
+
+// recordPushRejected counts a refused push. Counting is best-effort; a failed
+// write must not mask the push error.
+func (s *Syncer) recordPushRejected(reason string, entries int) {
+	if err := s.store.incrementMetrics(map[string]int64{
+		metricPushesRejected:  1,
+		metricEntriesRejected: int64(entries),
+	}); err != nil {
+		s.debug.LogError("record push rejection", err)
+	}
+	s.emitSyncEvent(SyncEvent{Type: SyncEventPushRejected, Reason: reason, Entries: entries})
+}
@@ -0,0 +1,99 @@
+package recall
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSyncMetrics_ReviewConflictDetectedAndResolved(t *testing.T) {
+	store, _ := setupConflict(t, ConflictPolicyReview)
+
+	m, err := store.SyncMetrics()
+	if err != nil {
+		t.Fatalf("SyncMetrics: %v", err)
+	}
+	if m.ConflictsDetected != 1 || m.LocalOverwrites != 0 {
+		t.Errorf("metrics = %+v, want 1 detected and no overwrites", m)
+	}
+
+	conflicts, _ := store.ListConflicts(false)
+	if _, err := store.ResolveConflict(conflicts[0].ID, &conflicts[0].Local, ConflictResolutionLocal); err != nil {
+		t.Fatalf("ResolveConflict: %v", err)
+	}
+
+	stats, err := store.Stats()
+	if err != nil {
+		t.Fatalf("Stats: %v", err)
+	}
+	if got := stats.SyncMetrics.ConflictsResolved[ConflictResolutionLocal]; got != 1 {
+		t.Errorf("ConflictsResolved[local] = %d, want 1", got)
+	}
+}
+
+func TestSyncMetrics_RemoteWinsCountsOverwrite(t *testing.T) {
+	store, _ := setupConflict(t, ConflictPolicyRemoteWins)
+
+	m, err := store.SyncMetrics()
+	if err != nil {
+		t.Fatalf("SyncMetrics: %v", err)
+	}
+	if m.LocalOverwrites != 1 || m.ConflictsDetected != 0 {
+		t.Errorf("metrics = %+v, want 1 overwrite and no detected conflicts", m)
+	}
+}
+
+func TestSyncMetrics_EventsForOverwriteAndRejectedPush(t *testing.T) {
+	store := newTestStore(t)
+	now := time.Now().UTC()
+	local := &Lore{ID: "lore-events-001", Content: "local", Category: CategoryPatternOutcome, Confidence: 0.5,
+		SourceID: store.SourceID(), CreatedAt: now, UpdatedAt: now}
+	if err := store.InsertLore(local); err != nil {
+		t.Fatalf("InsertLore: %v", err)
+	}
+
+	ts := now.Format(time.RFC3339)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_ = json.NewEncoder(w).Encode(SyncValidationError{Errors: []EntryError{
+				{Sequence: 1, TableName: "lore_entries", EntityID: local.ID, Code: "INVALID_PAYLOAD"},
+			}})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(SyncDeltaResponse{Entries: []DeltaEntry{{
+			Sequence: 3, TableName: "lore_entries", EntityID: local.ID, Operation: "delete",
+			SourceID: "remote-source", CreatedAt: ts, ReceivedAt: ts,
+		}}, LastSequence: 3, LatestSequence: 3})
+	}))
+	defer server.Close()
+
+	var events []SyncEvent
+	syncer := newTestSyncer(t, store, server.URL)
+	syncer.SetSyncEventHandler(func(ev SyncEvent) { events = append(events, ev) })
+
+	if _, err := syncer.SyncDelta(context.Background()); err != nil {
+		t.Fatalf("SyncDelta: %v", err)
+	}
+	if _, err := syncer.SyncPush(context.Background()); err == nil {
+		t.Fatal("SyncPush should fail on 422")
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("events = %+v, want overwrite and push rejection", events)
+	}
+	if events[0].Type != SyncEventLocalOverwrite || events[0].LoreID != local.ID {
+		t.Errorf("events[0] = %+v, want local_overwrite for %s", events[0], local.ID)
+	}
+	if events[1].Type != SyncEventPushRejected || events[1].Reason != "validation" || events[1].Entries != 1 {
+		t.Errorf("events[1] = %+v, want validation push rejection of 1 entry", events[1])
+	}
+
+	m, _ := store.SyncMetrics()
+	if m.PushesRejected != 1 || m.EntriesRejected != 1 || m.LocalOverwrites != 1 {
+		t.Errorf("metrics = %+v", m)
+	}
+}
@@ -0,0 +1,121 @@
+package recall
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// SyncReport is the outcome of SyncNow. A failed push does not stop the
+// pull, so either half may have succeeded when the other failed.
+type SyncReport struct {
+	Push      *PushResult   `json:"push,omitempty"` // nil if the push failed
+	Pull      *DeltaResult  `json:"pull,omitempty"` // nil if the pull failed
+	PushError error         `json:"-"`
+	PullError error         `json:"-"`
+	Parallel  bool          `json:"parallel"` // The first delta page was fetched during the push
+	PushTime  time.Duration `json:"push_time"`
+	PullTime  time.Duration `json:"pull_time"`
+}
+
+// SyncNow pushes local changes and then pulls remote ones.
+//
+// Process:
+//  1. With ParallelSync, start fetching the first delta page
+//  2. Push every pending change_log entry
+//  3. Wait for the prefetched page, then prepare and apply the delta
+//
+// The delta is always applied after the push ends, so conflict checks see
+// which local edits reached Engram. The prefetched page reads from the
+// pull position, which the push does not move; entries pushed meanwhile
+// come from our own source and are skipped by the next pull anyway.
+func (s *Syncer) SyncNow(ctx context.Context, parallel bool) *SyncReport {
+	report := &SyncReport{Parallel: parallel}
+
+	type page struct {
+		resp *SyncDeltaResponse
+		err  error
+	}
+	var prefetched chan page
+	if parallel {
+		prefetched = make(chan page, 1)
+		go func() {
+			after, cursor, err := s.deltaPosition()
+			if err != nil {
+				prefetched <- page{err: err}
+				return
+			}
+			resp, err := s.fetchDeltaPage(ctx, after, cursor)
+			prefetched <- page{resp: resp, err: err}
+		}()
+	}
+
+	start := time.Now()
+	report.Push, report.PushError = s.SyncPush(ctx)
+	if report.PushError != nil {
+		report.Push = nil
+	}
+	report.PushTime = time.Since(start)
+
+	start = time.Now()
+	var first *SyncDeltaResponse
+	if prefetched != nil {
+		p := <-prefetched
+		if p.err != nil {
+			report.PullError = fmt.Errorf("sync delta: %w", p.err)
+		}
+		first = p.resp
+	}
+	if report.PullError == nil {
+		report.Pull, report.PullError = s.syncDelta(ctx, first)
+	}
+	report.PullTime = time.Since(start)
+	return report
+}
+
+// err combines the push and pull errors, or returns nil if both succeeded.
+func (r *SyncReport) err() error {
+	var errs []error
+	if r.PushError != nil {
+		errs = append(errs, fmt.Errorf("push: %w", r.PushError))
+	}
+	if r.PullError != nil {
+		errs = append(errs, fmt.Errorf("pull: %w", r.PullError))
+	}
+	return errors.Join(errs...)
+}
+
+// SyncNow runs one full sync with Engram in the right order: feedback
+// queued under Config.ConfirmFeedback is reconciled, local changes are
+// pushed, then remote changes are pulled, so a pull never overwrites
+// edits that a push would have delivered. With Config.ParallelSync the
+// first delta page is fetched while the push is in flight.
+//
+// The report covers both halves even when one fails; the returned error
+// joins the push and pull errors. Returns ErrOffline if Engram is not
+// configured.
+func (c *Client) SyncNow(ctx context.Context) (*SyncReport, error) {
+	if c.syncer == nil {
+		return nil, ErrOffline
+	}
+	release, err := c.store.enter(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	if c.config.ConfirmFeedback {
+		if _, err := c.reconcileFeedback(ctx); err != nil {
+			return nil, fmt.Errorf("client: reconcile feedback: %w", err)
+		}
+	}
+	report := c.syncer.SyncNow(ctx, c.config.ParallelSync)
+	if report.PushError == nil {
+		c.replayAfterPush(ctx)
+	}
+	c.checkQuota(true)
+	err = report.err()
+	c.telemetry.countSync(err)
+	return report, err
+}
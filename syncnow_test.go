@@ -0,0 +1,143 @@
+package recall
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newSyncNowServer returns an Engram stub whose delta holds a remote edit
+// of id. With overlap set, the push is only answered once the delta has
+// been requested, so a sequential SyncNow would time out. The requests
+// are logged in the order they completed.
+func newSyncNowServer(t *testing.T, id string, overlap bool) (*httptest.Server, func() []string) {
+	t.Helper()
+	var mu sync.Mutex
+	var log []string
+	record := func(s string) {
+		mu.Lock()
+		defer mu.Unlock()
+		log = append(log, s)
+	}
+	deltaRequested := make(chan struct{})
+	var once sync.Once
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/stores/test-store/sync/push":
+			var req SyncPushRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			if overlap {
+				select {
+				case <-deltaRequested:
+				case <-time.After(5 * time.Second):
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+			}
+			record("push")
+			_ = json.NewEncoder(w).Encode(SyncPushResponse{Accepted: len(req.Entries), RemoteSequence: 10})
+		case "/api/v1/stores/test-store/sync/delta":
+			once.Do(func() { close(deltaRequested) })
+			ts := time.Now().UTC().Format(time.RFC3339)
+			entries := []DeltaEntry{{
+				Sequence: 11, TableName: "lore_entries", EntityID: id, Operation: "upsert",
+				Payload:  makeDeltaPayload(id, "remote content", "PATTERN_OUTCOME", "peer", ts, ts),
+				SourceID: "peer", CreatedAt: ts, ReceivedAt: ts,
+			}}
+			_ = json.NewEncoder(w).Encode(SyncDeltaResponse{Entries: entries, LastSequence: 11, LatestSequence: 11})
+			record("delta")
+		default:
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), log...)
+	}
+}
+
+func TestClient_SyncNow(t *testing.T) {
+	for _, parallel := range []bool{false, true} {
+		const id = "01SYNCNOW0000000000000000"
+		server, requests := newSyncNowServer(t, id, parallel)
+		var events []SyncEvent
+		client := newTestClient(t, Config{
+			Store:        "test-store",
+			EngramURL:    server.URL,
+			APIKey:       "key",
+			ParallelSync: parallel,
+			OnSyncEvent:  func(e SyncEvent) { events = append(events, e) },
+		})
+
+		now := time.Now().UTC()
+		if err := client.store.InsertLore(&Lore{
+			ID: id, Content: "local content", Category: CategoryPatternOutcome,
+			Confidence: 0.5, SourceID: client.store.SourceID(), CreatedAt: now, UpdatedAt: now,
+		}); err != nil {
+			t.Fatalf("InsertLore() error = %v", err)
+		}
+
+		report, err := client.SyncNow(context.Background())
+		if err != nil {
+			t.Fatalf("parallel=%v: SyncNow() error = %v", parallel, err)
+		}
+		if report.Push == nil || report.Push.EntriesPushed != 1 || report.Pull == nil || report.Pull.EntriesApplied != 1 || report.Parallel != parallel {
+			t.Errorf("parallel=%v: SyncNow() = %+v", parallel, report)
+		}
+		want := []string{"push", "delta"}
+		if parallel {
+			want = []string{"delta", "push"} // the delta was answered while the push waited
+		}
+		if got := requests(); len(got) != 2 || got[0] != want[0] || got[1] != want[1] {
+			t.Errorf("parallel=%v: requests = %v, want %v", parallel, got, want)
+		}
+
+		// The local edit was pushed before the delta was applied, so the
+		// remote edit is not an overwrite of unpushed work.
+		if len(events) != 0 {
+			t.Errorf("parallel=%v: sync events = %+v, want none", parallel, events)
+		}
+		got, err := client.store.Get(id)
+		if err != nil || got.Content != "remote content" {
+			t.Errorf("parallel=%v: Get() = %+v, %v; want the remote edit applied", parallel, got, err)
+		}
+	}
+}
+
+func TestClient_SyncNow_PullsAfterFailedPush(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/stores/test-store/sync/push" {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte(`{"accepted": 0, "errors": [{"code": "invalid", "message": "bad entry"}]}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(SyncDeltaResponse{LastSequence: 3, LatestSequence: 3})
+	}))
+	t.Cleanup(server.Close)
+
+	client := newTestClient(t, Config{Store: "test-store", EngramURL: server.URL, APIKey: "key"})
+	if _, err := client.Record("Local content", CategoryPatternOutcome); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+
+	report, err := client.SyncNow(context.Background())
+	if err == nil || report.PushError == nil || report.Push != nil {
+		t.Fatalf("SyncNow() = %+v, %v; want the push error", report, err)
+	}
+	if report.PullError != nil || report.Pull == nil || report.Pull.LastSequence != 3 {
+		t.Errorf("SyncNow() pull = %+v, %v; want it to run after the failed push", report.Pull, report.PullError)
+	}
+
+	offline := newTestClient(t, Config{})
+	if _, err := offline.SyncNow(context.Background()); !errors.Is(err, ErrOffline) {
+		t.Errorf("SyncNow() offline error = %v, want ErrOffline", err)
+	}
+}
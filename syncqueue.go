@@ -0,0 +1,180 @@
+package recall
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// syncQueueConvertedKey is the sync_meta key recording when the legacy
+// sync_queue was converted to change_log entries.
+const syncQueueConvertedKey = "sync_queue_converted"
+
+// SyncQueueConversion reports the result of ConvertSyncQueue.
+type SyncQueueConversion struct {
+	// Converted counts sync_queue rows replaced by a change_log entry.
+	Converted int `json:"converted"`
+	// Dropped counts rows with nothing left to push: the lore is gone or
+	// already has a change_log entry.
+	Dropped int `json:"dropped"`
+	// Kept counts FEEDBACK rows left for ReconcileFeedback.
+	Kept int `json:"kept"`
+	// ConvertedAt is when the store was first converted.
+	ConvertedAt time.Time `json:"converted_at"`
+}
+
+// ConvertSyncQueue translates the rows remaining in the legacy sync_queue
+// into change_log upserts carrying each entry's current state, which is
+// what the push protocol sends. Rows whose lore is deleted, or already has
+// a change_log entry, are dropped. FEEDBACK rows are kept when
+// keepFeedback is set: with Config.ConfirmFeedback they are tentative
+// feedback awaiting ReconcileFeedback, not legacy changes.
+//
+// Once converted, the deprecated Record and FeedbackBatch paths write
+// change_log entries instead of queueing, and HasPendingSync stops
+// counting the legacy queue. Converting again is safe.
+func (s *Store) ConvertSyncQueue(keepFeedback bool) (*SyncQueueConversion, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil, ErrStoreClosed
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("store: begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	rows, err := tx.Query(`SELECT id, lore_id, operation FROM sync_queue ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("store: read sync queue: %w", err)
+	}
+	type queued struct {
+		id        int64
+		loreID    string
+		operation string
+	}
+	var entries []queued
+	for rows.Next() {
+		var q queued
+		if err := rows.Scan(&q.id, &q.loreID, &q.operation); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("store: scan sync queue: %w", err)
+		}
+		entries = append(entries, q)
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("store: read sync queue: %w", err)
+	}
+
+	result := &SyncQueueConversion{}
+	logged := make(map[string]bool)
+	for _, q := range entries {
+		if q.operation == "FEEDBACK" && keepFeedback {
+			result.Kept++
+			continue
+		}
+		converted, err := s.convertQueuedTx(tx, q.loreID, q.operation, logged)
+		if err != nil {
+			return nil, err
+		}
+		if converted {
+			result.Converted++
+		} else {
+			result.Dropped++
+		}
+		if _, err := tx.Exec(`DELETE FROM sync_queue WHERE id = ?`, q.id); err != nil {
+			return nil, fmt.Errorf("store: delete sync queue entry: %w", err)
+		}
+	}
+
+	var convertedAt string
+	err = tx.QueryRow(`SELECT value FROM sync_meta WHERE key = ?`, syncQueueConvertedKey).Scan(&convertedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		convertedAt = s.now().Format(time.RFC3339)
+		if _, err := tx.Exec(`INSERT INTO sync_meta (key, value) VALUES (?, ?)`, syncQueueConvertedKey, convertedAt); err != nil {
+			return nil, fmt.Errorf("store: mark sync queue converted: %w", err)
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("store: read sync meta: %w", err)
+	}
+	result.ConvertedAt, _ = time.Parse(time.RFC3339, convertedAt)
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("store: commit: %w", err)
+	}
+	return result, nil
+}
+
+// convertQueuedTx writes a change_log upsert for a sync_queue row and
+// reports whether one was needed. An INSERT is already covered when the
+// lore has any change_log entry; FEEDBACK is covered once this conversion
+// has logged the entry's current state. logged tracks entries written.
+func (s *Store) convertQueuedTx(tx *sql.Tx, loreID, operation string, logged map[string]bool) (bool, error) {
+	if logged[loreID] {
+		return false, nil
+	}
+	if operation == "INSERT" {
+		var exists bool
+		err := tx.QueryRow(`SELECT EXISTS(SELECT 1 FROM change_log WHERE table_name = 'lore_entries' AND entity_id = ?)`, loreID).Scan(&exists)
+		if err != nil {
+			return false, fmt.Errorf("store: check change_log: %w", err)
+		}
+		if exists {
+			return false, nil
+		}
+	}
+
+	// sync_queue is shared by every namespace in the file, so the entry is
+	// read without the store's namespace filter.
+	lore, err := s.scanLore(tx.QueryRow(`
+		SELECT id, content, context, category, confidence, embedding, embedding_status, source_id, sources,
+		       validation_count, last_validated_at, created_at, updated_at, deleted_at, synced_at, namespace
+		FROM lore_entries WHERE id = ? AND deleted_at IS NULL
+	`, loreID))
+	if errors.Is(err, ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	payload, err := lorePayloadJSON(lore)
+	if err != nil {
+		return false, fmt.Errorf("store: marshal change_log payload: %w", err)
+	}
+	if err := s.appendChangeLog(tx, "lore_entries", loreID, "upsert", payload, s.sourceID); err != nil {
+		return false, err
+	}
+	logged[loreID] = true
+	return true, nil
+}
+
+// syncQueueConverted reports whether ConvertSyncQueue has run. The caller
+// holds s.mu.
+func (s *Store) syncQueueConverted() bool {
+	var value string
+	err := s.db.QueryRow(`SELECT value FROM sync_meta WHERE key = ?`, syncQueueConvertedKey).Scan(&value)
+	return err == nil && value != ""
+}
+
+// MigrateSyncQueue converts the legacy sync_queue into change_log entries
+// (see Store.ConvertSyncQueue). With Config.ConfirmFeedback, queued
+// feedback stays for ReconcileFeedback.
+func (c *Client) MigrateSyncQueue(ctx context.Context) (*SyncQueueConversion, error) {
+	release, err := c.store.enter(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	result, err := c.store.ConvertSyncQueue(c.config.ConfirmFeedback)
+	if err != nil {
+		return nil, fmt.Errorf("client: migrate sync queue: %w", err)
+	}
+	return result, nil
+}
@@ -0,0 +1,96 @@
+package recall
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStore_ConvertSyncQueue(t *testing.T) {
+	store := newTestStore(t)
+
+	legacy, err := store.Record(Lore{Content: "legacy insert", Category: CategoryPatternOutcome})
+	if err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+	gone, err := store.Record(Lore{Content: "deleted before conversion", Category: CategoryPatternOutcome})
+	if err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+	if err := store.SoftDeleteLoreAt(gone.ID, time.Now().UTC().Format(time.RFC3339)); err != nil {
+		t.Fatalf("SoftDeleteLoreAt: %v", err)
+	}
+	if err := store.queueSync(legacy.ID, "FEEDBACK", []byte(`{"outcome":"helpful"}`)); err != nil {
+		t.Fatalf("queueSync: %v", err)
+	}
+
+	result, err := store.ConvertSyncQueue(false)
+	if err != nil {
+		t.Fatalf("ConvertSyncQueue: %v", err)
+	}
+	// The FEEDBACK row is covered by the upsert written for the INSERT.
+	if result.Converted != 1 || result.Dropped != 2 || result.Kept != 0 || result.ConvertedAt.IsZero() {
+		t.Errorf("result = %+v, want 1 converted, 2 dropped", result)
+	}
+
+	queued, err := store.PendingSyncEntries()
+	if err != nil {
+		t.Fatalf("PendingSyncEntries: %v", err)
+	}
+	if len(queued) != 0 {
+		t.Errorf("sync_queue has %d rows after conversion", len(queued))
+	}
+	changes, err := store.UnpushedChanges(store.SourceID(), 0, 100)
+	if err != nil {
+		t.Fatalf("UnpushedChanges: %v", err)
+	}
+	if len(changes) != 1 || changes[0].EntityID != legacy.ID || changes[0].Operation != "upsert" {
+		t.Errorf("change_log = %+v, want one upsert for %s", changes, legacy.ID)
+	}
+
+	// Legacy writes now go straight to change_log.
+	if _, err := store.Record(Lore{Content: "after conversion", Category: CategoryPatternOutcome}); err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+	queued, _ = store.PendingSyncEntries()
+	pending, err := store.HasPendingSync()
+	if err != nil {
+		t.Fatalf("HasPendingSync: %v", err)
+	}
+	if len(queued) != 0 || pending != 2 {
+		t.Errorf("after conversion: %d queued, HasPendingSync = %d, want 0 and 2", len(queued), pending)
+	}
+
+	again, err := store.ConvertSyncQueue(false)
+	if err != nil {
+		t.Fatalf("ConvertSyncQueue again: %v", err)
+	}
+	if again.Converted != 0 || !again.ConvertedAt.Equal(result.ConvertedAt) {
+		t.Errorf("second conversion = %+v, want no-op keeping %v", again, result.ConvertedAt)
+	}
+}
+
+func TestStore_ConvertSyncQueue_KeepFeedback(t *testing.T) {
+	store := newTestStore(t)
+	lore := &Lore{ID: "kept", Content: "a", Category: CategoryPatternOutcome, Confidence: 0.5, CreatedAt: time.Now().UTC(), UpdatedAt: time.Now().UTC()}
+	if err := store.InsertLore(lore); err != nil {
+		t.Fatalf("InsertLore: %v", err)
+	}
+	if _, err := store.applyTentativeFeedback("kept", Helpful); err != nil {
+		t.Fatalf("applyTentativeFeedback: %v", err)
+	}
+
+	result, err := store.ConvertSyncQueue(true)
+	if err != nil {
+		t.Fatalf("ConvertSyncQueue: %v", err)
+	}
+	if result.Kept != 1 || result.Converted != 0 {
+		t.Errorf("result = %+v, want 1 kept", result)
+	}
+	pending, err := store.HasPendingSync()
+	if err != nil {
+		t.Fatalf("HasPendingSync: %v", err)
+	}
+	if pending != 2 { // InsertLore's change_log entry and the kept feedback
+		t.Errorf("HasPendingSync = %d, want 2", pending)
+	}
+}
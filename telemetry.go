@@ -0,0 +1,155 @@
+package recall
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// TelemetrySchemaVersion is the version of the TelemetryReport schema.
+// It changes whenever a field is added or removed.
+const TelemetrySchemaVersion = 1
+
+// DefaultTelemetryInterval is how often reports are sent when
+// Config.TelemetryInterval is unset.
+const DefaultTelemetryInterval = 24 * time.Hour
+
+// TelemetryReport is an aggregate usage summary for one reporting period.
+// The schema is fixed: it holds counts only, never lore content, IDs,
+// queries, store names, hostnames or source IDs.
+type TelemetryReport struct {
+	SchemaVersion int       `json:"schema_version"`
+	PeriodStart   time.Time `json:"period_start"`
+	PeriodEnd     time.Time `json:"period_end"`
+	Offline       bool      `json:"offline"` // Whether Engram sync is configured
+
+	Queries      int64 `json:"queries"`  // Query and ContextPack calls
+	Records      int64 `json:"records"`  // Entries recorded
+	Feedback     int64 `json:"feedback"` // Feedback and FeedbackBatch calls
+	Syncs        int64 `json:"syncs"`    // Sync operations attempted
+	SyncFailures int64 `json:"sync_failures"`
+}
+
+// TelemetryReporter receives aggregate usage reports. Telemetry is off
+// unless Config.Telemetry is set.
+type TelemetryReporter interface {
+	Report(ctx context.Context, report TelemetryReport) error
+}
+
+// TelemetryReporterFunc adapts a function to TelemetryReporter.
+type TelemetryReporterFunc func(ctx context.Context, report TelemetryReport) error
+
+// Report calls f(ctx, report).
+func (f TelemetryReporterFunc) Report(ctx context.Context, report TelemetryReport) error {
+	return f(ctx, report)
+}
+
+// httpTelemetryReporter POSTs reports as JSON.
+type httpTelemetryReporter struct {
+	url    string
+	client *http.Client
+}
+
+// NewHTTPTelemetryReporter returns a reporter that POSTs each report as JSON
+// to url.
+func NewHTTPTelemetryReporter(url string) TelemetryReporter {
+	return &httpTelemetryReporter{url: url, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+func (r *httpTelemetryReporter) Report(ctx context.Context, report TelemetryReport) error {
+	body, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("telemetry: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("telemetry: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("telemetry: %w", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("telemetry: unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// telemetryCounters accumulates usage counts between reports.
+type telemetryCounters struct {
+	queries      atomic.Int64
+	records      atomic.Int64
+	feedback     atomic.Int64
+	syncs        atomic.Int64
+	syncFailures atomic.Int64
+}
+
+// countSync records a sync attempt and its outcome.
+func (t *telemetryCounters) countSync(err error) {
+	t.syncs.Add(1)
+	if err != nil {
+		t.syncFailures.Add(1)
+	}
+}
+
+// take returns the counts since the last call and resets them.
+func (t *telemetryCounters) take() TelemetryReport {
+	return TelemetryReport{
+		SchemaVersion: TelemetrySchemaVersion,
+		Queries:       t.queries.Swap(0),
+		Records:       t.records.Swap(0),
+		Feedback:      t.feedback.Swap(0),
+		Syncs:         t.syncs.Swap(0),
+		SyncFailures:  t.syncFailures.Swap(0),
+	}
+}
+
+// reportTelemetry sends the counts accumulated since start. Periods with no
+// activity are not reported. Reporter errors are logged and dropped.
+func (c *Client) reportTelemetry(ctx context.Context, start time.Time) {
+	report := c.telemetry.take()
+	if report.Queries+report.Records+report.Feedback+report.Syncs == 0 {
+		return
+	}
+	report.PeriodStart = start.UTC()
+	report.PeriodEnd = time.Now().UTC()
+	report.Offline = c.config.IsOffline()
+	if err := c.config.Telemetry.Report(ctx, report); err != nil {
+		c.debug.LogError("telemetry report", err)
+	}
+}
+
+// telemetryLoop reports usage every TelemetryInterval, and once more when
+// the client closes.
+func (c *Client) telemetryLoop() {
+	defer close(c.telemetryDone)
+
+	interval := c.config.TelemetryInterval
+	if interval <= 0 {
+		interval = DefaultTelemetryInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	start := time.Now()
+	for {
+		select {
+		case <-c.stopSync:
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			c.reportTelemetry(ctx, start)
+			cancel()
+			return
+		case now := <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			c.reportTelemetry(ctx, start)
+			cancel()
+			start = now
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package recall
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestClient_Telemetry_ReportsCountsOnClose(t *testing.T) {
+	var reports []TelemetryReport
+	client, err := New(Config{
+		LocalPath: filepath.Join(t.TempDir(), "lore.db"),
+		Telemetry: TelemetryReporterFunc(func(_ context.Context, r TelemetryReport) error {
+			reports = append(reports, r)
+			return nil
+		}),
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	lore, err := client.Record("secret architectural detail", CategoryArchitecturalDecision)
+	if err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+	if _, err := client.Query(context.Background(), QueryParams{Query: "secret"}); err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	if _, err := client.Feedback(lore.ID, Helpful); err != nil {
+		t.Fatalf("Feedback: %v", err)
+	}
+	if err := client.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	if len(reports) != 1 {
+		t.Fatalf("got %d reports, want 1", len(reports))
+	}
+	r := reports[0]
+	if r.SchemaVersion != TelemetrySchemaVersion || r.Records != 1 || r.Queries != 1 || r.Feedback != 1 || !r.Offline {
+		t.Errorf("report = %+v", r)
+	}
+	data, _ := json.Marshal(r)
+	if strings.Contains(string(data), "secret") || strings.Contains(string(data), lore.ID) {
+		t.Errorf("report leaks content: %s", data)
+	}
+}
+
+func TestClient_Telemetry_SkipsIdlePeriods(t *testing.T) {
+	called := false
+	client, err := New(Config{
+		LocalPath: filepath.Join(t.TempDir(), "lore.db"),
+		Telemetry: TelemetryReporterFunc(func(context.Context, TelemetryReport) error {
+			called = true
+			return nil
+		}),
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	_ = client.Close()
+	if called {
+		t.Error("idle client should not report")
+	}
+}
+
+func TestHTTPTelemetryReporter(t *testing.T) {
+	var got TelemetryReport
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("method = %s, want POST", r.Method)
+		}
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	reporter := NewHTTPTelemetryReporter(srv.URL)
+	if err := reporter.Report(context.Background(), TelemetryReport{SchemaVersion: 1, Syncs: 3, SyncFailures: 1}); err != nil {
+		t.Fatalf("Report: %v", err)
+	}
+	if got.Syncs != 3 || got.SyncFailures != 1 {
+		t.Errorf("server received %+v", got)
+	}
+}
@@ -0,0 +1,233 @@
+package recall
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+)
+
+// Model families with built-in token estimators.
+const (
+	ModelFamilyClaude = "claude"
+	ModelFamilyGPT    = "gpt"
+	ModelFamilyLlama  = "llama"
+)
+
+// TokenEstimator counts the tokens a model family's tokenizer produces for
+// text. Counts are stored per estimator Name, so changing estimators
+// recomputes them rather than mixing families.
+type TokenEstimator interface {
+	Name() string
+	EstimateTokens(text string) int
+}
+
+// DefaultTokenEstimator is used when Config.TokenEstimator is nil.
+var DefaultTokenEstimator TokenEstimator = TokenEstimatorFor("")
+
+// TokenEstimatorFor returns the built-in estimator for a model family:
+// ModelFamilyClaude, ModelFamilyGPT or ModelFamilyLlama. Any other family
+// gets a generic estimator named "default". Built-in estimators are
+// heuristics tuned to each family's average word-piece length; plug in an
+// exact tokenizer by implementing TokenEstimator.
+func TokenEstimatorFor(family string) TokenEstimator {
+	switch strings.ToLower(family) {
+	case ModelFamilyClaude:
+		return heuristicEstimator{name: ModelFamilyClaude, charsPerToken: 3.5}
+	case ModelFamilyGPT:
+		return heuristicEstimator{name: ModelFamilyGPT, charsPerToken: 4}
+	case ModelFamilyLlama:
+		return heuristicEstimator{name: ModelFamilyLlama, charsPerToken: 3.8}
+	default:
+		return heuristicEstimator{name: "default", charsPerToken: 4}
+	}
+}
+
+// heuristicEstimator splits text the way BPE tokenizers roughly do: runs
+// of Latin letters become ceil(len/charsPerToken) tokens, digits group in
+// threes, and every other symbol, and every rune of scripts without spaces
+// between words, is a token of its own.
+type heuristicEstimator struct {
+	name          string
+	charsPerToken float64
+}
+
+func (e heuristicEstimator) Name() string { return e.name }
+
+func (e heuristicEstimator) EstimateTokens(text string) int {
+	tokens := 0
+	letters, digits := 0, 0
+	flush := func() {
+		if letters > 0 {
+			tokens += int(math.Ceil(float64(letters) / e.charsPerToken))
+		}
+		tokens += (digits + 2) / 3
+		letters, digits = 0, 0
+	}
+	for _, r := range text {
+		switch {
+		case r < unicode.MaxLatin1 && unicode.IsLetter(r):
+			if digits > 0 {
+				flush()
+			}
+			letters++
+		case unicode.IsDigit(r):
+			if letters > 0 {
+				flush()
+			}
+			digits++
+		case unicode.IsSpace(r):
+			flush()
+		default:
+			flush()
+			tokens++
+		}
+	}
+	flush()
+	return tokens
+}
+
+// tokenEstimator returns Config.TokenEstimator or DefaultTokenEstimator.
+func (c *Client) tokenEstimator() TokenEstimator {
+	if c.config.TokenEstimator != nil {
+		return c.config.TokenEstimator
+	}
+	return DefaultTokenEstimator
+}
+
+// SetTokenCounts stores token counts for lore entries under estimator,
+// tied to each entry's current content fingerprint. Counts are local-only.
+func (s *Store) SetTokenCounts(estimator string, counts map[string]int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return ErrStoreClosed
+	}
+	if len(counts) == 0 {
+		return nil
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("store: begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	for id, tokens := range counts {
+		_, err := tx.Exec(`
+			INSERT INTO lore_tokens (lore_id, estimator, fingerprint, tokens)
+			SELECT id, ?, fingerprint, ? FROM lore_entries WHERE id = ?
+			ON CONFLICT(lore_id, estimator) DO UPDATE SET fingerprint = excluded.fingerprint, tokens = excluded.tokens
+		`, estimator, tokens, id)
+		if err != nil {
+			return fmt.Errorf("store: set token count: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("store: commit: %w", err)
+	}
+	return nil
+}
+
+// TokenCounts returns the stored token counts under estimator for the
+// given lore IDs. IDs without a count, or whose content changed since it
+// was stored, are absent from the map.
+func (s *Store) TokenCounts(estimator string, loreIDs []string) (map[string]int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil, ErrStoreClosed
+	}
+
+	counts := make(map[string]int)
+	if len(loreIDs) == 0 {
+		return counts, nil
+	}
+
+	placeholders := make([]string, len(loreIDs))
+	args := make([]any, 0, len(loreIDs)+1)
+	args = append(args, estimator)
+	for i, id := range loreIDs {
+		placeholders[i] = "?"
+		args = append(args, id)
+	}
+
+	rows, err := s.db.Query(fmt.Sprintf(`
+		SELECT t.lore_id, t.tokens FROM lore_tokens t
+		JOIN lore_entries l ON l.id = t.lore_id AND l.fingerprint = t.fingerprint
+		WHERE t.estimator = ? AND t.lore_id IN (%s)
+	`, strings.Join(placeholders, ",")), args...)
+	if err != nil {
+		return nil, fmt.Errorf("store: query token counts: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var id string
+		var tokens int
+		if err := rows.Scan(&id, &tokens); err != nil {
+			return nil, fmt.Errorf("store: scan token count: %w", err)
+		}
+		counts[id] = tokens
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("store: iterate token counts: %w", err)
+	}
+	return counts, nil
+}
+
+// storeTokenCounts estimates and stores the token counts of newly recorded
+// lore. Counts are best-effort; tokenCounts recomputes missing ones.
+func (c *Client) storeTokenCounts(lore ...*Lore) {
+	estimator := c.tokenEstimator()
+	counts := make(map[string]int, len(lore))
+	for _, l := range lore {
+		counts[l.ID] = estimator.EstimateTokens(l.Content)
+	}
+	if err := c.store.SetTokenCounts(estimator.Name(), counts); err != nil {
+		c.debug.LogError("store token counts", err)
+	}
+}
+
+// tokenCounts returns the token count of each entry in lore and their sum.
+// Stored counts are used where present; the rest, such as lore that
+// arrived by sync, are estimated and stored. Entries reassembled from
+// chunks (listed in chunks) are estimated from their whole content.
+func (c *Client) tokenCounts(lore []Lore, chunks map[string][]string) (map[string]int, int) {
+	if len(lore) == 0 {
+		return nil, 0
+	}
+	estimator := c.tokenEstimator()
+
+	ids := make([]string, 0, len(lore))
+	for _, l := range lore {
+		if _, ok := chunks[l.ID]; !ok {
+			ids = append(ids, l.ID)
+		}
+	}
+	counts, err := c.store.TokenCounts(estimator.Name(), ids)
+	if err != nil {
+		c.debug.LogError("read token counts", err)
+		counts = make(map[string]int)
+	}
+
+	missing := make(map[string]int)
+	total := 0
+	for _, l := range lore {
+		tokens, ok := counts[l.ID]
+		if !ok {
+			tokens = estimator.EstimateTokens(l.Content)
+			counts[l.ID] = tokens
+			if _, chunked := chunks[l.ID]; !chunked {
+				missing[l.ID] = tokens
+			}
+		}
+		total += tokens
+	}
+	if err := c.store.SetTokenCounts(estimator.Name(), missing); err != nil {
+		c.debug.LogError("store token counts", err)
+	}
+	return counts, total
+}
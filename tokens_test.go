@@ -0,0 +1,110 @@
+package recall
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestTokenEstimatorFor(t *testing.T) {
+	tests := []struct {
+		family string
+		text   string
+		want   int
+	}{
+		{ModelFamilyGPT, "", 0},
+		{ModelFamilyGPT, "retry", 2},          // 5 letters / 4
+		{ModelFamilyGPT, "use pgx v5", 4},     // use, pgx, v, 5
+		{ModelFamilyGPT, "2026-03-01", 6},     // 20|26, -, 03, -, 01
+		{ModelFamilyClaude, "idempotency", 4}, // 11 letters / 3.5
+		{ModelFamilyGPT, "idempotency", 3},    // 11 letters / 4
+		{ModelFamilyGPT, "数据库", 3},            // one per rune
+		{"unknown", "a, b.", 4},
+	}
+	for _, tt := range tests {
+		if got := TokenEstimatorFor(tt.family).EstimateTokens(tt.text); got != tt.want {
+			t.Errorf("%s.EstimateTokens(%q) = %d, want %d", tt.family, tt.text, got, tt.want)
+		}
+	}
+	if name := TokenEstimatorFor("CLAUDE").Name(); name != ModelFamilyClaude {
+		t.Errorf("Name = %q, want %q", name, ModelFamilyClaude)
+	}
+	if name := TokenEstimatorFor("mystery").Name(); name != "default" {
+		t.Errorf("Name = %q, want default", name)
+	}
+}
+
+// countingEstimator counts one token per word and records its calls.
+type countingEstimator struct{ calls int }
+
+func (e *countingEstimator) Name() string { return "words" }
+func (e *countingEstimator) EstimateTokens(text string) int {
+	e.calls++
+	return len(strings.Fields(text))
+}
+
+func TestClient_TokenCounts(t *testing.T) {
+	estimator := &countingEstimator{}
+	client := newTestClient(t, Config{AutoSync: false, TokenEstimator: estimator})
+
+	a, err := client.Record("one two three", CategoryPatternOutcome)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := client.Record("four five", CategoryPatternOutcome)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if estimator.calls != 2 {
+		t.Fatalf("estimator called %d times at record time, want 2", estimator.calls)
+	}
+
+	result, err := client.Query(context.Background(), QueryParams{K: 10})
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	if result.TokenCounts[a.ID] != 3 || result.TokenCounts[b.ID] != 2 || result.TotalTokens != 5 {
+		t.Errorf("TokenCounts = %v, TotalTokens = %d; want 3 + 2 = 5", result.TokenCounts, result.TotalTokens)
+	}
+	if estimator.calls != 2 {
+		t.Errorf("estimator called %d times, want stored counts reused", estimator.calls)
+	}
+
+	// Changed content is re-estimated.
+	if _, err := client.store.db.Exec(`UPDATE lore_entries SET content = ?, fingerprint = ? WHERE id = ?`,
+		"four five six seven", Fingerprint("four five six seven"), b.ID); err != nil {
+		t.Fatal(err)
+	}
+	result, err = client.Query(context.Background(), QueryParams{K: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.TokenCounts[b.ID] != 4 || result.TotalTokens != 7 {
+		t.Errorf("after edit: TokenCounts = %v, TotalTokens = %d", result.TokenCounts, result.TotalTokens)
+	}
+	if counts, _ := client.store.TokenCounts("words", []string{b.ID}); counts[b.ID] != 4 {
+		t.Errorf("stored count = %v, want the re-estimate", counts)
+	}
+}
+
+func TestClient_ContextPack_TokenBudget(t *testing.T) {
+	client := newTestClient(t, Config{AutoSync: false, TokenEstimator: &countingEstimator{}})
+
+	for _, content := range []string{"retry with jitter and backoff", "retry budgets"} {
+		if _, err := client.Record(content, CategoryPatternOutcome); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	pack, err := client.ContextPack(context.Background(), PackParams{Topics: []string{"retry"}, TokenBudget: 3})
+	if err != nil {
+		t.Fatalf("ContextPack: %v", err)
+	}
+	if len(pack.Sections[0].Entries) != 1 || pack.Tokens != 2 || !pack.Truncated {
+		t.Errorf("pack = %d entries, %d tokens, truncated %v; want 1 entry of 2 tokens", len(pack.Sections[0].Entries), pack.Tokens, pack.Truncated)
+	}
+
+	if _, err := client.ContextPack(context.Background(), PackParams{Topics: []string{"retry"}, TokenBudget: -1}); err == nil {
+		t.Error("negative TokenBudget accepted")
+	}
+}
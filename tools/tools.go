@@ -0,0 +1,260 @@
+// Package tools exposes Recall as LLM function-calling tools.
+//
+// Definitions returns OpenAI-style tool definitions (query_lore, record_lore,
+// give_feedback) whose JSON schemas match the Client API, and Dispatcher maps
+// the model's tool calls back onto Client methods:
+//
+//	resp := openai.CreateChatCompletion(..., Tools: tools.Definitions())
+//	for _, call := range resp.Choices[0].Message.ToolCalls {
+//		out, err := dispatcher.Dispatch(ctx, call.Function.Name, call.Function.Arguments)
+//		// send out (or err.Error()) back as the tool message
+//	}
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hyperengineering/recall"
+)
+
+// Tool names.
+const (
+	QueryLore    = "query_lore"
+	RecordLore   = "record_lore"
+	GiveFeedback = "give_feedback"
+)
+
+// ErrUnknownTool is returned by Dispatch for tool names it does not handle.
+var ErrUnknownTool = errors.New("tools: unknown tool")
+
+// Definition is a tool definition in the OpenAI chat completions format.
+type Definition struct {
+	Type     string   `json:"type"` // Always "function"
+	Function Function `json:"function"`
+}
+
+// Function describes a callable function and its JSON-schema parameters.
+type Function struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Parameters  map[string]any `json:"parameters"`
+}
+
+// Definitions returns the tool definitions for query_lore, record_lore and
+// give_feedback.
+func Definitions() []Definition {
+	categories := make([]string, 0, len(recall.ValidCategories()))
+	for _, c := range recall.ValidCategories() {
+		categories = append(categories, string(c))
+	}
+	stringArray := func(description string) map[string]any {
+		return map[string]any{"type": "array", "items": map[string]any{"type": "string"}, "description": description}
+	}
+
+	return []Definition{
+		{
+			Type: "function",
+			Function: Function{
+				Name:        QueryLore,
+				Description: "Retrieve lore (lessons learned in earlier work) relevant to the current task. Results carry session refs (L1, L2) for give_feedback.",
+				Parameters: objectSchema(map[string]any{
+					"query": map[string]any{"type": "string", "description": "What you are working on or want to know"},
+					"k":     map[string]any{"type": "integer", "minimum": 1, "description": "Maximum number of results (default 5)"},
+					"min_confidence": map[string]any{
+						"type": "number", "minimum": recall.ConfidenceMin, "maximum": recall.ConfidenceMax,
+						"description": "Minimum confidence (default 0.5)",
+					},
+					"categories": map[string]any{
+						"type": "array", "items": map[string]any{"type": "string", "enum": categories},
+						"description": "Only return these categories",
+					},
+				}, "query"),
+			},
+		},
+		{
+			Type: "function",
+			Function: Function{
+				Name:        RecordLore,
+				Description: "Record a lesson learned so future sessions can recall it.",
+				Parameters: objectSchema(map[string]any{
+					"content": map[string]any{
+						"type": "string", "maxLength": recall.MaxContentLength,
+						"description": "What was learned, stated so it is useful without this conversation",
+					},
+					"category": map[string]any{"type": "string", "enum": categories, "description": "Kind of lesson"},
+					"context": map[string]any{
+						"type": "string", "maxLength": recall.MaxContextLength,
+						"description": "Where it was learned (story, task, situation)",
+					},
+					"confidence": map[string]any{
+						"type": "number", "minimum": recall.ConfidenceMin, "maximum": recall.ConfidenceMax,
+						"description": "Initial confidence (default 0.5)",
+					},
+				}, "content", "category"),
+			},
+		},
+		{
+			Type: "function",
+			Function: Function{
+				Name:        GiveFeedback,
+				Description: "Report which recalled lore helped, did not apply, was wrong, or is out of date, using session refs from query_lore.",
+				Parameters: objectSchema(map[string]any{
+					"helpful":      stringArray("Refs of lore that helped"),
+					"not_relevant": stringArray("Refs of lore that did not apply"),
+					"incorrect":    stringArray("Refs of lore that was wrong or misleading"),
+					"outdated":     stringArray("Refs of lore that was right but no longer is"),
+				}),
+			},
+		},
+	}
+}
+
+func objectSchema(properties map[string]any, required ...string) map[string]any {
+	schema := map[string]any{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// Dispatcher routes tool calls to a Client.
+type Dispatcher struct {
+	client *recall.Client
+}
+
+// NewDispatcher creates a Dispatcher for client.
+func NewDispatcher(client *recall.Client) *Dispatcher {
+	return &Dispatcher{client: client}
+}
+
+// queryArgs are the arguments of query_lore.
+type queryArgs struct {
+	Query         string   `json:"query"`
+	K             int      `json:"k"`
+	MinConfidence *float64 `json:"min_confidence"`
+	Categories    []string `json:"categories"`
+}
+
+// recordArgs are the arguments of record_lore.
+type recordArgs struct {
+	Content    string   `json:"content"`
+	Category   string   `json:"category"`
+	Context    string   `json:"context"`
+	Confidence *float64 `json:"confidence"`
+}
+
+// queryResult is the compact query_lore response sent back to the model.
+type queryResult struct {
+	Lore []queryLoreItem `json:"lore"`
+}
+
+type queryLoreItem struct {
+	Ref        string  `json:"ref"`
+	Content    string  `json:"content"`
+	Context    string  `json:"context,omitempty"`
+	Category   string  `json:"category"`
+	Confidence float64 `json:"confidence"`
+
+	NeedsValidation bool `json:"needs_validation,omitempty"`
+}
+
+// Dispatch executes the named tool with the model's JSON arguments and
+// returns the JSON result to send back as the tool message. Returns
+// ErrUnknownTool for unrecognized names.
+func (d *Dispatcher) Dispatch(ctx context.Context, name, arguments string) (string, error) {
+	if arguments == "" {
+		arguments = "{}"
+	}
+
+	var result any
+	switch name {
+	case QueryLore:
+		var args queryArgs
+		if err := json.Unmarshal([]byte(arguments), &args); err != nil {
+			return "", fmt.Errorf("tools: %s: parse arguments: %w", name, err)
+		}
+		r, err := d.query(ctx, args)
+		if err != nil {
+			return "", fmt.Errorf("tools: %s: %w", name, err)
+		}
+		result = r
+
+	case RecordLore:
+		var args recordArgs
+		if err := json.Unmarshal([]byte(arguments), &args); err != nil {
+			return "", fmt.Errorf("tools: %s: parse arguments: %w", name, err)
+		}
+		opts := []recall.RecordOption{}
+		if args.Context != "" {
+			opts = append(opts, recall.WithContext(args.Context))
+		}
+		if args.Confidence != nil {
+			opts = append(opts, recall.WithConfidence(*args.Confidence))
+		}
+		lore, err := d.client.Record(args.Content, recall.Category(args.Category), opts...)
+		if err != nil {
+			return "", fmt.Errorf("tools: %s: %w", name, err)
+		}
+		result = map[string]any{"id": lore.ID, "recorded": true}
+
+	case GiveFeedback:
+		var args recall.FeedbackParams
+		if err := json.Unmarshal([]byte(arguments), &args); err != nil {
+			return "", fmt.Errorf("tools: %s: parse arguments: %w", name, err)
+		}
+		r, err := d.client.FeedbackBatch(ctx, args)
+		if err != nil {
+			return "", fmt.Errorf("tools: %s: %w", name, err)
+		}
+		result = r
+
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownTool, name)
+	}
+
+	out, err := json.Marshal(result)
+	if err != nil {
+		return "", fmt.Errorf("tools: %s: marshal result: %w", name, err)
+	}
+	return string(out), nil
+}
+
+func (d *Dispatcher) query(ctx context.Context, args queryArgs) (*queryResult, error) {
+	if args.Query == "" {
+		return nil, &recall.ValidationError{Field: "query", Message: "required"}
+	}
+	params := recall.QueryParams{Query: args.Query, K: args.K, MinConfidence: args.MinConfidence}
+	for _, c := range args.Categories {
+		params.Categories = append(params.Categories, recall.Category(c))
+	}
+
+	res, err := d.client.Query(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	idToRef := make(map[string]string, len(res.SessionRefs))
+	for ref, id := range res.SessionRefs {
+		idToRef[id] = ref
+	}
+	out := &queryResult{Lore: make([]queryLoreItem, 0, len(res.Lore))}
+	for _, l := range res.Lore {
+		out.Lore = append(out.Lore, queryLoreItem{
+			Ref:        idToRef[l.ID],
+			Content:    l.Content,
+			Context:    l.Context,
+			Category:   string(l.Category),
+			Confidence: l.Confidence,
+
+			NeedsValidation: res.NeedsValidation[l.ID],
+		})
+	}
+	return out, nil
+}
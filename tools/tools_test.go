@@ -0,0 +1,118 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hyperengineering/recall"
+)
+
+func newTestDispatcher(t *testing.T) (*Dispatcher, *recall.Client) {
+	t.Helper()
+	client, err := recall.New(recall.Config{LocalPath: filepath.Join(t.TempDir(), "lore.db")})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return NewDispatcher(client), client
+}
+
+func TestDefinitions_Schema(t *testing.T) {
+	defs := Definitions()
+	if len(defs) != 3 {
+		t.Fatalf("definitions = %d, want 3", len(defs))
+	}
+
+	data, err := json.Marshal(defs)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var decoded []struct {
+		Type     string `json:"type"`
+		Function struct {
+			Name       string `json:"name"`
+			Parameters struct {
+				Type       string                     `json:"type"`
+				Required   []string                   `json:"required"`
+				Properties map[string]json.RawMessage `json:"properties"`
+			} `json:"parameters"`
+		} `json:"function"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	names := []string{QueryLore, RecordLore, GiveFeedback}
+	for i, d := range decoded {
+		if d.Type != "function" || d.Function.Name != names[i] || d.Function.Parameters.Type != "object" {
+			t.Errorf("definition %d = %+v", i, d)
+		}
+	}
+	if got := decoded[1].Function.Parameters.Required; len(got) != 2 || got[0] != "content" || got[1] != "category" {
+		t.Errorf("record_lore required = %v, want [content category]", got)
+	}
+	if !strings.Contains(string(decoded[1].Function.Parameters.Properties["category"]), `"TESTING_STRATEGY"`) {
+		t.Error("record_lore category enum should list valid categories")
+	}
+}
+
+func TestDispatch_RoundTrip(t *testing.T) {
+	d, _ := newTestDispatcher(t)
+	ctx := context.Background()
+
+	out, err := d.Dispatch(ctx, RecordLore, `{"content":"Cache tokens per tenant","category":"PATTERN_OUTCOME","confidence":0.7}`)
+	if err != nil {
+		t.Fatalf("record_lore: %v", err)
+	}
+	if !strings.Contains(out, `"recorded":true`) {
+		t.Errorf("record_lore output = %s", out)
+	}
+
+	out, err = d.Dispatch(ctx, QueryLore, `{"query":"tokens"}`)
+	if err != nil {
+		t.Fatalf("query_lore: %v", err)
+	}
+	var qr struct {
+		Lore []struct {
+			Ref        string  `json:"ref"`
+			Content    string  `json:"content"`
+			Confidence float64 `json:"confidence"`
+		} `json:"lore"`
+	}
+	if err := json.Unmarshal([]byte(out), &qr); err != nil {
+		t.Fatalf("unmarshal query output: %v", err)
+	}
+	if len(qr.Lore) != 1 || qr.Lore[0].Ref != "L1" || qr.Lore[0].Confidence != 0.7 {
+		t.Fatalf("query_lore output = %s", out)
+	}
+
+	out, err = d.Dispatch(ctx, GiveFeedback, `{"helpful":["L1"]}`)
+	if err != nil {
+		t.Fatalf("give_feedback: %v", err)
+	}
+	if !strings.Contains(out, `"updated"`) {
+		t.Errorf("give_feedback output = %s", out)
+	}
+}
+
+func TestDispatch_Errors(t *testing.T) {
+	d, _ := newTestDispatcher(t)
+	ctx := context.Background()
+
+	if _, err := d.Dispatch(ctx, "delete_everything", `{}`); !errors.Is(err, ErrUnknownTool) {
+		t.Errorf("unknown tool err = %v, want ErrUnknownTool", err)
+	}
+	if _, err := d.Dispatch(ctx, QueryLore, `{"query":`); err == nil {
+		t.Error("malformed arguments should fail")
+	}
+	if _, err := d.Dispatch(ctx, QueryLore, ``); err == nil {
+		t.Error("query_lore without query should fail")
+	}
+	if _, err := d.Dispatch(ctx, RecordLore, `{"content":"x","category":"NOPE"}`); err == nil {
+		t.Error("record_lore with invalid category should fail")
+	}
+}
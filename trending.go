@@ -0,0 +1,201 @@
+package recall
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// trendingBaselineWindows is how many windows before the current one
+	// make up the baseline a topic's recent activity is compared with.
+	trendingBaselineWindows = 4
+
+	// trendingMinEvents is the least recent activity a topic needs to
+	// count as trending.
+	trendingMinEvents = 3
+
+	// trendingMinRatio is how many times its baseline rate a topic's
+	// recent rate must reach to count as trending.
+	trendingMinRatio = 2.0
+
+	// trendingSimilarity is the cosine similarity above which embedded
+	// entries of one category join the same topic.
+	trendingSimilarity = 0.8
+
+	// trendingLabelLength caps the content excerpt used as a topic label.
+	trendingLabelLength = 80
+)
+
+// TrendingTopic is a cluster of related lore with unusually high recent
+// activity. Activity counts records and later local changes such as
+// feedback.
+type TrendingTopic struct {
+	Label          string   `json:"label"` // Excerpt of the most active entry
+	Category       Category `json:"category"`
+	LoreIDs        []string `json:"lore_ids"` // Most active first
+	RecentEvents   int      `json:"recent_events"`
+	BaselineEvents int      `json:"baseline_events"`
+	// Ratio is the recent activity rate over the baseline rate, smoothed
+	// so topics without a baseline do not divide by zero.
+	Ratio float64 `json:"ratio"`
+}
+
+// TrendingReport lists trending topics, hottest first.
+type TrendingReport struct {
+	Window        time.Duration   `json:"window"`
+	Since         time.Time       `json:"since"`          // Start of the recent window
+	BaselineSince time.Time       `json:"baseline_since"` // Start of the baseline
+	Topics        []TrendingTopic `json:"topics"`
+}
+
+// loreActivity is a lore entry with its activity counts.
+type loreActivity struct {
+	Lore
+	recent, baseline int
+}
+
+// activitySince returns entries with any activity since baselineStart,
+// counting records and later change_log upserts before and after
+// recentStart. The upsert written with a record, within a second of it,
+// is not counted again.
+func (s *Store) activitySince(baselineStart, recentStart time.Time) ([]loreActivity, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil, ErrStoreClosed
+	}
+
+	bs := baselineStart.UTC().Format(time.RFC3339)
+	rs := recentStart.UTC().Format(time.RFC3339)
+	rows, err := s.db.Query(`
+		SELECT id, content, category, embedding,
+		       (created_at >= ?1) + (SELECT COUNT(*) FROM change_log c
+		            WHERE c.table_name = 'lore_entries' AND c.entity_id = l.id AND c.operation = 'upsert'
+		              AND c.created_at > strftime('%Y-%m-%dT%H:%M:%SZ', l.created_at, '+1 second') AND c.created_at >= ?1),
+		       (created_at >= ?2 AND created_at < ?1) + (SELECT COUNT(*) FROM change_log c
+		            WHERE c.table_name = 'lore_entries' AND c.entity_id = l.id AND c.operation = 'upsert'
+		              AND c.created_at > strftime('%Y-%m-%dT%H:%M:%SZ', l.created_at, '+1 second') AND c.created_at >= ?2 AND c.created_at < ?1)
+		FROM lore_entries l
+		WHERE deleted_at IS NULL AND namespace = ?3
+		  AND (created_at >= ?2 OR EXISTS (SELECT 1 FROM change_log c
+		            WHERE c.table_name = 'lore_entries' AND c.entity_id = l.id AND c.created_at >= ?2))
+	`, rs, bs, s.namespace)
+	if err != nil {
+		return nil, fmt.Errorf("store: lore activity: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var activity []loreActivity
+	for rows.Next() {
+		var a loreActivity
+		if err := rows.Scan(&a.ID, &a.Content, &a.Category, &a.Embedding, &a.recent, &a.baseline); err != nil {
+			return nil, fmt.Errorf("store: scan lore activity: %w", err)
+		}
+		if a.recent+a.baseline > 0 {
+			activity = append(activity, a)
+		}
+	}
+	return activity, rows.Err()
+}
+
+// Trending finds topics with unusually high activity in the last window
+// compared with the trendingBaselineWindows windows before it, so leads can
+// spot recurring pain points early. Entries are grouped into topics by
+// category and, where they have embeddings, by similarity; a topic trends
+// when it has at least trendingMinEvents recent events at trendingMinRatio
+// times its baseline rate.
+func (c *Client) Trending(ctx context.Context, window time.Duration) (*TrendingReport, error) {
+	if window <= 0 {
+		return nil, &ValidationError{Field: "Window", Message: "must be positive"}
+	}
+	release, err := c.store.enter(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	now := c.store.now()
+	report := &TrendingReport{
+		Window:        window,
+		Since:         now.Add(-window),
+		BaselineSince: now.Add(-window * (trendingBaselineWindows + 1)),
+		Topics:        []TrendingTopic{},
+	}
+	activity, err := c.store.activitySince(report.BaselineSince, report.Since)
+	if err != nil {
+		return nil, fmt.Errorf("client: trending: %w", err)
+	}
+
+	for _, topic := range clusterActivity(activity) {
+		recentRate := float64(topic.RecentEvents)
+		baselineRate := float64(topic.BaselineEvents) / trendingBaselineWindows
+		topic.Ratio = (recentRate + 1) / (baselineRate + 1)
+		if topic.RecentEvents >= trendingMinEvents && topic.Ratio >= trendingMinRatio {
+			report.Topics = append(report.Topics, topic)
+		}
+	}
+	sort.SliceStable(report.Topics, func(i, j int) bool {
+		if report.Topics[i].Ratio != report.Topics[j].Ratio {
+			return report.Topics[i].Ratio > report.Topics[j].Ratio
+		}
+		return report.Topics[i].RecentEvents > report.Topics[j].RecentEvents
+	})
+	return report, nil
+}
+
+// clusterActivity groups active entries into topics. Within a category,
+// each embedded entry joins the first topic whose seed it resembles by
+// trendingSimilarity, most active entries first; entries without an
+// embedding share one topic per category.
+func clusterActivity(activity []loreActivity) []TrendingTopic {
+	sort.SliceStable(activity, func(i, j int) bool {
+		if activity[i].recent != activity[j].recent {
+			return activity[i].recent > activity[j].recent
+		}
+		return activity[i].baseline > activity[j].baseline
+	})
+
+	type cluster struct {
+		topic TrendingTopic
+		seed  []float32
+	}
+	var clusters []*cluster
+	unembedded := make(map[Category]*cluster)
+	for _, a := range activity {
+		var target *cluster
+		embedding := UnpackFloat32(a.Embedding)
+		if len(embedding) == 0 {
+			target = unembedded[a.Category]
+		} else {
+			for _, cl := range clusters {
+				if len(cl.seed) > 0 && cl.topic.Category == a.Category && CosineSimilarity(cl.seed, embedding) >= trendingSimilarity {
+					target = cl
+					break
+				}
+			}
+		}
+		if target == nil {
+			target = &cluster{
+				topic: TrendingTopic{Label: TruncateContent(strings.Join(strings.Fields(a.Content), " "), trendingLabelLength), Category: a.Category},
+				seed:  embedding,
+			}
+			clusters = append(clusters, target)
+			if len(embedding) == 0 {
+				unembedded[a.Category] = target
+			}
+		}
+		target.topic.LoreIDs = append(target.topic.LoreIDs, a.ID)
+		target.topic.RecentEvents += a.recent
+		target.topic.BaselineEvents += a.baseline
+	}
+
+	topics := make([]TrendingTopic, len(clusters))
+	for i, cl := range clusters {
+		topics[i] = cl.topic
+	}
+	return topics
+}
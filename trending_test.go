@@ -0,0 +1,91 @@
+package recall
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestClient_Trending(t *testing.T) {
+	client := newTestClient(t, Config{})
+
+	now := time.Now().UTC()
+	day := 24 * time.Hour
+	upsert := func(id string, category Category, createdAt time.Time, embedding []float32) {
+		t.Helper()
+		l := &Lore{ID: id, Content: "lore " + id, Category: category, Confidence: 0.5, CreatedAt: createdAt, UpdatedAt: createdAt}
+		if embedding != nil {
+			l.Embedding = PackFloat32(embedding)
+		}
+		if err := client.store.UpsertLore(l); err != nil {
+			t.Fatalf("UpsertLore: %v", err)
+		}
+	}
+	changed := func(id string, at time.Time) {
+		t.Helper()
+		if _, err := client.store.db.Exec(`
+			INSERT INTO change_log (table_name, entity_id, operation, payload, source_id, created_at)
+			VALUES ('lore_entries', ?, 'upsert', '{}', 'src', ?)
+		`, id, at.Format(time.RFC3339)); err != nil {
+			t.Fatalf("insert change_log: %v", err)
+		}
+	}
+
+	// A burst of flaky-test lore in the last day.
+	upsert("t1", CategoryTestingStrategy, now.Add(-2*time.Hour), nil)
+	upsert("t2", CategoryTestingStrategy, now.Add(-3*time.Hour), nil)
+	changed("t1", now.Add(-time.Hour))
+
+	// Steady activity: little busier in the last day than in each of the
+	// four before.
+	upsert("p1", CategoryPatternOutcome, now.Add(-5*day+time.Hour), nil)
+	for i := 1; i <= 4; i++ {
+		changed("p1", now.Add(-time.Duration(i)*day-time.Hour))
+	}
+	changed("p1", now.Add(-time.Hour))
+	changed("p1", now.Add(-2*time.Hour))
+	changed("p1", now.Add(-3*time.Hour))
+
+	// Two similar embedded entries form one topic; a dissimilar one its own.
+	upsert("e1", CategoryDependencyBehavior, now.Add(-time.Hour), []float32{1, 0})
+	upsert("e2", CategoryDependencyBehavior, now.Add(-time.Hour), []float32{0.95, 0.05})
+	changed("e1", now.Add(-30*time.Minute))
+	upsert("e3", CategoryDependencyBehavior, now.Add(-time.Hour), []float32{0, 1})
+
+	// Old activity is ignored.
+	upsert("old", CategoryTestingStrategy, now.Add(-30*day), nil)
+
+	report, err := client.Trending(context.Background(), day)
+	if err != nil {
+		t.Fatalf("Trending: %v", err)
+	}
+	if len(report.Topics) != 2 {
+		t.Fatalf("Topics = %+v, want the flaky-test and embedded topics", report.Topics)
+	}
+	for _, topic := range report.Topics {
+		switch topic.Category {
+		case CategoryTestingStrategy:
+			if topic.RecentEvents != 3 || topic.BaselineEvents != 0 || len(topic.LoreIDs) != 2 || topic.LoreIDs[0] != "t1" || topic.Label != "lore t1" {
+				t.Errorf("testing topic = %+v", topic)
+			}
+		case CategoryDependencyBehavior:
+			if topic.RecentEvents != 3 || len(topic.LoreIDs) != 2 || topic.LoreIDs[0] != "e1" {
+				t.Errorf("embedded topic = %+v, want e1 and e2", topic)
+			}
+		default:
+			t.Errorf("unexpected topic %+v", topic)
+		}
+		if topic.Ratio != 4 {
+			t.Errorf("%s ratio = %v, want 4", topic.Category, topic.Ratio)
+		}
+	}
+	if !report.Since.Before(now) || !report.BaselineSince.Equal(report.Since.Add(-4*day)) {
+		t.Errorf("Since = %v, BaselineSince = %v", report.Since, report.BaselineSince)
+	}
+
+	var ve *ValidationError
+	if _, err := client.Trending(context.Background(), 0); !errors.As(err, &ve) {
+		t.Errorf("zero window: err = %v, want ValidationError", err)
+	}
+}
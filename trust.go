@@ -0,0 +1,136 @@
+package recall
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SourceTrustDefault is the Config.SourceTrust key whose weight applies to
+// sources not listed. Without it, unlisted sources weigh 1.0.
+const SourceTrustDefault = "*"
+
+// ScoreExplanation shows how a query ranked an entry (QueryParams.Explain).
+type ScoreExplanation struct {
+	SourceID string `json:"source_id"`
+
+	// Similarity is the cosine similarity to QueryEmbedding; nil without one.
+	Similarity *float64 `json:"similarity,omitempty"`
+
+	// Trust is the weight of the entry's source from Config.SourceTrust.
+	Trust float64 `json:"trust"`
+
+	// CategoryWeight is 1 + CategoryRoutingBoost when the query was routed
+	// to the entry's category, otherwise 1.
+	CategoryWeight float64 `json:"category_weight"`
+
+	// Affinity is the local feedback affinity: above 1 when this store
+	// marked the entry helpful (see Config.FeedbackAffinity), otherwise 1.
+	Affinity float64 `json:"affinity"`
+
+	// Superseded is set when another entry supersedes this one; it then
+	// ranks below the others, its similarity score multiplied by
+	// SupersededPenalty.
+	Superseded bool `json:"superseded,omitempty"`
+
+	// Score is the ranking score: Similarity × Trust × CategoryWeight ×
+	// Affinity (and SupersededPenalty), or Trust × Affinity without
+	// QueryEmbedding.
+	Score float64 `json:"score"`
+}
+
+// ParseSourceTrust parses a comma-separated list of source=weight pairs,
+// e.g. "ci-bot=1.5,alice=2,*=0.8", as used by RECALL_SOURCE_TRUST.
+func ParseSourceTrust(s string) (map[string]float64, error) {
+	trust := make(map[string]float64)
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		source, weight, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, &ValidationError{Field: "SourceTrust", Message: fmt.Sprintf("%q: want source=weight", pair)}
+		}
+		w, err := strconv.ParseFloat(strings.TrimSpace(weight), 64)
+		if err != nil {
+			return nil, &ValidationError{Field: "SourceTrust", Message: fmt.Sprintf("%q: invalid weight", pair)}
+		}
+		trust[strings.TrimSpace(source)] = w
+	}
+	return trust, validateSourceTrust(trust)
+}
+
+// validateSourceTrust rejects negative weights.
+func validateSourceTrust(trust map[string]float64) error {
+	for source, w := range trust {
+		if w < 0 {
+			return &ValidationError{Field: "SourceTrust", Message: fmt.Sprintf("weight for %q must be non-negative", source)}
+		}
+	}
+	return nil
+}
+
+// sourceTrust returns the configured weight of sourceID.
+func (c *Client) sourceTrust(sourceID string) float64 {
+	if w, ok := c.config.SourceTrust[sourceID]; ok {
+		return w
+	}
+	if w, ok := c.config.SourceTrust[SourceTrustDefault]; ok {
+		return w
+	}
+	return 1.0
+}
+
+// rankByTrust stably reorders lore by source trust times feedback
+// affinity, keeping the store's order among equal weights. Used when there
+// is no similarity score to weight.
+func (c *Client) rankByTrust(lore []Lore, affinity map[string]float64) {
+	if len(c.config.SourceTrust) == 0 && affinity == nil {
+		return
+	}
+	sort.SliceStable(lore, func(i, j int) bool {
+		return c.sourceTrust(lore[i].SourceID)*affinityWeight(affinity, lore[i].ID) >
+			c.sourceTrust(lore[j].SourceID)*affinityWeight(affinity, lore[j].ID)
+	})
+}
+
+// weightByTrust multiplies similarity scores by source trust and re-sorts.
+func (c *Client) weightByTrust(scored []ScoredLore, loreByID map[string]Lore) {
+	if len(c.config.SourceTrust) == 0 {
+		return
+	}
+	for i := range scored {
+		scored[i].Score *= c.sourceTrust(loreByID[scored[i].ID].SourceID)
+	}
+	sort.SliceStable(scored, func(i, j int) bool { return scored[i].Score > scored[j].Score })
+}
+
+// explain builds score explanations for the returned lore. similarity is
+// nil for queries without an embedding; routed is the category the query
+// was routed to, if any, and superseded maps superseded entries to their
+// replacements.
+func (c *Client) explain(lore []Lore, similarity map[string]float64, routed Category, superseded map[string]string) map[string]ScoreExplanation {
+	affinity := c.feedbackAffinity()
+	out := make(map[string]ScoreExplanation, len(lore))
+	for _, l := range lore {
+		e := ScoreExplanation{
+			SourceID:       l.SourceID,
+			Trust:          c.sourceTrust(l.SourceID),
+			CategoryWeight: categoryWeight(l.Category, routed),
+			Affinity:       affinityWeight(affinity, l.ID),
+		}
+		_, e.Superseded = superseded[l.ID]
+		e.Score = e.Trust * e.Affinity
+		if sim, ok := similarity[l.ID]; ok {
+			e.Similarity = &sim
+			e.Score = sim * e.Trust * e.CategoryWeight * e.Affinity
+			if e.Superseded {
+				e.Score *= SupersededPenalty
+			}
+		}
+		out[l.ID] = e
+	}
+	return out
+}
@@ -0,0 +1,124 @@
+package recall
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseSourceTrust(t *testing.T) {
+	trust, err := ParseSourceTrust("ci-bot=1.5, alice=2 ,*=0.8")
+	if err != nil {
+		t.Fatalf("ParseSourceTrust() error = %v", err)
+	}
+	want := map[string]float64{"ci-bot": 1.5, "alice": 2, "*": 0.8}
+	if len(trust) != len(want) {
+		t.Fatalf("ParseSourceTrust() = %v, want %v", trust, want)
+	}
+	for k, v := range want {
+		if trust[k] != v {
+			t.Errorf("trust[%q] = %v, want %v", k, trust[k], v)
+		}
+	}
+
+	for _, in := range []string{"ci-bot", "ci-bot=high", "ci-bot=-1"} {
+		_, err := ParseSourceTrust(in)
+		var verr *ValidationError
+		if !errors.As(err, &verr) || verr.Field != "SourceTrust" {
+			t.Errorf("ParseSourceTrust(%q) error = %v, want SourceTrust ValidationError", in, err)
+		}
+	}
+}
+
+func TestConfig_Validate_NegativeSourceTrust(t *testing.T) {
+	cfg := Config{LocalPath: "x.db", SourceTrust: map[string]float64{"bot": -0.5}}
+	if err := cfg.Validate(); err == nil {
+		t.Error("Validate() should reject negative source trust")
+	}
+}
+
+// newTrustTestClient opens a client with the given trust map and inserts
+// one entry per source. A nil embedding inserts the entry without one.
+func newTrustTestClient(t *testing.T, trust map[string]float64, entries []Lore) *Client {
+	t.Helper()
+	client := newTestClient(t, Config{SourceTrust: trust})
+
+	now := time.Now().UTC()
+	for i := range entries {
+		entries[i].Category = CategoryPatternOutcome
+		entries[i].Confidence = 0.8
+		entries[i].CreatedAt = now
+		entries[i].UpdatedAt = now
+		if err := client.store.InsertLore(&entries[i]); err != nil {
+			t.Fatalf("InsertLore() error = %v", err)
+		}
+	}
+	return client
+}
+
+func TestQuery_SourceTrustReordersSimilarity(t *testing.T) {
+	client := newTrustTestClient(t, map[string]float64{"senior": 2, SourceTrustDefault: 0.5}, []Lore{
+		{ID: "close", Content: "close match from a stranger", SourceID: "stranger", Embedding: PackFloat32([]float32{1, 0, 0})},
+		{ID: "trusted", Content: "weaker match from a senior", SourceID: "senior", Embedding: PackFloat32([]float32{0.6, 0.8, 0})},
+	})
+
+	result, err := client.Query(context.Background(), QueryParams{
+		Query:          "q",
+		QueryEmbedding: []float32{1, 0, 0},
+		K:              1,
+		Explain:        true,
+	})
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+	// close: 1.0 × 0.5 = 0.5; trusted: 0.6 × 2 = 1.2.
+	if len(result.Lore) != 1 || result.Lore[0].ID != "trusted" {
+		t.Fatalf("Query() = %v, want only trusted", result.Lore)
+	}
+
+	e, ok := result.Explanations["trusted"]
+	if !ok {
+		t.Fatal("Explanations missing trusted")
+	}
+	if e.SourceID != "senior" || e.Trust != 2 {
+		t.Errorf("explanation = %+v, want source senior trust 2", e)
+	}
+	if e.Similarity == nil || *e.Similarity < 0.59 || *e.Similarity > 0.61 {
+		t.Errorf("Similarity = %v, want ~0.6", e.Similarity)
+	}
+	if e.Score < 1.19 || e.Score > 1.21 {
+		t.Errorf("Score = %v, want ~1.2", e.Score)
+	}
+}
+
+func TestQuery_SourceTrustReordersBasic(t *testing.T) {
+	client := newTrustTestClient(t, map[string]float64{"ci-bot": 3}, []Lore{
+		{ID: "a", Content: "from a person", SourceID: "alice"},
+		{ID: "b", Content: "from the bot", SourceID: "ci-bot"},
+	})
+
+	result, err := client.Query(context.Background(), QueryParams{Query: "q", K: 1, Explain: true})
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+	if len(result.Lore) != 1 || result.Lore[0].ID != "b" {
+		t.Fatalf("Query() = %v, want only b", result.Lore)
+	}
+	e := result.Explanations["b"]
+	if e.Similarity != nil || e.Trust != 3 || e.Score != 3 {
+		t.Errorf("explanation = %+v, want trust 3, score 3, no similarity", e)
+	}
+}
+
+func TestQuery_NoExplainOmitsExplanations(t *testing.T) {
+	client := newTrustTestClient(t, nil, []Lore{{ID: "a", Content: "entry", SourceID: "alice"}})
+
+	result, err := client.Query(context.Background(), QueryParams{Query: "q", K: 5})
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+	if result.Explanations != nil {
+		t.Errorf("Explanations = %v, want nil without Explain", result.Explanations)
+	}
+}
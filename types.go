@@ -18,7 +18,12 @@ type Lore struct {
 	CreatedAt       time.Time  `json:"created_at"`
 	UpdatedAt       time.Time  `json:"updated_at"`
 	DeletedAt       *time.Time `json:"deleted_at,omitempty"`
-	SyncedAt        *time.Time `json:"synced_at,omitempty"`
+	SyncedAt        *time.Time `json:"synced_at,omitempty"` // Last pushed to Engram; nil for lore received from it
+	Namespace       string     `json:"namespace,omitempty"` // Empty for the default namespace
+	Merged          bool       `json:"merged,omitempty"`    // Record returned an existing exact duplicate
+	Staged          bool       `json:"staged,omitempty"`    // Record staged the entry in the inbox (Config.Inbox)
+	Freshness       Freshness  `json:"freshness,omitempty"` // Set on query results
+	Remote          bool       `json:"remote,omitempty"`    // Query result fetched from Engram by the remote fallback
 }
 
 // Category classifies the type of lore.
@@ -66,11 +71,13 @@ const (
 	FeedbackHelpful     FeedbackType = "helpful"
 	FeedbackIncorrect   FeedbackType = "incorrect"
 	FeedbackNotRelevant FeedbackType = "not_relevant"
+	FeedbackOutdated    FeedbackType = "outdated" // Was right, no longer is
 
 	// Aliases for cleaner API
 	Helpful     = FeedbackHelpful
 	Incorrect   = FeedbackIncorrect
 	NotRelevant = FeedbackNotRelevant
+	Outdated    = FeedbackOutdated
 )
 
 // feedbackDelta returns the confidence delta for a feedback type.
@@ -80,6 +87,8 @@ func feedbackDelta(ft FeedbackType) float64 {
 		return ConfidenceHelpfulDelta
 	case Incorrect:
 		return ConfidenceIncorrectDelta
+	case Outdated:
+		return ConfidenceOutdatedDelta
 	default:
 		return ConfidenceNotRelevantDelta
 	}
@@ -100,12 +109,178 @@ type QueryParams struct {
 	K              int        `json:"k,omitempty"`
 	MinConfidence  *float64   `json:"min_confidence,omitempty"`
 	Categories     []Category `json:"categories,omitempty"`
+	IncludeNotes   bool       `json:"include_notes,omitempty"` // Attach private notes to results
+
+	// Mode selects how lore is matched: QueryModeSemantic,
+	// QueryModeKeyword or QueryModeHybrid. Empty ranks by similarity to
+	// QueryEmbedding when given, and otherwise lists matching lore by
+	// trust without reading Query.
+	Mode QueryMode `json:"mode,omitempty"`
+
+	// Temperature, when positive, samples the K results instead of taking
+	// the top K, so lesser-known lore gets surfaced and validated too.
+	// Results are drawn from the top 4×K of the ranking, weighted by
+	// exp(score/Temperature), where the score is confidence times
+	// similarity (confidence alone without QueryEmbedding). Around 0.1
+	// rarely leaves the head; 1 and above is close to a shuffle of the
+	// pool. Seed makes the draw reproducible; zero seeds from the clock.
+	Temperature float64 `json:"temperature,omitempty"`
+	Seed        int64   `json:"seed,omitempty"`
+
+	// CategoryQuotas partitions K across categories, e.g. 2 PATTERN_OUTCOME +
+	// 1 TESTING_STRATEGY. Unfilled quota slots are backfilled from other
+	// matches. K defaults to the quota total.
+	CategoryQuotas map[Category]int `json:"category_quotas,omitempty"`
+
+	// Explain attaches a ScoreExplanation per returned entry.
+	Explain bool `json:"explain,omitempty"`
+
+	// NoRouting disables category routing for this query. Routing never
+	// applies when Categories or CategoryQuotas are given.
+	NoRouting bool `json:"no_routing,omitempty"`
+
+	// HideSuperseded drops entries that another entry supersedes. Otherwise
+	// they rank below the rest (see SupersededPenalty).
+	HideSuperseded bool `json:"hide_superseded,omitempty"`
+
+	// SourceIDs limits results to lore recorded by these sources (agents
+	// or humans); ExcludeSourceIDs drops lore recorded by these.
+	SourceIDs        []string `json:"source_ids,omitempty"`
+	ExcludeSourceIDs []string `json:"exclude_source_ids,omitempty"`
+
+	// Owners limits results to lore owned by these people (see
+	// Client.Assign). Ownership is local, so the remote fallback is
+	// skipped; QueryAsOf does not apply it.
+	Owners []string `json:"owners,omitempty"`
+
+	// OnlySynced drops lore with local changes not yet pushed to Engram,
+	// for workflows that must rely only on shared knowledge.
+	OnlySynced bool `json:"only_synced,omitempty"`
+
+	// MinSimilarity drops results whose cosine similarity to
+	// QueryEmbedding is below it, even if fewer than K remain, so a query
+	// on a novel topic returns nothing rather than noise. It compares the
+	// raw similarity, before source trust and routing weights, and needs
+	// QueryEmbedding. Range [-1, 1]; nil applies no cutoff.
+	MinSimilarity *float64 `json:"min_similarity,omitempty"`
+
+	// MaxLatency and Deadline bound the time spent scoring candidates by
+	// similarity; the earlier of the two applies. Once it passes, Query
+	// returns the best results among the candidates scored so far and sets
+	// QueryStats.Partial, instead of scoring the rest. The remote fallback
+	// is skipped. Zero values leave the query unbounded.
+	MaxLatency time.Duration `json:"max_latency_ns,omitempty"`
+	Deadline   time.Time     `json:"-"`
+
+	// WorkingContext describes the active work area, e.g. "currently
+	// editing payment-service/handlers", to bias retrieval toward it. Its
+	// embedding, WorkingContextEmbedding if set or else Embed applied to
+	// WorkingContext, is blended into QueryEmbedding at
+	// WorkingContextWeight (0 selects DefaultWorkingContextWeight; range
+	// [0, 1]) for this query only; stored lore is not changed. Similarity,
+	// MinSimilarity and the remote fallback then use the blended vector.
+	// Without QueryEmbedding the working context is ignored.
+	WorkingContext          string    `json:"working_context,omitempty"`
+	WorkingContextEmbedding []float32 `json:"working_context_embedding,omitempty"`
+	WorkingContextWeight    float64   `json:"working_context_weight,omitempty"`
+	Embed                   EmbedFunc `json:"-"`
+
+	// categoryMinConfidence overrides MinConfidence per category when it
+	// was defaulted (Config.CategoryMinConfidence).
+	categoryMinConfidence map[Category]float64
+
+	// keywordMatch limits results to the maxKeywordMatches entries best
+	// matching this FTS5 expression, ordered by BM25 relevance (keyword
+	// and hybrid modes).
+	keywordMatch string
 }
 
 // QueryResult contains query results with session tracking.
 type QueryResult struct {
 	Lore        []Lore            `json:"lore"`
-	SessionRefs map[string]string `json:"session_refs"` // L1 -> lore ID
+	SessionRefs map[string]string `json:"session_refs"`    // L1 -> lore ID
+	Notes       map[string][]Note `json:"notes,omitempty"` // lore ID -> notes (IncludeNotes only)
+	Stats       QueryStats        `json:"stats"`
+
+	// Explanations maps lore ID to how it was ranked (Explain only).
+	Explanations map[string]ScoreExplanation `json:"explanations,omitempty"`
+
+	// SupersededBy maps each returned entry that another entry supersedes
+	// to its replacement's ID.
+	SupersededBy map[string]string `json:"superseded_by,omitempty"`
+
+	// NeedsValidation marks returned entries that were never validated or
+	// whose last validation is older than Config.ValidationStaleAfter.
+	// Agents should confirm or refute them with Feedback when they can.
+	NeedsValidation map[string]bool `json:"needs_validation,omitempty"`
+
+	// Chunks maps each returned entry that was reassembled from chunked
+	// content (by the ID of its first part) to the IDs of all its parts.
+	Chunks map[string][]string `json:"chunks,omitempty"`
+
+	// Episodes maps each returned entry recorded by RecordEpisode to its
+	// episode ID; Client.Episode returns the rest of the episode.
+	Episodes map[string]string `json:"episodes,omitempty"`
+
+	// Protected maps each returned protected entry to the confidence floor
+	// feedback cannot lower it below; see Client.Protect.
+	Protected map[string]float64 `json:"protected,omitempty"`
+
+	// TokenCounts maps each returned entry to its content's token count
+	// under Config.TokenEstimator; TotalTokens is their sum.
+	TokenCounts map[string]int `json:"token_counts,omitempty"`
+	TotalTokens int            `json:"total_tokens,omitempty"`
+}
+
+// QueryStats describes the matches behind a QueryResult, so callers can
+// decide whether to broaden or narrow a query.
+type QueryStats struct {
+	// TotalMatched is the number of entries that passed the filters, before
+	// K and category quotas were applied. With QueryEmbedding, only entries
+	// with embeddings count. In keyword mode it is capped at 1000.
+	TotalMatched int `json:"total_matched"`
+
+	// CategoryCounts is TotalMatched broken down by category.
+	CategoryCounts map[Category]int `json:"category_counts"`
+
+	// Time spent filtering in the store, scoring by similarity (zero
+	// without QueryEmbedding), and ranking and truncating, in nanoseconds.
+	FilterTime time.Duration `json:"filter_time_ns"`
+	ScoreTime  time.Duration `json:"score_time_ns"`
+	RankTime   time.Duration `json:"rank_time_ns"`
+
+	// RoutedCategory is the category whose lore was boosted because the
+	// query embedding was clearly nearest its centroid; empty if none.
+	RoutedCategory Category `json:"routed_category,omitempty"`
+
+	// RemoteResults is the number of returned entries fetched from Engram
+	// by the remote fallback (Config.RemoteFallbackScore), and RemoteTime
+	// the time spent asking; both are zero when it did not run.
+	RemoteResults int           `json:"remote_results,omitempty"`
+	RemoteTime    time.Duration `json:"remote_time_ns,omitempty"`
+
+	// Partial is set when QueryParams.MaxLatency or Deadline passed before
+	// every candidate was scored; Scored is the number that were. The
+	// results are the best among those scored.
+	Partial bool `json:"partial,omitempty"`
+	Scored  int  `json:"scored,omitempty"`
+
+	// BelowSimilarity is the number of results dropped by
+	// QueryParams.MinSimilarity.
+	BelowSimilarity int `json:"below_similarity,omitempty"`
+
+	// SampledFrom is the size of the pool the results were sampled from
+	// with QueryParams.Temperature; zero for top-K queries.
+	SampledFrom int `json:"sampled_from,omitempty"`
+}
+
+// countMatches records the entries that passed the filters.
+func (qs *QueryStats) countMatches(matched []Lore) {
+	qs.TotalMatched = len(matched)
+	qs.CategoryCounts = make(map[Category]int)
+	for _, l := range matched {
+		qs.CategoryCounts[l.Category]++
+	}
 }
 
 // FeedbackParams provides feedback on recalled lore.
@@ -113,12 +288,14 @@ type FeedbackParams struct {
 	Helpful     []string `json:"helpful,omitempty"`      // Session refs or content snippets
 	NotRelevant []string `json:"not_relevant,omitempty"` // Surfaced but didn't apply
 	Incorrect   []string `json:"incorrect,omitempty"`    // Wrong or misleading
+	Outdated    []string `json:"outdated,omitempty"`     // Once right, since superseded
 }
 
 // FeedbackResult contains the results of applying feedback.
 type FeedbackResult struct {
 	Updated  []FeedbackUpdate `json:"updated"`
 	NotFound []string         `json:"not_found,omitempty"` // Refs that weren't found
+	Skipped  []string         `json:"skipped,omitempty"`   // Refs repeated within Config.FeedbackWindow
 }
 
 // FeedbackUpdate describes a single confidence update.
@@ -150,10 +327,29 @@ type SyncStats struct {
 
 // StoreStats contains statistics about the local store.
 type StoreStats struct {
-	LoreCount     int       `json:"lore_count"`
-	PendingSync   int       `json:"pending_sync"`
-	LastSync      time.Time `json:"last_sync"`
-	SchemaVersion string    `json:"schema_version"`
+	LoreCount     int         `json:"lore_count"`
+	PendingSync   int         `json:"pending_sync"`
+	LastSync      time.Time   `json:"last_sync"`
+	SchemaVersion string      `json:"schema_version"`
+	Path          string      `json:"path"`                    // Resolved database location
+	MigratedFrom  string      `json:"migrated_from,omitempty"` // Legacy path this store was migrated from
+	RecoveredFrom string      `json:"recovered_from,omitempty"` // Quarantined corrupt database this store was salvaged from
+	SyncMetrics   SyncMetrics `json:"sync_metrics"`
+
+	// Sync backlog. UnpushedChanges are change_log entries not yet pushed;
+	// ChangeLogBytes counts their payloads across the whole change_log.
+	UnpushedChanges  int   `json:"unpushed_changes"`
+	ChangeLogEntries int   `json:"change_log_entries"`
+	ChangeLogBytes   int64 `json:"change_log_bytes"`
+
+	// Health compares the backlog against Config.SoftQuota; Warnings lists
+	// each measure over its threshold. Set by Client.Stats.
+	Health   HealthState    `json:"health,omitempty"`
+	Warnings []QuotaWarning `json:"warnings,omitempty"`
+
+	// AutoSync reports the background sync scheduler; nil when it is not
+	// running. Set by Client.Stats.
+	AutoSync *AutoSyncStatus `json:"auto_sync,omitempty"`
 }
 
 // HealthStatus represents the health of the client.
@@ -168,6 +364,7 @@ type HealthStatus struct {
 const (
 	ConfidenceHelpfulDelta    = 0.08
 	ConfidenceIncorrectDelta  = -0.15
+	ConfidenceOutdatedDelta   = -0.30
 	ConfidenceNotRelevantDelta = 0.0
 	ConfidenceMergeBoost      = 0.10
 	ConfidenceDecayPerMonth   = 0.01
@@ -180,6 +377,7 @@ const (
 const (
 	MaxContentLength = 4000
 	MaxContextLength = 1000
+	MaxNoteLength    = 1000
 )
 
 // SyncQueueEntry represents a pending sync operation.
@@ -0,0 +1,133 @@
+package recall
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// localOnlyLoreColumns are lore_entries columns that exist only in the local
+// database. Engram snapshots never carry them, so ReplaceFromSnapshot saves
+// their values by ID before the import and re-applies them afterwards.
+var localOnlyLoreColumns = []string{"usage_count", "last_used_at"}
+
+// LoreUsage holds local-only usage statistics for a lore entry.
+type LoreUsage struct {
+	LoreID     string     `json:"lore_id"`
+	UsageCount int        `json:"usage_count"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+}
+
+// RecordUsage increments the local usage count of each entry and stamps
+// last_used_at. Usage statistics are never synced.
+func (s *Store) RecordUsage(ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return ErrStoreClosed
+	}
+
+	placeholders := make([]string, len(ids))
+	args := make([]any, 0, len(ids)+1)
+	args = append(args, s.now().Format(time.RFC3339))
+	for i, id := range ids {
+		placeholders[i] = "?"
+		args = append(args, id)
+	}
+
+	_, err := s.db.Exec(fmt.Sprintf(`
+		UPDATE lore_entries SET usage_count = usage_count + 1, last_used_at = ?
+		WHERE id IN (%s) AND namespace = ?
+	`, strings.Join(placeholders, ",")), append(args, s.namespace)...)
+	if err != nil {
+		return fmt.Errorf("store: record usage: %w", err)
+	}
+	return nil
+}
+
+// Usage returns local usage statistics for a lore entry.
+// Returns ErrNotFound if the entry does not exist.
+func (s *Store) Usage(id string) (*LoreUsage, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil, ErrStoreClosed
+	}
+
+	usage := &LoreUsage{LoreID: id}
+	var lastUsedAt sql.NullString
+	err := s.db.QueryRow(`SELECT usage_count, last_used_at FROM lore_entries WHERE id = ? AND namespace = ?`, id, s.namespace).
+		Scan(&usage.UsageCount, &lastUsedAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("store: get usage: %w", err)
+	}
+	if lastUsedAt.Valid {
+		t, _ := time.Parse(time.RFC3339, lastUsedAt.String)
+		usage.LastUsedAt = &t
+	}
+	return usage, nil
+}
+
+// saveLocalOnlyColumnsTx captures localOnlyLoreColumns for every lore row,
+// keyed by ID, so they can be restored after the rows are replaced.
+func saveLocalOnlyColumnsTx(tx *sql.Tx) (map[string][]any, error) {
+	rows, err := tx.Query(fmt.Sprintf(`SELECT id, %s FROM lore_entries`,
+		strings.Join(localOnlyLoreColumns, ", ")))
+	if err != nil {
+		return nil, fmt.Errorf("read local-only columns: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	saved := make(map[string][]any)
+	for rows.Next() {
+		var id string
+		values := make([]any, len(localOnlyLoreColumns))
+		dest := make([]any, 0, len(values)+1)
+		dest = append(dest, &id)
+		for i := range values {
+			dest = append(dest, &values[i])
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("scan local-only columns: %w", err)
+		}
+		saved[id] = values
+	}
+	return saved, rows.Err()
+}
+
+// restoreLocalOnlyColumnsTx re-applies values captured by
+// saveLocalOnlyColumnsTx to rows that still exist. Entries absent from the
+// new data are dropped along with their local state.
+func restoreLocalOnlyColumnsTx(tx *sql.Tx, saved map[string][]any) error {
+	if len(saved) == 0 {
+		return nil
+	}
+
+	assignments := make([]string, len(localOnlyLoreColumns))
+	for i, col := range localOnlyLoreColumns {
+		assignments[i] = col + " = ?"
+	}
+	stmt, err := tx.Prepare(fmt.Sprintf(`UPDATE lore_entries SET %s WHERE id = ?`,
+		strings.Join(assignments, ", ")))
+	if err != nil {
+		return fmt.Errorf("prepare restore local-only columns: %w", err)
+	}
+	defer func() { _ = stmt.Close() }()
+
+	for id, values := range saved {
+		if _, err := stmt.Exec(append(values, id)...); err != nil {
+			return fmt.Errorf("restore local-only columns for %s: %w", id, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package recall
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestStore_RecordUsage(t *testing.T) {
+	store := newTestStore(t)
+	lore := &Lore{ID: "USAGE00000000000000000001", Content: "used", Category: CategoryPatternOutcome, Confidence: 0.5}
+	if err := store.InsertLore(lore); err != nil {
+		t.Fatalf("InsertLore: %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if err := store.RecordUsage([]string{lore.ID, "missing"}); err != nil {
+			t.Fatalf("RecordUsage: %v", err)
+		}
+	}
+
+	usage, err := store.Usage(lore.ID)
+	if err != nil {
+		t.Fatalf("Usage: %v", err)
+	}
+	if usage.UsageCount != 3 || usage.LastUsedAt == nil {
+		t.Errorf("usage = %+v, want count 3 with last_used_at", usage)
+	}
+
+	if _, err := store.Usage("missing"); err != ErrNotFound {
+		t.Errorf("Usage(missing) err = %v, want ErrNotFound", err)
+	}
+
+	// Usage never reaches the change_log.
+	entries, err := store.UnpushedChanges(store.SourceID(), 0, 100)
+	if err != nil {
+		t.Fatalf("UnpushedChanges: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("change_log entries = %d, want only the insert", len(entries))
+	}
+}
+
+func TestStore_ReplaceFromSnapshot_PreservesUsage(t *testing.T) {
+	mainStore := newTestStore(t)
+
+	kept := &Lore{ID: "KEPT000000000000000000001", Content: "local copy", Category: CategoryPatternOutcome, Confidence: 0.5}
+	dropped := &Lore{ID: "DROP000000000000000000001", Content: "gone remotely", Category: CategoryPatternOutcome, Confidence: 0.5}
+	for _, l := range []*Lore{kept, dropped} {
+		if err := mainStore.InsertLore(l); err != nil {
+			t.Fatalf("InsertLore: %v", err)
+		}
+	}
+	if err := mainStore.RecordUsage([]string{kept.ID, dropped.ID}); err != nil {
+		t.Fatalf("RecordUsage: %v", err)
+	}
+	if err := mainStore.RecordUsage([]string{kept.ID}); err != nil {
+		t.Fatalf("RecordUsage: %v", err)
+	}
+
+	snapshotPath := filepath.Join(t.TempDir(), "snapshot.db")
+	snapshotStore, err := NewStore(snapshotPath)
+	if err != nil {
+		t.Fatalf("NewStore (snapshot): %v", err)
+	}
+	if err := snapshotStore.InsertLore(&Lore{ID: kept.ID, Content: "remote copy", Category: CategoryPatternOutcome, Confidence: 0.8}); err != nil {
+		t.Fatalf("InsertLore (snapshot): %v", err)
+	}
+	snapshotStore.Close()
+
+	snapshotFile, err := os.Open(snapshotPath)
+	if err != nil {
+		t.Fatalf("open snapshot: %v", err)
+	}
+	defer snapshotFile.Close()
+
+	if err := mainStore.ReplaceFromSnapshot(snapshotFile); err != nil {
+		t.Fatalf("ReplaceFromSnapshot: %v", err)
+	}
+
+	got, err := mainStore.Get(kept.ID)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Content != "remote copy" {
+		t.Errorf("Content = %q, want snapshot content", got.Content)
+	}
+
+	usage, err := mainStore.Usage(kept.ID)
+	if err != nil {
+		t.Fatalf("Usage: %v", err)
+	}
+	if usage.UsageCount != 2 || usage.LastUsedAt == nil {
+		t.Errorf("usage = %+v, want count 2 preserved", usage)
+	}
+
+	if _, err := mainStore.Usage(dropped.ID); err != ErrNotFound {
+		t.Errorf("Usage(dropped) err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestClient_Query_RecordsUsage(t *testing.T) {
+	client := newTestClient(t, Config{AutoSync: false})
+
+	lore, err := client.Record("query me", CategoryPatternOutcome)
+	if err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+	if _, err := client.Query(context.Background(), QueryParams{}); err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+
+	usage, err := client.store.Usage(lore.ID)
+	if err != nil {
+		t.Fatalf("Usage: %v", err)
+	}
+	if usage.UsageCount != 1 {
+		t.Errorf("UsageCount = %d, want 1", usage.UsageCount)
+	}
+}
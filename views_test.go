@@ -0,0 +1,77 @@
+package recall
+
+import (
+	"testing"
+	"time"
+)
+
+// TestIntrospectionViews verifies the dashboard views exist and expose
+// their documented columns.
+func TestIntrospectionViews(t *testing.T) {
+	store := newTestStore(t)
+
+	now := time.Now().UTC()
+	for _, l := range []Lore{
+		{ID: "a", Content: "Pool connections", Category: CategoryPerformanceInsight, Confidence: 0.8, Embedding: PackFloat32([]float32{1, 0})},
+		{ID: "b", Content: "Cache aggressively", Category: CategoryPerformanceInsight, Confidence: 0.2},
+		{ID: "c", Content: "Table tests", Category: CategoryTestingStrategy, Confidence: 0.5},
+	} {
+		l.SourceID, l.CreatedAt, l.UpdatedAt = "test", now, now
+		if err := store.InsertLore(&l); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := store.ApplyFeedback("a", ConfidenceHelpfulDelta, true); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.DeleteLoreByID("c"); err != nil {
+		t.Fatal(err)
+	}
+
+	var id, summary string
+	var hasEmbedding, synced int
+	err := store.db.QueryRow(`
+		SELECT id, summary, has_embedding, synced FROM v_lore_overview WHERE id = 'a'
+	`).Scan(&id, &summary, &hasEmbedding, &synced)
+	if err != nil {
+		t.Fatalf("query v_lore_overview: %v", err)
+	}
+	if summary != "Pool connections" || hasEmbedding != 1 || synced != 0 {
+		t.Errorf("v_lore_overview row = %q/%d/%d", summary, hasEmbedding, synced)
+	}
+	var live int
+	if err := store.db.QueryRow(`SELECT COUNT(*) FROM v_lore_overview`).Scan(&live); err != nil || live != 2 {
+		t.Errorf("v_lore_overview rows = %d (%v), want 2 live entries", live, err)
+	}
+
+	backlog := map[string]int{}
+	rows, err := store.db.Query(`SELECT kind, pending FROM v_sync_backlog`)
+	if err != nil {
+		t.Fatalf("query v_sync_backlog: %v", err)
+	}
+	for rows.Next() {
+		var kind string
+		var pending int
+		if err := rows.Scan(&kind, &pending); err != nil {
+			t.Fatal(err)
+		}
+		backlog[kind] = pending
+	}
+	_ = rows.Close()
+	// 3 inserts, 1 feedback, 1 delete.
+	if backlog["unpushed_changes"] != 5 || backlog["unsynced_lore"] != 2 || backlog["unresolved_conflicts"] != 0 {
+		t.Errorf("v_sync_backlog = %v", backlog)
+	}
+
+	var entries, validated, lowConfidence, withEmbedding int
+	err = store.db.QueryRow(`
+		SELECT entries, validated, low_confidence, with_embedding
+		FROM v_category_health WHERE namespace = '' AND category = ?
+	`, string(CategoryPerformanceInsight)).Scan(&entries, &validated, &lowConfidence, &withEmbedding)
+	if err != nil {
+		t.Fatalf("query v_category_health: %v", err)
+	}
+	if entries != 2 || validated != 1 || lowConfidence != 1 || withEmbedding != 1 {
+		t.Errorf("v_category_health = %d entries, %d validated, %d low, %d embedded", entries, validated, lowConfidence, withEmbedding)
+	}
+}
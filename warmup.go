@@ -0,0 +1,99 @@
+package recall
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// WarmupOption configures Warmup.
+type WarmupOption func(*warmupOptions)
+
+type warmupOptions struct {
+	embed EmbedFunc
+}
+
+// WithWarmupEmbed makes Warmup embed a short probe text, so the embedding
+// provider loads its model or opens its connection before the first query.
+func WithWarmupEmbed(embed EmbedFunc) WarmupOption {
+	return func(o *warmupOptions) {
+		o.embed = embed
+	}
+}
+
+// WarmupResult reports what Client.Warmup loaded.
+type WarmupResult struct {
+	Entries    int `json:"entries"`    // Live entries read
+	Embeddings int `json:"embeddings"` // Entries with a decodable embedding
+	Dimensions int `json:"dimensions"` // Embedding dimensions, 0 if none
+
+	StoreTime time.Duration `json:"store_time"`
+
+	// Engram is pinged when configured; a failure is reported here rather
+	// than returned, so offline startup still warms the store.
+	EngramChecked   bool          `json:"engram_checked"`
+	EngramReachable bool          `json:"engram_reachable"`
+	EngramTime      time.Duration `json:"engram_time,omitempty"`
+	EngramError     string        `json:"engram_error,omitempty"`
+
+	// The embedding provider is probed with WithWarmupEmbed; failures are
+	// reported the same way.
+	EmbedChecked bool          `json:"embed_checked"`
+	EmbedTime    time.Duration `json:"embed_time,omitempty"`
+	EmbedError   string        `json:"embed_error,omitempty"`
+}
+
+// Warmup primes the client so the first query after startup is fast: it
+// reads every live entry of the namespace through the query path (filling
+// SQLite's page cache), checks that the embeddings decode, opens a
+// connection to Engram and, with WithWarmupEmbed, probes the embedding
+// provider. Agent frameworks can call it while starting up;
+// skipping it only makes the first query slower.
+func (c *Client) Warmup(ctx context.Context, opts ...WarmupOption) (*WarmupResult, error) {
+	options := warmupOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	result := &WarmupResult{}
+
+	start := time.Now()
+	lore, err := c.store.Query(QueryParams{})
+	if err != nil {
+		return nil, fmt.Errorf("client: warmup: %w", err)
+	}
+	result.Entries = len(lore)
+
+	for _, l := range lore {
+		if embedding := UnpackFloat32(l.Embedding); len(embedding) > 0 {
+			result.Embeddings++
+			result.Dimensions = len(embedding)
+		}
+	}
+	result.StoreTime = time.Since(start)
+
+	if err := ctx.Err(); err != nil {
+		return result, err
+	}
+
+	if c.syncer != nil {
+		start = time.Now()
+		_, err := c.syncer.Health(ctx)
+		result.EngramChecked = true
+		result.EngramTime = time.Since(start)
+		result.EngramReachable = err == nil
+		if err != nil {
+			result.EngramError = err.Error()
+		}
+	}
+
+	if options.embed != nil {
+		start = time.Now()
+		_, err := options.embed(ctx, "warmup")
+		result.EmbedChecked = true
+		result.EmbedTime = time.Since(start)
+		if err != nil {
+			result.EmbedError = err.Error()
+		}
+	}
+	return result, ctx.Err()
+}
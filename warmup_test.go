@@ -0,0 +1,65 @@
+package recall
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClient_Warmup(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"status":"healthy"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client := newTestClient(t, Config{
+		Store:     "test-store",
+		EngramURL: server.URL,
+		APIKey:    "key",
+		AutoSync:  false,
+	})
+
+	now := time.Now().UTC()
+	for _, l := range []Lore{
+		{ID: "a", Content: "Embedded", Embedding: PackFloat32([]float32{1, 0, 0})},
+		{ID: "b", Content: "Not embedded"},
+	} {
+		l.Category, l.Confidence, l.SourceID, l.CreatedAt, l.UpdatedAt = CategoryPatternOutcome, 0.5, "test", now, now
+		if err := client.store.InsertLore(&l); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	probes := 0
+	embed := func(ctx context.Context, text string) ([]float32, error) {
+		probes++
+		return []float32{1, 0, 0}, nil
+	}
+	result, err := client.Warmup(context.Background(), WithWarmupEmbed(embed))
+	if err != nil {
+		t.Fatalf("Warmup: %v", err)
+	}
+	if !result.EmbedChecked || probes != 1 || result.EmbedError != "" {
+		t.Errorf("embed checked=%v probes=%d err=%q, want one probe", result.EmbedChecked, probes, result.EmbedError)
+	}
+	if result.Entries != 2 || result.Embeddings != 1 || result.Dimensions != 3 {
+		t.Errorf("result = %d entries, %d embeddings, %d dims", result.Entries, result.Embeddings, result.Dimensions)
+	}
+	if !result.EngramChecked || !result.EngramReachable {
+		t.Errorf("Engram checked=%v reachable=%v (%s)", result.EngramChecked, result.EngramReachable, result.EngramError)
+	}
+}
+
+func TestClient_Warmup_Offline(t *testing.T) {
+	client := newTestClient(t, Config{})
+
+	result, err := client.Warmup(context.Background())
+	if err != nil {
+		t.Fatalf("Warmup: %v", err)
+	}
+	if result.Entries != 0 || result.EngramChecked {
+		t.Errorf("result = %+v, want an empty store and no Engram check", result)
+	}
+}
@@ -0,0 +1,85 @@
+package recall
+
+import (
+	"context"
+	"fmt"
+	"math"
+)
+
+// DefaultWorkingContextWeight is the share of the blended query vector
+// given to the working context when QueryParams.WorkingContextWeight is
+// zero.
+const DefaultWorkingContextWeight = 0.25
+
+// applyWorkingContext blends the working context into params.QueryEmbedding:
+// both vectors are scaled to unit length, mixed at the working context
+// weight, and the mix is scaled to unit length again. Queries without
+// QueryEmbedding are left unchanged. The working context embedding is
+// cleared once blended, so it is not logged.
+func applyWorkingContext(ctx context.Context, params *QueryParams) error {
+	if params.WorkingContextWeight < 0 || params.WorkingContextWeight > 1 {
+		return &ValidationError{Field: "WorkingContextWeight", Message: "must be between 0 and 1"}
+	}
+	if len(params.QueryEmbedding) == 0 || (params.WorkingContext == "" && len(params.WorkingContextEmbedding) == 0) {
+		return nil
+	}
+
+	working := params.WorkingContextEmbedding
+	if len(working) == 0 {
+		if params.Embed == nil {
+			return &ValidationError{Field: "WorkingContext", Message: "needs Embed or WorkingContextEmbedding"}
+		}
+		embedding, err := params.Embed(ctx, params.WorkingContext)
+		if err != nil {
+			return fmt.Errorf("client: query: embed working context: %w", err)
+		}
+		working = embedding
+	}
+	if len(working) != len(params.QueryEmbedding) {
+		return &ValidationError{
+			Field:   "WorkingContextEmbedding",
+			Message: fmt.Sprintf("has %d dimensions, query embedding has %d", len(working), len(params.QueryEmbedding)),
+		}
+	}
+
+	weight := params.WorkingContextWeight
+	if weight == 0 {
+		weight = DefaultWorkingContextWeight
+	}
+	params.QueryEmbedding = blendUnit(params.QueryEmbedding, working, weight)
+	params.WorkingContextEmbedding = nil
+	return nil
+}
+
+// blendUnit returns (1-weight)*â + weight*b̂ scaled to unit length, where â
+// and b̂ are a and b scaled to unit length. If either vector is zero, a is
+// returned unchanged.
+func blendUnit(a, b []float32, weight float64) []float32 {
+	na, nb := vectorNorm(a), vectorNorm(b)
+	if na == 0 || nb == 0 {
+		return a
+	}
+	blended := make([]float64, len(a))
+	var sum float64
+	for i := range a {
+		blended[i] = (1-weight)*float64(a[i])/na + weight*float64(b[i])/nb
+		sum += blended[i] * blended[i]
+	}
+	norm := math.Sqrt(sum)
+	if norm == 0 {
+		return a
+	}
+	out := make([]float32, len(a))
+	for i, v := range blended {
+		out[i] = float32(v / norm)
+	}
+	return out
+}
+
+func vectorNorm(v []float32) float64 {
+	var sum float64
+	for _, x := range v {
+		sum += float64(x) * float64(x)
+	}
+	return math.Sqrt(sum)
+}
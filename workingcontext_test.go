@@ -0,0 +1,72 @@
+package recall
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestClient_Query_WorkingContext(t *testing.T) {
+	client := newTestClient(t, Config{SourceID: "test"})
+
+	now := time.Now().UTC()
+	for _, l := range []*Lore{
+		{ID: "general", Embedding: PackFloat32([]float32{0.9, 0.1, 0})},
+		{ID: "payments", Embedding: PackFloat32([]float32{0.7, 0, 0.714})},
+	} {
+		l.Content, l.Category, l.Confidence, l.SourceID, l.CreatedAt, l.UpdatedAt = "entry "+l.ID, CategoryPatternOutcome, 0.5, "test", now, now
+		if err := client.store.InsertLore(l); err != nil {
+			t.Fatalf("InsertLore() error = %v", err)
+		}
+	}
+	query := []float32{1, 0, 0}
+
+	plain, err := client.Query(context.Background(), QueryParams{Query: "retries", QueryEmbedding: query, K: 1})
+	if err != nil || len(plain.Lore) != 1 || plain.Lore[0].ID != "general" {
+		t.Fatalf("Query() = %v, %v; want general first", plain, err)
+	}
+
+	boosted, err := client.Query(context.Background(), QueryParams{Query: "retries", QueryEmbedding: query, K: 1,
+		WorkingContextEmbedding: []float32{0, 0, 1}, WorkingContextWeight: 0.5})
+	if err != nil || len(boosted.Lore) != 1 || boosted.Lore[0].ID != "payments" {
+		t.Errorf("Query(WorkingContextEmbedding) = %v, %v; want payments first", boosted, err)
+	}
+
+	var embedded string
+	embed := func(_ context.Context, text string) ([]float32, error) {
+		embedded = text
+		return []float32{0, 0, 1}, nil
+	}
+	byText, err := client.Query(context.Background(), QueryParams{Query: "retries", QueryEmbedding: query, K: 1,
+		WorkingContext: "currently editing payment-service/handlers", WorkingContextWeight: 0.5, Embed: embed})
+	if err != nil || len(byText.Lore) != 1 || byText.Lore[0].ID != "payments" {
+		t.Errorf("Query(WorkingContext) = %v, %v; want payments first", byText, err)
+	}
+	if embedded != "currently editing payment-service/handlers" {
+		t.Errorf("Embed() called with %q", embedded)
+	}
+
+	// The default weight biases without overriding the query
+	light, err := client.Query(context.Background(), QueryParams{Query: "retries", QueryEmbedding: query, K: 1,
+		WorkingContextEmbedding: []float32{0, 0, 1}})
+	if err != nil || len(light.Lore) != 1 || light.Lore[0].ID != "general" {
+		t.Errorf("Query(default weight) = %v, %v; want general first", light, err)
+	}
+
+	stored, _ := client.store.Get("payments")
+	if got := UnpackFloat32(stored.Embedding); len(got) != 3 || got[2] != 0.714 {
+		t.Errorf("stored embedding = %v, want unchanged", got)
+	}
+
+	for name, params := range map[string]QueryParams{
+		"weight":    {QueryEmbedding: query, WorkingContextEmbedding: []float32{0, 0, 1}, WorkingContextWeight: 1.5},
+		"no embed":  {QueryEmbedding: query, WorkingContext: "payments"},
+		"dimension": {QueryEmbedding: query, WorkingContextEmbedding: []float32{0, 1}},
+	} {
+		var verr *ValidationError
+		if _, err := client.Query(context.Background(), params); !errors.As(err, &verr) {
+			t.Errorf("Query(%s) error = %v, want a ValidationError", name, err)
+		}
+	}
+}
@@ -0,0 +1,316 @@
+package recall
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/hyperengineering/recall/internal/store"
+)
+
+// WorkspaceFileName is the manifest file searched for from the working
+// directory upwards. Commit it at the root of a monorepo to share Recall
+// configuration with the team.
+const WorkspaceFileName = "recall.workspace.json"
+
+// WorkspaceEnv overrides manifest discovery with an explicit path.
+// Set it to "off" to ignore workspace manifests.
+const WorkspaceEnv = "RECALL_WORKSPACE"
+
+// Workspace is a shared manifest mapping directories to stores, retrieval
+// profiles, and sync settings. Secrets such as API keys do not belong in
+// the manifest; they still come from the environment.
+//
+// Example:
+//
+//	{
+//	  "store": "acme/monorepo",
+//	  "profile": "default",
+//	  "sync": {"engram_url": "https://engram.acme.dev", "sync_interval": "10m"},
+//	  "profiles": {
+//	    "default": {"k": 5, "min_confidence": 0.5},
+//	    "testing": {"k": 3, "categories": ["TESTING_STRATEGY"]}
+//	  },
+//	  "paths": [
+//	    {"path": "services/payments", "store": "acme/payments", "profile": "testing"},
+//	    {"path": "web", "namespace": "web"}
+//	  ]
+//	}
+type Workspace struct {
+	// Root is the directory containing the manifest; paths are relative to it.
+	Root string `json:"-"`
+
+	Store     string                      `json:"store,omitempty"`
+	Namespace string                      `json:"namespace,omitempty"`
+	Profile   string                      `json:"profile,omitempty"`
+	Sync      WorkspaceSync               `json:"sync,omitempty"`
+	Profiles  map[string]RetrievalProfile `json:"profiles,omitempty"`
+	Paths     []WorkspacePath             `json:"paths,omitempty"`
+}
+
+// WorkspacePath overrides workspace defaults for a directory subtree.
+// The longest matching path wins.
+type WorkspacePath struct {
+	Path      string `json:"path"`
+	Store     string `json:"store,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	Profile   string `json:"profile,omitempty"`
+}
+
+// WorkspaceSync holds shared sync settings.
+type WorkspaceSync struct {
+	EngramURL      string         `json:"engram_url,omitempty"`
+	SyncInterval   string         `json:"sync_interval,omitempty"` // Go duration, e.g. "10m"
+	AutoSync       *bool          `json:"auto_sync,omitempty"`
+	ConflictPolicy ConflictPolicy `json:"conflict_policy,omitempty"`
+	DeltaPageSize  int            `json:"delta_page_size,omitempty"`
+}
+
+// RetrievalProfile holds query defaults applied to fields a QueryParams
+// leaves unset.
+type RetrievalProfile struct {
+	K              int              `json:"k,omitempty"`
+	MinConfidence  *float64         `json:"min_confidence,omitempty"`
+	MinSimilarity  *float64         `json:"min_similarity,omitempty"`
+	Categories     []Category       `json:"categories,omitempty"`
+	CategoryQuotas map[Category]int `json:"category_quotas,omitempty"`
+}
+
+// Apply fills unset fields of params from the profile.
+func (p RetrievalProfile) Apply(params QueryParams) QueryParams {
+	if params.K == 0 {
+		params.K = p.K
+	}
+	if params.MinConfidence == nil && p.MinConfidence != nil {
+		v := *p.MinConfidence
+		params.MinConfidence = &v
+	}
+	if params.MinSimilarity == nil && p.MinSimilarity != nil {
+		v := *p.MinSimilarity
+		params.MinSimilarity = &v
+	}
+	if len(params.Categories) == 0 {
+		params.Categories = p.Categories
+	}
+	if len(params.CategoryQuotas) == 0 {
+		params.CategoryQuotas = p.CategoryQuotas
+	}
+	return params
+}
+
+// WorkspaceSettings are the manifest settings resolved for one directory.
+type WorkspaceSettings struct {
+	Store       string            `json:"store,omitempty"`
+	Namespace   string            `json:"namespace,omitempty"`
+	ProfileName string            `json:"profile_name,omitempty"`
+	Profile     *RetrievalProfile `json:"profile,omitempty"`
+}
+
+// LoadWorkspace reads and validates a workspace manifest.
+func LoadWorkspace(path string) (*Workspace, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("workspace: %w", err)
+	}
+	var ws Workspace
+	if err := json.Unmarshal(data, &ws); err != nil {
+		return nil, fmt.Errorf("workspace: parse %s: %w", path, err)
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("workspace: %w", err)
+	}
+	ws.Root = filepath.Dir(abs)
+	if err := ws.Validate(); err != nil {
+		return nil, err
+	}
+	return &ws, nil
+}
+
+// FindWorkspace loads the manifest named by RECALL_WORKSPACE, or the nearest
+// recall.workspace.json in dir or its parents. Returns nil, nil if there is
+// none or RECALL_WORKSPACE is "off".
+func FindWorkspace(dir string) (*Workspace, error) {
+	switch env := os.Getenv(WorkspaceEnv); env {
+	case "":
+	case "off":
+		return nil, nil
+	default:
+		return LoadWorkspace(env)
+	}
+
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("workspace: %w", err)
+	}
+	for {
+		path := filepath.Join(dir, WorkspaceFileName)
+		if _, err := os.Stat(path); err == nil {
+			return LoadWorkspace(path)
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("workspace: %w", err)
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, nil
+		}
+		dir = parent
+	}
+}
+
+// Validate checks store IDs, namespaces, profile references, and sync settings.
+// Returns *ValidationError with the offending manifest field.
+func (w *Workspace) Validate() error {
+	if w.Store != "" {
+		if err := store.ValidateStoreID(w.Store); err != nil {
+			return &ValidationError{Field: "workspace.store", Message: err.Error()}
+		}
+	}
+	if err := ValidateNamespace(w.Namespace); err != nil {
+		return &ValidationError{Field: "workspace.namespace", Message: err.Error()}
+	}
+	if w.Profile != "" {
+		if _, ok := w.Profiles[w.Profile]; !ok {
+			return &ValidationError{Field: "workspace.profile", Message: fmt.Sprintf("unknown profile %q", w.Profile)}
+		}
+	}
+	for name, p := range w.Profiles {
+		if p.K < 0 {
+			return &ValidationError{Field: "workspace.profiles." + name + ".k", Message: "must be non-negative"}
+		}
+		if p.MinSimilarity != nil && (*p.MinSimilarity < -1 || *p.MinSimilarity > 1) {
+			return &ValidationError{Field: "workspace.profiles." + name + ".min_similarity", Message: "must be between -1 and 1"}
+		}
+		for _, cat := range p.Categories {
+			if !cat.IsValid() {
+				return &ValidationError{Field: "workspace.profiles." + name + ".categories", Message: fmt.Sprintf("invalid category %q", cat)}
+			}
+		}
+		if _, err := validateCategoryQuotas(p.CategoryQuotas); err != nil {
+			return &ValidationError{Field: "workspace.profiles." + name + ".category_quotas", Message: err.Error()}
+		}
+	}
+	for i, p := range w.Paths {
+		field := fmt.Sprintf("workspace.paths[%d]", i)
+		if p.Path == "" || filepath.IsAbs(p.Path) {
+			return &ValidationError{Field: field + ".path", Message: "must be a relative path"}
+		}
+		if p.Store != "" {
+			if err := store.ValidateStoreID(p.Store); err != nil {
+				return &ValidationError{Field: field + ".store", Message: err.Error()}
+			}
+		}
+		if err := ValidateNamespace(p.Namespace); err != nil {
+			return &ValidationError{Field: field + ".namespace", Message: err.Error()}
+		}
+		if p.Profile != "" {
+			if _, ok := w.Profiles[p.Profile]; !ok {
+				return &ValidationError{Field: field + ".profile", Message: fmt.Sprintf("unknown profile %q", p.Profile)}
+			}
+		}
+	}
+	if w.Sync.SyncInterval != "" {
+		if d, err := time.ParseDuration(w.Sync.SyncInterval); err != nil || d < 0 {
+			return &ValidationError{Field: "workspace.sync.sync_interval", Message: "must be a non-negative duration"}
+		}
+	}
+	if w.Sync.DeltaPageSize < 0 {
+		return &ValidationError{Field: "workspace.sync.delta_page_size", Message: "must be non-negative"}
+	}
+	return nil
+}
+
+// Resolve returns the settings for dir: workspace defaults overridden by the
+// longest matching path entry. A namespace set on a path entry applies only
+// to that path's subtree.
+func (w *Workspace) Resolve(dir string) WorkspaceSettings {
+	settings := WorkspaceSettings{Store: w.Store, Namespace: w.Namespace, ProfileName: w.Profile}
+
+	if abs, err := filepath.Abs(dir); err == nil {
+		if rel, err := filepath.Rel(w.Root, abs); err == nil {
+			best := -1
+			for i, p := range w.Paths {
+				clean := filepath.Clean(p.Path)
+				if rel != clean && !strings.HasPrefix(rel, clean+string(filepath.Separator)) {
+					continue
+				}
+				if best < 0 || len(clean) > len(filepath.Clean(w.Paths[best].Path)) {
+					best = i
+				}
+			}
+			if best >= 0 {
+				p := w.Paths[best]
+				if p.Store != "" {
+					settings.Store = p.Store
+				}
+				if p.Namespace != "" {
+					settings.Namespace = p.Namespace
+				}
+				if p.Profile != "" {
+					settings.ProfileName = p.Profile
+				}
+			}
+		}
+	}
+
+	if profile, ok := w.Profiles[settings.ProfileName]; ok {
+		settings.Profile = &profile
+	}
+	return settings
+}
+
+// ApplyTo fills Config from the manifest settings for dir. Store,
+// Namespace, QueryProfile, EngramURL and ConflictPolicy are only filled when
+// unset, so explicit configuration wins; sync timing settings present in the
+// manifest replace the built-in defaults.
+func (w *Workspace) ApplyTo(cfg *Config, dir string) {
+	settings := w.Resolve(dir)
+	if cfg.Store == "" {
+		cfg.Store = settings.Store
+	}
+	if cfg.Namespace == "" {
+		cfg.Namespace = settings.Namespace
+	}
+	if cfg.QueryProfile == nil {
+		cfg.QueryProfile = settings.Profile
+	}
+	if cfg.EngramURL == "" {
+		cfg.EngramURL = w.Sync.EngramURL
+	}
+	if w.Sync.SyncInterval != "" {
+		cfg.SyncInterval, _ = time.ParseDuration(w.Sync.SyncInterval)
+	}
+	if w.Sync.AutoSync != nil {
+		cfg.AutoSync = *w.Sync.AutoSync
+	}
+	if cfg.ConflictPolicy == "" {
+		cfg.ConflictPolicy = w.Sync.ConflictPolicy
+	}
+	if w.Sync.DeltaPageSize != 0 {
+		cfg.DeltaPageSize = w.Sync.DeltaPageSize
+	}
+}
+
+// NewFromEnvironment creates a client from environment variables (see
+// ConfigFromEnv), filling anything unset from the workspace manifest that
+// encloses the working directory.
+func NewFromEnvironment() (*Client, error) {
+	cfg := ConfigFromEnv()
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("client: %w", err)
+	}
+	ws, err := FindWorkspace(cwd)
+	if err != nil {
+		return nil, fmt.Errorf("client: %w", err)
+	}
+	if ws != nil {
+		ws.ApplyTo(&cfg, cwd)
+	}
+	return New(cfg)
+}
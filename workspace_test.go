@@ -0,0 +1,165 @@
+package recall
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const testManifest = `{
+  "store": "acme/monorepo",
+  "profile": "default",
+  "sync": {"sync_interval": "10m", "delta_page_size": 200},
+  "profiles": {
+    "default": {"k": 2},
+    "testing": {"k": 1, "min_confidence": 0.7, "categories": ["TESTING_STRATEGY"]}
+  },
+  "paths": [
+    {"path": "services", "namespace": "services"},
+    {"path": "services/payments", "store": "acme/payments", "profile": "testing"}
+  ]
+}`
+
+func writeManifest(t *testing.T, dir, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, WorkspaceFileName)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("write manifest: %v", err)
+	}
+	return path
+}
+
+func TestFindWorkspace_ResolvesLongestPath(t *testing.T) {
+	t.Setenv(WorkspaceEnv, "")
+	root := t.TempDir()
+	writeManifest(t, root, testManifest)
+	deep := filepath.Join(root, "services", "payments", "api")
+	if err := os.MkdirAll(deep, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	ws, err := FindWorkspace(deep)
+	if err != nil {
+		t.Fatalf("FindWorkspace: %v", err)
+	}
+	if ws == nil {
+		t.Fatal("FindWorkspace returned nil, want manifest from ancestor")
+	}
+
+	got := ws.Resolve(deep)
+	if got.Store != "acme/payments" || got.ProfileName != "testing" || got.Profile == nil || got.Profile.K != 1 {
+		t.Errorf("Resolve(payments/api) = %+v, want acme/payments with testing profile", got)
+	}
+	// The longest match wins outright; its parent's namespace does not apply.
+	if got.Namespace != "" {
+		t.Errorf("Namespace = %q, want empty", got.Namespace)
+	}
+
+	got = ws.Resolve(filepath.Join(root, "services", "search"))
+	if got.Store != "acme/monorepo" || got.Namespace != "services" || got.ProfileName != "default" {
+		t.Errorf("Resolve(services/search) = %+v, want monorepo store in services namespace", got)
+	}
+
+	// "services-old" must not match the "services" prefix.
+	got = ws.Resolve(filepath.Join(root, "services-old"))
+	if got.Namespace != "" {
+		t.Errorf("Resolve(services-old) namespace = %q, want empty", got.Namespace)
+	}
+}
+
+func TestFindWorkspace_NoneAndOff(t *testing.T) {
+	t.Setenv(WorkspaceEnv, "")
+	if ws, err := FindWorkspace(t.TempDir()); err != nil || ws != nil {
+		t.Errorf("FindWorkspace(empty dir) = %v, %v; want nil, nil", ws, err)
+	}
+
+	root := t.TempDir()
+	writeManifest(t, root, testManifest)
+	t.Setenv(WorkspaceEnv, "off")
+	if ws, err := FindWorkspace(root); err != nil || ws != nil {
+		t.Errorf("FindWorkspace with RECALL_WORKSPACE=off = %v, %v; want nil, nil", ws, err)
+	}
+}
+
+func TestLoadWorkspace_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"workspace.store":                      `{"store": "Bad Store"}`,
+		"workspace.profile":                    `{"profile": "missing"}`,
+		"workspace.paths[0].path":              `{"paths": [{"path": "/abs"}]}`,
+		"workspace.paths[0].profile":           `{"paths": [{"path": "web", "profile": "nope"}]}`,
+		"workspace.profiles.x.categories":      `{"profiles": {"x": {"categories": ["NOPE"]}}}`,
+		"workspace.sync.sync_interval":         `{"sync": {"sync_interval": "soon"}}`,
+		"workspace.profiles.x.k":               `{"profiles": {"x": {"k": -1}}}`,
+		"workspace.paths[0].namespace":         `{"paths": [{"path": "web", "namespace": "Bad_NS"}]}`,
+		"workspace.profiles.x.category_quotas": `{"profiles": {"x": {"category_quotas": {"NOPE": 1}}}}`,
+	}
+	for field, manifest := range tests {
+		path := writeManifest(t, t.TempDir(), manifest)
+		_, err := LoadWorkspace(path)
+		var ve *ValidationError
+		if !errors.As(err, &ve) || ve.Field != field {
+			t.Errorf("LoadWorkspace(%s) err = %v, want ValidationError on %s", manifest, err, field)
+		}
+	}
+}
+
+func TestWorkspace_ApplyTo_ExplicitWins(t *testing.T) {
+	root := t.TempDir()
+	ws, err := LoadWorkspace(writeManifest(t, root, testManifest))
+	if err != nil {
+		t.Fatalf("LoadWorkspace: %v", err)
+	}
+
+	cfg := Config{Store: "explicit"}
+	ws.ApplyTo(&cfg, filepath.Join(root, "services"))
+	if cfg.Store != "explicit" {
+		t.Errorf("Store = %q, want explicit value kept", cfg.Store)
+	}
+	if cfg.Namespace != "services" {
+		t.Errorf("Namespace = %q, want services", cfg.Namespace)
+	}
+	if cfg.SyncInterval != 10*time.Minute || cfg.DeltaPageSize != 200 {
+		t.Errorf("sync settings = %v/%d, want 10m/200", cfg.SyncInterval, cfg.DeltaPageSize)
+	}
+	if cfg.QueryProfile == nil || cfg.QueryProfile.K != 2 {
+		t.Errorf("QueryProfile = %+v, want default profile", cfg.QueryProfile)
+	}
+}
+
+func TestNewFromEnvironment_AppliesWorkspaceProfile(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv(WorkspaceEnv, writeManifest(t, root, `{"profiles": {"p": {"k": 1}}, "profile": "p"}`))
+	t.Setenv("RECALL_DB_PATH", filepath.Join(root, "lore.db"))
+	t.Setenv("ENGRAM_URL", "")
+
+	client, err := NewFromEnvironment()
+	if err != nil {
+		t.Fatalf("NewFromEnvironment: %v", err)
+	}
+	defer client.Close()
+
+	for _, content := range []string{"first", "second", "third"} {
+		if _, err := client.Record(content, CategoryPatternOutcome); err != nil {
+			t.Fatalf("Record: %v", err)
+		}
+	}
+
+	result, err := client.Query(context.Background(), QueryParams{})
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	if len(result.Lore) != 1 {
+		t.Errorf("Query returned %d results, want 1 from profile K", len(result.Lore))
+	}
+
+	result, err = client.Query(context.Background(), QueryParams{K: 3})
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	if len(result.Lore) != 3 {
+		t.Errorf("Query with explicit K returned %d results, want 3", len(result.Lore))
+	}
+}